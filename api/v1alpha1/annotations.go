@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// NhcTimedOutAnnotation is the annotation which NHC adds to remediation CRs when it stops waiting for them, so
+	// that remediators can cancel their efforts. The value is the RFC3339 timestamp of the timeout, followed by the
+	// reason, e.g. "2024-01-02T15:04:05Z;reason=Timeout". With the operator's --plain-timed-out-annotation flag, the
+	// value is the timestamp only.
+	NhcTimedOutAnnotation = "remediation.medik8s.io/nhc-timed-out"
+	// NhcTimedOutStepAnnotation is the annotation which NHC adds to timed out remediation CRs together with the
+	// NhcTimedOutAnnotation. The value is the order of the escalating remediation and the remediation attempt for the
+	// node, e.g. "order=1;attempt=2". The order is omitted for classic remediation, the attempt for CRs without
+	// attempt annotation.
+	NhcTimedOutStepAnnotation = "remediation.medik8s.io/nhc-timed-out-step"
+)
+
+// TimedOutReason is the reason token of the NhcTimedOutAnnotation
+type TimedOutReason string
+
+const (
+	// TimedOutReasonTimeout is used when the timeout of the escalating remediation expired
+	TimedOutReasonTimeout TimedOutReason = "Timeout"
+	// TimedOutReasonFailed is used when the remediator reported the remediation as failed
+	TimedOutReasonFailed TimedOutReason = "Failed"
+	// TimedOutReasonLeaseOverdue is used when the node lease of the remediation expired
+	TimedOutReasonLeaseOverdue TimedOutReason = "LeaseOverdue"
+	// TimedOutReasonMaxRemediationDuration is used when the remediation of the node exceeded the
+	// MaxRemediationDuration
+	TimedOutReasonMaxRemediationDuration TimedOutReason = "MaxRemediationDuration"
+	// TimedOutReasonUnknown is returned for annotations in the plain timestamp form, which has no reason
+	TimedOutReasonUnknown TimedOutReason = "Unknown"
+)
+
+const (
+	annotationFieldSeparator = ";"
+	reasonField              = "reason="
+	orderField               = "order="
+	attemptField             = "attempt="
+)
+
+// FormatNhcTimedOutAnnotation returns the value of the NhcTimedOutAnnotation for the given time and reason
+func FormatNhcTimedOutAnnotation(timedOut time.Time, reason TimedOutReason) string {
+	return timedOut.UTC().Format(time.RFC3339) + annotationFieldSeparator + reasonField + string(reason)
+}
+
+// ParseNhcTimedOutAnnotation returns the time and reason of the given value of the NhcTimedOutAnnotation. Values in
+// the plain timestamp form have the TimedOutReasonUnknown reason.
+func ParseNhcTimedOutAnnotation(value string) (time.Time, TimedOutReason, error) {
+	fields := strings.Split(value, annotationFieldSeparator)
+	timedOut, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid timestamp in %q: %v", value, err)
+	}
+	reason := TimedOutReasonUnknown
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, reasonField) {
+			reason = TimedOutReason(strings.TrimPrefix(field, reasonField))
+		}
+	}
+	return timedOut, reason, nil
+}
+
+// FormatNhcTimedOutStepAnnotation returns the value of the NhcTimedOutStepAnnotation for the given order of the
+// escalating remediation and remediation attempt. Nil values are omitted.
+func FormatNhcTimedOutStepAnnotation(order, attempt *int) string {
+	var fields []string
+	if order != nil {
+		fields = append(fields, orderField+strconv.Itoa(*order))
+	}
+	if attempt != nil {
+		fields = append(fields, attemptField+strconv.Itoa(*attempt))
+	}
+	return strings.Join(fields, annotationFieldSeparator)
+}
+
+// ParseNhcTimedOutStepAnnotation returns the order of the escalating remediation and the remediation attempt of the
+// given value of the NhcTimedOutStepAnnotation. Missing values are nil.
+func ParseNhcTimedOutStepAnnotation(value string) (order, attempt *int, err error) {
+	for _, field := range strings.Split(value, annotationFieldSeparator) {
+		var target **int
+		switch {
+		case strings.HasPrefix(field, orderField):
+			target, field = &order, strings.TrimPrefix(field, orderField)
+		case strings.HasPrefix(field, attemptField):
+			target, field = &attempt, strings.TrimPrefix(field, attemptField)
+		default:
+			continue
+		}
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid number in %q: %v", value, err)
+		}
+		*target = &number
+	}
+	return order, attempt, nil
+}
@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("Timed out annotations", func() {
+	timedOut := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	It("should parse the structured timed out annotation", func() {
+		value := FormatNhcTimedOutAnnotation(timedOut, TimedOutReasonLeaseOverdue)
+		Expect(value).To(Equal("2024-01-02T15:04:05Z;reason=LeaseOverdue"))
+		parsed, reason, err := ParseNhcTimedOutAnnotation(value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(BeTemporally("==", timedOut))
+		Expect(reason).To(Equal(TimedOutReasonLeaseOverdue))
+	})
+
+	It("should parse the plain timed out annotation", func() {
+		parsed, reason, err := ParseNhcTimedOutAnnotation("2024-01-02T15:04:05Z")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(BeTemporally("==", timedOut))
+		Expect(reason).To(Equal(TimedOutReasonUnknown))
+	})
+
+	It("should reject an invalid timed out annotation", func() {
+		_, _, err := ParseNhcTimedOutAnnotation("yesterday;reason=Timeout")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should parse the timed out step annotation", func() {
+		value := FormatNhcTimedOutStepAnnotation(pointer.Int(5), pointer.Int(2))
+		Expect(value).To(Equal("order=5;attempt=2"))
+		order, attempt, err := ParseNhcTimedOutStepAnnotation(value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(order).To(Equal(pointer.Int(5)))
+		Expect(attempt).To(Equal(pointer.Int(2)))
+
+		By("omitting the order of classic remediation")
+		value = FormatNhcTimedOutStepAnnotation(nil, pointer.Int(1))
+		Expect(value).To(Equal("attempt=1"))
+		order, attempt, err = ParseNhcTimedOutStepAnnotation(value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(order).To(BeNil())
+		Expect(attempt).To(Equal(pointer.Int(1)))
+
+		By("rejecting invalid numbers")
+		_, _, err = ParseNhcTimedOutStepAnnotation("order=first")
+		Expect(err).To(HaveOccurred())
+	})
+})
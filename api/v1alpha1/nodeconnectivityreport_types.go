@@ -0,0 +1,81 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeConnectivityReportStatus is the result of the latest connectivity check of a node
+type NodeConnectivityReportStatus struct {
+	// Reachable is false when the node failed the connectivity check.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reachable bool `json:"reachable"`
+
+	// LastTransitionTime is the time at which Reachable changed the last time.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// LastProbeTime is the time of the latest connectivity check. Reports which weren't updated for more than
+	// 5 minutes are considered stale and are ignored.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastProbeTime metav1.Time `json:"lastProbeTime"`
+
+	// Reporter identifies the component which performed the connectivity check.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reporter string `json:"reporter,omitempty"`
+
+	// Message is a human readable explanation of the connectivity check result.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=nodeconnectivityreports,scope=Cluster,shortName=ncr
+//+kubebuilder:subresource:status
+
+// NodeConnectivityReport is the Schema for the nodeconnectivityreports API. It reports the result of synthetic
+// connectivity checks of the node with the same name, e.g. by a DaemonSet probing its peers. NodeHealthChecks with
+// a ConnectivityCheck consider nodes, which are reported as unreachable, as unhealthy.
+//
+// +operator-sdk:csv:customresourcedefinitions:resources={{"NodeConnectivityReport","v1alpha1","nodeconnectivityreports"}}
+type NodeConnectivityReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status NodeConnectivityReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeConnectivityReportList contains a list of NodeConnectivityReport
+type NodeConnectivityReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeConnectivityReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeConnectivityReport{}, &NodeConnectivityReportList{})
+}
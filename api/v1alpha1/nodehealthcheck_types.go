@@ -17,8 +17,15 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"math"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -32,8 +39,126 @@ const (
 	ConditionReasonDisabledTemplateNotFound = "RemediationTemplateNotFound"
 	// ConditionReasonDisabledTemplateInvalid is the reason for type Disabled when the template is invalid
 	ConditionReasonDisabledTemplateInvalid = "RemediationTemplateInvalid"
+	// ConditionReasonDisabledRemoteCluster is the reason for type Disabled when the remote cluster can't be used
+	ConditionReasonDisabledRemoteCluster = "RemoteClusterUnavailable"
+	// ConditionReasonDisabledRBAC is the reason for type Disabled when the RemediationServiceAccount isn't allowed to
+	// manage remediation CRs
+	ConditionReasonDisabledRBAC = "RemediationForbidden"
+	// DeferralReasonInsufficientSurgeCapacity is the reason of a remediation deferral by the SurgeGate
+	DeferralReasonInsufficientSurgeCapacity = "InsufficientSurgeCapacity"
+	// DeferralReasonExternallyRemediated is the reason of a remediation deferral, when the node has the
+	// out-of-service taint applied by someone else than NHC
+	DeferralReasonExternallyRemediated = "ExternallyRemediated"
+	// DeferralReasonEscalationCooldown is the reason of a remediation deferral, while the EscalationDelay after the
+	// timeout of the previous escalating remediation didn't expire yet
+	DeferralReasonEscalationCooldown = "EscalationCooldown"
+	// DeferralReasonTopologySerialization is the reason of a remediation deferral, while another node of the same
+	// failure domain, see SerializationTopologyKey, is remediated
+	DeferralReasonTopologySerialization = "TopologySerialization"
+	// DeferralReasonStorageNotSafe is the reason of a remediation deferral, while PersistentVolumes of the node are
+	// not safe for remediation, see DeferOnUnsafeStorage
+	DeferralReasonStorageNotSafe = "StorageNotSafe"
+	// DeferralReasonRemediationThrottled is the reason of a remediation deferral, while a limit of the
+	// RemediationThrottle is reached
+	DeferralReasonRemediationThrottled = "RemediationThrottled"
+	// DeferralReasonPDBViolation is the reason of a remediation deferral, while removing the pods of the node would
+	// violate a PodDisruptionBudget
+	DeferralReasonPDBViolation = "PDBViolation"
+	// DeferralReasonMaxUnhealthyReached is the reason of a remediation deferral, while MaxUnhealthy nodes are
+	// remediated already
+	DeferralReasonMaxUnhealthyReached = "MaxUnhealthyReached"
+	// DeferralReasonDryRun is the reason of a remediation deferral, while the NodeHealthCheck is in dry run mode, see
+	// DryRun
+	DeferralReasonDryRun = "DryRun"
+	// DeferralReasonRemediationCooldown is the reason of a remediation deferral, while the previous remediation of
+	// the node finished within the RemediationCooldown
+	DeferralReasonRemediationCooldown = "RemediationCooldown"
+	// DeferralReasonOutsideRemediationWindow is the reason of a remediation deferral, while the current time is
+	// outside of all RemediationWindows
+	DeferralReasonOutsideRemediationWindow = "OutsideRemediationWindow"
+	// ConditionTypeRemediatorDegraded is the condition type used while the circuit of the RemediatorCircuitBreaker
+	// is open for a remediation kind
+	ConditionTypeRemediatorDegraded = "RemediatorDegraded"
+	// ConditionReasonRemediatorCircuitOpen is the reason for type RemediatorDegraded and status True
+	ConditionReasonRemediatorCircuitOpen = "RemediatorCircuitOpen"
+	// ConditionReasonNoRemediatorDegraded is the reason for type RemediatorDegraded and status False
+	ConditionReasonNoRemediatorDegraded = "NoRemediatorDegraded"
+	// ConditionTypeRemediatorUnavailable is the condition type used while the Deployment of a remediator referenced
+	// in the RemediatorHealthCheck has no ready replicas
+	ConditionTypeRemediatorUnavailable = "RemediatorUnavailable"
+	// ConditionReasonRemediatorNotReady is the reason for type RemediatorUnavailable and status True
+	ConditionReasonRemediatorNotReady = "RemediatorNotReady"
+	// ConditionReasonRemediatorsReady is the reason for type RemediatorUnavailable and status False
+	ConditionReasonRemediatorsReady = "RemediatorsReady"
+	// ConditionTypeAlertSilencingFailed is the condition type used when NHC failed to create, extend or expire the
+	// Alertmanager silences of the AlertSilencing
+	ConditionTypeAlertSilencingFailed = "AlertSilencingFailed"
+	// ConditionReasonAlertmanagerRequestFailed is the reason for type AlertSilencingFailed and status True
+	ConditionReasonAlertmanagerRequestFailed = "AlertmanagerRequestFailed"
+	// ConditionReasonAlertSilencesSynced is the reason for type AlertSilencingFailed and status False
+	ConditionReasonAlertSilencesSynced = "AlertSilencesSynced"
 	// ConditionReasonEnabled is the condition reason for type Disabled and status False
 	ConditionReasonEnabled = "NodeHealthCheckEnabled"
+	// ConditionTypeRemediationExhausted is the condition type used when remediation of nodes was given up,
+	// because it took longer than the MaxRemediationDuration
+	ConditionTypeRemediationExhausted = "RemediationExhausted"
+	// ConditionReasonMaxRemediationDurationExceeded is the reason for type RemediationExhausted and status True
+	ConditionReasonMaxRemediationDurationExceeded = "MaxRemediationDurationExceeded"
+	// ConditionReasonNoRemediationExhausted is the reason for type RemediationExhausted and status False
+	ConditionReasonNoRemediationExhausted = "NoRemediationExhausted"
+	// ConditionTypeWaitingForCRD is the condition type used when the CRD of a remediation template isn't established
+	// yet, and NHC waits for it before disabling itself
+	ConditionTypeWaitingForCRD = "WaitingForCRD"
+	// ConditionReasonCRDNotEstablished is the reason for type WaitingForCRD and status True
+	ConditionReasonCRDNotEstablished = "RemediationCRDNotEstablished"
+	// ConditionReasonCRDEstablished is the reason for type WaitingForCRD and status False
+	ConditionReasonCRDEstablished = "RemediationCRDEstablished"
+	// ConditionTypeCleanupFailed is the condition type used when NHC failed to delete remediation CRs
+	ConditionTypeCleanupFailed = "CleanupFailed"
+	// ConditionReasonRemediationCRDeletionFailed is the reason for type CleanupFailed and status True
+	ConditionReasonRemediationCRDeletionFailed = "RemediationCRDeletionFailed"
+	// ConditionReasonNoCleanupFailed is the reason for type CleanupFailed and status False
+	ConditionReasonNoCleanupFailed = "NoCleanupFailed"
+	// ConditionTypePoolTooSmall is the condition type used when fewer nodes than MinSelectedNodes are selected, and
+	// remediation is withheld
+	ConditionTypePoolTooSmall = "PoolTooSmall"
+	// ConditionReasonSelectedNodesBelowMinimum is the reason for type PoolTooSmall and status True
+	ConditionReasonSelectedNodesBelowMinimum = "SelectedNodesBelowMinimum"
+	// ConditionReasonEnoughSelectedNodes is the reason for type PoolTooSmall and status False
+	ConditionReasonEnoughSelectedNodes = "EnoughSelectedNodes"
+	// ConditionTypeProgressing is the condition type used while NHC is actively remediating nodes, including
+	// draining nodes before remediation, verifying remediated nodes, and deleting remediation CRs
+	ConditionTypeProgressing = "Progressing"
+	// ConditionReasonRemediating is the reason for type Progressing and status True, when remediating nodes is the
+	// dominant activity
+	ConditionReasonRemediating = "Remediating"
+	// ConditionReasonDraining is the reason for type Progressing and status True, when draining nodes is the
+	// dominant activity
+	ConditionReasonDraining = "Draining"
+	// ConditionReasonVerifying is the reason for type Progressing and status True, when verifying remediated nodes is
+	// the dominant activity
+	ConditionReasonVerifying = "Verifying"
+	// ConditionReasonDeleting is the reason for type Progressing and status True, when deleting remediation CRs is
+	// the dominant activity
+	ConditionReasonDeleting = "Deleting"
+	// ConditionReasonIdle is the reason for type Progressing and status False
+	ConditionReasonIdle = "Idle"
+	// NodeConditionTypeRemediatedByNHC is the node condition type used for recording a remediation on the node,
+	// see RecordRemediationOnNode
+	NodeConditionTypeRemediatedByNHC corev1.NodeConditionType = "RemediatedByNHC"
+	// NodeConditionReasonRemediationSucceeded is the reason for node condition type RemediatedByNHC and status True
+	NodeConditionReasonRemediationSucceeded = "RemediationSucceeded"
+	// NodeConditionReasonRemediationStarted is the reason for node condition type RemediatedByNHC and status False,
+	// when the next remediation of the node started
+	NodeConditionReasonRemediationStarted = "RemediationStarted"
+	// NodeConditionReasonRetentionExpired is the reason for node condition type RemediatedByNHC and status False,
+	// when the RemediationRecordRetention expired
+	NodeConditionReasonRetentionExpired = "RetentionExpired"
+	// IgnoredReasonPreexistingCondition is the reason of an ignored unhealthy node, which was unhealthy already
+	// when the NHC was created
+	IgnoredReasonPreexistingCondition = "PreexistingCondition"
+	// IgnoredReasonNodeTooYoung is the reason of an ignored unhealthy node, which is younger than the MinNodeAge
+	IgnoredReasonNodeTooYoung = "NodeTooYoung"
 )
 
 // NHCPhase is the string used for NHC.Status.Phase
@@ -46,7 +171,15 @@ const (
 	// PhasePaused is used when not disabled, but PauseRequests is set
 	PhasePaused NHCPhase = "Paused"
 
-	// PhaseRemediating is used when not disabled and not paused, and InFlightRemediations is set
+	// PhaseDryRun is used when not disabled and not paused, but DryRun is set
+	PhaseDryRun NHCPhase = "DryRun"
+
+	// PhaseWaitingForWindow is used when not disabled, not paused and not in dry run mode, but the current time is
+	// outside of all RemediationWindows
+	PhaseWaitingForWindow NHCPhase = "WaitingForWindow"
+
+	// PhaseRemediating is used when not disabled, not paused, not in dry run mode and not waiting for a remediation
+	// window, and InFlightRemediations is set
 	PhaseRemediating NHCPhase = "Remediating"
 
 	// PhaseEnabled is used in all other cases
@@ -67,233 +200,2822 @@ type NodeHealthCheckSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	Selector metav1.LabelSelector `json:"selector"`
 
+	// NodeName restricts the NodeHealthCheck to the node with the given name, e.g. for testing a remediator on a
+	// single canary node. When set, it takes precedence over the Selector, which is not used for matching nodes
+	// anymore, but still validated if set.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeName string `json:"nodeName,omitempty"`
+
+	// TargetArchitectures restricts the selected nodes to nodes whose kubernetes.io/arch label has one of the given
+	// values, e.g. amd64. It applies on top of the Selector respectively NodeName. Empty selects nodes of all
+	// architectures.
+	//
+	//+optional
+	//+listType=set
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TargetArchitectures []string `json:"targetArchitectures,omitempty"`
+
+	// TargetOperatingSystems restricts the selected nodes to nodes whose kubernetes.io/os label has one of the given
+	// values, e.g. linux. It applies on top of the Selector respectively NodeName. Empty selects nodes of all
+	// operating systems.
+	//
+	//+optional
+	//+listType=set
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TargetOperatingSystems []string `json:"targetOperatingSystems,omitempty"`
+
 	// UnhealthyConditions contains a list of the conditions that determine
 	// whether a node is considered unhealthy.  The conditions are combined in a
 	// logical OR, i.e. if any of the conditions is met, the node is unhealthy.
+	// Defaults to the Ready condition with status False or Unknown for 300s.
 	//
 	//+optional
 	//+listType=map
 	//+listMapKey=type
 	//+listMapKey=status
-	//+kubebuilder:default:={{type:Ready,status:False,duration:"300s"},{type:Ready,status:Unknown,duration:"300s"}}
+	//+listMapKey=taintKey
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
 
+	// UnhealthyCapacity configures that nodes, whose capacity of the given resource is below the minimum quantity,
+	// or absent, for the given duration, are considered unhealthy, in addition to nodes matching the
+	// UnhealthyConditions. This allows to remediate e.g. nodes which lost their GPUs, but are still Ready.
+	//
+	//+optional
+	//+listType=map
+	//+listMapKey=resourceName
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyCapacity []UnhealthyCapacity `json:"unhealthyCapacity,omitempty"`
+
+	// UnhealthyTaints configures that nodes, which have a taint with the given key and effect for the given duration,
+	// are considered unhealthy, in addition to nodes matching the UnhealthyConditions. This allows to use taints,
+	// e.g. node.kubernetes.io/unreachable, as the authoritative signal of node problems, independent of node
+	// conditions. For combining a taint with a node condition, use the TaintKey of the UnhealthyConditions instead.
+	//
+	//+optional
+	//+listType=map
+	//+listMapKey=key
+	//+listMapKey=effect
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyTaints []UnhealthyTaint `json:"unhealthyTaints,omitempty"`
+
+	// UnhealthyAnnotations configures that nodes, which have an annotation with the given key and value for the given
+	// duration, are considered unhealthy, in addition to nodes matching the UnhealthyConditions. This allows to use
+	// annotations written by custom node agents, e.g. node-problem/ssd-failing=true, as signal of node problems.
+	//
+	//+optional
+	//+listType=map
+	//+listMapKey=key
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyAnnotations []AnnotationMatch `json:"unhealthyAnnotations,omitempty"`
+
+	// UnhealthyUtilization configures that nodes, whose utilization of the given resource is above the threshold for
+	// the given duration, are considered unhealthy, in addition to nodes matching the UnhealthyConditions. The
+	// utilization is read from the metrics.k8s.io API, e.g. served by metrics-server, and allows threshold based
+	// remediation instead of the binary MemoryPressure condition. Nodes without metrics are not considered unhealthy.
+	// Requires the operator to run with the --enable-utilization-checks flag, it is ignored otherwise.
+	//
+	//+optional
+	//+listType=map
+	//+listMapKey=resourceName
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyUtilization []UnhealthyUtilization `json:"unhealthyUtilization,omitempty"`
+
+	// ConnectivityCheck configures that nodes, which are reported as unreachable by their NodeConnectivityReport,
+	// are considered unhealthy, in addition to nodes matching the UnhealthyConditions.
+	// This catches network partitions which don't flip the node's Ready condition.
+	// Requires the operator to run with the --enable-connectivity-reports flag, it is ignored otherwise.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ConnectivityCheck *ConnectivityCheck `json:"connectivityCheck,omitempty"`
+
+	// HeartbeatSource configures that nodes, whose heartbeat object of the given kind is stale, are considered
+	// unhealthy, in addition to nodes matching the UnhealthyConditions. Heartbeat objects are posted by custom node
+	// agents, and allow to remediate nodes before their Ready condition changes.
+	// Requires the operator to run with the --enable-heartbeat-sources flag, it is ignored otherwise.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	HeartbeatSource *HeartbeatSource `json:"heartbeatSource,omitempty"`
+
+	// UnhealthyQuorum configures that nodes are only considered unhealthy, when at least the given number of
+	// configured signal categories vote them as unhealthy. The signal categories are Conditions (UnhealthyConditions
+	// without TaintKey), Taints (UnhealthyConditions with TaintKey and UnhealthyTaints), Annotations
+	// (UnhealthyAnnotations), Capacity (UnhealthyCapacity), Utilization (UnhealthyUtilization), Connectivity
+	// (ConnectivityCheck) and Heartbeat (HeartbeatSource). The votes are tracked in the UnhealthySignalVotes status
+	// field. Must not exceed the number of configured signal categories. Defaults to 0, which means that a single
+	// signal is sufficient.
+	//
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyQuorum int `json:"unhealthyQuorum,omitempty"`
+
+	// IgnorePreexistingConditions configures that nodes, which already matched the unhealthy conditions or were
+	// unreachable when the NHC was created, are not remediated. They are tracked in the IgnoredUnhealthyNodes status
+	// field instead. They are remediated when they get healthy and unhealthy again, or when they are annotated with
+	// "remediation.medik8s.io/remediate-preexisting-condition". Conditions without transition time are
+	// considered as preexisting. Ongoing remediations are not affected. Defaults to false.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	IgnorePreexistingConditions bool `json:"ignorePreexistingConditions,omitempty"`
+
 	// Remediation is allowed if at least "MinHealthy" nodes selected by "selector" are healthy.
 	// Expects either a positive integer value or a percentage value.
 	// Percentage values must be positive whole numbers and are capped at 100%.
 	// 100% is valid and will block all remediation.
+	// Defaults to 51%.
 	//
-	//+kubebuilder:default="51%"
 	//+kubebuilder:validation:XIntOrString
 	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	MinHealthy *intstr.IntOrString `json:"minHealthy,omitempty"`
 
+	// MaxUnhealthy is the maximum number of nodes selected by "selector" which are remediated at the same time.
+	// Remediation of further unhealthy nodes is deferred until a remediated node is healthy again. Nodes being
+	// drained for remediation are counted as remediated. This complements MinHealthy, which withholds all remediation
+	// when too few nodes are healthy.
+	// Expects either a non-negative integer value or a percentage value. Percentages are rounded down, and are capped
+	// at 100%. Unlimited when not set.
+	//
+	//+optional
+	//+kubebuilder:validation:XIntOrString
+	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// MinSelectedNodes is the minimum number of nodes which need to be selected by "selector". When fewer nodes are
+	// selected, all remediation is withheld and the PoolTooSmall condition is set. This protects small pools, for
+	// which percentage based MinHealthy values don't work well. It is checked before MinHealthy.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinSelectedNodes int `json:"minSelectedNodes,omitempty"`
+
+	// MinNodeAge is the minimum age of nodes before they are eligible for remediation. Younger unhealthy nodes, e.g.
+	// nodes whose Ready condition flaps during bootstrap, are never remediated, and not counted as unhealthy for
+	// MinHealthy. They are tracked in the IgnoredUnhealthyNodes status with the NodeTooYoung reason, until they are
+	// old enough.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinNodeAge *metav1.Duration `json:"minNodeAge,omitempty"`
+
+	// RemediationCooldown is the minimum time between the end of a remediation of a node, i.e. when the node got
+	// healthy again and its remediation CRs were deleted, and the start of the next remediation of the same node.
+	// This prevents remediation loops of flapping nodes. Nodes which get unhealthy again within the cooldown are
+	// deferred with the RemediationCooldown reason, and are remediated when they are still unhealthy after it.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationCooldown *metav1.Duration `json:"remediationCooldown,omitempty"`
+
+	// RequirePositiveHealth configures that only nodes with a Ready condition with status True are counted as healthy
+	// for MinHealthy. By default, all nodes which don't match any unhealthy condition are counted as healthy, including
+	// nodes with an unknown or missing Ready condition, which don't match an unhealthy condition yet.
+	// Remediation itself isn't affected, nodes without positive health are not remediated because of it.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RequirePositiveHealth bool `json:"requirePositiveHealth,omitempty"`
+
 	// RemediationTemplate is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
 	// If a node needs remediation the controller will create an object from this template
 	// and then it should be picked up by a remediation provider.
 	//
-	// Mutually exclusive with EscalatingRemediations
+	// Mutually exclusive with RemediationTemplates, EscalatingRemediations and InlineRemediationTemplate
 	//
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	RemediationTemplate *corev1.ObjectReference `json:"remediationTemplate,omitempty"`
 
+	// RemediationTemplates are references to several remediation templates, which are used all at once.
+	//
+	// If a node needs remediation the controller will create an object from each template at the same time,
+	// e.g. a diagnostics CR and a fencing CR. The node is remediated as long as any of these objects exists, and all
+	// of them are deleted when the node is healthy again. The templates must be unique.
+	//
+	// Mutually exclusive with RemediationTemplate, EscalatingRemediations and InlineRemediationTemplate
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationTemplates []corev1.ObjectReference `json:"remediationTemplates,omitempty"`
+
+	// InlineRemediationTemplate defines the remediation CR which will be created for unhealthy nodes inline,
+	// as an alternative to a reference to a remediation template.
+	//
+	// Mutually exclusive with RemediationTemplate, RemediationTemplates and EscalatingRemediations
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	InlineRemediationTemplate *InlineRemediationTemplate `json:"inlineRemediationTemplate,omitempty"`
+
 	// EscalatingRemediations contain a list of ordered remediation templates with a timeout.
 	// The remediation templates will be used one after another, until the unhealthy node
 	// gets healthy within the timeout of the currently processed remediation. The order of
 	// remediation is defined by the "order" field of each "escalatingRemediation".
 	//
-	// Mutually exclusive with RemediationTemplate
+	// Mutually exclusive with RemediationTemplate, RemediationTemplates and InlineRemediationTemplate
 	//
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	EscalatingRemediations []EscalatingRemediation `json:"escalatingRemediations,omitempty"`
 
-	// PauseRequests will prevent any new remediation to start, while in-flight remediations
-	// keep running. Each entry is free form, and ideally represents the requested party reason
-	// for this pausing - i.e:
-	//     "imaginary-cluster-upgrade-manager-operator"
+	// EscalationMemory configures escalating remediations to not start with the first remediation again, when a node
+	// gets unhealthy again shortly after its last remediation. Instead remediation starts with the remediation
+	// following the last one which was used for that node.
+	// Only applicable for escalating remediations.
+	//
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	PauseRequests []string `json:"pauseRequests,omitempty"`
-}
+	EscalationMemory *EscalationMemory `json:"escalationMemory,omitempty"`
 
-// UnhealthyCondition represents a Node condition type and value with a
-// specified duration. When the named condition has been in the given
-// status for at least the duration value a node is considered unhealthy.
-type UnhealthyCondition struct {
-	// The condition type in the node's status to watch for.
+	// EscalationTimeoutStrategy defines how the timeouts of escalating remediations are determined.
+	// Defaults to using the configured timeout of each escalating remediation.
+	// Only applicable for escalating remediations.
 	//
-	//+kubebuilder:validation:Type=string
-	//+kubebuilder:validation:MinLength=1
+	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	Type corev1.NodeConditionType `json:"type"`
+	EscalationTimeoutStrategy *EscalationTimeoutStrategy `json:"escalationTimeoutStrategy,omitempty"`
 
-	// The condition status in the node's status to watch for.
-	// Typically False, True or Unknown.
+	// EscalationHandshake configures NHC to wait for the remediator to acknowledge a timed out remediation,
+	// before the next escalating remediation is started. The remediator acknowledges by adding the
+	// "remediation.medik8s.io/nhc-timed-out-acknowledged" annotation to its remediation CR.
+	// Only applicable for escalating remediations.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	EscalationHandshake bool `json:"escalationHandshake,omitempty"`
+
+	// EscalationHandshakeGracePeriod is the maximum time to wait for the acknowledgment of a timed out remediation,
+	// when EscalationHandshake is enabled. The next escalating remediation is started when it expires.
+	// Defaults to 5m.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	//+kubebuilder:validation:Type=string
-	//+kubebuilder:validation:MinLength=1
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	Status corev1.ConditionStatus `json:"status"`
+	EscalationHandshakeGracePeriod *metav1.Duration `json:"escalationHandshakeGracePeriod,omitempty"`
 
-	// Duration of the condition specified when a node is considered unhealthy.
+	// EscalationDelay is the minimum time between the timeout of an escalating remediation and the start of the
+	// next one. While it didn't expire, the node's remediation is deferred with reason EscalationCooldown.
+	// Defaults to 0, which starts the next escalating remediation right away, and must not exceed 1h.
+	// Only applicable for escalating remediations.
 	//
 	// Expects a string of decimal numbers each with optional
 	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	//
+	//+optional
 	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	//+kubebuilder:validation:Type=string
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	Duration metav1.Duration `json:"duration"`
-}
+	EscalationDelay *metav1.Duration `json:"escalationDelay,omitempty"`
 
-// EscalatingRemediation defines a remediation template with order and timeout
-type EscalatingRemediation struct {
-	// RemediationTemplate is a reference to a remediation template
-	// provided by a remediation provider.
+	// DeleteTimedOutRemediations configures NHC to delete timed out remediation CRs before the next escalating
+	// remediation is started, for stopping remediators which keep running after their timeout. By default timed out
+	// remediation CRs are only annotated with "remediation.medik8s.io/nhc-timed-out", and are deleted when the node
+	// is healthy again.
+	// Only applicable for escalating remediations.
 	//
-	// If a node needs remediation the controller will create an object from this template
-	// and then it should be picked up by a remediation provider.
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeleteTimedOutRemediations bool `json:"deleteTimedOutRemediations,omitempty"`
+
+	// ExternalCRDeletionPolicy defines how NHC handles the remediation CR of an ongoing remediation, which was deleted
+	// by someone else than NHC while the node is still unhealthy, e.g. by the remediator or by an admin.
+	// Recreate recreates the CR and continues the same remediation attempt, including its timeout.
+	// TreatAsCompleted marks the remediation as Succeeded, and doesn't start further remediations until the node was
+	// healthy again.
+	// TreatAsFailed marks the remediation as failed, and continues with the next escalating remediation, if any.
+	// Defaults to Recreate.
 	//
+	//+kubebuilder:validation:Enum=Recreate;TreatAsCompleted;TreatAsFailed
+	//+kubebuilder:default=Recreate
+	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	RemediationTemplate corev1.ObjectReference `json:"remediationTemplate"`
+	ExternalCRDeletionPolicy ExternalCRDeletionPolicy `json:"externalCRDeletionPolicy,omitempty"`
 
-	// Order defines the order for this remediation.
-	// Remediations with lower order will be used before remediations with higher order.
-	// Remediations must not have the same order.
+	// RemediationNamespaceStrategy defines in which namespace remediation CRs are created.
+	// TemplateNamespace creates them in the namespace of the remediation template.
+	// MachineNamespace creates them in the namespace of the node's Machine, and falls back to the namespace of the
+	// remediation template for nodes without machine annotation. The template itself is still read from the
+	// namespace of its reference.
+	// Defaults to TemplateNamespace.
 	//
+	//+kubebuilder:validation:Enum=TemplateNamespace;MachineNamespace
+	//+kubebuilder:default=TemplateNamespace
+	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	Order int `json:"order"`
+	RemediationNamespaceStrategy RemediationNamespaceStrategy `json:"remediationNamespaceStrategy,omitempty"`
 
-	// Timeout defines how long NHC will wait for the node getting healthy
-	// before the next remediation (if any) will be used. When the last remediation times out,
-	// the overall remediation is considered as failed.
-	// As a safeguard for preventing parallel remediations, a minimum of 60s is enforced.
+	// RecordRemediationOnNode configures NHC to set the "RemediatedByNHC" condition on the node's status, when its
+	// remediation succeeded. The condition is set to False when the RemediationRecordRetention expires, or when the
+	// next remediation of the node starts.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RecordRemediationOnNode bool `json:"recordRemediationOnNode,omitempty"`
+
+	// RemediationRecordRetention is the time for which the "RemediatedByNHC" node condition stays True, when
+	// RecordRemediationOnNode is enabled.
+	// Defaults to 1h.
 	//
 	// Expects a string of decimal numbers each with optional
 	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	//
+	//+optional
 	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	//+kubebuilder:validation:Type=string
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
-	Timeout metav1.Duration `json:"timeout"`
-}
+	RemediationRecordRetention *metav1.Duration `json:"remediationRecordRetention,omitempty"`
 
-// NodeHealthCheckStatus defines the observed state of NodeHealthCheck
-type NodeHealthCheckStatus struct {
-	// ObservedNodes specified the number of nodes observed by using the NHC spec.selector
+	// HistoryMaxEntries is the maximum number of finished remediations, which are kept in the RemediationHistory
+	// status. The oldest records are removed first. Defaults to 8.
 	//
+	//+kubebuilder:default=8
+	//+kubebuilder:validation:Minimum=1
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	ObservedNodes *int `json:"observedNodes,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	HistoryMaxEntries int `json:"historyMaxEntries,omitempty"`
 
-	// HealthyNodes specified the number of healthy nodes observed
+	// PauseRequests will prevent any new remediation to start, while in-flight remediations
+	// keep running. Each entry is free form, and ideally represents the requested party reason
+	// for this pausing - i.e:
+	//     "imaginary-cluster-upgrade-manager-operator"
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PauseRequests []string `json:"pauseRequests,omitempty"`
+
+	// RemediationWindows are recurring time windows, during which new remediations are started. Outside of all
+	// windows, unhealthy nodes are detected and tracked in the status as usual, but their remediation is deferred with
+	// the OutsideRemediationWindow reason until the next window opens, and the phase is WaitingForWindow. Ongoing
+	// remediations keep running. By default, remediation is started at any time.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	HealthyNodes *int `json:"healthyNodes,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationWindows []TimeWindow `json:"remediationWindows,omitempty"`
 
-	// UnhealthyNodes tracks currently unhealthy nodes and their remediations.
+	// PollingInterval configures that the NodeHealthCheck is re-evaluated at least this often, also when no node
+	// events arrive, e.g. because they were missed in large clusters. It also caps the requeue interval while a cluster
+	// upgrade is in progress. By default, the NodeHealthCheck is only re-evaluated on events, or when a timeout
+	// expires. Must be positive.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	//
-	//+listType=map
-	//+listMapKey=name
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	UnhealthyNodes []*UnhealthyNode `json:"unhealthyNodes,omitempty"`
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PollingInterval *metav1.Duration `json:"pollingInterval,omitempty"`
 
-	// InFlightRemediations records the timestamp when remediation triggered per node.
-	// Deprecated in favour of UnhealthyNodes.
+	// DryRun configures that unhealthy nodes are detected and tracked in the status as usual, but no remediation CRs
+	// are created. Nodes which would be remediated are deferred with the DryRun reason, and are listed in the status
+	// reason, together with the reason why they are unhealthy. This allows validating the selector, the unhealthy
+	// conditions and the thresholds against the live cluster without any risk. Remediation CRs which exist already
+	// aren't deleted, but ongoing remediations aren't escalated. DryRun can't be disabled while remediations are
+	// ongoing.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	InFlightRemediations map[string]metav1.Time `json:"inFlightRemediations,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DryRun bool `json:"dryRun,omitempty"`
 
-	// Represents the observations of a NodeHealthCheck's current state.
-	// Known .status.conditions.type are: "Disabled"
+	// PriorityLabel is the key of a node label, whose integer value is the remediation priority of the node.
+	// When not all unhealthy nodes can be remediated at the same time, e.g. because of the cluster wide limit of
+	// simultaneous remediations, nodes with higher priority are remediated first. Nodes without the label, or with
+	// a non integer value, have priority 0. Ongoing remediations are never interrupted for nodes with higher priority.
+	// Nodes with the same priority are remediated in the order in which they became eligible for remediation.
 	//
-	//+listType=map
-	//+listMapKey=type
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
-	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PriorityLabel string `json:"priorityLabel,omitempty"`
 
-	// Phase represents the current phase of this Config.
-	// Known phases are Disabled, Paused, Remediating and Enabled, based on:\n
-	// - the status of the Disabled condition\n
-	// - the value of PauseRequests\n
-	// - the value of InFlightRemediations
+	// SerializationTopologyKey is the key of a node label, whose value defines a failure domain of nodes, e.g. the
+	// chassis of blade servers. At most one node per distinct label value is remediated at a time, the remediation
+	// of other unhealthy nodes with the same value is deferred. Within a failure domain, the node which is unhealthy
+	// for the longest time is remediated first. Nodes without the label aren't serialized.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.phase"
-	Phase NHCPhase `json:"phase,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	SerializationTopologyKey string `json:"serializationTopologyKey,omitempty"`
 
-	// Reason explains the current phase in more detail.
+	// RemoteCluster configures NHC to observe and remediate the nodes of a remote cluster, instead of the nodes
+	// of the cluster the operator is running on. Node selection, remediation templates, remediation CRs and node leases
+	// are all handled on the remote cluster.
+	// Requires the operator to run with remote clusters enabled.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.phase:reason"
-	Reason string `json:"reason,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemoteCluster *RemoteCluster `json:"remoteCluster,omitempty"`
 
-	// LastUpdateTime is the last time the status was updated.
+	// RemediationServiceAccount configures NHC to create, update and delete remediation CRs while impersonating the
+	// given ServiceAccount, so that the ServiceAccount's RBAC bounds which remediation CRs the NHC can manage.
+	// Remediation templates and remediation CRs are still read with the operator's permissions. NHC gets disabled
+	// while the ServiceAccount isn't allowed to manage the remediation CRs of all templates.
+	// Can't be used together with RemoteCluster.
 	//
 	//+optional
-	//+kubebuilder:validation:Type=string
-	//+kubebuilder:validation:Format=date-time
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
-}
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationServiceAccount *ServiceAccountReference `json:"remediationServiceAccount,omitempty"`
 
-// UnhealthyNode defines an unhealthy node and its remediations
-type UnhealthyNode struct {
-	// Name is the name of the unhealthy node
+	// SurgeGate defers the remediation of nodes owned by a MachineSet, as long as remediating them would leave
+	// the MachineSet with less ready replicas than configured.
+	// Requires the operator to run with MachineSet surge gating enabled.
 	//
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	Name string `json:"name"`
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	SurgeGate *SurgeGate `json:"surgeGate,omitempty"`
 
-	// Remediations tracks the remediations created for this node
+	// DeferOnUnsafeStorage defers the remediation of nodes with unsafe storage, in order to prevent data loss:
+	// as long as a PersistentVolume bound to a pod on the node is Failed, or a Released or Failed PersistentVolume
+	// is still attached to the node, remediation is deferred.
+	// Requires the operator to run with storage gating enabled.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	Remediations []*Remediation `json:"remediations,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeferOnUnsafeStorage bool `json:"deferOnUnsafeStorage,omitempty"`
 
-	// ConditionsHealthyTimestamp is RFC 3339 date and time at which the unhealthy conditions didn't match anymore.
-	// The remediation CR will be deleted at that time, but the node will still be tracked as unhealthy until all
-	// remediation CRs are actually deleted, when remediators finished cleanup and removed their finalizers.
+	// RespectPodDisruptionBudgets defers the remediation of nodes, as long as removing their pods would violate a
+	// PodDisruptionBudget in the configured namespaces. Nodes which are unreachable are not checked, because their
+	// pods are gone effectively already. Remediation proceeds anyway after the override timeout.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	ConditionsHealthyTimestamp *metav1.Time `json:"conditionsHealthyTimestamp,omitempty"`
-}
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RespectPodDisruptionBudgets *RespectPodDisruptionBudgets `json:"respectPodDisruptionBudgets,omitempty"`
 
-// Remediation defines a remediation which was created for a node
-type Remediation struct {
-	// Resource is the reference to the remediation CR which was created
+	// PostRemediationVerification configures a verification, which needs to succeed after a remediated node is
+	// healthy again, before the node is considered to be remediated. Until then, the node stays in the
+	// UnhealthyNodes status, with a Verifying verification phase.
+	// Requires the operator to run with post remediation verification enabled.
 	//
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	Resource corev1.ObjectReference `json:"resource"`
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PostRemediationVerification *PostRemediationVerification `json:"postRemediationVerification,omitempty"`
 
-	// Started is the creation time of the remediation CR
+	// MaxRemediationDuration caps the time a node can be under remediation, across all escalating remediations,
+	// counted from the start of its first remediation. When it is exceeded, the ongoing remediation is timed out,
+	// and remediation of the node is given up, regardless of remaining escalating remediations.
 	//
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	Started metav1.Time `json:"started"`
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxRemediationDuration *metav1.Duration `json:"maxRemediationDuration,omitempty"`
 
-	// TimedOut is the time when the remediation timed out.
-	// Applicable for escalating remediations only.
+	// RemediationThrottle paces the remediation of nodes of this NHC, by limiting the number of nodes under
+	// remediation at the same time, and the number of node remediations started within a sliding window.
+	// Remediation of further nodes is deferred while a limit is reached. Ongoing remediations, including their
+	// escalation, aren't affected.
 	//
 	//+optional
-	//+operator-sdk:csv:customresourcedefinitions:type=status
-	TimedOut *metav1.Time `json:"timedOut,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationThrottle *RemediationThrottle `json:"remediationThrottle,omitempty"`
+
+	// AdaptiveStabilization configures longer unhealthy condition durations for nodes which were remediated recently,
+	// because their remediation apparently doesn't fix the root cause of their failures. The duration of the unhealthy
+	// conditions is multiplied by the Multiplier for each remediation of the node which finished within the Window.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AdaptiveStabilization *AdaptiveStabilization `json:"adaptiveStabilization,omitempty"`
+
+	// RemediatorCircuitBreaker stops using escalating remediations, whose remediator is systematically failing, e.g.
+	// because its credentials expired. When the configured number of remediations of the same kind time out or fail
+	// in a row across all nodes, the circuit of that kind opens: no new remediation CRs of that kind are created,
+	// and escalation skips to the next escalating remediation. After the OpenDuration, one remediation of that kind
+	// is let through as probe, and its success closes the circuit again. Classic remediation isn't affected, because
+	// it has no next remediation to skip to.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediatorCircuitBreaker *RemediatorCircuitBreaker `json:"remediatorCircuitBreaker,omitempty"`
+
+	// RemediatorHealthCheck verifies that the remediators of remediation kinds are running, because an existing
+	// template doesn't mean that its remediation CRs are processed by anyone. For each remediation kind, it
+	// references the Deployment of its remediator, which needs to have ready replicas. Escalating remediations, whose
+	// remediator isn't running, are skipped as not applicable. For classic remediation, the RemediatorUnavailable
+	// condition warns about it, but the remediation CR is created anyway, because there is no other remediation to
+	// use. Deployments aren't watched, they are checked every minute.
+	//
+	//+listType=map
+	//+listMapKey=kind
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediatorHealthCheck []RemediatorHealthCheck `json:"remediatorHealthCheck,omitempty"`
+
+	// AlertSilencing silences the alerts of nodes in Alertmanager while they are remediated, because the alerts of
+	// a node which is fenced on purpose page people for nothing. The silence is created when remediation of a node
+	// starts, extended while remediation is in flight, and expired when the node is healthy again or its remediation
+	// was given up. Failures to reach Alertmanager are reported with the AlertSilencingFailed condition, they never
+	// affect remediation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AlertSilencing *AlertSilencing `json:"alertSilencing,omitempty"`
+
+	// ExternalRemediationGracePeriod is the time NHC leaves remediation of an unhealthy node to whoever applied the
+	// "node.kubernetes.io/out-of-service" taint, when the node has the taint before NHC started remediating it.
+	// When the taint is still present and the node didn't recover after the grace period, NHC takes over and creates
+	// a remediation CR. Defaults to 10m.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ExternalRemediationGracePeriod *metav1.Duration `json:"externalRemediationGracePeriod,omitempty"`
+
+	// PreRemediationDrain configures a drain of unhealthy nodes before their first remediation CR is created, in
+	// order to reduce the disruption of workloads by remediators which power-cycle the node right away.
+	// Unreachable nodes are not drained.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PreRemediationDrain *PreRemediationDrain `json:"preRemediationDrain,omitempty"`
+}
+
+// PreRemediationDrain defines how to drain unhealthy nodes before remediation
+type PreRemediationDrain struct {
+	// Enabled enables the drain of unhealthy nodes before remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Enabled bool `json:"enabled"`
+
+	// Timeout is the maximum time to wait for the drain to complete. When it expires, the remediation CR is created,
+	// even if pods are still running on the node. Defaults to 5m.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// DeleteEmptyDirData allows to evict pods with emptyDir volumes, whose data is lost.
+	// Otherwise these pods prevent the drain.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeleteEmptyDirData bool `json:"deleteEmptyDirData,omitempty"`
+
+	// IgnoreDaemonSets skips pods managed by a DaemonSet, which would be recreated on the node anyway.
+	// Otherwise these pods prevent the drain.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	IgnoreDaemonSets bool `json:"ignoreDaemonSets,omitempty"`
+}
+
+// RequiredPods defines pods which need to be Ready on a remediated node
+type RequiredPods struct {
+	// Namespace is the namespace of the pods
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+
+	// Selector is the label selector of the pods
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// PostRemediationVerification defines how to verify a remediated node
+type PostRemediationVerification struct {
+	// VerificationTemplate is a reference to a verification template, which needs to have a spec.template.spec.
+	// After a remediated node is healthy again, a verification CR is created from the template, like remediation
+	// CRs are created from remediation templates: its kind is the template's kind without the "Template" suffix,
+	// its name is the name of the node, and it is created in the template's namespace.
+	// The verifier needs to set a "Succeeded" condition on the verification CR, with status "True" when
+	// verification succeeded, or "False" when it failed.
+	// Optional when MinReadyTime or RequiredPods are set.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	VerificationTemplate corev1.ObjectReference `json:"verificationTemplate,omitempty"`
+
+	// MinReadyTime is the minimum time the remediated node needs to be Ready, before verification can succeed.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinReadyTime *metav1.Duration `json:"minReadyTime,omitempty"`
+
+	// RequiredPods are pods which need to be Ready on the remediated node, before verification can succeed, e.g.
+	// the pods of CNI or CSI daemonsets. For each entry, at least one pod matching it needs to be Ready on the node.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RequiredPods []RequiredPods `json:"requiredPods,omitempty"`
+
+	// Timeout is the time after which verification fails, when the verifier didn't report a result yet.
+	// Defaults to 10m.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// ReEscalateOnFailure restarts remediation with the next escalating remediation when verification failed.
+	// When not set, or when there is no escalating remediation left, a failed verification is reported with an event,
+	// and the node is considered to be remediated.
+	// Can only be used with EscalatingRemediations.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ReEscalateOnFailure bool `json:"reEscalateOnFailure,omitempty"`
+}
+
+// SurgeGate defines the minimum of ready replicas a MachineSet needs to keep during remediation
+type SurgeGate struct {
+	// MinReadyReplicas is the minimum number of ready replicas the MachineSet of an unhealthy node needs to keep
+	// when the node is remediated.
+	//
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinReadyReplicas int32 `json:"minReadyReplicas"`
+}
+
+// ConnectivityCheck defines when nodes, which are reported as unreachable, are considered unhealthy
+type ConnectivityCheck struct {
+	// Duration for which a node needs to be reported as unreachable, before it is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// HeartbeatSource defines a custom resource kind, whose objects report the liveness of nodes. Each heartbeat object
+// needs to be named like its node, or to have the name of its node in the spec.nodeName field. The time of the latest
+// heartbeat needs to be in the status.lastHeartbeatTime field, in RFC 3339 format.
+type HeartbeatSource struct {
+	// APIVersion is the group and version of the heartbeat kind, e.g. "agent.example.com/v1".
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the heartbeat objects.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the heartbeat objects, if the kind is namespaced.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace,omitempty"`
+
+	// StaleAfter is the duration after the latest heartbeat, after which the node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StaleAfter metav1.Duration `json:"staleAfter"`
+}
+
+// RemoteCluster defines how to access a remote cluster
+type RemoteCluster struct {
+	// KubeconfigSecretRef references a secret, which contains a kubeconfig for accessing the remote cluster
+	// in its "kubeconfig" key. The secret needs to be in the operator's namespace. Kubeconfigs using exec plugins,
+	// auth providers or file references are rejected.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	KubeconfigSecretRef corev1.SecretReference `json:"kubeconfigSecretRef"`
+}
+
+// ServiceAccountReference references a ServiceAccount
+type ServiceAccountReference struct {
+	// Name is the name of the ServiceAccount
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ServiceAccount
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+}
+
+// RemediatorHealthCheck references the Deployment of the remediator of a remediation kind
+type RemediatorHealthCheck struct {
+	// Kind is the kind of the remediation CRs, e.g. "SelfNodeRemediation"
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Kind string `json:"kind"`
+
+	// DeploymentRef references the Deployment of the remediator
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeploymentRef DeploymentReference `json:"deploymentRef"`
+}
+
+// DeploymentReference references a Deployment
+type DeploymentReference struct {
+	// Name is the name of the Deployment
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Deployment
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+}
+
+// AlertSilencing defines how to silence the alerts of nodes under remediation
+type AlertSilencing struct {
+	// AlertmanagerURL is the base URL of the Alertmanager API, e.g. "https://alertmanager-main.openshift-monitoring.svc:9094"
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AlertmanagerURL string `json:"alertmanagerURL"`
+
+	// SecretRef references a secret with an optional bearer token in its "token" key, and an optional PEM encoded CA
+	// bundle for verifying the certificate of Alertmanager in its "ca.crt" key. The secret needs to be in the operator's
+	// namespace. Note that the token is sent to the AlertmanagerURL, so everyone who can modify NodeHealthChecks can
+	// obtain it.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// Matchers select the alerts of the silence. Values are Go templates, in which {{.NodeName}} is replaced with
+	// the name of the remediated node, e.g. "{{.NodeName}}" for the "instance" label.
+	//
+	//+kubebuilder:validation:MinItems=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Matchers []SilenceMatcher `json:"matchers"`
+
+	// Duration is the duration of silences. Silences are extended while remediation is in flight, when less than
+	// half of their duration is left. Defaults to 1h.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:default:="1h"
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// SilenceMatcher matches an alert label of an Alertmanager silence
+type SilenceMatcher struct {
+	// Name is the name of the alert label
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+
+	// Value is a Go template of the value of the alert label, with the {{.NodeName}} placeholder
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Value string `json:"value"`
+
+	// IsRegex matches the value as regular expression
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	IsRegex bool `json:"isRegex,omitempty"`
+}
+
+// UnhealthyCondition represents a Node condition type and value with a
+// specified duration. When the named condition has been in the given
+// status for at least the duration value a node is considered unhealthy.
+type UnhealthyCondition struct {
+	// The condition type in the node's status to watch for.
+	//
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Type corev1.NodeConditionType `json:"type"`
+
+	// The condition status in the node's status to watch for.
+	// Typically False, True or Unknown.
+	//
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Duration of the condition specified when a node is considered unhealthy.
+	// A duration of 0s considers the node unhealthy as soon as the condition is observed, which is meant for
+	// critical conditions, e.g. a failing container runtime.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+
+	// RemediationEnabled defines if matching this condition triggers remediation. Defaults to true.
+	// Conditions with disabled remediation are report only: nodes matching them are listed in the
+	// ReportOnlyUnhealthyNodes status field, but are only remediated when they also match a condition
+	// with enabled remediation. This allows to stage new conditions before they are used for remediation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationEnabled *bool `json:"remediationEnabled,omitempty"`
+
+	// TaintKey combines the condition with the taint with the given key, e.g. node.kubernetes.io/unreachable.
+	// The node only matches the condition when it also has the taint for at least the TaintDuration.
+	// This allows to differentiate e.g. unreachable nodes from nodes with a stopped kubelet, which both
+	// have the Ready=Unknown condition.
+	// When a node has the taint, other conditions with the same type and status, but without a taint key, are
+	// ignored for that node. Otherwise the first matching condition in list order is used.
+	//
+	//+kubebuilder:default=""
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TaintKey string `json:"taintKey,omitempty"`
+
+	// TaintDuration is the minimum age of the taint referenced by TaintKey. The age is based on the taint's
+	// timeAdded field, taints without that timestamp match as soon as they are present.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TaintDuration *metav1.Duration `json:"taintDuration,omitempty"`
+}
+
+// UnhealthyTaint represents a node taint. When the node has the taint for at least the duration, the node is
+// considered unhealthy.
+type UnhealthyTaint struct {
+	// Key is the key of the taint, e.g. node.kubernetes.io/unreachable.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+
+	// Value is the value of the taint. When set, only taints with this value match, otherwise taints with any value
+	// match.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Value string `json:"value,omitempty"`
+
+	// Effect is the effect of the taint, one of NoSchedule, PreferNoSchedule and NoExecute.
+	//
+	//+kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Effect corev1.TaintEffect `json:"effect"`
+
+	// Duration for which the node needs to have the taint, before the node is considered unhealthy. The duration
+	// is based on the taint's timeAdded field. For taints without that timestamp, e.g. taints with NoSchedule
+	// effect, the duration starts when NHC observes the taint first.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// AnnotationMatch represents a node annotation. When the node has had the annotation for at least the duration, the
+// node is considered unhealthy.
+type AnnotationMatch struct {
+	// Key is the key of the annotation, e.g. node-problem/ssd-failing.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+
+	// Value is the expected value of the annotation. When set, only annotations with this value match, otherwise
+	// annotations with any value match.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Value string `json:"value,omitempty"`
+
+	// Duration for which the node needs to have the annotation, before the node is considered unhealthy. Since
+	// annotations have no timestamp, the duration starts when NHC observes the annotation first.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UnhealthyCapacity represents a node resource with a minimum capacity. When the node's capacity of the resource
+// has been below the minimum quantity for at least the duration, the node is considered unhealthy.
+type UnhealthyCapacity struct {
+	// ResourceName is the name of the resource in the node's capacity, e.g. nvidia.com/gpu.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// MinQuantity is the minimum capacity of the resource. A node without the resource in its capacity is
+	// considered to have a capacity of zero.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinQuantity resource.Quantity `json:"minQuantity"`
+
+	// Duration for which the capacity needs to be below the minimum quantity, before the node is considered
+	// unhealthy. Since node capacity has no transition time, the duration starts when NHC observes the
+	// insufficient capacity first.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UnhealthyUtilization represents a node resource with a maximum utilization. When the node's utilization of the
+// resource has been above the threshold for at least the duration, the node is considered unhealthy.
+type UnhealthyUtilization struct {
+	// ResourceName is the name of the resource, cpu or memory. The metrics.k8s.io API doesn't provide the usage of
+	// other resources, use the DiskPressure condition for disk usage.
+	//
+	//+kubebuilder:validation:Enum=cpu;memory
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// ThresholdPercent is the utilization in percent of the node's allocatable resource. The node's utilization
+	// needs to be above it for being considered unhealthy.
+	//
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ThresholdPercent int `json:"thresholdPercent"`
+
+	// Duration for which the utilization needs to be above the threshold, before the node is considered unhealthy.
+	// Since node metrics have no transition time, the duration starts when NHC observes the high utilization first.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// InlineRemediationTemplate defines a remediation CR inline
+type InlineRemediationTemplate struct {
+	// APIVersion is the apiVersion of the remediation CR
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the remediation CR, e.g. SelfNodeRemediation
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the remediation CR. Mandatory for namespaced remediation CRs.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace,omitempty"`
+
+	// Spec is the spec of the remediation CR
+	//
+	//+kubebuilder:pruning:PreserveUnknownFields
+	//+kubebuilder:validation:Schemaless
+	//+kubebuilder:validation:Type=object
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Spec runtime.RawExtension `json:"spec,omitempty"`
+}
+
+// EscalatingRemediation defines a remediation template with order and timeout
+type EscalatingRemediation struct {
+	// RemediationTemplate is a reference to a remediation template
+	// provided by a remediation provider.
+	//
+	// If a node needs remediation the controller will create an object from this template
+	// and then it should be picked up by a remediation provider.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationTemplate corev1.ObjectReference `json:"remediationTemplate"`
+
+	// Order defines the order for this remediation.
+	// Remediations with lower order will be used before remediations with higher order.
+	// Remediations must not have the same order.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Order int `json:"order"`
+
+	// Timeout defines how long NHC will wait for the node getting healthy
+	// before the next remediation (if any) will be used. When the last remediation times out,
+	// the overall remediation is considered as failed.
+	// As a safeguard for preventing parallel remediations, a minimum of 60s is enforced.
+	// Can be omitted when using the Exponential EscalationTimeoutStrategy, the timeout is computed then.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// TimeoutMultiplier stretches the timeout of retried remediations, for remediators which legitimately need
+	// longer on later attempts. A remediation is retried when the EscalationMemory starts remediation of a node
+	// again with the last escalating remediation. The Nth consecutive attempt of this remediation, see the
+	// StepAttempt of the EscalationStart in the status, uses Timeout * TimeoutMultiplier^(N-1).
+	// Expects a decimal number between 1 and 10, defaults to 1.
+	//
+	//+kubebuilder:validation:Pattern="^[0-9]+(\\.[0-9]+)?$"
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TimeoutMultiplier string `json:"timeoutMultiplier,omitempty"`
+
+	// MaxTimeout caps the timeout stretched by the TimeoutMultiplier.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxTimeout *metav1.Duration `json:"maxTimeout,omitempty"`
+
+	// RequiredNodeLabels are labels the node needs to have for using this remediation, e.g. capability labels of
+	// hardware specific remediators. They are evaluated when escalation reaches this remediation. When the node is
+	// missing any of them, the remediation is recorded as NotApplicable with the missing labels in the status,
+	// and escalation continues with the next remediation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RequiredNodeLabels map[string]string `json:"requiredNodeLabels,omitempty"`
+
+	// PauseReason pauses this remediation when set, e.g. during maintenance of the infrastructure it relies on.
+	// A paused remediation is recorded as NotApplicable with the StepPaused reason in the status, and escalation
+	// continues with the next remediation. Not all escalating remediations can be paused at the same time, use
+	// PauseRequests for pausing the whole NodeHealthCheck.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PauseReason string `json:"pauseReason,omitempty"`
+
+	// AbortOnPause configures what happens with ongoing remediations of this remediation, when it gets paused.
+	// By default they are left alone until they succeed or time out. When set to true, their remediation CRs are
+	// deleted, and escalation continues with the next remediation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AbortOnPause bool `json:"abortOnPause,omitempty"`
+}
+
+// ExternalCRDeletionPolicy defines how remediation CRs, which were deleted by someone else than NHC, are handled
+type ExternalCRDeletionPolicy string
+
+const (
+	// ExternalCRDeletionPolicyRecreate recreates the remediation CR and continues the same remediation attempt
+	ExternalCRDeletionPolicyRecreate ExternalCRDeletionPolicy = "Recreate"
+	// ExternalCRDeletionPolicyTreatAsCompleted marks the remediation as Succeeded
+	ExternalCRDeletionPolicyTreatAsCompleted ExternalCRDeletionPolicy = "TreatAsCompleted"
+	// ExternalCRDeletionPolicyTreatAsFailed marks the remediation as failed and escalates
+	ExternalCRDeletionPolicyTreatAsFailed ExternalCRDeletionPolicy = "TreatAsFailed"
+)
+
+// RemediationThrottle limits how many nodes are remediated at the same time and within a window
+type RemediationThrottle struct {
+	// MaxConcurrent is the maximum number of nodes under remediation at the same time. 0 means no limit.
+	//
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+
+	// MaxPerWindow is the maximum number of node remediations started within the Window. 0 means no limit.
+	//
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxPerWindow int `json:"maxPerWindow,omitempty"`
+
+	// Window is the duration of the sliding window for MaxPerWindow.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+}
+
+// TimeWindow defines a recurring time window
+type TimeWindow struct {
+	// Start is a cron expression in the standard 5 field format, e.g. "0 22 * * 1-5", which defines when the window
+	// opens.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Start string `json:"start"`
+
+	// End is a cron expression in the standard 5 field format, e.g. "0 4 * * 2-6", which defines when the window
+	// closes. The window is open from a Start time until the next End time, so Start and End times should alternate.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	End string `json:"end"`
+
+	// TimeZone is the name of the time zone in which Start and End are evaluated, e.g. "Europe/Berlin". Defaults to
+	// UTC.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// RespectPodDisruptionBudgets defines which PodDisruptionBudgets are respected before remediating a node
+type RespectPodDisruptionBudgets struct {
+	// Enabled enables checking PodDisruptionBudgets before remediation.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Enabled bool `json:"enabled"`
+
+	// Namespaces are the namespaces of the PodDisruptionBudgets which are respected. Defaults to all namespaces.
+	//
+	//+listType=set
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// OverrideTimeout is the time after which remediation proceeds, although it violates PodDisruptionBudgets.
+	// Defaults to 30m.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	OverrideTimeout *metav1.Duration `json:"overrideTimeout,omitempty"`
+}
+
+// AdaptiveStabilization defines how the unhealthy condition durations grow for nodes which were remediated recently
+type AdaptiveStabilization struct {
+	// Enabled enables the adaptive stabilization.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Enabled bool `json:"enabled"`
+
+	// Multiplier is the factor by which the unhealthy condition durations are multiplied for each recent remediation
+	// of a node. Defaults to 2.
+	//
+	//+kubebuilder:default=2
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Multiplier int `json:"multiplier,omitempty"`
+
+	// Window is the duration after a finished remediation, during which the remediation counts as recent.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+
+	// MaxDuration caps the multiplied unhealthy condition durations. Unhealthy conditions with a longer configured
+	// duration keep it. Not capped by default.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+}
+
+// RemediatorCircuitBreaker configures when to stop using remediators which are systematically failing
+type RemediatorCircuitBreaker struct {
+	// FailureThreshold is the number of remediations of the same kind, which need to time out or fail in a row
+	// across all nodes for opening the circuit of that kind. Defaults to 5.
+	//
+	//+kubebuilder:default=5
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+
+	// OpenDuration is how long a circuit stays open, before one remediation of its kind is let through as probe.
+	// Defaults to 30 minutes.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	OpenDuration *metav1.Duration `json:"openDuration,omitempty"`
+}
+
+// RemediationThrottleLimit is a limit of the RemediationThrottle
+type RemediationThrottleLimit string
+
+const (
+	// RemediationThrottleLimitMaxConcurrent is the limit of nodes under remediation at the same time
+	RemediationThrottleLimitMaxConcurrent RemediationThrottleLimit = "MaxConcurrent"
+	// RemediationThrottleLimitMaxPerWindow is the limit of node remediations started within the window
+	RemediationThrottleLimitMaxPerWindow RemediationThrottleLimit = "MaxPerWindow"
+)
+
+// RemediationNamespaceStrategy defines in which namespace remediation CRs are created
+type RemediationNamespaceStrategy string
+
+const (
+	// RemediationNamespaceStrategyTemplateNamespace creates remediation CRs in the namespace of the remediation template
+	RemediationNamespaceStrategyTemplateNamespace RemediationNamespaceStrategy = "TemplateNamespace"
+	// RemediationNamespaceStrategyMachineNamespace creates remediation CRs in the namespace of the node's Machine
+	RemediationNamespaceStrategyMachineNamespace RemediationNamespaceStrategy = "MachineNamespace"
+)
+
+// EscalationTimeoutStrategyType defines how timeouts of escalating remediations are determined
+type EscalationTimeoutStrategyType string
+
+const (
+	// EscalationTimeoutStrategyFixed uses the configured timeout of each escalating remediation
+	EscalationTimeoutStrategyFixed EscalationTimeoutStrategyType = "Fixed"
+	// EscalationTimeoutStrategyExponential computes the timeout of escalating remediations without configured timeout
+	EscalationTimeoutStrategyExponential EscalationTimeoutStrategyType = "Exponential"
+)
+
+// EscalationTimeoutStrategy defines how timeouts of escalating remediations are determined
+type EscalationTimeoutStrategy struct {
+	// Type is the type of the strategy, either Fixed or Exponential.
+	// Fixed uses the timeout configured on each escalating remediation.
+	// Exponential computes the timeout of escalating remediations without configured timeout from
+	// the Exponential configuration.
+	//
+	//+kubebuilder:validation:Enum=Fixed;Exponential
+	//+kubebuilder:default=Fixed
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Type EscalationTimeoutStrategyType `json:"type"`
+
+	// Exponential configures the Exponential strategy. Mandatory for and only allowed with the Exponential type.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Exponential *ExponentialTimeout `json:"exponential,omitempty"`
+}
+
+// ExponentialTimeout defines the computation of exponentially growing escalation timeouts.
+// The timeout of the n-th escalating remediation (counting from 0, sorted by order) is Base * Factor^n,
+// limited by Max.
+type ExponentialTimeout struct {
+	// Base is the timeout of the first escalating remediation. A minimum of 60s is enforced.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Base metav1.Duration `json:"base"`
+
+	// Factor is the factor by which the timeout grows with each escalation.
+	//
+	//+kubebuilder:default=2
+	//+kubebuilder:validation:Minimum=2
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Factor int `json:"factor,omitempty"`
+
+	// Max is the maximum computed timeout.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Max *metav1.Duration `json:"max,omitempty"`
+}
+
+// EscalationMemory defines for how long the last used escalating remediation of a node is remembered
+type EscalationMemory struct {
+	// Window is the duration after a finished remediation, during which a new remediation of the same node will
+	// start with the remediation following the last used one.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+}
+
+// NodeHealthCheckStatus defines the observed state of NodeHealthCheck
+type NodeHealthCheckStatus struct {
+	// ObservedNodes specified the number of nodes observed by using the NHC spec.selector
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedNodes *int `json:"observedNodes,omitempty"`
+
+	// HealthyNodes specified the number of healthy nodes observed
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthyNodes *int `json:"healthyNodes,omitempty"`
+
+	// UnhealthyDurationBuckets summarizes for how long the nodes, which match an unhealthy condition, match it already.
+	// Nodes are counted as soon as they match the condition's type and status, before its duration expired.
+	// This helps with tuning the duration of the unhealthy conditions.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthyDurationBuckets *UnhealthyDurationBuckets `json:"unhealthyDurationBuckets,omitempty"`
+
+	// DefaultTemplateNamespace is the namespace used for namespaced remediation templates which are referenced
+	// without a namespace. It is resolved once to the operator's namespace.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	DefaultTemplateNamespace string `json:"defaultTemplateNamespace,omitempty"`
+
+	// EffectiveConfig summarizes the configuration which the controller enforced in its last reconcile, after
+	// defaulting and resolving the spec and the operator's flags. It is only updated when the resolved values change.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+
+	// CreatedRemediationKinds tracks the kinds of all remediation CRs which were created by this NHC. Remediation CRs
+	// of kinds which aren't referenced by the spec anymore are deleted.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	CreatedRemediationKinds []metav1.GroupVersionKind `json:"createdRemediationKinds,omitempty"`
+
+	// ReportOnlyUnhealthyNodes tracks nodes which match report only unhealthy conditions, that are conditions with
+	// disabled remediation, but no condition with enabled remediation. These nodes are not remediated.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ReportOnlyUnhealthyNodes []ReportOnlyUnhealthyNode `json:"reportOnlyUnhealthyNodes,omitempty"`
+
+	// IgnoredUnhealthyNodes tracks unhealthy nodes which are not remediated, e.g. because they were unhealthy already
+	// when the NHC was created and IgnorePreexistingConditions is set.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	IgnoredUnhealthyNodes []IgnoredUnhealthyNode `json:"ignoredUnhealthyNodes,omitempty"`
+
+	// InsufficientCapacityNodes tracks since when nodes have a capacity below the minimum quantity of an
+	// UnhealthyCapacity, because node capacity has no transition time.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+listMapKey=resourceName
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	InsufficientCapacityNodes []InsufficientCapacityNode `json:"insufficientCapacityNodes,omitempty"`
+
+	// TaintedNodes tracks since when nodes have a taint of an UnhealthyTaint, for taints without timeAdded field.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+listMapKey=key
+	//+listMapKey=effect
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TaintedNodes []TaintedNode `json:"taintedNodes,omitempty"`
+
+	// AnnotatedNodes tracks since when nodes have an annotation of an UnhealthyAnnotations entry, because
+	// annotations have no timestamp.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+listMapKey=key
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	AnnotatedNodes []AnnotatedNode `json:"annotatedNodes,omitempty"`
+
+	// HighUtilizationNodes tracks since when nodes have a utilization above the threshold of an UnhealthyUtilization,
+	// because node metrics have no transition time.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+listMapKey=resourceName
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HighUtilizationNodes []HighUtilizationNode `json:"highUtilizationNodes,omitempty"`
+
+	// UnhealthySignalVotes tracks which signal categories vote nodes as unhealthy, when UnhealthyQuorum is set.
+	// Nodes are only considered unhealthy when they have at least UnhealthyQuorum votes.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthySignalVotes []UnhealthySignalVote `json:"unhealthySignalVotes,omitempty"`
+
+	// UnhealthyNodes tracks currently unhealthy nodes and their remediations.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthyNodes []*UnhealthyNode `json:"unhealthyNodes,omitempty"`
+
+	// ExhaustedNodes are the names of the unhealthy nodes whose remediation was given up, because it exceeded the
+	// MaxRemediationDuration. Their remediation can be resumed with the remediation.medik8s.io/reset-node annotation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ExhaustedNodes []string `json:"exhaustedNodes,omitempty"`
+
+	// RecentRemediations remembers the last used escalating remediation of nodes which got healthy again,
+	// for the duration of the EscalationMemory window.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RecentRemediations []*RecentRemediation `json:"recentRemediations,omitempty"`
+
+	// RemediationHistory records finished remediations, also of nodes which are healthy again, for debugging past
+	// incidents. It's ordered by the time the remediations finished, and keeps the latest HistoryMaxEntries records.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationHistory []NodeRemediationRecord `json:"remediationHistory,omitempty"`
+
+	// CoolingDownNodes tracks the nodes whose remediation finished within the RemediationCooldown. Only set when the
+	// RemediationCooldown is configured.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	CoolingDownNodes []*CoolingDownNode `json:"coolingDownNodes,omitempty"`
+
+	// RemediationThrottle reports the state of the RemediationThrottle. Only set when it is configured.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationThrottle *RemediationThrottleStatus `json:"remediationThrottle,omitempty"`
+
+	// StabilizingNodes tracks the recent remediations of nodes for the AdaptiveStabilization, and the effective
+	// unhealthy condition duration of nodes which are going to match an unhealthy condition.
+	// Only set when the AdaptiveStabilization is enabled.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	StabilizingNodes []*StabilizingNode `json:"stabilizingNodes,omitempty"`
+
+	// RemediatorCircuits tracks the circuits of the RemediatorCircuitBreaker per remediation kind, while remediations
+	// of that kind failed in a row, or while the circuit isn't closed.
+	//
+	//+listType=map
+	//+listMapKey=kind
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediatorCircuits []*RemediatorCircuit `json:"remediatorCircuits,omitempty"`
+
+	// UnavailableRemediators tracks the remediation kinds of the RemediatorHealthCheck, whose remediator isn't
+	// running.
+	//
+	//+listType=map
+	//+listMapKey=kind
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnavailableRemediators []*UnavailableRemediator `json:"unavailableRemediators,omitempty"`
+
+	// AlertSilences tracks the Alertmanager silences of the AlertSilencing per node.
+	//
+	//+listType=map
+	//+listMapKey=nodeName
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	AlertSilences []*AlertSilence `json:"alertSilences,omitempty"`
+
+	// OrphanedRemediations tracks remediation CRs which NHC failed to delete, e.g. because of missing permissions.
+	// Their deletion is retried, and they are removed from this list as soon as deletion succeeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	OrphanedRemediations []OrphanedRemediation `json:"orphanedRemediations,omitempty"`
+
+	// InFlightRemediations records the timestamp when remediation triggered per node.
+	// Deprecated in favour of UnhealthyNodes, and not written anymore when the operator runs with
+	// the legacy status disabled. Use GetInFlightRemediations() for reading it in a compatible way.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	InFlightRemediations map[string]metav1.Time `json:"inFlightRemediations,omitempty"`
+
+	// Represents the observations of a NodeHealthCheck's current state.
+	// Known .status.conditions.type are: "Disabled"
+	//
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase represents the current phase of this Config.
+	// Known phases are Disabled, Paused, DryRun, WaitingForWindow, Remediating and Enabled, based on:\n
+	// - the status of the Disabled condition\n
+	// - the value of PauseRequests\n
+	// - the value of DryRun\n
+	// - the value of RemediationWindows\n
+	// - the value of InFlightRemediations
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.phase"
+	Phase NHCPhase `json:"phase,omitempty"`
+
+	// Reason explains the current phase in more detail.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.phase:reason"
+	Reason string `json:"reason,omitempty"`
+
+	// LastPhaseTransitionTime is the last time the phase changed.
+	//
+	//+optional
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:Format=date-time
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastPhaseTransitionTime *metav1.Time `json:"lastPhaseTransitionTime,omitempty"`
+
+	// PhaseDurations is the cumulative time spent in each phase, up to the LastPhaseTransitionTime.
+	// The time spent in the current phase since then isn't included.
+	//
+	//+optional
+	//+listType=map
+	//+listMapKey=phase
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	PhaseDurations []PhaseDuration `json:"phaseDurations,omitempty"`
+
+	// LastError is the error of the last failed reconcile, e.g. a transient API server error while fetching a
+	// remediation template. The phase isn't changed by such errors, and reconciling is retried with backoff.
+	// It is removed by the next successful reconcile.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastError *LastError `json:"lastError,omitempty"`
+
+	// LastReconcileTime is the time of the last successful reconcile. It is only refreshed when it is older than the
+	// operator's configured interval, in order to avoid a status update on every reconcile. It serves as a heartbeat
+	// of the controller.
+	//
+	//+optional
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:Format=date-time
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// LastUpdateTime is the last time the status was updated.
+	//
+	//+optional
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:Format=date-time
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// NextRetryTime is the time when a disabled NHC is evaluated again, for detecting that the reason for disabling
+	// it was resolved without any event which NHC watches. The retry interval grows exponentially, and is reset by
+	// spec changes. It is removed when the NHC isn't disabled anymore.
+	//
+	//+optional
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:Format=date-time
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// GetNodeSelector returns the selector of the nodes matching the Selector, restricted to the TargetArchitectures
+// and TargetOperatingSystems
+func (s *NodeHealthCheckSpec) GetNodeSelector() (labels.Selector, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&s.Selector)
+	if err != nil {
+		return nil, err
+	}
+	requirements, err := s.getTargetPlatformRequirements()
+	if err != nil {
+		return nil, err
+	}
+	return selector.Add(requirements...), nil
+}
+
+// MatchesTargetPlatform returns true if the given node labels match the TargetArchitectures and
+// TargetOperatingSystems. Invalid values don't match any node.
+func (s *NodeHealthCheckSpec) MatchesTargetPlatform(nodeLabels map[string]string) bool {
+	requirements, err := s.getTargetPlatformRequirements()
+	if err != nil {
+		return false
+	}
+	return labels.NewSelector().Add(requirements...).Matches(labels.Set(nodeLabels))
+}
+
+func (s *NodeHealthCheckSpec) getTargetPlatformRequirements() ([]labels.Requirement, error) {
+	var requirements []labels.Requirement
+	for _, target := range []struct {
+		key    string
+		values []string
+	}{
+		{corev1.LabelArchStable, s.TargetArchitectures},
+		{corev1.LabelOSStable, s.TargetOperatingSystems},
+	} {
+		if len(target.values) == 0 {
+			continue
+		}
+		requirement, err := labels.NewRequirement(target.key, selection.In, target.values)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *requirement)
+	}
+	return requirements, nil
+}
+
+// GetUnhealthySignals returns the configured signal categories, which vote for the UnhealthyQuorum. Conditions with
+// disabled remediation don't vote.
+func (s *NodeHealthCheckSpec) GetUnhealthySignals() []UnhealthySignal {
+	var signals []UnhealthySignal
+	hasConditions, hasTaints := false, false
+	for _, c := range s.UnhealthyConditions {
+		if c.RemediationEnabled != nil && !*c.RemediationEnabled {
+			continue
+		}
+		if c.TaintKey == "" {
+			hasConditions = true
+		} else {
+			hasTaints = true
+		}
+	}
+	if hasConditions {
+		signals = append(signals, UnhealthySignalConditions)
+	}
+	if hasTaints || len(s.UnhealthyTaints) > 0 {
+		signals = append(signals, UnhealthySignalTaints)
+	}
+	if len(s.UnhealthyAnnotations) > 0 {
+		signals = append(signals, UnhealthySignalAnnotations)
+	}
+	if len(s.UnhealthyCapacity) > 0 {
+		signals = append(signals, UnhealthySignalCapacity)
+	}
+	if len(s.UnhealthyUtilization) > 0 {
+		signals = append(signals, UnhealthySignalUtilization)
+	}
+	if s.ConnectivityCheck != nil {
+		signals = append(signals, UnhealthySignalConnectivity)
+	}
+	if s.HeartbeatSource != nil {
+		signals = append(signals, UnhealthySignalHeartbeat)
+	}
+	return signals
+}
+
+// GetEscalatingRemediationTimeout returns the effective timeout of the given escalating remediation. That is the
+// configured timeout, or the timeout computed by the Exponential EscalationTimeoutStrategy if no timeout is configured.
+func (s *NodeHealthCheckSpec) GetEscalatingRemediationTimeout(remediation EscalatingRemediation) time.Duration {
+	strategy := s.EscalationTimeoutStrategy
+	if remediation.Timeout.Duration > 0 || strategy == nil || strategy.Type != EscalationTimeoutStrategyExponential || strategy.Exponential == nil {
+		return remediation.Timeout.Duration
+	}
+	exponential := strategy.Exponential
+
+	// the exponent is the position of the remediation when sorted by order
+	position := 0
+	for _, other := range s.EscalatingRemediations {
+		if other.Order < remediation.Order {
+			position++
+		}
+	}
+	factor := time.Duration(exponential.Factor)
+	if factor < 2 {
+		factor = 2
+	}
+
+	timeout := exponential.Base.Duration
+	for i := 0; i < position; i++ {
+		if timeout > math.MaxInt64/factor {
+			// prevent overflow
+			timeout = math.MaxInt64
+			break
+		}
+		timeout *= factor
+	}
+	if exponential.Max != nil && timeout > exponential.Max.Duration {
+		timeout = exponential.Max.Duration
+	}
+	return timeout
+}
+
+// GetInFlightRemediations returns the deprecated InFlightRemediations if it is populated. Otherwise it is
+// synthesized from UnhealthyNodes, using the start time of the first remediation of each node.
+func (s *NodeHealthCheckStatus) GetInFlightRemediations() map[string]metav1.Time {
+	if len(s.InFlightRemediations) > 0 {
+		return s.InFlightRemediations
+	}
+	inFlight := make(map[string]metav1.Time)
+	for _, unhealthyNode := range s.UnhealthyNodes {
+		if unhealthyNode == nil || len(unhealthyNode.Remediations) == 0 {
+			continue
+		}
+		inFlight[unhealthyNode.Name] = unhealthyNode.Remediations[0].Started
+	}
+	return inFlight
+}
+
+// EffectiveConfig is the resolved configuration of a NodeHealthCheck
+type EffectiveConfig struct {
+	// MinHealthy is the absolute number of healthy nodes which is required for remediation, resolved from MinHealthy
+	// and the number of observed nodes.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MinHealthy *int `json:"minHealthy,omitempty"`
+
+	// MaxUnhealthy is the absolute number of nodes which are remediated at the same time at most, resolved from
+	// MaxUnhealthy and the number of observed nodes. Unlimited when not set.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MaxUnhealthy *int `json:"maxUnhealthy,omitempty"`
+
+	// UnhealthyConditions are the unhealthy conditions which trigger remediation, with defaults applied. Report only
+	// conditions are not included.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+
+	// RemediationTemplates are the remediation templates of all routes, with resolved namespaces. The namespace of
+	// cluster scoped templates is empty.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationTemplates []corev1.ObjectReference `json:"remediationTemplates,omitempty"`
+
+	// MaxClusterRemediations is the maximum of simultaneous remediations across all NodeHealthChecks, configured by
+	// the operator. 0 means unlimited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MaxClusterRemediations int `json:"maxClusterRemediations,omitempty"`
+
+	// MaxConcurrentRemediations is the maximum of simultaneous remediations of this NodeHealthCheck, configured by
+	// its RemediationThrottle. 0 means unlimited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MaxConcurrentRemediations int `json:"maxConcurrentRemediations,omitempty"`
+
+	// MaxRemediationsPerWindow is the maximum of remediations started within the window of the RemediationThrottle.
+	// 0 means unlimited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MaxRemediationsPerWindow int `json:"maxRemediationsPerWindow,omitempty"`
+
+	// RemediationCreationConcurrency is the number of remediation CRs which are created in parallel, configured by
+	// the operator.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationCreationConcurrency int `json:"remediationCreationConcurrency,omitempty"`
+
+	// Hash is a hash of the other fields, which changes when the resolved configuration changes.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Hash string `json:"hash"`
+}
+
+// PhaseDuration is the cumulative time spent in a phase
+type PhaseDuration struct {
+	// Phase is the phase
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Phase NHCPhase `json:"phase"`
+
+	// Duration is the cumulative time spent in the phase
+	//
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Duration metav1.Duration `json:"duration"`
+}
+
+// LastError describes the error of the last failed reconcile
+type LastError struct {
+	// Message is the error message.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message"`
+
+	// Since is the time since when reconciling fails with this error.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// UnhealthyDurationBuckets counts nodes matching unhealthy conditions by how long they match already
+type UnhealthyDurationBuckets struct {
+	// LessThan1m is the number of nodes which are unhealthy for less than 1 minute
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LessThan1m int `json:"lessThan1m"`
+
+	// From1mTo5m is the number of nodes which are unhealthy for at least 1 minute and less than 5 minutes
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	From1mTo5m int `json:"from1mTo5m"`
+
+	// AtLeast5m is the number of nodes which are unhealthy for 5 minutes or more
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	AtLeast5m int `json:"atLeast5m"`
+}
+
+// ReportOnlyUnhealthyNode is a node which matches report only unhealthy conditions
+type ReportOnlyUnhealthyNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Conditions are the matching unhealthy conditions with disabled remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []MatchedCondition `json:"conditions"`
+
+	// Since is the time at which the node was detected to match report only conditions
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// UnhealthySignal is a category of signals, which vote nodes as unhealthy, see UnhealthyQuorum
+type UnhealthySignal string
+
+const (
+	// UnhealthySignalConditions votes for nodes matching an UnhealthyCondition without TaintKey
+	UnhealthySignalConditions UnhealthySignal = "Conditions"
+	// UnhealthySignalTaints votes for nodes matching an UnhealthyCondition with TaintKey, or an UnhealthyTaint
+	UnhealthySignalTaints UnhealthySignal = "Taints"
+	// UnhealthySignalAnnotations votes for nodes matching an UnhealthyAnnotations entry
+	UnhealthySignalAnnotations UnhealthySignal = "Annotations"
+	// UnhealthySignalCapacity votes for nodes matching an UnhealthyCapacity
+	UnhealthySignalCapacity UnhealthySignal = "Capacity"
+	// UnhealthySignalUtilization votes for nodes matching an UnhealthyUtilization
+	UnhealthySignalUtilization UnhealthySignal = "Utilization"
+	// UnhealthySignalConnectivity votes for nodes which are reported as unreachable, see ConnectivityCheck
+	UnhealthySignalConnectivity UnhealthySignal = "Connectivity"
+	// UnhealthySignalHeartbeat votes for nodes with a stale heartbeat, see HeartbeatSource
+	UnhealthySignalHeartbeat UnhealthySignal = "Heartbeat"
+)
+
+// UnhealthySignalVote is a node and the signal categories which vote it as unhealthy
+type UnhealthySignalVote struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Signals are the signal categories which vote the node as unhealthy
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Signals []UnhealthySignal `json:"signals"`
+}
+
+// InsufficientCapacityNode is a node whose capacity of a resource is below the minimum quantity
+type InsufficientCapacityNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// ResourceName is the name of the resource with insufficient capacity
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Since is the time at which the insufficient capacity was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// TaintedNode is a node with a taint of an unhealthy taint, which has no timeAdded field
+type TaintedNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Key is the key of the taint
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Key string `json:"key"`
+
+	// Effect is the effect of the taint
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Effect corev1.TaintEffect `json:"effect"`
+
+	// Since is the time at which the taint was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// AnnotatedNode is a node with an annotation of an UnhealthyAnnotations entry
+type AnnotatedNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Key is the key of the annotation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Key string `json:"key"`
+
+	// Since is the time at which the annotation was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// MatchedAnnotation is a node annotation which matches an UnhealthyAnnotations entry
+type MatchedAnnotation struct {
+	// Key is the key of the annotation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Key string `json:"key"`
+
+	// Value is the value of the annotation
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Value string `json:"value,omitempty"`
+
+	// Since is the time at which the annotation was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// MatchedTaint is a node taint which matches an unhealthy taint
+type MatchedTaint struct {
+	// Key is the key of the taint
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Key string `json:"key"`
+
+	// Effect is the effect of the taint
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Effect corev1.TaintEffect `json:"effect"`
+
+	// Since is the time at which the taint was added, or observed first for taints without timeAdded field
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// MatchedCapacity is a node resource whose capacity matches an unhealthy capacity
+type MatchedCapacity struct {
+	// ResourceName is the name of the resource with insufficient capacity
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Since is the time at which the insufficient capacity was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// HighUtilizationNode is a node whose utilization of a resource is above the threshold
+type HighUtilizationNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// ResourceName is the name of the resource with high utilization
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Since is the time at which the high utilization was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// MatchedUtilization is a node resource whose utilization matches an unhealthy utilization
+type MatchedUtilization struct {
+	// ResourceName is the name of the resource with high utilization
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Since is the time at which the high utilization was observed first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// IgnoredUnhealthyNode is an unhealthy node which is not remediated
+type IgnoredUnhealthyNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Reason explains why the node is not remediated. Known reasons are PreexistingCondition and NodeTooYoung.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason"`
+
+	// Since is the time at which the node got unhealthy, respectively was created for the NodeTooYoung reason
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+
+	// EligibleAt is the time at which the node becomes eligible for remediation, if known
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	EligibleAt *metav1.Time `json:"eligibleAt,omitempty"`
+}
+
+// MatchedCondition is a node condition type and status which matches an unhealthy condition
+type MatchedCondition struct {
+	// Type is the node condition type
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status is the node condition status
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Status corev1.ConditionStatus `json:"status"`
+
+	// TaintKey is the taint key of the matching unhealthy condition, if it has one
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TaintKey string `json:"taintKey,omitempty"`
+}
+
+// UnhealthyNode defines an unhealthy node and its remediations
+type UnhealthyNode struct {
+	// Name is the name of the unhealthy node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// EligibleSince is the time since when the node is a candidate for remediation, i.e. since when it is tracked as
+	// unhealthy. When not all candidates can be remediated at the same time, e.g. because of the RemediationThrottle,
+	// candidates with the same priority are remediated in the order of this time, oldest first, so that no node is
+	// starved by nodes which became eligible later.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	EligibleSince *metav1.Time `json:"eligibleSince,omitempty"`
+
+	// Remediations tracks the remediations created for this node
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Remediations []*Remediation `json:"remediations,omitempty"`
+
+	// IsControllingNHC is true when this NodeHealthCheck owns the remediation CR of the node, and false when the
+	// remediation CR is owned by another NodeHealthCheck, e.g. because their selectors overlap. It isn't set before
+	// a remediation CR was created or found.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	IsControllingNHC *bool `json:"isControllingNHC,omitempty"`
+
+	// MatchedCondition is the unhealthy condition, including its taint key, which the node matched. It isn't set
+	// when the node is unhealthy because of other signals only, e.g. its connectivity report.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MatchedCondition *MatchedCondition `json:"matchedCondition,omitempty"`
+
+	// MatchedCapacity is set when the node is unhealthy because its capacity of a resource is below the minimum
+	// quantity of an UnhealthyCapacity.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MatchedCapacity *MatchedCapacity `json:"matchedCapacity,omitempty"`
+
+	// MatchedTaint is set when the node is unhealthy because it has the taint of an UnhealthyTaint.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MatchedTaint *MatchedTaint `json:"matchedTaint,omitempty"`
+
+	// MatchedAnnotation is set when the node is unhealthy because it has the annotation of an UnhealthyAnnotations
+	// entry.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MatchedAnnotation *MatchedAnnotation `json:"matchedAnnotation,omitempty"`
+
+	// MatchedUtilization is set when the node is unhealthy because its utilization of a resource is above the
+	// threshold of an UnhealthyUtilization.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MatchedUtilization *MatchedUtilization `json:"matchedUtilization,omitempty"`
+
+	// ConditionsHealthyTimestamp is RFC 3339 date and time at which the unhealthy conditions didn't match anymore.
+	// The remediation CR will be deleted at that time, but the node will still be tracked as unhealthy until all
+	// remediation CRs are actually deleted, when remediators finished cleanup and removed their finalizers.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ConditionsHealthyTimestamp *metav1.Time `json:"conditionsHealthyTimestamp,omitempty"`
+
+	// EscalationStart is set when escalating remediation didn't start with the first remediation,
+	// because of the EscalationMemory.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	EscalationStart *EscalationStart `json:"escalationStart,omitempty"`
+
+	// Route is the route which is used for remediating the node, i.e. where its remediation templates come from,
+	// and why it was chosen.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Route *RemediationRoute `json:"route,omitempty"`
+
+	// Deferral is set when remediation of the node is deferred, e.g. because of the SurgeGate.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Deferral *RemediationDeferral `json:"deferral,omitempty"`
+
+	// Drain is set when the node is drained before remediation, see PreRemediationDrain.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Drain *Drain `json:"drain,omitempty"`
+
+	// Verification is set while the post remediation verification of the healthy again node is ongoing,
+	// and when it failed and remediation was re-escalated.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Verification *Verification `json:"verification,omitempty"`
+
+	// RemediationExhausted is set when remediation of the node was given up, because it exceeded the
+	// MaxRemediationDuration.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationExhausted *metav1.Time `json:"remediationExhausted,omitempty"`
+
+	// UnreachableSince is set when the node is unhealthy because its NodeConnectivityReport reports it as
+	// unreachable. It is the time since when the node is reported as unreachable.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnreachableSince *metav1.Time `json:"unreachableSince,omitempty"`
+
+	// HeartbeatStaleSince is set when the node is unhealthy because its heartbeat object is stale. It is the time
+	// since when the heartbeat is stale.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HeartbeatStaleSince *metav1.Time `json:"heartbeatStaleSince,omitempty"`
+
+	// PDBViolationSince is set when the remediation of the node was deferred, because it would violate a
+	// PodDisruptionBudget. It is the time since when remediation is deferred, which starts the override timeout.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	PDBViolationSince *metav1.Time `json:"pdbViolationSince,omitempty"`
+}
+
+// VerificationPhase is the string used for Verification.Phase
+type VerificationPhase string
+
+const (
+	// VerificationPhaseVerifying is used while the verifier didn't report a result yet
+	VerificationPhaseVerifying VerificationPhase = "Verifying"
+
+	// VerificationPhaseSucceeded is used when verification succeeded
+	VerificationPhaseSucceeded VerificationPhase = "Succeeded"
+
+	// VerificationPhaseFailed is used when verification failed or timed out
+	VerificationPhaseFailed VerificationPhase = "Failed"
+)
+
+// Verification defines the post remediation verification of a node
+type Verification struct {
+	// Resource is the reference to the verification CR. It is empty when no VerificationTemplate is configured.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// Started is the creation time of the verification CR, or the start time of the verification without CR
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Started metav1.Time `json:"started"`
+
+	// Phase is the phase of the verification
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Phase VerificationPhase `json:"phase"`
+
+	// Message explains the phase in more detail
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// DrainPhase is the string used for Drain.Phase
+type DrainPhase string
+
+const (
+	// DrainPhaseDraining is used while pods are evicted from the node
+	DrainPhaseDraining DrainPhase = "Draining"
+
+	// DrainPhaseCompleted is used when all pods were evicted
+	DrainPhaseCompleted DrainPhase = "Completed"
+
+	// DrainPhaseTimedOut is used when the drain didn't complete before its timeout
+	DrainPhaseTimedOut DrainPhase = "TimedOut"
+
+	// DrainPhaseFailed is used when the drain can't complete, e.g. because of pods with emptyDir data
+	DrainPhaseFailed DrainPhase = "Failed"
+)
+
+// Drain defines the pre remediation drain of a node
+type Drain struct {
+	// Started is the time the drain started
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Started metav1.Time `json:"started"`
+
+	// Phase is the phase of the drain
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Phase DrainPhase `json:"phase"`
+
+	// Message explains the phase in more detail, e.g. which pods are not evicted yet
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// RemediationDeferral defines why remediation of a node is deferred
+type RemediationDeferral struct {
+	// Reason is the reason of the deferral in CamelCase
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason"`
+
+	// Message explains the deferral in more detail
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message"`
+
+	// Since is the time since when remediation is deferred
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// EscalationStart defines with which escalating remediation remediation started, and why
+type EscalationStart struct {
+	// Order is the order of the escalating remediation which was used first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Order int `json:"order"`
+
+	// Reason explains why remediation didn't start with the first escalating remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason"`
+
+	// StepAttempt is the number of the consecutive attempt of the escalating remediation with Order, when it is
+	// retried because it was the last one used for the node. It stretches the timeout, see TimeoutMultiplier.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	StepAttempt int `json:"stepAttempt,omitempty"`
+}
+
+// RemediationRouteType is the string used for RemediationRoute.Type
+type RemediationRouteType string
+
+const (
+	// RemediationRouteEscalation is used when the EscalatingRemediations are used
+	RemediationRouteEscalation RemediationRouteType = "EscalatingRemediations"
+	// RemediationRouteTemplate is used when the RemediationTemplate is used
+	RemediationRouteTemplate RemediationRouteType = "RemediationTemplate"
+	// RemediationRouteTemplates is used when the RemediationTemplates are used
+	RemediationRouteTemplates RemediationRouteType = "RemediationTemplates"
+	// RemediationRouteInlineTemplate is used when the InlineRemediationTemplate is used
+	RemediationRouteInlineTemplate RemediationRouteType = "InlineRemediationTemplate"
+)
+
+// RemediationRoute defines where the remediation templates of a node come from, and why
+type RemediationRoute struct {
+	// Type is the type of the route
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Type RemediationRouteType `json:"type"`
+
+	// Reason explains why this route was chosen, e.g. which other configured routes it takes precedence over
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason"`
+}
+
+// OrphanedRemediation is a remediation CR which NHC failed to delete
+type OrphanedRemediation struct {
+	// Resource is the reference to the remediation CR
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// NodeName is the name of the node the remediation CR belongs to
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Reason is the error of the latest deletion attempt
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason"`
+
+	// Since is the time of the first failed deletion attempt
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// StabilizingNode defines the recent remediations of a node, and its effective unhealthy condition duration
+type StabilizingNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// RemediationsFinished are the times when remediations of the node finished within the window
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationsFinished []metav1.Time `json:"remediationsFinished,omitempty"`
+
+	// EffectiveDuration is the duration in force for the unhealthy condition which the node is going to match
+	// first. Only set while the node is going to match an unhealthy condition.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	EffectiveDuration *metav1.Duration `json:"effectiveDuration,omitempty"`
+}
+
+// CoolingDownNode defines a node whose remediation finished within the RemediationCooldown
+type CoolingDownNode struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// RemediationFinished is the time when the last remediation of the node finished
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationFinished metav1.Time `json:"remediationFinished"`
+}
+
+// CircuitState is the string used for RemediatorCircuit.State
+type CircuitState string
+
+const (
+	// CircuitStateClosed is used while remediations of the kind are used as usual
+	CircuitStateClosed CircuitState = "Closed"
+	// CircuitStateOpen is used while no new remediation CRs of the kind are created
+	CircuitStateOpen CircuitState = "Open"
+	// CircuitStateHalfOpen is used while the remediation of a single node probes if the remediator works again
+	CircuitStateHalfOpen CircuitState = "HalfOpen"
+)
+
+// RemediatorCircuit is the circuit of the RemediatorCircuitBreaker for a remediation kind
+type RemediatorCircuit struct {
+	// Kind is the kind of the remediation CRs
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Kind string `json:"kind"`
+
+	// State is the state of the circuit
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	State CircuitState `json:"state"`
+
+	// ConsecutiveFailures is the number of remediations of the kind which timed out or failed in a row
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// Opened is the time when the circuit opened last
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Opened *metav1.Time `json:"opened,omitempty"`
+
+	// ProbeNode is the node whose remediation probes the remediator while the circuit is half open
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ProbeNode string `json:"probeNode,omitempty"`
+}
+
+// UnavailableRemediator is a remediation kind of the RemediatorHealthCheck, whose remediator isn't running
+type UnavailableRemediator struct {
+	// Kind is the kind of the remediation CRs
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Kind string `json:"kind"`
+
+	// Message explains why the remediator isn't running
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+
+	// Since is the time when the remediator was observed as not running first
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+}
+
+// AlertSilence is an Alertmanager silence of the alerts of a node under remediation
+type AlertSilence struct {
+	// NodeName is the name of the silenced node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NodeName string `json:"nodeName"`
+
+	// ID is the ID of the silence in Alertmanager
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ID string `json:"id"`
+
+	// EndsAt is the time when the silence ends if it isn't extended
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	EndsAt metav1.Time `json:"endsAt"`
+}
+
+// RemediationThrottleStatus reports the state of the RemediationThrottle
+type RemediationThrottleStatus struct {
+	// BindingLimit is the limit which currently defers the remediation of nodes, MaxConcurrent or MaxPerWindow.
+	// Empty when no limit is reached.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	BindingLimit RemediationThrottleLimit `json:"bindingLimit,omitempty"`
+
+	// InFlight is the number of nodes under remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	InFlight int `json:"inFlight"`
+
+	// StartedInWindow are the start times of the node remediations within the current window
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	StartedInWindow []metav1.Time `json:"startedInWindow,omitempty"`
+}
+
+// RecentRemediation defines the last used escalating remediation of a node which got healthy again
+type RecentRemediation struct {
+	// Name is the name of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Order is the order of the last escalating remediation which was used for the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Order int `json:"order"`
+
+	// Finished is the time when the node got healthy
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Finished metav1.Time `json:"finished"`
+
+	// Attempts is the number of remediation attempts of the node, which is continued when the node gets
+	// unhealthy again within the escalation memory window.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Attempts int `json:"attempts,omitempty"`
+
+	// StepAttempts is the number of consecutive attempts of the escalating remediation with Order, when it was
+	// retried, see the StepAttempt of the EscalationStart.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	StepAttempts int `json:"stepAttempts,omitempty"`
+}
+
+// RemediationOutcome is the string used for NodeRemediationRecord.Outcome
+type RemediationOutcome string
+
+const (
+	// RemediationOutcomeSucceeded is used when the node got healthy before the remediation timed out
+	RemediationOutcomeSucceeded RemediationOutcome = "Succeeded"
+
+	// RemediationOutcomeTimedOut is used when the remediation timed out, or was given up because of the
+	// MaxRemediationDuration
+	RemediationOutcomeTimedOut RemediationOutcome = "TimedOut"
+
+	// RemediationOutcomeFailed is used when the remediator reported the remediation as failed
+	RemediationOutcomeFailed RemediationOutcome = "Failed"
+)
+
+// NodeRemediationRecord is a finished remediation of a node
+type NodeRemediationRecord struct {
+	// NodeName is the name of the remediated node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NodeName string `json:"nodeName"`
+
+	// TemplateKind is the kind of the remediation template which was used
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TemplateKind string `json:"templateKind"`
+
+	// TemplateName is the name of the remediation template which was used, when using several templates of the
+	// same kind
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TemplateName string `json:"templateName,omitempty"`
+
+	// Started is the creation time of the remediation CR
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Started metav1.Time `json:"started"`
+
+	// Finished is the time when the node got healthy, or when the remediation timed out or failed
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Finished metav1.Time `json:"finished"`
+
+	// Outcome is the outcome of the remediation, one of Succeeded, TimedOut or Failed
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Outcome RemediationOutcome `json:"outcome"`
+}
+
+// RemediationPhase is the string used for Remediation.Phase
+type RemediationPhase string
+
+const (
+	// RemediationPhaseRunning is used while the remediation didn't time out
+	RemediationPhaseRunning RemediationPhase = "Running"
+
+	// RemediationPhaseAwaitingAcknowledgment is used when the remediation timed out, and NHC waits for the
+	// remediator to acknowledge the timeout before escalating
+	RemediationPhaseAwaitingAcknowledgment RemediationPhase = "AwaitingAcknowledgment"
+
+	// RemediationPhaseTimedOut is used when the remediation timed out, and the next remediation can be started
+	RemediationPhaseTimedOut RemediationPhase = "TimedOut"
+
+	// RemediationPhaseAborted is used when the remediation's template was removed from the spec, its
+	// escalating remediation was paused with AbortOnPause, or all remediations were aborted with the abort-all
+	// annotation, and the remediation CR was deleted
+	RemediationPhaseAborted RemediationPhase = "Aborted"
+
+	// RemediationPhaseSucceeded is used when the remediation CR was deleted by someone else than NHC, and the
+	// ExternalCRDeletionPolicy is TreatAsCompleted
+	RemediationPhaseSucceeded RemediationPhase = "Succeeded"
+
+	// RemediationPhaseNotApplicable is used when an escalating remediation was skipped, because the node is missing
+	// its required labels, or because it is paused. No remediation CR was created for it.
+	RemediationPhaseNotApplicable RemediationPhase = "NotApplicable"
+)
+
+const (
+	// RemediationReasonMissingNodeLabels is used for NotApplicable remediations, when the node is missing required labels
+	RemediationReasonMissingNodeLabels = "MissingNodeLabels"
+
+	// RemediationReasonStepPaused is used for NotApplicable remediations, when the escalating remediation is paused
+	RemediationReasonStepPaused = "StepPaused"
+
+	// RemediationReasonCircuitOpen is used for NotApplicable remediations, when the circuit of the
+	// RemediatorCircuitBreaker is open for the remediation's kind
+	RemediationReasonCircuitOpen = "RemediatorCircuitOpen"
+
+	// RemediationReasonRemediatorUnavailable is used for NotApplicable remediations, when the remediator of the
+	// remediation's kind isn't running, see RemediatorHealthCheck
+	RemediationReasonRemediatorUnavailable = "RemediatorUnavailable"
+
+	// RemediationReasonAbortAll is used for Aborted remediations, when all remediations were aborted with the
+	// abort-all annotation
+	RemediationReasonAbortAll = "AbortAll"
+)
+
+// Remediation defines a remediation which was created for a node
+type Remediation struct {
+	// Resource is the reference to the remediation CR which was created
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// Started is the creation time of the remediation CR
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Started metav1.Time `json:"started"`
+
+	// TimedOut is the time when the remediation timed out.
+	// Applicable for escalating remediations only.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TimedOut *metav1.Time `json:"timedOut,omitempty"`
+
+	// Phase is the phase of the remediation.
+	// Known phases are Running, AwaitingAcknowledgment, TimedOut, Aborted, Succeeded and NotApplicable.
+	// AwaitingAcknowledgment is used for timed out escalating remediations, when the escalation handshake is enabled
+	// and the remediator didn't acknowledge the timeout yet. Aborted is used when the remediation's template was
+	// removed from the spec, when its escalating remediation was paused with AbortOnPause, or when all remediations
+	// were aborted, see Reason. Succeeded is used when the remediation CR was deleted externally, and the
+	// ExternalCRDeletionPolicy is TreatAsCompleted.
+	// NotApplicable is used when an escalating remediation was skipped, see Reason.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Phase RemediationPhase `json:"phase,omitempty"`
+
+	// Timeout is the effective timeout of the remediation, either configured or computed by
+	// the EscalationTimeoutStrategy, and stretched by the TimeoutMultiplier.
+	// Applicable for escalating remediations only.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// TimeoutAt is the time when the remediation times out, which is Started plus Timeout.
+	// Applicable for escalating remediations only.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TimeoutAt *metav1.Time `json:"timeoutAt,omitempty"`
 
 	// TemplateName is required when using several templates of the same kind
 	// +optional
 	//+operator-sdk:csv:customresourcedefinitions:type=status
 	TemplateName string `json:"templateName,omitempty"`
+
+	// MissingNodeLabels are the required node labels of a NotApplicable escalating remediation, which the node
+	// is missing, formatted as "key=value".
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MissingNodeLabels []string `json:"missingNodeLabels,omitempty"`
+
+	// Reason explains why an escalating remediation is NotApplicable, or why a remediation was Aborted.
+	// Known reasons are MissingNodeLabels and StepPaused for NotApplicable remediations, and AbortAll for
+	// remediations which were aborted with the remediation.medik8s.io/abort-all annotation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason,omitempty"`
+
+	// Attempt is the number of the remediation attempt for the node, as annotated on the remediation CR.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Attempt int `json:"attempt,omitempty"`
+
+	// DeletedExternally is the time when NHC noticed that the remediation CR was deleted by someone else than NHC,
+	// while the node was still unhealthy. See ExternalCRDeletionPolicy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	DeletedExternally *metav1.Time `json:"deletedExternally,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -19,15 +19,27 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
+	"github.com/robfig/cron"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -39,13 +51,73 @@ import (
 const (
 	OngoingRemediationError   = "prohibited due to running remediation"
 	minHealthyError           = "MinHealthy must not be negative"
+	maxUnhealthyError         = "MaxUnhealthy must not be negative"
+	maxUnhealthyPercentError  = "MaxUnhealthy must be a percentage between 0% and 100%"
+	minSelectedNodesError     = "MinSelectedNodes must not be negative"
 	invalidSelectorError      = "Invalid selector"
 	missingSelectorError      = "Selector is mandatory"
-	mandatoryRemediationError = "Either RemediationTemplate or at least one EscalatingRemediations must be set"
-	mutualRemediationError    = "RemediationTemplate and EscalatingRemediations usage is mutual exclusive"
+	nodeNameError             = "NodeName must be a valid node name"
+	mandatoryRemediationError = "Either RemediationTemplate, InlineRemediationTemplate, at least one RemediationTemplates or at least one EscalatingRemediations must be set"
+	mutualRemediationError    = "RemediationTemplate, RemediationTemplates, InlineRemediationTemplate and EscalatingRemediations usage is mutual exclusive"
+	uniqueTemplatesError      = "RemediationTemplates must be unique"
+	inlineTemplateGVKError    = "InlineRemediationTemplate must have a valid apiVersion with group and version, and the kind of the remediation CR"
+	inlineTemplateSpecError   = "InlineRemediationTemplate spec must be an object"
+	inlineTemplateCRDError    = "InlineRemediationTemplate kind isn't known, ensure the remediator is installed"
 	uniqueOrderError          = "EscalatingRemediation Order must be unique"
+	templateKindError         = "EscalatingRemediation RemediationTemplate must have a kind"
 	uniqueRemediatorError     = "Using multiple templates of same kind is not supported for this template"
 	minimumTimeoutError       = "EscalatingRemediation Timeout must be at least one minute"
+	remoteClusterSecretError  = "RemoteCluster KubeconfigSecretRef must have a name and a namespace"
+	remoteClusterSAError      = "RemediationServiceAccount can't be used with RemoteCluster"
+	escalationMemoryError     = "EscalationMemory can only be used with EscalatingRemediations"
+	timeoutStrategyError      = "EscalationTimeoutStrategy can only be used with EscalatingRemediations"
+	exponentialConfigError    = "EscalationTimeoutStrategy Exponential must be set if and only if type is Exponential"
+	exponentialBaseError      = "EscalationTimeoutStrategy Exponential Base must be at least one minute"
+	exponentialMaxError       = "EscalationTimeoutStrategy Exponential Max must not be lower than Base"
+	timeoutAboveMaxError      = "EscalatingRemediation Timeout must not exceed EscalationTimeoutStrategy Exponential Max"
+	escalationDelayError      = "EscalationDelay must not exceed one hour"
+	timeoutMultiplierError    = "EscalatingRemediation TimeoutMultiplier must be a decimal number between 1 and 10"
+	maxTimeoutError           = "EscalatingRemediation MaxTimeout must be at least one minute, and must not be lower than the effective Timeout"
+	verificationTemplateError = "PostRemediationVerification VerificationTemplate must have an apiVersion, a name, and a kind with \"Template\" suffix"
+	reEscalateOnFailureError  = "PostRemediationVerification ReEscalateOnFailure can only be used with EscalatingRemediations"
+	verificationChecksError   = "PostRemediationVerification needs a VerificationTemplate, MinReadyTime or RequiredPods"
+	requiredPodsError         = "PostRemediationVerification RequiredPods must have a namespace and a valid selector"
+	requiredNodeLabelsError   = "EscalatingRemediation RequiredNodeLabels must have valid label keys and values"
+	allStepsPausedError       = "EscalatingRemediations must not all be paused, use PauseRequests for pausing the NodeHealthCheck"
+	priorityLabelError        = "PriorityLabel must be a valid label key"
+	unhealthyConditionsError  = "UnhealthyConditions must not be empty, omit it for using the default conditions"
+	taintKeyError             = "UnhealthyCondition TaintKey must be a valid taint key"
+	taintDurationError        = "UnhealthyCondition TaintDuration can only be used with TaintKey"
+	unhealthyCapacityError    = "UnhealthyCapacity must have a valid resource name and a non negative minimum quantity"
+	unhealthyTaintError       = "UnhealthyTaint must have a valid key and value, and one of the effects NoSchedule, PreferNoSchedule and NoExecute"
+	unhealthyAnnotationError  = "UnhealthyAnnotations must have valid and unique annotation keys"
+	unhealthyQuorumError      = "UnhealthyQuorum must not be negative and must not exceed the number of configured signal categories"
+	remediationThrottleError  = "RemediationThrottle MaxConcurrent and MaxPerWindow must not be negative, and Window must be positive"
+	targetPlatformError       = "TargetArchitectures and TargetOperatingSystems must be valid label values"
+	stabilizationError        = "AdaptiveStabilization Multiplier must be at least 1, and Window and MaxDuration must be positive"
+	alertmanagerURLError      = "AlertSilencing AlertmanagerURL must be an absolute http or https URL"
+	silenceMatcherError       = "AlertSilencing Matchers must have valid templates, which can only use {{.NodeName}}"
+	silenceDurationError      = "AlertSilencing Duration must be positive"
+	pollingIntervalError      = "PollingInterval must be positive"
+	remediationWindowError    = "RemediationWindows must have Start and End cron expressions in the standard 5 field format, and a known TimeZone"
+
+	duplicateTemplateWarning = "EscalatingRemediations reference the same template several times, which repeats the same remediation"
+	minSelectedNodesWarning  = "MinSelectedNodes exceeds the number of nodes which are currently selected, remediation is withheld until more nodes are selected"
+	targetPlatformWarning    = "unknown value, ensure that nodes have a matching label"
+
+	defaultUnhealthyConditionDuration = 300 * time.Second
+	maxEscalationDelay                = time.Hour
+)
+
+// DefaultMinHealthy is the MinHealthy which is used when it isn't set
+const DefaultMinHealthy = "51%"
+
+var (
+	// knownArchitectures are the values of the kubernetes.io/arch node label of the architectures supported by Kubernetes
+	knownArchitectures = sets.New[string]("amd64", "arm64", "arm", "ppc64le", "s390x", "386", "riscv64")
+	// knownOperatingSystems are the values of the kubernetes.io/os node label of the operating systems supported by
+	// Kubernetes
+	knownOperatingSystems = sets.New[string]("linux", "windows")
 )
 
 // log is for logging in this package.
@@ -54,10 +126,45 @@ var nodehealthchecklog = logf.Log.WithName("nodehealthcheck-resource")
 func (nhc *NodeHealthCheck) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(nhc).
+		WithDefaulter(&customDefaulter{}).
 		WithValidator(&customValidator{mgr.GetClient()}).
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-remediation-medik8s-io-v1alpha1-nodehealthcheck,mutating=true,failurePolicy=fail,sideEffects=None,groups=remediation.medik8s.io,resources=nodehealthchecks,verbs=create;update,versions=v1alpha1,name=mnodehealthcheck.kb.io,admissionReviewVersions=v1
+
+type customDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type. It sets the default
+// UnhealthyConditions and MinHealthy, instead of relying on CRD defaulting.
+func (d *customDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	nhc := obj.(*NodeHealthCheck)
+	nodehealthchecklog.Info("default", "name", nhc.Name)
+	// the patch of the defaulted object would drop an explicitly empty list, so reject it here already
+	if nhc.Spec.UnhealthyConditions != nil && len(nhc.Spec.UnhealthyConditions) == 0 {
+		return fmt.Errorf(unhealthyConditionsError)
+	}
+	if nhc.Spec.UnhealthyConditions == nil {
+		nhc.Spec.UnhealthyConditions = []UnhealthyCondition{
+			{
+				Type:     corev1.NodeReady,
+				Status:   corev1.ConditionFalse,
+				Duration: metav1.Duration{Duration: defaultUnhealthyConditionDuration},
+			},
+			{
+				Type:     corev1.NodeReady,
+				Status:   corev1.ConditionUnknown,
+				Duration: metav1.Duration{Duration: defaultUnhealthyConditionDuration},
+			},
+		}
+	}
+	if nhc.Spec.MinHealthy == nil {
+		minHealthy := intstr.FromString(DefaultMinHealthy)
+		nhc.Spec.MinHealthy = &minHealthy
+	}
+	return nil
+}
+
 //+kubebuilder:webhook:path=/validate-remediation-medik8s-io-v1alpha1-nodehealthcheck,mutating=false,failurePolicy=fail,sideEffects=None,groups=remediation.medik8s.io,resources=nodehealthchecks,verbs=create;update;delete,versions=v1alpha1,name=vnodehealthcheck.kb.io,admissionReviewVersions=v1
 
 type customValidator struct {
@@ -68,7 +175,7 @@ type customValidator struct {
 func (v *customValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
 	nhc := obj.(*NodeHealthCheck)
 	nodehealthchecklog.Info("validate create", "name", nhc.Name)
-	return admission.Warnings{}, v.validate(ctx, nhc)
+	return v.warnings(ctx, nhc), v.validate(ctx, nhc)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -77,17 +184,18 @@ func (v *customValidator) ValidateUpdate(ctx context.Context, old runtime.Object
 	nodehealthchecklog.Info("validate update", "name", nhc.Name)
 
 	// do the normal validation
+	warnings = v.warnings(ctx, nhc)
 	if err := v.validate(ctx, nhc); err != nil {
-		return admission.Warnings{}, err
+		return warnings, err
 	}
 
 	// during ongoing remediations, some updates are forbidden
 	if nhc.isRemediating() {
 		if updated, field := nhc.isRestrictedFieldUpdated(old.(*NodeHealthCheck)); updated {
-			return admission.Warnings{}, fmt.Errorf("%s update %s", field, OngoingRemediationError)
+			return warnings, fmt.Errorf("%s update %s", field, OngoingRemediationError)
 		}
 	}
-	return admission.Warnings{}, nil
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -103,9 +211,30 @@ func (v *customValidator) ValidateDelete(_ context.Context, obj runtime.Object)
 func (v *customValidator) validate(ctx context.Context, nhc *NodeHealthCheck) error {
 	aggregated := errors.NewAggregate([]error{
 		v.validateMinHealthy(nhc),
+		v.validateMaxUnhealthy(nhc),
+		v.validateMinSelectedNodes(nhc),
 		v.validateSelector(nhc),
 		v.validateMutualRemediations(nhc),
+		v.validateInlineRemediationTemplate(nhc),
+		v.validateRemediationTemplates(ctx, nhc),
 		v.validateEscalatingRemediations(ctx, nhc),
+		v.validateEscalationMemory(nhc),
+		v.validateEscalationTimeoutStrategy(nhc),
+		v.validateEscalationDelay(nhc),
+		v.validateRemoteCluster(nhc),
+		v.validatePostRemediationVerification(nhc),
+		v.validatePriorityLabel(nhc),
+		v.validateUnhealthyConditions(nhc),
+		v.validateUnhealthyCapacity(nhc),
+		v.validateUnhealthyTaints(nhc),
+		v.validateUnhealthyAnnotations(nhc),
+		v.validateUnhealthyQuorum(nhc),
+		v.validateRemediationThrottle(nhc),
+		v.validateTargetPlatform(nhc),
+		v.validateAdaptiveStabilization(nhc),
+		v.validateAlertSilencing(nhc),
+		v.validatePollingInterval(nhc),
+		v.validateRemediationWindows(nhc),
 	})
 
 	// everything else should have been covered by API server validation
@@ -114,10 +243,20 @@ func (v *customValidator) validate(ctx context.Context, nhc *NodeHealthCheck) er
 	return aggregated
 }
 
+// warnings returns warnings about configurations which are valid, but are most probably mistakes
+func (v *customValidator) warnings(ctx context.Context, nhc *NodeHealthCheck) admission.Warnings {
+	warnings := admission.Warnings{}
+	warnings = append(warnings, v.warnEscalatingRemediationsDuplicateTemplates(nhc)...)
+	warnings = append(warnings, v.warnMinSelectedNodes(ctx, nhc)...)
+	warnings = append(warnings, v.warnTargetPlatform(nhc)...)
+	return warnings
+}
+
 func (v *customValidator) validateMinHealthy(nhc *NodeHealthCheck) error {
 	// Using Minimum kubebuilder marker for IntOrStr does not work (yet)
+	// nil is defaulted by the mutating webhook
 	if nhc.Spec.MinHealthy == nil {
-		return fmt.Errorf("MinHealthy must not be empty")
+		return nil
 	}
 	if nhc.Spec.MinHealthy.Type == intstr.Int && nhc.Spec.MinHealthy.IntVal < 0 {
 		return fmt.Errorf("%s: %v", minHealthyError, nhc.Spec.MinHealthy)
@@ -125,8 +264,77 @@ func (v *customValidator) validateMinHealthy(nhc *NodeHealthCheck) error {
 	return nil
 }
 
+func (v *customValidator) validateMaxUnhealthy(nhc *NodeHealthCheck) error {
+	// Using Minimum kubebuilder marker for IntOrStr does not work (yet)
+	if nhc.Spec.MaxUnhealthy == nil {
+		return nil
+	}
+	if nhc.Spec.MaxUnhealthy.Type == intstr.Int {
+		if nhc.Spec.MaxUnhealthy.IntVal < 0 {
+			return fmt.Errorf("%s: %v", maxUnhealthyError, nhc.Spec.MaxUnhealthy)
+		}
+		return nil
+	}
+	// scaling to 100 returns the percentage itself
+	if percent, err := intstr.GetScaledValueFromIntOrPercent(nhc.Spec.MaxUnhealthy, 100, false); err != nil || percent < 0 || percent > 100 {
+		return fmt.Errorf("%s: %v", maxUnhealthyPercentError, nhc.Spec.MaxUnhealthy)
+	}
+	return nil
+}
+
+func (v *customValidator) validateMinSelectedNodes(nhc *NodeHealthCheck) error {
+	if nhc.Spec.MinSelectedNodes < 0 {
+		return fmt.Errorf("%s: %d", minSelectedNodesError, nhc.Spec.MinSelectedNodes)
+	}
+	return nil
+}
+
+// warnMinSelectedNodes warns when the selector currently selects fewer nodes than MinSelectedNodes. Nodes of remote
+// clusters can't be looked up, and lookup errors are ignored, because this is a warning only.
+func (v *customValidator) warnMinSelectedNodes(ctx context.Context, nhc *NodeHealthCheck) admission.Warnings {
+	if nhc.Spec.MinSelectedNodes <= 0 || nhc.Spec.RemoteCluster != nil {
+		return nil
+	}
+	listOptions := &client.ListOptions{}
+	if nhc.Spec.NodeName == "" {
+		selector, err := nhc.Spec.GetNodeSelector()
+		if err != nil {
+			// reported by validation
+			return nil
+		}
+		listOptions.LabelSelector = selector
+	}
+	nodes := &corev1.NodeList{}
+	if err := v.Client.List(ctx, nodes, listOptions); err != nil {
+		nodehealthchecklog.Error(err, "failed to list nodes for checking MinSelectedNodes", "name", nhc.Name)
+		return nil
+	}
+	selected := len(nodes.Items)
+	if nhc.Spec.NodeName != "" {
+		selected = 0
+		for _, node := range nodes.Items {
+			if node.GetName() == nhc.Spec.NodeName && nhc.Spec.MatchesTargetPlatform(node.GetLabels()) {
+				selected++
+			}
+		}
+	}
+	if selected < nhc.Spec.MinSelectedNodes {
+		return admission.Warnings{fmt.Sprintf("%s: %d nodes are selected, MinSelectedNodes is %d", minSelectedNodesWarning, selected, nhc.Spec.MinSelectedNodes)}
+	}
+	return nil
+}
+
 func (v *customValidator) validateSelector(nhc *NodeHealthCheck) error {
+	if nhc.Spec.NodeName != "" {
+		if errs := validation.IsDNS1123Subdomain(nhc.Spec.NodeName); len(errs) > 0 {
+			return fmt.Errorf("%s: %s", nodeNameError, strings.Join(errs, ", "))
+		}
+	}
+	// the selector isn't used when a node name is set, but still needs to be valid
 	if len(nhc.Spec.Selector.MatchExpressions) == 0 && len(nhc.Spec.Selector.MatchLabels) == 0 {
+		if nhc.Spec.NodeName != "" {
+			return nil
+		}
 		return fmt.Errorf(missingSelectorError)
 	}
 	if _, err := metav1.LabelSelectorAsSelector(&nhc.Spec.Selector); err != nil {
@@ -136,15 +344,80 @@ func (v *customValidator) validateSelector(nhc *NodeHealthCheck) error {
 }
 
 func (v *customValidator) validateMutualRemediations(nhc *NodeHealthCheck) error {
-	if nhc.Spec.RemediationTemplate == nil && len(nhc.Spec.EscalatingRemediations) == 0 {
+	configured := 0
+	if nhc.Spec.RemediationTemplate != nil {
+		configured++
+	}
+	if len(nhc.Spec.RemediationTemplates) > 0 {
+		configured++
+	}
+	if nhc.Spec.InlineRemediationTemplate != nil {
+		configured++
+	}
+	if len(nhc.Spec.EscalatingRemediations) > 0 {
+		configured++
+	}
+	if configured == 0 {
 		return fmt.Errorf(mandatoryRemediationError)
 	}
-	if nhc.Spec.RemediationTemplate != nil && len(nhc.Spec.EscalatingRemediations) > 0 {
+	if configured > 1 {
 		return fmt.Errorf(mutualRemediationError)
 	}
 	return nil
 }
 
+func (v *customValidator) validateInlineRemediationTemplate(nhc *NodeHealthCheck) error {
+	inline := nhc.Spec.InlineRemediationTemplate
+	if inline == nil {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(inline.APIVersion)
+	if err != nil || gv.Group == "" || gv.Version == "" || inline.Kind == "" || strings.HasSuffix(inline.Kind, "Template") {
+		return fmt.Errorf("%s: found apiVersion %q and kind %q", inlineTemplateGVKError, inline.APIVersion, inline.Kind)
+	}
+	if len(inline.Spec.Raw) > 0 {
+		spec := make(map[string]interface{})
+		if err := json.Unmarshal(inline.Spec.Raw, &spec); err != nil {
+			return fmt.Errorf("%s: %v", inlineTemplateSpecError, err)
+		}
+	}
+
+	if annotations.HasSkipInlineTemplateValidationAnnotation(nhc) {
+		return nil
+	}
+	if _, err := v.Client.RESTMapper().RESTMapping(schema.GroupKind{Group: gv.Group, Kind: inline.Kind}, gv.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("%s: %v", inlineTemplateCRDError, err)
+		}
+		// don't block on unexpected errors, the controller will disable the NHC if the kind doesn't exist
+		nodehealthchecklog.Error(err, "failed to check inline remediation template kind", "kind", inline.Kind)
+	}
+	return nil
+}
+
+func (v *customValidator) validateRemediationTemplates(ctx context.Context, nhc *NodeHealthCheck) error {
+	templates := make(map[corev1.ObjectReference]struct{}, len(nhc.Spec.RemediationTemplates))
+	remediators := make(map[string]struct{}, len(nhc.Spec.RemediationTemplates))
+	for _, ref := range nhc.Spec.RemediationTemplates {
+		template := corev1.ObjectReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+		}
+		if _, exists := templates[template]; exists {
+			return fmt.Errorf("%s: %s %s/%s is listed several times", uniqueTemplatesError, template.Kind, template.Namespace, template.Name)
+		}
+		templates[template] = struct{}{}
+		// remediation CRs of the same kind need different names, like with escalating remediations
+		if _, exists := remediators[ref.Kind]; exists && !v.isMultipleTemplatesSupported(ctx, ref) {
+			return fmt.Errorf("%s: duplicate template kind: %v", uniqueRemediatorError, ref.Kind)
+		}
+		remediators[ref.Kind] = struct{}{}
+	}
+	return nil
+}
+
 func (v *customValidator) validateEscalatingRemediations(ctx context.Context, nhc *NodeHealthCheck) error {
 	if nhc.Spec.EscalatingRemediations == nil {
 		return nil
@@ -152,8 +425,12 @@ func (v *customValidator) validateEscalatingRemediations(ctx context.Context, nh
 
 	aggregated := errors.NewAggregate([]error{
 		v.validateEscalatingRemediationsUniqueOrder(nhc),
+		v.validateEscalatingRemediationsTemplateKind(nhc),
 		v.validateEscalatingRemediationsTimeout(nhc),
+		v.validateEscalatingRemediationsTimeoutMultiplier(nhc),
 		v.validateEscalatingRemediationsUniqueRemediator(ctx, nhc),
+		v.validateEscalatingRemediationsRequiredNodeLabels(nhc),
+		v.validateEscalatingRemediationsPauseReason(nhc),
 	})
 	return aggregated
 }
@@ -169,15 +446,76 @@ func (v *customValidator) validateEscalatingRemediationsUniqueOrder(nhc *NodeHea
 	return nil
 }
 
+func (v *customValidator) validateEscalatingRemediationsTemplateKind(nhc *NodeHealthCheck) error {
+	for _, rem := range nhc.Spec.EscalatingRemediations {
+		if rem.RemediationTemplate.Kind == "" {
+			return fmt.Errorf("%s: found template %q of order %d without kind", templateKindError, rem.RemediationTemplate.Name, rem.Order)
+		}
+	}
+	return nil
+}
+
 func (v *customValidator) validateEscalatingRemediationsTimeout(nhc *NodeHealthCheck) error {
+	exponential := nhc.Spec.EscalationTimeoutStrategy.getExponential()
 	for _, rem := range nhc.Spec.EscalatingRemediations {
+		if rem.Timeout.Duration == 0 && exponential != nil {
+			// computed by strategy
+			continue
+		}
 		if rem.Timeout.Duration < 1*time.Minute {
 			return fmt.Errorf("%s: found timeout %v", minimumTimeoutError, rem.Timeout)
 		}
+		if exponential != nil && exponential.Max != nil && rem.Timeout.Duration > exponential.Max.Duration {
+			return fmt.Errorf("%s: found timeout %v, max is %v", timeoutAboveMaxError, rem.Timeout, exponential.Max)
+		}
+	}
+	return nil
+}
+
+func (v *customValidator) validateEscalatingRemediationsTimeoutMultiplier(nhc *NodeHealthCheck) error {
+	for _, rem := range nhc.Spec.EscalatingRemediations {
+		if rem.TimeoutMultiplier != "" {
+			if multiplier, err := strconv.ParseFloat(rem.TimeoutMultiplier, 64); err != nil || multiplier < 1 || multiplier > 10 {
+				return fmt.Errorf("%s: found multiplier %q of order %d", timeoutMultiplierError, rem.TimeoutMultiplier, rem.Order)
+			}
+		}
+		if rem.MaxTimeout == nil {
+			continue
+		}
+		// compare with the effective timeout, which is computed by the Exponential strategy when no timeout is set
+		if timeout := nhc.Spec.GetEscalatingRemediationTimeout(rem); rem.MaxTimeout.Duration < 1*time.Minute || rem.MaxTimeout.Duration < timeout {
+			return fmt.Errorf("%s: found max timeout %v and timeout %v of order %d", maxTimeoutError, rem.MaxTimeout.Duration, timeout, rem.Order)
+		}
+	}
+	return nil
+}
+
+func (v *customValidator) validateEscalatingRemediationsRequiredNodeLabels(nhc *NodeHealthCheck) error {
+	for _, rem := range nhc.Spec.EscalatingRemediations {
+		for key, value := range rem.RequiredNodeLabels {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("%s: invalid key %q: %s", requiredNodeLabelsError, key, strings.Join(errs, "; "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("%s: invalid value %q of key %q: %s", requiredNodeLabelsError, value, key, strings.Join(errs, "; "))
+			}
+		}
 	}
 	return nil
 }
 
+func (v *customValidator) validateEscalatingRemediationsPauseReason(nhc *NodeHealthCheck) error {
+	if len(nhc.Spec.EscalatingRemediations) == 0 {
+		return nil
+	}
+	for _, rem := range nhc.Spec.EscalatingRemediations {
+		if rem.PauseReason == "" {
+			return nil
+		}
+	}
+	return fmt.Errorf(allStepsPausedError)
+}
+
 func (v *customValidator) validateEscalatingRemediationsUniqueRemediator(ctx context.Context, nhc *NodeHealthCheck) error {
 	remediators := make(map[string]struct{}, len(nhc.Spec.EscalatingRemediations))
 	for _, rem := range nhc.Spec.EscalatingRemediations {
@@ -190,6 +528,287 @@ func (v *customValidator) validateEscalatingRemediationsUniqueRemediator(ctx con
 	return nil
 }
 
+func (v *customValidator) warnEscalatingRemediationsDuplicateTemplates(nhc *NodeHealthCheck) admission.Warnings {
+	var warnings admission.Warnings
+	orders := make(map[corev1.ObjectReference]int, len(nhc.Spec.EscalatingRemediations))
+	for _, rem := range nhc.Spec.EscalatingRemediations {
+		template := corev1.ObjectReference{
+			APIVersion: rem.RemediationTemplate.APIVersion,
+			Kind:       rem.RemediationTemplate.Kind,
+			Namespace:  rem.RemediationTemplate.Namespace,
+			Name:       rem.RemediationTemplate.Name,
+		}
+		if order, exists := orders[template]; exists {
+			warnings = append(warnings, fmt.Sprintf("%s: %s %s/%s is used by orders %d and %d", duplicateTemplateWarning, template.Kind, template.Namespace, template.Name, order, rem.Order))
+			continue
+		}
+		orders[template] = rem.Order
+	}
+	return warnings
+}
+
+func (v *customValidator) validateEscalationMemory(nhc *NodeHealthCheck) error {
+	if nhc.Spec.EscalationMemory != nil && len(nhc.Spec.EscalatingRemediations) == 0 {
+		return fmt.Errorf(escalationMemoryError)
+	}
+	return nil
+}
+
+func (v *customValidator) validateEscalationTimeoutStrategy(nhc *NodeHealthCheck) error {
+	strategy := nhc.Spec.EscalationTimeoutStrategy
+	if strategy == nil {
+		return nil
+	}
+	if len(nhc.Spec.EscalatingRemediations) == 0 {
+		return fmt.Errorf(timeoutStrategyError)
+	}
+	if (strategy.Type == EscalationTimeoutStrategyExponential) != (strategy.Exponential != nil) {
+		return fmt.Errorf(exponentialConfigError)
+	}
+	if exponential := strategy.getExponential(); exponential != nil {
+		if exponential.Base.Duration < 1*time.Minute {
+			return fmt.Errorf("%s: found base %v", exponentialBaseError, exponential.Base)
+		}
+		if exponential.Max != nil && exponential.Max.Duration < exponential.Base.Duration {
+			return fmt.Errorf("%s: found base %v and max %v", exponentialMaxError, exponential.Base, exponential.Max)
+		}
+	}
+	return nil
+}
+
+func (v *customValidator) validateEscalationDelay(nhc *NodeHealthCheck) error {
+	if nhc.Spec.EscalationDelay != nil && nhc.Spec.EscalationDelay.Duration > maxEscalationDelay {
+		return fmt.Errorf("%s: found delay %v", escalationDelayError, nhc.Spec.EscalationDelay.Duration)
+	}
+	return nil
+}
+
+// getExponential returns the Exponential configuration if the Exponential strategy is used, else nil
+func (s *EscalationTimeoutStrategy) getExponential() *ExponentialTimeout {
+	if s == nil || s.Type != EscalationTimeoutStrategyExponential {
+		return nil
+	}
+	return s.Exponential
+}
+
+func (v *customValidator) validateRemoteCluster(nhc *NodeHealthCheck) error {
+	if nhc.Spec.RemoteCluster == nil {
+		return nil
+	}
+	if secretRef := nhc.Spec.RemoteCluster.KubeconfigSecretRef; secretRef.Name == "" || secretRef.Namespace == "" {
+		return fmt.Errorf(remoteClusterSecretError)
+	}
+	if nhc.Spec.RemediationServiceAccount != nil {
+		return fmt.Errorf(remoteClusterSAError)
+	}
+	return nil
+}
+
+func (v *customValidator) validatePostRemediationVerification(nhc *NodeHealthCheck) error {
+	verification := nhc.Spec.PostRemediationVerification
+	if verification == nil {
+		return nil
+	}
+	templateRef := verification.VerificationTemplate
+	if templateRef == (corev1.ObjectReference{}) {
+		if verification.MinReadyTime == nil && len(verification.RequiredPods) == 0 {
+			return fmt.Errorf(verificationChecksError)
+		}
+	} else if templateRef.APIVersion == "" || templateRef.Name == "" || !strings.HasSuffix(templateRef.Kind, "Template") || templateRef.Kind == "Template" {
+		return fmt.Errorf(verificationTemplateError)
+	}
+	for _, requiredPods := range verification.RequiredPods {
+		if requiredPods.Namespace == "" {
+			return fmt.Errorf(requiredPodsError)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&requiredPods.Selector); err != nil {
+			return fmt.Errorf("%s: %v", requiredPodsError, err)
+		}
+	}
+	if verification.ReEscalateOnFailure && len(nhc.Spec.EscalatingRemediations) == 0 {
+		return fmt.Errorf(reEscalateOnFailureError)
+	}
+	return nil
+}
+
+func (v *customValidator) validatePriorityLabel(nhc *NodeHealthCheck) error {
+	if nhc.Spec.PriorityLabel == "" {
+		return nil
+	}
+	if errs := validation.IsQualifiedName(nhc.Spec.PriorityLabel); len(errs) > 0 {
+		return fmt.Errorf("%s: invalid key %q: %s", priorityLabelError, nhc.Spec.PriorityLabel, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (v *customValidator) validateTargetPlatform(nhc *NodeHealthCheck) error {
+	for _, value := range append(append([]string{}, nhc.Spec.TargetArchitectures...), nhc.Spec.TargetOperatingSystems...) {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 || value == "" {
+			return fmt.Errorf("%s: invalid value %q: %s", targetPlatformError, value, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// warnTargetPlatform warns about target architectures and operating systems, which are not known to be used by
+// Kubernetes nodes. They are still used, in order to support custom values.
+func (v *customValidator) warnTargetPlatform(nhc *NodeHealthCheck) admission.Warnings {
+	var warnings admission.Warnings
+	for _, arch := range nhc.Spec.TargetArchitectures {
+		if !knownArchitectures.Has(arch) {
+			warnings = append(warnings, fmt.Sprintf("TargetArchitectures %q: %s", arch, targetPlatformWarning))
+		}
+	}
+	for _, os := range nhc.Spec.TargetOperatingSystems {
+		if !knownOperatingSystems.Has(os) {
+			warnings = append(warnings, fmt.Sprintf("TargetOperatingSystems %q: %s", os, targetPlatformWarning))
+		}
+	}
+	return warnings
+}
+
+func (v *customValidator) validateUnhealthyConditions(nhc *NodeHealthCheck) error {
+	// nil gets the default conditions, but an explicitly empty list would never match any node
+	if nhc.Spec.UnhealthyConditions != nil && len(nhc.Spec.UnhealthyConditions) == 0 {
+		return fmt.Errorf(unhealthyConditionsError)
+	}
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		if c.TaintKey == "" {
+			if c.TaintDuration != nil {
+				return fmt.Errorf("%s: found taint duration %v for condition type %q status %q", taintDurationError, c.TaintDuration, c.Type, c.Status)
+			}
+			continue
+		}
+		if errs := validation.IsQualifiedName(c.TaintKey); len(errs) > 0 {
+			return fmt.Errorf("%s: invalid key %q: %s", taintKeyError, c.TaintKey, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+func (v *customValidator) validateUnhealthyCapacity(nhc *NodeHealthCheck) error {
+	for _, c := range nhc.Spec.UnhealthyCapacity {
+		if errs := validation.IsQualifiedName(string(c.ResourceName)); len(errs) > 0 {
+			return fmt.Errorf("%s: invalid resource name %q: %s", unhealthyCapacityError, c.ResourceName, strings.Join(errs, "; "))
+		}
+		if c.MinQuantity.Sign() < 0 {
+			return fmt.Errorf("%s: found minimum quantity %s for resource %q", unhealthyCapacityError, c.MinQuantity.String(), c.ResourceName)
+		}
+	}
+	return nil
+}
+
+func (v *customValidator) validateUnhealthyTaints(nhc *NodeHealthCheck) error {
+	for _, t := range nhc.Spec.UnhealthyTaints {
+		if t.Key == "" {
+			return fmt.Errorf("%s: found empty key", unhealthyTaintError)
+		}
+		if errs := validation.IsQualifiedName(t.Key); len(errs) > 0 {
+			return fmt.Errorf("%s: invalid key %q: %s", unhealthyTaintError, t.Key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(t.Value); len(errs) > 0 {
+			return fmt.Errorf("%s: invalid value %q for key %q: %s", unhealthyTaintError, t.Value, t.Key, strings.Join(errs, "; "))
+		}
+		switch t.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("%s: invalid effect %q for key %q", unhealthyTaintError, t.Effect, t.Key)
+		}
+	}
+	return nil
+}
+
+func (v *customValidator) validateUnhealthyAnnotations(nhc *NodeHealthCheck) error {
+	keys := sets.New[string]()
+	for _, a := range nhc.Spec.UnhealthyAnnotations {
+		if a.Key == "" {
+			return fmt.Errorf("%s: found empty key", unhealthyAnnotationError)
+		}
+		if errs := validation.IsQualifiedName(a.Key); len(errs) > 0 {
+			return fmt.Errorf("%s: invalid key %q: %s", unhealthyAnnotationError, a.Key, strings.Join(errs, "; "))
+		}
+		if keys.Has(a.Key) {
+			return fmt.Errorf("%s: found duplicate key %q", unhealthyAnnotationError, a.Key)
+		}
+		keys.Insert(a.Key)
+	}
+	return nil
+}
+
+func (v *customValidator) validateUnhealthyQuorum(nhc *NodeHealthCheck) error {
+	signals := nhc.Spec.GetUnhealthySignals()
+	if nhc.Spec.UnhealthyQuorum < 0 || nhc.Spec.UnhealthyQuorum > len(signals) {
+		return fmt.Errorf("%s: quorum %d, configured signals %v", unhealthyQuorumError, nhc.Spec.UnhealthyQuorum, signals)
+	}
+	return nil
+}
+
+func (v *customValidator) validateRemediationThrottle(nhc *NodeHealthCheck) error {
+	throttle := nhc.Spec.RemediationThrottle
+	if throttle == nil {
+		return nil
+	}
+	if throttle.MaxConcurrent < 0 || throttle.MaxPerWindow < 0 || throttle.Window.Duration <= 0 {
+		return fmt.Errorf("%s: found maxConcurrent %d, maxPerWindow %d, window %s", remediationThrottleError, throttle.MaxConcurrent, throttle.MaxPerWindow, throttle.Window.Duration)
+	}
+	return nil
+}
+
+func (v *customValidator) validateAdaptiveStabilization(nhc *NodeHealthCheck) error {
+	stabilization := nhc.Spec.AdaptiveStabilization
+	if stabilization == nil {
+		return nil
+	}
+	if stabilization.Multiplier < 1 || stabilization.Window.Duration <= 0 || (stabilization.MaxDuration != nil && stabilization.MaxDuration.Duration <= 0) {
+		return fmt.Errorf("%s: found multiplier %d, window %s, maxDuration %v", stabilizationError, stabilization.Multiplier, stabilization.Window.Duration, stabilization.MaxDuration)
+	}
+	return nil
+}
+
+func (v *customValidator) validateAlertSilencing(nhc *NodeHealthCheck) error {
+	silencing := nhc.Spec.AlertSilencing
+	if silencing == nil {
+		return nil
+	}
+	if u, err := url.Parse(silencing.AlertmanagerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s: found %q", alertmanagerURLError, silencing.AlertmanagerURL)
+	}
+	for _, matcher := range silencing.Matchers {
+		tmpl, err := template.New(matcher.Name).Option("missingkey=error").Parse(matcher.Value)
+		if err == nil {
+			err = tmpl.Execute(io.Discard, struct{ NodeName string }{NodeName: "node"})
+		}
+		if err != nil {
+			return fmt.Errorf("%s: invalid value %q of label %q: %v", silenceMatcherError, matcher.Value, matcher.Name, err)
+		}
+	}
+	if silencing.Duration != nil && silencing.Duration.Duration <= 0 {
+		return fmt.Errorf("%s: found %v", silenceDurationError, silencing.Duration.Duration)
+	}
+	return nil
+}
+
+func (v *customValidator) validatePollingInterval(nhc *NodeHealthCheck) error {
+	if interval := nhc.Spec.PollingInterval; interval != nil && interval.Duration <= 0 {
+		return fmt.Errorf("%s: found %v", pollingIntervalError, interval.Duration)
+	}
+	return nil
+}
+
+func (v *customValidator) validateRemediationWindows(nhc *NodeHealthCheck) error {
+	for _, window := range nhc.Spec.RemediationWindows {
+		for _, spec := range []string{window.Start, window.End} {
+			if _, err := cron.ParseStandard(spec); err != nil {
+				return fmt.Errorf("%s: invalid cron expression %q: %v", remediationWindowError, spec, err)
+			}
+		}
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			return fmt.Errorf("%s: invalid time zone %q: %v", remediationWindowError, window.TimeZone, err)
+		}
+	}
+	return nil
+}
+
 func (v *customValidator) isMultipleTemplatesSupported(ctx context.Context, nhcExpectedTemplate corev1.ObjectReference) bool {
 	templateCRBase := &unstructured.Unstructured{}
 	templateCRBase.SetGroupVersionKind(nhcExpectedTemplate.GroupVersionKind())
@@ -214,15 +833,50 @@ func (nhc *NodeHealthCheck) isRestrictedFieldUpdated(old *NodeHealthCheck) (bool
 	if !reflect.DeepEqual(nhc.Spec.Selector, old.Spec.Selector) {
 		return true, "selector"
 	}
+	if nhc.Spec.NodeName != old.Spec.NodeName {
+		return true, "node name"
+	}
+	if !reflect.DeepEqual(nhc.Spec.TargetArchitectures, old.Spec.TargetArchitectures) ||
+		!reflect.DeepEqual(nhc.Spec.TargetOperatingSystems, old.Spec.TargetOperatingSystems) {
+		return true, "target platforms"
+	}
 	if !reflect.DeepEqual(nhc.Spec.RemediationTemplate, old.Spec.RemediationTemplate) {
 		return true, "remediation template"
 	}
-	if !reflect.DeepEqual(nhc.Spec.EscalatingRemediations, old.Spec.EscalatingRemediations) {
+	if !reflect.DeepEqual(nhc.Spec.RemediationTemplates, old.Spec.RemediationTemplates) {
+		return true, "remediation templates"
+	}
+	if !reflect.DeepEqual(nhc.Spec.InlineRemediationTemplate, old.Spec.InlineRemediationTemplate) {
+		return true, "inline remediation template"
+	}
+	// pausing escalating remediations is allowed during ongoing remediations
+	if !reflect.DeepEqual(withoutPause(nhc.Spec.EscalatingRemediations), withoutPause(old.Spec.EscalatingRemediations)) {
 		return true, "escalating remediations"
 	}
+	if !reflect.DeepEqual(nhc.Spec.RemoteCluster, old.Spec.RemoteCluster) {
+		return true, "remote cluster"
+	}
+	// leaving dry run mode would escalate the held back remediations right away, entering it is allowed
+	if old.Spec.DryRun && !nhc.Spec.DryRun {
+		return true, "dry run"
+	}
 	return false, ""
 }
 
+// withoutPause returns a copy of the given escalating remediations without their pause configuration
+func withoutPause(remediations []EscalatingRemediation) []EscalatingRemediation {
+	if remediations == nil {
+		return nil
+	}
+	copied := make([]EscalatingRemediation, len(remediations))
+	for i := range remediations {
+		remediations[i].DeepCopyInto(&copied[i])
+		copied[i].PauseReason = ""
+		copied[i].AbortOnPause = false
+	}
+	return copied
+}
+
 func (nhc *NodeHealthCheck) isRemediating() bool {
 	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
 		if len(unhealthyNode.Remediations) > 0 {
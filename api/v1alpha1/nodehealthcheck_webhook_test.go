@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -9,17 +10,27 @@ import (
 	"github.com/onsi/gomega/types"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
 )
 
 var _ = Describe("NodeHealthCheck Validation", func() {
+	inlineGV := schema.GroupVersion{Group: "test.medik8s.io", Version: "v1alpha1"}
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{inlineGV})
+	restMapper.Add(inlineGV.WithKind("InfrastructureRemediation"), meta.RESTScopeNamespace)
 	var mockValidatorClient = &mockClient{
-		listFunc: func(context.Context, client.ObjectList, ...client.ListOption) error { return nil },
+		listFunc:   func(context.Context, client.ObjectList, ...client.ListOption) error { return nil },
+		restMapper: restMapper,
 	}
 	var validator = &customValidator{mockValidatorClient}
 	Context("Creating or updating a NHC", func() {
@@ -52,81 +63,931 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 			}
 		})
 
-		Context("with valid config and remediation template", func() {
-			It("should be allowed", func() {
+		Context("with valid config and remediation template", func() {
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+		})
+
+		Context("with valid config and escalating remediations", func() {
+			BeforeEach(func() {
+				setEscalatingRemediations(nhc)
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+		})
+
+		Context("with negative minHealthy", func() {
+			BeforeEach(func() {
+				mh := intstr.FromInt(-1)
+				nhc.Spec.MinHealthy = &mh
+			})
+
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(minHealthyError)))
+			})
+		})
+
+		Context("with negative maxUnhealthy", func() {
+			BeforeEach(func() {
+				mu := intstr.FromInt(-1)
+				nhc.Spec.MaxUnhealthy = &mu
+			})
+
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(maxUnhealthyError)))
+			})
+		})
+
+		Context("with percentage maxUnhealthy", func() {
+			BeforeEach(func() {
+				mu := intstr.FromString("20%")
+				nhc.Spec.MaxUnhealthy = &mu
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+		})
+
+		Context("with maxUnhealthy percentage above 100%", func() {
+			BeforeEach(func() {
+				mu := intstr.FromString("150%")
+				nhc.Spec.MaxUnhealthy = &mu
+			})
+
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(maxUnhealthyPercentError)))
+			})
+		})
+
+		Context("with negative percentage maxUnhealthy", func() {
+			BeforeEach(func() {
+				mu := intstr.FromString("-10%")
+				nhc.Spec.MaxUnhealthy = &mu
+			})
+
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(maxUnhealthyPercentError)))
+			})
+		})
+
+		Context("with invalid selector", func() {
+			BeforeEach(func() {
+				selector := metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key: "node-role.kubernetes.io/control-plane",
+							// LabelSelectorOpIn needs a value
+							Operator: metav1.LabelSelectorOpIn,
+						},
+					},
+				}
+				nhc.Spec.Selector = selector
+			})
+
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(invalidSelectorError)))
+			})
+		})
+
+		Context("with empty selector", func() {
+			BeforeEach(func() {
+				selector := metav1.LabelSelector{}
+				nhc.Spec.Selector = selector
+			})
+
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(missingSelectorError)))
+			})
+
+			When("node name is set", func() {
+				BeforeEach(func() {
+					nhc.Spec.NodeName = "canary-node"
+				})
+				It("should be allowed", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+			})
+		})
+
+		Context("with node name", func() {
+			BeforeEach(func() {
+				nhc.Spec.NodeName = "canary-node"
+			})
+
+			It("should be allowed together with a selector", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an invalid node name", func() {
+				nhc.Spec.NodeName = "Invalid_Node"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(nodeNameError)))
+			})
+
+			It("should be denied with an invalid selector", func() {
+				nhc.Spec.Selector.MatchExpressions[0].Operator = metav1.LabelSelectorOpIn
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(invalidSelectorError)))
+			})
+		})
+
+		Context("with neither remediation template or escalating remediations set", func() {
+			BeforeEach(func() {
+				nhc.Spec.RemediationTemplate = nil
+				nhc.Spec.EscalatingRemediations = []EscalatingRemediation{}
+			})
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(mandatoryRemediationError)))
+			})
+		})
+
+		Context("with both remediation template and escalating remediations set", func() {
+			BeforeEach(func() {
+				templ := nhc.Spec.RemediationTemplate
+				setEscalatingRemediations(nhc)
+				nhc.Spec.RemediationTemplate = templ
+			})
+			It("should be denied", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(mutualRemediationError)))
+			})
+		})
+
+		Context("with inline remediation template", func() {
+			BeforeEach(func() {
+				nhc.Spec.RemediationTemplate = nil
+				nhc.Spec.InlineRemediationTemplate = &InlineRemediationTemplate{
+					APIVersion: inlineGV.String(),
+					Kind:       "InfrastructureRemediation",
+					Namespace:  "default",
+					Spec:       runtime.RawExtension{Raw: []byte(`{"strategy":"Reboot"}`)},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			When("remediation template is set as well", func() {
+				BeforeEach(func() {
+					nhc.Spec.RemediationTemplate = &v1.ObjectReference{
+						Kind:      "R",
+						Namespace: "dummy",
+						Name:      "r",
+					}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(mutualRemediationError)))
+				})
+			})
+
+			When("apiVersion has no group", func() {
+				BeforeEach(func() {
+					nhc.Spec.InlineRemediationTemplate.APIVersion = "v1"
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(inlineTemplateGVKError)))
+				})
+			})
+
+			When("kind is a template kind", func() {
+				BeforeEach(func() {
+					nhc.Spec.InlineRemediationTemplate.Kind = "InfrastructureRemediationTemplate"
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(inlineTemplateGVKError)))
+				})
+			})
+
+			When("spec isn't an object", func() {
+				BeforeEach(func() {
+					nhc.Spec.InlineRemediationTemplate.Spec = runtime.RawExtension{Raw: []byte(`["Reboot"]`)}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(inlineTemplateSpecError)))
+				})
+			})
+
+			When("kind is unknown", func() {
+				BeforeEach(func() {
+					nhc.Spec.InlineRemediationTemplate.Kind = "UnknownRemediation"
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(inlineTemplateCRDError)))
+				})
+
+				When("validation is skipped by annotation", func() {
+					BeforeEach(func() {
+						nhc.Annotations = map[string]string{
+							annotations.SkipInlineTemplateValidationAnnotation: "true",
+						}
+					})
+					It("should be allowed", func() {
+						Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+					})
+				})
+			})
+		})
+
+		Context("with remediation templates", func() {
+			BeforeEach(func() {
+				nhc.Spec.RemediationTemplate = nil
+				nhc.Spec.RemediationTemplates = []v1.ObjectReference{
+					{Kind: "DiagnosticsRemediationTemplate", Namespace: "dummy", Name: "d", APIVersion: "r"},
+					{Kind: "R", Namespace: "dummy", Name: "r", APIVersion: "r"},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			When("remediation template is set as well", func() {
+				BeforeEach(func() {
+					nhc.Spec.RemediationTemplate = &nhc.Spec.RemediationTemplates[1]
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(mutualRemediationError)))
+				})
+			})
+
+			When("a template is listed several times", func() {
+				BeforeEach(func() {
+					nhc.Spec.RemediationTemplates = append(nhc.Spec.RemediationTemplates, nhc.Spec.RemediationTemplates[0])
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(uniqueTemplatesError)))
+				})
+			})
+
+			When("templates of the same kind are listed", func() {
+				BeforeEach(func() {
+					other := nhc.Spec.RemediationTemplates[1]
+					other.Name = "other"
+					nhc.Spec.RemediationTemplates = append(nhc.Spec.RemediationTemplates, other)
+				})
+				It("should be denied without multiple templates support", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(uniqueRemediatorError)))
+				})
+			})
+		})
+
+		Context("with remote cluster", func() {
+			BeforeEach(func() {
+				nhc.Spec.RemoteCluster = &RemoteCluster{
+					KubeconfigSecretRef: v1.SecretReference{
+						Namespace: "dummy",
+						Name:      "spoke-kubeconfig",
+					},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			When("secret namespace is missing", func() {
+				BeforeEach(func() {
+					nhc.Spec.RemoteCluster.KubeconfigSecretRef.Namespace = ""
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remoteClusterSecretError)))
+				})
+			})
+
+			When("a remediation ServiceAccount is configured", func() {
+				BeforeEach(func() {
+					nhc.Spec.RemediationServiceAccount = &ServiceAccountReference{Namespace: "dummy", Name: "remediator"}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remoteClusterSAError)))
+				})
+			})
+		})
+
+		Context("with escalation memory", func() {
+			BeforeEach(func() {
+				nhc.Spec.EscalationMemory = &EscalationMemory{
+					Window: metav1.Duration{Duration: 1 * time.Hour},
+				}
+			})
+
+			It("should be denied without escalating remediations", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(escalationMemoryError)))
+			})
+
+			When("escalating remediations are used", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+				})
+				It("should be allowed", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+			})
+		})
+
+		Context("with escalation delay", func() {
+			BeforeEach(func() {
+				setEscalatingRemediations(nhc)
+				nhc.Spec.EscalationDelay = &metav1.Duration{Duration: time.Hour}
+			})
+
+			It("should be allowed up to one hour", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			When("the delay exceeds one hour", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalationDelay = &metav1.Duration{Duration: time.Hour + time.Second}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(escalationDelayError)))
+				})
+			})
+		})
+
+		Context("with min selected nodes", func() {
+			var selectedNodes int
+
+			BeforeEach(func() {
+				nhc.Spec.MinSelectedNodes = 3
+				selectedNodes = 3
+				orgListFunc := mockValidatorClient.listFunc
+				mockValidatorClient.listFunc = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if nodes, ok := list.(*v1.NodeList); ok {
+						nodes.Items = make([]v1.Node, selectedNodes)
+					}
+					return nil
+				}
+				DeferCleanup(func() {
+					mockValidatorClient.listFunc = orgListFunc
+				})
+			})
+
+			It("should be allowed", func() {
+				warnings, err := validator.ValidateCreate(context.Background(), nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+
+			It("should be denied when negative", func() {
+				nhc.Spec.MinSelectedNodes = -1
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(minSelectedNodesError)))
+			})
+
+			When("fewer nodes are selected", func() {
+				BeforeEach(func() {
+					selectedNodes = 2
+				})
+				It("should warn", func() {
+					warnings, err := validator.ValidateCreate(context.Background(), nhc)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf(And(
+						ContainSubstring(minSelectedNodesWarning),
+						ContainSubstring("2 nodes are selected"),
+					)))
+				})
+			})
+
+			When("node name is set", func() {
+				BeforeEach(func() {
+					nhc.Spec.NodeName = "canary-node"
+				})
+				It("should warn when the node doesn't exist", func() {
+					warnings, err := validator.ValidateCreate(context.Background(), nhc)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf(And(
+						ContainSubstring(minSelectedNodesWarning),
+						ContainSubstring("0 nodes are selected"),
+					)))
+				})
+			})
+		})
+
+		Context("with unhealthy conditions", func() {
+			It("should be allowed when not set, because defaults will be used", func() {
+				nhc.Spec.UnhealthyConditions = nil
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied when explicitly empty", func() {
+				nhc.Spec.UnhealthyConditions = []UnhealthyCondition{}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyConditionsError)))
+			})
+
+			It("should be denied when explicitly empty after JSON decoding", func() {
+				decoded := &NodeHealthCheck{}
+				Expect(json.Unmarshal([]byte(`{"spec":{"unhealthyConditions":[]}}`), decoded)).To(Succeed())
+				nhc.Spec.UnhealthyConditions = decoded.Spec.UnhealthyConditions
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyConditionsError)))
+
+				decoded = &NodeHealthCheck{}
+				Expect(json.Unmarshal([]byte(`{"spec":{}}`), decoded)).To(Succeed())
+				nhc.Spec.UnhealthyConditions = decoded.Spec.UnhealthyConditions
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+		})
+
+		Context("with defaulting", func() {
+			defaulter := &customDefaulter{}
+
+			It("should set the default unhealthy conditions and min healthy when not set", func() {
+				nhc.Spec.UnhealthyConditions = nil
+				nhc.Spec.MinHealthy = nil
+				Expect(defaulter.Default(context.Background(), nhc)).To(Succeed())
+				Expect(nhc.Spec.UnhealthyConditions).To(Equal([]UnhealthyCondition{
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionFalse,
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}))
+				Expect(nhc.Spec.MinHealthy).To(HaveValue(Equal(intstr.FromString("51%"))))
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should keep configured values", func() {
+				conditions := []UnhealthyCondition{
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						Duration: metav1.Duration{Duration: time.Minute},
+					},
+				}
+				nhc.Spec.UnhealthyConditions = conditions
+				mh := intstr.FromInt(2)
+				nhc.Spec.MinHealthy = &mh
+				Expect(defaulter.Default(context.Background(), nhc)).To(Succeed())
+				Expect(nhc.Spec.UnhealthyConditions).To(Equal(conditions))
+				Expect(nhc.Spec.MinHealthy).To(HaveValue(Equal(intstr.FromInt(2))))
+			})
+
+			It("should deny explicitly empty unhealthy conditions, which the patch would drop", func() {
+				nhc.Spec.UnhealthyConditions = []UnhealthyCondition{}
+				Expect(defaulter.Default(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyConditionsError)))
+			})
+		})
+
+		Context("with unhealthy condition taints", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyConditions = []UnhealthyCondition{
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+					{
+						Type:          v1.NodeReady,
+						Status:        v1.ConditionUnknown,
+						Duration:      metav1.Duration{Duration: 5 * time.Minute},
+						TaintKey:      v1.TaintNodeUnreachable,
+						TaintDuration: &metav1.Duration{Duration: 2 * time.Minute},
+					},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an invalid taint key", func() {
+				nhc.Spec.UnhealthyConditions[1].TaintKey = "node.kubernetes.io/unreachable/network"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(taintKeyError)))
+			})
+
+			It("should be denied with a taint duration but without taint key", func() {
+				nhc.Spec.UnhealthyConditions[0].TaintDuration = &metav1.Duration{Duration: time.Minute}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(taintDurationError)))
+			})
+		})
+
+		Context("with unhealthy capacity", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyCapacity = []UnhealthyCapacity{
+					{
+						ResourceName: "nvidia.com/gpu",
+						MinQuantity:  resource.MustParse("1"),
+						Duration:     metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an invalid resource name", func() {
+				nhc.Spec.UnhealthyCapacity[0].ResourceName = "nvidia.com/gpu/a100"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyCapacityError)))
+			})
+
+			It("should be denied with a negative minimum quantity", func() {
+				nhc.Spec.UnhealthyCapacity[0].MinQuantity = resource.MustParse("-1")
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyCapacityError)))
+			})
+		})
+
+		Context("with unhealthy taints", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyTaints = []UnhealthyTaint{
+					{
+						Key:      v1.TaintNodeUnreachable,
+						Effect:   v1.TaintEffectNoExecute,
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an empty key", func() {
+				nhc.Spec.UnhealthyTaints[0].Key = ""
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyTaintError)))
+			})
+
+			It("should be denied with an invalid value", func() {
+				nhc.Spec.UnhealthyTaints[0].Value = "disk failure"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyTaintError)))
+			})
+
+			It("should be denied with an invalid effect", func() {
+				nhc.Spec.UnhealthyTaints[0].Effect = "NoReboot"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyTaintError)))
+			})
+		})
+
+		Context("with unhealthy annotations", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyAnnotations = []AnnotationMatch{
+					{
+						Key:      "node-problem/ssd-failing",
+						Value:    "true",
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be allowed with an arbitrary value", func() {
+				nhc.Spec.UnhealthyAnnotations[0].Value = "disk failure: /dev/sda"
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an empty key", func() {
+				nhc.Spec.UnhealthyAnnotations[0].Key = ""
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyAnnotationError)))
+			})
+
+			It("should be denied with an invalid key", func() {
+				nhc.Spec.UnhealthyAnnotations[0].Key = "ssd failing"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyAnnotationError)))
+			})
+
+			It("should be denied with a duplicate key", func() {
+				nhc.Spec.UnhealthyAnnotations = append(nhc.Spec.UnhealthyAnnotations, nhc.Spec.UnhealthyAnnotations[0])
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyAnnotationError)))
+			})
+		})
+
+		Context("with unhealthy quorum", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyConditions = []UnhealthyCondition{
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+				nhc.Spec.ConnectivityCheck = &ConnectivityCheck{}
+				nhc.Spec.UnhealthyQuorum = 2
+			})
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied when exceeding the configured signals", func() {
+				nhc.Spec.UnhealthyQuorum = 3
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyQuorumError)))
+			})
+
+			It("should not count conditions with disabled remediation", func() {
+				nhc.Spec.UnhealthyConditions[0].RemediationEnabled = pointer.Bool(false)
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(unhealthyQuorumError)))
+			})
+		})
+
+		Context("with remediation throttle", func() {
+			BeforeEach(func() {
+				nhc.Spec.RemediationThrottle = &RemediationThrottle{
+					MaxConcurrent: 2,
+					MaxPerWindow:  5,
+					Window:        metav1.Duration{Duration: time.Hour},
+				}
+			})
+
+			It("should be allowed with valid limits", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with negative limits", func() {
+				nhc.Spec.RemediationThrottle.MaxConcurrent = -1
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remediationThrottleError)))
+				nhc.Spec.RemediationThrottle.MaxConcurrent = 0
+				nhc.Spec.RemediationThrottle.MaxPerWindow = -1
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remediationThrottleError)))
+			})
+
+			It("should be denied without positive window", func() {
+				nhc.Spec.RemediationThrottle.Window = metav1.Duration{}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remediationThrottleError)))
+			})
+		})
+
+		Context("with adaptive stabilization", func() {
+			BeforeEach(func() {
+				nhc.Spec.AdaptiveStabilization = &AdaptiveStabilization{
+					Enabled:     true,
+					Multiplier:  2,
+					Window:      metav1.Duration{Duration: time.Hour},
+					MaxDuration: &metav1.Duration{Duration: 30 * time.Minute},
+				}
+			})
+
+			It("should be allowed with valid values", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with a multiplier below 1", func() {
+				nhc.Spec.AdaptiveStabilization.Multiplier = 0
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(stabilizationError)))
+			})
+
+			It("should be denied without positive durations", func() {
+				nhc.Spec.AdaptiveStabilization.Window = metav1.Duration{}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(stabilizationError)))
+				nhc.Spec.AdaptiveStabilization.Window = metav1.Duration{Duration: time.Hour}
+				nhc.Spec.AdaptiveStabilization.MaxDuration = &metav1.Duration{}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(stabilizationError)))
+			})
+		})
+
+		Context("with alert silencing", func() {
+			BeforeEach(func() {
+				nhc.Spec.AlertSilencing = &AlertSilencing{
+					AlertmanagerURL: "https://alertmanager.monitoring.svc:9094",
+					Matchers: []SilenceMatcher{{
+						Name:  "instance",
+						Value: "{{.NodeName}}",
+					}},
+				}
+			})
+
+			It("should be allowed with valid values", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an invalid URL", func() {
+				nhc.Spec.AlertSilencing.AlertmanagerURL = "alertmanager:9093"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(alertmanagerURLError)))
+			})
+
+			It("should be denied with invalid templates", func() {
+				nhc.Spec.AlertSilencing.Matchers[0].Value = "{{.NodeName"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(silenceMatcherError)))
+				nhc.Spec.AlertSilencing.Matchers[0].Value = "{{.Namespace}}"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(silenceMatcherError)))
+			})
+
+			It("should be denied without positive duration", func() {
+				nhc.Spec.AlertSilencing.Duration = &metav1.Duration{}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(silenceDurationError)))
+			})
+		})
+
+		Context("with polling interval", func() {
+			It("should be allowed with positive interval", func() {
+				nhc.Spec.PollingInterval = &metav1.Duration{Duration: 5 * time.Minute}
 				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
 			})
+
+			It("should be denied without positive interval", func() {
+				nhc.Spec.PollingInterval = &metav1.Duration{}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(pollingIntervalError)))
+				nhc.Spec.PollingInterval = &metav1.Duration{Duration: -time.Minute}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(pollingIntervalError)))
+			})
 		})
 
-		Context("with valid config and escalating remediations", func() {
+		Context("with remediation windows", func() {
 			BeforeEach(func() {
-				setEscalatingRemediations(nhc)
+				nhc.Spec.RemediationWindows = []TimeWindow{{
+					Start:    "0 22 * * 1-5",
+					End:      "0 4 * * 2-6",
+					TimeZone: "Europe/Berlin",
+				}}
 			})
 
-			It("should be allowed", func() {
+			It("should be allowed with valid values", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				nhc.Spec.RemediationWindows[0].TimeZone = ""
 				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
 			})
+
+			It("should be denied with invalid cron expressions", func() {
+				nhc.Spec.RemediationWindows[0].Start = "0 25 * * *"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remediationWindowError)))
+				nhc.Spec.RemediationWindows[0].Start = "0 22 * * 1-5"
+				nhc.Spec.RemediationWindows[0].End = "0 0 4 * * 2-6"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remediationWindowError)))
+			})
+
+			It("should be denied with an unknown time zone", func() {
+				nhc.Spec.RemediationWindows[0].TimeZone = "Mars/Olympus_Mons"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(remediationWindowError)))
+			})
 		})
 
-		Context("with negative minHealthy", func() {
-			BeforeEach(func() {
-				mh := intstr.FromInt(-1)
-				nhc.Spec.MinHealthy = &mh
+		Context("with target platforms", func() {
+			It("should be allowed with known values", func() {
+				nhc.Spec.TargetArchitectures = []string{"amd64", "s390x"}
+				nhc.Spec.TargetOperatingSystems = []string{"linux"}
+				warnings, err := validator.ValidateCreate(context.Background(), nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
 			})
 
-			It("should be denied", func() {
-				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(minHealthyError)))
+			It("should warn about custom values", func() {
+				nhc.Spec.TargetArchitectures = []string{"amd64", "mips64le"}
+				warnings, err := validator.ValidateCreate(context.Background(), nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf(And(
+					ContainSubstring(targetPlatformWarning),
+					ContainSubstring("mips64le"),
+				)))
+			})
+
+			It("should be denied with invalid label values", func() {
+				nhc.Spec.TargetOperatingSystems = []string{"linux/amd64"}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(targetPlatformError)))
+				nhc.Spec.TargetOperatingSystems = []string{""}
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(targetPlatformError)))
 			})
 		})
 
-		Context("with invalid selector", func() {
+		Context("with priority label", func() {
+			It("should be allowed with a valid label key", func() {
+				nhc.Spec.PriorityLabel = "example.com/remediation-priority"
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			It("should be denied with an invalid label key", func() {
+				nhc.Spec.PriorityLabel = "example.com/remediation/priority"
+				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(priorityLabelError)))
+			})
+		})
+
+		Context("with post remediation verification", func() {
 			BeforeEach(func() {
-				selector := metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key: "node-role.kubernetes.io/control-plane",
-							// LabelSelectorOpIn needs a value
-							Operator: metav1.LabelSelectorOpIn,
-						},
+				nhc.Spec.PostRemediationVerification = &PostRemediationVerification{
+					VerificationTemplate: v1.ObjectReference{
+						APIVersion: "verification.example.com/v1",
+						Kind:       "WorkloadCheckTemplate",
+						Namespace:  "default",
+						Name:       "workloads",
 					},
 				}
-				nhc.Spec.Selector = selector
 			})
 
-			It("should be denied", func() {
-				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(invalidSelectorError)))
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
 			})
-		})
 
-		Context("with empty selector", func() {
-			BeforeEach(func() {
-				selector := metav1.LabelSelector{}
-				nhc.Spec.Selector = selector
+			When("template kind has no Template suffix", func() {
+				BeforeEach(func() {
+					nhc.Spec.PostRemediationVerification.VerificationTemplate.Kind = "WorkloadCheck"
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(verificationTemplateError)))
+				})
 			})
 
-			It("should be denied", func() {
-				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(missingSelectorError)))
+			When("re-escalation on failure is enabled", func() {
+				BeforeEach(func() {
+					nhc.Spec.PostRemediationVerification.ReEscalateOnFailure = true
+				})
+				It("should be denied without escalating remediations", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(reEscalateOnFailureError)))
+				})
+				It("should be allowed with escalating remediations", func() {
+					setEscalatingRemediations(nhc)
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
 			})
-		})
 
-		Context("with neither remediation template or escalating remediations set", func() {
-			BeforeEach(func() {
-				nhc.Spec.RemediationTemplate = nil
-				nhc.Spec.EscalatingRemediations = []EscalatingRemediation{}
+			When("there is no verification template", func() {
+				BeforeEach(func() {
+					nhc.Spec.PostRemediationVerification.VerificationTemplate = v1.ObjectReference{}
+				})
+				It("should be denied without other checks", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(verificationChecksError)))
+				})
+				It("should be allowed with min ready time", func() {
+					nhc.Spec.PostRemediationVerification.MinReadyTime = &metav1.Duration{Duration: time.Minute}
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+				It("should be allowed with required pods", func() {
+					nhc.Spec.PostRemediationVerification.RequiredPods = []RequiredPods{{
+						Namespace: "kube-system",
+						Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "cni"}},
+					}}
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
 			})
-			It("should be denied", func() {
-				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(mandatoryRemediationError)))
+
+			When("required pods are invalid", func() {
+				It("should be denied without namespace", func() {
+					nhc.Spec.PostRemediationVerification.RequiredPods = []RequiredPods{{
+						Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "cni"}},
+					}}
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(requiredPodsError)))
+				})
+				It("should be denied with an invalid selector", func() {
+					nhc.Spec.PostRemediationVerification.RequiredPods = []RequiredPods{{
+						Namespace: "kube-system",
+						Selector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "app",
+							Operator: "Matches",
+						}}},
+					}}
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(requiredPodsError)))
+				})
 			})
 		})
 
-		Context("with both remediation template and escalating remediations set", func() {
+		Context("with exponential escalation timeout strategy", func() {
 			BeforeEach(func() {
-				templ := nhc.Spec.RemediationTemplate
 				setEscalatingRemediations(nhc)
-				nhc.Spec.RemediationTemplate = templ
+				nhc.Spec.EscalatingRemediations[0].Timeout = metav1.Duration{}
+				nhc.Spec.EscalationTimeoutStrategy = &EscalationTimeoutStrategy{
+					Type: EscalationTimeoutStrategyExponential,
+					Exponential: &ExponentialTimeout{
+						Base:   metav1.Duration{Duration: 2 * time.Minute},
+						Factor: 2,
+						Max:    &metav1.Duration{Duration: 30 * time.Minute},
+					},
+				}
 			})
-			It("should be denied", func() {
-				Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(mutualRemediationError)))
+
+			It("should be allowed", func() {
+				Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+			})
+
+			When("exponential config is missing", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalationTimeoutStrategy.Exponential = nil
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(exponentialConfigError)))
+				})
+			})
+
+			When("max is lower than base", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalationTimeoutStrategy.Exponential.Max = &metav1.Duration{Duration: 1 * time.Minute}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(exponentialMaxError)))
+				})
+			})
+
+			When("an explicit timeout exceeds max", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalatingRemediations[1].Timeout = metav1.Duration{Duration: 1 * time.Hour}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(timeoutAboveMaxError)))
+				})
+			})
+
+			When("fixed strategy is used", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalationTimeoutStrategy.Type = EscalationTimeoutStrategyFixed
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(exponentialConfigError)))
+				})
 			})
 		})
 
@@ -143,6 +1004,16 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 				})
 			})
 
+			Context("with template without kind", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].RemediationTemplate.Kind = ""
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(templateKindError)))
+				})
+			})
+
 			Context("with too low timeout", func() {
 				BeforeEach(func() {
 					setEscalatingRemediations(nhc)
@@ -154,6 +1025,156 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 				})
 			})
 
+			Context("with too high timeout multiplier", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].TimeoutMultiplier = "42"
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(timeoutMultiplierError)))
+				})
+			})
+
+			Context("with too low timeout multiplier", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].TimeoutMultiplier = "0.5"
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(timeoutMultiplierError)))
+				})
+			})
+
+			Context("with max timeout lower than timeout", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].TimeoutMultiplier = "1.5"
+					nhc.Spec.EscalatingRemediations[1].MaxTimeout = &metav1.Duration{Duration: nhc.Spec.EscalatingRemediations[1].Timeout.Duration - time.Second}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(maxTimeoutError)))
+				})
+			})
+
+			Context("with max timeout lower than the timeout computed by the exponential strategy", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[0].Timeout = metav1.Duration{}
+					nhc.Spec.EscalatingRemediations[0].TimeoutMultiplier = "2"
+					nhc.Spec.EscalatingRemediations[0].MaxTimeout = &metav1.Duration{Duration: time.Minute}
+					nhc.Spec.EscalationTimeoutStrategy = &EscalationTimeoutStrategy{
+						Type: EscalationTimeoutStrategyExponential,
+						Exponential: &ExponentialTimeout{
+							Base:   metav1.Duration{Duration: 2 * time.Minute},
+							Factor: 2,
+						},
+					}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(maxTimeoutError)))
+				})
+			})
+
+			Context("with valid timeout multiplier and max timeout", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].TimeoutMultiplier = "1.5"
+					nhc.Spec.EscalatingRemediations[1].MaxTimeout = &metav1.Duration{Duration: 3 * nhc.Spec.EscalatingRemediations[1].Timeout.Duration}
+				})
+				It("should be allowed", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+			})
+
+			Context("with invalid required node label key", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].RequiredNodeLabels = map[string]string{"bmc/has/ipmi": "true"}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(requiredNodeLabelsError)))
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring("bmc/has/ipmi")))
+				})
+			})
+
+			Context("with invalid required node label value", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].RequiredNodeLabels = map[string]string{"example.com/bmc": "not valid"}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(requiredNodeLabelsError)))
+				})
+			})
+
+			Context("with valid required node labels", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].RequiredNodeLabels = map[string]string{"example.com/bmc": "ipmi"}
+				})
+				It("should be allowed", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+			})
+
+			Context("with all escalating remediations paused", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					for i := range nhc.Spec.EscalatingRemediations {
+						nhc.Spec.EscalatingRemediations[i].PauseReason = "firmware update"
+					}
+				})
+				It("should be denied", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(MatchError(ContainSubstring(allStepsPausedError)))
+				})
+			})
+
+			Context("with empty escalating remediations", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalatingRemediations = []EscalatingRemediation{}
+				})
+				It("should be allowed with a remediation template", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+			})
+
+			Context("with some escalating remediations paused", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[0].PauseReason = "firmware update"
+					nhc.Spec.EscalatingRemediations[0].AbortOnPause = true
+				})
+				It("should be allowed", func() {
+					Expect(validator.validate(context.Background(), nhc)).To(Succeed())
+				})
+			})
+
+			Context("with duplicate template", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+					nhc.Spec.EscalatingRemediations[1].RemediationTemplate = nhc.Spec.EscalatingRemediations[0].RemediationTemplate
+				})
+				It("should warn", func() {
+					warnings, _ := validator.ValidateCreate(context.Background(), nhc)
+					Expect(warnings).To(ConsistOf(And(
+						ContainSubstring(duplicateTemplateWarning),
+						ContainSubstring("R2 dummy/r2"),
+						ContainSubstring("orders 20 and 30"),
+					)))
+				})
+			})
+
+			Context("without duplicate templates", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhc)
+				})
+				It("should not warn", func() {
+					warnings, err := validator.ValidateCreate(context.Background(), nhc)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings).To(BeEmpty())
+				})
+			})
+
 			Context("with duplicate remediator", func() {
 				var firstTemplate, secondTemplate unstructured.Unstructured
 				BeforeEach(func() {
@@ -269,6 +1290,16 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 			})
 		})
 
+		Context("updating node name", func() {
+			BeforeEach(func() {
+				nhcNew = nhcOld.DeepCopy()
+				nhcNew.Spec.NodeName = "canary-node"
+			})
+			It("should be denied", func() {
+				validateError(validator.ValidateUpdate, nhcOld, nhcNew, OngoingRemediationError, "node name")
+			})
+		})
+
 		Context("updating remediation template", func() {
 			BeforeEach(func() {
 				nhcNew = nhcOld.DeepCopy()
@@ -279,6 +1310,17 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 			})
 		})
 
+		Context("updating remediation templates", func() {
+			BeforeEach(func() {
+				nhcNew = nhcOld.DeepCopy()
+				nhcNew.Spec.RemediationTemplates = []v1.ObjectReference{*nhcNew.Spec.RemediationTemplate}
+				nhcNew.Spec.RemediationTemplate = nil
+			})
+			It("should be denied", func() {
+				validateError(validator.ValidateUpdate, nhcOld, nhcNew, OngoingRemediationError, "remediation template")
+			})
+		})
+
 		Context("updating escalating remediations", func() {
 			BeforeEach(func() {
 				setEscalatingRemediations(nhcOld)
@@ -289,6 +1331,41 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 				validateError(validator.ValidateUpdate, nhcOld, nhcNew, OngoingRemediationError, "escalating remediations")
 			})
 		})
+
+		Context("disabling dry run", func() {
+			BeforeEach(func() {
+				nhcOld.Spec.DryRun = true
+				nhcNew = nhcOld.DeepCopy()
+				nhcNew.Spec.DryRun = false
+			})
+			It("should be denied", func() {
+				validateError(validator.ValidateUpdate, nhcOld, nhcNew, OngoingRemediationError, "dry run")
+			})
+		})
+
+		Context("enabling dry run", func() {
+			BeforeEach(func() {
+				nhcNew = nhcOld.DeepCopy()
+				nhcNew.Spec.DryRun = true
+			})
+			It("should be allowed", func() {
+				_, err := validator.ValidateUpdate(context.Background(), nhcOld, nhcNew)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("pausing an escalating remediation", func() {
+			BeforeEach(func() {
+				setEscalatingRemediations(nhcOld)
+				nhcNew = nhcOld.DeepCopy()
+				nhcNew.Spec.EscalatingRemediations[0].PauseReason = "firmware update"
+				nhcNew.Spec.EscalatingRemediations[0].AbortOnPause = true
+			})
+			It("should be allowed", func() {
+				_, err := validator.ValidateUpdate(context.Background(), nhcOld, nhcNew)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
 	})
 
 	Context("Test isRemediating", func() {
@@ -381,7 +1458,12 @@ func setEscalatingRemediations(nhc *NodeHealthCheck) {
 
 type mockClient struct {
 	client.Client
-	listFunc func(context.Context, client.ObjectList, ...client.ListOption) error
+	listFunc   func(context.Context, client.ObjectList, ...client.ListOption) error
+	restMapper meta.RESTMapper
+}
+
+func (m *mockClient) RESTMapper() meta.RESTMapper {
+	return m.restMapper
 }
 
 func (m *mockClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
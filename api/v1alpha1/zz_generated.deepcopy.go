@@ -27,194 +27,1553 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveStabilization) DeepCopyInto(out *AdaptiveStabilization) {
+	*out = *in
+	out.Window = in.Window
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveStabilization.
+func (in *AdaptiveStabilization) DeepCopy() *AdaptiveStabilization {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveStabilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotatedNode) DeepCopyInto(out *AnnotatedNode) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotatedNode.
+func (in *AnnotatedNode) DeepCopy() *AnnotatedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotatedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotationMatch) DeepCopyInto(out *AnnotationMatch) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationMatch.
+func (in *AnnotationMatch) DeepCopy() *AnnotationMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSilence) DeepCopyInto(out *AlertSilence) {
+	*out = *in
+	in.EndsAt.DeepCopyInto(&out.EndsAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSilence.
+func (in *AlertSilence) DeepCopy() *AlertSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSilencing) DeepCopyInto(out *AlertSilencing) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]SilenceMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSilencing.
+func (in *AlertSilencing) DeepCopy() *AlertSilencing {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSilencing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityCheck) DeepCopyInto(out *ConnectivityCheck) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityCheck.
+func (in *ConnectivityCheck) DeepCopy() *ConnectivityCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoolingDownNode) DeepCopyInto(out *CoolingDownNode) {
+	*out = *in
+	in.RemediationFinished.DeepCopyInto(&out.RemediationFinished)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoolingDownNode.
+func (in *CoolingDownNode) DeepCopy() *CoolingDownNode {
+	if in == nil {
+		return nil
+	}
+	out := new(CoolingDownNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentReference) DeepCopyInto(out *DeploymentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentReference.
+func (in *DeploymentReference) DeepCopy() *DeploymentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drain) DeepCopyInto(out *Drain) {
+	*out = *in
+	in.Started.DeepCopyInto(&out.Started)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drain.
+func (in *Drain) DeepCopy() *Drain {
+	if in == nil {
+		return nil
+	}
+	out := new(Drain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfig) DeepCopyInto(out *EffectiveConfig) {
+	*out = *in
+	if in.MinHealthy != nil {
+		in, out := &in.MinHealthy, &out.MinHealthy
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(int)
+		**out = **in
+	}
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]UnhealthyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemediationTemplates != nil {
+		in, out := &in.RemediationTemplates, &out.RemediationTemplates
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfig.
+func (in *EffectiveConfig) DeepCopy() *EffectiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EscalatingRemediation) DeepCopyInto(out *EscalatingRemediation) {
 	*out = *in
-	out.RemediationTemplate = in.RemediationTemplate
-	out.Timeout = in.Timeout
+	out.RemediationTemplate = in.RemediationTemplate
+	out.Timeout = in.Timeout
+	if in.MaxTimeout != nil {
+		in, out := &in.MaxTimeout, &out.MaxTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RequiredNodeLabels != nil {
+		in, out := &in.RequiredNodeLabels, &out.RequiredNodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalatingRemediation.
+func (in *EscalatingRemediation) DeepCopy() *EscalatingRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalatingRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationMemory) DeepCopyInto(out *EscalationMemory) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationMemory.
+func (in *EscalationMemory) DeepCopy() *EscalationMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationStart) DeepCopyInto(out *EscalationStart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationStart.
+func (in *EscalationStart) DeepCopy() *EscalationStart {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationStart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalationTimeoutStrategy) DeepCopyInto(out *EscalationTimeoutStrategy) {
+	*out = *in
+	if in.Exponential != nil {
+		in, out := &in.Exponential, &out.Exponential
+		*out = new(ExponentialTimeout)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalationTimeoutStrategy.
+func (in *EscalationTimeoutStrategy) DeepCopy() *EscalationTimeoutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalationTimeoutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExponentialTimeout) DeepCopyInto(out *ExponentialTimeout) {
+	*out = *in
+	out.Base = in.Base
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExponentialTimeout.
+func (in *ExponentialTimeout) DeepCopy() *ExponentialTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(ExponentialTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatSource) DeepCopyInto(out *HeartbeatSource) {
+	*out = *in
+	out.StaleAfter = in.StaleAfter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatSource.
+func (in *HeartbeatSource) DeepCopy() *HeartbeatSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighUtilizationNode) DeepCopyInto(out *HighUtilizationNode) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighUtilizationNode.
+func (in *HighUtilizationNode) DeepCopy() *HighUtilizationNode {
+	if in == nil {
+		return nil
+	}
+	out := new(HighUtilizationNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnoredUnhealthyNode) DeepCopyInto(out *IgnoredUnhealthyNode) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.EligibleAt != nil {
+		in, out := &in.EligibleAt, &out.EligibleAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnoredUnhealthyNode.
+func (in *IgnoredUnhealthyNode) DeepCopy() *IgnoredUnhealthyNode {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnoredUnhealthyNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineRemediationTemplate) DeepCopyInto(out *InlineRemediationTemplate) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineRemediationTemplate.
+func (in *InlineRemediationTemplate) DeepCopy() *InlineRemediationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineRemediationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InsufficientCapacityNode) DeepCopyInto(out *InsufficientCapacityNode) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InsufficientCapacityNode.
+func (in *InsufficientCapacityNode) DeepCopy() *InsufficientCapacityNode {
+	if in == nil {
+		return nil
+	}
+	out := new(InsufficientCapacityNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastError) DeepCopyInto(out *LastError) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastError.
+func (in *LastError) DeepCopy() *LastError {
+	if in == nil {
+		return nil
+	}
+	out := new(LastError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedAnnotation) DeepCopyInto(out *MatchedAnnotation) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedAnnotation.
+func (in *MatchedAnnotation) DeepCopy() *MatchedAnnotation {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedAnnotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedCapacity) DeepCopyInto(out *MatchedCapacity) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedCapacity.
+func (in *MatchedCapacity) DeepCopy() *MatchedCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedCondition) DeepCopyInto(out *MatchedCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedCondition.
+func (in *MatchedCondition) DeepCopy() *MatchedCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedTaint) DeepCopyInto(out *MatchedTaint) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedTaint.
+func (in *MatchedTaint) DeepCopy() *MatchedTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConnectivityReport) DeepCopyInto(out *NodeConnectivityReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConnectivityReport.
+func (in *NodeConnectivityReport) DeepCopy() *NodeConnectivityReport {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConnectivityReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeConnectivityReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConnectivityReportList) DeepCopyInto(out *NodeConnectivityReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeConnectivityReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConnectivityReportList.
+func (in *NodeConnectivityReportList) DeepCopy() *NodeConnectivityReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConnectivityReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeConnectivityReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConnectivityReportStatus) DeepCopyInto(out *NodeConnectivityReportStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConnectivityReportStatus.
+func (in *NodeConnectivityReportStatus) DeepCopy() *NodeConnectivityReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConnectivityReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedUtilization) DeepCopyInto(out *MatchedUtilization) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedUtilization.
+func (in *MatchedUtilization) DeepCopy() *MatchedUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheck) DeepCopyInto(out *NodeHealthCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheck.
+func (in *NodeHealthCheck) DeepCopy() *NodeHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheckList) DeepCopyInto(out *NodeHealthCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckList.
+func (in *NodeHealthCheckList) DeepCopy() *NodeHealthCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheckSpec) DeepCopyInto(out *NodeHealthCheckSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.TargetArchitectures != nil {
+		in, out := &in.TargetArchitectures, &out.TargetArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetOperatingSystems != nil {
+		in, out := &in.TargetOperatingSystems, &out.TargetOperatingSystems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]UnhealthyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnhealthyCapacity != nil {
+		in, out := &in.UnhealthyCapacity, &out.UnhealthyCapacity
+		*out = make([]UnhealthyCapacity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnhealthyTaints != nil {
+		in, out := &in.UnhealthyTaints, &out.UnhealthyTaints
+		*out = make([]UnhealthyTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyAnnotations != nil {
+		in, out := &in.UnhealthyAnnotations, &out.UnhealthyAnnotations
+		*out = make([]AnnotationMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyUtilization != nil {
+		in, out := &in.UnhealthyUtilization, &out.UnhealthyUtilization
+		*out = make([]UnhealthyUtilization, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectivityCheck != nil {
+		in, out := &in.ConnectivityCheck, &out.ConnectivityCheck
+		*out = new(ConnectivityCheck)
+		**out = **in
+	}
+	if in.HeartbeatSource != nil {
+		in, out := &in.HeartbeatSource, &out.HeartbeatSource
+		*out = new(HeartbeatSource)
+		**out = **in
+	}
+	if in.MinHealthy != nil {
+		in, out := &in.MinHealthy, &out.MinHealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.RemediationTemplate != nil {
+		in, out := &in.RemediationTemplate, &out.RemediationTemplate
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.RemediationTemplates != nil {
+		in, out := &in.RemediationTemplates, &out.RemediationTemplates
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.InlineRemediationTemplate != nil {
+		in, out := &in.InlineRemediationTemplate, &out.InlineRemediationTemplate
+		*out = new(InlineRemediationTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EscalatingRemediations != nil {
+		in, out := &in.EscalatingRemediations, &out.EscalatingRemediations
+		*out = make([]EscalatingRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EscalationMemory != nil {
+		in, out := &in.EscalationMemory, &out.EscalationMemory
+		*out = new(EscalationMemory)
+		**out = **in
+	}
+	if in.EscalationTimeoutStrategy != nil {
+		in, out := &in.EscalationTimeoutStrategy, &out.EscalationTimeoutStrategy
+		*out = new(EscalationTimeoutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EscalationHandshakeGracePeriod != nil {
+		in, out := &in.EscalationHandshakeGracePeriod, &out.EscalationHandshakeGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EscalationDelay != nil {
+		in, out := &in.EscalationDelay, &out.EscalationDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RemediationRecordRetention != nil {
+		in, out := &in.RemediationRecordRetention, &out.RemediationRecordRetention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PauseRequests != nil {
+		in, out := &in.PauseRequests, &out.PauseRequests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemediationWindows != nil {
+		in, out := &in.RemediationWindows, &out.RemediationWindows
+		*out = make([]TimeWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RemoteCluster != nil {
+		in, out := &in.RemoteCluster, &out.RemoteCluster
+		*out = new(RemoteCluster)
+		**out = **in
+	}
+	if in.RemediationServiceAccount != nil {
+		in, out := &in.RemediationServiceAccount, &out.RemediationServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
+	if in.SurgeGate != nil {
+		in, out := &in.SurgeGate, &out.SurgeGate
+		*out = new(SurgeGate)
+		**out = **in
+	}
+	if in.RespectPodDisruptionBudgets != nil {
+		in, out := &in.RespectPodDisruptionBudgets, &out.RespectPodDisruptionBudgets
+		*out = new(RespectPodDisruptionBudgets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRemediationVerification != nil {
+		in, out := &in.PostRemediationVerification, &out.PostRemediationVerification
+		*out = new(PostRemediationVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinNodeAge != nil {
+		in, out := &in.MinNodeAge, &out.MinNodeAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RemediationCooldown != nil {
+		in, out := &in.RemediationCooldown, &out.RemediationCooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxRemediationDuration != nil {
+		in, out := &in.MaxRemediationDuration, &out.MaxRemediationDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RemediationThrottle != nil {
+		in, out := &in.RemediationThrottle, &out.RemediationThrottle
+		*out = new(RemediationThrottle)
+		**out = **in
+	}
+	if in.AdaptiveStabilization != nil {
+		in, out := &in.AdaptiveStabilization, &out.AdaptiveStabilization
+		*out = new(AdaptiveStabilization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediatorCircuitBreaker != nil {
+		in, out := &in.RemediatorCircuitBreaker, &out.RemediatorCircuitBreaker
+		*out = new(RemediatorCircuitBreaker)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediatorHealthCheck != nil {
+		in, out := &in.RemediatorHealthCheck, &out.RemediatorHealthCheck
+		*out = make([]RemediatorHealthCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertSilencing != nil {
+		in, out := &in.AlertSilencing, &out.AlertSilencing
+		*out = new(AlertSilencing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalRemediationGracePeriod != nil {
+		in, out := &in.ExternalRemediationGracePeriod, &out.ExternalRemediationGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PreRemediationDrain != nil {
+		in, out := &in.PreRemediationDrain, &out.PreRemediationDrain
+		*out = new(PreRemediationDrain)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckSpec.
+func (in *NodeHealthCheckSpec) DeepCopy() *NodeHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheckStatus) DeepCopyInto(out *NodeHealthCheckStatus) {
+	*out = *in
+	if in.ObservedNodes != nil {
+		in, out := &in.ObservedNodes, &out.ObservedNodes
+		*out = new(int)
+		**out = **in
+	}
+	if in.HealthyNodes != nil {
+		in, out := &in.HealthyNodes, &out.HealthyNodes
+		*out = new(int)
+		**out = **in
+	}
+	if in.UnhealthyDurationBuckets != nil {
+		in, out := &in.UnhealthyDurationBuckets, &out.UnhealthyDurationBuckets
+		*out = new(UnhealthyDurationBuckets)
+		**out = **in
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(EffectiveConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CreatedRemediationKinds != nil {
+		in, out := &in.CreatedRemediationKinds, &out.CreatedRemediationKinds
+		*out = make([]metav1.GroupVersionKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReportOnlyUnhealthyNodes != nil {
+		in, out := &in.ReportOnlyUnhealthyNodes, &out.ReportOnlyUnhealthyNodes
+		*out = make([]ReportOnlyUnhealthyNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IgnoredUnhealthyNodes != nil {
+		in, out := &in.IgnoredUnhealthyNodes, &out.IgnoredUnhealthyNodes
+		*out = make([]IgnoredUnhealthyNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InsufficientCapacityNodes != nil {
+		in, out := &in.InsufficientCapacityNodes, &out.InsufficientCapacityNodes
+		*out = make([]InsufficientCapacityNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TaintedNodes != nil {
+		in, out := &in.TaintedNodes, &out.TaintedNodes
+		*out = make([]TaintedNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnnotatedNodes != nil {
+		in, out := &in.AnnotatedNodes, &out.AnnotatedNodes
+		*out = make([]AnnotatedNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HighUtilizationNodes != nil {
+		in, out := &in.HighUtilizationNodes, &out.HighUtilizationNodes
+		*out = make([]HighUtilizationNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnhealthySignalVotes != nil {
+		in, out := &in.UnhealthySignalVotes, &out.UnhealthySignalVotes
+		*out = make([]UnhealthySignalVote, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnhealthyNodes != nil {
+		in, out := &in.UnhealthyNodes, &out.UnhealthyNodes
+		*out = make([]*UnhealthyNode, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(UnhealthyNode)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ExhaustedNodes != nil {
+		in, out := &in.ExhaustedNodes, &out.ExhaustedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecentRemediations != nil {
+		in, out := &in.RecentRemediations, &out.RecentRemediations
+		*out = make([]*RecentRemediation, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RecentRemediation)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RemediationHistory != nil {
+		in, out := &in.RemediationHistory, &out.RemediationHistory
+		*out = make([]NodeRemediationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CoolingDownNodes != nil {
+		in, out := &in.CoolingDownNodes, &out.CoolingDownNodes
+		*out = make([]*CoolingDownNode, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CoolingDownNode)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RemediationThrottle != nil {
+		in, out := &in.RemediationThrottle, &out.RemediationThrottle
+		*out = new(RemediationThrottleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StabilizingNodes != nil {
+		in, out := &in.StabilizingNodes, &out.StabilizingNodes
+		*out = make([]*StabilizingNode, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StabilizingNode)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RemediatorCircuits != nil {
+		in, out := &in.RemediatorCircuits, &out.RemediatorCircuits
+		*out = make([]*RemediatorCircuit, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RemediatorCircuit)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.UnavailableRemediators != nil {
+		in, out := &in.UnavailableRemediators, &out.UnavailableRemediators
+		*out = make([]*UnavailableRemediator, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(UnavailableRemediator)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.AlertSilences != nil {
+		in, out := &in.AlertSilences, &out.AlertSilences
+		*out = make([]*AlertSilence, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(AlertSilence)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.OrphanedRemediations != nil {
+		in, out := &in.OrphanedRemediations, &out.OrphanedRemediations
+		*out = make([]OrphanedRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InFlightRemediations != nil {
+		in, out := &in.InFlightRemediations, &out.InFlightRemediations
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastPhaseTransitionTime != nil {
+		in, out := &in.LastPhaseTransitionTime, &out.LastPhaseTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseDurations != nil {
+		in, out := &in.PhaseDurations, &out.PhaseDurations
+		*out = make([]PhaseDuration, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(LastError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckStatus.
+func (in *NodeHealthCheckStatus) DeepCopy() *NodeHealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRemediationRecord) DeepCopyInto(out *NodeRemediationRecord) {
+	*out = *in
+	in.Started.DeepCopyInto(&out.Started)
+	in.Finished.DeepCopyInto(&out.Finished)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRemediationRecord.
+func (in *NodeRemediationRecord) DeepCopy() *NodeRemediationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRemediationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedRemediation) DeepCopyInto(out *OrphanedRemediation) {
+	*out = *in
+	out.Resource = in.Resource
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedRemediation.
+func (in *OrphanedRemediation) DeepCopy() *OrphanedRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseDuration) DeepCopyInto(out *PhaseDuration) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseDuration.
+func (in *PhaseDuration) DeepCopy() *PhaseDuration {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseDuration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostRemediationVerification) DeepCopyInto(out *PostRemediationVerification) {
+	*out = *in
+	out.VerificationTemplate = in.VerificationTemplate
+	if in.MinReadyTime != nil {
+		in, out := &in.MinReadyTime, &out.MinReadyTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RequiredPods != nil {
+		in, out := &in.RequiredPods, &out.RequiredPods
+		*out = make([]RequiredPods, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostRemediationVerification.
+func (in *PostRemediationVerification) DeepCopy() *PostRemediationVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(PostRemediationVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreRemediationDrain) DeepCopyInto(out *PreRemediationDrain) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreRemediationDrain.
+func (in *PreRemediationDrain) DeepCopy() *PreRemediationDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(PreRemediationDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecentRemediation) DeepCopyInto(out *RecentRemediation) {
+	*out = *in
+	in.Finished.DeepCopyInto(&out.Finished)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecentRemediation.
+func (in *RecentRemediation) DeepCopy() *RecentRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(RecentRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Remediation) DeepCopyInto(out *Remediation) {
+	*out = *in
+	out.Resource = in.Resource
+	in.Started.DeepCopyInto(&out.Started)
+	if in.TimedOut != nil {
+		in, out := &in.TimedOut, &out.TimedOut
+		*out = (*in).DeepCopy()
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TimeoutAt != nil {
+		in, out := &in.TimeoutAt, &out.TimeoutAt
+		*out = (*in).DeepCopy()
+	}
+	if in.MissingNodeLabels != nil {
+		in, out := &in.MissingNodeLabels, &out.MissingNodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeletedExternally != nil {
+		in, out := &in.DeletedExternally, &out.DeletedExternally
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
+func (in *Remediation) DeepCopy() *Remediation {
+	if in == nil {
+		return nil
+	}
+	out := new(Remediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationDeferral) DeepCopyInto(out *RemediationDeferral) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationDeferral.
+func (in *RemediationDeferral) DeepCopy() *RemediationDeferral {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationDeferral)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRoute) DeepCopyInto(out *RemediationRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRoute.
+func (in *RemediationRoute) DeepCopy() *RemediationRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationThrottle) DeepCopyInto(out *RemediationThrottle) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationThrottle.
+func (in *RemediationThrottle) DeepCopy() *RemediationThrottle {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationThrottle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationThrottleStatus) DeepCopyInto(out *RemediationThrottleStatus) {
+	*out = *in
+	if in.StartedInWindow != nil {
+		in, out := &in.StartedInWindow, &out.StartedInWindow
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationThrottleStatus.
+func (in *RemediationThrottleStatus) DeepCopy() *RemediationThrottleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationThrottleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediatorCircuit) DeepCopyInto(out *RemediatorCircuit) {
+	*out = *in
+	if in.Opened != nil {
+		in, out := &in.Opened, &out.Opened
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediatorCircuit.
+func (in *RemediatorCircuit) DeepCopy() *RemediatorCircuit {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediatorCircuit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediatorCircuitBreaker) DeepCopyInto(out *RemediatorCircuitBreaker) {
+	*out = *in
+	if in.OpenDuration != nil {
+		in, out := &in.OpenDuration, &out.OpenDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediatorCircuitBreaker.
+func (in *RemediatorCircuitBreaker) DeepCopy() *RemediatorCircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediatorCircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediatorHealthCheck) DeepCopyInto(out *RemediatorHealthCheck) {
+	*out = *in
+	out.DeploymentRef = in.DeploymentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediatorHealthCheck.
+func (in *RemediatorHealthCheck) DeepCopy() *RemediatorHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediatorHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
+func (in *RemoteCluster) DeepCopy() *RemoteCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportOnlyUnhealthyNode) DeepCopyInto(out *ReportOnlyUnhealthyNode) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MatchedCondition, len(*in))
+		copy(*out, *in)
+	}
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportOnlyUnhealthyNode.
+func (in *ReportOnlyUnhealthyNode) DeepCopy() *ReportOnlyUnhealthyNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportOnlyUnhealthyNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredPods) DeepCopyInto(out *RequiredPods) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalatingRemediation.
-func (in *EscalatingRemediation) DeepCopy() *EscalatingRemediation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredPods.
+func (in *RequiredPods) DeepCopy() *RequiredPods {
 	if in == nil {
 		return nil
 	}
-	out := new(EscalatingRemediation)
+	out := new(RequiredPods)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeHealthCheck) DeepCopyInto(out *NodeHealthCheck) {
+func (in *RespectPodDisruptionBudgets) DeepCopyInto(out *RespectPodDisruptionBudgets) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverrideTimeout != nil {
+		in, out := &in.OverrideTimeout, &out.OverrideTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheck.
-func (in *NodeHealthCheck) DeepCopy() *NodeHealthCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RespectPodDisruptionBudgets.
+func (in *RespectPodDisruptionBudgets) DeepCopy() *RespectPodDisruptionBudgets {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeHealthCheck)
+	out := new(RespectPodDisruptionBudgets)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeHealthCheck) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeHealthCheckList) DeepCopyInto(out *NodeHealthCheckList) {
+func (in *SilenceMatcher) DeepCopyInto(out *SilenceMatcher) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NodeHealthCheck, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceMatcher.
+func (in *SilenceMatcher) DeepCopy() *SilenceMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StabilizingNode) DeepCopyInto(out *StabilizingNode) {
+	*out = *in
+	if in.RemediationsFinished != nil {
+		in, out := &in.RemediationsFinished, &out.RemediationsFinished
+		*out = make([]metav1.Time, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EffectiveDuration != nil {
+		in, out := &in.EffectiveDuration, &out.EffectiveDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckList.
-func (in *NodeHealthCheckList) DeepCopy() *NodeHealthCheckList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StabilizingNode.
+func (in *StabilizingNode) DeepCopy() *StabilizingNode {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeHealthCheckList)
+	out := new(StabilizingNode)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeHealthCheckList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SurgeGate) DeepCopyInto(out *SurgeGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SurgeGate.
+func (in *SurgeGate) DeepCopy() *SurgeGate {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(SurgeGate)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeHealthCheckSpec) DeepCopyInto(out *NodeHealthCheckSpec) {
+func (in *TaintedNode) DeepCopyInto(out *TaintedNode) {
 	*out = *in
-	in.Selector.DeepCopyInto(&out.Selector)
-	if in.UnhealthyConditions != nil {
-		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
-		*out = make([]UnhealthyCondition, len(*in))
-		copy(*out, *in)
-	}
-	if in.MinHealthy != nil {
-		in, out := &in.MinHealthy, &out.MinHealthy
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.RemediationTemplate != nil {
-		in, out := &in.RemediationTemplate, &out.RemediationTemplate
-		*out = new(v1.ObjectReference)
-		**out = **in
-	}
-	if in.EscalatingRemediations != nil {
-		in, out := &in.EscalatingRemediations, &out.EscalatingRemediations
-		*out = make([]EscalatingRemediation, len(*in))
-		copy(*out, *in)
-	}
-	if in.PauseRequests != nil {
-		in, out := &in.PauseRequests, &out.PauseRequests
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.Since.DeepCopyInto(&out.Since)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckSpec.
-func (in *NodeHealthCheckSpec) DeepCopy() *NodeHealthCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaintedNode.
+func (in *TaintedNode) DeepCopy() *TaintedNode {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeHealthCheckSpec)
+	out := new(TaintedNode)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeHealthCheckStatus) DeepCopyInto(out *NodeHealthCheckStatus) {
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
 	*out = *in
-	if in.ObservedNodes != nil {
-		in, out := &in.ObservedNodes, &out.ObservedNodes
-		*out = new(int)
-		**out = **in
-	}
-	if in.HealthyNodes != nil {
-		in, out := &in.HealthyNodes, &out.HealthyNodes
-		*out = new(int)
-		**out = **in
-	}
-	if in.UnhealthyNodes != nil {
-		in, out := &in.UnhealthyNodes, &out.UnhealthyNodes
-		*out = make([]*UnhealthyNode, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(UnhealthyNode)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.InFlightRemediations != nil {
-		in, out := &in.InFlightRemediations, &out.InFlightRemediations
-		*out = make(map[string]metav1.Time, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckStatus.
-func (in *NodeHealthCheckStatus) DeepCopy() *NodeHealthCheckStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeHealthCheckStatus)
+	out := new(TimeWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Remediation) DeepCopyInto(out *Remediation) {
+func (in *UnavailableRemediator) DeepCopyInto(out *UnavailableRemediator) {
 	*out = *in
-	out.Resource = in.Resource
-	in.Started.DeepCopyInto(&out.Started)
-	if in.TimedOut != nil {
-		in, out := &in.TimedOut, &out.TimedOut
-		*out = (*in).DeepCopy()
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnavailableRemediator.
+func (in *UnavailableRemediator) DeepCopy() *UnavailableRemediator {
+	if in == nil {
+		return nil
 	}
+	out := new(UnavailableRemediator)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
-func (in *Remediation) DeepCopy() *Remediation {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyCapacity) DeepCopyInto(out *UnhealthyCapacity) {
+	*out = *in
+	out.MinQuantity = in.MinQuantity.DeepCopy()
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyCapacity.
+func (in *UnhealthyCapacity) DeepCopy() *UnhealthyCapacity {
 	if in == nil {
 		return nil
 	}
-	out := new(Remediation)
+	out := new(UnhealthyCapacity)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -223,6 +1582,16 @@ func (in *Remediation) DeepCopy() *Remediation {
 func (in *UnhealthyCondition) DeepCopyInto(out *UnhealthyCondition) {
 	*out = *in
 	out.Duration = in.Duration
+	if in.RemediationEnabled != nil {
+		in, out := &in.RemediationEnabled, &out.RemediationEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TaintDuration != nil {
+		in, out := &in.TaintDuration, &out.TaintDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyCondition.
@@ -235,9 +1604,28 @@ func (in *UnhealthyCondition) DeepCopy() *UnhealthyCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyDurationBuckets) DeepCopyInto(out *UnhealthyDurationBuckets) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyDurationBuckets.
+func (in *UnhealthyDurationBuckets) DeepCopy() *UnhealthyDurationBuckets {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyDurationBuckets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnhealthyNode) DeepCopyInto(out *UnhealthyNode) {
 	*out = *in
+	if in.EligibleSince != nil {
+		in, out := &in.EligibleSince, &out.EligibleSince
+		*out = (*in).DeepCopy()
+	}
 	if in.Remediations != nil {
 		in, out := &in.Remediations, &out.Remediations
 		*out = make([]*Remediation, len(*in))
@@ -249,10 +1637,81 @@ func (in *UnhealthyNode) DeepCopyInto(out *UnhealthyNode) {
 			}
 		}
 	}
+	if in.IsControllingNHC != nil {
+		in, out := &in.IsControllingNHC, &out.IsControllingNHC
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MatchedCondition != nil {
+		in, out := &in.MatchedCondition, &out.MatchedCondition
+		*out = new(MatchedCondition)
+		**out = **in
+	}
+	if in.MatchedCapacity != nil {
+		in, out := &in.MatchedCapacity, &out.MatchedCapacity
+		*out = new(MatchedCapacity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchedTaint != nil {
+		in, out := &in.MatchedTaint, &out.MatchedTaint
+		*out = new(MatchedTaint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchedAnnotation != nil {
+		in, out := &in.MatchedAnnotation, &out.MatchedAnnotation
+		*out = new(MatchedAnnotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchedUtilization != nil {
+		in, out := &in.MatchedUtilization, &out.MatchedUtilization
+		*out = new(MatchedUtilization)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ConditionsHealthyTimestamp != nil {
 		in, out := &in.ConditionsHealthyTimestamp, &out.ConditionsHealthyTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.EscalationStart != nil {
+		in, out := &in.EscalationStart, &out.EscalationStart
+		*out = new(EscalationStart)
+		**out = **in
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RemediationRoute)
+		**out = **in
+	}
+	if in.Deferral != nil {
+		in, out := &in.Deferral, &out.Deferral
+		*out = new(RemediationDeferral)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(Drain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(Verification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationExhausted != nil {
+		in, out := &in.RemediationExhausted, &out.RemediationExhausted
+		*out = (*in).DeepCopy()
+	}
+	if in.UnreachableSince != nil {
+		in, out := &in.UnreachableSince, &out.UnreachableSince
+		*out = (*in).DeepCopy()
+	}
+	if in.HeartbeatStaleSince != nil {
+		in, out := &in.HeartbeatStaleSince, &out.HeartbeatStaleSince
+		*out = (*in).DeepCopy()
+	}
+	if in.PDBViolationSince != nil {
+		in, out := &in.PDBViolationSince, &out.PDBViolationSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyNode.
@@ -264,3 +1723,72 @@ func (in *UnhealthyNode) DeepCopy() *UnhealthyNode {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthySignalVote) DeepCopyInto(out *UnhealthySignalVote) {
+	*out = *in
+	if in.Signals != nil {
+		in, out := &in.Signals, &out.Signals
+		*out = make([]UnhealthySignal, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthySignalVote.
+func (in *UnhealthySignalVote) DeepCopy() *UnhealthySignalVote {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthySignalVote)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyTaint) DeepCopyInto(out *UnhealthyTaint) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyTaint.
+func (in *UnhealthyTaint) DeepCopy() *UnhealthyTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyUtilization) DeepCopyInto(out *UnhealthyUtilization) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyUtilization.
+func (in *UnhealthyUtilization) DeepCopy() *UnhealthyUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Verification) DeepCopyInto(out *Verification) {
+	*out = *in
+	out.Resource = in.Resource
+	in.Started.DeepCopyInto(&out.Started)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Verification.
+func (in *Verification) DeepCopy() *Verification {
+	if in == nil {
+		return nil
+	}
+	out := new(Verification)
+	in.DeepCopyInto(out)
+	return out
+}
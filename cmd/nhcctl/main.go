@@ -0,0 +1,160 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// nhcctl shows which nodes NodeHealthChecks are acting on, and where each of them is in the remediation process.
+// With --validate-nhc, it shows what the NodeHealthCheck of the given manifest would do, if it was applied now.
+//
+// Usage:
+//
+//	nhcctl [--kubeconfig <path>] [-o table|json] [NodeHealthCheck name...]
+//	nhcctl [--kubeconfig <path>] [-o table|json] --validate-nhc <file>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"go.uber.org/zap/zapcore"
+
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/inspect"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func main() {
+	var output string
+	var timeout time.Duration
+	var manifest string
+	flag.StringVar(&output, "o", outputTable, "The output format, table or json.")
+	flag.DurationVar(&timeout, "timeout", 30*time.Second, "The timeout for reading the remediation state from the cluster.")
+	flag.StringVar(&manifest, "validate-nhc", "", "The path of a NodeHealthCheck manifest, which is evaluated against the cluster with a dry run, instead of showing the remediation state.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [NodeHealthCheck name...]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s [flags] --validate-nhc <file>\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Shows the remediation state of the given NodeHealthChecks, or of all NodeHealthChecks,")
+		fmt.Fprintln(flag.CommandLine.Output(), "or what the NodeHealthCheck of the given manifest would do if it was applied now.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(output, timeout, manifest, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, timeout time.Duration, manifest string, names []string) error {
+	if err := validateFlags(output, manifest, names); err != nil {
+		return err
+	}
+
+	scheme := pkgruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(remediationv1alpha1.AddToScheme(scheme))
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	// only log errors, the output is written to stdout
+	log := zap.New(zap.WriteTo(os.Stderr), zap.Level(zapcore.ErrorLevel))
+
+	return report(ctx, os.Stdout, c, log, time.Now(), output, manifest, names)
+}
+
+// validateFlags returns an error for unsupported output formats and conflicting arguments
+func validateFlags(output string, manifest string, names []string) error {
+	if output != outputTable && output != outputJSON {
+		return fmt.Errorf("unsupported output format %q, use %s or %s", output, outputTable, outputJSON)
+	}
+	if manifest != "" && len(names) > 0 {
+		return fmt.Errorf("NodeHealthCheck names can't be combined with --validate-nhc")
+	}
+	return nil
+}
+
+// report writes the remediation state of the given NodeHealthChecks, or the validation of the given manifest, in the
+// given output format
+func report(ctx context.Context, w io.Writer, c client.Client, log logr.Logger, now time.Time, output string, manifest string, names []string) error {
+	if manifest != "" {
+		nhc, err := readManifest(manifest)
+		if err != nil {
+			return err
+		}
+		validation, err := inspect.Validate(ctx, c, log, now, nhc)
+		if err != nil {
+			return err
+		}
+		if output == outputJSON {
+			return inspect.PrintJSON(w, validation)
+		}
+		return inspect.PrintValidationTable(w, validation)
+	}
+
+	state, err := inspect.Inspect(ctx, c, log, now, names...)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		return inspect.PrintJSON(w, state)
+	}
+	return inspect.PrintTable(w, state)
+}
+
+// readManifest reads the NodeHealthCheck of the given YAML or JSON file
+func readManifest(path string) (*remediationv1alpha1.NodeHealthCheck, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read NodeHealthCheck manifest")
+	}
+	nhc := &remediationv1alpha1.NodeHealthCheck{}
+	if err := yaml.UnmarshalStrict(data, nhc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse NodeHealthCheck manifest %s", path)
+	}
+	if nhc.Kind != "NodeHealthCheck" {
+		return nil, fmt.Errorf("manifest %s has kind %q, expected NodeHealthCheck", path, nhc.Kind)
+	}
+	return nhc, nil
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+const validManifest = `apiVersion: remediation.medik8s.io/v1alpha1
+kind: NodeHealthCheck
+metadata:
+  name: validated
+spec:
+  minHealthy: 1
+  remediationTemplate:
+    apiVersion: remediation.example.com/v1
+    kind: RebootRemediationTemplate
+    namespace: default
+    name: template
+  unhealthyConditions:
+  - type: Ready
+    status: "False"
+    duration: 5m
+`
+
+func writeManifest(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "nhc.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newFakeClient(now time.Time) client.Client {
+	gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+	template := &unstructured.Unstructured{}
+	template.SetGroupVersionKind(gv.WithKind("RebootRemediationTemplate"))
+	template.SetNamespace("default")
+	template.SetName("template")
+	utilruntime.Must(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template"))
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+	mapper.Add(gv.WithKind("RebootRemediationTemplate"), meta.RESTScopeNamespace)
+	mapper.Add(remediationv1alpha1.GroupVersion.WithKind("NodeHealthCheck"), meta.RESTScopeRoot)
+	scheme := pkgruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(remediationv1alpha1.AddToScheme(scheme))
+
+	nhc := &remediationv1alpha1.NodeHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+		Status:     remediationv1alpha1.NodeHealthCheckStatus{Phase: remediationv1alpha1.PhaseEnabled},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "unhealthy"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{
+				Type:               corev1.NodeReady,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.Time{Time: now.Add(-time.Hour)},
+			}},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).
+		WithObjects(template, nhc, node).WithStatusSubresource(nhc).Build()
+}
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		manifest string
+		names    []string
+		wantErr  string
+	}{
+		{
+			name:   "table output of all NodeHealthChecks",
+			output: outputTable,
+		},
+		{
+			name:   "json output of named NodeHealthChecks",
+			output: outputJSON,
+			names:  []string{"nhc"},
+		},
+		{
+			name:     "validation of a manifest",
+			output:   outputTable,
+			manifest: "nhc.yaml",
+		},
+		{
+			name:    "unsupported output format",
+			output:  "yaml",
+			wantErr: `unsupported output format "yaml"`,
+		},
+		{
+			name:     "names and manifest",
+			output:   outputTable,
+			manifest: "nhc.yaml",
+			names:    []string{"nhc"},
+			wantErr:  "can't be combined with --validate-nhc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFlags(tt.output, tt.manifest, tt.names)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateFlags() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("validateFlags() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name:     "valid manifest",
+			manifest: validManifest,
+		},
+		{
+			name:     "other kind",
+			manifest: strings.Replace(validManifest, "kind: NodeHealthCheck", "kind: MachineHealthCheck", 1),
+			wantErr:  `has kind "MachineHealthCheck"`,
+		},
+		{
+			name:     "unknown field",
+			manifest: validManifest + "  unhealthyConditionz: []\n",
+			wantErr:  "failed to parse NodeHealthCheck manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nhc, err := readManifest(writeManifest(t, tt.manifest))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readManifest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readManifest() error = %v", err)
+			}
+			if nhc.GetName() != "validated" || len(nhc.Spec.UnhealthyConditions) != 1 {
+				t.Errorf("readManifest() = %+v, want the NodeHealthCheck of the manifest", nhc)
+			}
+		})
+	}
+
+	if _, err := readManifest(filepath.Join(t.TempDir(), "missing.yaml")); err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("readManifest() of a missing file error = %v", err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	manifest := writeManifest(t, validManifest)
+	tests := []struct {
+		name     string
+		output   string
+		manifest string
+		names    []string
+		// want are substrings of the table output, wantJSON are fields of the JSON output with their values
+		want     []string
+		wantJSON map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:   "state as table",
+			output: outputTable,
+			want:   []string{"NODEHEALTHCHECK", "PHASE", "existing", string(remediationv1alpha1.PhaseEnabled)},
+		},
+		{
+			name:   "state as json",
+			output: outputJSON,
+			names:  []string{"existing"},
+			wantJSON: map[string]interface{}{
+				"nodeHealthChecks": []interface{}{map[string]interface{}{"name": "existing", "phase": string(remediationv1alpha1.PhaseEnabled)}},
+			},
+		},
+		{
+			name:    "state of a missing NodeHealthCheck",
+			output:  outputTable,
+			names:   []string{"missing"},
+			wantErr: true,
+		},
+		{
+			name:     "validation as table",
+			output:   outputTable,
+			manifest: manifest,
+			want:     []string{"NODEHEALTHCHECK:", "validated", "TEMPLATES:", "unhealthy", "Ready=False"},
+		},
+		{
+			name:     "validation as json",
+			output:   outputJSON,
+			manifest: manifest,
+			wantJSON: map[string]interface{}{"name": "validated", "templatesValid": true},
+		},
+		{
+			name:     "validation of a missing manifest",
+			output:   outputTable,
+			manifest: filepath.Join(t.TempDir(), "missing.yaml"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now().Truncate(time.Second)
+			out := &bytes.Buffer{}
+			err := report(context.Background(), out, newFakeClient(now), zap.New(), now, tt.output, tt.manifest, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("report() output doesn't contain %q:\n%s", want, out.String())
+				}
+			}
+			if tt.wantJSON == nil {
+				return
+			}
+			got := map[string]interface{}{}
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("report() output isn't JSON: %v\n%s", err, out.String())
+			}
+			for key, want := range tt.wantJSON {
+				if !jsonContains(got[key], want) {
+					t.Errorf("report() output %s = %v, want %v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+// jsonContains returns true if the decoded JSON value got contains all fields of want
+func jsonContains(got, want interface{}) bool {
+	switch want := want.(type) {
+	case map[string]interface{}:
+		gotMap, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range want {
+			if !jsonContains(gotMap[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		gotSlice, ok := got.([]interface{})
+		if !ok || len(gotSlice) != len(want) {
+			return false
+		}
+		for i := range want {
+			if !jsonContains(gotSlice[i], want[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return got == want
+	}
+}
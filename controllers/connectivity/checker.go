@@ -0,0 +1,88 @@
+package connectivity
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// MaxReportAge is the time after which a NodeConnectivityReport, which wasn't probed again, is considered stale
+const MaxReportAge = 5 * time.Minute
+
+// NotEnabledError indicates that a NHC configures a connectivity check, but connectivity reports are not enabled
+var NotEnabledError = errors.New("connectivity reports are not enabled, start the operator with --enable-connectivity-reports")
+
+// Checker evaluates the NodeConnectivityReports of nodes
+type Checker interface {
+	// IsEnabled returns true if connectivity reports are enabled
+	IsEnabled() bool
+	// GetUnreachableSince returns since when the given node is reported as unreachable, when that is at least
+	// the duration of the NHC's connectivity check. When the node is reported as unreachable for a shorter time,
+	// it returns nil and when the duration expires. Returns nil if the NHC doesn't configure a connectivity check,
+	// if connectivity reports are not enabled, or if the node's report is missing or stale. The given client is
+	// used for reading the reports.
+	GetUnreachableSince(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, now time.Time) (since *metav1.Time, expiresAfter *time.Duration, err error)
+}
+
+// NewChecker creates a new Checker. If not enabled, nodes are never considered unreachable.
+func NewChecker(enabled bool, log logr.Logger) Checker {
+	return &checker{
+		enabled: enabled,
+		log:     log.WithName("ConnectivityChecker"),
+	}
+}
+
+type checker struct {
+	enabled bool
+	log     logr.Logger
+}
+
+var _ Checker = &checker{}
+
+func (ch *checker) IsEnabled() bool {
+	return ch.enabled
+}
+
+func (ch *checker) GetUnreachableSince(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, now time.Time) (*metav1.Time, *time.Duration, error) {
+	if nhc.Spec.ConnectivityCheck == nil {
+		return nil, nil, nil
+	}
+	if !ch.enabled {
+		ch.log.Info("skipping connectivity check", "NHC", nhc.GetName(), "node", nodeName, "reason", NotEnabledError.Error())
+		return nil, nil, nil
+	}
+
+	report := &remediationv1alpha1.NodeConnectivityReport{}
+	if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, report); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrapf(err, "failed to get connectivity report of node %s", nodeName)
+	}
+
+	if report.Status.Reachable {
+		return nil, nil, nil
+	}
+	if now.Sub(report.Status.LastProbeTime.Time) > MaxReportAge {
+		// the reporter might be gone, don't act on outdated data
+		ch.log.Info("ignoring stale connectivity report", "NHC", nhc.GetName(), "node", nodeName, "lastProbeTime", report.Status.LastProbeTime)
+		return nil, nil, nil
+	}
+
+	duration := nhc.Spec.ConnectivityCheck.Duration.Duration
+	unreachableUntil := report.Status.LastTransitionTime.Add(duration)
+	if duration > 0 && !now.After(unreachableUntil) {
+		// not unreachable for long enough yet
+		return nil, pointer.Duration(unreachableUntil.Sub(now) + 1*time.Second), nil
+	}
+	return &report.Status.LastTransitionTime, nil, nil
+}
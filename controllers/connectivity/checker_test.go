@@ -0,0 +1,143 @@
+package connectivity
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connectivity checker", func() {
+
+	const nodeName = "node"
+
+	var (
+		c       client.Client
+		ch      Checker
+		nhc     *remediationv1alpha1.NodeHealthCheck
+		report  *remediationv1alpha1.NodeConnectivityReport
+		now     time.Time
+		enabled bool
+	)
+
+	BeforeEach(func() {
+		enabled = true
+		now = time.Now()
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				ConnectivityCheck: &remediationv1alpha1.ConnectivityCheck{
+					Duration: metav1.Duration{Duration: time.Minute},
+				},
+			},
+		}
+		report = &remediationv1alpha1.NodeConnectivityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: remediationv1alpha1.NodeConnectivityReportStatus{
+				Reachable:          false,
+				LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+				LastProbeTime:      metav1.NewTime(now.Add(-10 * time.Second)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(remediationv1alpha1.AddToScheme(scheme)).To(Succeed())
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		if report != nil {
+			builder = builder.WithObjects(report)
+		}
+		c = builder.Build()
+		ch = NewChecker(enabled, zap.New())
+	})
+
+	getUnreachableSince := func() (*metav1.Time, *time.Duration) {
+		since, expiresAfter, err := ch.GetUnreachableSince(context.Background(), c, nhc, nodeName, now)
+		Expect(err).ToNot(HaveOccurred())
+		return since, expiresAfter
+	}
+
+	When("the node is reported as unreachable for longer than the duration", func() {
+		It("should return since when it is unreachable", func() {
+			since, expiresAfter := getUnreachableSince()
+			Expect(since).ToNot(BeNil())
+			Expect(since.Time).To(BeTemporally("~", now.Add(-2*time.Minute), time.Second))
+			Expect(expiresAfter).To(BeNil())
+		})
+	})
+
+	When("the node is reported as unreachable for a shorter time than the duration", func() {
+		BeforeEach(func() {
+			report.Status.LastTransitionTime = metav1.NewTime(now.Add(-30 * time.Second))
+		})
+		It("should return when the duration expires", func() {
+			since, expiresAfter := getUnreachableSince()
+			Expect(since).To(BeNil())
+			Expect(expiresAfter).ToNot(BeNil())
+			Expect(*expiresAfter).To(BeNumerically("~", 31*time.Second, time.Second))
+		})
+	})
+
+	When("the node is reported as reachable", func() {
+		BeforeEach(func() {
+			report.Status.Reachable = true
+		})
+		It("should not consider the node as unreachable", func() {
+			since, expiresAfter := getUnreachableSince()
+			Expect(since).To(BeNil())
+			Expect(expiresAfter).To(BeNil())
+		})
+	})
+
+	When("the report is stale", func() {
+		BeforeEach(func() {
+			report.Status.LastProbeTime = metav1.NewTime(now.Add(-MaxReportAge - time.Second))
+		})
+		It("should ignore the report", func() {
+			since, expiresAfter := getUnreachableSince()
+			Expect(since).To(BeNil())
+			Expect(expiresAfter).To(BeNil())
+		})
+	})
+
+	When("the node has no report", func() {
+		BeforeEach(func() {
+			report = nil
+		})
+		It("should not consider the node as unreachable", func() {
+			since, expiresAfter := getUnreachableSince()
+			Expect(since).To(BeNil())
+			Expect(expiresAfter).To(BeNil())
+		})
+	})
+
+	When("no connectivity check is configured", func() {
+		BeforeEach(func() {
+			nhc.Spec.ConnectivityCheck = nil
+		})
+		It("should not consider the node as unreachable", func() {
+			since, _ := getUnreachableSince()
+			Expect(since).To(BeNil())
+		})
+	})
+
+	When("connectivity reports are not enabled", func() {
+		BeforeEach(func() {
+			enabled = false
+		})
+		It("should not consider the node as unreachable", func() {
+			since, _ := getUnreachableSince()
+			Expect(since).To(BeNil())
+		})
+	})
+})
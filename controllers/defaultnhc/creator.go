@@ -0,0 +1,172 @@
+package defaultnhc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+const (
+	// DefaultNHCName is the name of the NodeHealthCheck created by the operator
+	DefaultNHCName = "nhc-worker-default"
+	// CreatedByLabelKey is the label key for marking the NodeHealthCheck created by the operator
+	CreatedByLabelKey = "remediation.medik8s.io/created-by"
+	// CreatedByLabelValue is the label value for marking the NodeHealthCheck created by the operator
+	CreatedByLabelValue = "node-healthcheck-operator"
+	// MarkerConfigMapName is the name of the ConfigMap in the operator's namespace, which records that the default
+	// NodeHealthCheck was created. It is never created again when the marker exists, even if it was deleted.
+	MarkerConfigMapName = "nhc-default-created"
+
+	// DefaultTemplateAPIVersion, DefaultTemplateKind and DefaultTemplateName reference the template of the
+	// SelfNodeRemediation remediator, which uses its automatic strategy
+	DefaultTemplateAPIVersion = "self-node-remediation.medik8s.io/v1alpha1"
+	DefaultTemplateKind       = "SelfNodeRemediationTemplate"
+	DefaultTemplateName       = "self-node-remediation-automatic-strategy-template"
+
+	checkInterval = 10 * time.Minute
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;delete
+
+// TemplateConfig configures the remediation template of the default NodeHealthCheck
+type TemplateConfig struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// NewCreator returns a Runnable, which creates a default NodeHealthCheck on startup and periodically, in case no
+// NodeHealthCheck exists, and the default NodeHealthCheck was never created before. If not enabled, nothing is created.
+func NewCreator(c client.Client, reader client.Reader, enabled bool, template TemplateConfig, log logr.Logger) manager.Runnable {
+	return &creator{
+		client:   c,
+		reader:   reader,
+		enabled:  enabled,
+		template: template,
+		log:      log.WithName("DefaultNHCCreator"),
+	}
+}
+
+type creator struct {
+	client   client.Client
+	reader   client.Reader
+	enabled  bool
+	template TemplateConfig
+	log      logr.Logger
+}
+
+var _ manager.Runnable = &creator{}
+
+func (c *creator) Start(ctx context.Context) error {
+	if !c.enabled {
+		return nil
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.ensureDefaultNHC(ctx); err != nil {
+			c.log.Error(err, "failed to ensure default NodeHealthCheck")
+		}
+	}, checkInterval)
+	return nil
+}
+
+// ensureDefaultNHC creates the default NHC if no NHC exists, and if it wasn't created before
+func (c *creator) ensureDefaultNHC(ctx context.Context) error {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return errors.Wrap(err, "unable to get the deployment namespace")
+	}
+
+	// check the marker first, a deliberately deleted default NHC must not be recreated
+	marker := &corev1.ConfigMap{}
+	if err := c.reader.Get(ctx, client.ObjectKey{Namespace: ns, Name: MarkerConfigMapName}, marker); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to get default NodeHealthCheck marker")
+	}
+
+	// don't fight with GitOps or users, only create when there is no NHC at all.
+	// Use the API reader, the cache might not be up-to-date.
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := c.reader.List(ctx, nhcList, client.Limit(1)); err != nil {
+		return errors.Wrap(err, "failed to list NodeHealthChecks")
+	}
+	if len(nhcList.Items) > 0 {
+		return nil
+	}
+
+	// create the marker before the NHC, so that a failure between both can't lead to recreation
+	marker = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      MarkerConfigMapName,
+			Labels:    map[string]string{CreatedByLabelKey: CreatedByLabelValue},
+		},
+		Data: map[string]string{"nodeHealthCheck": DefaultNHCName},
+	}
+	if err := c.client.Create(ctx, marker); err != nil {
+		return errors.Wrap(err, "failed to create default NodeHealthCheck marker")
+	}
+
+	nhc := c.newDefaultNHC()
+	if err := c.client.Create(ctx, nhc); err != nil {
+		// allow to try again
+		if deleteErr := c.client.Delete(ctx, marker); deleteErr != nil {
+			c.log.Error(deleteErr, "failed to delete default NodeHealthCheck marker after failed NodeHealthCheck creation")
+		}
+		return errors.Wrap(err, "failed to create default NodeHealthCheck")
+	}
+	c.log.Info("created default NodeHealthCheck", "name", nhc.GetName(), "template kind", c.template.Kind)
+	return nil
+}
+
+func (c *creator) newDefaultNHC() *remediationv1alpha1.NodeHealthCheck {
+	minHealthy := intstr.FromString("51%")
+	return &remediationv1alpha1.NodeHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   DefaultNHCName,
+			Labels: map[string]string{CreatedByLabelKey: CreatedByLabelValue},
+		},
+		Spec: remediationv1alpha1.NodeHealthCheckSpec{
+			Selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "node-role.kubernetes.io/worker",
+						Operator: metav1.LabelSelectorOpExists,
+					},
+				},
+			},
+			MinHealthy: &minHealthy,
+			UnhealthyConditions: []remediationv1alpha1.UnhealthyCondition{
+				{
+					Type:     corev1.NodeReady,
+					Status:   corev1.ConditionFalse,
+					Duration: metav1.Duration{Duration: 5 * time.Minute},
+				},
+				{
+					Type:     corev1.NodeReady,
+					Status:   corev1.ConditionUnknown,
+					Duration: metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			// the namespace defaults to the operator's namespace
+			RemediationTemplate: &corev1.ObjectReference{
+				APIVersion: c.template.APIVersion,
+				Kind:       c.template.Kind,
+				Name:       c.template.Name,
+			},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package defaultnhc
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Default NHC creator", func() {
+
+	const ns = "operator-ns"
+
+	var (
+		c         client.Client
+		underTest *creator
+		objects   []client.Object
+	)
+
+	BeforeEach(func() {
+		objects = nil
+		Expect(os.Setenv("DEPLOYMENT_NAMESPACE", ns)).To(Succeed())
+		DeferCleanup(os.Unsetenv, "DEPLOYMENT_NAMESPACE")
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(remediationv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		underTest = NewCreator(c, c, true, TemplateConfig{
+			APIVersion: DefaultTemplateAPIVersion,
+			Kind:       DefaultTemplateKind,
+			Name:       DefaultTemplateName,
+		}, zap.New()).(*creator)
+	})
+
+	listNHCs := func() []remediationv1alpha1.NodeHealthCheck {
+		nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+		Expect(c.List(context.Background(), nhcList)).To(Succeed())
+		return nhcList.Items
+	}
+
+	When("no NHC exists", func() {
+		It("should create the default NHC and the marker", func() {
+			Expect(underTest.ensureDefaultNHC(context.Background())).To(Succeed())
+
+			nhcs := listNHCs()
+			Expect(nhcs).To(HaveLen(1))
+			Expect(nhcs[0].GetName()).To(Equal(DefaultNHCName))
+			Expect(nhcs[0].GetLabels()).To(HaveKeyWithValue(CreatedByLabelKey, CreatedByLabelValue))
+			Expect(nhcs[0].Spec.RemediationTemplate.Kind).To(Equal(DefaultTemplateKind))
+			Expect(nhcs[0].Spec.RemediationTemplate.Namespace).To(BeEmpty())
+
+			marker := &corev1.ConfigMap{}
+			Expect(c.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: MarkerConfigMapName}, marker)).To(Succeed())
+		})
+
+		It("should not recreate the default NHC after it was deleted", func() {
+			Expect(underTest.ensureDefaultNHC(context.Background())).To(Succeed())
+			nhcs := listNHCs()
+			Expect(nhcs).To(HaveLen(1))
+			Expect(c.Delete(context.Background(), &nhcs[0])).To(Succeed())
+
+			Expect(underTest.ensureDefaultNHC(context.Background())).To(Succeed())
+			Expect(listNHCs()).To(BeEmpty())
+		})
+	})
+
+	When("a NHC exists", func() {
+		BeforeEach(func() {
+			objects = append(objects, &remediationv1alpha1.NodeHealthCheck{ObjectMeta: metav1.ObjectMeta{Name: "gitops"}})
+		})
+
+		It("should not create the default NHC", func() {
+			Expect(underTest.ensureDefaultNHC(context.Background())).To(Succeed())
+			nhcs := listNHCs()
+			Expect(nhcs).To(HaveLen(1))
+			Expect(nhcs[0].GetName()).To(Equal("gitops"))
+		})
+	})
+
+	When("the operator namespace is unknown", func() {
+		BeforeEach(func() {
+			Expect(os.Unsetenv("DEPLOYMENT_NAMESPACE")).To(Succeed())
+		})
+
+		It("should fail", func() {
+			Expect(underTest.ensureDefaultNHC(context.Background())).ToNot(Succeed())
+			Expect(listNHCs()).To(BeEmpty())
+		})
+	})
+})
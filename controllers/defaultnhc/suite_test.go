@@ -0,0 +1,13 @@
+package defaultnhc
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDefaultNHC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DefaultNHC Suite")
+}
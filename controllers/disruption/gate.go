@@ -0,0 +1,133 @@
+package disruption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// Gate checks if remediating a node would violate PodDisruptionBudgets
+type Gate interface {
+	// IsRemediationAllowed returns false and an explanation naming the violated PodDisruptionBudgets, if removing the
+	// pods of the given node would violate PodDisruptionBudgets in the namespaces configured in the NHC's
+	// RespectPodDisruptionBudgets. Like the eviction API, only healthy pods count against the disruptions allowed by
+	// the budget's status. The given client is used for reading pods and PodDisruptionBudgets.
+	IsRemediationAllowed(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) (allowed bool, message string, err error)
+}
+
+// NewGate creates a new Gate
+func NewGate(log logr.Logger) Gate {
+	return &gate{
+		log: log.WithName("DisruptionGate"),
+	}
+}
+
+type gate struct {
+	log logr.Logger
+}
+
+var _ Gate = &gate{}
+
+func (g *gate) IsRemediationAllowed(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) (bool, string, error) {
+	config := nhc.Spec.RespectPodDisruptionBudgets
+	if config == nil || !config.Enabled {
+		return true, "", nil
+	}
+
+	namespaces := config.Namespaces
+	if len(namespaces) == 0 {
+		// all namespaces
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	var violations []string
+	for _, namespace := range namespaces {
+		namespaceViolations, err := g.getViolations(ctx, c, namespace, node)
+		if err != nil {
+			return false, "", err
+		}
+		violations = append(violations, namespaceViolations...)
+	}
+	if len(violations) == 0 {
+		return true, "", nil
+	}
+
+	sort.Strings(violations)
+	message := fmt.Sprintf("removing the pods of node %s would violate PodDisruptionBudgets: %s", node.GetName(), strings.Join(violations, ", "))
+	g.log.Info("PodDisruptionBudgets would be violated, deferring remediation", "NHC", nhc.GetName(), "node", node.GetName(), "budgets", violations)
+	return false, message, nil
+}
+
+// getViolations returns the PodDisruptionBudgets of the given namespace, which would be violated by removing the
+// pods of the given node, with an explanation
+func (g *gate) getViolations(ctx context.Context, c client.Client, namespace string, node *corev1.Node) ([]string, error) {
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := c.List(ctx, pdbs, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list PodDisruptionBudgets")
+	}
+	if len(pdbs.Items) == 0 {
+		return nil, nil
+	}
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods")
+	}
+
+	var violations []string
+	for _, pdb := range pdbs.Items {
+		// a nil selector selects no pods
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			g.log.Info("ignoring PodDisruptionBudget with invalid selector", "namespace", pdb.GetNamespace(), "name", pdb.GetName(), "error", err.Error())
+			continue
+		}
+		healthyPods := 0
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Spec.NodeName == node.GetName() && pod.GetNamespace() == pdb.GetNamespace() && selector.Matches(labels.Set(pod.GetLabels())) && isHealthy(pod) {
+				healthyPods++
+			}
+		}
+		if healthyPods == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", pdb.GetNamespace(), pdb.GetName())
+		// like the eviction API, don't trust outdated budgets
+		if pdb.Status.ObservedGeneration < pdb.GetGeneration() {
+			violations = append(violations, fmt.Sprintf("%s (status is outdated)", name))
+			continue
+		}
+		if int32(healthyPods) > pdb.Status.DisruptionsAllowed {
+			violations = append(violations, fmt.Sprintf("%s (%d healthy pods on the node, %d disruptions allowed)", name, healthyPods, pdb.Status.DisruptionsAllowed))
+		}
+	}
+	return violations, nil
+}
+
+// isHealthy returns true if the given pod counts as healthy for PodDisruptionBudgets: it is running, not terminating,
+// and ready
+func isHealthy(pod *corev1.Pod) bool {
+	if pod.GetDeletionTimestamp() != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
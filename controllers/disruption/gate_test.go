@@ -0,0 +1,155 @@
+package disruption
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Disruption gate", func() {
+
+	const (
+		nodeName  = "node"
+		namespace = "critical"
+	)
+
+	var (
+		c       client.Client
+		g       Gate
+		nhc     *remediationv1alpha1.NodeHealthCheck
+		node    *corev1.Node
+		objects []client.Object
+	)
+
+	newPod := func(name, podNodeName string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"app": "critical"}},
+			Spec:       corev1.PodSpec{NodeName: podNodeName},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	newPDB := func(disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "budget"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "critical"}},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+
+	BeforeEach(func() {
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				RespectPodDisruptionBudgets: &remediationv1alpha1.RespectPodDisruptionBudgets{
+					Enabled:    true,
+					Namespaces: []string{namespace},
+				},
+			},
+		}
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		objects = nil
+	})
+
+	JustBeforeEach(func() {
+		c = fake.NewClientBuilder().WithObjects(objects...).Build()
+		g = NewGate(zap.New())
+	})
+
+	isAllowed := func() (bool, string) {
+		allowed, message, err := g.IsRemediationAllowed(context.Background(), c, nhc, node)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(Equal(message == ""))
+		return allowed, message
+	}
+
+	When("the node hosts the last healthy replica", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newPod("on-node", nodeName, true), newPod("other", "other", false), newPDB(0)}
+		})
+		It("should defer remediation naming the budget", func() {
+			allowed, message := isAllowed()
+			Expect(allowed).To(BeFalse())
+			Expect(message).To(ContainSubstring("critical/budget"))
+		})
+
+		When("the namespace isn't configured", func() {
+			BeforeEach(func() {
+				nhc.Spec.RespectPodDisruptionBudgets.Namespaces = []string{"other"}
+			})
+			It("should allow remediation", func() {
+				Expect(isAllowed()).To(BeTrue())
+			})
+		})
+
+		When("no namespace is configured", func() {
+			BeforeEach(func() {
+				nhc.Spec.RespectPodDisruptionBudgets.Namespaces = nil
+			})
+			It("should defer remediation", func() {
+				allowed, _ := isAllowed()
+				Expect(allowed).To(BeFalse())
+			})
+		})
+
+		When("respecting PodDisruptionBudgets isn't enabled", func() {
+			BeforeEach(func() {
+				nhc.Spec.RespectPodDisruptionBudgets.Enabled = false
+			})
+			It("should allow remediation", func() {
+				Expect(isAllowed()).To(BeTrue())
+			})
+		})
+	})
+
+	When("the budget allows the disruption of the node's healthy pods", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newPod("on-node", nodeName, true), newPod("other", "other", true), newPDB(1)}
+		})
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("the node's pods are not ready", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newPod("on-node", nodeName, false), newPod("other", "other", true), newPDB(0)}
+		})
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("the budget's status is outdated", func() {
+		BeforeEach(func() {
+			pdb := newPDB(1)
+			pdb.Generation = 2
+			pdb.Status.ObservedGeneration = 1
+			objects = []client.Object{newPod("on-node", nodeName, true), pdb}
+		})
+		It("should defer remediation", func() {
+			allowed, message := isAllowed()
+			Expect(allowed).To(BeFalse())
+			Expect(message).To(ContainSubstring("outdated"))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package disruption
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDisruption(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Disruption Gate Suite")
+}
@@ -0,0 +1,195 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
+)
+
+// maxListedPods is the maximum number of pod names in drain messages
+const maxListedPods = 5
+
+// BlockedError indicates that pods prevent the drain of a node, according to the drain configuration
+type BlockedError struct {
+	msg string
+}
+
+func (e BlockedError) Error() string {
+	return e.msg
+}
+
+// Drainer drains unhealthy nodes before remediation
+type Drainer interface {
+	// Drain cordons the given node, and evicts its pods according to the given config. It returns true when no pods
+	// are left to evict, and otherwise a message about the pods which are left. Returns a BlockedError without
+	// evicting any pods, when pods prevent the drain, e.g. pods with emptyDir volumes without DeleteEmptyDirData.
+	// The given client is used for managing the node and its pods.
+	Drain(ctx context.Context, c client.Client, node *corev1.Node, config *remediationv1alpha1.PreRemediationDrain) (bool, string, error)
+	// Uncordon marks the node with the given name as schedulable again, if it was cordoned by Drain
+	Uncordon(ctx context.Context, c client.Client, nodeName string) error
+}
+
+// NewDrainer creates a new Drainer
+func NewDrainer(log logr.Logger) Drainer {
+	return &drainer{
+		log: log.WithName("Drainer"),
+	}
+}
+
+type drainer struct {
+	log logr.Logger
+}
+
+var _ Drainer = &drainer{}
+
+func (d *drainer) Drain(ctx context.Context, c client.Client, node *corev1.Node, config *remediationv1alpha1.PreRemediationDrain) (bool, string, error) {
+	if err := d.cordon(ctx, c, node); err != nil {
+		return false, "", err
+	}
+
+	allPods := &corev1.PodList{}
+	if err := c.List(ctx, allPods); err != nil {
+		return false, "", errors.Wrapf(err, "failed to list pods")
+	}
+	var pods, blocking []corev1.Pod
+	for _, pod := range allPods.Items {
+		if pod.Spec.NodeName != node.GetName() || isTerminated(&pod) || isMirrorPod(&pod) {
+			continue
+		}
+		if isDaemonSetPod(&pod) {
+			if !config.IgnoreDaemonSets {
+				blocking = append(blocking, pod)
+			}
+			continue
+		}
+		if hasEmptyDir(&pod) && !config.DeleteEmptyDirData {
+			blocking = append(blocking, pod)
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	if len(blocking) > 0 {
+		return false, "", BlockedError{msg: fmt.Sprintf("pods of DaemonSets or with emptyDir data prevent the drain: %s", podNames(blocking))}
+	}
+	if len(pods) == 0 {
+		return true, "", nil
+	}
+
+	var disruptionBudgetPods []corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.GetDeletionTimestamp() != nil {
+			// evicted already, waiting for termination
+			continue
+		}
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.GetName(), Namespace: pod.GetNamespace()},
+		}
+		if err := c.SubResource("eviction").Create(ctx, pod, eviction); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if apierrors.IsTooManyRequests(err) {
+				// the eviction would violate a pod disruption budget, retry later
+				disruptionBudgetPods = append(disruptionBudgetPods, *pod)
+				continue
+			}
+			return false, "", errors.Wrapf(err, "failed to evict pod %s/%s", pod.GetNamespace(), pod.GetName())
+		}
+		d.log.Info("evicted pod", "node", node.GetName(), "pod", pod.GetName(), "namespace", pod.GetNamespace())
+	}
+
+	message := fmt.Sprintf("waiting for %d pods to terminate: %s", len(pods), podNames(pods))
+	if len(disruptionBudgetPods) > 0 {
+		message += fmt.Sprintf(", eviction prevented by disruption budgets: %s", podNames(disruptionBudgetPods))
+	}
+	return false, message, nil
+}
+
+func (d *drainer) cordon(ctx context.Context, c client.Client, node *corev1.Node) error {
+	if node.Spec.Unschedulable {
+		// cordoned already, by us or by someone else who should uncordon it
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[annotations.CordonedByNHCAnnotation] = "true"
+	if err := c.Patch(ctx, node, patch); err != nil {
+		return errors.Wrapf(err, "failed to cordon node %s", node.GetName())
+	}
+	d.log.Info("cordoned node", "node", node.GetName())
+	return nil
+}
+
+func (d *drainer) Uncordon(ctx context.Context, c client.Client, nodeName string) error {
+	node := &corev1.Node{}
+	if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get node %s", nodeName)
+	}
+	if _, cordoned := node.GetAnnotations()[annotations.CordonedByNHCAnnotation]; !cordoned {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = false
+	delete(node.Annotations, annotations.CordonedByNHCAnnotation)
+	if err := c.Patch(ctx, node, patch); err != nil {
+		return errors.Wrapf(err, "failed to uncordon node %s", nodeName)
+	}
+	d.log.Info("uncordoned node", "node", nodeName)
+	return nil
+}
+
+func isTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+func isMirrorPod(pod *corev1.Pod) bool {
+	_, isMirror := pod.GetAnnotations()[corev1.MirrorPodAnnotationKey]
+	return isMirror
+}
+
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	controller := metav1.GetControllerOf(pod)
+	return controller != nil && controller.Kind == "DaemonSet"
+}
+
+func hasEmptyDir(pod *corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// podNames returns the sorted namespaced names of the given pods, shortened to maxListedPods
+func podNames(pods []corev1.Pod) string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, fmt.Sprintf("%s/%s", pod.GetNamespace(), pod.GetName()))
+	}
+	sort.Strings(names)
+	if len(names) > maxListedPods {
+		names = append(names[:maxListedPods], "...")
+	}
+	return strings.Join(names, ", ")
+}
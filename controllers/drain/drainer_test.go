@@ -0,0 +1,175 @@
+package drain
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
+)
+
+var _ = Describe("Drainer", func() {
+
+	const nodeName = "node"
+
+	var (
+		c         client.Client
+		d         Drainer
+		node      *corev1.Node
+		config    *remediationv1alpha1.PreRemediationDrain
+		objects   []client.Object
+		evictions []string
+		pdbPods   map[string]bool
+	)
+
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	getNode := func() *corev1.Node {
+		n := &corev1.Node{}
+		Expect(c.Get(context.Background(), client.ObjectKey{Name: nodeName}, n)).To(Succeed())
+		return n
+	}
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		config = &remediationv1alpha1.PreRemediationDrain{Enabled: true}
+		objects = nil
+		evictions = nil
+		pdbPods = map[string]bool{}
+	})
+
+	JustBeforeEach(func() {
+		c = fake.NewClientBuilder().WithObjects(append(objects, node)...).WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+				Expect(subResourceName).To(Equal("eviction"))
+				if pdbPods[obj.GetName()] {
+					return apierrors.NewTooManyRequests("disruption budget", 10)
+				}
+				evictions = append(evictions, obj.GetName())
+				return nil
+			},
+		}).Build()
+		d = NewDrainer(zap.New())
+	})
+
+	When("the node has no pods", func() {
+		It("cordons the node and completes", func() {
+			done, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeTrue())
+			updated := getNode()
+			Expect(updated.Spec.Unschedulable).To(BeTrue())
+			Expect(updated.Annotations).To(HaveKey(annotations.CordonedByNHCAnnotation))
+		})
+
+		It("uncordons the node it cordoned", func() {
+			_, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.Uncordon(context.Background(), c, nodeName)).To(Succeed())
+			updated := getNode()
+			Expect(updated.Spec.Unschedulable).To(BeFalse())
+			Expect(updated.Annotations).ToNot(HaveKey(annotations.CordonedByNHCAnnotation))
+		})
+	})
+
+	When("the node was cordoned by someone else", func() {
+		BeforeEach(func() {
+			node.Spec.Unschedulable = true
+		})
+
+		It("doesn't uncordon the node", func() {
+			_, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.Uncordon(context.Background(), c, nodeName)).To(Succeed())
+			Expect(getNode().Spec.Unschedulable).To(BeTrue())
+		})
+	})
+
+	When("the node has pods", func() {
+		BeforeEach(func() {
+			terminated := newPod("terminated", nodeName)
+			terminated.Status.Phase = corev1.PodSucceeded
+			mirror := newPod("mirror", nodeName)
+			mirror.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "mirror"}
+			objects = append(objects, newPod("workload", nodeName), newPod("protected", nodeName),
+				newPod("other-node", "other"), terminated, mirror)
+			pdbPods["protected"] = true
+		})
+
+		It("evicts pods of the node, and waits for their termination", func() {
+			done, message, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeFalse())
+			Expect(evictions).To(ConsistOf("workload"))
+			Expect(message).To(Equal("waiting for 2 pods to terminate: default/protected, default/workload, " +
+				"eviction prevented by disruption budgets: default/protected"))
+		})
+	})
+
+	When("the node has pods of DaemonSets", func() {
+		BeforeEach(func() {
+			pod := newPod("daemon", nodeName)
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "DaemonSet", Name: "daemon", UID: "1234", Controller: pointer.Bool(true),
+			}}
+			objects = append(objects, pod, newPod("workload", nodeName))
+		})
+
+		It("is blocked without evicting pods", func() {
+			_, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).To(BeAssignableToTypeOf(BlockedError{}))
+			Expect(err.Error()).To(ContainSubstring("default/daemon"))
+			Expect(evictions).To(BeEmpty())
+		})
+
+		It("ignores them when configured", func() {
+			config.IgnoreDaemonSets = true
+			_, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(evictions).To(ConsistOf("workload"))
+		})
+	})
+
+	When("the node has pods with emptyDir volumes", func() {
+		BeforeEach(func() {
+			pod := newPod("cache", nodeName)
+			pod.Spec.Volumes = []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+			objects = append(objects, pod)
+		})
+
+		It("is blocked", func() {
+			_, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).To(BeAssignableToTypeOf(BlockedError{}))
+			Expect(evictions).To(BeEmpty())
+		})
+
+		It("evicts them when configured", func() {
+			config.DeleteEmptyDirData = true
+			_, _, err := d.Drain(context.Background(), c, node, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(evictions).To(ConsistOf("cache"))
+		})
+	})
+
+	When("the node doesn't exist anymore", func() {
+		It("doesn't fail uncordoning", func() {
+			Expect(d.Uncordon(context.Background(), fake.NewClientBuilder().Build(), nodeName)).To(Succeed())
+		})
+	})
+})
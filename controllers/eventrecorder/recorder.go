@@ -0,0 +1,158 @@
+package eventrecorder
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// aggregationWindow is the time in which identical events are aggregated into one
+	aggregationWindow = 5 * time.Minute
+	// eventsQPS and eventsBurst configure the token bucket for emitting events which aren't aggregated
+	eventsQPS   = 1
+	eventsBurst = 25
+	// pruneAfter is the time after the end of an aggregation window, after which it is dropped,
+	// including the count of suppressed events
+	pruneAfter = aggregationWindow
+)
+
+// NewRecorder wraps the given EventRecorder with deduplication and rate limiting. Identical events, with the same
+// object, type, reason and message, are emitted once per aggregation window. The count of suppressed events is
+// added to the next emitted event. Events with one of the given priority reasons are always emitted.
+func NewRecorder(delegate record.EventRecorder, priorityReasons []string, log logr.Logger) record.EventRecorder {
+	return newRecorder(delegate, priorityReasons, clock.RealClock{}, log)
+}
+
+func newRecorder(delegate record.EventRecorder, priorityReasons []string, clock clock.PassiveClock, log logr.Logger) *recorder {
+	return &recorder{
+		delegate:        delegate,
+		priorityReasons: sets.New[string](priorityReasons...),
+		clock:           clock,
+		limiter:         flowcontrol.NewTokenBucketPassiveRateLimiterWithClock(eventsQPS, eventsBurst, clock),
+		aggregations:    make(map[eventKey]*aggregation),
+		log:             log.WithName("EventRecorder"),
+	}
+}
+
+// eventKey identifies identical events
+type eventKey struct {
+	kind      string
+	namespace string
+	name      string
+	uid       types.UID
+	eventType string
+	reason    string
+	message   string
+}
+
+// aggregation tracks the events of a key which were suppressed in the current window
+type aggregation struct {
+	windowEnd  time.Time
+	suppressed int
+}
+
+type recorder struct {
+	delegate        record.EventRecorder
+	priorityReasons sets.Set[string]
+	clock           clock.PassiveClock
+	limiter         flowcontrol.PassiveRateLimiter
+	lock            sync.Mutex
+	aggregations    map[eventKey]*aggregation
+	log             logr.Logger
+}
+
+var _ record.EventRecorder = &recorder{}
+
+func (r *recorder) Event(object runtime.Object, eventType, reason, message string) {
+	if message, ok := r.shouldEmit(object, eventType, reason, message); ok {
+		r.delegate.Event(object, eventType, reason, message)
+	}
+}
+
+func (r *recorder) Eventf(object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventType, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.shouldEmit(object, eventType, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.delegate.AnnotatedEventf(object, annotations, eventType, reason, "%s", message)
+	}
+}
+
+// shouldEmit returns true if the event should be emitted, together with the message to use
+func (r *recorder) shouldEmit(object runtime.Object, eventType, reason, message string) (string, bool) {
+	if r.priorityReasons.Has(reason) {
+		return message, true
+	}
+	key, err := newEventKey(object, eventType, reason, message)
+	if err != nil {
+		r.log.Error(err, "failed to get event key, emitting event without aggregation", "reason", reason)
+		return message, true
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	r.prune(now)
+
+	agg, exists := r.aggregations[key]
+	if exists && now.Before(agg.windowEnd) {
+		agg.suppressed++
+		return "", false
+	}
+	if !exists {
+		agg = &aggregation{}
+		r.aggregations[key] = agg
+	}
+	if !r.limiter.TryAccept() {
+		// keep the expired window, so that the next identical event is emitted with the updated count
+		agg.windowEnd = now
+		agg.suppressed++
+		r.log.V(1).Info("rate limited event", "reason", reason, "object", key.name)
+		return "", false
+	}
+
+	if agg.suppressed > 0 {
+		message = fmt.Sprintf("%s (%d identical events were suppressed)", message, agg.suppressed)
+	}
+	agg.windowEnd = now.Add(aggregationWindow)
+	agg.suppressed = 0
+	return message, true
+}
+
+// prune drops aggregations whose window ended a while ago
+func (r *recorder) prune(now time.Time) {
+	for key, agg := range r.aggregations {
+		if now.After(agg.windowEnd.Add(pruneAfter)) {
+			delete(r.aggregations, key)
+		}
+	}
+}
+
+func newEventKey(object runtime.Object, eventType, reason, message string) (eventKey, error) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return eventKey{}, err
+	}
+	return eventKey{
+		kind:      object.GetObjectKind().GroupVersionKind().Kind,
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+		uid:       accessor.GetUID(),
+		eventType: eventType,
+		reason:    reason,
+		message:   message,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package eventrecorder
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("EventRecorder", func() {
+
+	const priorityReason = "RemediationCreated"
+
+	var (
+		fakeRecorder *record.FakeRecorder
+		fakeClock    *clocktesting.FakeClock
+		underTest    *recorder
+		nhc          *remediationv1alpha1.NodeHealthCheck
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(100)
+		fakeClock = clocktesting.NewFakeClock(time.Now())
+		underTest = newRecorder(fakeRecorder, []string{priorityReason}, fakeClock, zap.New())
+		nhc = &remediationv1alpha1.NodeHealthCheck{ObjectMeta: metav1.ObjectMeta{Name: "nhc", UID: "1234"}}
+	})
+
+	drainEvents := func() []string {
+		var events []string
+		for len(fakeRecorder.Events) > 0 {
+			events = append(events, <-fakeRecorder.Events)
+		}
+		return events
+	}
+
+	expectEvents := func(expected ...string) {
+		GinkgoHelper()
+		events := drainEvents()
+		if len(expected) == 0 {
+			Expect(events).To(BeEmpty())
+			return
+		}
+		Expect(events).To(Equal(expected))
+	}
+
+	It("should aggregate identical events", func() {
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		underTest.Eventf(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped %s", "node-1")
+		expectEvents("Warning RemediationSkipped skipped node-1")
+
+		By("emitting the count after the window")
+		fakeClock.Step(aggregationWindow)
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		expectEvents("Warning RemediationSkipped skipped node-1 (2 identical events were suppressed)")
+
+		By("starting a new window")
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		expectEvents()
+	})
+
+	It("should not aggregate different events", func() {
+		otherNHC := nhc.DeepCopy()
+		otherNHC.Name = "other"
+		otherNHC.UID = "5678"
+
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-2")
+		underTest.Event(nhc, corev1.EventTypeNormal, "DetectedUnhealthy", "skipped node-1")
+		underTest.Event(otherNHC, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		expectEvents(
+			"Warning RemediationSkipped skipped node-1",
+			"Warning RemediationSkipped skipped node-2",
+			"Normal DetectedUnhealthy skipped node-1",
+			"Warning RemediationSkipped skipped node-1",
+		)
+	})
+
+	It("should not aggregate priority events", func() {
+		underTest.Event(nhc, corev1.EventTypeNormal, priorityReason, "created node-1")
+		underTest.Event(nhc, corev1.EventTypeNormal, priorityReason, "created node-1")
+		expectEvents(
+			"Normal RemediationCreated created node-1",
+			"Normal RemediationCreated created node-1",
+		)
+	})
+
+	It("should rate limit events", func() {
+		for i := 0; i < eventsBurst+1; i++ {
+			underTest.Event(nhc, corev1.EventTypeNormal, "DetectedUnhealthy", fmt.Sprintf("node-%d", i))
+		}
+		Expect(drainEvents()).To(HaveLen(eventsBurst))
+
+		By("not rate limiting priority events")
+		underTest.Event(nhc, corev1.EventTypeNormal, priorityReason, "created node-1")
+		expectEvents("Normal RemediationCreated created node-1")
+
+		By("emitting the rate limited event with its count when tokens are available again")
+		fakeClock.Step(time.Second)
+		underTest.Event(nhc, corev1.EventTypeNormal, "DetectedUnhealthy", fmt.Sprintf("node-%d", eventsBurst))
+		expectEvents(fmt.Sprintf("Normal DetectedUnhealthy node-%d (1 identical events were suppressed)", eventsBurst))
+	})
+
+	It("should prune old aggregations", func() {
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-1")
+		Expect(underTest.aggregations).To(HaveLen(1))
+
+		fakeClock.Step(aggregationWindow + pruneAfter + time.Second)
+		underTest.Event(nhc, corev1.EventTypeWarning, "RemediationSkipped", "skipped node-2")
+		Expect(underTest.aggregations).To(HaveLen(1))
+		expectEvents(
+			"Warning RemediationSkipped skipped node-1",
+			"Warning RemediationSkipped skipped node-2",
+		)
+	})
+})
@@ -0,0 +1,13 @@
+package eventrecorder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEventRecorder(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "EventRecorder Suite")
+}
@@ -0,0 +1,144 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/medik8s/node-healthcheck-operator/metrics"
+)
+
+const (
+	// EventTypeRemediationStarted is the type of events sent when a remediation CR was created
+	EventTypeRemediationStarted = "io.medik8s.nhc.remediation.started"
+	// EventTypeRemediationTimedOut is the type of events sent when a remediation timed out or failed
+	EventTypeRemediationTimedOut = "io.medik8s.nhc.remediation.timedout"
+	// EventTypeRemediationCompleted is the type of events sent when a remediated node is healthy again
+	EventTypeRemediationCompleted = "io.medik8s.nhc.remediation.completed"
+
+	// queueSize is the number of events which can be queued for sending. When the queue is full, new events are dropped.
+	queueSize = 100
+)
+
+var (
+	retryDelay = 1 * time.Second
+	maxRetries = 5
+)
+
+// RemediationEventData is the data payload of all remediation lifecycle events
+type RemediationEventData struct {
+	// NodeHealthCheck is the name of the NHC which remediates the node
+	NodeHealthCheck string `json:"nodeHealthCheck"`
+	// Node is the name of the remediated node
+	Node string `json:"node"`
+	// Remediation is the reference to the remediation CR
+	Remediation corev1.ObjectReference `json:"remediation"`
+	// TemplateKind is the kind of the remediation template which was used for creating the remediation CR
+	TemplateKind string `json:"templateKind"`
+	// TemplateName is the name of the remediation template which was used for creating the remediation CR, if known
+	TemplateName string `json:"templateName,omitempty"`
+	// Started is the time when the remediation CR was created
+	Started time.Time `json:"started"`
+}
+
+// Emitter sends remediation lifecycle events in CloudEvents format to a sink
+type Emitter interface {
+	// Emit queues an event of the given type for being sent asynchronously. It never blocks.
+	Emit(eventType string, data RemediationEventData)
+	// Start sends queued events until the given context is cancelled
+	Start(ctx context.Context) error
+}
+
+// NewEmitter creates a new Emitter which sends events to the given sink URI. If the sink URI is empty,
+// no events are sent.
+func NewEmitter(sinkURI string, log logr.Logger) (Emitter, error) {
+	if sinkURI == "" {
+		return DummyEmitter{}, nil
+	}
+	client, err := cloudevents.NewClientHTTP(cloudevents.WithTarget(sinkURI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloudEvents client for sink %s: %w", sinkURI, err)
+	}
+	return newEmitter(client, log), nil
+}
+
+func newEmitter(client cloudevents.Client, log logr.Logger) *emitter {
+	return &emitter{
+		client: client,
+		log:    log.WithName("CloudEventsEmitter"),
+		queue:  make(chan cloudevents.Event, queueSize),
+	}
+}
+
+type emitter struct {
+	client cloudevents.Client
+	log    logr.Logger
+	queue  chan cloudevents.Event
+}
+
+var _ Emitter = &emitter{}
+
+func (e *emitter) Emit(eventType string, data RemediationEventData) {
+	event := cloudevents.NewEvent()
+	event.SetID(string(uuid.NewUUID()))
+	event.SetType(eventType)
+	event.SetSource(fmt.Sprintf("/apis/remediation.medik8s.io/v1alpha1/nodehealthchecks/%s", data.NodeHealthCheck))
+	event.SetSubject(data.Node)
+	event.SetTime(time.Now())
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		e.log.Error(err, "failed to set event data, dropping event", "type", eventType, "node", data.Node)
+		metrics.ObserveCloudEventDeadLetter(eventType)
+		return
+	}
+
+	select {
+	case e.queue <- event:
+	default:
+		e.log.Info("event queue is full, dropping event", "type", eventType, "node", data.Node)
+		metrics.ObserveCloudEventDeadLetter(eventType)
+	}
+}
+
+func (e *emitter) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-e.queue:
+			e.send(ctx, event)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the queue should be drained on every instance
+func (e *emitter) NeedLeaderElection() bool {
+	return false
+}
+
+func (e *emitter) send(ctx context.Context, event cloudevents.Event) {
+	sendCtx := cloudevents.ContextWithRetriesExponentialBackoff(ctx, retryDelay, maxRetries)
+	if result := e.client.Send(sendCtx, event); cloudevents.IsUndelivered(result) || !cloudevents.IsACK(result) {
+		e.log.Error(result, "failed to send event, dropping it", "type", event.Type(), "node", event.Subject())
+		metrics.ObserveCloudEventDeadLetter(event.Type())
+	}
+}
+
+// DummyEmitter can be used when no sink is configured, or in tests
+// Using NewEmitter is recommended though
+type DummyEmitter struct{}
+
+var _ Emitter = DummyEmitter{}
+
+// Emit is a no op
+func (d DummyEmitter) Emit(_ string, _ RemediationEventData) {}
+
+// Start returns immediately, there is nothing to send
+func (d DummyEmitter) Start(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package eventsink
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol/gochan"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("CloudEvents emitter", func() {
+
+	var (
+		ctx      context.Context
+		received chan cloudevents.Event
+		e        *emitter
+		data     RemediationEventData
+	)
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		// in memory protocol, events sent by the emitter are received by the receiver
+		protocol := gochan.New()
+		client, err := cloudevents.NewClient(protocol)
+		Expect(err).ToNot(HaveOccurred())
+
+		received = make(chan cloudevents.Event, queueSize)
+		go func() {
+			defer GinkgoRecover()
+			Expect(client.StartReceiver(ctx, func(event cloudevents.Event) {
+				received <- event
+			})).To(Succeed())
+		}()
+
+		e = newEmitter(client, ctrl.Log)
+		go func() {
+			defer GinkgoRecover()
+			Expect(e.Start(ctx)).To(Succeed())
+		}()
+
+		data = RemediationEventData{
+			NodeHealthCheck: "test-nhc",
+			Node:            "test-node",
+			Remediation: corev1.ObjectReference{
+				APIVersion: "test.medik8s.io/v1alpha1",
+				Kind:       "TestRemediation",
+				Namespace:  "test-ns",
+				Name:       "test-node",
+			},
+			TemplateKind: "TestRemediationTemplate",
+			TemplateName: "test-template",
+			Started:      time.Now().Truncate(time.Second).UTC(),
+		}
+	})
+
+	DescribeTable("should send events",
+		func(eventType string) {
+			e.Emit(eventType, data)
+
+			var event cloudevents.Event
+			Eventually(received, "5s").Should(Receive(&event))
+			Expect(event.Validate()).To(Succeed())
+			Expect(event.Type()).To(Equal(eventType))
+			Expect(event.Source()).To(Equal("/apis/remediation.medik8s.io/v1alpha1/nodehealthchecks/test-nhc"))
+			Expect(event.Subject()).To(Equal("test-node"))
+			Expect(event.DataContentType()).To(Equal(cloudevents.ApplicationJSON))
+
+			payload := RemediationEventData{}
+			Expect(event.DataAs(&payload)).To(Succeed())
+			Expect(payload).To(Equal(data))
+		},
+		Entry("started", EventTypeRemediationStarted),
+		Entry("timed out", EventTypeRemediationTimedOut),
+		Entry("completed", EventTypeRemediationCompleted),
+	)
+
+	It("should not block when the queue is full", func() {
+		blocked := newEmitter(e.client, ctrl.Log)
+		done := make(chan struct{})
+		go func() {
+			// not started, so nothing is sent
+			for i := 0; i < queueSize+10; i++ {
+				blocked.Emit(EventTypeRemediationStarted, data)
+			}
+			close(done)
+		}()
+		Eventually(done, "5s").Should(BeClosed())
+		Expect(blocked.queue).To(HaveLen(queueSize))
+	})
+})
@@ -0,0 +1,13 @@
+package eventsink
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEventSink(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Event Sink Suite")
+}
@@ -0,0 +1,111 @@
+package heartbeat
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var (
+	// NodeNameField is the path of the optional field of heartbeat objects, which contains the name of their node.
+	// Heartbeat objects without it need to be named like their node.
+	NodeNameField = []string{"spec", "nodeName"}
+	// LastHeartbeatTimeField is the path of the field of heartbeat objects, which contains the RFC 3339 time of the
+	// latest heartbeat
+	LastHeartbeatTimeField = []string{"status", "lastHeartbeatTime"}
+)
+
+// NotEnabledError indicates that a NHC configures a heartbeat source, but heartbeat sources are not enabled
+var NotEnabledError = errors.New("heartbeat sources are not enabled, start the operator with --enable-heartbeat-sources")
+
+// Checker evaluates the heartbeat objects of nodes, which are posted by custom node agents
+type Checker interface {
+	// IsEnabled returns true if heartbeat sources are enabled
+	IsEnabled() bool
+	// GetStaleNodes returns the nodes with the given names, whose heartbeat is stale, with the time since when it
+	// is stale. It also returns when the next heartbeat gets stale. Returns nothing if the NHC doesn't configure a
+	// heartbeat source, or if heartbeat sources are not enabled. Nodes without heartbeat object, or with an
+	// invalid heartbeat time, are never stale. The given client is used for listing the heartbeat objects.
+	GetStaleNodes(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeNames []string, now time.Time) (staleNodes map[string]metav1.Time, expiresAfter *time.Duration, err error)
+}
+
+// NewChecker creates a new Checker. If not enabled, heartbeats are never considered stale.
+func NewChecker(enabled bool, log logr.Logger) Checker {
+	return &checker{
+		enabled: enabled,
+		log:     log.WithName("HeartbeatChecker"),
+	}
+}
+
+type checker struct {
+	enabled bool
+	log     logr.Logger
+}
+
+var _ Checker = &checker{}
+
+func (ch *checker) IsEnabled() bool {
+	return ch.enabled
+}
+
+func (ch *checker) GetStaleNodes(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeNames []string, now time.Time) (map[string]metav1.Time, *time.Duration, error) {
+	staleNodes := make(map[string]metav1.Time)
+	source := nhc.Spec.HeartbeatSource
+	if source == nil {
+		return staleNodes, nil, nil
+	}
+	if !ch.enabled {
+		ch.log.Info("skipping heartbeat check", "NHC", nhc.GetName(), "reason", NotEnabledError.Error())
+		return staleNodes, nil, nil
+	}
+
+	heartbeatList := &unstructured.UnstructuredList{}
+	heartbeatList.SetAPIVersion(source.APIVersion)
+	heartbeatList.SetKind(source.Kind + "List")
+	if err := c.List(ctx, heartbeatList, client.InNamespace(source.Namespace)); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to list heartbeats of kind %s", source.Kind)
+	}
+
+	lastHeartbeats := make(map[string]time.Time)
+	for _, heartbeat := range heartbeatList.Items {
+		nodeName, _, _ := unstructured.NestedString(heartbeat.Object, NodeNameField...)
+		if nodeName == "" {
+			nodeName = heartbeat.GetName()
+		}
+		value, _, _ := unstructured.NestedString(heartbeat.Object, LastHeartbeatTimeField...)
+		lastHeartbeat, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ch.log.Info("ignoring heartbeat with invalid time", "NHC", nhc.GetName(), "node", nodeName, "name", heartbeat.GetName(), "lastHeartbeatTime", value)
+			continue
+		}
+		lastHeartbeats[nodeName] = lastHeartbeat
+	}
+
+	var expiresAfter *time.Duration
+	for _, nodeName := range nodeNames {
+		lastHeartbeat, exists := lastHeartbeats[nodeName]
+		if !exists {
+			continue
+		}
+		staleAt := lastHeartbeat.Add(source.StaleAfter.Duration)
+		if now.After(staleAt) {
+			staleNodes[nodeName] = metav1.NewTime(staleAt)
+			continue
+		}
+		// the heartbeat gets stale if it isn't updated in time
+		thisExpiresAfter := staleAt.Sub(now) + 1*time.Second
+		if expiresAfter == nil || thisExpiresAfter < *expiresAfter {
+			expiresAfter = pointer.Duration(thisExpiresAfter)
+		}
+	}
+	return staleNodes, expiresAfter, nil
+}
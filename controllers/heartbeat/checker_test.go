@@ -0,0 +1,138 @@
+package heartbeat
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Heartbeat checker", func() {
+
+	var (
+		gvk        = schema.GroupVersionKind{Group: "agent.example.com", Version: "v1", Kind: "NodeHeartbeat"}
+		c          client.Client
+		ch         Checker
+		nhc        *remediationv1alpha1.NodeHealthCheck
+		heartbeats []client.Object
+		now        time.Time
+		enabled    bool
+	)
+
+	newHeartbeat := func(name string, lastHeartbeat time.Time) *unstructured.Unstructured {
+		heartbeat := &unstructured.Unstructured{}
+		heartbeat.SetGroupVersionKind(gvk)
+		heartbeat.SetNamespace("agents")
+		heartbeat.SetName(name)
+		Expect(unstructured.SetNestedField(heartbeat.Object, lastHeartbeat.Format(time.RFC3339), LastHeartbeatTimeField...)).To(Succeed())
+		return heartbeat
+	}
+
+	BeforeEach(func() {
+		enabled = true
+		now = time.Now()
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				HeartbeatSource: &remediationv1alpha1.HeartbeatSource{
+					APIVersion: gvk.GroupVersion().String(),
+					Kind:       gvk.Kind,
+					Namespace:  "agents",
+					StaleAfter: metav1.Duration{Duration: time.Minute},
+				},
+			},
+		}
+		heartbeats = []client.Object{
+			newHeartbeat("alive", now.Add(-10*time.Second)),
+			newHeartbeat("stale", now.Add(-2*time.Minute)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+		c = fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(heartbeats...).Build()
+		ch = NewChecker(enabled, zap.New())
+	})
+
+	getStaleNodes := func(nodeNames ...string) (map[string]metav1.Time, *time.Duration) {
+		staleNodes, expiresAfter, err := ch.GetStaleNodes(context.Background(), c, nhc, nodeNames, now)
+		Expect(err).ToNot(HaveOccurred())
+		return staleNodes, expiresAfter
+	}
+
+	When("heartbeats are stale", func() {
+		It("should return since when they are stale", func() {
+			staleNodes, expiresAfter := getStaleNodes("alive", "stale")
+			Expect(staleNodes).To(HaveLen(1))
+			Expect(staleNodes).To(HaveKey("stale"))
+			Expect(staleNodes["stale"].Time).To(BeTemporally("~", now.Add(-time.Minute), time.Second))
+			Expect(expiresAfter).ToNot(BeNil())
+			Expect(*expiresAfter).To(BeNumerically("~", 51*time.Second, time.Second))
+		})
+	})
+
+	When("the heartbeat has a node name", func() {
+		BeforeEach(func() {
+			heartbeat := newHeartbeat("agent-xyz", now.Add(-2*time.Minute))
+			Expect(unstructured.SetNestedField(heartbeat.Object, "worker", NodeNameField...)).To(Succeed())
+			heartbeats = []client.Object{heartbeat}
+		})
+		It("should map the heartbeat to the node", func() {
+			staleNodes, _ := getStaleNodes("worker", "agent-xyz")
+			Expect(staleNodes).To(HaveLen(1))
+			Expect(staleNodes).To(HaveKey("worker"))
+		})
+	})
+
+	When("nodes have no heartbeat", func() {
+		It("should not consider them as stale", func() {
+			staleNodes, expiresAfter := getStaleNodes("unknown")
+			Expect(staleNodes).To(BeEmpty())
+			Expect(expiresAfter).To(BeNil())
+		})
+	})
+
+	When("the heartbeat time is invalid", func() {
+		BeforeEach(func() {
+			heartbeat := newHeartbeat("stale", now)
+			Expect(unstructured.SetNestedField(heartbeat.Object, "yesterday", LastHeartbeatTimeField...)).To(Succeed())
+			heartbeats = []client.Object{heartbeat}
+		})
+		It("should ignore the heartbeat", func() {
+			staleNodes, _ := getStaleNodes("stale")
+			Expect(staleNodes).To(BeEmpty())
+		})
+	})
+
+	When("no heartbeat source is configured", func() {
+		BeforeEach(func() {
+			nhc.Spec.HeartbeatSource = nil
+		})
+		It("should not consider nodes as stale", func() {
+			staleNodes, _ := getStaleNodes("stale")
+			Expect(staleNodes).To(BeEmpty())
+		})
+	})
+
+	When("heartbeat sources are not enabled", func() {
+		BeforeEach(func() {
+			enabled = false
+		})
+		It("should not consider nodes as stale", func() {
+			staleNodes, _ := getStaleNodes("stale")
+			Expect(staleNodes).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package impersonation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// ClientProvider provides clients which impersonate the remediation ServiceAccounts configured in NHCs
+type ClientProvider interface {
+	// GetClient returns the client to use for the remediation CRs of the given NHC. That is the given client for NHCs
+	// without remediation ServiceAccount. Otherwise, the returned client reads with the given client, and creates,
+	// updates and deletes while impersonating the ServiceAccount, so that its RBAC bounds what the NHC can do.
+	GetClient(c client.Client, nhc *remediationv1alpha1.NodeHealthCheck) (client.Client, error)
+}
+
+// NewClientProvider creates a new ClientProvider. The given config is the operator's config, which is copied for
+// impersonating ServiceAccounts.
+func NewClientProvider(config *rest.Config, log logr.Logger) ClientProvider {
+	return &clientProvider{
+		config: config,
+		log:    log.WithName("ImpersonatingClientProvider"),
+		newClient: func(config *rest.Config, c client.Client) (client.Client, error) {
+			return client.New(config, client.Options{Scheme: c.Scheme(), Mapper: c.RESTMapper()})
+		},
+		clients: make(map[string]client.Client),
+	}
+}
+
+type clientProvider struct {
+	config    *rest.Config
+	log       logr.Logger
+	newClient func(config *rest.Config, c client.Client) (client.Client, error)
+	lock      sync.Mutex
+	// clients caches impersonating clients by ServiceAccount username
+	clients map[string]client.Client
+}
+
+var _ ClientProvider = &clientProvider{}
+
+func (p *clientProvider) GetClient(c client.Client, nhc *remediationv1alpha1.NodeHealthCheck) (client.Client, error) {
+	sa := nhc.Spec.RemediationServiceAccount
+	if sa == nil {
+		return c, nil
+	}
+	writer, err := p.getImpersonatingClient(GetUsername(sa), c)
+	if err != nil {
+		return nil, err
+	}
+	return &splitClient{Client: c, writer: writer}, nil
+}
+
+func (p *clientProvider) getImpersonatingClient(username string, c client.Client) (client.Client, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if cached, exists := p.clients[username]; exists {
+		return cached, nil
+	}
+	config := rest.CopyConfig(p.config)
+	config.Impersonate = rest.ImpersonationConfig{UserName: username}
+	impersonatingClient, err := p.newClient(config, c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client impersonating %s", username)
+	}
+	p.log.Info("created impersonating client", "username", username)
+	p.clients[username] = impersonatingClient
+	return impersonatingClient, nil
+}
+
+// GetUsername returns the username of the given ServiceAccount, which is used for impersonating it
+func GetUsername(sa *remediationv1alpha1.ServiceAccountReference) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", sa.Namespace, sa.Name)
+}
+
+// splitClient reads with the embedded client, and writes with the impersonating one
+type splitClient struct {
+	client.Client
+	writer client.Writer
+}
+
+var _ client.Client = &splitClient{}
+
+func (s *splitClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return s.writer.Create(ctx, obj, opts...)
+}
+
+func (s *splitClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return s.writer.Delete(ctx, obj, opts...)
+}
+
+func (s *splitClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return s.writer.Update(ctx, obj, opts...)
+}
+
+func (s *splitClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return s.writer.Patch(ctx, obj, patch, opts...)
+}
+
+func (s *splitClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return s.writer.DeleteAllOf(ctx, obj, opts...)
+}
@@ -0,0 +1,79 @@
+package impersonation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Impersonating clients", func() {
+
+	var (
+		reader, writer client.Client
+		usernames      []string
+		p              *clientProvider
+		nhc            *remediationv1alpha1.NodeHealthCheck
+	)
+
+	BeforeEach(func() {
+		reader = fake.NewClientBuilder().Build()
+		writer = fake.NewClientBuilder().Build()
+		usernames = nil
+		p = NewClientProvider(&rest.Config{Host: "https://api.example.com"}, zap.New()).(*clientProvider)
+		p.newClient = func(config *rest.Config, _ client.Client) (client.Client, error) {
+			usernames = append(usernames, config.Impersonate.UserName)
+			return writer, nil
+		}
+		nhc = &remediationv1alpha1.NodeHealthCheck{ObjectMeta: metav1.ObjectMeta{Name: "nhc"}}
+	})
+
+	When("no remediation ServiceAccount is configured", func() {
+		It("should return the given client", func() {
+			c, err := p.GetClient(reader, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c).To(BeIdenticalTo(reader))
+			Expect(usernames).To(BeEmpty())
+		})
+	})
+
+	When("a remediation ServiceAccount is configured", func() {
+		BeforeEach(func() {
+			nhc.Spec.RemediationServiceAccount = &remediationv1alpha1.ServiceAccountReference{Namespace: "ns", Name: "sa"}
+		})
+
+		It("should impersonate the ServiceAccount", func() {
+			_, err := p.GetClient(reader, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usernames).To(ConsistOf("system:serviceaccount:ns:sa"))
+			Expect(p.config.Impersonate.UserName).To(BeEmpty(), "operator config must not be modified")
+		})
+
+		It("should cache the impersonating client", func() {
+			_, err := p.GetClient(reader, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = p.GetClient(reader, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usernames).To(HaveLen(1))
+		})
+
+		It("should read with the given client and write with the impersonating client", func() {
+			c, err := p.GetClient(reader, nhc)
+			Expect(err).ToNot(HaveOccurred())
+
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cm"}}
+			Expect(c.Create(context.Background(), cm)).To(Succeed())
+			Expect(writer.Get(context.Background(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).To(Succeed())
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package impersonation
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestImpersonation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Impersonation Suite")
+}
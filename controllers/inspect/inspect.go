@@ -0,0 +1,343 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+const (
+	// SkipReasonReportOnly is the reason of skipped nodes, which only match unhealthy conditions with disabled
+	// remediation
+	SkipReasonReportOnly = "ReportOnly"
+	// SkipReasonRemediationExhausted is the reason of skipped nodes, whose remediation exceeded the
+	// MaxRemediationDuration
+	SkipReasonRemediationExhausted = "RemediationExhausted"
+	// PendingReasonUnhealthyCondition is the reason of pending nodes, which match an unhealthy condition, but not for
+	// its duration yet
+	PendingReasonUnhealthyCondition = "UnhealthyCondition"
+	// PendingReasonInsufficientCapacity is the reason of pending nodes, which have insufficient capacity, but not for
+	// the duration of the unhealthy capacity yet
+	PendingReasonInsufficientCapacity = "InsufficientCapacity"
+)
+
+// State is the remediation state of NodeHealthChecks
+type State struct {
+	NodeHealthChecks []NodeHealthCheck `json:"nodeHealthChecks"`
+}
+
+// NodeHealthCheck is the remediation state of a NodeHealthCheck
+type NodeHealthCheck struct {
+	Name         string                       `json:"name"`
+	Phase        remediationv1alpha1.NHCPhase `json:"phase,omitempty"`
+	Reason       string                       `json:"reason,omitempty"`
+	Remediations []Remediation                `json:"remediations,omitempty"`
+	Skipped      []SkippedNode                `json:"skipped,omitempty"`
+	Pending      []PendingNode                `json:"pending,omitempty"`
+}
+
+// Remediation is a remediation of an unhealthy node, as tracked in the NodeHealthCheck status, with the conditions of
+// its remediation CR
+type Remediation struct {
+	Node string `json:"node"`
+	// Step is the order of the escalating remediation, it isn't set for classic remediation
+	Step      *int                                 `json:"step,omitempty"`
+	Kind      string                               `json:"kind"`
+	Namespace string                               `json:"namespace,omitempty"`
+	Name      string                               `json:"name,omitempty"`
+	Phase     remediationv1alpha1.RemediationPhase `json:"phase,omitempty"`
+	Reason    string                               `json:"reason,omitempty"`
+	Started   metav1.Time                          `json:"started"`
+	// Deadline is when the remediation times out, it is only set for escalating remediations
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+	// CRFound is false when the remediation CR doesn't exist (anymore)
+	CRFound    bool               `json:"crFound"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SkippedNode is an unhealthy node which isn't remediated
+type SkippedNode struct {
+	Node    string       `json:"node"`
+	Reason  string       `json:"reason"`
+	Message string       `json:"message,omitempty"`
+	Since   *metav1.Time `json:"since,omitempty"`
+}
+
+// PendingNode is a node whose remediation didn't start yet
+type PendingNode struct {
+	Node    string `json:"node"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+	// EligibleAt is when the node is going to be remediated, it isn't set when that's unknown
+	EligibleAt *metav1.Time `json:"eligibleAt,omitempty"`
+}
+
+// Inspect returns the remediation state of the NodeHealthChecks with the given names, or of all NodeHealthChecks if
+// no names are given. The given client needs read access to NodeHealthChecks, nodes, and remediation CRs.
+func Inspect(ctx context.Context, c client.Client, log logr.Logger, now time.Time, names ...string) (*State, error) {
+	var nhcs []remediationv1alpha1.NodeHealthCheck
+	if len(names) == 0 {
+		nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+		if err := c.List(ctx, nhcList); err != nil {
+			return nil, errors.Wrapf(err, "failed to list NodeHealthChecks")
+		}
+		nhcs = nhcList.Items
+	}
+	for _, name := range names {
+		nhc := remediationv1alpha1.NodeHealthCheck{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, &nhc); err != nil {
+			return nil, errors.Wrapf(err, "failed to get NodeHealthCheck %s", name)
+		}
+		nhcs = append(nhcs, nhc)
+	}
+	sort.Slice(nhcs, func(i, j int) bool {
+		return nhcs[i].GetName() < nhcs[j].GetName()
+	})
+
+	state := &State{NodeHealthChecks: make([]NodeHealthCheck, 0, len(nhcs))}
+	for i := range nhcs {
+		nhcState, err := inspectNHC(ctx, c, log, now, &nhcs[i])
+		if err != nil {
+			return nil, err
+		}
+		state.NodeHealthChecks = append(state.NodeHealthChecks, *nhcState)
+	}
+	return state, nil
+}
+
+func inspectNHC(ctx context.Context, c client.Client, log logr.Logger, now time.Time, nhc *remediationv1alpha1.NodeHealthCheck) (*NodeHealthCheck, error) {
+	rm := resources.NewManager(c, ctx, log, false, nil, nil, false, nil)
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(_ unstructured.Unstructured) bool {
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list remediation CRs of NodeHealthCheck %s", nhc.GetName())
+	}
+
+	report := &NodeHealthCheck{
+		Name:   nhc.GetName(),
+		Phase:  nhc.Status.Phase,
+		Reason: nhc.Status.Reason,
+	}
+	unhealthyNodes := make(map[string]bool)
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		unhealthyNodes[unhealthyNode.Name] = true
+		for _, remediation := range unhealthyNode.Remediations {
+			report.Remediations = append(report.Remediations, newRemediation(nhc, unhealthyNode.Name, remediation, remediationCRs))
+		}
+		if unhealthyNode.RemediationExhausted != nil {
+			report.Skipped = append(report.Skipped, SkippedNode{
+				Node:   unhealthyNode.Name,
+				Reason: SkipReasonRemediationExhausted,
+				Since:  unhealthyNode.RemediationExhausted,
+			})
+		}
+		if unhealthyNode.Deferral != nil {
+			report.Pending = append(report.Pending, PendingNode{
+				Node:       unhealthyNode.Name,
+				Reason:     unhealthyNode.Deferral.Reason,
+				Message:    unhealthyNode.Deferral.Message,
+				EligibleAt: getDeferralEnd(nhc, unhealthyNode.Name, unhealthyNode.Deferral),
+			})
+		}
+	}
+
+	for _, ignored := range nhc.Status.IgnoredUnhealthyNodes {
+		if ignored.EligibleAt != nil {
+			report.Pending = append(report.Pending, PendingNode{
+				Node:       ignored.Name,
+				Reason:     ignored.Reason,
+				EligibleAt: ignored.EligibleAt,
+			})
+			continue
+		}
+		since := ignored.Since
+		report.Skipped = append(report.Skipped, SkippedNode{
+			Node:   ignored.Name,
+			Reason: ignored.Reason,
+			Since:  &since,
+		})
+	}
+	for _, reportOnly := range nhc.Status.ReportOnlyUnhealthyNodes {
+		since := reportOnly.Since
+		report.Skipped = append(report.Skipped, SkippedNode{
+			Node:    reportOnly.Name,
+			Reason:  SkipReasonReportOnly,
+			Message: formatMatchedConditions(reportOnly.Conditions),
+			Since:   &since,
+		})
+	}
+
+	for _, insufficient := range nhc.Status.InsufficientCapacityNodes {
+		if unhealthyNodes[insufficient.Name] {
+			continue
+		}
+		for _, capacity := range nhc.Spec.UnhealthyCapacity {
+			if capacity.ResourceName != insufficient.ResourceName {
+				continue
+			}
+			if eligibleAt := insufficient.Since.Add(capacity.Duration.Duration); now.Before(eligibleAt) {
+				report.Pending = append(report.Pending, PendingNode{
+					Node:       insufficient.Name,
+					Reason:     PendingReasonInsufficientCapacity,
+					Message:    fmt.Sprintf("%s below %s", capacity.ResourceName, capacity.MinQuantity.String()),
+					EligibleAt: &metav1.Time{Time: eligibleAt},
+				})
+				unhealthyNodes[insufficient.Name] = true
+			}
+			break
+		}
+	}
+
+	// nodes of remote clusters can't be inspected with the local client
+	if nhc.Spec.RemoteCluster == nil {
+		nodes, err := rm.GetSelectedNodes(nhc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get nodes of NodeHealthCheck %s", nhc.GetName())
+		}
+		for i := range nodes {
+			if unhealthyNodes[nodes[i].GetName()] {
+				continue
+			}
+			if pending := getPendingUnhealthyCondition(nhc, &nodes[i], now); pending != nil {
+				report.Pending = append(report.Pending, *pending)
+			}
+		}
+	}
+
+	sort.SliceStable(report.Skipped, func(i, j int) bool {
+		return report.Skipped[i].Node < report.Skipped[j].Node
+	})
+	sort.SliceStable(report.Pending, func(i, j int) bool {
+		return report.Pending[i].Node < report.Pending[j].Node
+	})
+	return report, nil
+}
+
+// newRemediation returns the given remediation of the given node, with the conditions of its remediation CR
+func newRemediation(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, remediation *remediationv1alpha1.Remediation, remediationCRs []unstructured.Unstructured) Remediation {
+	result := Remediation{
+		Node:      nodeName,
+		Kind:      remediation.Resource.Kind,
+		Namespace: remediation.Resource.Namespace,
+		Name:      remediation.Resource.Name,
+		Phase:     remediation.Phase,
+		Reason:    remediation.Reason,
+		Started:   remediation.Started,
+	}
+	if escRem := resources.FindEscalatingRemediation(nhc, remediation); escRem != nil {
+		order := escRem.Order
+		result.Step = &order
+	}
+	if remediation.Timeout != nil {
+		result.Deadline = &metav1.Time{Time: remediation.Started.Add(remediation.Timeout.Duration)}
+	}
+	for i := range remediationCRs {
+		cr := &remediationCRs[i]
+		if cr.GroupVersionKind() != remediation.Resource.GroupVersionKind() || cr.GetNamespace() != remediation.Resource.Namespace || cr.GetName() != remediation.Resource.Name {
+			continue
+		}
+		result.CRFound = true
+		result.Conditions = getConditions(cr)
+		break
+	}
+	return result
+}
+
+// getDeferralEnd returns when the given deferral of the given node ends, or nil if that's unknown
+func getDeferralEnd(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, deferral *remediationv1alpha1.RemediationDeferral) *metav1.Time {
+	switch deferral.Reason {
+	case remediationv1alpha1.DeferralReasonExternallyRemediated:
+		return &metav1.Time{Time: deferral.Since.Add(utils.GetExternalRemediationGracePeriod(nhc))}
+	case remediationv1alpha1.DeferralReasonPDBViolation:
+		since := resources.GetStatusPDBViolationSince(nodeName, nhc)
+		if since == nil {
+			return nil
+		}
+		return &metav1.Time{Time: since.Add(utils.GetPDBOverrideTimeout(nhc))}
+	case remediationv1alpha1.DeferralReasonEscalationCooldown:
+		last := resources.GetStatusLastRemediation(nodeName, nhc)
+		if last == nil || last.TimedOut == nil || nhc.Spec.EscalationDelay == nil {
+			return nil
+		}
+		return &metav1.Time{Time: last.TimedOut.Add(nhc.Spec.EscalationDelay.Duration)}
+	default:
+		return nil
+	}
+}
+
+// getPendingUnhealthyCondition returns the given node as pending, if it is going to match an unhealthy condition with
+// enabled remediation, or nil if it doesn't
+func getPendingUnhealthyCondition(nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node, now time.Time) *PendingNode {
+	var pending *PendingNode
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		if !utils.IsRemediationEnabled(c) || utils.IsOverriddenByTaintedCondition(c, nhc.Spec.UnhealthyConditions, node) {
+			continue
+		}
+		c = resources.GetStabilizedUnhealthyCondition(node.GetName(), nhc, c)
+		matches, expiresAfter := utils.MatchesUnhealthyCondition(c, node, now)
+		if matches || expiresAfter == nil {
+			// matching nodes are either tracked in the status already, or will be on the next reconcile
+			continue
+		}
+		eligibleAt := now.Add(*expiresAfter)
+		if pending != nil && !eligibleAt.Before(pending.EligibleAt.Time) {
+			continue
+		}
+		pending = &PendingNode{
+			Node:       node.GetName(),
+			Reason:     PendingReasonUnhealthyCondition,
+			Message:    formatMatchedConditions([]remediationv1alpha1.MatchedCondition{{Type: c.Type, Status: c.Status, TaintKey: c.TaintKey}}),
+			EligibleAt: &metav1.Time{Time: eligibleAt},
+		}
+	}
+	return pending
+}
+
+// getConditions returns the status conditions of the given remediation CR
+func getConditions(cr *unstructured.Unstructured) []metav1.Condition {
+	rawConditions, found, _ := unstructured.NestedSlice(cr.Object, "status", "conditions")
+	if !found {
+		return nil
+	}
+	var conditions []metav1.Condition
+	for _, rawCondition := range rawConditions {
+		rawCondition, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawCondition, &condition); err != nil {
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+func formatMatchedConditions(conditions []remediationv1alpha1.MatchedCondition) string {
+	formatted := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		s := fmt.Sprintf("%s=%s", c.Type, c.Status)
+		if c.TaintKey != "" {
+			s += fmt.Sprintf(" (taint %s)", c.TaintKey)
+		}
+		formatted = append(formatted, s)
+	}
+	return strings.Join(formatted, ", ")
+}
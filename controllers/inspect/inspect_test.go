@@ -0,0 +1,212 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Inspect", func() {
+
+	var (
+		c   client.Client
+		nhc *remediationv1alpha1.NodeHealthCheck
+		now time.Time
+	)
+
+	gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+
+	newNode := func(name string, ready corev1.ConditionStatus, readySince time.Time) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{
+					Type:               corev1.NodeReady,
+					Status:             ready,
+					LastTransitionTime: metav1.Time{Time: readySince},
+				}},
+			},
+		}
+	}
+
+	newRemediationCR := func(kind, nodeName string) *unstructured.Unstructured {
+		cr := &unstructured.Unstructured{}
+		cr.SetGroupVersionKind(gv.WithKind(kind))
+		cr.SetNamespace("default")
+		cr.SetName(nodeName)
+		return cr
+	}
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				UnhealthyConditions: []remediationv1alpha1.UnhealthyCondition{{
+					Type:     corev1.NodeReady,
+					Status:   corev1.ConditionFalse,
+					Duration: metav1.Duration{Duration: 5 * time.Minute},
+				}},
+				EscalationDelay: &metav1.Duration{Duration: 2 * time.Minute},
+			},
+		}
+		for i, kind := range []string{"RebootRemediationTemplate", "ReprovisionRemediationTemplate"} {
+			nhc.Spec.EscalatingRemediations = append(nhc.Spec.EscalatingRemediations, remediationv1alpha1.EscalatingRemediation{
+				RemediationTemplate: corev1.ObjectReference{APIVersion: gv.String(), Kind: kind, Namespace: "default", Name: "template"},
+				Order:               i,
+				Timeout:             metav1.Duration{Duration: 10 * time.Minute},
+			})
+		}
+
+		started := metav1.Time{Time: now.Add(-15 * time.Minute)}
+		timedOut := metav1.Time{Time: now.Add(-5 * time.Minute)}
+		nhc.Status = remediationv1alpha1.NodeHealthCheckStatus{
+			Phase:  remediationv1alpha1.PhaseRemediating,
+			Reason: "NHC is remediating 2 nodes",
+			UnhealthyNodes: []*remediationv1alpha1.UnhealthyNode{
+				{
+					Name: "escalated",
+					Remediations: []*remediationv1alpha1.Remediation{
+						{
+							Resource: corev1.ObjectReference{APIVersion: gv.String(), Kind: "RebootRemediation", Namespace: "default", Name: "escalated"},
+							Started:  started,
+							TimedOut: &timedOut,
+							Timeout:  &metav1.Duration{Duration: 10 * time.Minute},
+							Phase:    remediationv1alpha1.RemediationPhaseTimedOut,
+						},
+						{
+							Resource: corev1.ObjectReference{APIVersion: gv.String(), Kind: "ReprovisionRemediation", Namespace: "default", Name: "escalated"},
+							Started:  timedOut,
+							Timeout:  &metav1.Duration{Duration: 10 * time.Minute},
+							Phase:    remediationv1alpha1.RemediationPhaseRunning,
+						},
+					},
+				},
+				{
+					Name: "cooling-down",
+					Remediations: []*remediationv1alpha1.Remediation{
+						{
+							Resource: corev1.ObjectReference{APIVersion: gv.String(), Kind: "RebootRemediation", Namespace: "default", Name: "cooling-down"},
+							Started:  started,
+							TimedOut: &timedOut,
+							Timeout:  &metav1.Duration{Duration: 10 * time.Minute},
+							Phase:    remediationv1alpha1.RemediationPhaseTimedOut,
+						},
+					},
+					Deferral: &remediationv1alpha1.RemediationDeferral{
+						Reason:  remediationv1alpha1.DeferralReasonEscalationCooldown,
+						Message: "waiting for the escalation delay",
+						Since:   timedOut,
+					},
+				},
+			},
+			IgnoredUnhealthyNodes: []remediationv1alpha1.IgnoredUnhealthyNode{
+				{Name: "preexisting", Reason: "PreexistingCondition", Since: started},
+				{Name: "young", Reason: "NodeTooYoung", Since: timedOut, EligibleAt: &metav1.Time{Time: now.Add(10 * time.Minute)}},
+			},
+		}
+
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+		for _, kind := range []string{"RebootRemediation", "ReprovisionRemediation"} {
+			mapper.Add(gv.WithKind(kind), meta.RESTScopeNamespace)
+		}
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(remediationv1alpha1.AddToScheme(scheme))
+
+		rebootCR := newRemediationCR("RebootRemediation", "escalated")
+		reprovisionCR := newRemediationCR("ReprovisionRemediation", "escalated")
+		Expect(unstructured.SetNestedSlice(reprovisionCR.Object, []interface{}{
+			map[string]interface{}{"type": "Processing", "status": "True", "reason": "Reprovisioning", "lastTransitionTime": now.Format(time.RFC3339)},
+		}, "status", "conditions")).To(Succeed())
+
+		c = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(
+			nhc,
+			rebootCR,
+			reprovisionCR,
+			newNode("healthy", corev1.ConditionTrue, now.Add(-time.Hour)),
+			newNode("escalated", corev1.ConditionFalse, now.Add(-time.Hour)),
+			newNode("getting-unhealthy", corev1.ConditionFalse, now.Add(-time.Minute)),
+		).Build()
+	})
+
+	It("reports remediations, skipped and pending nodes", func() {
+		state, err := Inspect(context.Background(), c, zap.New(), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.NodeHealthChecks).To(HaveLen(1))
+		nhcState := state.NodeHealthChecks[0]
+		Expect(nhcState.Name).To(Equal("nhc"))
+		Expect(nhcState.Phase).To(Equal(remediationv1alpha1.PhaseRemediating))
+
+		By("reporting the remediations with their CRs")
+		Expect(nhcState.Remediations).To(HaveLen(3))
+		reprovision := nhcState.Remediations[1]
+		Expect(reprovision.Node).To(Equal("escalated"))
+		Expect(reprovision.Kind).To(Equal("ReprovisionRemediation"))
+		Expect(*reprovision.Step).To(Equal(1))
+		Expect(reprovision.Deadline.Time).To(Equal(now.Add(5 * time.Minute)))
+		Expect(reprovision.CRFound).To(BeTrue())
+		Expect(reprovision.Conditions).To(ConsistOf(HaveField("Type", "Processing")))
+		Expect(nhcState.Remediations[2].Node).To(Equal("cooling-down"))
+		Expect(nhcState.Remediations[2].CRFound).To(BeFalse())
+
+		By("reporting skipped nodes")
+		Expect(nhcState.Skipped).To(ConsistOf(HaveField("Node", "preexisting")))
+		Expect(nhcState.Skipped[0].Reason).To(Equal("PreexistingCondition"))
+
+		By("reporting pending nodes with their eligibility times")
+		Expect(nhcState.Pending).To(HaveLen(3))
+		Expect(nhcState.Pending[0].Node).To(Equal("cooling-down"))
+		Expect(nhcState.Pending[0].Reason).To(Equal(remediationv1alpha1.DeferralReasonEscalationCooldown))
+		Expect(nhcState.Pending[0].EligibleAt.Time).To(Equal(now.Add(-3 * time.Minute)))
+		Expect(nhcState.Pending[1].Node).To(Equal("getting-unhealthy"))
+		Expect(nhcState.Pending[1].Reason).To(Equal(PendingReasonUnhealthyCondition))
+		Expect(nhcState.Pending[1].EligibleAt.Time).To(Equal(now.Add(4 * time.Minute)))
+		Expect(nhcState.Pending[2].Node).To(Equal("young"))
+		Expect(nhcState.Pending[2].Reason).To(Equal(remediationv1alpha1.IgnoredReasonNodeTooYoung))
+		Expect(nhcState.Pending[2].EligibleAt.Time).To(Equal(now.Add(10 * time.Minute)))
+	})
+
+	It("fails for unknown NodeHealthChecks", func() {
+		_, err := Inspect(context.Background(), c, zap.New(), now, "unknown")
+		Expect(err).To(MatchError(ContainSubstring("failed to get NodeHealthCheck unknown")))
+	})
+
+	It("prints tables and JSON", func() {
+		state, err := Inspect(context.Background(), c, zap.New(), now, "nhc")
+		Expect(err).ToNot(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		Expect(PrintTable(out, state)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Remediating"))
+		Expect(out.String()).To(ContainSubstring("Processing=True"))
+		Expect(out.String()).To(ContainSubstring("<CR not found>"))
+		Expect(out.String()).To(ContainSubstring("PreexistingCondition"))
+		Expect(out.String()).To(ContainSubstring("EscalationCooldown"))
+
+		out.Reset()
+		Expect(PrintJSON(out, state)).To(Succeed())
+		parsed := &State{}
+		Expect(json.Unmarshal(out.Bytes(), parsed)).To(Succeed())
+		Expect(parsed.NodeHealthChecks).To(HaveLen(1))
+		Expect(parsed.NodeHealthChecks[0].Remediations).To(HaveLen(3))
+	})
+})
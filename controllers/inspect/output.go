@@ -0,0 +1,140 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const none = "-"
+
+// PrintJSON writes the given state or validation as indented JSON
+func PrintJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// PrintTable writes the given state as tables of NodeHealthChecks, remediations, skipped nodes and pending nodes.
+// Empty tables are omitted.
+func PrintTable(w io.Writer, state *State) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NODEHEALTHCHECK\tPHASE\tREASON")
+	for _, nhc := range state.NodeHealthChecks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", nhc.Name, orNone(string(nhc.Phase)), orNone(nhc.Reason))
+	}
+
+	var remediations, skipped, pending []string
+	for _, nhc := range state.NodeHealthChecks {
+		for _, r := range nhc.Remediations {
+			step := none
+			if r.Step != nil {
+				step = strconv.Itoa(*r.Step)
+			}
+			conditions := none
+			if r.CRFound {
+				conditions = formatConditions(r.Conditions)
+			} else if r.Name != "" {
+				conditions = "<CR not found>"
+			}
+			remediations = append(remediations, strings.Join([]string{nhc.Name, r.Node, step, r.Kind, orNone(r.Name),
+				orNone(string(r.Phase)), formatTime(&r.Started), formatTime(r.Deadline), conditions}, "\t"))
+		}
+		for _, s := range nhc.Skipped {
+			skipped = append(skipped, strings.Join([]string{nhc.Name, s.Node, s.Reason, formatTime(s.Since), orNone(s.Message)}, "\t"))
+		}
+		for _, p := range nhc.Pending {
+			pending = append(pending, strings.Join([]string{nhc.Name, p.Node, p.Reason, formatTime(p.EligibleAt), orNone(p.Message)}, "\t"))
+		}
+	}
+	printSection(tw, "NODEHEALTHCHECK\tNODE\tSTEP\tKIND\tNAME\tPHASE\tSTARTED\tDEADLINE\tCONDITIONS", remediations)
+	printSection(tw, "NODEHEALTHCHECK\tNODE\tSKIPPED\tSINCE\tMESSAGE", skipped)
+	printSection(tw, "NODEHEALTHCHECK\tNODE\tPENDING\tELIGIBLE\tMESSAGE", pending)
+
+	return tw.Flush()
+}
+
+// PrintValidationTable writes the given validation as a summary, followed by tables of unhealthy, skipped and pending
+// nodes. Empty tables are omitted.
+func PrintValidationTable(w io.Writer, validation *Validation) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	admission := "OK"
+	if validation.AdmissionError != "" {
+		admission = validation.AdmissionError
+	}
+	templates := "valid"
+	if !validation.TemplatesValid {
+		templates = "invalid: " + orNone(validation.TemplatesMessage)
+	}
+	remediation := "allowed"
+	if !validation.RemediationAllowed {
+		remediation = "skipped: " + orNone(validation.Message)
+	}
+	fmt.Fprintf(tw, "NODEHEALTHCHECK:\t%s\n", validation.Name)
+	fmt.Fprintf(tw, "ADMISSION:\t%s\n", admission)
+	fmt.Fprintf(tw, "TEMPLATES:\t%s\n", templates)
+	fmt.Fprintf(tw, "SELECTED NODES:\t%d\n", len(validation.SelectedNodes))
+	fmt.Fprintf(tw, "HEALTHY NODES:\t%d, at least %d required\n", validation.HealthyNodes, validation.MinHealthy)
+	fmt.Fprintf(tw, "UNHEALTHY NODES:\t%d\n", len(validation.UnhealthyNodes))
+	fmt.Fprintf(tw, "REMEDIATION:\t%s\n", remediation)
+
+	var unhealthy, skipped, pending []string
+	for _, u := range validation.UnhealthyNodes {
+		unhealthy = append(unhealthy, strings.Join([]string{u.Node, u.Condition}, "\t"))
+	}
+	for _, s := range validation.Skipped {
+		skipped = append(skipped, strings.Join([]string{s.Node, s.Reason, orNone(s.Message)}, "\t"))
+	}
+	for _, p := range validation.Pending {
+		pending = append(pending, strings.Join([]string{p.Node, p.Reason, formatTime(p.EligibleAt), orNone(p.Message)}, "\t"))
+	}
+	printSection(tw, "NODE\tUNHEALTHY CONDITION", unhealthy)
+	printSection(tw, "NODE\tSKIPPED\tMESSAGE", skipped)
+	printSection(tw, "NODE\tPENDING\tELIGIBLE\tMESSAGE", pending)
+
+	return tw.Flush()
+}
+
+func printSection(w io.Writer, header string, rows []string) {
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, header)
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
+	}
+}
+
+func formatConditions(conditions []metav1.Condition) string {
+	if len(conditions) == 0 {
+		return none
+	}
+	formatted := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", c.Type, c.Status))
+	}
+	return strings.Join(formatted, ",")
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return none
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return none
+	}
+	return s
+}
@@ -0,0 +1,13 @@
+package inspect
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInspect(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Inspect Suite")
+}
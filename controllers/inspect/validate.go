@@ -0,0 +1,163 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
+)
+
+// Validation is what a NodeHealthCheck would do, if it was applied now
+type Validation struct {
+	Name string `json:"name"`
+	// AdmissionError is the error of applying the NodeHealthCheck with a dry run request, e.g. raised by the
+	// validating webhook. The other fields are evaluated anyway, but without the defaults of the API server.
+	AdmissionError string `json:"admissionError,omitempty"`
+	// TemplatesValid is false when the remediation templates don't exist or are invalid, which disables the
+	// NodeHealthCheck
+	TemplatesValid bool `json:"templatesValid"`
+	// TemplatesMessage explains why the remediation templates aren't valid
+	TemplatesMessage string `json:"templatesMessage,omitempty"`
+	// SelectedNodes are the nodes which are selected by the selector
+	SelectedNodes []string `json:"selectedNodes"`
+	// UnhealthyNodes are the selected nodes which match an unhealthy condition
+	UnhealthyNodes []UnhealthyNode `json:"unhealthyNodes,omitempty"`
+	Skipped        []SkippedNode   `json:"skipped,omitempty"`
+	Pending        []PendingNode   `json:"pending,omitempty"`
+	HealthyNodes   int             `json:"healthyNodes"`
+	// MinHealthy is the number of healthy nodes required for remediation
+	MinHealthy int `json:"minHealthy"`
+	// RemediationAllowed is false when remediation of unhealthy nodes would be skipped, e.g. because too few nodes are
+	// healthy
+	RemediationAllowed bool `json:"remediationAllowed"`
+	// Message explains why remediation would be skipped
+	Message string `json:"message,omitempty"`
+}
+
+// UnhealthyNode is a node which matches an unhealthy condition
+type UnhealthyNode struct {
+	Node      string `json:"node"`
+	Condition string `json:"condition"`
+}
+
+// Validate returns what the given NodeHealthCheck would do, if it was applied now. The NodeHealthCheck is applied with
+// a dry run request, for getting the defaults of the API server and the errors of the validating webhook. Nodes are
+// only evaluated against the unhealthy conditions, signals which need the history of the NodeHealthCheck, e.g. the
+// connectivity of nodes, aren't considered. The given client needs read access to nodes and remediation templates,
+// and create and update access to NodeHealthChecks for the dry run requests.
+func Validate(ctx context.Context, c client.Client, log logr.Logger, now time.Time, nhc *remediationv1alpha1.NodeHealthCheck) (*Validation, error) {
+	validation := &Validation{Name: nhc.GetName()}
+
+	applied, err := dryRunApply(ctx, c, nhc)
+	if err != nil {
+		if !apierrors.IsInvalid(err) && !apierrors.IsForbidden(err) && !apierrors.IsBadRequest(err) {
+			return nil, errors.Wrapf(err, "failed to apply NodeHealthCheck %s with a dry run request", nhc.GetName())
+		}
+		validation.AdmissionError = err.Error()
+		nhc = nhc.DeepCopy()
+	} else {
+		nhc = applied
+	}
+	// evaluate the spec only, the status of an existing NodeHealthCheck doesn't belong to the manifest
+	nhc.Status = remediationv1alpha1.NodeHealthCheckStatus{}
+
+	rm := resources.NewManager(c, ctx, log, false, nil, nil, false, nil)
+	valid, _, message, err := rm.ValidateTemplates(nhc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to validate remediation templates of NodeHealthCheck %s", nhc.GetName())
+	}
+	validation.TemplatesValid = valid
+	validation.TemplatesMessage = message
+
+	if nhc.Spec.RemoteCluster != nil {
+		validation.Message = "Nodes of remote clusters can't be evaluated"
+		return validation, nil
+	}
+	nodes, err := rm.GetSelectedNodes(nhc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get nodes of NodeHealthCheck %s", nhc.GetName())
+	}
+	validation.SelectedNodes = make([]string, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		validation.SelectedNodes = append(validation.SelectedNodes, node.GetName())
+
+		if matched, _ := resources.GetMatchingUnhealthyCondition(nhc, node, now); matched != nil {
+			validation.UnhealthyNodes = append(validation.UnhealthyNodes, UnhealthyNode{
+				Node:      node.GetName(),
+				Condition: formatMatchedConditions([]remediationv1alpha1.MatchedCondition{*matched}),
+			})
+			continue
+		}
+		if reportOnly, _ := resources.GetMatchingReportOnlyConditions(nhc, node, now); len(reportOnly) > 0 {
+			validation.Skipped = append(validation.Skipped, SkippedNode{
+				Node:    node.GetName(),
+				Reason:  SkipReasonReportOnly,
+				Message: formatMatchedConditions(reportOnly),
+			})
+		}
+		if pending := getPendingUnhealthyCondition(nhc, node, now); pending != nil {
+			validation.Pending = append(validation.Pending, *pending)
+		}
+		if !nhc.Spec.RequirePositiveHealth || resources.HasPositiveHealth(node) {
+			validation.HealthyNodes++
+		}
+	}
+	sort.Strings(validation.SelectedNodes)
+	sort.SliceStable(validation.UnhealthyNodes, func(i, j int) bool {
+		return validation.UnhealthyNodes[i].Node < validation.UnhealthyNodes[j].Node
+	})
+	sort.SliceStable(validation.Skipped, func(i, j int) bool {
+		return validation.Skipped[i].Node < validation.Skipped[j].Node
+	})
+	sort.SliceStable(validation.Pending, func(i, j int) bool {
+		return validation.Pending[i].Node < validation.Pending[j].Node
+	})
+
+	if validation.MinHealthy, err = resources.GetMinHealthy(nhc, len(nodes)); err != nil {
+		// the validating webhook rejects invalid values, which is reported already
+		validation.Message = fmt.Sprintf("MinHealthy can't be evaluated: %v", err)
+		return validation, nil
+	}
+	allowed, message, _ := resources.CheckMinHealthy(nhc, len(nodes), validation.HealthyNodes)
+	switch {
+	case !validation.TemplatesValid:
+		validation.Message = "Skipped remediation because the NodeHealthCheck would be disabled"
+	case len(nodes) < nhc.Spec.MinSelectedNodes:
+		validation.Message = fmt.Sprintf("Skipped remediation because %d nodes are selected, but remediation requires at least %d", len(nodes), nhc.Spec.MinSelectedNodes)
+	case len(nhc.Spec.PauseRequests) > 0:
+		validation.Message = "Skipped remediation because of pause requests"
+	case !allowed:
+		validation.Message = message
+	default:
+		validation.RemediationAllowed = true
+	}
+	return validation, nil
+}
+
+// dryRunApply creates the given NodeHealthCheck, or updates it if it exists already, with a dry run request, and
+// returns the result, which has the defaults of the API server
+func dryRunApply(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck) (*remediationv1alpha1.NodeHealthCheck, error) {
+	applied := nhc.DeepCopy()
+	err := c.Create(ctx, applied, client.DryRunAll)
+	if !apierrors.IsAlreadyExists(err) {
+		return applied, err
+	}
+	existing := &remediationv1alpha1.NodeHealthCheck{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(nhc), existing); err != nil {
+		return nil, err
+	}
+	applied = nhc.DeepCopy()
+	applied.SetResourceVersion(existing.GetResourceVersion())
+	applied.Status = existing.Status
+	return applied, c.Update(ctx, applied, client.DryRunAll)
+}
@@ -0,0 +1,174 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Validate", func() {
+
+	var (
+		builder *fake.ClientBuilder
+		nhc     *remediationv1alpha1.NodeHealthCheck
+		now     time.Time
+	)
+
+	gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+
+	newNode := func(name string, ready corev1.ConditionStatus, readySince time.Time) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"role": "worker"}},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{
+					Type:               corev1.NodeReady,
+					Status:             ready,
+					LastTransitionTime: metav1.Time{Time: readySince},
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		minHealthy := intstr.FromString("60%")
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+				UnhealthyConditions: []remediationv1alpha1.UnhealthyCondition{{
+					Type:     corev1.NodeReady,
+					Status:   corev1.ConditionFalse,
+					Duration: metav1.Duration{Duration: 5 * time.Minute},
+				}},
+				MinHealthy: &minHealthy,
+				RemediationTemplate: &corev1.ObjectReference{
+					APIVersion: gv.String(), Kind: "RebootRemediationTemplate", Namespace: "default", Name: "template",
+				},
+			},
+		}
+
+		template := &unstructured.Unstructured{}
+		template.SetGroupVersionKind(gv.WithKind("RebootRemediationTemplate"))
+		template.SetNamespace("default")
+		template.SetName("template")
+		Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template")).To(Succeed())
+
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+		mapper.Add(gv.WithKind("RebootRemediationTemplate"), meta.RESTScopeNamespace)
+		mapper.Add(remediationv1alpha1.GroupVersion.WithKind("NodeHealthCheck"), meta.RESTScopeRoot)
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(remediationv1alpha1.AddToScheme(scheme))
+
+		builder = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(
+			template,
+			newNode("healthy-1", corev1.ConditionTrue, now.Add(-time.Hour)),
+			newNode("healthy-2", corev1.ConditionTrue, now.Add(-time.Hour)),
+			newNode("unhealthy", corev1.ConditionFalse, now.Add(-time.Hour)),
+			newNode("getting-unhealthy", corev1.ConditionFalse, now.Add(-time.Minute)),
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "not-selected"}},
+		)
+	})
+
+	It("reports selected and unhealthy nodes, and the MinHealthy math", func() {
+		c := builder.Build()
+		validation, err := Validate(context.Background(), c, zap.New(), now, nhc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validation.AdmissionError).To(BeEmpty())
+		Expect(validation.TemplatesValid).To(BeTrue())
+		Expect(validation.SelectedNodes).To(Equal([]string{"getting-unhealthy", "healthy-1", "healthy-2", "unhealthy"}))
+		Expect(validation.UnhealthyNodes).To(Equal([]UnhealthyNode{{Node: "unhealthy", Condition: "Ready=False"}}))
+		Expect(validation.Pending).To(ConsistOf(HaveField("Node", "getting-unhealthy")))
+		Expect(validation.Pending[0].EligibleAt.Time).To(Equal(now.Add(4 * time.Minute)))
+		Expect(validation.HealthyNodes).To(Equal(3))
+		Expect(validation.MinHealthy).To(Equal(3))
+		Expect(validation.RemediationAllowed).To(BeTrue())
+
+		By("not creating the NodeHealthCheck")
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), &remediationv1alpha1.NodeHealthCheck{})).
+			To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("reports skipped remediation when too few nodes are healthy", func() {
+		minHealthy := intstr.FromInt(4)
+		nhc.Spec.MinHealthy = &minHealthy
+		validation, err := Validate(context.Background(), builder.Build(), zap.New(), now, nhc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validation.RemediationAllowed).To(BeFalse())
+		Expect(validation.Message).To(ContainSubstring("is 3 and should equal or exceed 4"))
+	})
+
+	It("reports missing templates", func() {
+		nhc.Spec.RemediationTemplate.Name = "typo"
+		validation, err := Validate(context.Background(), builder.Build(), zap.New(), now, nhc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validation.TemplatesValid).To(BeFalse())
+		Expect(validation.TemplatesMessage).ToNot(BeEmpty())
+		Expect(validation.RemediationAllowed).To(BeFalse())
+	})
+
+	It("validates existing NodeHealthChecks without changing them", func() {
+		existing := nhc.DeepCopy()
+		existing.Spec.PauseRequests = []string{"maintenance"}
+		c := builder.WithObjects(existing).Build()
+		validation, err := Validate(context.Background(), c, zap.New(), now, nhc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validation.RemediationAllowed).To(BeTrue())
+
+		stored := &remediationv1alpha1.NodeHealthCheck{}
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), stored)).To(Succeed())
+		Expect(stored.Spec.PauseRequests).To(ConsistOf("maintenance"))
+	})
+
+	It("reports admission errors, and evaluates the manifest anyway", func() {
+		c := builder.WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				return apierrors.NewInvalid(remediationv1alpha1.GroupVersion.WithKind("NodeHealthCheck").GroupKind(), obj.GetName(), nil)
+			},
+		}).Build()
+		validation, err := Validate(context.Background(), c, zap.New(), now, nhc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validation.AdmissionError).To(ContainSubstring("is invalid"))
+		Expect(validation.UnhealthyNodes).To(HaveLen(1))
+	})
+
+	It("prints tables and JSON", func() {
+		validation, err := Validate(context.Background(), builder.Build(), zap.New(), now, nhc)
+		Expect(err).ToNot(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		Expect(PrintValidationTable(out, validation)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("allowed"))
+		Expect(out.String()).To(ContainSubstring("3, at least 3 required"))
+		Expect(out.String()).To(ContainSubstring("Ready=False"))
+		Expect(out.String()).To(ContainSubstring(PendingReasonUnhealthyCondition))
+
+		out.Reset()
+		Expect(PrintJSON(out, validation)).To(Succeed())
+		parsed := &Validation{}
+		Expect(json.Unmarshal(out.Bytes(), parsed)).To(Succeed())
+		Expect(parsed.SelectedNodes).To(HaveLen(4))
+	})
+})
@@ -0,0 +1,213 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/metrics"
+)
+
+var (
+	// reservationTimeout is the time after which a reservation, which isn't reflected in the NHC's status, is dropped.
+	// That happens when remediation couldn't be started after the slot was reserved, e.g. because of a taken node lease.
+	reservationTimeout = 1 * time.Minute
+	// waitingTimeout is the time after which a NHC, which didn't ask for a slot again, loses its place in the queue
+	waitingTimeout = 2 * time.Minute
+	currentTime    = func() time.Time { return time.Now() }
+)
+
+// Limiter limits the number of simultaneous remediations across all NodeHealthChecks
+type Limiter interface {
+	// TryAcquire returns true if the given NHC is allowed to remediate the given node. Nodes which are already
+	// being remediated are always allowed. For new remediations a slot is reserved, if one is available and
+	// the NHC is next in line.
+	TryAcquire(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) (bool, error)
+	// Release frees the slot of the given node of the given NHC
+	Release(nhcName, nodeName string)
+	// Max returns the configured maximum of simultaneous remediations
+	Max() int
+}
+
+// NewLimiter creates a new Limiter. If maxRemediations isn't positive, remediations aren't limited.
+func NewLimiter(c client.Client, maxRemediations int, log logr.Logger) Limiter {
+	if maxRemediations <= 0 {
+		return DummyLimiter{}
+	}
+	metrics.ObserveClusterRemediationsMax(maxRemediations)
+	return &limiter{
+		client:          c,
+		log:             log.WithName("ClusterRemediationLimiter"),
+		maxRemediations: maxRemediations,
+		reservations:    make(map[slot]time.Time),
+		waiting:         make([]waitingNHC, 0),
+	}
+}
+
+// slot identifies a remediation of a node by a NHC
+type slot struct {
+	nhcName  string
+	nodeName string
+}
+
+type waitingNHC struct {
+	nhcName string
+	since   time.Time
+}
+
+type limiter struct {
+	client          client.Client
+	log             logr.Logger
+	maxRemediations int
+	lock            sync.Mutex
+	// reservations are slots which were granted, but which might not be reflected in the NHC status yet
+	reservations map[slot]time.Time
+	// waiting is the queue of NHCs which were denied a slot, used for round-robin fairness
+	waiting []waitingNHC
+}
+
+var _ Limiter = &limiter{}
+
+func (l *limiter) TryAcquire(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	inFlight, err := l.getInFlightRemediations(ctx, nhc)
+	if err != nil {
+		return false, err
+	}
+	defer l.observe(inFlight)
+
+	requested := slot{nhcName: nhc.GetName(), nodeName: nodeName}
+	if _, exists := inFlight[requested]; exists {
+		// ongoing remediation, e.g. escalation to the next template
+		return true, nil
+	}
+
+	now := currentTime()
+	l.pruneWaiting(now)
+	free := l.maxRemediations - len(inFlight)
+	position := l.waitingPosition(nhc.GetName())
+	if position < free {
+		l.reservations[requested] = now
+		inFlight[requested] = struct{}{}
+		l.removeWaiting(nhc.GetName())
+		return true, nil
+	}
+
+	l.log.Info("cluster wide remediation limit reached, deferring remediation", "NHC", nhc.GetName(), "node", nodeName,
+		"max", l.maxRemediations, "inFlight", len(inFlight), "queue position", position)
+	if position == len(l.waiting) {
+		l.waiting = append(l.waiting, waitingNHC{nhcName: nhc.GetName(), since: now})
+	} else {
+		l.waiting[position].since = now
+	}
+	return false, nil
+}
+
+func (l *limiter) Release(nhcName, nodeName string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.reservations, slot{nhcName: nhcName, nodeName: nodeName})
+}
+
+func (l *limiter) Max() int {
+	return l.maxRemediations
+}
+
+// getInFlightRemediations returns all slots in use, based on the status of all NHCs and the pending reservations.
+// The status of the given NHC is taken from the given object, because it is more recent than the cached one.
+func (l *limiter) getInFlightRemediations(ctx context.Context, current *remediationv1alpha1.NodeHealthCheck) (map[slot]struct{}, error) {
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := l.client.List(ctx, nhcList); err != nil {
+		l.log.Error(err, "failed to list NHCs")
+		return nil, err
+	}
+
+	inFlight := make(map[slot]struct{})
+	existingNHCs := make(map[string]struct{}, len(nhcList.Items))
+	for i := range nhcList.Items {
+		nhc := &nhcList.Items[i]
+		if nhc.GetName() == current.GetName() {
+			nhc = current
+		}
+		existingNHCs[nhc.GetName()] = struct{}{}
+		for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+			if len(unhealthyNode.Remediations) > 0 {
+				inFlight[slot{nhcName: nhc.GetName(), nodeName: unhealthyNode.Name}] = struct{}{}
+			}
+		}
+	}
+
+	now := currentTime()
+	for reserved, since := range l.reservations {
+		if _, exists := inFlight[reserved]; exists {
+			// reflected in status, can be dropped
+			delete(l.reservations, reserved)
+			continue
+		}
+		if _, exists := existingNHCs[reserved.nhcName]; !exists || now.After(since.Add(reservationTimeout)) {
+			delete(l.reservations, reserved)
+			continue
+		}
+		inFlight[reserved] = struct{}{}
+	}
+	return inFlight, nil
+}
+
+func (l *limiter) waitingPosition(nhcName string) int {
+	for i, w := range l.waiting {
+		if w.nhcName == nhcName {
+			return i
+		}
+	}
+	return len(l.waiting)
+}
+
+func (l *limiter) removeWaiting(nhcName string) {
+	if i := l.waitingPosition(nhcName); i < len(l.waiting) {
+		l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+	}
+}
+
+func (l *limiter) pruneWaiting(now time.Time) {
+	waiting := make([]waitingNHC, 0, len(l.waiting))
+	for _, w := range l.waiting {
+		if now.Before(w.since.Add(waitingTimeout)) {
+			waiting = append(waiting, w)
+		}
+	}
+	l.waiting = waiting
+}
+
+func (l *limiter) observe(inFlight map[slot]struct{}) {
+	perNHC := make(map[string]int)
+	for s := range inFlight {
+		perNHC[s.nhcName]++
+	}
+	metrics.ObserveClusterRemediationsInFlight(len(inFlight), perNHC)
+}
+
+// DummyLimiter can be used when remediations aren't limited, or in tests
+// Using NewLimiter is recommended though
+type DummyLimiter struct{}
+
+var _ Limiter = DummyLimiter{}
+
+// TryAcquire always returns true
+func (d DummyLimiter) TryAcquire(_ context.Context, _ *remediationv1alpha1.NodeHealthCheck, _ string) (bool, error) {
+	return true, nil
+}
+
+// Release is a no op
+func (d DummyLimiter) Release(_, _ string) {}
+
+// Max always returns 0, which means unlimited
+func (d DummyLimiter) Max() int {
+	return 0
+}
@@ -0,0 +1,229 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// step is a call of the limiter at a given offset from the start of the test
+type step struct {
+	after time.Duration
+	// remediating updates the status of the NHC to the given remediated nodes before the call
+	remediating map[string][]string
+	// release releases the slot instead of trying to acquire it
+	release     bool
+	nhc         string
+	node        string
+	wantAllowed bool
+}
+
+func newNHC(name string, remediatedNodes ...string) *remediationv1alpha1.NodeHealthCheck {
+	nhc := &remediationv1alpha1.NodeHealthCheck{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	setRemediatedNodes(nhc, remediatedNodes)
+	return nhc
+}
+
+func setRemediatedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []string) {
+	nhc.Status.UnhealthyNodes = nil
+	for _, node := range nodes {
+		nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes, &remediationv1alpha1.UnhealthyNode{
+			Name:         node,
+			Remediations: []*remediationv1alpha1.Remediation{{Started: metav1.Now()}},
+		})
+	}
+}
+
+func newFakeClient(t *testing.T, nhcs ...*remediationv1alpha1.NodeHealthCheck) client.Client {
+	scheme := runtime.NewScheme()
+	if err := remediationv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, nhc := range nhcs {
+		builder.WithObjects(nhc)
+	}
+	return builder.Build()
+}
+
+func setClock(t *testing.T, now *time.Time) {
+	t.Cleanup(func() { currentTime = func() time.Time { return time.Now() } })
+	currentTime = func() time.Time { return *now }
+}
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		name  string
+		max   int
+		nhcs  []*remediationv1alpha1.NodeHealthCheck
+		steps []step
+	}{
+		{
+			name: "grants free slots only",
+			max:  2,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a", "node-1"), newNHC("b")},
+			steps: []step{
+				{nhc: "a", node: "node-2", wantAllowed: true},
+				// the reservation of node-2 isn't in the status yet, but counts
+				{nhc: "b", node: "node-3", wantAllowed: false},
+			},
+		},
+		{
+			name: "always allows ongoing remediations",
+			max:  1,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a", "node-1"), newNHC("b", "node-2")},
+			steps: []step{
+				{nhc: "a", node: "node-1", wantAllowed: true},
+				{nhc: "b", node: "node-2", wantAllowed: true},
+				{nhc: "b", node: "node-3", wantAllowed: false},
+			},
+		},
+		{
+			name: "drops reservations which aren't reflected in the status",
+			max:  1,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a"), newNHC("b")},
+			steps: []step{
+				{nhc: "a", node: "node-1", wantAllowed: true},
+				{after: 30 * time.Second, nhc: "b", node: "node-2", wantAllowed: false},
+				{after: reservationTimeout + time.Second, nhc: "b", node: "node-2", wantAllowed: true},
+			},
+		},
+		{
+			name: "keeps reservations which are reflected in the status",
+			max:  1,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a"), newNHC("b")},
+			steps: []step{
+				{nhc: "a", node: "node-1", wantAllowed: true},
+				{after: reservationTimeout + time.Second, remediating: map[string][]string{"a": {"node-1"}}, nhc: "b", node: "node-2", wantAllowed: false},
+			},
+		},
+		{
+			name: "frees released slots",
+			max:  1,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a"), newNHC("b")},
+			steps: []step{
+				{nhc: "a", node: "node-1", wantAllowed: true},
+				{nhc: "a", node: "node-1", release: true},
+				{nhc: "b", node: "node-2", wantAllowed: true},
+			},
+		},
+		{
+			name: "hands out free slots round-robin",
+			max:  1,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a", "node-1"), newNHC("b"), newNHC("c")},
+			steps: []step{
+				{nhc: "b", node: "node-2", wantAllowed: false},
+				{nhc: "c", node: "node-3", wantAllowed: false},
+				// a finished, b is first in line
+				{after: 10 * time.Second, remediating: map[string][]string{"a": nil}, nhc: "c", node: "node-3", wantAllowed: false},
+				{after: 10 * time.Second, nhc: "a", node: "node-4", wantAllowed: false},
+				{after: 10 * time.Second, nhc: "b", node: "node-2", wantAllowed: true},
+				{after: 20 * time.Second, remediating: map[string][]string{"b": {"node-2"}}, nhc: "a", node: "node-4", wantAllowed: false},
+				// b finished, c is next, before a
+				{after: 30 * time.Second, remediating: map[string][]string{"b": nil}, nhc: "a", node: "node-4", wantAllowed: false},
+				{after: 30 * time.Second, nhc: "c", node: "node-3", wantAllowed: true},
+			},
+		},
+		{
+			name: "drops NHCs from the queue which stopped asking",
+			max:  1,
+			nhcs: []*remediationv1alpha1.NodeHealthCheck{newNHC("a", "node-1"), newNHC("b"), newNHC("c")},
+			steps: []step{
+				{nhc: "b", node: "node-2", wantAllowed: false},
+				{after: time.Minute, nhc: "c", node: "node-3", wantAllowed: false},
+				{after: waitingTimeout + time.Second, remediating: map[string][]string{"a": nil}, nhc: "c", node: "node-3", wantAllowed: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			setClock(t, &now)
+			c := newFakeClient(t, tt.nhcs...)
+			l := NewLimiter(c, tt.max, zap.New())
+
+			start := now
+			for i, s := range tt.steps {
+				now = start.Add(s.after)
+				for name, nodes := range s.remediating {
+					nhc := &remediationv1alpha1.NodeHealthCheck{}
+					if err := c.Get(context.Background(), client.ObjectKey{Name: name}, nhc); err != nil {
+						t.Fatal(err)
+					}
+					setRemediatedNodes(nhc, nodes)
+					if err := c.Update(context.Background(), nhc); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if s.release {
+					l.Release(s.nhc, s.node)
+					continue
+				}
+				nhc := &remediationv1alpha1.NodeHealthCheck{}
+				if err := c.Get(context.Background(), client.ObjectKey{Name: s.nhc}, nhc); err != nil {
+					t.Fatal(err)
+				}
+				allowed, err := l.TryAcquire(context.Background(), nhc, s.node)
+				if err != nil {
+					t.Fatalf("step %d: TryAcquire() error = %v", i, err)
+				}
+				if allowed != s.wantAllowed {
+					t.Errorf("step %d: TryAcquire(%s, %s) = %v, want %v", i, s.nhc, s.node, allowed, s.wantAllowed)
+				}
+			}
+		})
+	}
+}
+
+// TestLimiterConcurrency ensures that concurrent reconciles don't oversubscribe the limit, run it with -race
+func TestLimiterConcurrency(t *testing.T) {
+	const (
+		maxRemediations = 3
+		nhcs            = 5
+		nodes           = 10
+	)
+	var objects []*remediationv1alpha1.NodeHealthCheck
+	for i := 0; i < nhcs; i++ {
+		objects = append(objects, newNHC(fmt.Sprintf("nhc-%d", i)))
+	}
+	l := NewLimiter(newFakeClient(t, objects...), maxRemediations, zap.New())
+
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		granted []string
+	)
+	for _, nhc := range objects {
+		for i := 0; i < nodes; i++ {
+			wg.Add(1)
+			go func(nhc *remediationv1alpha1.NodeHealthCheck, node string) {
+				defer wg.Done()
+				allowed, err := l.TryAcquire(context.Background(), nhc.DeepCopy(), node)
+				if err != nil {
+					t.Errorf("TryAcquire() error = %v", err)
+					return
+				}
+				if allowed {
+					lock.Lock()
+					defer lock.Unlock()
+					granted = append(granted, nhc.GetName()+"/"+node)
+				}
+			}(nhc, fmt.Sprintf("node-%d", i))
+		}
+	}
+	wg.Wait()
+
+	if len(granted) != maxRemediations {
+		t.Errorf("granted %d slots %v, want %d", len(granted), granted, maxRemediations)
+	}
+}
@@ -159,7 +159,7 @@ func (r *MachineHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if err != nil {
 		return result, err
 	}
-	resourceManager := resources.NewManager(r.Client, ctx, r.Log, true, leaseManager, r.Recorder)
+	resourceManager := resources.NewManager(r.Client, ctx, r.Log, true, leaseManager, r.Recorder, false, nil)
 
 	// always check if we need to patch status before we exit Reconcile
 	mhcOrig := mhc.DeepCopy()
@@ -1101,7 +1101,7 @@ func TestGetTargetsFromMHC(t *testing.T) {
 			reconciler := newFakeReconciler(objects...)
 			leaseManager, _ := resources.NewLeaseManager(reconciler.Client, "test", reconciler.Log)
 			recorder := record.NewFakeRecorder(2)
-			rm := resources.NewManager(reconciler, ctx, reconciler.Log, true, leaseManager, recorder)
+			rm := resources.NewManager(reconciler, ctx, reconciler.Log, true, leaseManager, recorder, false, nil)
 			got, err := rm.GetMHCTargets(tc.mhc)
 			if !equality.Semantic.DeepEqual(got, tc.expectedTargets) {
 				t.Errorf("Case: %v. Got: %+v, expected: %+v", tc.testCase, got, tc.expectedTargets)
@@ -19,9 +19,12 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-logr/logr"
 	commonannotations "github.com/medik8s/common/pkg/annotations"
@@ -34,14 +37,17 @@ import (
 	"github.com/pkg/errors"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -55,17 +61,31 @@ import (
 
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
 	"github.com/medik8s/node-healthcheck-operator/controllers/cluster"
+	"github.com/medik8s/node-healthcheck-operator/controllers/connectivity"
+	"github.com/medik8s/node-healthcheck-operator/controllers/disruption"
+	"github.com/medik8s/node-healthcheck-operator/controllers/drain"
+	"github.com/medik8s/node-healthcheck-operator/controllers/eventsink"
+	"github.com/medik8s/node-healthcheck-operator/controllers/heartbeat"
+	"github.com/medik8s/node-healthcheck-operator/controllers/impersonation"
+	"github.com/medik8s/node-healthcheck-operator/controllers/limiter"
 	"github.com/medik8s/node-healthcheck-operator/controllers/mhc"
+	"github.com/medik8s/node-healthcheck-operator/controllers/remote"
 	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
+	"github.com/medik8s/node-healthcheck-operator/controllers/silencing"
+	"github.com/medik8s/node-healthcheck-operator/controllers/storage"
+	"github.com/medik8s/node-healthcheck-operator/controllers/surge"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utilization"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
+	"github.com/medik8s/node-healthcheck-operator/controllers/verification"
 	"github.com/medik8s/node-healthcheck-operator/metrics"
 )
 
 const (
 	oldRemediationCRAnnotationKey = "nodehealthcheck.medik8s.io/old-remediation-cr-flag"
 	remediationCRAlertTimeout     = time.Hour * 48
-	eventReasonNoTemplateLeft     = "NoTemplateLeft"
 	enabledMessage                = "No issues found, NodeHealthCheck is enabled."
+	abortAllPauseRequestPrefix    = "abort-all: "
 
 	// RemediationControlPlaneLabelKey is the label key to put on remediation CRs for control plane nodes
 	RemediationControlPlaneLabelKey = "remediation.medik8s.io/isControlPlaneNode"
@@ -73,29 +93,91 @@ const (
 
 var (
 	clusterUpgradeRequeueAfter       = 1 * time.Minute
-	templateNotFoundRequeueAfter     = 15 * time.Second
+	remediationLimitRequeueAfter     = 30 * time.Second
+	remoteClusterRequeueAfter        = 1 * time.Minute
+	surgeGateRequeueAfter            = 1 * time.Minute
+	storageGateRequeueAfter          = 30 * time.Second
+	disruptionGateRequeueAfter       = 30 * time.Second
+	topologyDeferralRequeueAfter     = 1 * time.Minute
+	disabledRetryMinRequeueAfter     = 15 * time.Second
+	disabledRetryMaxRequeueAfter     = 10 * time.Minute
+	crdWaitMinRequeueAfter           = 1 * time.Second
+	crdWaitMaxRequeueAfter           = 30 * time.Second
+	removedKindCleanUpRequeueAfter   = 10 * time.Second
+	orphanedCleanUpRequeueAfter      = 1 * time.Minute
+	drainRequeueAfter                = 10 * time.Second
+	abortAllRequeueAfter             = 30 * time.Second
+	resetNodeRequeueAfter            = 10 * time.Second
+	utilizationCheckRequeueAfter     = 1 * time.Minute
+	remediatorCheckRequeueAfter      = 1 * time.Minute
+	alertSilencingRetryRequeueAfter  = 1 * time.Minute
+	externalDeletionGracePeriod      = 10 * time.Second
 	logWhenCRPendingDeletionDuration = 10 * time.Second
+	verificationPollInterval         = 10 * time.Second
 	currentTime                      = func() time.Time { return time.Now() }
 )
 
 // NodeHealthCheckReconciler reconciles a NodeHealthCheck object
 type NodeHealthCheckReconciler struct {
+	// Client is expected to read from the manager's cache, so that the node reads of all NHCs are served by a single
+	// shared node informer, instead of listing all nodes from the API server on every reconcile
 	client.Client
 	Log                         logr.Logger
 	Recorder                    record.EventRecorder
 	ClusterUpgradeStatusChecker cluster.UpgradeChecker
 	MHCChecker                  mhc.Checker
+	RemediationLimiter          limiter.Limiter
+	RemoteClients               remote.ClientProvider
+	ImpersonatingClients        impersonation.ClientProvider
+	EventEmitter                eventsink.Emitter
+	SurgeGate                   surge.Gate
+	StorageGate                 storage.Gate
+	DisruptionGate              disruption.Gate
+	Verifier                    verification.Verifier
+	ConnectivityChecker         connectivity.Checker
+	HeartbeatChecker            heartbeat.Checker
+	UtilizationChecker          utilization.Checker
+	AlertSilencer               silencing.Silencer
+	Drainer                     drain.Drainer
 	OnOpenShift                 bool
-	MHCEvents                   chan event.GenericEvent
-	controller                  controller.Controller
-	watches                     map[string]struct{}
-	watchesLock                 *sync.Mutex
-	cache                       cache.Cache
+	// DisableInFlightRemediationsStatus prevents writing the deprecated InFlightRemediations status field
+	DisableInFlightRemediationsStatus bool
+	// PlainTimedOutAnnotation writes the timed out annotation with the timestamp only, for remediators which don't
+	// support its structured form
+	PlainTimedOutAnnotation bool
+	// GenerateRemediationCRNames creates remediation CRs with a generated name instead of the node name
+	GenerateRemediationCRNames bool
+	// CRDWaitTimeout is the time to wait for the CRD of a remediation template to be established, before the NHC
+	// is disabled. Zero disables waiting.
+	CRDWaitTimeout time.Duration
+	// LastReconcileTimeInterval is the maximum age of the LastReconcileTime status field before it is refreshed by a
+	// successful reconcile. Zero disables the field.
+	LastReconcileTimeInterval time.Duration
+	// RemediationCreationConcurrency is the maximum number of remediation CRs which are created in parallel during a
+	// single reconcile. Values below 2 create them one after another.
+	RemediationCreationConcurrency int
+	// ManagementCluster is the management cluster of hosted control planes, where the Machines of the nodes live.
+	// Nil when Machines live next to the nodes.
+	ManagementCluster *resources.ManagementCluster
+	MHCEvents         chan event.GenericEvent
+	controller        controller.Controller
+	watches           map[string]struct{}
+	watchesLock       *sync.Mutex
+	cache             cache.Cache
+	// disabledRetries has the disabledRetry of disabled NHCs by name
+	disabledRetries sync.Map
+}
+
+// disabledRetry is the schedule of evaluating a disabled NHC again
+type disabledRetry struct {
+	generation int64
+	interval   time.Duration
+	next       time.Time
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NodeHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	controller, err := ctrl.NewControllerManagedBy(mgr).
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&remediationv1alpha1.NodeHealthCheck{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(
 			&v1.Node{},
@@ -103,7 +185,9 @@ func (r *NodeHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			builder.WithPredicates(
 				predicate.Funcs{
 					// check for modified conditions on updates in order to prevent unneeded reconciliations
-					UpdateFunc: func(ev event.UpdateEvent) bool { return nodeUpdateNeedsReconcile(ev) },
+					UpdateFunc: func(ev event.UpdateEvent) bool {
+						return nodeUpdateNeedsReconcile(ev) || r.nodeCapacityUpdateNeedsReconcile(ev) || r.nodeAnnotationUpdateNeedsReconcile(ev)
+					},
 					// potentially delete orphaned remediation CRs when new node will have new name
 					DeleteFunc: func(_ event.DeleteEvent) bool { return true },
 					// create (new nodes don't have correct conditions yet), and generic events are not interesting for now
@@ -115,8 +199,21 @@ func (r *NodeHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		WatchesRawSource(
 			&source.Channel{Source: r.MHCEvents},
 			handler.EnqueueRequestsFromMapFunc(utils.NHCByMHCEventMapperFunc(mgr.GetClient(), mgr.GetLogger())),
-		).
-		Build(r)
+		)
+	if r.ConnectivityChecker.IsEnabled() {
+		// reports are named like their node, so they can be mapped like nodes
+		bldr = bldr.Watches(
+			&remediationv1alpha1.NodeConnectivityReport{},
+			handler.EnqueueRequestsFromMapFunc(utils.NHCByNodeMapperFunc(mgr.GetClient(), mgr.GetLogger())),
+			builder.WithPredicates(
+				predicate.Funcs{
+					// ignore probe time updates
+					UpdateFunc: func(ev event.UpdateEvent) bool { return connectivityReportUpdateNeedsReconcile(ev) },
+				},
+			),
+		)
+	}
+	controller, err := bldr.Build(r)
 
 	if err != nil {
 		return err
@@ -128,18 +225,33 @@ func (r *NodeHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return nil
 }
 
-// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=nodes/status,verbs=patch
 // +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthchecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthchecks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthchecks/finalizers,verbs=update
+// +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodeconnectivityreports,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=machine.openshift.io,resources=machines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=machine.openshift.io,resources=machinesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=nodes,verbs=get;list
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;update;patch;watch;create;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;create
+// +kubebuilder:rbac:groups=core,namespace=system,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=impersonate
 
 // for the etcd check of github.com/medik8s/common/pkg/etcd
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+
+// for storage gating
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes;persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
+
+// for remediator health checks
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -152,33 +264,58 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("NodeHealthCheck CR not found", "name", req.Name)
+			metrics.DeleteNodeHealthCheckInfo(req.Name)
+			metrics.DeleteNodeHealthCheckPhaseDurations(req.Name)
+			metrics.DeleteNodeHealthCheckReconcileSuccess(req.Name)
+			metrics.DeleteNodeHealthCheckRemediatorCircuits(req.Name)
+			metrics.DeleteNodeHealthCheckAlertSilencingFailures(req.Name)
+			metrics.DeleteNodeHealthCheckNodes(req.Name)
+			r.disabledRetries.Delete(req.Name)
+			r.RemoteClients.RemoveClient(req.Name)
 			return result, nil
 		}
 		log.Error(err, "failed to get NodeHealthCheck CR", "name", req.Name)
 		return result, err
 	}
-
-	leaseHolderIdent := fmt.Sprintf("NodeHealthCheck-%s", nhc.GetName())
-	leaseManager, err := resources.NewLeaseManager(r.Client, leaseHolderIdent, log)
-	if err != nil {
-		return result, err
-	}
-	resourceManager := resources.NewManager(r.Client, ctx, r.Log, r.OnOpenShift, leaseManager, r.Recorder)
+	// respect the NHC's log level from now on
+	log = utils.GetLogWithNHC(r.Log, nhc)
+	observeInfo(nhc)
 
 	// always check if we need to patch status before we exit Reconcile
 	nhcOrig := nhc.DeepCopy()
 	defer func() {
+		updateLastError(nhc, returnErr)
+		trackFailedCleanUp(nhc, returnErr)
+		now := currentTime()
+		if nhc.Spec.AlertSilencing != nil || len(nhc.Status.AlertSilences) > 0 {
+			updateRequeueAfter(&result, r.syncAlertSilences(ctx, nhc, now))
+		}
+		if returnErr == nil {
+			r.updateLastReconcileTime(nhc, now)
+		}
+		updateRequeueAfter(&result, r.updateNextRetryTime(nhc, now))
+		observeNodeCounts(nhc)
 		patchErr := r.patchStatus(ctx, log, nhc, nhcOrig)
 		if patchErr != nil {
 			log.Error(err, "failed to update status")
 		}
 		returnErr = utilerrors.NewAggregate([]error{patchErr, returnErr})
+		if returnErr == nil {
+			metrics.ObserveNodeHealthCheckReconcileSuccess(nhc.GetName(), now)
+			// re-evaluate regularly if configured, in case node events were missed
+			updateRequeueAfter(&result, getPollingInterval(nhc))
+			// keep the heartbeat going in quiet clusters
+			if r.LastReconcileTimeInterval > 0 && (result.RequeueAfter == 0 || result.RequeueAfter > r.LastReconcileTimeInterval) {
+				result.RequeueAfter = r.LastReconcileTimeInterval
+			}
+		}
 		log.Info("reconcile end", "error", returnErr, "requeue", result.Requeue, "requeuAfter", result.RequeueAfter)
 	}()
 
 	// set counters to zero for disabled NHC
 	nhc.Status.ObservedNodes = pointer.Int(0)
 	nhc.Status.HealthyNodes = pointer.Int(0)
+	nhc.Status.UnhealthyDurationBuckets = nil
 
 	// check if we need to disable NHC because of existing MHCs
 	if disable := r.MHCChecker.NeedDisableNHC(); disable {
@@ -197,11 +334,67 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return result, nil
 	}
 
+	// get the client for nodes and remediation resources, which points to a remote cluster if configured
+	nodesClient, err := r.RemoteClients.GetClient(ctx, nhc)
+	if err != nil {
+		message := fmt.Sprintf("Remote cluster can't be used: %s", err.Error())
+		if !utils.IsConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled, remediationv1alpha1.ConditionReasonDisabledRemoteCluster) {
+			log.Info("disabling NHC", "reason", remediationv1alpha1.ConditionReasonDisabledRemoteCluster, "message", message)
+			meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+				Type:    remediationv1alpha1.ConditionTypeDisabled,
+				Status:  metav1.ConditionTrue,
+				Reason:  remediationv1alpha1.ConditionReasonDisabledRemoteCluster,
+				Message: message,
+			})
+			commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonDisabled, "Disabling NHC. Reason: %s, Message: %s", remediationv1alpha1.ConditionReasonDisabledRemoteCluster, message)
+		}
+		// the disabled NHC is evaluated again with backoff, for checking back if the remote cluster is available later
+		return result, nil
+	}
+	if nhc.Spec.RemoteCluster != nil {
+		// we don't get node events from remote clusters, so we need to poll
+		updateRequeueAfter(&result, &remoteClusterRequeueAfter)
+	}
+
+	leaseHolderIdent := fmt.Sprintf("NodeHealthCheck-%s", nhc.GetName())
+	leaseManager, err := resources.NewLeaseManager(nodesClient, leaseHolderIdent, log)
+	if err != nil {
+		return result, err
+	}
+	// remediation CRs are managed while impersonating the remediation ServiceAccount, if configured
+	remediationClient, err := r.ImpersonatingClients.GetClient(nodesClient, nhc)
+	if err != nil {
+		return result, err
+	}
+	// Machines, and optionally remediation CRs, live in the management cluster of hosted control planes
+	machinesClient := nodesClient
+	managementCluster := r.getManagementCluster(nhc)
+	if managementCluster != nil {
+		machinesClient = managementCluster.Client
+		if managementCluster.HasRemediations {
+			if nhc.Spec.RemediationServiceAccount != nil {
+				return result, errors.New("the remediation ServiceAccount can't be used for remediation CRs in the management cluster")
+			}
+			remediationClient = managementCluster.Client
+			// we don't get events of remediation CRs in the management cluster, so we need to poll
+			updateRequeueAfter(&result, &remoteClusterRequeueAfter)
+		}
+	}
+	resourceManager := resources.NewManager(remediationClient, ctx, log, r.OnOpenShift, leaseManager, r.Recorder, r.GenerateRemediationCRNames, managementCluster)
+
 	// check if we need to disable NHC because of missing or misconfigured template CRs
 	if valid, reason, message, err := resourceManager.ValidateTemplates(nhc); err != nil {
 		log.Error(err, "failed to validate template")
 		return result, err
 	} else if !valid {
+		if reason == remediationv1alpha1.ConditionReasonCRDNotEstablished {
+			if requeueAfter, waiting := r.waitForCRD(nhc, message, log); waiting {
+				result.RequeueAfter = requeueAfter
+				return result, nil
+			}
+			// we waited long enough, the template can't be found
+			reason = remediationv1alpha1.ConditionReasonDisabledTemplateNotFound
+		}
 		if !utils.IsConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled, reason) {
 			log.Info("disabling NHC", "reason", reason, "message", message)
 			meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
@@ -212,14 +405,42 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			})
 			commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonDisabled, "Disabling NHC. Reason: %s, Message: %s", reason, message)
 		}
-		if reason == remediationv1alpha1.ConditionReasonDisabledTemplateNotFound {
-			// requeue for checking back if template exists later
-			result.RequeueAfter = templateNotFoundRequeueAfter
-		}
+		// the disabled NHC is evaluated again with backoff, for checking back if the template exists later
 		return result, nil
 	}
 
+	// check if we need to disable NHC because the remediation ServiceAccount lacks permissions
+	if sa := nhc.Spec.RemediationServiceAccount; sa != nil {
+		if allowed, message, err := resourceManager.ValidateRemediationCRPermissions(nhc); err != nil {
+			log.Error(err, "failed to validate remediation CR permissions")
+			return result, err
+		} else if !allowed {
+			message = fmt.Sprintf("ServiceAccount %s/%s isn't allowed to manage remediation CRs: %s", sa.Namespace, sa.Name, message)
+			if !utils.IsConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled, remediationv1alpha1.ConditionReasonDisabledRBAC) {
+				log.Info("disabling NHC", "reason", remediationv1alpha1.ConditionReasonDisabledRBAC, "message", message)
+				meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+					Type:    remediationv1alpha1.ConditionTypeDisabled,
+					Status:  metav1.ConditionTrue,
+					Reason:  remediationv1alpha1.ConditionReasonDisabledRBAC,
+					Message: message,
+				})
+				commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonDisabled, "Disabling NHC. Reason: %s, Message: %s", remediationv1alpha1.ConditionReasonDisabledRBAC, message)
+			}
+			// the disabled NHC is evaluated again with backoff, for checking back if RBAC was fixed
+			return result, nil
+		}
+	}
+
 	// all checks passed, update status if needed
+	if meta.IsStatusConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeWaitingForCRD) {
+		log.Info("remediation CRD is established")
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeWaitingForCRD,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonCRDEstablished,
+			Message: "CRDs of all remediation templates are established",
+		})
+	}
 	if !meta.IsStatusConditionFalse(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled) {
 		log.Info("enabling NHC, valid config, no conflicting MHC configured in the cluster")
 		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
@@ -231,19 +452,97 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		commonevents.NormalEvent(r.Recorder, nhc, utils.EventReasonEnabled, enabledMessage)
 	}
 
-	// add watches for template and remediation CRs
-	if err = r.addWatches(resourceManager, nhc); err != nil {
+	// add watches for template and remediation CRs, which is only possible for the local cluster
+	if nhc.Spec.RemoteCluster == nil && (managementCluster == nil || !managementCluster.HasRemediations) {
+		if err = r.addWatches(resourceManager, nhc); err != nil {
+			return result, err
+		}
+	}
+
+	// reconstruct unhealthy nodes of NHCs which were updated from a version only maintaining InFlightRemediations
+	if err = r.migrateInFlightRemediations(nhc, resourceManager, log); err != nil {
+		return result, err
+	}
+
+	// delete remediation CRs of kinds which were removed from the spec
+	if pending, err := r.cleanUpRemovedRemediationKinds(nhc, resourceManager, log); err != nil {
 		return result, err
+	} else if pending {
+		// check back for removing the kind from the status, the CRs might not be watched anymore
+		updateRequeueAfter(&result, &removedKindCleanUpRequeueAfter)
+	}
+
+	// retry deleting remediation CRs which couldn't be deleted before
+	if pending := r.retryOrphanedRemediationsCleanUp(nhc, resourceManager, log); pending {
+		updateRequeueAfter(&result, &orphanedCleanUpRequeueAfter)
 	}
 
-	// select nodes using the nhc.selector
-	selectedNodes, err := resourceManager.GetNodes(nhc.Spec.Selector)
+	// abort all remediations and keep the NHC paused, when requested by an admin
+	if reason, abort := annotations.GetAbortAllReason(nhc); abort {
+		if pending, err := r.abortAll(ctx, nodesClient, nhc, reason, resourceManager, log); err != nil {
+			return result, err
+		} else if pending {
+			updateRequeueAfter(&result, &abortAllRequeueAfter)
+		}
+	}
+
+	// reset the remediation state of a node, when requested by an admin
+	if nodeName, reset := annotations.GetResetNode(nhc); reset {
+		if pending, err := r.resetNode(ctx, nodesClient, nhc, nodeName, resourceManager, log); err != nil {
+			return result, err
+		} else if pending {
+			updateRequeueAfter(&result, &resetNodeRequeueAfter)
+		}
+	}
+
+	// track remediators which aren't running, Deployments aren't watched, so check back regularly
+	if len(nhc.Spec.RemediatorHealthCheck) > 0 {
+		if err := r.updateUnavailableRemediators(ctx, nodesClient, nhc, currentTime()); err != nil {
+			return result, err
+		}
+		updateRequeueAfter(&result, &remediatorCheckRequeueAfter)
+	}
+
+	// select nodes using the nhc.selector, or the nhc.nodeName
+	selectedNodes, err := resourceManager.GetSelectedNodes(nhc)
 	if err != nil {
 		return result, err
 	}
 
+	// track insufficient capacity, it has no transition time
+	updateInsufficientCapacityNodes(nhc, selectedNodes, currentTime())
+	// and unhealthy taints without timestamp
+	updateTaintedNodes(nhc, selectedNodes, currentTime())
+	// and unhealthy annotations, they have no timestamp at all
+	updateAnnotatedNodes(nhc, selectedNodes, currentTime())
+
+	// track high utilization, node metrics have no transition time and no events, so check back regularly
+	if err := r.updateHighUtilizationNodes(ctx, nodesClient, nhc, selectedNodes, currentTime()); err != nil {
+		return result, err
+	}
+	if len(nhc.Spec.UnhealthyUtilization) > 0 && r.UtilizationChecker.IsEnabled() {
+		updateRequeueAfter(&result, &utilizationCheckRequeueAfter)
+	}
+
+	// forget remediations which finished before the adaptive stabilization window
+	resources.PruneStatusStabilizingNodes(nhc, currentTime())
+
 	// check nodes health
-	notMatchingNodes, soonMatchingNodes, matchingNodes, requeueAfter := r.checkNodeConditions(selectedNodes, nhc)
+	notMatchingNodes, soonMatchingNodes, matchingNodes, evaluations, requeueAfter, err := r.checkNodeConditions(ctx, nodesClient, selectedNodes, nhc)
+	if err != nil {
+		return result, err
+	}
+	updateRequeueAfter(&result, requeueAfter)
+	r.updateStatusUnhealthySignals(nhc, evaluations)
+	resources.UpdateStatusUnhealthyDurationBuckets(nhc, append(soonMatchingNodes, matchingNodes...), currentTime())
+	resources.UpdateStatusEffectiveDurations(nhc, getEffectiveDurations(nhc, soonMatchingNodes, currentTime()))
+	// don't remediate nodes which were unhealthy already when the NHC was created, if configured
+	matchingNodes, ignoredNodes := r.filterPreexistingUnhealthyNodes(nhc, matchingNodes, evaluations, log)
+	// don't remediate nodes which are younger than the min node age
+	matchingNodes, tooYoungNodes, requeueAfter := r.filterTooYoungNodes(nhc, matchingNodes, log)
+	updateRequeueAfter(&result, requeueAfter)
+	// track nodes which only match report only conditions, they are not remediated
+	requeueAfter = r.updateReportOnlyUnhealthyNodes(nhc, append(notMatchingNodes, soonMatchingNodes...), log)
 	updateRequeueAfter(&result, requeueAfter)
 
 	// TODO consider setting Disabled condition?
@@ -252,6 +551,7 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		log.Info(msg)
 		commonevents.NormalEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
 		result.RequeueAfter = clusterUpgradeRequeueAfter
+		updateRequeueAfter(&result, getPollingInterval(nhc))
 		return result, nil
 	}
 
@@ -263,10 +563,23 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return result, nil
 	}
 
+	// start over with remediations which were aborted with the abort-all annotation
+	resources.RemoveStatusRemediationsAbortedAll(nhc)
+
+	// forget remediations which finished before the escalation memory window
+	resources.PruneStatusRecentRemediations(nhc, currentTime())
+	// and nodes whose remediation cooldown expired
+	resources.PruneStatusCoolingDownNodes(nhc, currentTime())
+
 	// Delete orphaned CRs: they have no node, and Succeeded and NodeNameChangeExpected conditions set to True.
 	// This happens e.g. on cloud providers with Machine Deletion remediation: the broken node will be deleted and
 	// a new node created, with a new name, and no relationship to the old node
-	if err = r.deleteOrphanedRemediationCRs(nhc, append(notMatchingNodes, append(soonMatchingNodes, matchingNodes...)...), resourceManager, log); err != nil {
+	if err = r.deleteOrphanedRemediationCRs(nhc, append(notMatchingNodes, append(soonMatchingNodes, append(matchingNodes, append(ignoredNodes, tooYoungNodes...)...)...)...), resourceManager, log); err != nil {
+		return result, err
+	}
+
+	// forget unhealthy nodes which were deleted from the cluster
+	if err = r.handleDeletedNodes(ctx, nodesClient, nhc, selectedNodes, resourceManager, log); err != nil {
 		return result, err
 	}
 
@@ -276,6 +589,23 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// (e.g. from Ready=Unknown to Ready=False)
 	healthyCount := 0
 	for _, node := range notMatchingNodes {
+		// remediation of nodes with failed verification continues, until the re-escalated remediation succeeded
+		if reEscalating, err := r.isReEscalating(nhc, &node, resourceManager, log); err != nil {
+			log.Error(err, "failed to check re-escalated remediation", "node", node.Name)
+			return result, err
+		} else if reEscalating {
+			matchingNodes = append(matchingNodes, node)
+			continue
+		}
+
+		// set the RemediatedByNHC node condition to False when its retention expired
+		if requeueIn, err := r.expireRemediationRecord(ctx, nodesClient, nhc, &node, log); err != nil {
+			log.Error(err, "failed to expire remediation record on node", "node", node.Name)
+			return result, err
+		} else {
+			updateRequeueAfter(&result, requeueIn)
+		}
+
 		log.Info("handling healthy node", "node", node.GetName())
 		remediationCRs, err := resourceManager.HandleHealthyNode(node.GetName(), node.GetName(), nhc)
 		if err != nil {
@@ -285,7 +615,40 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 		// only consider nodes without remediation CRs as healthy
 		if len(remediationCRs) == 0 {
+			// but only after their remediation was verified
+			if verified, reEscalate, requeueIn, err := r.verifyRemediation(ctx, nodesClient, nhc, &node, resourceManager, log); err != nil {
+				log.Error(err, "failed to verify remediation", "node", node.Name)
+				return result, err
+			} else if !verified {
+				updateRequeueAfter(&result, requeueIn)
+				if reEscalate {
+					matchingNodes = append(matchingNodes, node)
+				}
+				continue
+			}
+			if err := r.recordRemediationOnNode(ctx, nodesClient, nhc, &node, log); err != nil {
+				log.Error(err, "failed to record remediation on node", "node", node.Name)
+				return result, err
+			}
+			// make a node schedulable again, which was drained before remediation
+			if resources.GetStatusDrain(node.GetName(), nhc) != nil {
+				if err := r.Drainer.Uncordon(ctx, nodesClient, node.GetName()); err != nil {
+					log.Error(err, "failed to uncordon node", "node", node.Name)
+					return result, err
+				}
+			}
+			r.emitRemediationCompletedEvent(nhc, node.GetName())
+			r.observeRemediationSucceeded(nhc, node.GetName())
+			resources.UpdateStatusRecentRemediation(node.GetName(), nhc, currentTime())
+			resources.RecordStatusStabilizationRemediation(node.GetName(), nhc, currentTime())
+			resources.RecordStatusRemediationCooldown(node.GetName(), nhc, currentTime())
 			resources.UpdateStatusNodeHealthy(node.GetName(), nhc)
+			r.RemediationLimiter.Release(nhc.GetName(), node.GetName())
+			// not matching any unhealthy condition isn't enough for counting the node as healthy, if configured
+			if nhc.Spec.RequirePositiveHealth && !resources.HasPositiveHealth(&node) {
+				log.Info("not counting node as healthy, its Ready condition isn't True", "node", node.GetName())
+				continue
+			}
 			healthyCount++
 			continue
 		}
@@ -318,6 +681,9 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	nhc.Status.ObservedNodes = pointer.Int(len(selectedNodes))
 	nhc.Status.HealthyNodes = &healthyCount
 
+	// withhold remediation as long as too few nodes are selected
+	poolTooSmall := r.updatePoolTooSmallCondition(nhc, len(selectedNodes), log)
+
 	// log currently unhealthy nodes with only soon unhealthy conditions left
 	for _, node := range soonMatchingNodes {
 		for _, unhealthy := range nhc.Status.UnhealthyNodes {
@@ -327,29 +693,86 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	// count nodes under remediation, and remediations started within the window of the remediation throttle
+	resources.UpdateStatusRemediationThrottle(nhc, currentTime())
+
+	// summarize the configuration which is enforced
+	if err = r.updateEffectiveConfig(nhc, resourceManager, len(selectedNodes)-len(tooYoungNodes)); err != nil {
+		log.Error(err, "failed to update effective config")
+		return result, err
+	}
+
+	// re-evaluate when a remediation window opens or closes, which also keeps the phase up to date
+	inRemediationWindow, nextWindowChange := utils.GetRemediationWindowState(nhc.Spec.RemediationWindows, currentTime())
+	if !nextWindowChange.IsZero() {
+		updateRequeueAfter(&result, pointer.Duration(nextWindowChange.Sub(currentTime())+1*time.Second))
+	}
+
 	// we are done in case we don't have unhealthy nodes
 	if len(matchingNodes) == 0 {
 		return result, nil
 	}
+	// show which unhealthy signals the nodes, which are added to the status below, matched
+	defer r.updateStatusUnhealthySignals(nhc, evaluations)
 
-	// check if we have enough healthy nodes
+	// check if we have enough selected and healthy nodes
 	skipRemediation := false
-	if minHealthy, err := intstr.GetScaledValueFromIntOrPercent(nhc.Spec.MinHealthy, len(selectedNodes), true); err != nil {
+	if poolTooSmall {
+		msg := fmt.Sprintf("Skipped remediation because the number of nodes selected by the selector is %d and should equal or exceed %d", len(selectedNodes), nhc.Spec.MinSelectedNodes)
+		log.Info(msg)
+		commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+		skipRemediation = true
+	} else if allowed, msg, err := resources.CheckMinHealthy(nhc, len(selectedNodes)-len(tooYoungNodes), *nhc.Status.HealthyNodes); err != nil {
 		log.Error(err, "failed to calculate min healthy allowed nodes",
 			"minHealthy", nhc.Spec.MinHealthy, "observedNodes", nhc.Status.ObservedNodes)
 		return result, err
-	} else if *nhc.Status.HealthyNodes < minHealthy {
-		msg := fmt.Sprintf("Skipped remediation because the number of healthy nodes selected by the selector is %d and should equal or exceed %d", *nhc.Status.HealthyNodes, minHealthy)
+	} else if !allowed {
 		log.Info(msg)
 		commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
 		skipRemediation = true
 	}
 
-	// remediate unhealthy nodes
+	// update unhealthy nodes in status, which records since when they are eligible for remediation
+	for i := range matchingNodes {
+		resources.UpdateStatusNodeUnhealthy(&matchingNodes[i], nhc, currentTime())
+	}
+
+	// remediate nodes with higher priority first, so that they get free remediation slots first, and nodes with the
+	// same priority in the order in which they became eligible, so that waiting nodes aren't starved by newer ones
+	utils.SortNodesByEligibility(matchingNodes, nhc.Spec.PriorityLabel, func(nodeName string) time.Time {
+		return resources.GetStatusEligibleSince(nodeName, nhc, currentTime())
+	})
+
+	// remediate only one node per failure domain at a time, the one which is unhealthy for the longest time first
+	topologyDeferredNodes := utils.GetTopologyDeferredNodes(matchingNodes, nhc.Spec.SerializationTopologyKey, 1, func(nodeName string) bool {
+		return (resources.HasStatusRemediations(nodeName, nhc) && !resources.IsStatusRemediationExhausted(nodeName, nhc)) ||
+			resources.GetStatusDrain(nodeName, nhc) != nil
+	}, func(node *v1.Node) time.Time {
+		return getUnhealthySince(node, evaluations[node.GetName()])
+	})
+
+	// cap the number of nodes under remediation, if configured
+	maxUnhealthy, err := resources.GetMaxUnhealthy(nhc, len(selectedNodes)-len(tooYoungNodes))
+	if err != nil {
+		log.Error(err, "failed to calculate max unhealthy nodes", "maxUnhealthy", nhc.Spec.MaxUnhealthy, "observedNodes", nhc.Status.ObservedNodes)
+		return result, err
+	}
+	remediatedNodes := resources.CountStatusRemediatedNodes(nhc)
+
+	// remediate unhealthy nodes: prepare remediation CRs one node after another, create them in parallel, and process
+	// the results in node order again, in order to keep the status deterministic
+	var remediations []*pendingRemediation
 	for _, node := range matchingNodes {
 
-		// update unhealthy node in status
-		resources.UpdateStatusNodeUnhealthy(&node, nhc)
+		// stop verification of nodes which are unhealthy again
+		if verification := resources.GetStatusVerification(node.GetName(), nhc); verification != nil && verification.Phase == remediationv1alpha1.VerificationPhaseVerifying {
+			log.Info("node is unhealthy again, stopping post remediation verification", "node", node.GetName())
+			if err := r.Verifier.CleanUp(ctx, nodesClient, verification); err != nil {
+				return result, err
+			}
+			resources.UpdateStatusVerification(node.GetName(), nhc, nil)
+		}
+
 		if skipRemediation {
 			continue
 		}
@@ -361,21 +784,197 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			continue
 		}
 
+		// give up remediation of nodes which are under remediation for too long
+		if exhausted, requeueAfter, err := r.checkMaxRemediationDuration(nhc, &node, resourceManager, log); err != nil {
+			log.Error(err, "failed to check max remediation duration")
+			return result, err
+		} else if exhausted {
+			continue
+		} else {
+			updateRequeueAfter(&result, requeueAfter)
+		}
+
+		// leave nodes alone which are remediated by someone else, and check if the node's MachineSet can tolerate
+		// losing the node, for new remediations only
+		if !resources.HasStatusRemediations(node.GetName(), nhc) {
+			if external, requeueAfter := r.isExternallyRemediated(nhc, &node, log); external {
+				updateRequeueAfter(&result, requeueAfter)
+				continue
+			}
+			// don't remediate flapping nodes again right away
+			if remaining := resources.GetRemediationCooldownRemaining(node.GetName(), nhc, currentTime()); remaining != nil {
+				message := fmt.Sprintf("its previous remediation finished within the remediation cooldown of %s", nhc.Spec.RemediationCooldown.Duration)
+				msg := fmt.Sprintf("Skipped remediation of node %s because %s", node.GetName(), message)
+				log.Info(msg)
+				commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+				resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonRemediationCooldown, message, currentTime())
+				updateRequeueAfter(&result, pointer.Duration(*remaining+1*time.Second))
+				continue
+			}
+			// start new remediations only within the remediation windows, the requeue at the next window is set already
+			if !inRemediationWindow {
+				message := fmt.Sprintf("the current time is outside of the remediation windows, the next window opens at %s", nextWindowChange.UTC().Format(time.RFC3339))
+				msg := fmt.Sprintf("Skipped remediation of node %s because %s", node.GetName(), message)
+				log.Info(msg)
+				commonevents.NormalEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+				resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonOutsideRemediationWindow, message, currentTime())
+				continue
+			}
+			if allowed, message, err := r.SurgeGate.IsRemediationAllowed(ctx, machinesClient, nhc, &node); err != nil {
+				log.Error(err, "failed to check surge capacity")
+				return result, err
+			} else if !allowed {
+				msg := fmt.Sprintf("Skipped remediation of node %s because of insufficient surge capacity: %s", node.GetName(), message)
+				log.Info(msg)
+				commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+				resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonInsufficientSurgeCapacity, message, currentTime())
+				updateRequeueAfter(&result, &surgeGateRequeueAfter)
+				continue
+			}
+			if allowed, message, err := r.StorageGate.IsRemediationAllowed(ctx, nodesClient, nhc, &node); err != nil {
+				log.Error(err, "failed to check storage of node")
+				return result, err
+			} else if !allowed {
+				msg := fmt.Sprintf("Skipped remediation of node %s because of unsafe storage: %s", node.GetName(), message)
+				log.Info(msg)
+				commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+				resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonStorageNotSafe, message, currentTime())
+				updateRequeueAfter(&result, &storageGateRequeueAfter)
+				continue
+			}
+			if deferred, requeueAfter, err := r.isPDBViolated(ctx, nodesClient, nhc, &node, evaluations[node.GetName()], log); err != nil {
+				log.Error(err, "failed to check PodDisruptionBudgets")
+				return result, err
+			} else if deferred {
+				updateRequeueAfter(&result, requeueAfter)
+				continue
+			}
+			if value, deferred := topologyDeferredNodes[node.GetName()]; deferred {
+				message := fmt.Sprintf("another node with label %s=%s is remediated", nhc.Spec.SerializationTopologyKey, value)
+				msg := fmt.Sprintf("Skipped remediation of node %s because %s", node.GetName(), message)
+				log.Info(msg)
+				commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+				resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonTopologySerialization, message, currentTime())
+				updateRequeueAfter(&result, &topologyDeferralRequeueAfter)
+				continue
+			}
+			// nodes being drained were admitted by the remediation throttle already
+			if resources.GetStatusDrain(node.GetName(), nhc) == nil {
+				if limit, requeueAfter := resources.GetRemediationThrottleLimit(nhc, currentTime()); limit != "" {
+					message := fmt.Sprintf("the %s limit of the remediation throttle is reached", limit)
+					msg := fmt.Sprintf("Skipped remediation of node %s because %s", node.GetName(), message)
+					log.Info(msg)
+					commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+					resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonRemediationThrottled, message, currentTime())
+					nhc.Status.RemediationThrottle.BindingLimit = limit
+					updateRequeueAfter(&result, requeueAfter)
+					continue
+				}
+				if maxUnhealthy >= 0 && remediatedNodes >= maxUnhealthy {
+					// remediations finishing trigger a reconcile anyway
+					message := fmt.Sprintf("%d nodes are remediated already, which is the MaxUnhealthy limit of %d", remediatedNodes, maxUnhealthy)
+					msg := fmt.Sprintf("Skipped remediation of node %s because %s", node.GetName(), message)
+					log.Info(msg)
+					commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+					resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonMaxUnhealthyReached, message, currentTime())
+					continue
+				}
+				// count the admitted node, so that the next nodes see the updated number
+				remediatedNodes++
+			}
+			if nhc.Spec.DryRun {
+				r.deferDryRunRemediation(nhc, &node, log)
+				continue
+			}
+			resources.UpdateStatusDeferral(node.GetName(), nhc, "", "", currentTime())
+
+			// drain the node before its first remediation
+			if draining, requeueAfter, err := r.drainBeforeRemediation(ctx, nodesClient, nhc, &node, evaluations[node.GetName()], log); err != nil {
+				log.Error(err, "failed to drain node", "node", node.GetName())
+				return result, err
+			} else if draining {
+				updateRequeueAfter(&result, requeueAfter)
+				continue
+			}
+		} else if nhc.Spec.DryRun {
+			// don't escalate ongoing remediations either
+			r.deferDryRunRemediation(nhc, &node, log)
+			continue
+		}
+
+		// check the cluster wide limit of simultaneous remediations
+		if acquired, err := r.RemediationLimiter.TryAcquire(ctx, nhc, node.GetName()); err != nil {
+			log.Error(err, "failed to check cluster wide remediation limit")
+			return result, err
+		} else if !acquired {
+			msg := fmt.Sprintf("Skipped remediation of node %s because the cluster wide limit of %d simultaneous remediations is reached", node.GetName(), r.RemediationLimiter.Max())
+			log.Info(msg)
+			commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+			updateRequeueAfter(&result, &remediationLimitRequeueAfter)
+			continue
+		}
+
 		log.Info("handling unhealthy node", "node", node.GetName())
-		requeueAfter, err := r.remediate(ctx, &node, nhc, resourceManager)
+		isNewRemediation := !resources.HasStatusRemediations(node.GetName(), nhc)
+		pending, err := r.prepareRemediation(ctx, node.DeepCopy(), nhc, resourceManager, remediations)
 		if err != nil {
 			// don't try to remediate other nodes
 			log.Error(err, "failed to start remediation")
 			return result, err
 		}
+		if isNewRemediation && pending.cr != nil {
+			// count the start before creating the CR, so that the next nodes see the updated limits
+			resources.RecordStatusRemediationThrottleStart(node.GetName(), nhc, currentTime())
+		}
+		remediations = append(remediations, pending)
+	}
+
+	r.createRemediationCRs(ctx, nhc, resourceManager, remediations)
+
+	var errs []error
+	for _, pending := range remediations {
+		node := pending.node
+		if pending.cr != nil && !pending.attempted {
+			// creation wasn't started because creating another CR failed
+			continue
+		}
+		requeueAfter, err := r.finishRemediation(nhc, pending, resourceManager)
+		if err != nil {
+			log.Error(err, "failed to start remediation", "node", node.GetName())
+			errs = append(errs, err)
+			continue
+		}
 		updateRequeueAfter(&result, requeueAfter)
+		for _, fanOut := range pending.fanOut {
+			if !fanOut.attempted {
+				// creation stopped at a previous CR of the node
+				continue
+			}
+			requeueAfter, err := r.finishRemediation(nhc, fanOut, resourceManager)
+			if err != nil {
+				log.Error(err, "failed to start remediation", "node", node.GetName())
+				errs = append(errs, err)
+				continue
+			}
+			updateRequeueAfter(&result, requeueAfter)
+		}
+
+		// a recorded previous remediation doesn't apply anymore when the node is remediated again
+		if resources.HasStatusRemediations(node.GetName(), nhc) {
+			if err := patchRemediatedByNHCCondition(ctx, nodesClient, node, v1.ConditionFalse, remediationv1alpha1.NodeConditionReasonRemediationStarted,
+				fmt.Sprintf("Node is remediated again by NodeHealthCheck %s", nhc.GetName())); err != nil {
+				log.Error(err, "failed to clear remediation record on node", "node", node.Name)
+				errs = append(errs, err)
+				continue
+			}
+		}
 
 		// check if we need to alert about a very old remediation CR
 		remediationCRs, err := resourceManager.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
-			return cr.GetName() == node.GetName() && resources.IsOwner(&cr, nhc)
+			return resources.GetNodeName(cr) == node.GetName() && resources.IsOwner(&cr, nhc)
 		})
 		for _, remediationCR := range remediationCRs {
-			isAlert, requeueAfter := r.alertOldRemediationCR(&remediationCR)
+			isAlert, requeueAfter := r.alertOldRemediationCR(&remediationCR, resourceManager)
 			if isAlert {
 				metrics.ObserveNodeHealthCheckOldRemediationCR(node.Name, node.Namespace)
 			}
@@ -383,7 +982,15 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
-	return result, nil
+	return result, utilerrors.NewAggregate(errs)
+}
+
+// deferDryRunRemediation records that the given node would be remediated, if the NHC wasn't in dry run mode
+func (r *NodeHealthCheckReconciler) deferDryRunRemediation(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, log logr.Logger) {
+	msg := fmt.Sprintf("Skipped remediation of node %s because of dry run mode", node.GetName())
+	log.Info(msg)
+	commonevents.NormalEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+	resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonDryRun, "the NodeHealthCheck is in dry run mode", currentTime())
 }
 
 func (r *NodeHealthCheckReconciler) isClusterUpgrading() bool {
@@ -396,10 +1003,90 @@ func (r *NodeHealthCheckReconciler) isClusterUpgrading() bool {
 	return clusterUpgrading
 }
 
-func (r *NodeHealthCheckReconciler) checkNodeConditions(nodes []v1.Node, nhc *remediationv1alpha1.NodeHealthCheck) (notMatchingNodes, soonMatchingNodes, matchingNodes []v1.Node, requeueAfter *time.Duration) {
+// checkNodeConditions sorts the given nodes by whether they match the unhealthy signals of the NHC, will match them
+// soon, or don't match them. The returned evaluations contain the matched signals of each node by node name.
+func (r *NodeHealthCheckReconciler) checkNodeConditions(ctx context.Context, c client.Client, nodes []v1.Node, nhc *remediationv1alpha1.NodeHealthCheck) (notMatchingNodes, soonMatchingNodes, matchingNodes []v1.Node, evaluations map[string]*resources.NodeEvaluation, requeueAfter *time.Duration, err error) {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	evaluations = make(map[string]*resources.NodeEvaluation, len(nodes))
+	signalVotes := make(map[string][]remediationv1alpha1.UnhealthySignal)
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.GetName())
+	}
+	// heartbeats get stale without any update, so check back when the next one gets stale
+	staleHeartbeatNodes, requeueAfter, err := r.HeartbeatChecker.GetStaleNodes(ctx, c, nhc, nodeNames, currentTime())
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
 	for _, node := range nodes {
 		node := node
-		if matchesUnhealthyConditions, thisRequeueAfter := r.matchesUnhealthyConditions(nhc, &node); !matchesUnhealthyConditions {
+		evaluation := &resources.NodeEvaluation{}
+		evaluations[node.GetName()] = evaluation
+		matchedCondition, thisRequeueAfter := r.getMatchingUnhealthyCondition(nhc, &node)
+		evaluation.Condition = matchedCondition
+		matchesUnhealthyConditions := matchedCondition != nil
+		unreachableSince, unreachableRequeueAfter, err := r.ConnectivityChecker.GetUnreachableSince(ctx, c, nhc, node.GetName(), currentTime())
+		if err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
+		if since, isStale := staleHeartbeatNodes[node.GetName()]; isStale {
+			evaluation.HeartbeatStaleSince = since.DeepCopy()
+			matchesUnhealthyConditions = true
+		}
+		matchedCapacity, capacityRequeueAfter := getMatchingUnhealthyCapacity(nhc, &node, currentTime())
+		if matchedCapacity != nil {
+			evaluation.Capacity = matchedCapacity
+			matchesUnhealthyConditions = true
+		} else if !matchesUnhealthyConditions {
+			thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, capacityRequeueAfter)
+		}
+		matchedTaint, taintRequeueAfter := getMatchingUnhealthyTaint(nhc, &node, currentTime())
+		if matchedTaint != nil {
+			evaluation.Taint = matchedTaint
+			matchesUnhealthyConditions = true
+		} else if !matchesUnhealthyConditions {
+			thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, taintRequeueAfter)
+		}
+		matchedAnnotation, annotationRequeueAfter := getMatchingUnhealthyAnnotation(nhc, &node, currentTime())
+		if matchedAnnotation != nil {
+			evaluation.Annotation = matchedAnnotation
+			matchesUnhealthyConditions = true
+		} else if !matchesUnhealthyConditions {
+			thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, annotationRequeueAfter)
+		}
+		matchedUtilization, utilizationRequeueAfter := getMatchingUnhealthyUtilization(nhc, &node, currentTime())
+		if matchedUtilization != nil {
+			evaluation.Utilization = matchedUtilization
+			matchesUnhealthyConditions = true
+		} else if !matchesUnhealthyConditions {
+			thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, utilizationRequeueAfter)
+		}
+		if unreachableSince != nil {
+			evaluation.UnreachableSince = unreachableSince
+			matchesUnhealthyConditions = true
+		} else if !matchesUnhealthyConditions {
+			thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, unreachableRequeueAfter)
+		}
+		if nhc.Spec.UnhealthyQuorum > 0 {
+			signals, signalsRequeueAfter := getUnhealthySignals(nhc, &node, evaluation, currentTime())
+			if len(signals) > 0 {
+				signalVotes[node.GetName()] = signals
+			}
+			if len(signals) < nhc.Spec.UnhealthyQuorum {
+				if matchesUnhealthyConditions {
+					log.Info("Node is unhealthy, but misses the unhealthy quorum", "node", node.GetName(), "signals", signals, "quorum", nhc.Spec.UnhealthyQuorum)
+				}
+				// check back when more signals might vote
+				matchesUnhealthyConditions = false
+				thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, signalsRequeueAfter)
+				thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, capacityRequeueAfter)
+				thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, taintRequeueAfter)
+				thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, annotationRequeueAfter)
+				thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, utilizationRequeueAfter)
+				thisRequeueAfter = utils.MinRequeueDuration(thisRequeueAfter, unreachableRequeueAfter)
+			}
+		}
+		if !matchesUnhealthyConditions {
 			if thisRequeueAfter != nil && *thisRequeueAfter > 0 {
 				soonMatchingNodes = append(soonMatchingNodes, node)
 				requeueAfter = utils.MinRequeueDuration(requeueAfter, thisRequeueAfter)
@@ -413,69 +1100,805 @@ func (r *NodeHealthCheckReconciler) checkNodeConditions(nodes []v1.Node, nhc *re
 			matchingNodes = append(matchingNodes, node)
 		}
 	}
+	if nhc.Spec.UnhealthyQuorum > 0 || len(nhc.Status.UnhealthySignalVotes) > 0 {
+		resources.UpdateStatusUnhealthySignalVotes(nhc, signalVotes)
+	}
 	return
 }
 
-func (r *NodeHealthCheckReconciler) matchesUnhealthyConditions(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) (bool, *time.Duration) {
-	nodeConditionByType := make(map[v1.NodeConditionType]v1.NodeCondition)
-	for _, nc := range node.Status.Conditions {
-		nodeConditionByType[nc.Type] = nc
+// updateStatusUnhealthySignals sets the unhealthy signals, which the unhealthy nodes in the status matched, and emits
+// events for signals which unhealthy nodes started to match.
+func (r *NodeHealthCheckReconciler) updateStatusUnhealthySignals(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*resources.NodeEvaluation) {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	resources.UpdateStatusMatchedCondition(nhc, evaluations)
+	for _, nodeName := range resources.UpdateStatusMatchedCapacity(nhc, evaluations) {
+		matchedCapacity := evaluations[nodeName].Capacity
+		log.Info("Node has insufficient capacity", "node", nodeName, "resource", matchedCapacity.ResourceName, "since", matchedCapacity.Since)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedLowCapacity, "Node %q has insufficient capacity of %s since %s", nodeName, matchedCapacity.ResourceName, matchedCapacity.Since.UTC().Format(time.RFC3339))
+	}
+	for _, nodeName := range resources.UpdateStatusMatchedTaint(nhc, evaluations) {
+		matchedTaint := evaluations[nodeName].Taint
+		log.Info("Node has an unhealthy taint", "node", nodeName, "key", matchedTaint.Key, "effect", matchedTaint.Effect)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedUnhealthyTaint, "Node %q has the unhealthy taint %s:%s", nodeName, matchedTaint.Key, matchedTaint.Effect)
+	}
+	for _, nodeName := range resources.UpdateStatusMatchedAnnotation(nhc, evaluations) {
+		matchedAnnotation := evaluations[nodeName].Annotation
+		log.Info("Node has an unhealthy annotation", "node", nodeName, "key", matchedAnnotation.Key, "value", matchedAnnotation.Value)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedUnhealthyAnnot, "Node %q has the unhealthy annotation %s=%s", nodeName, matchedAnnotation.Key, matchedAnnotation.Value)
+	}
+	for _, nodeName := range resources.UpdateStatusMatchedUtilization(nhc, evaluations) {
+		matchedUtilization := evaluations[nodeName].Utilization
+		log.Info("Node has high utilization", "node", nodeName, "resource", matchedUtilization.ResourceName, "since", matchedUtilization.Since)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedHighUtilization, "Node %q has a utilization of %s above the threshold since %s", nodeName, matchedUtilization.ResourceName, matchedUtilization.Since.UTC().Format(time.RFC3339))
+	}
+	for _, nodeName := range resources.UpdateStatusUnreachableSince(nhc, evaluations) {
+		since := evaluations[nodeName].UnreachableSince
+		log.Info("Node is reported as unreachable", "node", nodeName, "since", since)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedUnreachable, "Node %q is reported as unreachable since %s", nodeName, since.UTC().Format(time.RFC3339))
+	}
+	for _, nodeName := range resources.UpdateStatusHeartbeatStaleSince(nhc, evaluations) {
+		since := evaluations[nodeName].HeartbeatStaleSince
+		log.Info("Node has a stale heartbeat", "node", nodeName, "since", since)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedStaleHeartbeat, "Node %q has a stale heartbeat since %s", nodeName, since.UTC().Format(time.RFC3339))
 	}
+}
 
-	var expiresAfter *time.Duration
+// getUnhealthySignals returns the configured signal categories which vote the node as unhealthy, and when an
+// unhealthy condition is going to match. The other signals are taken from the given evaluation of the node.
+func getUnhealthySignals(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, evaluation *resources.NodeEvaluation, now time.Time) ([]remediationv1alpha1.UnhealthySignal, *time.Duration) {
+	votes := map[remediationv1alpha1.UnhealthySignal]bool{
+		remediationv1alpha1.UnhealthySignalCapacity:     evaluation.Capacity != nil,
+		remediationv1alpha1.UnhealthySignalTaints:       evaluation.Taint != nil,
+		remediationv1alpha1.UnhealthySignalAnnotations:  evaluation.Annotation != nil,
+		remediationv1alpha1.UnhealthySignalUtilization:  evaluation.Utilization != nil,
+		remediationv1alpha1.UnhealthySignalConnectivity: evaluation.UnreachableSince != nil,
+		remediationv1alpha1.UnhealthySignalHeartbeat:    evaluation.HeartbeatStaleSince != nil,
+	}
+	var requeueAfter *time.Duration
 	for _, c := range nhc.Spec.UnhealthyConditions {
-		n, exists := nodeConditionByType[c.Type]
-		if !exists {
+		if !utils.IsRemediationEnabled(c) || utils.IsOverriddenByTaintedCondition(c, nhc.Spec.UnhealthyConditions, node) {
 			continue
 		}
-		if n.Status == c.Status {
-			now := currentTime()
-			if now.After(n.LastTransitionTime.Add(c.Duration.Duration)) {
-				// unhealthy condition duration expired, node is unhealthy
-				r.Log.Info("Node matches unhealthy condition", "node", node.GetName(), "condition type", c.Type, "condition status", c.Status)
-				commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedUnhealthy, "Node matches unhealthy condition. Node %q, condition type %q, condition status %q", node.GetName(), c.Type, c.Status)
-				return true, nil
-			} else {
-				// unhealthy condition duration not expired yet, node is healthy. Requeue when duration expires
-				thisExpiresAfter := n.LastTransitionTime.Add(c.Duration.Duration).Sub(now)
-				r.Log.Info("Node is going to match unhealthy condition", "node", node.GetName(), "condition type", c.Type, "condition status", c.Status, "duration left", thisExpiresAfter)
-				expiresAfter = utils.MinRequeueDuration(expiresAfter, pointer.Duration(thisExpiresAfter+1*time.Second))
-			}
+		matches, expiresAfter := utils.MatchesUnhealthyCondition(c, node, now)
+		if matches && c.TaintKey == "" {
+			votes[remediationv1alpha1.UnhealthySignalConditions] = true
+		} else if matches {
+			votes[remediationv1alpha1.UnhealthySignalTaints] = true
+		} else if expiresAfter != nil {
+			requeueAfter = utils.MinRequeueDuration(requeueAfter, pointer.Duration(*expiresAfter+1*time.Second))
 		}
 	}
-	return false, expiresAfter
-}
-
-func (r *NodeHealthCheckReconciler) deleteOrphanedRemediationCRs(nhc *remediationv1alpha1.NodeHealthCheck, allNodes []v1.Node, rm resources.Manager, log logr.Logger) error {
-	orphanedRemediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
-		// skip already deleted CRs
-		if cr.GetDeletionTimestamp() != nil {
-			return false
+	var signals []remediationv1alpha1.UnhealthySignal
+	for _, signal := range nhc.Spec.GetUnhealthySignals() {
+		if votes[signal] {
+			signals = append(signals, signal)
 		}
+	}
+	return signals, requeueAfter
+}
 
-		// skip CRs we don't own
-		if !resources.IsOwner(&cr, nhc) {
-			return false
+// nodeCapacityUpdateNeedsReconcile returns true when the node's capacity of a resource changed, which is used by an
+// unhealthy capacity of any NHC.
+func (r *NodeHealthCheckReconciler) nodeCapacityUpdateNeedsReconcile(ev event.UpdateEvent) bool {
+	oldNode, ok := ev.ObjectOld.(*v1.Node)
+	if !ok {
+		return false
+	}
+	newNode, ok := ev.ObjectNew.(*v1.Node)
+	if !ok {
+		return false
+	}
+	// capacity rarely changes, so avoid listing NHCs for most node updates
+	if equality.Semantic.DeepEqual(oldNode.Status.Capacity, newNode.Status.Capacity) {
+		return false
+	}
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := r.List(context.Background(), nhcList); err != nil {
+		r.Log.Error(err, "failed to list NHCs for checking capacity update", "node", newNode.GetName())
+		// better reconcile too often than missing insufficient capacity
+		return true
+	}
+	for _, nhc := range nhcList.Items {
+		for _, c := range nhc.Spec.UnhealthyCapacity {
+			if capacityNeedsReconcile(oldNode.Status.Capacity, newNode.Status.Capacity, c.ResourceName) {
+				return true
+			}
 		}
+	}
+	return false
+}
 
-		// check conditions
-		permanentNodeDeletionExpectedCondition := getCondition(&cr, commonconditions.PermanentNodeDeletionExpectedType, log)
-		permanentNodeDeletionExpected := permanentNodeDeletionExpectedCondition != nil && permanentNodeDeletionExpectedCondition.Status == metav1.ConditionTrue
-		succeededCondition := getCondition(&cr, commonconditions.SucceededType, log)
-		succeeded := succeededCondition != nil && succeededCondition.Status == metav1.ConditionTrue
-		if !permanentNodeDeletionExpected || !succeeded {
-			// no node name change expected, or not succeeded yet
-			return false
+// nodeAnnotationUpdateNeedsReconcile returns true when the node's annotation changed, which is used by an unhealthy
+// annotation of any NHC.
+func (r *NodeHealthCheckReconciler) nodeAnnotationUpdateNeedsReconcile(ev event.UpdateEvent) bool {
+	oldNode, ok := ev.ObjectOld.(*v1.Node)
+	if !ok {
+		return false
+	}
+	newNode, ok := ev.ObjectNew.(*v1.Node)
+	if !ok {
+		return false
+	}
+	// avoid listing NHCs for node updates without annotation changes
+	if equality.Semantic.DeepEqual(oldNode.GetAnnotations(), newNode.GetAnnotations()) {
+		return false
+	}
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := r.List(context.Background(), nhcList); err != nil {
+		r.Log.Error(err, "failed to list NHCs for checking annotation update", "node", newNode.GetName())
+		// better reconcile too often than missing unhealthy annotations
+		return true
+	}
+	for _, nhc := range nhcList.Items {
+		for _, a := range nhc.Spec.UnhealthyAnnotations {
+			if annotationNeedsReconcile(oldNode.GetAnnotations(), newNode.GetAnnotations(), a.Key) {
+				return true
+			}
 		}
+	}
+	return false
+}
 
-		// check if node exists
-		for _, node := range allNodes {
-			if node.GetName() == cr.GetName() {
-				// node still exists
-				return false
+// updateInsufficientCapacityNodes tracks since when the given nodes have a capacity below the minimum quantity of the
+// unhealthy capacities, because node capacity has no transition time.
+func updateInsufficientCapacityNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+	var insufficient []remediationv1alpha1.InsufficientCapacityNode
+	for i := range nodes {
+		for _, c := range nhc.Spec.UnhealthyCapacity {
+			if hasSufficientCapacity(c, &nodes[i]) {
+				continue
 			}
+			insufficient = append(insufficient, remediationv1alpha1.InsufficientCapacityNode{
+				Name:         nodes[i].GetName(),
+				ResourceName: c.ResourceName,
+				Since:        metav1.Time{Time: now},
+			})
 		}
-
+	}
+	resources.UpdateStatusInsufficientCapacityNodes(nhc, insufficient)
+}
+
+// hasSufficientCapacity returns true if the node's capacity of the resource isn't below the minimum quantity.
+// A node without the resource in its capacity has insufficient capacity.
+func hasSufficientCapacity(c remediationv1alpha1.UnhealthyCapacity, node *v1.Node) bool {
+	quantity, exists := node.Status.Capacity[c.ResourceName]
+	return exists && quantity.Cmp(c.MinQuantity) >= 0
+}
+
+// getMatchingUnhealthyCapacity returns the first unhealthy capacity which the node matches for at least its duration,
+// based on the tracked insufficient capacity. If there is none, it returns when the next one is going to match.
+func getMatchingUnhealthyCapacity(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, now time.Time) (*remediationv1alpha1.MatchedCapacity, *time.Duration) {
+	var expiresAfter *time.Duration
+	for _, c := range nhc.Spec.UnhealthyCapacity {
+		since := resources.GetStatusInsufficientCapacitySince(node.GetName(), c.ResourceName, nhc)
+		if since == nil {
+			continue
+		}
+		unhealthyAt := since.Add(c.Duration.Duration)
+		if !now.Before(unhealthyAt) {
+			return &remediationv1alpha1.MatchedCapacity{
+				ResourceName: c.ResourceName,
+				Since:        *since,
+			}, nil
+		}
+		expiresAfter = utils.MinRequeueDuration(expiresAfter, pointer.Duration(unhealthyAt.Sub(now)+1*time.Second))
+	}
+	return nil, expiresAfter
+}
+
+// updateTaintedNodes tracks since when the given nodes have the taints of the unhealthy taints, for taints without
+// timestamp, because they have no transition time otherwise.
+func updateTaintedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+	var tainted []remediationv1alpha1.TaintedNode
+	for i := range nodes {
+		for _, t := range nhc.Spec.UnhealthyTaints {
+			taint := getUnhealthyTaint(t, &nodes[i])
+			if taint == nil || taint.TimeAdded != nil {
+				continue
+			}
+			tainted = append(tainted, remediationv1alpha1.TaintedNode{
+				Name:   nodes[i].GetName(),
+				Key:    taint.Key,
+				Effect: taint.Effect,
+				Since:  metav1.Time{Time: now},
+			})
+		}
+	}
+	resources.UpdateStatusTaintedNodes(nhc, tainted)
+}
+
+// getUnhealthyTaint returns the node's taint matching the given unhealthy taint, or nil if the node doesn't have it
+func getUnhealthyTaint(t remediationv1alpha1.UnhealthyTaint, node *v1.Node) *v1.Taint {
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Key == t.Key && taint.Effect == t.Effect && (t.Value == "" || taint.Value == t.Value) {
+			return taint
+		}
+	}
+	return nil
+}
+
+// getMatchingUnhealthyTaint returns the first unhealthy taint which the node has for at least its duration, based on
+// the taint's timestamp or the tracked tainted nodes. If there is none, it returns when the next one is going to match.
+func getMatchingUnhealthyTaint(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, now time.Time) (*remediationv1alpha1.MatchedTaint, *time.Duration) {
+	var expiresAfter *time.Duration
+	for _, t := range nhc.Spec.UnhealthyTaints {
+		taint := getUnhealthyTaint(t, node)
+		if taint == nil {
+			continue
+		}
+		since := taint.TimeAdded
+		if since == nil {
+			if since = resources.GetStatusTaintedSince(node.GetName(), taint.Key, taint.Effect, nhc); since == nil {
+				continue
+			}
+		}
+		unhealthyAt := since.Add(t.Duration.Duration)
+		if !now.Before(unhealthyAt) {
+			return &remediationv1alpha1.MatchedTaint{
+				Key:    taint.Key,
+				Effect: taint.Effect,
+				Since:  *since,
+			}, nil
+		}
+		expiresAfter = utils.MinRequeueDuration(expiresAfter, pointer.Duration(unhealthyAt.Sub(now)+1*time.Second))
+	}
+	return nil, expiresAfter
+}
+
+// updateAnnotatedNodes tracks since when the given nodes have the annotations of the unhealthy annotations, because
+// annotations have no timestamp.
+func updateAnnotatedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+	var annotated []remediationv1alpha1.AnnotatedNode
+	for i := range nodes {
+		for _, a := range nhc.Spec.UnhealthyAnnotations {
+			if _, matches := getUnhealthyAnnotation(a, &nodes[i]); !matches {
+				continue
+			}
+			annotated = append(annotated, remediationv1alpha1.AnnotatedNode{
+				Name:  nodes[i].GetName(),
+				Key:   a.Key,
+				Since: metav1.Time{Time: now},
+			})
+		}
+	}
+	resources.UpdateStatusAnnotatedNodes(nhc, annotated)
+}
+
+// getUnhealthyAnnotation returns the value of the node's annotation matching the given unhealthy annotation, and
+// whether the node has it
+func getUnhealthyAnnotation(a remediationv1alpha1.AnnotationMatch, node *v1.Node) (string, bool) {
+	value, exists := node.GetAnnotations()[a.Key]
+	if !exists || (a.Value != "" && value != a.Value) {
+		return "", false
+	}
+	return value, true
+}
+
+// getMatchingUnhealthyAnnotation returns the first unhealthy annotation which the node has for at least its duration,
+// based on the tracked annotated nodes. If there is none, it returns when the next one is going to match.
+func getMatchingUnhealthyAnnotation(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, now time.Time) (*remediationv1alpha1.MatchedAnnotation, *time.Duration) {
+	var expiresAfter *time.Duration
+	for _, a := range nhc.Spec.UnhealthyAnnotations {
+		value, matches := getUnhealthyAnnotation(a, node)
+		if !matches {
+			continue
+		}
+		since := resources.GetStatusAnnotatedSince(node.GetName(), a.Key, nhc)
+		if since == nil {
+			continue
+		}
+		unhealthyAt := since.Add(a.Duration.Duration)
+		if !now.Before(unhealthyAt) {
+			return &remediationv1alpha1.MatchedAnnotation{
+				Key:   a.Key,
+				Value: value,
+				Since: *since,
+			}, nil
+		}
+		expiresAfter = utils.MinRequeueDuration(expiresAfter, pointer.Duration(unhealthyAt.Sub(now)+1*time.Second))
+	}
+	return nil, expiresAfter
+}
+
+// updateHighUtilizationNodes tracks since when the given nodes have a utilization above the threshold of the unhealthy
+// utilizations, because node metrics have no transition time. Nodes without metrics don't have high utilization, so
+// the tracking restarts when metrics are unavailable.
+func (r *NodeHealthCheckReconciler) updateHighUtilizationNodes(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) error {
+	nodeUtilization, err := r.UtilizationChecker.GetUtilization(ctx, c, nhc, nodes)
+	if err != nil {
+		return err
+	}
+	var high []remediationv1alpha1.HighUtilizationNode
+	for i := range nodes {
+		for _, u := range nhc.Spec.UnhealthyUtilization {
+			percent, exists := nodeUtilization[nodes[i].GetName()][u.ResourceName]
+			if !exists || percent <= int64(u.ThresholdPercent) {
+				continue
+			}
+			high = append(high, remediationv1alpha1.HighUtilizationNode{
+				Name:         nodes[i].GetName(),
+				ResourceName: u.ResourceName,
+				Since:        metav1.Time{Time: now},
+			})
+		}
+	}
+	resources.UpdateStatusHighUtilizationNodes(nhc, high)
+	return nil
+}
+
+// updateUnavailableRemediators tracks since when the remediators of the RemediatorHealthCheck aren't running, and
+// emits events when they stop or start running
+func (r *NodeHealthCheckReconciler) updateUnavailableRemediators(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) error {
+	unavailable, err := resources.GetUnavailableRemediators(ctx, c, nhc, r.Log)
+	if err != nil {
+		return err
+	}
+	stopped, started := resources.UpdateStatusUnavailableRemediators(nhc, unavailable, now)
+	for _, kind := range stopped {
+		r.Log.Info("remediator isn't running", "NHC", nhc.GetName(), "kind", kind, "message", unavailable[kind])
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonRemediatorUnavailable, "The remediator of %s isn't running: %s", kind, unavailable[kind])
+	}
+	for _, kind := range started {
+		r.Log.Info("remediator is running again", "NHC", nhc.GetName(), "kind", kind)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonRemediatorAvailable, "The remediator of %s is running again", kind)
+	}
+	return nil
+}
+
+// syncAlertSilences silences the alerts of nodes under remediation in Alertmanager, extends the silences while
+// remediation is in flight, and expires them when the node is healthy again or its remediation ended. Failures never
+// affect remediation, they are only reported with the AlertSilencingFailed condition and metric. Returns when the next
+// silence needs to be extended, or when failed requests are retried.
+func (r *NodeHealthCheckReconciler) syncAlertSilences(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) *time.Duration {
+	if nhc.Spec.AlertSilencing == nil {
+		// Alertmanager isn't known anymore, the silences end on their own
+		nhc.Status.AlertSilences = nil
+		meta.RemoveStatusCondition(&nhc.Status.Conditions, remediationv1alpha1.ConditionTypeAlertSilencingFailed)
+		return nil
+	}
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	duration := utils.GetAlertSilenceDuration(nhc)
+	var failures []string
+	var requeueAfter *time.Duration
+
+	underRemediation := sets.New[string](resources.GetStatusNodesUnderRemediation(nhc)...)
+	for _, nodeName := range sets.List(underRemediation) {
+		silence := resources.GetStatusAlertSilence(nodeName, nhc)
+		// extend silences when half of their duration is over
+		if silence == nil || silence.EndsAt.Sub(now) < duration/2 {
+			id := ""
+			if silence != nil {
+				id = silence.ID
+			}
+			endsAt := now.Add(duration)
+			newID, err := r.AlertSilencer.Silence(ctx, nhc, nodeName, id, endsAt)
+			if err != nil {
+				log.Error(err, "failed to silence alerts of node", "node", nodeName)
+				metrics.ObserveNodeHealthCheckAlertSilencingFailure(nhc.GetName(), "silence")
+				failures = append(failures, fmt.Sprintf("silencing node %s: %s", nodeName, err.Error()))
+				continue
+			}
+			silence = &remediationv1alpha1.AlertSilence{
+				NodeName: nodeName,
+				ID:       newID,
+				EndsAt:   metav1.Time{Time: endsAt},
+			}
+			resources.UpdateStatusAlertSilence(nhc, silence)
+		}
+		requeueAfter = utils.MinRequeueDuration(requeueAfter, pointer.Duration(silence.EndsAt.Add(-duration/2).Sub(now)+time.Second))
+	}
+
+	for _, silence := range append([]*remediationv1alpha1.AlertSilence{}, nhc.Status.AlertSilences...) {
+		if underRemediation.Has(silence.NodeName) {
+			continue
+		}
+		// silences which ended already don't need to be expired
+		if now.Before(silence.EndsAt.Time) {
+			if err := r.AlertSilencer.Expire(ctx, nhc, silence.ID); err != nil {
+				log.Error(err, "failed to expire silence of node", "node", silence.NodeName, "silenceID", silence.ID)
+				metrics.ObserveNodeHealthCheckAlertSilencingFailure(nhc.GetName(), "expire")
+				failures = append(failures, fmt.Sprintf("expiring silence of node %s: %s", silence.NodeName, err.Error()))
+				continue
+			}
+		}
+		resources.RemoveStatusAlertSilence(silence.NodeName, nhc)
+	}
+
+	if len(failures) > 0 {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeAlertSilencingFailed,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonAlertmanagerRequestFailed,
+			Message: fmt.Sprintf("Failed to sync Alertmanager silences: %s", strings.Join(failures, "; ")),
+		})
+		requeueAfter = utils.MinRequeueDuration(requeueAfter, &alertSilencingRetryRequeueAfter)
+	} else if meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeAlertSilencingFailed) != nil {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeAlertSilencingFailed,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonAlertSilencesSynced,
+			Message: "Alertmanager silences of all nodes under remediation are in sync",
+		})
+	}
+	return requeueAfter
+}
+
+// getMatchingUnhealthyUtilization returns the first unhealthy utilization which the node matches for at least its
+// duration, based on the tracked high utilization. If there is none, it returns when the next one is going to match.
+func getMatchingUnhealthyUtilization(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, now time.Time) (*remediationv1alpha1.MatchedUtilization, *time.Duration) {
+	var expiresAfter *time.Duration
+	for _, u := range nhc.Spec.UnhealthyUtilization {
+		since := resources.GetStatusHighUtilizationSince(node.GetName(), u.ResourceName, nhc)
+		if since == nil {
+			continue
+		}
+		unhealthyAt := since.Add(u.Duration.Duration)
+		if !now.Before(unhealthyAt) {
+			return &remediationv1alpha1.MatchedUtilization{
+				ResourceName: u.ResourceName,
+				Since:        *since,
+			}, nil
+		}
+		expiresAfter = utils.MinRequeueDuration(expiresAfter, pointer.Duration(unhealthyAt.Sub(now)+1*time.Second))
+	}
+	return nil, expiresAfter
+}
+
+func (r *NodeHealthCheckReconciler) matchesUnhealthyConditions(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) (bool, *time.Duration) {
+	matchedCondition, expiresAfter := r.getMatchingUnhealthyCondition(nhc, node)
+	return matchedCondition != nil, expiresAfter
+}
+
+// getMatchingUnhealthyCondition returns the first unhealthy condition with enabled remediation which the node matches.
+// If there is none, it returns when the next condition is going to match.
+func (r *NodeHealthCheckReconciler) getMatchingUnhealthyCondition(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) (*remediationv1alpha1.MatchedCondition, *time.Duration) {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	c, expiresAfter := resources.GetMatchingUnhealthyCondition(nhc, node, currentTime())
+	if c != nil {
+		// unhealthy condition duration expired, node is unhealthy
+		log.Info("Node matches unhealthy condition", "node", node.GetName(), "condition type", c.Type, "condition status", c.Status, "taint key", c.TaintKey)
+		if c.TaintKey == "" {
+			commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedUnhealthy, "Node matches unhealthy condition. Node %q, condition type %q, condition status %q", node.GetName(), c.Type, c.Status)
+		} else {
+			commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedUnhealthy, "Node matches unhealthy condition. Node %q, condition type %q, condition status %q, taint key %q", node.GetName(), c.Type, c.Status, c.TaintKey)
+		}
+		return c, nil
+	}
+	if expiresAfter == nil {
+		return nil, nil
+	}
+	// unhealthy condition duration not expired yet, node is healthy. Requeue when duration expires
+	log.Info("Node is going to match unhealthy condition", "node", node.GetName(), "duration left", *expiresAfter)
+	// the duration is measured with the clock of this operator, a node clock which is ahead delays the match
+	for _, condition := range node.Status.Conditions {
+		if condition.LastTransitionTime.After(currentTime()) {
+			log.Info("Transition time of node condition is in the future, check the clocks for skew", "node", node.GetName(), "condition type", condition.Type, "transition time", condition.LastTransitionTime)
+		}
+	}
+	return nil, pointer.Duration(*expiresAfter + 1*time.Second)
+}
+
+// getEffectiveDurations returns the duration in force for the unhealthy condition with enabled remediation, which
+// each of the given nodes is going to match first
+func getEffectiveDurations(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) map[string]time.Duration {
+	effectiveDurations := make(map[string]time.Duration)
+	for i := range nodes {
+		var firstExpiresAfter *time.Duration
+		for _, c := range nhc.Spec.UnhealthyConditions {
+			if !utils.IsRemediationEnabled(c) || utils.IsOverriddenByTaintedCondition(c, nhc.Spec.UnhealthyConditions, &nodes[i]) {
+				continue
+			}
+			c = resources.GetStabilizedUnhealthyCondition(nodes[i].GetName(), nhc, c)
+			matches, expiresAfter := utils.MatchesUnhealthyCondition(c, &nodes[i], now)
+			if matches || expiresAfter == nil || (firstExpiresAfter != nil && *expiresAfter >= *firstExpiresAfter) {
+				continue
+			}
+			firstExpiresAfter = expiresAfter
+			effectiveDurations[nodes[i].GetName()] = c.Duration.Duration
+		}
+	}
+	return effectiveDurations
+}
+
+// updateReportOnlyUnhealthyNodes updates the status with the given nodes, which match unhealthy conditions with
+// disabled remediation. Returns when the next report only condition is going to match.
+func (r *NodeHealthCheckReconciler) updateReportOnlyUnhealthyNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, log logr.Logger) *time.Duration {
+	now := currentTime()
+	var expiresAfter *time.Duration
+	matches := make(map[string][]remediationv1alpha1.MatchedCondition)
+	for i := range nodes {
+		matched, thisExpiresAfter := resources.GetMatchingReportOnlyConditions(nhc, &nodes[i], now)
+		if len(matched) > 0 {
+			matches[nodes[i].GetName()] = matched
+		}
+		if thisExpiresAfter != nil {
+			expiresAfter = utils.MinRequeueDuration(expiresAfter, pointer.Duration(*thisExpiresAfter+1*time.Second))
+		}
+	}
+
+	for _, nodeName := range resources.UpdateStatusReportOnlyUnhealthyNodes(nhc, matches, now) {
+		log.Info("Node matches report only unhealthy conditions, not remediating it", "node", nodeName, "conditions", matches[nodeName])
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedReportOnly, "Node %q matches unhealthy conditions with disabled remediation only", nodeName)
+	}
+	return expiresAfter
+}
+
+// filterPreexistingUnhealthyNodes returns the given unhealthy nodes which need remediation, and the nodes which are
+// ignored, because they were unhealthy already when the NHC was created and the NHC ignores preexisting conditions.
+// Ignored nodes are tracked in the status. Nodes with remediations, or with the remediate-preexisting-condition
+// annotation, are never ignored. The given evaluations contain since when nodes are unhealthy because of other signals
+// than node conditions, e.g. their connectivity report.
+func (r *NodeHealthCheckReconciler) filterPreexistingUnhealthyNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, evaluations map[string]*resources.NodeEvaluation, log logr.Logger) (remediate, ignored []v1.Node) {
+	var ignoredStatus []remediationv1alpha1.IgnoredUnhealthyNode
+	for _, node := range nodes {
+		node := node
+		if !nhc.Spec.IgnorePreexistingConditions || resources.HasStatusRemediations(node.GetName(), nhc) ||
+			annotations.HasRemediatePreexistingConditionAnnotation(&node) {
+			remediate = append(remediate, node)
+			continue
+		}
+		since := getPreexistingUnhealthySince(nhc, &node, evaluations[node.GetName()], currentTime())
+		if since == nil {
+			remediate = append(remediate, node)
+			continue
+		}
+		ignored = append(ignored, node)
+		ignoredStatus = append(ignoredStatus, remediationv1alpha1.IgnoredUnhealthyNode{
+			Name:   node.GetName(),
+			Reason: remediationv1alpha1.IgnoredReasonPreexistingCondition,
+			Since:  *since,
+		})
+	}
+
+	for _, nodeName := range resources.UpdateStatusIgnoredUnhealthyNodes(nhc, remediationv1alpha1.IgnoredReasonPreexistingCondition, ignoredStatus) {
+		log.Info("Node was unhealthy already when the NHC was created, not remediating it", "node", nodeName)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedPreexisting, "Node %q was unhealthy already when the NHC was created, not remediating it", nodeName)
+	}
+	return remediate, ignored
+}
+
+// filterTooYoungNodes returns the given unhealthy nodes which are old enough for remediation, the nodes which are
+// younger than the NHC's MinNodeAge, and when the next of them gets eligible. Young nodes are tracked in the status.
+// Nodes with ongoing remediation are never filtered.
+func (r *NodeHealthCheckReconciler) filterTooYoungNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, log logr.Logger) (remediate, tooYoung []v1.Node, requeueAfter *time.Duration) {
+	var tooYoungStatus []remediationv1alpha1.IgnoredUnhealthyNode
+	now := currentTime()
+	for _, node := range nodes {
+		node := node
+		if nhc.Spec.MinNodeAge == nil || resources.HasStatusRemediations(node.GetName(), nhc) {
+			remediate = append(remediate, node)
+			continue
+		}
+		eligibleAt := node.GetCreationTimestamp().Add(nhc.Spec.MinNodeAge.Duration)
+		if !now.Before(eligibleAt) {
+			remediate = append(remediate, node)
+			continue
+		}
+		tooYoung = append(tooYoung, node)
+		tooYoungStatus = append(tooYoungStatus, remediationv1alpha1.IgnoredUnhealthyNode{
+			Name:       node.GetName(),
+			Reason:     remediationv1alpha1.IgnoredReasonNodeTooYoung,
+			Since:      node.GetCreationTimestamp(),
+			EligibleAt: &metav1.Time{Time: eligibleAt},
+		})
+		// add a second to be sure the node is eligible on requeue
+		eligibleIn := eligibleAt.Sub(now) + time.Second
+		if requeueAfter == nil || eligibleIn < *requeueAfter {
+			requeueAfter = &eligibleIn
+		}
+	}
+
+	for _, nodeName := range resources.UpdateStatusIgnoredUnhealthyNodes(nhc, remediationv1alpha1.IgnoredReasonNodeTooYoung, tooYoungStatus) {
+		log.Info("Node is younger than the min node age, not remediating it yet", "node", nodeName)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDetectedTooYoung, "Node %q is younger than the min node age, not remediating it yet", nodeName)
+	}
+	return remediate, tooYoung, requeueAfter
+}
+
+// getPreexistingUnhealthySince returns since when the given node matches unhealthy conditions with enabled remediation
+// or is unhealthy because of other signals, if all of them started before the NHC was created. Returns nil if any of
+// them started afterwards. Conditions without transition time are considered as preexisting.
+func getPreexistingUnhealthySince(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, evaluation *resources.NodeEvaluation, now time.Time) *metav1.Time {
+	created := nhc.GetCreationTimestamp()
+	since := created
+	isPreexisting := func(onset metav1.Time) bool {
+		if onset.IsZero() {
+			return true
+		}
+		if onset.After(created.Time) {
+			return false
+		}
+		if onset.Before(&since) {
+			since = onset
+		}
+		return true
+	}
+
+	for _, onset := range evaluation.GetUnhealthySince() {
+		if !isPreexisting(onset) {
+			return nil
+		}
+	}
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		if !utils.IsRemediationEnabled(c) {
+			continue
+		}
+		for _, nc := range node.Status.Conditions {
+			if nc.Type != c.Type || nc.Status != c.Status {
+				continue
+			}
+			if c.Duration.Duration > 0 && !now.After(nc.LastTransitionTime.Add(c.Duration.Duration)) {
+				// doesn't match yet
+				continue
+			}
+			if !isPreexisting(nc.LastTransitionTime) {
+				return nil
+			}
+		}
+	}
+	return &since
+}
+
+// verifyRemediation runs the post remediation verification of a remediated node, which is healthy again.
+// Returns true when the node is verified, or when there is nothing to verify. Returns reEscalate = true when
+// verification failed, and remediation needs to continue with the next escalating remediation.
+func (r *NodeHealthCheckReconciler) verifyRemediation(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager, log logr.Logger) (verified bool, reEscalate bool, requeueIn *time.Duration, err error) {
+	if !resources.HasStatusRemediations(node.GetName(), nhc) {
+		// node wasn't remediated, nothing to verify
+		return true, false, nil, nil
+	}
+	previous := resources.GetStatusVerification(node.GetName(), nhc)
+	verification, err := r.Verifier.Verify(ctx, c, nhc, node.GetName(), currentTime())
+	if err != nil {
+		return false, false, nil, err
+	} else if verification == nil {
+		return true, false, nil, nil
+	}
+	if previous == nil {
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonVerificationStarted, "Started verification of remediated node %s", node.GetName())
+	}
+	resources.UpdateStatusVerification(node.GetName(), nhc, verification)
+
+	switch verification.Phase {
+	case remediationv1alpha1.VerificationPhaseVerifying:
+		// come back when verification times out
+		requeueIn = pointer.Duration(verification.Started.Add(utils.GetVerificationTimeout(nhc)).Sub(currentTime()) + 1*time.Second)
+		if config := nhc.Spec.PostRemediationVerification; config.MinReadyTime != nil || len(config.RequiredPods) > 0 {
+			// pods aren't watched, check them regularly
+			requeueIn = utils.MinRequeueDuration(requeueIn, &verificationPollInterval)
+		}
+		return false, false, requeueIn, nil
+	case remediationv1alpha1.VerificationPhaseSucceeded:
+		log.Info("post remediation verification succeeded", "node", node.GetName())
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonVerificationSucceeded, "Verification of remediated node %s succeeded", node.GetName())
+		return true, false, nil, r.Verifier.CleanUp(ctx, c, verification)
+	}
+
+	if err = r.Verifier.CleanUp(ctx, c, verification); err != nil {
+		return false, false, nil, err
+	}
+	log.Info("post remediation verification failed", "node", node.GetName(), "message", verification.Message)
+	commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonVerificationFailed, "Verification of remediated node %s failed: %s", node.GetName(), verification.Message)
+	if !nhc.Spec.PostRemediationVerification.ReEscalateOnFailure {
+		return true, false, nil, nil
+	}
+
+	// time out the last remediation, for continuing with the next one
+	if lastRemediation := resources.FindStatusRemediation(node, nhc, resources.IsStatusRemediationOngoing); lastRemediation != nil {
+		lastRemediation.TimedOut = &metav1.Time{Time: currentTime()}
+		lastRemediation.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+	}
+	if _, _, err = rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime()); err != nil {
+		if _, ok := err.(resources.NoTemplateLeftError); ok {
+			log.Info("no escalating remediation left for re-escalation", "node", node.GetName())
+			return true, false, nil, nil
+		}
+		return false, false, nil, err
+	}
+	log.Info("re-escalating remediation because of failed verification", "node", node.GetName())
+	return false, true, nil, nil
+}
+
+// isReEscalating returns true if remediation of the given healthy node was re-escalated because of failed
+// verification, and the re-escalated remediation didn't succeed yet
+func (r *NodeHealthCheckReconciler) isReEscalating(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager, log logr.Logger) (bool, error) {
+	verification := resources.GetStatusVerification(node.GetName(), nhc)
+	if verification == nil || verification.Phase != remediationv1alpha1.VerificationPhaseFailed {
+		return false, nil
+	}
+
+	running := resources.FindStatusRemediation(node, nhc, resources.IsStatusRemediationOngoing)
+	if running == nil {
+		// the re-escalated remediation wasn't started yet, or it timed out already
+		if _, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime()); err != nil {
+			if _, ok := err.(resources.NoTemplateLeftError); ok {
+				log.Info("no escalating remediation left for re-escalation, stopping it", "node", node.GetName())
+				resources.UpdateStatusVerification(node.GetName(), nhc, nil)
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return cr.GetKind() == running.Resource.Kind && cr.GetName() == running.Resource.Name && resources.IsOwner(&cr, nhc)
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, cr := range remediationCRs {
+		if succeeded := getCondition(&cr, commonconditions.SucceededType, log); succeeded != nil && succeeded.Status == metav1.ConditionTrue {
+			log.Info("re-escalated remediation succeeded, verifying it again", "node", node.GetName())
+			resources.UpdateStatusVerification(node.GetName(), nhc, nil)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *NodeHealthCheckReconciler) migrateInFlightRemediations(nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager, log logr.Logger) error {
+	nodeNames := resources.GetUnmigratedInFlightRemediations(nhc)
+	if len(nodeNames) == 0 {
+		return nil
+	}
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return cr.GetDeletionTimestamp() == nil && resources.IsOwner(&cr, nhc)
+	})
+	if err != nil {
+		log.Error(err, "failed to list remediation CRs for status migration")
+		return err
+	}
+	for _, nodeName := range nodeNames {
+		var nodeCRs []unstructured.Unstructured
+		for _, cr := range remediationCRs {
+			if resources.GetNodeName(cr) == nodeName {
+				nodeCRs = append(nodeCRs, cr)
+			}
+		}
+		if len(nodeCRs) == 0 {
+			// nothing to migrate, the remediation will be restarted as usual if the node is still unhealthy
+			log.Info("Dropping deprecated in-flight remediation without remediation CRs", "node", nodeName)
+			delete(nhc.Status.InFlightRemediations, nodeName)
+			continue
+		}
+		log.Info("Migrating deprecated in-flight remediation to unhealthy nodes status", "node", nodeName, "remediations", len(nodeCRs))
+		resources.MigrateStatusInFlightRemediation(nodeName, nhc, nodeCRs)
+	}
+	return nil
+}
+
+func (r *NodeHealthCheckReconciler) deleteOrphanedRemediationCRs(nhc *remediationv1alpha1.NodeHealthCheck, allNodes []v1.Node, rm resources.Manager, log logr.Logger) error {
+	orphanedRemediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		// skip already deleted CRs
+		if cr.GetDeletionTimestamp() != nil {
+			return false
+		}
+
+		// skip CRs we don't own
+		if !resources.IsOwner(&cr, nhc) {
+			return false
+		}
+
+		// check conditions
+		permanentNodeDeletionExpectedCondition := getCondition(&cr, commonconditions.PermanentNodeDeletionExpectedType, log)
+		permanentNodeDeletionExpected := permanentNodeDeletionExpectedCondition != nil && permanentNodeDeletionExpectedCondition.Status == metav1.ConditionTrue
+		succeededCondition := getCondition(&cr, commonconditions.SucceededType, log)
+		succeeded := succeededCondition != nil && succeededCondition.Status == metav1.ConditionTrue
+		if !permanentNodeDeletionExpected || !succeeded {
+			// no node name change expected, or not succeeded yet
+			return false
+		}
+
+		// check if node exists
+		for _, node := range allNodes {
+			if node.GetName() == cr.GetName() {
+				// node still exists
+				return false
+			}
+		}
+
 		return true
 	})
 	if err != nil {
@@ -493,180 +1916,1719 @@ func (r *NodeHealthCheckReconciler) deleteOrphanedRemediationCRs(nhc *remediatio
 		if cr.GetAnnotations() != nil && len(cr.GetAnnotations()[commonannotations.NodeNameAnnotation]) > 0 {
 			nodeName = cr.GetAnnotations()[commonannotations.NodeNameAnnotation]
 		}
-		// do some housekeeping first. When the CRs are deleted, we never get back here...
-		if err := rm.CleanUp(nodeName); err != nil {
-			log.Error(err, "failed to clean up orphaned node", "node", cr.GetName())
-			return err
+		// do some housekeeping first. When the CRs are deleted, we never get back here...
+		if err := rm.CleanUp(nodeName); err != nil {
+			log.Error(err, "failed to clean up orphaned node", "node", cr.GetName())
+			return err
+		}
+		resources.UpdateStatusNodeHealthy(nodeName, nhc)
+		r.RemediationLimiter.Release(nhc.GetName(), nodeName)
+
+		if deleted, err := rm.DeleteRemediationCR(&cr, nhc); err != nil {
+			log.Error(err, "failed to delete remediation CR", "name", cr.GetName())
+			return err
+		} else if deleted {
+			permanentNodeDeletionExpectedCondition := getCondition(&cr, commonconditions.PermanentNodeDeletionExpectedType, log)
+			log.Info("deleted orphaned remediation CR", "name", cr.GetName(),
+				"reason", permanentNodeDeletionExpectedCondition.Reason,
+				"message", permanentNodeDeletionExpectedCondition.Message)
+		}
+
+	}
+	return nil
+}
+
+// handleDeletedNodes removes unhealthy nodes from the status, which don't exist anymore. Their remediation CRs are
+// deleted, unless the remediator expects the node's deletion and didn't succeed yet, those are deleted as orphaned
+// remediation CRs as soon as they succeeded.
+func (r *NodeHealthCheckReconciler) handleDeletedNodes(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, selectedNodes []v1.Node, rm resources.Manager, log logr.Logger) error {
+	selected := make(map[string]struct{}, len(selectedNodes))
+	for _, node := range selectedNodes {
+		selected[node.GetName()] = struct{}{}
+	}
+	var deletedNodes []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if _, exists := selected[unhealthyNode.Name]; exists {
+			continue
+		}
+		// the node might just not be selected anymore
+		if err := c.Get(ctx, client.ObjectKey{Name: unhealthyNode.Name}, &v1.Node{}); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get node %s", unhealthyNode.Name)
+		}
+		deletedNodes = append(deletedNodes, unhealthyNode.Name)
+	}
+
+	for _, nodeName := range deletedNodes {
+		remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+			return resources.GetNodeName(cr) == nodeName && resources.IsOwner(&cr, nhc)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get remediation CRs of deleted node %s", nodeName)
+		}
+		for i := range remediationCRs {
+			cr := &remediationCRs[i]
+			if isNodeDeletionExpected(cr, log) {
+				log.Info("keeping remediation CR of deleted node, the remediator expects the node's deletion", "node", nodeName, "name", cr.GetName())
+				continue
+			}
+			if _, err := rm.DeleteRemediationCR(cr, nhc); err != nil {
+				return err
+			}
+		}
+		if err := rm.CleanUp(nodeName); err != nil {
+			return errors.Wrapf(err, "failed to clean up deleted node %s", nodeName)
+		}
+		log.Info("node was deleted during remediation", "node", nodeName)
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonNodeDeleted, "Node %s was removed from the cluster during remediation", nodeName)
+		resources.UpdateStatusNodeHealthy(nodeName, nhc)
+		r.RemediationLimiter.Release(nhc.GetName(), nodeName)
+	}
+	return nil
+}
+
+// isNodeDeletionExpected returns true if the remediator of the given CR expects the node's deletion, and didn't
+// succeed yet
+func isNodeDeletionExpected(cr *unstructured.Unstructured, log logr.Logger) bool {
+	deletionExpected := getCondition(cr, commonconditions.PermanentNodeDeletionExpectedType, log)
+	succeeded := getCondition(cr, commonconditions.SucceededType, log)
+	return deletionExpected != nil && deletionExpected.Status == metav1.ConditionTrue &&
+		(succeeded == nil || succeeded.Status != metav1.ConditionTrue)
+}
+
+// pendingRemediation is the remediation of an unhealthy node, which is prepared by prepareRemediation, created by
+// createRemediationCRs, and finished by finishRemediation.
+type pendingRemediation struct {
+	node *v1.Node
+	// cr is the remediation CR to create, nil when there is nothing to create
+	cr                           *unstructured.Unstructured
+	isControlPlane               bool
+	timeout                      *time.Duration
+	currentRemediationDuration   time.Duration
+	previousRemediationsDuration time.Duration
+	// requeueAfter is the requeue duration for remediations without CR to create
+	requeueAfter *time.Duration
+
+	// fanOut are the remediations of the other RemediationTemplates of the node, whose CRs are created together with
+	// this one
+	fanOut []*pendingRemediation
+
+	// the result of creating the CR
+	attempted      bool
+	created        bool
+	leaseRequeueIn *time.Duration
+	remediationCR  *unstructured.Unstructured
+	err            error
+}
+
+// prepareRemediation checks if the remediation of the given node can continue, and generates the remediation CR of
+// the current remediation template. Other is the list of remediations which were prepared before during this reconcile.
+func (r *NodeHealthCheckReconciler) prepareRemediation(ctx context.Context, node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager, other []*pendingRemediation) (*pendingRemediation, error) {
+
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	pending := &pendingRemediation{node: node}
+
+	// prevent remediation of more than 1 control plane node at a time!
+	isControlPlaneNode := nodes.IsControlPlane(node)
+	if isControlPlaneNode {
+		if isAllowed, err := r.isControlPlaneRemediationAllowed(ctx, node, nhc, rm); err != nil {
+			return nil, errors.Wrapf(err, "failed to check if control plane remediation is allowed")
+		} else if !isAllowed || isOtherControlPlaneRemediationPending(node, other) {
+			log.Info("skipping remediation for preventing control plane / etcd quorum loss, going to retry in a minute", "node", node.GetName())
+			commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonRemediationSkipped, "Skipping remediation of %s for preventing control plane / etcd quorum loss, going to retry in a minute", node.GetName())
+			pending.requeueAfter = pointer.Duration(1 * time.Minute)
+			return pending, nil
+		}
+	}
+	// start escalation at a later step if the node was remediated shortly before
+	if start := resources.GetEscalationStartFromMemory(node.GetName(), nhc, currentTime()); start != nil {
+		log.Info("node was remediated recently, starting escalation at a later step", "node", node.GetName(), "order", start.Order, "reason", start.Reason)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonEscalationStartAdjusted, "Starting remediation of node %s with escalating remediation of order %d: %s", node.GetName(), start.Order, start.Reason)
+		resources.UpdateStatusEscalationStart(node.GetName(), nhc, start)
+	}
+
+	// abort the ongoing remediation if its escalating remediation was paused, see AbortOnPause
+	if err := r.abortPausedRemediation(nhc, node, rm, log); err != nil {
+		return nil, err
+	}
+
+	// don't escalate before the remediator acknowledged the timeout of the previous remediation
+	if awaiting := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+		return r.Phase == remediationv1alpha1.RemediationPhaseAwaitingAcknowledgment
+	}); awaiting != nil {
+		if requeueIn, err := r.checkEscalationHandshake(nhc, node.GetName(), awaiting, rm, log); err != nil {
+			return nil, err
+		} else if requeueIn != nil {
+			pending.requeueAfter = requeueIn
+			return pending, nil
+		}
+	}
+
+	// stop timed out remediators before escalating, see DeleteTimedOutRemediations
+	if err := r.deleteTimedOutRemediations(nhc, node, rm, log); err != nil {
+		return nil, err
+	}
+
+	// apply the ExternalCRDeletionPolicy to remediation CRs which were deleted by someone else
+	if requeueIn, err := r.handleExternallyDeletedRemediationCR(nhc, node, rm, log); err != nil {
+		return nil, err
+	} else if requeueIn != nil {
+		pending.requeueAfter = requeueIn
+		return pending, nil
+	}
+	if last := resources.GetStatusLastRemediation(node.GetName(), nhc); last != nil && last.DeletedExternally != nil && !resources.IsStatusRemediationOngoing(last) &&
+		(last.Phase == remediationv1alpha1.RemediationPhaseSucceeded || len(nhc.Spec.EscalatingRemediations) == 0) {
+		// completed, or failed without escalating remediation left, wait for the node to get healthy
+		log.Info("remediation CR was deleted externally, no further remediation", "node", node.GetName(), "phase", last.Phase)
+		return pending, nil
+	}
+
+	// don't escalate before the escalation delay expired
+	if requeueIn := r.checkEscalationDelay(nhc, node, log); requeueIn != nil {
+		pending.requeueAfter = requeueIn
+		return pending, nil
+	}
+
+	// record which route is used for remediating the node, see ResolveRemediationRoute
+	route := resources.ResolveRemediationRoute(nhc)
+	if resources.UpdateStatusRemediationRoute(node.GetName(), nhc, route) && route != nil {
+		log.Info("resolved remediation route", "node", node.GetName(), "route", route.Type, "reason", route.Reason)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonRouteResolved, "Remediating node %s using %s: %s", node.GetName(), route.Type, route.Reason)
+	}
+
+	// get the templates of the remediation CRs
+	var templates []*unstructured.Unstructured
+	var timeout *time.Duration
+	if route != nil && route.Type == remediationv1alpha1.RemediationRouteTemplates {
+		// all templates are used at once
+		var err error
+		if templates, err = rm.GetRemediationTemplates(nhc); err != nil {
+			return nil, errors.Wrapf(err, "failed to get remediation templates")
+		}
+	} else {
+		currentTemplate, currentTimeout, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+		if err != nil {
+			if _, ok := err.(resources.NoTemplateLeftError); ok {
+				log.Error(err, "Remediation timed out, and no template left to try")
+				commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonNoTemplateLeft, "Remediation timed out, and no template left to try. %s", err.Error())
+				// there is nothing we can do about this
+				return pending, nil
+			}
+			return nil, errors.Wrapf(err, "failed to get current template")
+		}
+		templates, timeout = []*unstructured.Unstructured{currentTemplate}, currentTimeout
+	}
+
+	// let remediators know how often the node was remediated already
+	attempt, previousOutcome := resources.GetNextRemediationAttempt(node.GetName(), nhc, currentTime())
+	if ongoing := resources.FindStatusRemediation(node, nhc, resources.IsStatusRemediationOngoing); ongoing != nil && ongoing.DeletedExternally != nil && ongoing.Attempt > 0 {
+		// a recreated CR continues the attempt of the deleted one
+		attempt = ongoing.Attempt
+	}
+
+	// generate remediation CRs
+	for i, template := range templates {
+		generatedRemediationCR, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate remediation CR")
+		}
+
+		if isControlPlaneNode {
+			labels := generatedRemediationCR.GetLabels()
+			labels[RemediationControlPlaneLabelKey] = ""
+			generatedRemediationCR.SetLabels(labels)
+		}
+
+		crAnnotations := generatedRemediationCR.GetAnnotations()
+		if crAnnotations == nil {
+			crAnnotations = make(map[string]string)
+		}
+		crAnnotations[annotations.RemediationAttemptAnnotation] = strconv.Itoa(attempt)
+		if previousOutcome != "" {
+			crAnnotations[annotations.PreviousAttemptOutcomeAnnotation] = previousOutcome
+		}
+		generatedRemediationCR.SetAnnotations(crAnnotations)
+
+		current := pending
+		if i > 0 {
+			current = &pendingRemediation{node: node}
+			pending.fanOut = append(pending.fanOut, current)
+		}
+		current.cr = generatedRemediationCR
+		current.isControlPlane = isControlPlaneNode
+		current.timeout = timeout
+		current.currentRemediationDuration, current.previousRemediationsDuration = resources.GetRemediationDuration(node, nhc, generatedRemediationCR, currentTime())
+	}
+	return pending, nil
+}
+
+// isOtherControlPlaneRemediationPending returns true if a remediation CR for another control plane node than the given
+// one will be created during this reconcile.
+func isOtherControlPlaneRemediationPending(node *v1.Node, other []*pendingRemediation) bool {
+	for _, pending := range other {
+		if pending.isControlPlane && pending.cr != nil && pending.node.GetName() != node.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// updateEffectiveConfig records the configuration which is resolved from the spec and the operator's flags in the
+// status, if it changed. MinHealthy is resolved with the given number of observed nodes.
+func (r *NodeHealthCheckReconciler) updateEffectiveConfig(nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager, observedNodes int) error {
+	templates, err := rm.ResolveTemplateRefs(nhc)
+	if err != nil {
+		return err
+	}
+	config := &remediationv1alpha1.EffectiveConfig{
+		RemediationTemplates:           templates,
+		RemediationCreationConcurrency: r.RemediationCreationConcurrency,
+	}
+	if config.RemediationCreationConcurrency < 1 {
+		config.RemediationCreationConcurrency = 1
+	}
+	if minHealthy, err := resources.GetMinHealthy(nhc, observedNodes); err == nil {
+		config.MinHealthy = &minHealthy
+	}
+	if maxUnhealthy, err := resources.GetMaxUnhealthy(nhc, observedNodes); err == nil && maxUnhealthy >= 0 {
+		config.MaxUnhealthy = &maxUnhealthy
+	}
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		if utils.IsRemediationEnabled(c) {
+			config.UnhealthyConditions = append(config.UnhealthyConditions, c)
+		}
+	}
+	if r.RemediationLimiter != nil {
+		config.MaxClusterRemediations = r.RemediationLimiter.Max()
+	}
+	if throttle := nhc.Spec.RemediationThrottle; throttle != nil {
+		config.MaxConcurrentRemediations = throttle.MaxConcurrent
+		config.MaxRemediationsPerWindow = throttle.MaxPerWindow
+	}
+	return resources.UpdateStatusEffectiveConfig(nhc, config)
+}
+
+// createRemediationCRs creates the remediation CRs of the given remediations, up to RemediationCreationConcurrency in
+// parallel. After an unexpected error no further creations are started, like when creating one CR after another.
+func (r *NodeHealthCheckReconciler) createRemediationCRs(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager, remediations []*pendingRemediation) {
+	workers := r.RemediationCreationConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	workqueue.ParallelizeUntil(ctx, workers, len(remediations), func(i int) {
+		if remediations[i].cr == nil {
+			return
+		}
+		// the CRs of a node are created one after another, they share the node's lease
+		for _, pending := range append([]*pendingRemediation{remediations[i]}, remediations[i].fanOut...) {
+			pending.attempted = true
+			pending.created, pending.leaseRequeueIn, pending.remediationCR, pending.err =
+				rm.CreateRemediationCR(pending.cr, nhc, &pending.node.Name, pending.currentRemediationDuration, pending.previousRemediationsDuration)
+			if pending.err != nil {
+				if !isHandledCreationError(pending.err) {
+					cancel()
+				}
+				return
+			}
+		}
+	})
+}
+
+// isHandledCreationError returns true for errors of CreateRemediationCR which are handled by finishRemediation, and
+// which don't prevent the remediation of other nodes.
+func isHandledCreationError(err error) bool {
+	switch err.(type) {
+	case lease.AlreadyHeldError, resources.LeaseOverDueError, resources.RemediationCRNotOwned:
+		return true
+	}
+	return false
+}
+
+// finishRemediation updates the status with the result of creating the remediation CR, and handles timeouts of
+// ongoing remediations.
+func (r *NodeHealthCheckReconciler) finishRemediation(nhc *remediationv1alpha1.NodeHealthCheck, pending *pendingRemediation, rm resources.Manager) (*time.Duration, error) {
+
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	if pending.cr == nil {
+		return pending.requeueAfter, nil
+	}
+	node, timeout := pending.node, pending.timeout
+	created, leaseRequeueIn, remediationCR, err := pending.created, pending.leaseRequeueIn, pending.remediationCR, pending.err
+
+	if err != nil {
+		// An unhealthy node exists, but remediation couldn't be created because lease wasn't obtained
+		if _, isLeaseAlreadyTaken := err.(lease.AlreadyHeldError); isLeaseAlreadyTaken {
+			return leaseRequeueIn, nil
+		}
+
+		// Lease is overdue
+		if _, isLeaseOverDue := err.(resources.LeaseOverDueError); isLeaseOverDue {
+			now := currentTime()
+			if timeOutErr := r.addTimeOutAnnotation(rm, nhc, remediationCR, metav1.Time{Time: now}, remediationv1alpha1.TimedOutReasonLeaseOverdue); timeOutErr != nil {
+				return nil, timeOutErr
+			}
+			startedRemediation := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+				return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() && r.Resource.Name == remediationCR.GetName()
+			})
+
+			if startedRemediation == nil {
+				// should not have happened, seems last status update failed
+				return nil, errors.New("failed to find started remediation in status for handling overdue lease")
+			}
+
+			// update status (important to do this after CR update, else we won't retry that update in case of error)
+			startedRemediation.TimedOut = &metav1.Time{Time: now}
+			startedRemediation.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+			r.emitRemediationEvent(eventsink.EventTypeRemediationTimedOut, nhc, node.GetName(), startedRemediation)
+			return nil, nil
+		}
+
+		if _, ok := err.(resources.RemediationCRNotOwned); ok {
+			// CR exists but not owned by us, nothing to do
+			resources.UpdateStatusControllingNHC(node.GetName(), nhc, false)
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to create remediation CR")
+	}
+
+	// always update status, in case patching it failed during last reconcile
+	resources.UpdateStatusRemediationStarted(node, nhc, remediationCR, timeout)
+	resources.UpdateStatusControllingNHC(node.GetName(), nhc, true)
+
+	// ensure to provide correct metrics in case the CR existed already after a pod restart
+	metrics.ObserveNodeHealthCheckRemediationCreated(node.GetName(), remediationCR.GetNamespace(), remediationCR.GetKind())
+
+	if created {
+		metrics.ObserveNodeHealthCheckRemediationStarted(nhc.GetName(), remediationCR.GetKind())
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonRemediationCreated, "Created remediation object for node %s", node.Name)
+		if startedRemediation := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+			return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() && r.Resource.Name == remediationCR.GetName()
+		}); startedRemediation != nil {
+			r.emitRemediationEvent(eventsink.EventTypeRemediationStarted, nhc, node.GetName(), startedRemediation)
+		}
+		var requeueIn *time.Duration
+		if timeout != nil {
+			// come back when timeout expires
+			requeueIn = pointer.Duration(*timeout + 1*time.Second)
+		}
+		return utils.MinRequeueDuration(leaseRequeueIn, requeueIn), nil
+	}
+	// CR already exists, check for timeout in case we need to
+	if timeout == nil {
+		// no timeout set for classic remediation
+		// nothing to do anymore here
+		return leaseRequeueIn, nil
+	}
+
+	startedRemediation := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+		return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() && r.Resource.Name == remediationCR.GetName()
+	})
+
+	if startedRemediation == nil {
+		// should not have happened, seems last status update failed
+		return nil, errors.New("failed to find started remediation in status for handling timeout")
+	}
+
+	if startedRemediation.TimedOut != nil {
+		// timeout handled already: should not have happened, but ok. Just reconcile again asap for trying the next template
+		return nil, errors.New("unexpected timout found on started remediation in status")
+	}
+
+	now := metav1.Time{Time: currentTime()}
+	timeoutAt := getTimeoutAt(startedRemediation, timeout)
+	timedOut := now.After(timeoutAt)
+	// keep the recorded deadline up to date, the timeout might have been reconfigured
+	startedRemediation.Timeout = &metav1.Duration{Duration: *timeout}
+	startedRemediation.TimeoutAt = &metav1.Time{Time: timeoutAt}
+
+	failed := remediationFailed(remediationCR, log)
+
+	if !timedOut && !failed {
+		// not timed out yet, come back when we do so
+		return utils.MinRequeueDuration(leaseRequeueIn, pointer.Duration(timeoutAt.Sub(now.Time))), nil
+	}
+
+	// handle timeout and failure
+	reason := remediationv1alpha1.TimedOutReasonTimeout
+	if timedOut {
+		log.Info("remediation timed out")
+	} else if failed {
+		log.Info("remediation failed")
+		reason = remediationv1alpha1.TimedOutReasonFailed
+	}
+
+	// add timeout annotation to remediation CR
+	if err := r.addTimeOutAnnotation(rm, nhc, remediationCR, now, reason); err != nil {
+		return nil, err
+	}
+	// update status (important to do this after CR update, else we won't retry that update in case of error)
+	startedRemediation.TimedOut = &now
+	r.emitRemediationEvent(eventsink.EventTypeRemediationTimedOut, nhc, node.GetName(), startedRemediation)
+	if timedOut {
+		r.observeRemediationOutcome(nhc, node.GetName(), startedRemediation, metrics.RemediationOutcomeTimedOut)
+	} else {
+		r.observeRemediationOutcome(nhc, node.GetName(), startedRemediation, metrics.RemediationOutcomeFailed)
+	}
+
+	if timedOut && nhc.Spec.EscalationHandshake {
+		// a failed remediator doesn't need to acknowledge, but one which timed out might still be busy
+		log.Info("waiting for the remediator to acknowledge the timeout", "node", node.GetName())
+		startedRemediation.Phase = remediationv1alpha1.RemediationPhaseAwaitingAcknowledgment
+		return pointer.Duration(utils.GetEscalationHandshakeGracePeriod(nhc) + 1*time.Second), nil
+	}
+	startedRemediation.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+
+	// try next remediation asap
+	return pointer.Duration(1 * time.Second), nil
+}
+
+// handleExternallyDeletedRemediationCR applies the ExternalCRDeletionPolicy, when the remediation CR of the ongoing
+// remediation of the given node doesn't exist anymore. NHC updates the status of remediations whose CRs it deletes
+// while the node is unhealthy, so a missing CR of an ongoing remediation was deleted by someone else. It returns when
+// to check again, while a missing CR might just not be cached yet.
+func (r *NodeHealthCheckReconciler) handleExternallyDeletedRemediationCR(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager, log logr.Logger) (*time.Duration, error) {
+	ongoing := resources.FindStatusRemediation(node, nhc, resources.IsStatusRemediationOngoing)
+	if ongoing == nil {
+		return nil, nil
+	}
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return cr.GetName() == ongoing.Resource.Name && cr.GroupVersionKind() == ongoing.Resource.GroupVersionKind()
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remediation CR for checking its existence")
+	}
+	if len(remediationCRs) > 0 {
+		return nil, nil
+	}
+
+	// the cache might not contain CRs which were created or recreated just before
+	now := currentTime()
+	createdAt := ongoing.Started.Time
+	if ongoing.DeletedExternally != nil && ongoing.DeletedExternally.After(createdAt) {
+		createdAt = ongoing.DeletedExternally.Time
+	}
+	if graceEnd := createdAt.Add(externalDeletionGracePeriod); now.Before(graceEnd) {
+		return pointer.Duration(graceEnd.Sub(now) + 1*time.Second), nil
+	}
+
+	policy := nhc.Spec.ExternalCRDeletionPolicy
+	if policy == "" {
+		policy = remediationv1alpha1.ExternalCRDeletionPolicyRecreate
+	}
+	log.Info("remediation CR was deleted externally", "node", node.GetName(), "kind", ongoing.Resource.Kind, "name", ongoing.Resource.Name, "policy", policy)
+	metrics.ObserveNodeHealthCheckExternalCRDeletion(ongoing.Resource.Kind+"Template", ongoing.TemplateName, string(policy))
+	ongoing.DeletedExternally = &metav1.Time{Time: now}
+	switch policy {
+	case remediationv1alpha1.ExternalCRDeletionPolicyTreatAsCompleted:
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonRemediationCRDeleted, "%s remediation CR of node %s was deleted externally, treating remediation as completed", ongoing.Resource.Kind, node.GetName())
+		ongoing.Phase = remediationv1alpha1.RemediationPhaseSucceeded
+	case remediationv1alpha1.ExternalCRDeletionPolicyTreatAsFailed:
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonRemediationCRDeleted, "%s remediation CR of node %s was deleted externally, treating remediation as failed", ongoing.Resource.Kind, node.GetName())
+		ongoing.TimedOut = &metav1.Time{Time: now}
+		ongoing.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+		r.emitRemediationEvent(eventsink.EventTypeRemediationTimedOut, nhc, node.GetName(), ongoing)
+		r.observeRemediationOutcome(nhc, node.GetName(), ongoing, metrics.RemediationOutcomeFailed)
+	default:
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonRemediationCRDeleted, "%s remediation CR of node %s was deleted externally, recreating it", ongoing.Resource.Kind, node.GetName())
+	}
+	return nil, nil
+}
+
+// abortPausedRemediation deletes the remediation CR of the ongoing remediation of the given node, if its escalating
+// remediation was paused with AbortOnPause. The remediation is aborted in the status, so that escalation continues
+// with the next escalating remediation.
+func (r *NodeHealthCheckReconciler) abortPausedRemediation(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager, log logr.Logger) error {
+	ongoing := resources.FindStatusRemediation(node, nhc, resources.IsStatusRemediationOngoing)
+	if ongoing == nil {
+		return nil
+	}
+	escRem := resources.FindEscalatingRemediation(nhc, ongoing)
+	if escRem == nil || escRem.PauseReason == "" || !escRem.AbortOnPause {
+		return nil
+	}
+
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return cr.GetName() == ongoing.Resource.Name && cr.GroupVersionKind() == ongoing.Resource.GroupVersionKind() && resources.IsOwner(&cr, nhc)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get remediation CR for aborting it")
+	}
+	for i := range remediationCRs {
+		if _, err := rm.DeleteRemediationCR(&remediationCRs[i], nhc); err != nil {
+			return errors.Wrapf(err, "failed to delete remediation CR of paused escalating remediation")
+		}
+	}
+
+	log.Info("aborted remediation, because its escalating remediation is paused", "node", node.GetName(), "kind", ongoing.Resource.Kind, "pauseReason", escRem.PauseReason)
+	commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonNotApplicable, "Aborted %s remediation for node %s, it is paused: %s", ongoing.Resource.Kind, node.GetName(), escRem.PauseReason)
+	// update status (important to do this after CR deletion, else we won't retry that deletion in case of error)
+	ongoing.TimedOut = &metav1.Time{Time: currentTime()}
+	ongoing.Phase = remediationv1alpha1.RemediationPhaseAborted
+	return nil
+}
+
+// abortAll deletes all remediation CRs owned by the given NHC, aborts its ongoing remediations with the given reason,
+// and uncordons the nodes it drained. It adds a pause request to the NHC, so that no remediation starts again until
+// both the abort-all annotation and the pause request are removed. Returns true when remediation CRs are still
+// pending deletion, e.g. because of finalizers.
+func (r *NodeHealthCheckReconciler) abortAll(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, reason string, rm resources.Manager, log logr.Logger) (bool, error) {
+	pauseRequest := abortAllPauseRequestPrefix + reason
+	pauseRequested := false
+	for _, request := range nhc.Spec.PauseRequests {
+		if request == pauseRequest {
+			pauseRequested = true
+			break
+		}
+	}
+	if !pauseRequested {
+		latest := &remediationv1alpha1.NodeHealthCheck{ObjectMeta: metav1.ObjectMeta{Name: nhc.GetName()}}
+		if _, err := utils.UpdateWithConflictRetry(ctx, r.Client, latest, func() bool {
+			for _, request := range latest.Spec.PauseRequests {
+				if request == pauseRequest {
+					return false
+				}
+			}
+			latest.Spec.PauseRequests = append(latest.Spec.PauseRequests, pauseRequest)
+			return true
+		}); err != nil {
+			return false, errors.Wrapf(err, "failed to add pause request for aborting all remediations")
+		}
+		// the in-memory copy is used for the rest of this reconcile
+		nhc.Spec.PauseRequests = append(nhc.Spec.PauseRequests, pauseRequest)
+		log.Info("aborting all remediations", "reason", reason)
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonAbortedAll, "Aborting all remediations and pausing NHC: %s", reason)
+	}
+
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return resources.IsOwner(&cr, nhc)
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get remediation CRs for aborting them")
+	}
+	var stuck []string
+	for i := range remediationCRs {
+		cr := &remediationCRs[i]
+		if cr.GetDeletionTimestamp() != nil {
+			stuck = append(stuck, fmt.Sprintf("%s %s/%s", cr.GetKind(), cr.GetNamespace(), cr.GetName()))
+			continue
+		}
+		if _, err := rm.DeleteRemediationCR(cr, nhc); err != nil {
+			return false, errors.Wrapf(err, "failed to delete remediation CR for aborting it")
+		}
+	}
+	if len(stuck) > 0 {
+		log.Info("aborted remediation CRs are pending deletion", "remediationCRs", stuck)
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonAbortedAll, "Aborted remediation CRs are pending deletion: %s", strings.Join(stuck, ", "))
+	}
+
+	// update status (important to do this after CR deletion, else we won't retry that deletion in case of error)
+	now := metav1.Time{Time: currentTime()}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		for _, rem := range unhealthyNode.Remediations {
+			if !resources.IsStatusRemediationOngoing(rem) {
+				continue
+			}
+			log.Info("aborted remediation", "node", unhealthyNode.Name, "kind", rem.Resource.Kind, "reason", reason)
+			rem.TimedOut = &now
+			rem.Phase = remediationv1alpha1.RemediationPhaseAborted
+			rem.Reason = remediationv1alpha1.RemediationReasonAbortAll
+		}
+		// make a node schedulable again, which was drained before remediation
+		if unhealthyNode.Drain != nil {
+			if err := r.Drainer.Uncordon(ctx, c, unhealthyNode.Name); err != nil {
+				return false, errors.Wrapf(err, "failed to uncordon node %s", unhealthyNode.Name)
+			}
+			unhealthyNode.Drain = nil
+		}
+		delete(nhc.Status.InFlightRemediations, unhealthyNode.Name)
+		r.RemediationLimiter.Release(nhc.GetName(), unhealthyNode.Name)
+	}
+	return len(stuck) > 0, nil
+}
+
+// resetNode resets the remediation state of the given node, e.g. after it was repaired manually, so that its
+// remediation starts over when it's still unhealthy. Owned remediation CRs of the node are deleted first, the status
+// is only reset when they are gone. Then the reset-node annotation is removed. Returns true when remediation CRs are
+// still pending deletion.
+func (r *NodeHealthCheckReconciler) resetNode(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, rm resources.Manager, log logr.Logger) (bool, error) {
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return resources.GetNodeName(cr) == nodeName && resources.IsOwner(&cr, nhc)
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get remediation CRs of node %s for resetting it", nodeName)
+	}
+	if len(remediationCRs) > 0 {
+		for i := range remediationCRs {
+			if _, err := rm.DeleteRemediationCR(&remediationCRs[i], nhc); err != nil {
+				return false, errors.Wrapf(err, "failed to delete remediation CR of node %s for resetting it", nodeName)
+			}
+		}
+		log.Info("waiting for deletion of remediation CRs before resetting node", "node", nodeName, "count", len(remediationCRs))
+		return true, nil
+	}
+
+	// make a node schedulable again, which was drained before remediation
+	if resources.GetStatusDrain(nodeName, nhc) != nil {
+		if err := r.Drainer.Uncordon(ctx, c, nodeName); err != nil {
+			return false, errors.Wrapf(err, "failed to uncordon node %s", nodeName)
+		}
+	}
+	exhausted := resources.IsStatusRemediationExhausted(nodeName, nhc)
+	resources.ResetStatusNode(nodeName, nhc)
+	r.RemediationLimiter.Release(nhc.GetName(), nodeName)
+
+	latest := &remediationv1alpha1.NodeHealthCheck{ObjectMeta: metav1.ObjectMeta{Name: nhc.GetName()}}
+	if _, err := utils.UpdateWithConflictRetry(ctx, r.Client, latest, func() bool {
+		if value, exists := latest.GetAnnotations()[annotations.ResetNodeAnnotation]; !exists || value != nodeName {
+			return false
+		}
+		delete(latest.Annotations, annotations.ResetNodeAnnotation)
+		return true
+	}); err != nil {
+		return false, errors.Wrapf(err, "failed to remove reset-node annotation")
+	}
+	log.Info("reset remediation state of node", "node", nodeName, "exhausted", exhausted)
+	commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonNodeReset, "Reset remediation state of node %s", nodeName)
+	return false, nil
+}
+
+// checkEscalationDelay defers the next escalating remediation of the given node, as long as the EscalationDelay after
+// the timeout of its last remediation didn't expire. It returns when the delay expires.
+func (r *NodeHealthCheckReconciler) checkEscalationDelay(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, log logr.Logger) *time.Duration {
+	deferral := resources.GetStatusDeferral(node.GetName(), nhc)
+	inCooldown := deferral != nil && deferral.Reason == remediationv1alpha1.DeferralReasonEscalationCooldown
+
+	last := resources.GetStatusLastRemediation(node.GetName(), nhc)
+	if nhc.Spec.EscalationDelay == nil || last == nil || last.Phase != remediationv1alpha1.RemediationPhaseTimedOut || last.TimedOut == nil {
+		if inCooldown {
+			resources.UpdateStatusDeferral(node.GetName(), nhc, "", "", currentTime())
+		}
+		return nil
+	}
+
+	now := currentTime()
+	delayEnd := last.TimedOut.Add(nhc.Spec.EscalationDelay.Duration)
+	if !now.Before(delayEnd) {
+		if inCooldown {
+			log.Info("escalation delay expired", "node", node.GetName())
+			resources.UpdateStatusDeferral(node.GetName(), nhc, "", "", now)
+		}
+		return nil
+	}
+
+	message := fmt.Sprintf("%s remediation timed out, next escalating remediation starts at %s", last.Resource.Kind, delayEnd.UTC().Format(time.RFC3339))
+	if !inCooldown {
+		log.Info("delaying escalation", "node", node.GetName(), "until", delayEnd)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonEscalationCooldown, "Delaying escalation of remediation for node %s: %s", node.GetName(), message)
+	}
+	resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonEscalationCooldown, message, now)
+	return pointer.Duration(delayEnd.Sub(now) + 1*time.Second)
+}
+
+// deleteTimedOutRemediations deletes the remediation CRs of the given node's timed out remediations, if
+// DeleteTimedOutRemediations is enabled. Remediations which are awaiting the acknowledgment of their timeout are kept.
+func (r *NodeHealthCheckReconciler) deleteTimedOutRemediations(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager, log logr.Logger) error {
+	if !nhc.Spec.DeleteTimedOutRemediations {
+		return nil
+	}
+	var timedOut []*remediationv1alpha1.Remediation
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != node.GetName() {
+			continue
+		}
+		for _, remediation := range unhealthyNode.Remediations {
+			if remediation.Phase == remediationv1alpha1.RemediationPhaseTimedOut {
+				timedOut = append(timedOut, remediation)
+			}
+		}
+	}
+	if len(timedOut) == 0 {
+		return nil
+	}
+
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		if cr.GetDeletionTimestamp() != nil || !resources.IsOwner(&cr, nhc) {
+			return false
+		}
+		for _, remediation := range timedOut {
+			if cr.GetName() == remediation.Resource.Name && cr.GroupVersionKind() == remediation.Resource.GroupVersionKind() {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get timed out remediation CRs for deleting them")
+	}
+	for i := range remediationCRs {
+		if _, err := rm.DeleteRemediationCR(&remediationCRs[i], nhc); err != nil {
+			return errors.Wrapf(err, "failed to delete timed out remediation CR")
+		}
+		log.Info("deleted timed out remediation CR", "node", node.GetName(), "kind", remediationCRs[i].GetKind())
+	}
+	return nil
+}
+
+// checkMaxRemediationDuration returns true if remediation of the given node was given up, because it's ongoing for
+// longer than the MaxRemediationDuration. In that case the ongoing remediation is timed out. Otherwise it returns
+// when the MaxRemediationDuration expires.
+func (r *NodeHealthCheckReconciler) checkMaxRemediationDuration(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager, log logr.Logger) (bool, *time.Duration, error) {
+	if nhc.Spec.MaxRemediationDuration == nil {
+		return false, nil, nil
+	}
+	if resources.IsStatusRemediationExhausted(node.GetName(), nhc) {
+		return true, nil, nil
+	}
+	started := resources.GetStatusFirstRemediationStarted(node.GetName(), nhc)
+	if started == nil {
+		// remediation didn't start yet
+		return false, nil, nil
+	}
+
+	now := currentTime()
+	deadline := started.Add(nhc.Spec.MaxRemediationDuration.Duration)
+	if now.Before(deadline) {
+		return false, pointer.Duration(deadline.Sub(now) + 1*time.Second), nil
+	}
+
+	// time out the ongoing remediations, there are several with RemediationTemplates
+	for _, ongoing := range resources.FindStatusRemediations(node, nhc, resources.IsStatusRemediationOngoing) {
+		remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+			return cr.GetName() == ongoing.Resource.Name && cr.GroupVersionKind() == ongoing.Resource.GroupVersionKind() && resources.IsOwner(&cr, nhc)
+		})
+		if err != nil {
+			return false, nil, errors.Wrapf(err, "failed to get remediation CR for timing it out")
+		}
+		timedOut := metav1.Time{Time: now}
+		for i := range remediationCRs {
+			if err := r.addTimeOutAnnotation(rm, nhc, &remediationCRs[i], timedOut, remediationv1alpha1.TimedOutReasonMaxRemediationDuration); err != nil {
+				return false, nil, err
+			}
+		}
+		// update status (important to do this after CR update, else we won't retry that update in case of error)
+		ongoing.TimedOut = &timedOut
+		ongoing.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+		r.emitRemediationEvent(eventsink.EventTypeRemediationTimedOut, nhc, node.GetName(), ongoing)
+		r.observeRemediationOutcome(nhc, node.GetName(), ongoing, metrics.RemediationOutcomeExhausted)
+	}
+
+	msg := fmt.Sprintf("Gave up remediation of node %s, because it exceeded the max remediation duration of %s", node.GetName(), nhc.Spec.MaxRemediationDuration.Duration)
+	log.Info(msg)
+	commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationExhausted, msg)
+	resources.UpdateStatusRemediationExhausted(node.GetName(), nhc, now)
+	return true, nil, nil
+}
+
+// isExternallyRemediated returns true if the given node has the out-of-service taint, which wasn't applied as part
+// of a remediation by NHC, and the ExternalRemediationGracePeriod didn't expire yet. Then remediation is deferred,
+// and the returned duration is the time until the grace period expires.
+// Callers need to ensure that the node doesn't have remediations in the status.
+func (r *NodeHealthCheckReconciler) isExternallyRemediated(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, log logr.Logger) (bool, *time.Duration) {
+	taint := getOutOfServiceTaint(node)
+	if taint == nil {
+		return false, nil
+	}
+
+	// the grace period starts when the taint was added, or when we saw it first
+	now := currentTime()
+	deferral := resources.GetStatusDeferral(node.GetName(), nhc)
+	isDeferred := deferral != nil && deferral.Reason == remediationv1alpha1.DeferralReasonExternallyRemediated
+	since := now
+	if taint.TimeAdded != nil {
+		since = taint.TimeAdded.Time
+	} else if isDeferred {
+		since = deferral.Since.Time
+	}
+
+	gracePeriod := utils.GetExternalRemediationGracePeriod(nhc)
+	if expiresAt := since.Add(gracePeriod); now.Before(expiresAt) {
+		if !isDeferred {
+			msg := fmt.Sprintf("Skipped remediation of node %s because it has the %s taint, waiting up to %s for its recovery", node.GetName(), v1.TaintNodeOutOfService, gracePeriod)
+			log.Info(msg)
+			commonevents.NormalEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+		}
+		resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonExternallyRemediated,
+			fmt.Sprintf("node has the %s taint, which wasn't applied by a remediation of this NodeHealthCheck", v1.TaintNodeOutOfService), since)
+		return true, pointer.Duration(expiresAt.Sub(now) + 1*time.Second)
+	}
+
+	if isDeferred {
+		msg := fmt.Sprintf("Node %s still has the %s taint and didn't recover within %s, starting remediation", node.GetName(), v1.TaintNodeOutOfService, gracePeriod)
+		log.Info(msg)
+		commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonExternalRemediationOver, msg)
+	}
+	return false, nil
+}
+
+// isPDBViolated checks if removing the pods of the given node would violate PodDisruptionBudgets, if configured by
+// RespectPodDisruptionBudgets. It returns true while remediation is deferred, with when to check again. Unreachable
+// nodes are not checked, because their pods are gone effectively already. Remediation isn't deferred for longer
+// than the override timeout.
+func (r *NodeHealthCheckReconciler) isPDBViolated(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, evaluation *resources.NodeEvaluation, log logr.Logger) (bool, *time.Duration, error) {
+	config := nhc.Spec.RespectPodDisruptionBudgets
+	if config == nil || !config.Enabled {
+		return false, nil, nil
+	}
+	reportedUnreachable := evaluation != nil && evaluation.UnreachableSince != nil
+	if reportedUnreachable || !isNodeReachable(node) {
+		resources.UpdateStatusPDBViolationSince(node.GetName(), nhc, nil)
+		return false, nil, nil
+	}
+	allowed, message, err := r.DisruptionGate.IsRemediationAllowed(ctx, c, nhc, node)
+	if err != nil {
+		return false, nil, err
+	}
+	if allowed {
+		resources.UpdateStatusPDBViolationSince(node.GetName(), nhc, nil)
+		return false, nil, nil
+	}
+
+	now := currentTime()
+	since := resources.GetStatusPDBViolationSince(node.GetName(), nhc)
+	if since == nil {
+		since = &metav1.Time{Time: now}
+		resources.UpdateStatusPDBViolationSince(node.GetName(), nhc, since)
+	}
+	overrideTimeout := utils.GetPDBOverrideTimeout(nhc)
+	if expiresAt := since.Add(overrideTimeout); now.Before(expiresAt) {
+		msg := fmt.Sprintf("Skipped remediation of node %s because %s", node.GetName(), message)
+		log.Info(msg)
+		commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonRemediationSkipped, msg)
+		resources.UpdateStatusDeferral(node.GetName(), nhc, remediationv1alpha1.DeferralReasonPDBViolation, message, since.Time)
+		return true, utils.MinRequeueDuration(pointer.Duration(disruptionGateRequeueAfter), pointer.Duration(expiresAt.Sub(now)+1*time.Second)), nil
+	}
+
+	msg := fmt.Sprintf("Remediating node %s although %s, because it is deferred for more than %s", node.GetName(), message, overrideTimeout)
+	log.Info(msg)
+	commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonPDBViolationOverridden, msg)
+	return false, nil, nil
+}
+
+// drainBeforeRemediation drains the given node before its first remediation, if configured by PreRemediationDrain.
+// It returns true while the drain is ongoing, with the duration after which the drain should be checked again.
+// Unreachable nodes are not drained. Callers need to ensure that the node doesn't have remediations in the status.
+func (r *NodeHealthCheckReconciler) drainBeforeRemediation(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, evaluation *resources.NodeEvaluation, log logr.Logger) (bool, *time.Duration, error) {
+	config := nhc.Spec.PreRemediationDrain
+	if config == nil || !config.Enabled {
+		return false, nil, nil
+	}
+	status := resources.GetStatusDrain(node.GetName(), nhc)
+	if status != nil && status.Phase != remediationv1alpha1.DrainPhaseDraining {
+		// the drain finished already
+		return false, nil, nil
+	}
+
+	now := currentTime()
+	if status == nil {
+		reportedUnreachable := evaluation != nil && evaluation.UnreachableSince != nil
+		if reportedUnreachable || !isNodeReachable(node) {
+			log.Info("skipping drain of unreachable node", "node", node.GetName())
+			return false, nil, nil
+		}
+		status = &remediationv1alpha1.Drain{
+			Started: metav1.Time{Time: now},
+			Phase:   remediationv1alpha1.DrainPhaseDraining,
+		}
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDrainStarted, "Draining node %s before remediation", node.GetName())
+	}
+	defer resources.UpdateStatusDrain(node.GetName(), nhc, status)
+
+	timeout := utils.GetPreRemediationDrainTimeout(nhc)
+	timeoutAt := status.Started.Add(timeout)
+	if !now.Before(timeoutAt) {
+		status.Phase = remediationv1alpha1.DrainPhaseTimedOut
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonDrainFinished, "Drain of node %s didn't complete within %s, starting remediation: %s", node.GetName(), timeout, status.Message)
+		return false, nil, nil
+	}
+
+	done, message, err := r.Drainer.Drain(ctx, c, node, config)
+	var blocked drain.BlockedError
+	if errors.As(err, &blocked) {
+		status.Phase = remediationv1alpha1.DrainPhaseFailed
+		status.Message = blocked.Error()
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonDrainFinished, "Drain of node %s failed, starting remediation: %s", node.GetName(), status.Message)
+		return false, nil, nil
+	} else if err != nil {
+		return false, nil, err
+	}
+	if done {
+		status.Phase = remediationv1alpha1.DrainPhaseCompleted
+		status.Message = ""
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonDrainFinished, "Drained node %s, starting remediation", node.GetName())
+		return false, nil, nil
+	}
+	status.Message = message
+	return true, utils.MinRequeueDuration(pointer.Duration(drainRequeueAfter), pointer.Duration(timeoutAt.Sub(now)+1*time.Second)), nil
+}
+
+// isNodeReachable returns false if the kubelet of the given node doesn't report its status anymore
+func isNodeReachable(node *v1.Node) bool {
+	if utils.GetTaint(node, v1.TaintNodeUnreachable) != nil {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status != v1.ConditionUnknown
+		}
+	}
+	return false
+}
+
+// getUnhealthySince returns the time since when the given node is unhealthy, based on its matched condition or
+// capacity, or on when it was reported as unreachable or its heartbeat got stale. Returns the current time if unknown.
+func getUnhealthySince(node *v1.Node, evaluation *resources.NodeEvaluation) time.Time {
+	if evaluation == nil {
+		return currentTime()
+	}
+	if evaluation.Condition != nil {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == evaluation.Condition.Type {
+				return condition.LastTransitionTime.Time
+			}
+		}
+	}
+	for _, since := range evaluation.GetUnhealthySince() {
+		return since.Time
+	}
+	return currentTime()
+}
+
+func getOutOfServiceTaint(node *v1.Node) *v1.Taint {
+	return utils.GetTaint(node, v1.TaintNodeOutOfService)
+}
+
+// waitForCRD sets the WaitingForCRD condition, and returns true and when to check again, as long as the
+// CRDWaitTimeout didn't expire
+func (r *NodeHealthCheckReconciler) waitForCRD(nhc *remediationv1alpha1.NodeHealthCheck, message string, log logr.Logger) (time.Duration, bool) {
+	if r.CRDWaitTimeout == 0 {
+		return 0, false
+	}
+	if !meta.IsStatusConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeWaitingForCRD) {
+		log.Info("waiting for remediation CRD", "message", message)
+		commonevents.NormalEvent(r.Recorder, nhc, utils.EventReasonWaitingForCRD, message)
+	}
+	// the transition time is kept as long as the status doesn't change
+	meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+		Type:    remediationv1alpha1.ConditionTypeWaitingForCRD,
+		Status:  metav1.ConditionTrue,
+		Reason:  remediationv1alpha1.ConditionReasonCRDNotEstablished,
+		Message: message,
+	})
+	waitingSince := meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeWaitingForCRD).LastTransitionTime
+	waiting := currentTime().Sub(waitingSince.Time)
+	if waiting >= r.CRDWaitTimeout {
+		return 0, false
+	}
+
+	// back off by waiting as long as we waited already
+	requeueAfter := waiting
+	if requeueAfter < crdWaitMinRequeueAfter {
+		requeueAfter = crdWaitMinRequeueAfter
+	} else if requeueAfter > crdWaitMaxRequeueAfter {
+		requeueAfter = crdWaitMaxRequeueAfter
+	}
+	return requeueAfter, true
+}
+
+// cleanUpRemovedRemediationKinds deletes the remediation CRs owned by the given NHC, whose kind was created by the
+// NHC, but isn't referenced by its spec anymore. Their remediations in the status are aborted.
+// Returns true if CRs of removed kinds still existed.
+func (r *NodeHealthCheckReconciler) cleanUpRemovedRemediationKinds(nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager, log logr.Logger) (bool, error) {
+	// also consider remediations which were created before their kinds were tracked
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		for _, rem := range unhealthyNode.Remediations {
+			if rem.Phase != remediationv1alpha1.RemediationPhaseAborted && rem.Phase != remediationv1alpha1.RemediationPhaseNotApplicable {
+				resources.AddStatusCreatedRemediationKind(nhc, rem.Resource.GroupVersionKind())
+			}
+		}
+	}
+
+	// compare group and kind only, CRs of the same kind are still managed when only the version changed
+	referenced := make(map[schema.GroupKind]bool)
+	for _, templateRef := range utils.GetAllRemediationTemplates(nhc) {
+		referenced[rm.GenerateRemediationCRBase(templateRef.GroupVersionKind()).GroupVersionKind().GroupKind()] = true
+	}
+
+	pending := false
+	createdKinds := make([]metav1.GroupVersionKind, len(nhc.Status.CreatedRemediationKinds))
+	copy(createdKinds, nhc.Status.CreatedRemediationKinds)
+	for _, kind := range createdKinds {
+		gvk := schema.GroupVersionKind{Group: kind.Group, Version: kind.Version, Kind: kind.Kind}
+		if referenced[gvk.GroupKind()] {
+			continue
+		}
+		remaining, err := rm.DeleteRemediationCRsOfKind(gvk, nhc)
+		if err != nil && !meta.IsNoMatchError(err) {
+			return false, err
+		} else if err != nil {
+			// nothing we can clean up
+			log.Info("CRD of removed remediation kind doesn't exist anymore, skipping clean up", "kind", gvk)
+			remaining = 0
+		} else if remaining > 0 {
+			log.Info("deleting remediation CRs of kind which was removed from the spec", "kind", gvk, "count", remaining)
+		}
+		resources.UpdateStatusRemediationsAborted(nhc, gvk)
+		if remaining == 0 {
+			resources.RemoveStatusCreatedRemediationKind(nhc, gvk)
+		} else {
+			pending = true
+		}
+	}
+	return pending, nil
+}
+
+// retryOrphanedRemediationsCleanUp retries deleting the remediation CRs which NHC failed to delete before, and removes
+// them from the status on success. Returns true if deletion failed again.
+func (r *NodeHealthCheckReconciler) retryOrphanedRemediationsCleanUp(nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager, log logr.Logger) bool {
+	pending := false
+	orphanedRemediations := make([]remediationv1alpha1.OrphanedRemediation, len(nhc.Status.OrphanedRemediations))
+	copy(orphanedRemediations, nhc.Status.OrphanedRemediations)
+	for _, orphaned := range orphanedRemediations {
+		cr := rm.GenerateRemediationCRBase(orphaned.Resource.GroupVersionKind())
+		cr.SetNamespace(orphaned.Resource.Namespace)
+		cr.SetName(orphaned.Resource.Name)
+		if _, err := rm.DeleteRemediationCR(cr, nhc); err != nil && !meta.IsNoMatchError(err) {
+			log.Error(err, "failed to delete orphaned remediation CR again", "kind", cr.GetKind(), "namespace", cr.GetNamespace(), "name", cr.GetName())
+			resources.UpdateStatusOrphanedRemediation(nhc, orphaned.Resource, orphaned.NodeName, err.Error(), currentTime())
+			pending = true
+			continue
+		}
+		log.Info("deleted orphaned remediation CR", "kind", cr.GetKind(), "namespace", cr.GetNamespace(), "name", cr.GetName())
+		resources.RemoveStatusOrphanedRemediation(nhc, orphaned.Resource)
+	}
+	return pending
+}
+
+// trackFailedCleanUp tracks the remediation CR in the status, if the given reconcile error is caused by a failed
+// deletion of it
+func trackFailedCleanUp(nhc *remediationv1alpha1.NodeHealthCheck, reconcileErr error) {
+	var deletionFailed resources.RemediationCRDeletionFailed
+	if errors.As(reconcileErr, &deletionFailed) {
+		resources.UpdateStatusOrphanedRemediation(nhc, deletionFailed.Resource, deletionFailed.NodeName, deletionFailed.Error(), currentTime())
+	}
+}
+
+// updateCleanUpFailedCondition sets the CleanupFailed condition based on the remediation CRs which NHC failed to
+// delete. The condition is only added when deleting a remediation CR failed.
+func updateCleanUpFailedCondition(nhc *remediationv1alpha1.NodeHealthCheck) {
+	if len(nhc.Status.OrphanedRemediations) > 0 {
+		var names []string
+		for _, orphaned := range nhc.Status.OrphanedRemediations {
+			names = append(names, fmt.Sprintf("%s %s/%s", orphaned.Resource.Kind, orphaned.Resource.Namespace, orphaned.Resource.Name))
+		}
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeCleanupFailed,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonRemediationCRDeletionFailed,
+			Message: fmt.Sprintf("Failed to delete remediation CRs: %s", strings.Join(names, ", ")),
+		})
+	} else if meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeCleanupFailed) != nil {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeCleanupFailed,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonNoCleanupFailed,
+			Message: "All remediation CRs which needed to be deleted were deleted",
+		})
+	}
+}
+
+// updatePoolTooSmallCondition sets the PoolTooSmall condition, and returns true when fewer nodes than MinSelectedNodes
+// are selected. The condition is only added when the pool is too small.
+func (r *NodeHealthCheckReconciler) updatePoolTooSmallCondition(nhc *remediationv1alpha1.NodeHealthCheck, selectedNodes int, log logr.Logger) bool {
+	if selectedNodes < nhc.Spec.MinSelectedNodes {
+		msg := fmt.Sprintf("%d nodes are selected, but remediation requires at least %d", selectedNodes, nhc.Spec.MinSelectedNodes)
+		if !meta.IsStatusConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypePoolTooSmall) {
+			log.Info("withholding remediation, too few nodes are selected", "selectedNodes", selectedNodes, "minSelectedNodes", nhc.Spec.MinSelectedNodes)
+			commonevents.WarningEvent(r.Recorder, nhc, utils.EventReasonPoolTooSmall, msg)
+		}
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypePoolTooSmall,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonSelectedNodesBelowMinimum,
+			Message: msg,
+		})
+		return true
+	}
+	if meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypePoolTooSmall) != nil {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypePoolTooSmall,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonEnoughSelectedNodes,
+			Message: fmt.Sprintf("%d nodes are selected, remediation requires at least %d", selectedNodes, nhc.Spec.MinSelectedNodes),
+		})
+	}
+	return false
+}
+
+// updateRemediationExhaustedCondition sets the ExhaustedNodes and the RemediationExhausted condition based on the
+// unhealthy nodes whose remediation was given up. The condition is only added when remediation of a node was given up.
+func updateRemediationExhaustedCondition(nhc *remediationv1alpha1.NodeHealthCheck) {
+	exhaustedNodes := resources.GetStatusExhaustedNodes(nhc)
+	nhc.Status.ExhaustedNodes = exhaustedNodes
+	if len(exhaustedNodes) > 0 {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeRemediationExhausted,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonMaxRemediationDurationExceeded,
+			Message: fmt.Sprintf("Remediation of nodes exceeded the max remediation duration: %s", strings.Join(exhaustedNodes, ", ")),
+		})
+	} else if meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeRemediationExhausted) != nil {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeRemediationExhausted,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonNoRemediationExhausted,
+			Message: "No remediation exceeded the max remediation duration",
+		})
+	}
+}
+
+// updateRemediatorDegradedCondition sets the RemediatorDegraded condition and metric based on the remediation kinds
+// whose circuit of the RemediatorCircuitBreaker isn't closed. The condition is only added when a circuit opened.
+func updateRemediatorDegradedCondition(nhc *remediationv1alpha1.NodeHealthCheck) {
+	resources.PruneStatusRemediatorCircuits(nhc)
+	openKinds := resources.GetStatusOpenRemediatorCircuits(nhc)
+	metrics.ObserveNodeHealthCheckRemediatorCircuits(nhc.GetName(), openKinds)
+	if len(openKinds) > 0 {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeRemediatorDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonRemediatorCircuitOpen,
+			Message: fmt.Sprintf("The circuit of remediation kinds is open, because their remediations timed out or failed in a row: %s", strings.Join(openKinds, ", ")),
+		})
+	} else if meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeRemediatorDegraded) != nil {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeRemediatorDegraded,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonNoRemediatorDegraded,
+			Message: "The circuits of all remediation kinds are closed",
+		})
+	}
+}
+
+// updateRemediatorUnavailableCondition sets the RemediatorUnavailable condition based on the remediators of the
+// RemediatorHealthCheck which aren't running
+func updateRemediatorUnavailableCondition(nhc *remediationv1alpha1.NodeHealthCheck) {
+	if len(nhc.Spec.RemediatorHealthCheck) == 0 {
+		nhc.Status.UnavailableRemediators = nil
+	}
+	if len(nhc.Status.UnavailableRemediators) > 0 {
+		var messages []string
+		for _, remediator := range nhc.Status.UnavailableRemediators {
+			messages = append(messages, fmt.Sprintf("%s (%s)", remediator.Kind, remediator.Message))
+		}
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeRemediatorUnavailable,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonRemediatorNotReady,
+			Message: fmt.Sprintf("The remediators of remediation kinds aren't running: %s", strings.Join(messages, ", ")),
+		})
+	} else if meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeRemediatorUnavailable) != nil {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeRemediatorUnavailable,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonRemediatorsReady,
+			Message: "The remediators of all remediation kinds are running",
+		})
+	}
+}
+
+const (
+	// maxStatusReasonLength is the max length of the status reason, longer reasons are truncated
+	maxStatusReasonLength = 1024
+	// maxStatusReasonNodes is the max number of nodes listed in a fragment of the status reason
+	maxStatusReasonNodes = 10
+	// statusReasonTruncated indicates a truncated status reason
+	statusReasonTruncated = "... (truncated)"
+
+	// codes of the status reason fragments, in addition to the phases
+	statusReasonWaitingForCRD        = "WaitingForCRD"
+	statusReasonInsufficientCapacity = "InsufficientCapacity"
+	statusReasonHighUtilization      = "HighUtilization"
+	statusReasonDryRunNodes          = "DryRunNodes"
+	statusReasonMaxUnhealthyReached  = "MaxUnhealthyReached"
+	statusReasonWindowDeferredNodes  = "WindowDeferredNodes"
+)
+
+// statusReasonFragments are the templates of the status reason, keyed by their codes. Keep the wording stable, changed
+// reasons are only written with other status changes.
+var statusReasonFragments = map[string]string{
+	string(remediationv1alpha1.PhaseDisabled):         "NHC is disabled: %s: %s",
+	statusReasonWaitingForCRD:                         "NHC is waiting for a remediation CRD: %s",
+	string(remediationv1alpha1.PhasePaused):           "NHC is paused: %s",
+	string(remediationv1alpha1.PhaseDryRun):           "NHC is in dry run mode, would remediate %d nodes",
+	statusReasonDryRunNodes:                           "nodes which would be remediated: %s",
+	string(remediationv1alpha1.PhaseWaitingForWindow): "NHC is waiting for the next remediation window at %s",
+	statusReasonWindowDeferredNodes:                   "deferred nodes: %s",
+	string(remediationv1alpha1.PhaseRemediating):      "NHC is remediating %d nodes",
+	statusReasonInsufficientCapacity:                  "nodes with insufficient capacity: %s",
+	statusReasonHighUtilization:                       "nodes with high utilization: %s",
+	statusReasonMaxUnhealthyReached:                   "MaxUnhealthy is reached, deferred nodes: %s",
+	string(remediationv1alpha1.PhaseEnabled):          "NHC is enabled, no ongoing remediation",
+}
+
+// updatePhaseAndReason sets the phase and the reason of the status, based on its conditions and remediations
+func updatePhaseAndReason(nhc *remediationv1alpha1.NodeHealthCheck) {
+	var fragments []string
+	fragment := func(code string, args ...interface{}) {
+		fragments = append(fragments, fmt.Sprintf(statusReasonFragments[code], args...))
+	}
+
+	disabledCondition := meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled)
+	if disabledCondition != nil && disabledCondition.Status == metav1.ConditionTrue {
+		nhc.Status.Phase = remediationv1alpha1.PhaseDisabled
+		fragment(string(remediationv1alpha1.PhaseDisabled), disabledCondition.Reason, disabledCondition.Message)
+	} else if waitingCondition := meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeWaitingForCRD); waitingCondition != nil && waitingCondition.Status == metav1.ConditionTrue {
+		nhc.Status.Phase = remediationv1alpha1.PhaseEnabled
+		fragment(statusReasonWaitingForCRD, waitingCondition.Message)
+	} else if len(nhc.Spec.PauseRequests) > 0 {
+		nhc.Status.Phase = remediationv1alpha1.PhasePaused
+		fragment(string(remediationv1alpha1.PhasePaused), strings.Join(nhc.Spec.PauseRequests, ","))
+	} else if nhc.Spec.DryRun {
+		nhc.Status.Phase = remediationv1alpha1.PhaseDryRun
+		var dryRunNodes []string
+		for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+			if unhealthyNode.Deferral != nil && unhealthyNode.Deferral.Reason == remediationv1alpha1.DeferralReasonDryRun {
+				dryRunNodes = append(dryRunNodes, fmt.Sprintf("%s (%s)", unhealthyNode.Name, describeUnhealthiness(unhealthyNode)))
+			}
+		}
+		fragment(string(remediationv1alpha1.PhaseDryRun), len(dryRunNodes))
+		if len(dryRunNodes) > 0 {
+			fragment(statusReasonDryRunNodes, formatStatusReasonNodes(dryRunNodes))
 		}
-		resources.UpdateStatusNodeHealthy(nodeName, nhc)
-
-		if deleted, err := rm.DeleteRemediationCR(&cr, nhc); err != nil {
-			log.Error(err, "failed to delete remediation CR", "name", cr.GetName())
-			return err
-		} else if deleted {
-			permanentNodeDeletionExpectedCondition := getCondition(&cr, commonconditions.PermanentNodeDeletionExpectedType, log)
-			log.Info("deleted orphaned remediation CR", "name", cr.GetName(),
-				"reason", permanentNodeDeletionExpectedCondition.Reason,
-				"message", permanentNodeDeletionExpectedCondition.Message)
+	} else if inWindow, nextWindowStart := utils.GetRemediationWindowState(nhc.Spec.RemediationWindows, currentTime()); !inWindow {
+		nhc.Status.Phase = remediationv1alpha1.PhaseWaitingForWindow
+		fragment(string(remediationv1alpha1.PhaseWaitingForWindow), nextWindowStart.UTC().Format(time.RFC3339))
+		var deferredNodes []string
+		for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+			if unhealthyNode.Deferral != nil && unhealthyNode.Deferral.Reason == remediationv1alpha1.DeferralReasonOutsideRemediationWindow {
+				deferredNodes = append(deferredNodes, unhealthyNode.Name)
+			}
+		}
+		if len(deferredNodes) > 0 {
+			fragment(statusReasonWindowDeferredNodes, formatStatusReasonNodes(deferredNodes))
 		}
+	} else if inFlightRemediations := nhc.Status.GetInFlightRemediations(); len(inFlightRemediations) > 0 {
+		nhc.Status.Phase = remediationv1alpha1.PhaseRemediating
+		fragment(string(remediationv1alpha1.PhaseRemediating), len(inFlightRemediations))
+		// make clear that remediation wasn't triggered by a node condition
+		var lowCapacityNodes, highUtilizationNodes []string
+		for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+			if unhealthyNode.MatchedCapacity != nil {
+				lowCapacityNodes = append(lowCapacityNodes, fmt.Sprintf("%s (%s)", unhealthyNode.Name, unhealthyNode.MatchedCapacity.ResourceName))
+			}
+			if unhealthyNode.MatchedUtilization != nil {
+				highUtilizationNodes = append(highUtilizationNodes, fmt.Sprintf("%s (%s)", unhealthyNode.Name, unhealthyNode.MatchedUtilization.ResourceName))
+			}
+		}
+		if len(lowCapacityNodes) > 0 {
+			fragment(statusReasonInsufficientCapacity, formatStatusReasonNodes(lowCapacityNodes))
+		}
+		if len(highUtilizationNodes) > 0 {
+			fragment(statusReasonHighUtilization, formatStatusReasonNodes(highUtilizationNodes))
+		}
+	} else {
+		nhc.Status.Phase = remediationv1alpha1.PhaseEnabled
+		fragment(string(remediationv1alpha1.PhaseEnabled))
+	}
+	// make clear when MaxUnhealthy is the limiting factor, instead of MinHealthy
+	if nhc.Status.Phase == remediationv1alpha1.PhaseRemediating || nhc.Status.Phase == remediationv1alpha1.PhaseEnabled {
+		var maxUnhealthyNodes []string
+		for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+			if unhealthyNode.Deferral != nil && unhealthyNode.Deferral.Reason == remediationv1alpha1.DeferralReasonMaxUnhealthyReached {
+				maxUnhealthyNodes = append(maxUnhealthyNodes, unhealthyNode.Name)
+			}
+		}
+		if len(maxUnhealthyNodes) > 0 {
+			fragment(statusReasonMaxUnhealthyReached, formatStatusReasonNodes(maxUnhealthyNodes))
+		}
+	}
+	nhc.Status.Reason = truncateStatusReason(strings.Join(fragments, ", "))
+}
 
+// describeUnhealthiness returns why the given node is unhealthy, for the status reason
+func describeUnhealthiness(unhealthyNode *remediationv1alpha1.UnhealthyNode) string {
+	switch {
+	case unhealthyNode.MatchedCondition != nil:
+		return fmt.Sprintf("%s=%s", unhealthyNode.MatchedCondition.Type, unhealthyNode.MatchedCondition.Status)
+	case unhealthyNode.MatchedCapacity != nil:
+		return fmt.Sprintf("insufficient %s capacity", unhealthyNode.MatchedCapacity.ResourceName)
+	case unhealthyNode.MatchedTaint != nil:
+		return fmt.Sprintf("taint %s:%s", unhealthyNode.MatchedTaint.Key, unhealthyNode.MatchedTaint.Effect)
+	case unhealthyNode.MatchedAnnotation != nil:
+		return fmt.Sprintf("annotation %s", unhealthyNode.MatchedAnnotation.Key)
+	case unhealthyNode.MatchedUtilization != nil:
+		return fmt.Sprintf("high %s utilization", unhealthyNode.MatchedUtilization.ResourceName)
+	case unhealthyNode.UnreachableSince != nil:
+		return "unreachable"
+	case unhealthyNode.HeartbeatStaleSince != nil:
+		return "stale heartbeat"
+	default:
+		return "unhealthy"
 	}
-	return nil
 }
 
-func (r *NodeHealthCheckReconciler) remediate(ctx context.Context, node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) (*time.Duration, error) {
+// formatStatusReasonNodes returns the sorted list of the given nodes, limited to maxStatusReasonNodes
+func formatStatusReasonNodes(nodes []string) string {
+	sort.Strings(nodes)
+	if len(nodes) <= maxStatusReasonNodes {
+		return strings.Join(nodes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(nodes[:maxStatusReasonNodes], ", "), len(nodes)-maxStatusReasonNodes)
+}
 
-	log := utils.GetLogWithNHC(r.Log, nhc)
+// truncateStatusReason truncates the given reason to maxStatusReasonLength bytes, without splitting runes
+func truncateStatusReason(reason string) string {
+	if len(reason) <= maxStatusReasonLength {
+		return reason
+	}
+	end := maxStatusReasonLength - len(statusReasonTruncated)
+	for end > 0 && !utf8.RuneStart(reason[end]) {
+		end--
+	}
+	return reason[:end] + statusReasonTruncated
+}
 
-	// prevent remediation of more than 1 control plane node at a time!
-	isControlPlaneNode := nodes.IsControlPlane(node)
-	if isControlPlaneNode {
-		if isAllowed, err := r.isControlPlaneRemediationAllowed(ctx, node, nhc, rm); err != nil {
-			return nil, errors.Wrapf(err, "failed to check if control plane remediation is allowed")
-		} else if !isAllowed {
-			log.Info("skipping remediation for preventing control plane / etcd quorum loss, going to retry in a minute", "node", node.GetName())
-			commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonRemediationSkipped, "Skipping remediation of %s for preventing control plane / etcd quorum loss, going to retry in a minute", node.GetName())
-			return pointer.Duration(1 * time.Minute), nil
+// updateProgressingCondition sets the Progressing condition, which is true while nodes are drained, remediated or
+// verified, or while remediation CRs are deleted. The reason is the activity with the most nodes, the message has
+// the counts of all activities.
+func updateProgressingCondition(nhc *remediationv1alpha1.NodeHealthCheck) {
+	counts := map[string]int{}
+	counted := map[string]bool{}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		switch {
+		case unhealthyNode.Drain != nil && unhealthyNode.Drain.Phase == remediationv1alpha1.DrainPhaseDraining:
+			counts[remediationv1alpha1.ConditionReasonDraining]++
+		case unhealthyNode.Verification != nil && unhealthyNode.Verification.Phase == remediationv1alpha1.VerificationPhaseVerifying:
+			counts[remediationv1alpha1.ConditionReasonVerifying]++
+		default:
+			continue
 		}
+		counted[unhealthyNode.Name] = true
 	}
-	// generate remediation CR
-	currentTemplate, timeout, err := rm.GetCurrentTemplateWithTimeout(node, nhc)
-	if err != nil {
-		if _, ok := err.(resources.NoTemplateLeftError); ok {
-			log.Error(err, "Remediation timed out, and no template left to try")
-			commonevents.WarningEventf(r.Recorder, nhc, eventReasonNoTemplateLeft, "Remediation timed out, and no template left to try. %s", err.Error())
-			// there is nothing we can do about this
-			return nil, nil
+	// consistent with the Remediating phase
+	for nodeName := range nhc.Status.GetInFlightRemediations() {
+		if !counted[nodeName] {
+			counts[remediationv1alpha1.ConditionReasonRemediating]++
 		}
-		return nil, errors.Wrapf(err, "failed to get current template")
 	}
-	generatedRemediationCR, err := rm.GenerateRemediationCRForNode(node, nhc, currentTemplate)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to generate remediation CR")
+	counts[remediationv1alpha1.ConditionReasonDeleting] = len(nhc.Status.OrphanedRemediations)
+
+	// in order of precedence on equal counts
+	activities := []struct{ reason, description string }{
+		{remediationv1alpha1.ConditionReasonRemediating, "remediating %d nodes"},
+		{remediationv1alpha1.ConditionReasonDraining, "draining %d nodes"},
+		{remediationv1alpha1.ConditionReasonVerifying, "verifying %d nodes"},
+		{remediationv1alpha1.ConditionReasonDeleting, "deleting %d remediation CRs"},
+	}
+	reason := ""
+	var descriptions []string
+	for _, activity := range activities {
+		count := counts[activity.reason]
+		if count == 0 {
+			continue
+		}
+		if reason == "" || count > counts[reason] {
+			reason = activity.reason
+		}
+		descriptions = append(descriptions, fmt.Sprintf(activity.description, count))
 	}
 
-	if isControlPlaneNode {
-		labels := generatedRemediationCR.GetLabels()
-		labels[RemediationControlPlaneLabelKey] = ""
-		generatedRemediationCR.SetLabels(labels)
+	if reason == "" {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:               remediationv1alpha1.ConditionTypeProgressing,
+			Status:             metav1.ConditionFalse,
+			Reason:             remediationv1alpha1.ConditionReasonIdle,
+			Message:            "No ongoing remediation",
+			ObservedGeneration: nhc.GetGeneration(),
+		})
+		return
 	}
+	message := strings.Join(descriptions, ", ")
+	meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+		Type:               remediationv1alpha1.ConditionTypeProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            strings.ToUpper(message[:1]) + message[1:],
+		ObservedGeneration: nhc.GetGeneration(),
+	})
+}
 
-	currentRemediationDuration, previousRemediationsDuration := utils.GetRemediationDuration(nhc, generatedRemediationCR)
+// checkEscalationHandshake checks if the remediator acknowledged the timeout of the given remediation, or if the
+// handshake's grace period expired. It returns a requeue duration as long as escalation needs to wait.
+func (r *NodeHealthCheckReconciler) checkEscalationHandshake(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, remediation *remediationv1alpha1.Remediation, rm resources.Manager, log logr.Logger) (*time.Duration, error) {
+	remediationCRs, err := rm.ListRemediationCRs(utils.GetAllRemediationTemplates(nhc), func(cr unstructured.Unstructured) bool {
+		return cr.GetName() == remediation.Resource.Name && cr.GroupVersionKind() == remediation.Resource.GroupVersionKind()
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remediation CR for checking timeout acknowledgment")
+	}
 
-	// create remediation CR
-	created, leaseRequeueIn, remediationCR, err := rm.CreateRemediationCR(generatedRemediationCR, nhc, &node.Name, currentRemediationDuration, previousRemediationsDuration)
+	// a deleted CR doesn't need to acknowledge
+	if len(remediationCRs) == 0 || annotations.HasNhcTimedOutAcknowledgedAnnotation(&remediationCRs[0]) {
+		log.Info("remediator acknowledged timeout", "node", nodeName, "kind", remediation.Resource.Kind)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonTimeoutAcknowledged, "Remediator acknowledged timeout of %s remediation for node %s", remediation.Resource.Kind, nodeName)
+		remediation.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+		return nil, nil
+	}
 
-	if err != nil {
-		// An unhealthy node exists, but remediation couldn't be created because lease wasn't obtained
-		if _, isLeaseAlreadyTaken := err.(lease.AlreadyHeldError); isLeaseAlreadyTaken {
-			return leaseRequeueIn, nil
-		}
+	now := currentTime()
+	gracePeriodEnd := remediation.TimedOut.Add(utils.GetEscalationHandshakeGracePeriod(nhc))
+	if now.Before(gracePeriodEnd) {
+		// not acknowledged yet, come back when the grace period expires, CR updates trigger a reconcile earlier
+		return pointer.Duration(gracePeriodEnd.Sub(now) + 1*time.Second), nil
+	}
 
-		// Lease is overdue
-		if _, isLeaseOverDue := err.(resources.LeaseOverDueError); isLeaseOverDue {
-			now := currentTime()
-			if timeOutErr := r.addTimeOutAnnotation(rm, remediationCR, metav1.Time{Time: now}); timeOutErr != nil {
-				return nil, timeOutErr
-			}
-			startedRemediation := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
-				return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind()
-			})
+	log.Info("remediator didn't acknowledge timeout within grace period", "node", nodeName, "kind", remediation.Resource.Kind)
+	commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonHandshakeExpired, "Remediator didn't acknowledge timeout of %s remediation for node %s within grace period, escalating anyway", remediation.Resource.Kind, nodeName)
+	remediation.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+	return nil, nil
+}
 
-			if startedRemediation == nil {
-				// should not have happened, seems last status update failed
-				return nil, errors.New("failed to find started remediation in status for handling overdue lease")
-			}
+// recordRemediationOnNode sets the RemediatedByNHC condition of the given healthy node to True, if RecordRemediationOnNode
+// is enabled and the node was remediated successfully.
+func (r *NodeHealthCheckReconciler) recordRemediationOnNode(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, log logr.Logger) error {
+	if !nhc.Spec.RecordRemediationOnNode || resources.IsStatusRemediationExhausted(node.GetName(), nhc) {
+		return nil
+	}
+	remediation := resources.GetStatusLastRemediation(node.GetName(), nhc)
+	if remediation == nil {
+		return nil
+	}
+	log.Info("recording remediation on node", "node", node.GetName(), "kind", remediation.Resource.Kind)
+	return patchRemediatedByNHCCondition(ctx, c, node, v1.ConditionTrue, remediationv1alpha1.NodeConditionReasonRemediationSucceeded,
+		fmt.Sprintf("Node was remediated by NodeHealthCheck %s with %s remediation", nhc.GetName(), remediation.Resource.Kind))
+}
 
-			// update status (important to do this after CR update, else we won't retry that update in case of error)
-			startedRemediation.TimedOut = &metav1.Time{Time: now}
-			return nil, nil
-		}
+// expireRemediationRecord sets the RemediatedByNHC condition of the given node to False, when it is True for longer than
+// the RemediationRecordRetention. Otherwise it returns when the retention expires.
+func (r *NodeHealthCheckReconciler) expireRemediationRecord(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, log logr.Logger) (*time.Duration, error) {
+	condition := getRemediatedByNHCCondition(node)
+	if condition == nil || condition.Status != v1.ConditionTrue {
+		return nil, nil
+	}
+	expiresAt := condition.LastTransitionTime.Add(utils.GetRemediationRecordRetention(nhc))
+	if now := currentTime(); now.Before(expiresAt) {
+		return pointer.Duration(expiresAt.Sub(now) + 1*time.Second), nil
+	}
+	log.Info("remediation record on node expired", "node", node.GetName())
+	return nil, patchRemediatedByNHCCondition(ctx, c, node, v1.ConditionFalse, remediationv1alpha1.NodeConditionReasonRetentionExpired,
+		fmt.Sprintf("Remediation record of NodeHealthCheck %s expired", nhc.GetName()))
+}
 
-		if _, ok := err.(resources.RemediationCRNotOwned); ok {
-			// CR exists but not owned by us, nothing to do
-			return nil, nil
+// getRemediatedByNHCCondition returns the RemediatedByNHC condition of the given node, or nil
+func getRemediatedByNHCCondition(node *v1.Node) *v1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == remediationv1alpha1.NodeConditionTypeRemediatedByNHC {
+			return &node.Status.Conditions[i]
 		}
-		return nil, errors.Wrapf(err, "failed to create remediation CR")
 	}
+	return nil
+}
 
-	// always update status, in case patching it failed during last reconcile
-	resources.UpdateStatusRemediationStarted(node, nhc, remediationCR)
-
-	// ensure to provide correct metrics in case the CR existed already after a pod restart
-	metrics.ObserveNodeHealthCheckRemediationCreated(node.GetName(), remediationCR.GetNamespace(), remediationCR.GetKind())
+// patchRemediatedByNHCCondition sets the RemediatedByNHC condition of the given node, if its status changes. A missing
+// condition isn't added with status False. The node status is patched with a strategic merge patch, which only contains
+// this condition, so that concurrent updates of other conditions by the kubelet aren't overwritten.
+func patchRemediatedByNHCCondition(ctx context.Context, c client.Client, node *v1.Node, status v1.ConditionStatus, reason, message string) error {
+	existing := getRemediatedByNHCCondition(node)
+	if existing == nil && status == v1.ConditionFalse {
+		return nil
+	}
+	if existing != nil && existing.Status == status {
+		return nil
+	}
 
-	if created {
-		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonRemediationCreated, "Created remediation object for node %s", node.Name)
-		var requeueIn *time.Duration
-		if timeout != nil {
-			// come back when timeout expires
-			requeueIn = pointer.Duration(*timeout + 1*time.Second)
+	now := metav1.Time{Time: currentTime()}
+	condition := v1.NodeCondition{
+		Type:               remediationv1alpha1.NodeConditionTypeRemediatedByNHC,
+		Status:             status,
+		LastHeartbeatTime:  now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+	patched := node.DeepCopy()
+	if existing := getRemediatedByNHCCondition(patched); existing != nil {
+		*existing = condition
+	} else {
+		patched.Status.Conditions = append(patched.Status.Conditions, condition)
+	}
+	if err := c.Status().Patch(ctx, patched, client.StrategicMergeFrom(node)); err != nil {
+		if apierrors.IsNotFound(err) {
+			// node is gone, nothing to record
+			return nil
 		}
-		return utils.MinRequeueDuration(leaseRequeueIn, requeueIn), nil
+		return errors.Wrapf(err, "failed to patch %s condition of node %s", remediationv1alpha1.NodeConditionTypeRemediatedByNHC, node.GetName())
 	}
-	// CR already exists, check for timeout in case we need to
-	if timeout == nil {
-		// no timeout set for classic remediation
-		// nothing to do anymore here
-		return leaseRequeueIn, nil
+	node.Status.Conditions = patched.Status.Conditions
+	return nil
+}
+
+// emitRemediationCompletedEvent emits the completed event for the last remediation of the given node, if any
+func (r *NodeHealthCheckReconciler) emitRemediationCompletedEvent(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName && len(unhealthyNode.Remediations) > 0 {
+			r.emitRemediationEvent(eventsink.EventTypeRemediationCompleted, nhc, nodeName, unhealthyNode.Remediations[len(unhealthyNode.Remediations)-1])
+			return
+		}
 	}
+}
 
-	startedRemediation := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
-		return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind()
+func (r *NodeHealthCheckReconciler) emitRemediationEvent(eventType string, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, remediation *remediationv1alpha1.Remediation) {
+	r.EventEmitter.Emit(eventType, eventsink.RemediationEventData{
+		NodeHealthCheck: nhc.GetName(),
+		Node:            nodeName,
+		Remediation:     remediation.Resource,
+		TemplateKind:    remediation.Resource.Kind + "Template",
+		TemplateName:    remediation.TemplateName,
+		Started:         remediation.Started.Time,
 	})
+}
 
-	if startedRemediation == nil {
-		// should not have happened, seems last status update failed
-		return nil, errors.New("failed to find started remediation in status for handling timeout")
+// observeInfo observes the effective configuration of the given NHC
+func observeInfo(nhc *remediationv1alpha1.NodeHealthCheck) {
+	strategy := "classic"
+	if len(nhc.Spec.EscalatingRemediations) > 0 {
+		strategy = "escalating"
 	}
-
-	if startedRemediation.TimedOut != nil {
-		// timeout handled already: should not have happened, but ok. Just reconcile again asap for trying the next template
-		return nil, errors.New("unexpected timout found on started remediation in status")
+	minHealthy := ""
+	if nhc.Spec.MinHealthy != nil {
+		minHealthy = nhc.Spec.MinHealthy.String()
 	}
+	// report only NHCs don't have any unhealthy condition which triggers remediation
+	reportOnly := len(nhc.Spec.UnhealthyConditions) > 0
+	for _, condition := range nhc.Spec.UnhealthyConditions {
+		if utils.IsRemediationEnabled(condition) {
+			reportOnly = false
+			break
+		}
+	}
+	metrics.ObserveNodeHealthCheckInfo(nhc.GetName(), strategy, minHealthy, len(nhc.Spec.PauseRequests) > 0, reportOnly)
+}
 
-	now := metav1.Time{Time: currentTime()}
-	timeoutAt := getTimeoutAt(startedRemediation, timeout)
-	timedOut := now.After(timeoutAt)
-
-	failed := remediationFailed(remediationCR, log)
+// observeNodeCounts observes the number of observed, healthy, unhealthy and remediated nodes of the given NHC, as
+// reported in its status
+func observeNodeCounts(nhc *remediationv1alpha1.NodeHealthCheck) {
+	metrics.ObserveNodeHealthCheckNodes(nhc.GetName(), pointer.IntDeref(nhc.Status.ObservedNodes, 0), pointer.IntDeref(nhc.Status.HealthyNodes, 0))
+	metrics.ObserveNodeHealthCheckRemediationsInFlight(nhc.GetName(), resources.CountStatusRemediatedNodes(nhc))
+}
 
-	if !timedOut && !failed {
-		// not timed out yet, come back when we do so
-		return utils.MinRequeueDuration(leaseRequeueIn, pointer.Duration(timeoutAt.Sub(now.Time))), nil
+// observePhaseDurations observes the cumulative time the given NHC spent in each phase until now
+func observePhaseDurations(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	durations := make(map[string]time.Duration)
+	for phase, duration := range resources.GetPhaseDurations(nhc, now) {
+		durations[string(phase)] = duration
 	}
+	metrics.ObserveNodeHealthCheckPhaseDurations(nhc.GetName(), durations)
+}
 
-	// handle timeout and failure
-	if timedOut {
-		log.Info("remediation timed out")
-	} else if failed {
-		log.Info("remediation failed")
+// observeRemediationSucceeded observes the succeeded outcome for the latest remediation of the given healthy node.
+// Remediations which timed out, failed, or were given up, are skipped, their outcome was observed already.
+func (r *NodeHealthCheckReconciler) observeRemediationSucceeded(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != nodeName {
+			continue
+		}
+		if unhealthyNode.RemediationExhausted != nil {
+			return
+		}
+		for i := len(unhealthyNode.Remediations) - 1; i >= 0; i-- {
+			remediation := unhealthyNode.Remediations[i]
+			if remediation.Phase == remediationv1alpha1.RemediationPhaseNotApplicable {
+				// no remediation CR was created
+				continue
+			}
+			if remediation.TimedOut == nil && remediation.Phase != remediationv1alpha1.RemediationPhaseAborted {
+				r.observeRemediationOutcome(nhc, nodeName, remediation, metrics.RemediationOutcomeSucceeded)
+			}
+			return
+		}
+		return
 	}
+}
 
-	// add timeout annotation to remediation CR
-	if err := r.addTimeOutAnnotation(rm, remediationCR, now); err != nil {
-		return nil, err
+// observeRemediationOutcome observes the outcome of the given remediation of the given node, and records it in the
+// remediation history and for the RemediatorCircuitBreaker
+func (r *NodeHealthCheckReconciler) observeRemediationOutcome(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, remediation *remediationv1alpha1.Remediation, outcome string) {
+	metrics.ObserveNodeHealthCheckRemediationOutcome(remediation.Resource.Kind+"Template", remediation.TemplateName, outcome)
+	if outcome == metrics.RemediationOutcomeTimedOut {
+		metrics.ObserveNodeHealthCheckRemediationTimedOut(nhc.GetName(), remediation.Resource.Kind)
+	}
+	switch outcome {
+	case metrics.RemediationOutcomeSucceeded:
+		resources.AppendStatusRemediationHistory(nodeName, nhc, remediation, remediationv1alpha1.RemediationOutcomeSucceeded, currentTime())
+	case metrics.RemediationOutcomeFailed:
+		resources.AppendStatusRemediationHistory(nodeName, nhc, remediation, remediationv1alpha1.RemediationOutcomeFailed, currentTime())
+	default:
+		resources.AppendStatusRemediationHistory(nodeName, nhc, remediation, remediationv1alpha1.RemediationOutcomeTimedOut, currentTime())
+	}
+	if outcome == metrics.RemediationOutcomeExhausted {
+		// giving up is decided by NHC, it doesn't tell anything about the remediator
+		return
+	}
+	kind := remediation.Resource.Kind
+	circuit := resources.RecordStatusRemediatorOutcome(nhc, kind, nodeName, outcome == metrics.RemediationOutcomeSucceeded, currentTime())
+	if circuit == nil {
+		return
+	}
+	if circuit.State == remediationv1alpha1.CircuitStateOpen {
+		r.Log.Info("opened remediator circuit", "NHC", nhc.GetName(), "kind", kind, "consecutiveFailures", circuit.ConsecutiveFailures)
+		commonevents.WarningEventf(r.Recorder, nhc, utils.EventReasonCircuitOpened, "Opened the circuit of %s remediations, because they timed out or failed in a row, skipping them for %s", kind, utils.GetCircuitOpenDuration(nhc))
+	} else {
+		r.Log.Info("closed remediator circuit", "NHC", nhc.GetName(), "kind", kind)
+		commonevents.NormalEventf(r.Recorder, nhc, utils.EventReasonCircuitClosed, "Closed the circuit of %s remediations, because the remediation of node %s succeeded", kind, nodeName)
 	}
-	// update status (important to do this after CR update, else we won't retry that update in case of error)
-	startedRemediation.TimedOut = &now
-
-	// try next remediation asap
-	return pointer.Duration(1 * time.Second), nil
 }
 
-func (r *NodeHealthCheckReconciler) addTimeOutAnnotation(rm resources.Manager, remediationCR *unstructured.Unstructured, now metav1.Time) error {
-	annotations := remediationCR.GetAnnotations()
-	if annotations == nil {
-		annotations = make(map[string]string, 1)
+// addTimeOutAnnotation adds the timed out annotation with the given reason, and the timed out step annotation, to the
+// given remediation CR, unless the timed out annotation exists already
+func (r *NodeHealthCheckReconciler) addTimeOutAnnotation(rm resources.Manager, nhc *remediationv1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured, now metav1.Time, reason remediationv1alpha1.TimedOutReason) error {
+	if _, exists := remediationCR.GetAnnotations()[remediationv1alpha1.NhcTimedOutAnnotation]; exists {
+		return nil
 	}
-	annotations[commonannotations.NhcTimedOut] = now.Format(time.RFC3339)
-	remediationCR.SetAnnotations(annotations)
-	if err := rm.UpdateRemediationCR(remediationCR); err != nil {
+	if _, err := rm.UpdateRemediationCR(remediationCR, func() bool {
+		crAnnotations := remediationCR.GetAnnotations()
+		if _, exists := crAnnotations[remediationv1alpha1.NhcTimedOutAnnotation]; exists {
+			return false
+		}
+		if crAnnotations == nil {
+			crAnnotations = make(map[string]string, 2)
+		}
+		if r.PlainTimedOutAnnotation {
+			crAnnotations[remediationv1alpha1.NhcTimedOutAnnotation] = now.Format(time.RFC3339)
+		} else {
+			crAnnotations[remediationv1alpha1.NhcTimedOutAnnotation] = remediationv1alpha1.FormatNhcTimedOutAnnotation(now.Time, reason)
+		}
+		if step := getTimedOutStep(nhc, remediationCR); step != "" {
+			crAnnotations[remediationv1alpha1.NhcTimedOutStepAnnotation] = step
+		}
+		remediationCR.SetAnnotations(crAnnotations)
+		return true
+	}); err != nil {
 		return errors.Wrapf(err, "failed to update remediation CR with timeout annotation")
 	}
 	return nil
 }
 
+// getTimedOutStep returns the value of the timed out step annotation for the given remediation CR: the order of its
+// escalating remediation, and its remediation attempt
+func getTimedOutStep(nhc *remediationv1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured) string {
+	var order, attempt *int
+	escRem := resources.FindEscalatingRemediation(nhc, &remediationv1alpha1.Remediation{
+		Resource:     v1.ObjectReference{Kind: remediationCR.GetKind()},
+		TemplateName: remediationCR.GetAnnotations()[annotations.TemplateNameAnnotation],
+	})
+	if escRem != nil {
+		order = &escRem.Order
+	}
+	if value, err := strconv.Atoi(remediationCR.GetAnnotations()[annotations.RemediationAttemptAnnotation]); err == nil {
+		attempt = &value
+	}
+	return remediationv1alpha1.FormatNhcTimedOutStepAnnotation(order, attempt)
+}
+
 func (r *NodeHealthCheckReconciler) isControlPlaneRemediationAllowed(ctx context.Context, node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) (bool, error) {
 	if !nodes.IsControlPlane(node) {
 		return true, fmt.Errorf("%s isn't a control plane node", node.GetName())
@@ -682,10 +3644,10 @@ func (r *NodeHealthCheckReconciler) isControlPlaneRemediationAllowed(ctx context
 	}
 	// if there is a control plane remediation CR for this node already, we can continue with the remediation process
 	for _, cr := range controlPlaneRemediationCRs {
-		if cr.GetName() == node.GetName() {
+		if resources.GetNodeName(cr) == node.GetName() {
 			return true, nil
 		}
-		r.Log.Info("ongoing control plane remediation", "node", cr.GetName())
+		utils.GetLogWithNHC(r.Log, nhc).Info("ongoing control plane remediation", "node", resources.GetNodeName(cr))
 	}
 	// if there is a control plane remediation CR for another cp node, don't start remediation for this node
 	if len(controlPlaneRemediationCRs) > 0 {
@@ -693,8 +3655,8 @@ func (r *NodeHealthCheckReconciler) isControlPlaneRemediationAllowed(ctx context
 	}
 
 	// no ongoing control plane remediation, check etcd quorum
-	if !r.OnOpenShift {
-		// etcd quorum PDB is only installed in OpenShift
+	if !r.OnOpenShift || nhc.Spec.RemoteCluster != nil {
+		// etcd quorum PDB is only installed in OpenShift, and only checked on the local cluster
 		return true, nil
 	}
 	var allowed bool
@@ -704,26 +3666,103 @@ func (r *NodeHealthCheckReconciler) isControlPlaneRemediationAllowed(ctx context
 	return allowed, nil
 }
 
+// updateLastError records the given reconcile error in the status, or removes the last error if there is none
+func updateLastError(nhc *remediationv1alpha1.NodeHealthCheck, reconcileErr error) {
+	if reconcileErr == nil {
+		nhc.Status.LastError = nil
+		return
+	}
+	if nhc.Status.LastError != nil && nhc.Status.LastError.Message == reconcileErr.Error() {
+		// keep the time since when the error occurs
+		return
+	}
+	nhc.Status.LastError = &remediationv1alpha1.LastError{
+		Message: reconcileErr.Error(),
+		Since:   metav1.Time{Time: currentTime()},
+	}
+}
+
+// updateLastReconcileTime refreshes the LastReconcileTime, when it is older than the LastReconcileTimeInterval
+func (r *NodeHealthCheckReconciler) updateLastReconcileTime(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if r.LastReconcileTimeInterval == 0 {
+		nhc.Status.LastReconcileTime = nil
+		return
+	}
+	if last := nhc.Status.LastReconcileTime; last != nil && now.Sub(last.Time) < r.LastReconcileTimeInterval {
+		return
+	}
+	nhc.Status.LastReconcileTime = &metav1.Time{Time: now}
+}
+
+// updateNextRetryTime schedules the next evaluation of a disabled NHC, because the reason for disabling it can be
+// resolved without any event which NHC watches, e.g. by a RoleBinding in another namespace. The retry interval is
+// doubled on every due retry, up to a maximum, and is reset when the spec changes. Returns the time until the next
+// retry, or nil if the NHC isn't disabled.
+func (r *NodeHealthCheckReconciler) updateNextRetryTime(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) *time.Duration {
+	if !meta.IsStatusConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled) {
+		r.disabledRetries.Delete(nhc.GetName())
+		nhc.Status.NextRetryTime = nil
+		return nil
+	}
+
+	retry := disabledRetry{generation: nhc.GetGeneration(), interval: disabledRetryMinRequeueAfter}
+	if value, exists := r.disabledRetries.Load(nhc.GetName()); exists {
+		if previous := value.(disabledRetry); previous.generation == nhc.GetGeneration() {
+			if now.Before(previous.next) {
+				// reconciled by an event before the retry was due, keep the schedule
+				requeueAfter := previous.next.Sub(now)
+				return &requeueAfter
+			}
+			retry.interval = previous.interval * 2
+			if retry.interval > disabledRetryMaxRequeueAfter {
+				retry.interval = disabledRetryMaxRequeueAfter
+			}
+		}
+	}
+	retry.next = now.Add(retry.interval)
+	r.disabledRetries.Store(nhc.GetName(), retry)
+	nhc.Status.NextRetryTime = &metav1.Time{Time: retry.next}
+	return &retry.interval
+}
+
 func (r *NodeHealthCheckReconciler) patchStatus(ctx context.Context, log logr.Logger, nhc, nhcOrig *remediationv1alpha1.NodeHealthCheck) error {
 
+	updateRemediationExhaustedCondition(nhc)
+	updateCleanUpFailedCondition(nhc)
+	updateRemediatorDegradedCondition(nhc)
+	updateRemediatorUnavailableCondition(nhc)
+
+	// apply a lowered HistoryMaxEntries also without new records
+	resources.TrimStatusRemediationHistory(nhc)
+
 	// calculate phase and reason
-	disabledCondition := meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled)
-	if disabledCondition != nil && disabledCondition.Status == metav1.ConditionTrue {
-		nhc.Status.Phase = remediationv1alpha1.PhaseDisabled
-		nhc.Status.Reason = fmt.Sprintf("NHC is disabled: %s: %s", disabledCondition.Reason, disabledCondition.Message)
-	} else if len(nhc.Spec.PauseRequests) > 0 {
-		nhc.Status.Phase = remediationv1alpha1.PhasePaused
-		nhc.Status.Reason = fmt.Sprintf("NHC is paused: %s", strings.Join(nhc.Spec.PauseRequests, ","))
-	} else if len(nhc.Status.InFlightRemediations) > 0 {
-		nhc.Status.Phase = remediationv1alpha1.PhaseRemediating
-		nhc.Status.Reason = fmt.Sprintf("NHC is remediating %v nodes", len(nhc.Status.InFlightRemediations))
-	} else {
-		nhc.Status.Phase = remediationv1alpha1.PhaseEnabled
-		nhc.Status.Reason = "NHC is enabled, no ongoing remediation"
+	updatePhaseAndReason(nhc)
+
+	updateProgressingCondition(nhc)
+
+	// track the time spent in each phase
+	resources.UpdateStatusPhaseTransition(nhc, nhcOrig.Status.Phase, currentTime())
+	observePhaseDurations(nhc, currentTime())
+	metrics.ObserveNodeHealthCheckPhase(nhc.GetName(), string(nhc.Status.Phase))
+
+	// keep the deprecated field as long as it contains remediations which were not migrated yet
+	if r.DisableInFlightRemediationsStatus && len(resources.GetUnmigratedInFlightRemediations(nhc)) == 0 {
+		nhc.Status.InFlightRemediations = nil
 	}
 
 	mergeFrom := client.MergeFrom(nhcOrig)
 
+	// the reason is derived from the other status fields, don't write it when nothing else changed, e.g. when only
+	// its wording changed after an upgrade. Spec changes update the observed generation of the Progressing condition,
+	// so reasons which are derived from the spec are written as well.
+	if reason := nhc.Status.Reason; reason != nhcOrig.Status.Reason {
+		nhc.Status.Reason = nhcOrig.Status.Reason
+		if patchBytes, err := mergeFrom.Data(nhc); err == nil && string(patchBytes) == "{}" {
+			return nil
+		}
+		nhc.Status.Reason = reason
+	}
+
 	// check if there are any changes.
 	// reflect.DeepEqual does not work, it has many false positives!
 	if patchBytes, err := mergeFrom.Data(nhc); err != nil {
@@ -765,27 +3804,32 @@ func (r *NodeHealthCheckReconciler) patchStatus(ctx context.Context, log logr.Lo
 	return nil
 }
 
-func (r *NodeHealthCheckReconciler) alertOldRemediationCR(remediationCR *unstructured.Unstructured) (bool, *time.Duration) {
+func (r *NodeHealthCheckReconciler) alertOldRemediationCR(remediationCR *unstructured.Unstructured, rm resources.Manager) (bool, *time.Duration) {
 
 	isSendAlert := false
 	var nextReconcile *time.Duration = nil
 	//verify remediationCR is old
 	now := currentTime()
 	if currentTime().After(remediationCR.GetCreationTimestamp().Add(remediationCRAlertTimeout)) {
-		var remediationCrAnnotations map[string]string
-		if remediationCrAnnotations = remediationCR.GetAnnotations(); remediationCrAnnotations == nil {
-			remediationCrAnnotations = map[string]string{}
-		}
-		//verify this is the first alert for this remediationCR
-		if _, isAlertedSent := remediationCrAnnotations[oldRemediationCRAnnotationKey]; !isAlertedSent {
+		//verify this is the first alert for this remediationCR, also on retries
+		if _, isAlertedSent := remediationCR.GetAnnotations()[oldRemediationCRAnnotationKey]; isAlertedSent {
+			return false, nil
+		} else if updated, err := rm.UpdateRemediationCR(remediationCR, func() bool {
+			remediationCrAnnotations := remediationCR.GetAnnotations()
+			if _, isAlertedSent := remediationCrAnnotations[oldRemediationCRAnnotationKey]; isAlertedSent {
+				return false
+			}
+			if remediationCrAnnotations == nil {
+				remediationCrAnnotations = map[string]string{}
+			}
 			remediationCrAnnotations[oldRemediationCRAnnotationKey] = "flagon"
 			remediationCR.SetAnnotations(remediationCrAnnotations)
-			if err := r.Client.Update(context.TODO(), remediationCR); err == nil {
-				isSendAlert = true
-				r.Log.Info("old remediation, going to alert!")
-			} else {
-				r.Log.Error(err, "Setting `old remediationCR` annotation on remediation CR %s: failed to update: %v", remediationCR.GetName(), err)
-			}
+			return true
+		}); err != nil {
+			r.Log.Error(err, "Setting `old remediationCR` annotation on remediation CR %s: failed to update: %v", remediationCR.GetName(), err)
+		} else if updated {
+			isSendAlert = true
+			r.Log.Info("old remediation, going to alert!")
 		}
 	} else {
 		calcNextReconcile := remediationCRAlertTimeout - now.Sub(remediationCR.GetCreationTimestamp().Time) + time.Minute
@@ -795,6 +3839,15 @@ func (r *NodeHealthCheckReconciler) alertOldRemediationCR(remediationCR *unstruc
 
 }
 
+// getManagementCluster returns the management cluster of the Machines of the NHC's nodes, or nil if there is none.
+// NHCs of remote clusters never use the management cluster, because it belongs to the local cluster.
+func (r *NodeHealthCheckReconciler) getManagementCluster(nhc *remediationv1alpha1.NodeHealthCheck) *resources.ManagementCluster {
+	if r.ManagementCluster == nil || nhc.Spec.RemoteCluster != nil {
+		return nil
+	}
+	return r.ManagementCluster
+}
+
 func (r *NodeHealthCheckReconciler) addWatches(rm resources.Manager, nhc *remediationv1alpha1.NodeHealthCheck) error {
 
 	addWatches := func(ref v1.ObjectReference) error {
@@ -815,6 +3868,19 @@ func (r *NodeHealthCheckReconciler) addWatches(rm resources.Manager, nhc *remedi
 		if err := addWatches(*nhc.Spec.RemediationTemplate); err != nil {
 			return err
 		}
+	} else if len(nhc.Spec.RemediationTemplates) > 0 {
+		for _, ref := range nhc.Spec.RemediationTemplates {
+			if err := addWatches(ref); err != nil {
+				return err
+			}
+		}
+	} else if nhc.Spec.InlineRemediationTemplate != nil {
+		// there is no template CR to watch for inline templates
+		rem := rm.GenerateRemediationCRBase(utils.GetInlineRemediationTemplateRef(nhc).GroupVersionKind())
+		if err := r.addRemediationCRWatch(rem); err != nil {
+			r.Log.Error(err, "failed to add watch for remediation CR", "kind", rem.GetKind())
+			return err
+		}
 	} else {
 		for _, rem := range nhc.Spec.EscalatingRemediations {
 			if err := addWatches(rem.RemediationTemplate); err != nil {
@@ -823,6 +3889,15 @@ func (r *NodeHealthCheckReconciler) addWatches(rm resources.Manager, nhc *remedi
 		}
 	}
 
+	// verification CRs are owned by the NHC as well, so they can be handled like remediation CRs
+	if verification := nhc.Spec.PostRemediationVerification; verification != nil && verification.VerificationTemplate != (v1.ObjectReference{}) {
+		verificationCR := rm.GenerateRemediationCRBase(verification.VerificationTemplate.GroupVersionKind())
+		if err := r.addRemediationCRWatch(verificationCR); err != nil {
+			r.Log.Error(err, "failed to add watch for verification CR", "kind", verificationCR.GetKind())
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -923,6 +3998,14 @@ func getCondition(remediationCR *unstructured.Unstructured, conditionType string
 }
 
 // updateRequeueAfter updates the requeueAfter field of the result if newRequeueAfter is lower than the current value.
+// getPollingInterval returns the PollingInterval of the given NHC, or nil if it isn't configured
+func getPollingInterval(nhc *remediationv1alpha1.NodeHealthCheck) *time.Duration {
+	if nhc.Spec.PollingInterval == nil || nhc.Spec.PollingInterval.Duration <= 0 {
+		return nil
+	}
+	return &nhc.Spec.PollingInterval.Duration
+}
+
 func updateRequeueAfter(result *ctrl.Result, newRequeueAfter *time.Duration) {
 	if newRequeueAfter == nil {
 		return
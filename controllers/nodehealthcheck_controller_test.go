@@ -1,10 +1,20 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
 	"time"
+	"unicode/utf8"
 
 	commonannotations "github.com/medik8s/common/pkg/annotations"
 	commonconditions "github.com/medik8s/common/pkg/conditions"
@@ -12,27 +22,46 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	appsv1 "k8s.io/api/apps/v1"
 	coordv1 "k8s.io/api/coordination/v1"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 
 	"github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/connectivity"
+	"github.com/medik8s/node-healthcheck-operator/controllers/disruption"
+	"github.com/medik8s/node-healthcheck-operator/controllers/drain"
+	"github.com/medik8s/node-healthcheck-operator/controllers/eventsink"
+	"github.com/medik8s/node-healthcheck-operator/controllers/heartbeat"
+	"github.com/medik8s/node-healthcheck-operator/controllers/limiter"
+	"github.com/medik8s/node-healthcheck-operator/controllers/mhc"
+	"github.com/medik8s/node-healthcheck-operator/controllers/remote"
 	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utilization"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
+	"github.com/medik8s/node-healthcheck-operator/metrics"
 )
 
 const (
@@ -73,6 +102,7 @@ var _ = Describe("Node Health Check CR", func() {
 				Expect(underTest.Spec.UnhealthyConditions[1].Status).To(Equal(v1.ConditionUnknown))
 				Expect(underTest.Spec.UnhealthyConditions[1].Duration).To(Equal(metav1.Duration{Duration: time.Minute * 5}))
 				Expect(underTest.Spec.MinHealthy.StrVal).To(Equal(intstr.FromString("51%").StrVal))
+				Expect(underTest.Spec.HistoryMaxEntries).To(Equal(8))
 				Expect(underTest.Spec.Selector.MatchLabels).To(BeEmpty())
 				Expect(underTest.Spec.Selector.MatchExpressions).To(BeEmpty())
 			})
@@ -265,8 +295,29 @@ var _ = Describe("Node Health Check CR", func() {
 						}
 					})
 
-					It("should set corresponding condition", func() {
-						expectTemplateNotFound(Default, underTest, "no namespace is provided")
+					It("should default to the operator namespace", func() {
+						Expect(underTest.Status.DefaultTemplateNamespace).To(Equal(DeploymentNamespace))
+						// the template exists in another namespace only
+						expectTemplateNotFound(Default, underTest, fmt.Sprintf("failed to get external remediation template %s/", DeploymentNamespace))
+					})
+
+					Context("and the operator namespace is unknown", func() {
+						BeforeEach(func() {
+							Expect(os.Unsetenv("DEPLOYMENT_NAMESPACE")).To(Succeed())
+							DeferCleanup(os.Setenv, "DEPLOYMENT_NAMESPACE", DeploymentNamespace)
+						})
+
+						It("should set corresponding condition", func() {
+							Expect(underTest.Status.DefaultTemplateNamespace).To(BeEmpty())
+							Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseDisabled))
+							Expect(underTest.Status.Reason).To(ContainSubstring("the default namespace can't be determined"))
+							Expect(underTest.Status.Conditions).To(ContainElement(
+								And(
+									HaveField("Type", v1alpha1.ConditionTypeDisabled),
+									HaveField("Status", metav1.ConditionTrue),
+									HaveField("Reason", v1alpha1.ConditionReasonDisabledTemplateInvalid),
+								)))
+						})
 					})
 				})
 
@@ -321,6 +372,161 @@ var _ = Describe("Node Health Check CR", func() {
 				})
 			})
 
+			When("a node matches a critical unhealthy condition with zero duration", func() {
+				const runtimeUnhealthy v1.NodeConditionType = "ContainerRuntimeUnhealthy"
+				runtimeUnhealthyNodeName := "runtime-unhealthy-node"
+
+				BeforeEach(func() {
+					underTest.Spec.UnhealthyConditions = append(underTest.Spec.UnhealthyConditions, v1alpha1.UnhealthyCondition{
+						Type:     runtimeUnhealthy,
+						Status:   v1.ConditionTrue,
+						Duration: metav1.Duration{Duration: 0},
+					})
+					setupObjects(0, 2, false)
+
+					// the node is ready, but its container runtime just failed, with a transition time
+					// in the future because of clock skew
+					node := newNode(runtimeUnhealthyNodeName, v1.NodeReady, v1.ConditionTrue, false, false).(*v1.Node)
+					node.Status.Conditions = append(node.Status.Conditions, v1.NodeCondition{
+						Type:               runtimeUnhealthy,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: metav1.Time{Time: time.Now().Add(1 * time.Minute)},
+					})
+					objects = append(objects, node)
+				})
+
+				It("should remediate the node immediately", func() {
+					cr := newRemediationCRForNHC(runtimeUnhealthyNodeName, underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+					Expect(underTest.Status.UnhealthyNodes).To(ContainElement(HaveField("Name", runtimeUnhealthyNodeName)))
+				})
+			})
+
+			When("a node matches a report only unhealthy condition", func() {
+				const memoryPressure = v1.NodeMemoryPressure
+				reportOnlyNodeName := "memory-pressure-node"
+
+				BeforeEach(func() {
+					underTest.Spec.UnhealthyConditions = append(underTest.Spec.UnhealthyConditions, v1alpha1.UnhealthyCondition{
+						Type:               memoryPressure,
+						Status:             v1.ConditionTrue,
+						Duration:           metav1.Duration{Duration: 0},
+						RemediationEnabled: pointer.Bool(false),
+					})
+					setupObjects(1, 2, true)
+
+					node := newNode(reportOnlyNodeName, v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node)
+					node.Status.Conditions = append(node.Status.Conditions, v1.NodeCondition{
+						Type:               memoryPressure,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: metav1.Now(),
+					})
+					objects = append(objects, node)
+				})
+
+				It("should report the node without remediating it", func() {
+					// the node matching a remediation enabled condition is remediated
+					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					cr = newRemediationCRForNHC(reportOnlyNodeName, underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					Expect(*underTest.Status.HealthyNodes).To(Equal(3))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].Name).To(Equal(unhealthyNodeName))
+					Expect(underTest.Status.ReportOnlyUnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.ReportOnlyUnhealthyNodes[0].Name).To(Equal(reportOnlyNodeName))
+					Expect(underTest.Status.ReportOnlyUnhealthyNodes[0].Conditions).To(ConsistOf(v1alpha1.MatchedCondition{
+						Type:   memoryPressure,
+						Status: v1.ConditionTrue,
+					}))
+				})
+			})
+
+			When("a node doesn't report a Ready condition", func() {
+				const noReadyNodeName = "no-ready-node"
+
+				BeforeEach(func() {
+					setupObjects(1, 2, true)
+					objects = append(objects, newNode(noReadyNodeName, v1.NodeMemoryPressure, v1.ConditionFalse, false, true))
+				})
+
+				It("should count the node as healthy by default", func() {
+					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(*underTest.Status.HealthyNodes).To(Equal(3))
+				})
+
+				When("positive health is required", func() {
+					BeforeEach(func() {
+						underTest.Spec.RequirePositiveHealth = true
+					})
+
+					It("should not count the node as healthy, and skip remediation because of minHealthy", func() {
+						cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+
+						cr = newRemediationCRForNHC(noReadyNodeName, underTest)
+						err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+
+						Expect(*underTest.Status.ObservedNodes).To(Equal(4))
+						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+					})
+				})
+			})
+
+			When("a node is reported as unreachable by its connectivity report", func() {
+				const unreachableNodeName = "healthy-worker-node-1"
+
+				BeforeEach(func() {
+					underTest.Spec.ConnectivityCheck = &v1alpha1.ConnectivityCheck{
+						Duration: metav1.Duration{Duration: time.Minute},
+					}
+					setupObjects(0, 3, true)
+				})
+
+				It("should remediate the node and mark it as unreachable", func() {
+					report := &v1alpha1.NodeConnectivityReport{
+						ObjectMeta: metav1.ObjectMeta{Name: unreachableNodeName},
+					}
+					Expect(k8sClient.Create(context.Background(), report)).To(Succeed())
+					DeferCleanup(k8sClient.Delete, context.Background(), report)
+					unreachableSince := metav1.NewTime(time.Now().Add(-2 * time.Minute).Truncate(time.Second))
+					report.Status = v1alpha1.NodeConnectivityReportStatus{
+						Reachable:          false,
+						LastTransitionTime: unreachableSince,
+						LastProbeTime:      metav1.Now(),
+						Reporter:           "test",
+					}
+					Expect(k8sClient.Status().Update(context.Background(), report)).To(Succeed())
+
+					cr := newRemediationCRForNHC(unreachableNodeName, underTest)
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						g.Expect(underTest.Status.UnhealthyNodes[0].Name).To(Equal(unreachableNodeName))
+						g.Expect(underTest.Status.UnhealthyNodes[0].UnreachableSince).ToNot(BeNil())
+						g.Expect(underTest.Status.UnhealthyNodes[0].UnreachableSince.Time).To(BeTemporally("==", unreachableSince.Time))
+					}, "5s", "500ms").Should(Succeed())
+
+					By("reporting the node as reachable again")
+					report.Status.Reachable = true
+					report.Status.LastTransitionTime = metav1.Now()
+					Expect(k8sClient.Status().Update(context.Background(), report)).To(Succeed())
+					Eventually(func(g Gomega) {
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}, "5s", "500ms").Should(Succeed())
+				})
+			})
+
 			When("few nodes are unhealthy and healthy nodes meet min healthy", func() {
 				BeforeEach(func() {
 					setupObjects(1, 2, false)
@@ -335,11 +541,10 @@ var _ = Describe("Node Health Check CR", func() {
 					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
 					Expect(errors.IsNotFound(err)).To(BeTrue())
 
-					By("waiting until nodes are unhealthy")
-					time.Sleep(nodeUnhealthyIn)
-
-					By("checking CR is created now")
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					By("checking CR is created when the unhealthy duration expires, without further node updates")
+					Eventually(func() error {
+						return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					}, nodeUnhealthyIn+2*time.Second, "200ms").Should(Succeed())
 					Expect(cr.Object).To(ContainElement(map[string]interface{}{"size": "foo"}))
 					Expect(cr.GetOwnerReferences()).
 						To(ContainElement(
@@ -370,6 +575,7 @@ var _ = Describe("Node Health Check CR", func() {
 					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.UID).To(Equal(cr.GetUID()))
 					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Started).ToNot(BeNil())
 					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).To(BeNil())
+					Expect(underTest.Status.UnhealthyDurationBuckets).To(Equal(&v1alpha1.UnhealthyDurationBuckets{LessThan1m: 1}))
 					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
 					Expect(underTest.Status.Reason).ToNot(BeEmpty())
 					Expect(underTest.Status.Conditions).To(ContainElement(
@@ -378,6 +584,13 @@ var _ = Describe("Node Health Check CR", func() {
 							HaveField("Status", metav1.ConditionFalse),
 							HaveField("Reason", v1alpha1.ConditionReasonEnabled),
 						)))
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeProgressing),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonRemediating),
+							HaveField("ObservedGeneration", underTest.GetGeneration()),
+						)))
 
 					By("making node ready")
 					unhealthyNode := &v1.Node{}
@@ -419,6 +632,7 @@ var _ = Describe("Node Health Check CR", func() {
 						g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(0))
 						g.Expect(*underTest.Status.HealthyNodes).To(Equal(3))
 						g.Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+						g.Expect(meta.IsStatusConditionFalse(underTest.Status.Conditions, v1alpha1.ConditionTypeProgressing)).To(BeTrue())
 					}, "5s", "500ms").Should(Succeed(), "expected conditionsHealthyTimestamp to be set")
 
 				})
@@ -829,6 +1043,37 @@ var _ = Describe("Node Health Check CR", func() {
 			})
 		})
 
+		Context("with Node having the out-of-service taint", func() {
+			gracePeriod := 3 * time.Second
+			BeforeEach(func() {
+				underTest.Spec.ExternalRemediationGracePeriod = &metav1.Duration{Duration: gracePeriod}
+				setupObjects(1, 2, true)
+				node := objects[0].(*v1.Node)
+				node.Spec.Taints = append(node.Spec.Taints, v1.Taint{
+					Key:    v1.TaintNodeOutOfService,
+					Value:  "nodeshutdown",
+					Effect: v1.TaintEffectNoExecute,
+				})
+			})
+
+			It("should defer remediation until the grace period expires", func() {
+				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(BeEmpty())
+				Expect(underTest.Status.UnhealthyNodes[0].Deferral).ToNot(BeNil())
+				Expect(underTest.Status.UnhealthyNodes[0].Deferral.Reason).To(Equal(v1alpha1.DeferralReasonExternallyRemediated))
+
+				By("taking over when the grace period expired")
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Deferral).To(BeNil())
+				}, gracePeriod*3, time.Millisecond*300).Should(Succeed())
+			})
+		})
+
 		Context("with a single escalating remediation", func() {
 
 			BeforeEach(func() {
@@ -889,6 +1134,30 @@ var _ = Describe("Node Health Check CR", func() {
 
 			})
 
+			It("should annotate the remediation attempt on the CRs", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(annotations.RemediationAttemptAnnotation, "1"))
+				Expect(cr.GetAnnotations()).ToNot(HaveKey(annotations.PreviousAttemptOutcomeAnnotation))
+
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)).To(Succeed())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+				Expect(newCr.GetAnnotations()).To(HaveKeyWithValue(annotations.RemediationAttemptAnnotation, "2"))
+				Expect(newCr.GetAnnotations()).To(HaveKeyWithValue(annotations.PreviousAttemptOutcomeAnnotation, string(v1alpha1.RemediationPhaseTimedOut)))
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(2))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Attempt).To(Equal(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Attempt).To(Equal(2))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			})
+
 			It("it should try one remediation after another", func() {
 				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
 				// first call should fail, because the node gets unready in a few seconds only
@@ -929,7 +1198,8 @@ var _ = Describe("Node Health Check CR", func() {
 				Eventually(func(g Gomega) {
 					// get updated CR
 					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
-					g.Expect(cr.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), Not(BeNil())))
+					g.Expect(cr.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), HaveSuffix(";reason=Timeout")))
+					g.Expect(cr.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out-step"), HavePrefix("order=0;attempt=")))
 
 				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 
@@ -1006,7 +1276,8 @@ var _ = Describe("Node Health Check CR", func() {
 					g.Expect(thirdCR.GetName()).ToNot(Equal(unhealthyNodeName))
 					g.Expect(thirdCR.GetAnnotations()[commonannotations.NodeNameAnnotation]).To(Equal(unhealthyNodeName))
 					g.Expect(thirdCR.GetAnnotations()[annotations.TemplateNameAnnotation]).To(Equal(multiSupportTemplateRef.Name))
-					g.Expect(thirdCR.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), Not(BeNil())))
+					g.Expect(thirdCR.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), HaveSuffix(";reason=Timeout")))
+					g.Expect(thirdCR.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out-step"), HavePrefix("order=6;attempt=")))
 				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 
 				// Wait for 4th remediation to start
@@ -1068,10 +1339,10 @@ var _ = Describe("Node Health Check CR", func() {
 
 				It("should not proceed with next remediation when duration didn't expire yet", func() {
 					// wait until nodes are unhealthy
-					time.Sleep(nodeUnhealthyIn)
-
 					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Eventually(func() error {
+						return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					}, nodeUnhealthyIn+2*time.Second, "200ms").Should(Succeed())
 
 					By("changing to other unhealthy condition")
 					node := &v1.Node{}
@@ -1116,613 +1387,5447 @@ var _ = Describe("Node Health Check CR", func() {
 
 		})
 
-		Context("with progressing condition being set", func() {
-
+		Context("with escalation handshake", func() {
+			firstRemediationTimeout := time.Second
+			gracePeriod := 4 * time.Second
 			BeforeEach(func() {
 				templateRef1 := underTest.Spec.RemediationTemplate
 				underTest.Spec.RemediationTemplate = nil
+
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
+
 				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
 					{
 						RemediationTemplate: *templateRef1,
 						Order:               0,
-						Timeout:             metav1.Duration{Duration: 5 * time.Minute},
+						Timeout:             metav1.Duration{Duration: firstRemediationTimeout},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
 					},
 				}
-				setupObjects(1, 2, true)
+				underTest.Spec.EscalationHandshake = true
+				underTest.Spec.EscalationHandshakeGracePeriod = &metav1.Duration{Duration: gracePeriod}
+
+				setupObjects(1, 2, false)
 			})
 
-			It("it should timeout early", func() {
+			waitForAwaitingAcknowledgment := func(cr *unstructured.Unstructured) {
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					g.Expect(cr.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), Not(BeNil())))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).ToNot(BeNil())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseAwaitingAcknowledgment))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			}
+
+			expectEscalated := func(cr *unstructured.Unstructured) {
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Eventually(func() error {
+					return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(2))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.GroupVersionKind()).To(Equal(cr.GroupVersionKind()))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Resource.GroupVersionKind()).To(Equal(newCr.GroupVersionKind()))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Phase).To(Equal(v1alpha1.RemediationPhaseRunning))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			}
+
+			expectNotEscalated := func(duration time.Duration) {
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Consistently(func(g Gomega) {
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, duration, time.Millisecond*300).Should(Succeed())
+			}
+
+			It("should escalate when the remediator acknowledges the timeout", func() {
 				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				waitForAwaitingAcknowledgment(cr)
+				expectNotEscalated(time.Second)
+
+				By("acknowledging the timeout")
 				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				crAnnotations := cr.GetAnnotations()
+				crAnnotations[annotations.NhcTimedOutAcknowledgedAnnotation] = "true"
+				cr.SetAnnotations(crAnnotations)
+				Expect(k8sClient.Update(context.Background(), cr)).To(Succeed())
 
-				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-				Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Started).ToNot(BeNil())
-				Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).To(BeNil())
+				expectEscalated(cr)
+			})
 
-				By("letting the remediation stop progressing")
-				conditions := []interface{}{
-					map[string]interface{}{
-						"type":               "Succeeded",
-						"status":             "False",
-						"lastTransitionTime": time.Now().Format(time.RFC3339),
+			It("should escalate when the grace period expires without acknowledgment", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				waitForAwaitingAcknowledgment(cr)
+				expectNotEscalated(gracePeriod / 2)
+
+				expectEscalated(cr)
+				Expect(cr.GetAnnotations()).ToNot(HaveKey(annotations.NhcTimedOutAcknowledgedAnnotation))
+			})
+		})
+
+		Context("with escalation delay", func() {
+			escalationDelay := 4 * time.Second
+			BeforeEach(func() {
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
+
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
+
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: time.Second},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
 					},
 				}
-				unstructured.SetNestedSlice(cr.Object, conditions, "status", "conditions")
-				Expect(k8sClient.Status().Update(context.Background(), cr))
+				underTest.Spec.EscalationDelay = &metav1.Duration{Duration: escalationDelay}
 
-				// Wait for hardcoded timeout to expire
-				time.Sleep(5 * time.Second)
+				setupObjects(1, 2, false)
+			})
 
-				// get updated CR
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
-				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), Not(BeNil())))
+			It("should wait for the escalation delay before starting the next remediation", func() {
+				By("waiting for the first remediation to time out")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Deferral).ToNot(BeNil())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Deferral.Reason).To(Equal(v1alpha1.DeferralReasonEscalationCooldown))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+				timedOut := underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut.Time
 
-				// get updated NHC
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
-				Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).ToNot(BeNil())
-				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+				By("verifying the next remediation doesn't start during the delay")
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Consistently(func(g Gomega) {
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, escalationDelay/2, time.Millisecond*300).Should(Succeed())
+
+				By("waiting for the next remediation")
+				Eventually(func() error {
+					return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+				Expect(newCr.GetCreationTimestamp().Time).To(BeTemporally(">=", timedOut.Add(escalationDelay).Truncate(time.Second)))
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(2))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Phase).To(Equal(v1alpha1.RemediationPhaseRunning))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Deferral).To(BeNil())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 			})
 		})
 
-		Context("with expected permanent node deletion", func() {
-
+		Context("with deleting timed out remediations", func() {
 			BeforeEach(func() {
-				// TODO will work with classic remediation as well when https://github.com/medik8s/node-healthcheck-operator/pull/230 is merged
 				templateRef1 := underTest.Spec.RemediationTemplate
 				underTest.Spec.RemediationTemplate = nil
+
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
+
 				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
 					{
 						RemediationTemplate: *templateRef1,
 						Order:               0,
-						Timeout:             metav1.Duration{Duration: 5 * time.Minute},
+						Timeout:             metav1.Duration{Duration: time.Second},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
 					},
 				}
-				setupObjects(1, 2, true)
+				underTest.Spec.DeleteTimedOutRemediations = true
+
+				setupObjects(1, 2, false)
 			})
 
-			deleteNode := func() {
-				By("deleting the node")
-				node := &v1.Node{}
-				node.Name = unhealthyNodeName
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
-				Expect(k8sClient.Delete(context.Background(), node))
-			}
-
-			markCR := func() *unstructured.Unstructured {
-				By("marking CR as succeeded and permanent node deletion expected")
-				cr := newRemediationCRForNHC("unhealthy-worker-node-1", underTest)
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
-				conditions := []interface{}{
-					map[string]interface{}{
-						"type":               commonconditions.SucceededType,
-						"status":             "True",
-						"lastTransitionTime": time.Now().Format(time.RFC3339),
-					},
-					map[string]interface{}{
-						"type":               commonconditions.PermanentNodeDeletionExpectedType,
-						"status":             "True",
-						"lastTransitionTime": time.Now().Format(time.RFC3339),
-					},
-				}
-				unstructured.SetNestedSlice(cr.Object, conditions, "status", "conditions")
-				Expect(k8sClient.Status().Update(context.Background(), cr))
-				return cr
-			}
+			It("should delete the timed out remediation CR before escalating", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func() error {
+					return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 
-			expectCRDeletion := func(cr *unstructured.Unstructured) {
-				By("waiting for CR to be deleted")
-				Eventually(func() bool {
+				By("waiting for the timed out CR to be deleted")
+				Eventually(func(g Gomega) {
 					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-					return errors.IsNotFound(err)
-				}, "2s", "200ms").Should(BeTrue())
-
-				// get updated NHC
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
-				Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
-			}
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 
-			It("it should delete orphaned CR when CR was updated", func() {
-				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-				deleteNode()
-				time.Sleep(1 * time.Second)
-				cr := markCR()
-				expectCRDeletion(cr)
-			})
+				By("waiting for the next remediation")
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Eventually(func() error {
+					return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 
-			It("it should delete orphaned CR when node is deleted", func() {
-				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-				cr := markCR()
-				time.Sleep(1 * time.Second)
-				deleteNode()
-				expectCRDeletion(cr)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(2))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.GroupVersionKind()).To(Equal(cr.GroupVersionKind()))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Phase).To(Equal(v1alpha1.RemediationPhaseRunning))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 			})
 		})
 
-		Context("control plane nodes", func() {
-
-			var pdb *policyv1.PodDisruptionBudget
-			pdbSelector := map[string]string{
-				"app": "guard",
-			}
-
+		Context("with NetworkUnavailable condition", func() {
 			BeforeEach(func() {
-				// create etcd namespace
-				ns := &v1.Namespace{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "openshift-etcd",
-					},
-				}
-				// namespaces can't be deleted!
-				err := k8sClient.Create(context.Background(), ns)
-				if err != nil {
-					Expect(errors.IsAlreadyExists(err)).To(BeTrue())
-				}
-
-				// create PDB
-				pdb = &policyv1.PodDisruptionBudget{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "some-name",
-						Namespace: "openshift-etcd",
-					},
-					Spec: policyv1.PodDisruptionBudgetSpec{
-						Selector: &metav1.LabelSelector{MatchLabels: pdbSelector},
+				underTest.Spec.UnhealthyConditions = []v1alpha1.UnhealthyCondition{
+					{
+						Type:     v1.NodeNetworkUnavailable,
+						Status:   v1.ConditionTrue,
+						Duration: metav1.Duration{Duration: unhealthyConditionDuration},
 					},
 				}
-				Expect(k8sClient.Create(context.Background(), pdb)).To(Succeed())
-				DeferCleanup(func() {
-					Expect(k8sClient.Delete(context.Background(), pdb)).To(Succeed())
+				setupObjects(0, 2, true)
+				// a node which is Ready, but whose network is unavailable for longer than the duration
+				node := newNode(unhealthyNodeName, v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node)
+				node.Status.Conditions = append(node.Status.Conditions, v1.NodeCondition{
+					Type:               v1.NodeNetworkUnavailable,
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: node.Status.Conditions[0].LastTransitionTime,
 				})
+				objects = append(objects, node)
+			})
 
-				// update pdb status
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pdb), pdb)).To(Succeed())
-				pdb.Status.DisruptionsAllowed = 1
-				Expect(k8sClient.Status().Update(context.Background(), pdb)).To(Succeed())
+			It("should remediate the node", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+					g.Expect(underTest.Status.UnhealthyNodes).To(ConsistOf(And(
+						HaveField("Name", unhealthyNodeName),
+						HaveField("MatchedCondition", HaveField("Type", v1.NodeNetworkUnavailable)),
+					)))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			})
+		})
 
+		Context("with node name", func() {
+			BeforeEach(func() {
+				minHealthy := intstr.FromInt(0)
+				underTest.Spec.MinHealthy = &minHealthy
+				setupObjects(1, 2, true)
 			})
 
-			When("two control plane nodes are unhealthy, they should be remediated one after another", func() {
+			When("the named node is unhealthy", func() {
 				BeforeEach(func() {
-					objects = newNodes(2, 1, true, true)
-					objects = append(objects, newNodes(1, 5, false, true)...)
-					underTest = newNodeHealthCheck()
-					objects = append(objects, underTest)
+					underTest.Spec.NodeName = unhealthyNodeName
 				})
 
-				It("creates a one remediation CR for control plane node and updates status", func() {
-					cr := newRemediationCRForNHC("", underTest)
-					crList := &unstructured.UnstructuredList{Object: cr.Object}
-					Expect(k8sClient.List(context.Background(), crList)).To(Succeed())
+				It("should remediate the named node only", func() {
+					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(*underTest.Status.ObservedNodes).To(Equal(1))
+						g.Expect(*underTest.Status.HealthyNodes).To(Equal(0))
+						g.Expect(underTest.Status.UnhealthyNodes).To(ConsistOf(HaveField("Name", unhealthyNodeName)))
+					}, time.Second*10, time.Millisecond*300).Should(Succeed())
+				})
+			})
 
-					Expect(len(crList.Items)).To(BeNumerically("==", 2), "expected 2 remediations, one for control plane, one for worker")
-					Expect(crList.Items).To(ContainElements(
-						// the unhealthy worker
-						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", unhealthyNodeName))),
-						// one of the unhealthy control plane nodes
-						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", ContainSubstring("unhealthy-control-plane-node")))),
-					))
-					Expect(*underTest.Status.HealthyNodes).To(Equal(6))
-					Expect(*underTest.Status.ObservedNodes).To(Equal(9))
-					Expect(underTest.Status.InFlightRemediations).To(HaveLen(2))
-					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(3))
-					Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
-						And(
-							HaveField("Name", unhealthyNodeName),
-							HaveField("Remediations", ContainElement(
-								And(
-									HaveField("Resource.Name", unhealthyNodeName),
-									HaveField("Started", Not(BeNil())),
-									HaveField("TimedOut", BeNil()),
-								),
-							)),
-						),
-						And(
-							HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
-							HaveField("Remediations", ContainElement(
-								And(
-									HaveField("Resource.Name", ContainSubstring("unhealthy-control-plane-node")),
-									HaveField("Started", Not(BeNil())),
-									HaveField("TimedOut", BeNil()),
-								),
-							)),
-						),
-					))
+			When("another node is named", func() {
+				BeforeEach(func() {
+					underTest.Spec.NodeName = "healthy-worker-node-1"
+				})
 
-					var remediatedUnhealthyCPNodeName string
-					for _, unhealthyNode := range underTest.Status.UnhealthyNodes {
-						if strings.Contains(unhealthyNode.Name, "unhealthy-control-plane-node") {
-							remediatedUnhealthyCPNodeName = unhealthyNode.Name
-							break
-						}
+				It("should not remediate the unhealthy node", func() {
+					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+					Consistently(func(g Gomega) {
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(*underTest.Status.ObservedNodes).To(Equal(1))
+						g.Expect(*underTest.Status.HealthyNodes).To(Equal(1))
+						g.Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					}, time.Second*3, time.Millisecond*300).Should(Succeed())
+				})
+			})
+		})
+
+		Context("with overlapping NHCs", func() {
+			var otherNHC *v1alpha1.NodeHealthCheck
+
+			BeforeEach(func() {
+				setupObjects(1, 2, true)
+				otherNHC = newNodeHealthCheck()
+				otherNHC.Name = "test-2"
+				objects = append(objects, otherNHC)
+			})
+
+			It("should report which NHC controls the remediation of the node", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(otherNHC), otherNHC)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(otherNHC.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].IsControllingNHC).ToNot(BeNil())
+					g.Expect(otherNHC.Status.UnhealthyNodes[0].IsControllingNHC).ToNot(BeNil())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+				// the NHC which created the CR first controls the remediation, it is the first owner
+				controlling, other := underTest, otherNHC
+				if cr.GetOwnerReferences()[0].Name != underTest.Name {
+					controlling, other = otherNHC, underTest
+				}
+				Expect(*controlling.Status.UnhealthyNodes[0].IsControllingNHC).To(BeTrue())
+				Expect(controlling.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+				Expect(*other.Status.UnhealthyNodes[0].IsControllingNHC).To(BeFalse())
+				Expect(other.Status.UnhealthyNodes[0].Remediations).To(BeEmpty())
+			})
+		})
+
+		Context("with recording remediations on nodes", func() {
+			BeforeEach(func() {
+				underTest.Spec.RecordRemediationOnNode = true
+				underTest.Spec.RemediationRecordRetention = &metav1.Duration{Duration: 3 * time.Second}
+				setupObjects(1, 2, true)
+			})
+
+			getRemediatedByNHCCondition := func(g Gomega) *v1.NodeCondition {
+				node := &v1.Node{}
+				g.Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: unhealthyNodeName}, node)).To(Succeed())
+				for _, c := range node.Status.Conditions {
+					if c.Type == v1alpha1.NodeConditionTypeRemediatedByNHC {
+						return &c
 					}
-					Expect(remediatedUnhealthyCPNodeName).ToNot(BeEmpty())
+				}
+				return nil
+			}
 
-					By("simulating remediator by putting a finalizer on the cp remediation CR")
-					unhealthyCPNodeCR := newRemediationCRForNHC(remediatedUnhealthyCPNodeName, underTest)
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNodeCR), unhealthyCPNodeCR)).To(Succeed())
-					unhealthyCPNodeCR.SetFinalizers([]string{"dummy"})
-					Expect(k8sClient.Update(context.Background(), unhealthyCPNodeCR)).To(Succeed())
+			It("should set the node condition after remediation, and clear it when the retention expires", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func() error {
+					return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+				Consistently(func(g Gomega) {
+					g.Expect(getRemediatedByNHCCondition(g)).To(BeNil())
+				}, time.Second, time.Millisecond*300).Should(Succeed())
 
-					By("make cp node healthy")
-					unhealthyCPNode := &v1.Node{}
-					unhealthyCPNode.Name = remediatedUnhealthyCPNodeName
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNode), unhealthyCPNode)).To(Succeed())
-					unhealthyCPNode.Status.Conditions = []v1.NodeCondition{
-						{
-							Type:   v1.NodeReady,
-							Status: v1.ConditionTrue,
-						},
+				By("making the node healthy")
+				node := &v1.Node{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: unhealthyNodeName}, node)).To(Succeed())
+				for i, c := range node.Status.Conditions {
+					if c.Type == v1.NodeReady {
+						node.Status.Conditions[i].Status = v1.ConditionTrue
 					}
-					Expect(k8sClient.Status().Update(context.Background(), unhealthyCPNode))
+				}
+				Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
 
-					By("waiting for remediation end of cp node")
-					Eventually(func(g Gomega) *metav1.Time {
-						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNodeCR), unhealthyCPNodeCR)).To(Succeed())
-						return unhealthyCPNodeCR.GetDeletionTimestamp()
-					}, "2s", "100ms").ShouldNot(BeNil(), "expected CR to be deleted")
+				By("waiting for the remediation to be recorded")
+				Eventually(func(g Gomega) {
+					condition := getRemediatedByNHCCondition(g)
+					g.Expect(condition).ToNot(BeNil())
+					g.Expect(condition.Status).To(Equal(v1.ConditionTrue))
+					g.Expect(condition.Reason).To(Equal(v1alpha1.NodeConditionReasonRemediationSucceeded))
+					g.Expect(condition.Message).To(ContainSubstring(underTest.Name))
+				}, time.Second*5, time.Millisecond*300).Should(Succeed())
+
+				By("waiting for the retention to expire")
+				Eventually(func(g Gomega) {
+					condition := getRemediatedByNHCCondition(g)
+					g.Expect(condition).ToNot(BeNil())
+					g.Expect(condition.Status).To(Equal(v1.ConditionFalse))
+					g.Expect(condition.Reason).To(Equal(v1alpha1.NodeConditionReasonRetentionExpired))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
 
-					By("ensuring other cp node isn't remediated yet")
-					Consistently(func(g Gomega) []*v1alpha1.UnhealthyNode {
-						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
-						return underTest.Status.UnhealthyNodes
+				By("verifying the kubelet's conditions were kept")
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: unhealthyNodeName}, node)).To(Succeed())
+				Expect(node.Status.Conditions).To(ContainElement(HaveField("Type", v1.NodeReady)))
+			})
+		})
 
-					}, "5s", "1s").Should(HaveLen(3))
+		Context("with max remediation duration", func() {
+			maxRemediationDuration := 3 * time.Second
+			BeforeEach(func() {
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
 
-					By("simulating remediator finished by removing finalizer on the cp remediation CR")
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNodeCR), unhealthyCPNodeCR)).To(Succeed())
-					unhealthyCPNodeCR.SetFinalizers([]string{})
-					Expect(k8sClient.Update(context.Background(), unhealthyCPNodeCR)).To(Succeed())
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
 
-					By("ensuring other cp node is remediated now")
-					Eventually(func(g Gomega) []*v1alpha1.UnhealthyNode {
-						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
-						return underTest.Status.UnhealthyNodes
-					}, "2s", "100ms").Should(ContainElements(
-						And(
-							HaveField("Name", "unhealthy-worker-node-1"),
-							HaveField("Remediations", ContainElement(
-								And(
-									HaveField("Resource.Name", "unhealthy-worker-node-1"),
-									HaveField("Started", Not(BeNil())),
-									HaveField("TimedOut", BeNil()),
-								),
-							)),
-						),
+				// escalation would continue after the first timeout, which is way beyond the max duration
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+				}
+				underTest.Spec.MaxRemediationDuration = &metav1.Duration{Duration: maxRemediationDuration}
+
+				setupObjects(1, 2, false)
+			})
+
+			It("should give up remediation when the max duration is exceeded", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(cr.GetAnnotations()).ToNot(HaveKey(commonannotations.NhcTimedOut))
+
+				By("waiting for the max remediation duration to expire")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].RemediationExhausted).ToNot(BeNil())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+					g.Expect(underTest.Status.Conditions).To(ContainElement(
 						And(
-							HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
-							// ensure it's the other cp node now
-							Not(HaveField("Name", remediatedUnhealthyCPNodeName)),
-							HaveField("Remediations", ContainElement(
-								And(
-									HaveField("Resource.Name", ContainSubstring("unhealthy-control-plane-node")),
-									HaveField("Started", Not(BeNil())),
-									HaveField("TimedOut", BeNil()),
-								),
-							)),
-						),
-					))
-					crList = &unstructured.UnstructuredList{Object: cr.Object}
-					Expect(k8sClient.List(context.Background(), crList)).To(Succeed())
-					Expect(len(crList.Items)).To(BeNumerically("==", 2), "expected 2 remediations, one for control plane, one for worker")
-					Expect(crList.Items).To(ContainElements(
-						// the unhealthy worker
-						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", "unhealthy-worker-node-1"))),
-						// the other unhealthy control plane nodes
-						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", ContainSubstring("unhealthy-control-plane-node")))),
-					))
-					Expect(crList.Items).ToNot(ContainElements(
-						// the old unhealthy control plane node
-						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", remediatedUnhealthyCPNodeName))),
-					))
+							HaveField("Type", v1alpha1.ConditionTypeRemediationExhausted),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonMaxRemediationDurationExceeded),
+						)))
+				}, maxRemediationDuration*3, time.Millisecond*300).Should(Succeed())
+
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(cr.GetAnnotations()).To(HaveKey(commonannotations.NhcTimedOut))
+
+				By("ensuring that escalation doesn't continue")
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Consistently(func(g Gomega) {
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, 3*time.Second, time.Millisecond*300).Should(Succeed())
+			})
+		})
+
+		Context("with an escalating remediation removed from the spec", func() {
+			var otherNHCsCR *unstructured.Unstructured
+
+			BeforeEach(func() {
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
+
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
+
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+				}
+
+				// a CR of the same kind owned by another NHC
+				otherNHCsCR = newRemediationCR("other-node", *templateRef1, metav1.OwnerReference{
+					APIVersion: underTest.APIVersion,
+					Kind:       underTest.Kind,
+					Name:       "other",
+					UID:        "5678",
+				})
+
+				setupObjects(1, 2, true)
+			})
+
+			It("should delete the remediation CRs of the removed kind", func() {
+				Expect(k8sClient.Create(context.Background(), otherNHCsCR)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, context.Background(), otherNHCsCR)
+
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(underTest.Status.CreatedRemediationKinds).To(ConsistOf(metav1.GroupVersionKind{
+					Group:   cr.GroupVersionKind().Group,
+					Version: cr.GroupVersionKind().Version,
+					Kind:    cr.GetKind(),
+				}))
+
+				By("removing the first escalating remediation")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					underTest.Spec.EscalatingRemediations = underTest.Spec.EscalatingRemediations[1:]
+					g.Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+				}, time.Second*5, time.Millisecond*300).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(ContainElement(And(
+						HaveField("Resource.Kind", cr.GetKind()),
+						HaveField("Phase", v1alpha1.RemediationPhaseAborted),
+					)))
+					g.Expect(underTest.Status.CreatedRemediationKinds).ToNot(ContainElement(HaveField("Kind", cr.GetKind())))
+				}, time.Second*15, time.Millisecond*300).Should(Succeed())
+
+				By("continuing with the remaining escalating remediation")
+				newCr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)).To(Succeed())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+				By("not touching the CR of the other NHC")
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(otherNHCsCR), otherNHCsCR)).To(Succeed())
+				Expect(otherNHCsCR.GetDeletionTimestamp()).To(BeNil())
+			})
+		})
+
+		Context("with remediation tracked in deprecated status field only", func() {
+			BeforeEach(func() {
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
+
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
+
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+				}
+				// simulate an update of the operator while remediation is ongoing
+				underTest.Spec.PauseRequests = []string{"operator update"}
+				setupObjects(1, 2, true)
+			})
+
+			It("should migrate the status and continue remediation", func() {
+				By("creating a timed out remediation CR and the deprecated status of an older operator version")
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				cr.SetOwnerReferences([]metav1.OwnerReference{{
+					APIVersion: v1alpha1.GroupVersion.String(),
+					Kind:       "NodeHealthCheck",
+					Name:       underTest.Name,
+					UID:        underTest.UID,
+				}})
+				timedOut := time.Now().Add(-time.Minute).Truncate(time.Second)
+				cr.SetAnnotations(map[string]string{
+					"remediation.medik8s.io/nhc-timed-out": timedOut.Format(time.RFC3339),
+				})
+				Expect(k8sClient.Create(context.Background(), cr)).To(Succeed())
+				DeferCleanup(func() {
+					newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+					_ = k8sClient.Delete(context.Background(), newCr)
+				})
+
+				underTest.Status.InFlightRemediations = map[string]metav1.Time{
+					unhealthyNodeName: cr.GetCreationTimestamp(),
+				}
+				underTest.Status.UnhealthyNodes = nil
+				Expect(k8sClient.Status().Update(context.Background(), underTest)).To(Succeed())
+
+				By("triggering a reconcile")
+				underTest.Spec.PauseRequests = []string{"operator updated"}
+				Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Name).To(Equal(unhealthyNodeName))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.Name).To(Equal(cr.GetName()))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut.Time).To(BeTemporally("==", timedOut))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+					g.Expect(underTest.Status.GetInFlightRemediations()).To(HaveKey(unhealthyNodeName))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+				By("unpausing")
+				underTest.Spec.PauseRequests = nil
+				Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+
+				newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+				Eventually(func() error {
+					return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(2))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Phase).To(Equal(v1alpha1.RemediationPhaseRunning))
+					g.Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			})
+		})
+
+		Context("with post remediation verification", func() {
+			verificationTemplateRef := v1.ObjectReference{
+				APIVersion: InfraRemediationAPIVersion,
+				Kind:       VerificationKind + "Template",
+				Namespace:  MachineNamespace,
+				Name:       VerificationTemplateName,
+			}
+
+			BeforeEach(func() {
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
+
+				templateRef2 := templateRef1.DeepCopy()
+				templateRef2.Kind = "Metal3RemediationTemplate"
+				templateRef2.Name = "ok"
+				templateRef2.Namespace = MachineNamespace
+
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+					{
+						RemediationTemplate: *templateRef2,
+						Order:               5,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					},
+				}
+				underTest.Spec.PostRemediationVerification = &v1alpha1.PostRemediationVerification{
+					VerificationTemplate: verificationTemplateRef,
+				}
+				setupObjects(1, 2, true)
+			})
+
+			newVerificationCR := func() *unstructured.Unstructured {
+				return newRemediationCR(unhealthyNodeName, verificationTemplateRef, metav1.OwnerReference{})
+			}
+
+			setSucceeded := func(cr *unstructured.Unstructured, status metav1.ConditionStatus) {
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(unstructured.SetNestedSlice(cr.Object, []interface{}{
+					map[string]interface{}{
+						"type":               commonconditions.SucceededType,
+						"status":             string(status),
+						"lastTransitionTime": time.Now().Format(time.RFC3339),
+					},
+				}, "status", "conditions")).To(Succeed())
+				Expect(k8sClient.Status().Update(context.Background(), cr)).To(Succeed())
+			}
+
+			makeNodeHealthyAndExpectVerifying := func() {
+				DeferCleanup(func() {
+					_ = k8sClient.Delete(context.Background(), newVerificationCR())
+				})
+
+				node := &v1.Node{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: unhealthyNodeName}, node)).To(Succeed())
+				for i, c := range node.Status.Conditions {
+					if c.Type == v1.NodeReady {
+						node.Status.Conditions[i].Status = v1.ConditionTrue
+					}
+				}
+				Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newVerificationCR()), newVerificationCR())).To(Succeed())
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					g.Expect(underTest.Status.UnhealthyNodes[0].Verification).ToNot(BeNil())
+					g.Expect(underTest.Status.UnhealthyNodes[0].Verification.Phase).To(Equal(v1alpha1.VerificationPhaseVerifying))
+					g.Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			}
+
+			It("should consider the node healthy after verification succeeded", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+				makeNodeHealthyAndExpectVerifying()
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				setSucceeded(newVerificationCR(), metav1.ConditionTrue)
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					g.Expect(*underTest.Status.HealthyNodes).To(Equal(3))
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newVerificationCR()), newVerificationCR())
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}, time.Second*10, time.Millisecond*300).Should(Succeed())
+			})
+
+			When("re-escalation on failure is enabled", func() {
+				BeforeEach(func() {
+					underTest.Spec.PostRemediationVerification.ReEscalateOnFailure = true
+				})
 
+				It("should re-escalate after failed verification, and verify again", func() {
+					makeNodeHealthyAndExpectVerifying()
+
+					newCr := newRemediationCRForNHCSecondRemediation(unhealthyNodeName, underTest)
+					DeferCleanup(func() {
+						_ = k8sClient.Delete(context.Background(), newCr)
+					})
+
+					By("failing verification")
+					setSucceeded(newVerificationCR(), metav1.ConditionFalse)
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)).To(Succeed())
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						g.Expect(underTest.Status.UnhealthyNodes[0].Verification.Phase).To(Equal(v1alpha1.VerificationPhaseFailed))
+						g.Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(2))
+						g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+						g.Expect(underTest.Status.UnhealthyNodes[0].Remediations[1].Phase).To(Equal(v1alpha1.RemediationPhaseRunning))
+					}, time.Second*10, time.Millisecond*300).Should(Succeed())
+
+					By("finishing the re-escalated remediation")
+					setSucceeded(newCr, metav1.ConditionTrue)
+					Eventually(func(g Gomega) {
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCr), newCr)
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						g.Expect(underTest.Status.UnhealthyNodes[0].Verification).ToNot(BeNil())
+						g.Expect(underTest.Status.UnhealthyNodes[0].Verification.Phase).To(Equal(v1alpha1.VerificationPhaseVerifying))
+					}, time.Second*10, time.Millisecond*300).Should(Succeed())
 				})
 			})
+		})
+
+		Context("with progressing condition being set", func() {
+
+			BeforeEach(func() {
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+				setupObjects(1, 2, true)
+			})
+
+			It("it should timeout early", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Started).ToNot(BeNil())
+				Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).To(BeNil())
+
+				By("letting the remediation stop progressing")
+				conditions := []interface{}{
+					map[string]interface{}{
+						"type":               "Succeeded",
+						"status":             "False",
+						"lastTransitionTime": time.Now().Format(time.RFC3339),
+					},
+				}
+				unstructured.SetNestedSlice(cr.Object, conditions, "status", "conditions")
+				Expect(k8sClient.Status().Update(context.Background(), cr))
+
+				// Wait for hardcoded timeout to expire
+				time.Sleep(5 * time.Second)
+
+				// get updated CR
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(Equal("remediation.medik8s.io/nhc-timed-out"), Not(BeNil())))
+
+				// get updated NHC
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+				Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).ToNot(BeNil())
+				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+			})
+		})
+
+		Context("with expected permanent node deletion", func() {
+
+			BeforeEach(func() {
+				// TODO will work with classic remediation as well when https://github.com/medik8s/node-healthcheck-operator/pull/230 is merged
+				templateRef1 := underTest.Spec.RemediationTemplate
+				underTest.Spec.RemediationTemplate = nil
+				underTest.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: *templateRef1,
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+				setupObjects(1, 2, true)
+			})
+
+			deleteNode := func() {
+				By("deleting the node")
+				node := &v1.Node{}
+				node.Name = unhealthyNodeName
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+				Expect(k8sClient.Delete(context.Background(), node))
+			}
+
+			markCR := func() *unstructured.Unstructured {
+				By("marking CR as succeeded and permanent node deletion expected")
+				cr := newRemediationCRForNHC("unhealthy-worker-node-1", underTest)
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				conditions := []interface{}{
+					map[string]interface{}{
+						"type":               commonconditions.SucceededType,
+						"status":             "True",
+						"lastTransitionTime": time.Now().Format(time.RFC3339),
+					},
+					map[string]interface{}{
+						"type":               commonconditions.PermanentNodeDeletionExpectedType,
+						"status":             "True",
+						"lastTransitionTime": time.Now().Format(time.RFC3339),
+					},
+				}
+				unstructured.SetNestedSlice(cr.Object, conditions, "status", "conditions")
+				Expect(k8sClient.Status().Update(context.Background(), cr))
+				return cr
+			}
+
+			expectCRDeletion := func(cr *unstructured.Unstructured) {
+				By("waiting for CR to be deleted")
+				Eventually(func() bool {
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					return errors.IsNotFound(err)
+				}, "2s", "200ms").Should(BeTrue())
+
+				// get updated NHC
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+				Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+			}
+
+			It("it should delete orphaned CR when CR was updated", func() {
+				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+				deleteNode()
+				time.Sleep(1 * time.Second)
+				cr := markCR()
+				expectCRDeletion(cr)
+			})
+
+			It("it should delete orphaned CR when node is deleted", func() {
+				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+				cr := markCR()
+				time.Sleep(1 * time.Second)
+				deleteNode()
+				expectCRDeletion(cr)
+			})
+		})
+
+		Context("control plane nodes", func() {
+
+			var pdb *policyv1.PodDisruptionBudget
+			pdbSelector := map[string]string{
+				"app": "guard",
+			}
+
+			BeforeEach(func() {
+				// create etcd namespace
+				ns := &v1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "openshift-etcd",
+					},
+				}
+				// namespaces can't be deleted!
+				err := k8sClient.Create(context.Background(), ns)
+				if err != nil {
+					Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+				}
+
+				// create PDB
+				pdb = &policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "some-name",
+						Namespace: "openshift-etcd",
+					},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: pdbSelector},
+					},
+				}
+				Expect(k8sClient.Create(context.Background(), pdb)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(k8sClient.Delete(context.Background(), pdb)).To(Succeed())
+				})
+
+				// update pdb status
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pdb), pdb)).To(Succeed())
+				pdb.Status.DisruptionsAllowed = 1
+				Expect(k8sClient.Status().Update(context.Background(), pdb)).To(Succeed())
+
+			})
+
+			When("two control plane nodes are unhealthy, they should be remediated one after another", func() {
+				BeforeEach(func() {
+					objects = newNodes(2, 1, true, true)
+					objects = append(objects, newNodes(1, 5, false, true)...)
+					underTest = newNodeHealthCheck()
+					objects = append(objects, underTest)
+				})
+
+				It("creates a one remediation CR for control plane node and updates status", func() {
+					cr := newRemediationCRForNHC("", underTest)
+					crList := &unstructured.UnstructuredList{Object: cr.Object}
+					Expect(k8sClient.List(context.Background(), crList)).To(Succeed())
+
+					Expect(len(crList.Items)).To(BeNumerically("==", 2), "expected 2 remediations, one for control plane, one for worker")
+					Expect(crList.Items).To(ContainElements(
+						// the unhealthy worker
+						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", unhealthyNodeName))),
+						// one of the unhealthy control plane nodes
+						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", ContainSubstring("unhealthy-control-plane-node")))),
+					))
+					Expect(*underTest.Status.HealthyNodes).To(Equal(6))
+					Expect(*underTest.Status.ObservedNodes).To(Equal(9))
+					Expect(underTest.Status.InFlightRemediations).To(HaveLen(2))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(3))
+					Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
+						And(
+							HaveField("Name", unhealthyNodeName),
+							HaveField("Remediations", ContainElement(
+								And(
+									HaveField("Resource.Name", unhealthyNodeName),
+									HaveField("Started", Not(BeNil())),
+									HaveField("TimedOut", BeNil()),
+								),
+							)),
+						),
+						And(
+							HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
+							HaveField("Remediations", ContainElement(
+								And(
+									HaveField("Resource.Name", ContainSubstring("unhealthy-control-plane-node")),
+									HaveField("Started", Not(BeNil())),
+									HaveField("TimedOut", BeNil()),
+								),
+							)),
+						),
+					))
+
+					var remediatedUnhealthyCPNodeName string
+					for _, unhealthyNode := range underTest.Status.UnhealthyNodes {
+						if strings.Contains(unhealthyNode.Name, "unhealthy-control-plane-node") {
+							remediatedUnhealthyCPNodeName = unhealthyNode.Name
+							break
+						}
+					}
+					Expect(remediatedUnhealthyCPNodeName).ToNot(BeEmpty())
+
+					By("simulating remediator by putting a finalizer on the cp remediation CR")
+					unhealthyCPNodeCR := newRemediationCRForNHC(remediatedUnhealthyCPNodeName, underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNodeCR), unhealthyCPNodeCR)).To(Succeed())
+					unhealthyCPNodeCR.SetFinalizers([]string{"dummy"})
+					Expect(k8sClient.Update(context.Background(), unhealthyCPNodeCR)).To(Succeed())
+
+					By("make cp node healthy")
+					unhealthyCPNode := &v1.Node{}
+					unhealthyCPNode.Name = remediatedUnhealthyCPNodeName
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNode), unhealthyCPNode)).To(Succeed())
+					unhealthyCPNode.Status.Conditions = []v1.NodeCondition{
+						{
+							Type:   v1.NodeReady,
+							Status: v1.ConditionTrue,
+						},
+					}
+					Expect(k8sClient.Status().Update(context.Background(), unhealthyCPNode))
+
+					By("waiting for remediation end of cp node")
+					Eventually(func(g Gomega) *metav1.Time {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNodeCR), unhealthyCPNodeCR)).To(Succeed())
+						return unhealthyCPNodeCR.GetDeletionTimestamp()
+					}, "2s", "100ms").ShouldNot(BeNil(), "expected CR to be deleted")
+
+					By("ensuring other cp node isn't remediated yet")
+					Consistently(func(g Gomega) []*v1alpha1.UnhealthyNode {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						return underTest.Status.UnhealthyNodes
+
+					}, "5s", "1s").Should(HaveLen(3))
+
+					By("simulating remediator finished by removing finalizer on the cp remediation CR")
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unhealthyCPNodeCR), unhealthyCPNodeCR)).To(Succeed())
+					unhealthyCPNodeCR.SetFinalizers([]string{})
+					Expect(k8sClient.Update(context.Background(), unhealthyCPNodeCR)).To(Succeed())
+
+					By("ensuring other cp node is remediated now")
+					Eventually(func(g Gomega) []*v1alpha1.UnhealthyNode {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						return underTest.Status.UnhealthyNodes
+					}, "2s", "100ms").Should(ContainElements(
+						And(
+							HaveField("Name", "unhealthy-worker-node-1"),
+							HaveField("Remediations", ContainElement(
+								And(
+									HaveField("Resource.Name", "unhealthy-worker-node-1"),
+									HaveField("Started", Not(BeNil())),
+									HaveField("TimedOut", BeNil()),
+								),
+							)),
+						),
+						And(
+							HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
+							// ensure it's the other cp node now
+							Not(HaveField("Name", remediatedUnhealthyCPNodeName)),
+							HaveField("Remediations", ContainElement(
+								And(
+									HaveField("Resource.Name", ContainSubstring("unhealthy-control-plane-node")),
+									HaveField("Started", Not(BeNil())),
+									HaveField("TimedOut", BeNil()),
+								),
+							)),
+						),
+					))
+					crList = &unstructured.UnstructuredList{Object: cr.Object}
+					Expect(k8sClient.List(context.Background(), crList)).To(Succeed())
+					Expect(len(crList.Items)).To(BeNumerically("==", 2), "expected 2 remediations, one for control plane, one for worker")
+					Expect(crList.Items).To(ContainElements(
+						// the unhealthy worker
+						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", "unhealthy-worker-node-1"))),
+						// the other unhealthy control plane nodes
+						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", ContainSubstring("unhealthy-control-plane-node")))),
+					))
+					Expect(crList.Items).ToNot(ContainElements(
+						// the old unhealthy control plane node
+						HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", remediatedUnhealthyCPNodeName))),
+					))
+
+				})
+			})
+
+			Context("one control plane node is unhealthy, and DisruptionsAllowed = 0", func() {
+				BeforeEach(func() {
+					objects = newNodes(1, 2, true, true)
+					underTest = newNodeHealthCheck()
+					objects = append(objects, underTest)
+
+					// update pdb status
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pdb), pdb)).To(Succeed())
+					pdb.Status.DisruptionsAllowed = 0
+					Expect(k8sClient.Status().Update(context.Background(), pdb)).To(Succeed())
+				})
+
+				createGuardPod := func(isReady bool) {
+					pod := &v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "some-name",
+							Namespace: pdb.Namespace,
+							Labels:    pdbSelector,
+						},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{
+									Name:  "test",
+									Image: "test",
+								},
+							},
+							NodeName: "unhealthy-control-plane-node-1",
+						},
+					}
+					Expect(k8sClient.Create(context.Background(), pod)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(k8sClient.Delete(context.Background(), pod, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})).To(Succeed())
+					})
+
+					// update pod status
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+					status := v1.ConditionTrue
+					if !isReady {
+						status = v1.ConditionFalse
+					}
+					pod.Status.Conditions = []v1.PodCondition{
+						{
+							Type:   v1.PodReady,
+							Status: status,
+						},
+					}
+					Expect(k8sClient.Status().Update(context.Background(), pod)).To(Succeed())
+				}
+
+				When("unhealthy node is not disrupted already (guard pod is ready)", func() {
+
+					BeforeEach(func() {
+						// create ready guard pod
+						createGuardPod(true)
+					})
+
+					It("doesn't create a remediation CR for control plane node", func() {
+						cr := newRemediationCRForNHC("unhealthy-control-plane-node-1", underTest)
+						crList := &unstructured.UnstructuredList{Object: cr.Object}
+						Consistently(func(g Gomega) {
+							g.Expect(k8sClient.List(context.Background(), crList)).To(Succeed())
+							g.Expect(len(crList.Items)).To(BeNumerically("==", 0), "expected no remediation for cp node")
+						}, "10s", "1s").Should(Succeed())
+						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+						Expect(*underTest.Status.ObservedNodes).To(Equal(3))
+						Expect(underTest.Status.InFlightRemediations).To(HaveLen(0))
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
+							And(
+								HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
+								HaveField("Remediations", BeNil()),
+							),
+						))
+					})
+				})
+
+				When("unhealthy node is disrupted already (guard pod is not ready)", func() {
+
+					BeforeEach(func() {
+						// create unready pod
+						createGuardPod(false)
+					})
+
+					It("does create a remediation CR for control plane node", func() {
+						cr := newRemediationCRForNHC("unhealthy-control-plane-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+						Expect(*underTest.Status.ObservedNodes).To(Equal(3))
+						Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
+							And(
+								HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
+								HaveField("Remediations", Not(BeNil())),
+							),
+						))
+					})
+				})
+
+				When("unhealthy node has no guard pod (node doesn't run etcd or guard pod was deleted)", func() {
+
+					It("does create a remediation CR for control plane node", func() {
+						cr := newRemediationCRForNHC("unhealthy-control-plane-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+						Expect(*underTest.Status.ObservedNodes).To(Equal(3))
+						Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
+							And(
+								HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
+								HaveField("Remediations", Not(BeNil())),
+							),
+						))
+					})
+				})
+			})
+
+		})
+
+		When("remediation is needed but pauseRequests exists", func() {
+			BeforeEach(func() {
+				setupObjects(1, 2, true)
+				underTest.Spec.PauseRequests = []string{"I'm an admin, asking you to stop remediating this group of nodes"}
+			})
+
+			It("skips remediation and updates status", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				Expect(*underTest.Status.HealthyNodes).To(Equal(0))
+				Expect(*underTest.Status.ObservedNodes).To(Equal(0))
+				Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+				Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhasePaused))
+				Expect(underTest.Status.Reason).ToNot(BeEmpty())
+			})
+		})
+
+		When("remediation is needed but dryRun is set", func() {
+			BeforeEach(func() {
+				setupObjects(1, 2, true)
+				underTest.Spec.DryRun = true
+			})
+
+			It("skips remediation and reports the node", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(BeEmpty())
+				Expect(underTest.Status.UnhealthyNodes[0].Deferral).ToNot(BeNil())
+				Expect(underTest.Status.UnhealthyNodes[0].Deferral.Reason).To(Equal(v1alpha1.DeferralReasonDryRun))
+				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseDryRun))
+				Expect(underTest.Status.Reason).To(ContainSubstring(unhealthyNodeName))
+			})
+		})
+
+		When("Nodes are candidates for remediation and cluster is upgrading", func() {
+			BeforeEach(func() {
+				clusterUpgradeRequeueAfter = 5 * time.Second
+				upgradeChecker.Upgrading = true
+				setupObjects(1, 2, true)
+			})
+
+			AfterEach(func() {
+				upgradeChecker.Upgrading = false
+			})
+
+			It("doesn't not remediate but requeues reconciliation and updates status", func() {
+				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				Expect(*underTest.Status.HealthyNodes).To(Equal(0))
+				Expect(*underTest.Status.ObservedNodes).To(Equal(0))
+				Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+				Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+				Expect(underTest.Status.Reason).ToNot(BeEmpty())
+
+				By("stopping upgrade and waiting for requeue")
+				upgradeChecker.Upgrading = false
+				time.Sleep(10 * time.Second)
+				err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+				Expect(*underTest.Status.HealthyNodes).To(Equal(2))
+				Expect(*underTest.Status.ObservedNodes).To(Equal(3))
+				Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
+				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+			})
+
+		})
+
+		Context("Machine owners", func() {
+			When("Metal3RemediationTemplate is in correct namespace", func() {
+
+				var machine *machinev1beta1.Machine
+
+				BeforeEach(func() {
+					setupObjects(1, 2, true)
+
+					// create machine
+					machine = &machinev1beta1.Machine{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-machine",
+							Namespace: MachineNamespace,
+						},
+					}
+					objects = append(objects, machine)
+
+					// set machine annotation to unhealthy node
+					for _, o := range objects {
+						o := o
+						if o.GetName() == unhealthyNodeName {
+							ann := make(map[string]string)
+							ann["machine.openshift.io/machine"] = fmt.Sprintf("%s/%s", machine.Namespace, machine.Name)
+							o.SetAnnotations(ann)
+						}
+					}
+
+					// set metal3 template
+					underTest.Spec.RemediationTemplate.Kind = "Metal3RemediationTemplate"
+					underTest.Spec.RemediationTemplate.Name = "ok"
+					underTest.Spec.RemediationTemplate.Namespace = MachineNamespace
+
+				})
+
+				It("should set owner ref to the machine", func() {
+					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetOwnerReferences()).To(
+						ContainElement(
+							And(
+								// Kind and API version aren't set on underTest, envtest issue...
+								// Controller is empty for HaveField because false is the zero value?
+								HaveField("Name", machine.Name),
+								HaveField("UID", machine.UID),
+							),
+						),
+					)
+				})
+			})
+
+			When("a non Metal3 template is in the machine's namespace", func() {
+
+				var machine *machinev1beta1.Machine
+
+				BeforeEach(func() {
+					setupObjects(1, 2, true)
+
+					// create machine
+					machine = &machinev1beta1.Machine{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-machine",
+							Namespace: MachineNamespace,
+						},
+					}
+					objects = append(objects, machine)
+
+					// set machine annotation to unhealthy node
+					for _, o := range objects {
+						o := o
+						if o.GetName() == unhealthyNodeName {
+							ann := make(map[string]string)
+							ann["machine.openshift.io/machine"] = fmt.Sprintf("%s/%s", machine.Namespace, machine.Name)
+							o.SetAnnotations(ann)
+						}
+					}
+				})
+
+				It("should set owner refs to the NHC as controller and to the machine", func() {
+					Expect(underTest.Spec.RemediationTemplate.Kind).To(Equal(InfraRemediationTemplateKind))
+					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetOwnerReferences()).To(HaveLen(2))
+					Expect(cr.GetOwnerReferences()).To(
+						ContainElement(
+							And(
+								HaveField("Name", machine.Name),
+								HaveField("UID", machine.UID),
+							),
+						),
+					)
+					Expect(cr.GetOwnerReferences()).To(
+						ContainElement(
+							And(
+								HaveField("Name", underTest.Name),
+								HaveField("UID", underTest.UID),
+							),
+						),
+					)
+				})
+			})
+
+		})
+
+	})
+
+	// TODO move to new suite in utils package
+	Context("Controller Watches", func() {
+		var (
+			underTest1 *v1alpha1.NodeHealthCheck
+			underTest2 *v1alpha1.NodeHealthCheck
+			objects    []client.Object
+		)
+
+		JustBeforeEach(func() {
+			createObjects(objects...)
+			time.Sleep(2 * time.Second)
+		})
+
+		AfterEach(func() {
+			deleteObjects(objects...)
+			time.Sleep(1 * time.Second)
+		})
+
+		When("a node changes status and is selectable by one NHC selector", func() {
+			BeforeEach(func() {
+				objects = newNodes(3, 10, false, true)
+				underTest1 = newNodeHealthCheck()
+				underTest2 = newNodeHealthCheck()
+				underTest2.Name = "test-2"
+				emptySelector, _ := metav1.ParseToLabelSelector("fooLabel=bar")
+				underTest2.Spec.Selector = *emptySelector
+				objects = append(objects, underTest1, underTest2)
+			})
+
+			It("creates a reconcile request", func() {
+				handler := utils.NHCByNodeMapperFunc(k8sClient, controllerruntime.Log)
+				updatedNode := v1.Node{
+					ObjectMeta: controllerruntime.ObjectMeta{Name: "healthy-worker-node-1"},
+				}
+				requests := handler(context.TODO(), &updatedNode)
+				Expect(len(requests)).To(Equal(1))
+				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest1.GetName()}}))
+			})
+		})
+
+		When("a node changes status and is selectable by the more 2 NHC selector", func() {
+			BeforeEach(func() {
+				objects = newNodes(3, 10, false, true)
+				underTest1 = newNodeHealthCheck()
+				underTest2 = newNodeHealthCheck()
+				underTest2.Name = "test-2"
+				objects = append(objects, underTest1, underTest2)
+			})
+
+			It("creates 2 reconcile requests", func() {
+				handler := utils.NHCByNodeMapperFunc(k8sClient, controllerruntime.Log)
+				updatedNode := v1.Node{
+					ObjectMeta: controllerruntime.ObjectMeta{Name: "healthy-worker-node-1"},
+				}
+				requests := handler(context.TODO(), &updatedNode)
+				Expect(len(requests)).To(Equal(2))
+				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest1.GetName()}}))
+				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest2.GetName()}}))
+			})
+		})
+		When("a node changes status and there are no NHC objects", func() {
+			BeforeEach(func() {
+				objects = newNodes(3, 10, false, true)
+			})
+
+			It("doesn't create reconcile requests", func() {
+				handler := utils.NHCByNodeMapperFunc(k8sClient, controllerruntime.Log)
+				updatedNode := v1.Node{
+					ObjectMeta: controllerruntime.ObjectMeta{Name: "healthy-worker-node-1"},
+				}
+				requests := handler(context.TODO(), &updatedNode)
+				Expect(requests).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Remediation CRs with generated names", func() {
+		const nodeName = "generated-name-node"
+
+		var (
+			rm   resources.Manager
+			nhc  *v1alpha1.NodeHealthCheck
+			node *v1.Node
+		)
+
+		BeforeEach(func() {
+			leaseManager, err := resources.NewLeaseManager(k8sClient, "test", controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			rm = resources.NewManager(k8sClient, context.Background(), controllerruntime.Log, false, leaseManager, record.NewFakeRecorder(10), true, nil)
+			nhc = newNodeHealthCheck()
+			node = newNode(nodeName, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+		})
+
+		It("creates, tracks and cleans up the remediation CR by its generated name", func() {
+			cr, err := rm.GenerateRemediationCRForNode(node, nhc, infraRemediationTemplate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cr.GetName()).To(BeEmpty())
+			Expect(cr.GetGenerateName()).To(Equal(nodeName))
+
+			created, _, createdCR, err := rm.CreateRemediationCR(cr, nhc, nil, 0, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(createdCR.GetName()).To(HavePrefix(nodeName))
+			Expect(createdCR.GetName()).ToNot(Equal(nodeName))
+			Expect(resources.GetNodeName(*createdCR)).To(Equal(nodeName))
+
+			By("finding the existing CR instead of creating a new one")
+			cr, err = rm.GenerateRemediationCRForNode(node, nhc, infraRemediationTemplate)
+			Expect(err).ToNot(HaveOccurred())
+			created, _, existingCR, err := rm.CreateRemediationCR(cr, nhc, nil, 0, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(existingCR.GetName()).To(Equal(createdCR.GetName()))
+
+			By("tracking the generated name in the status")
+			resources.UpdateStatusRemediationStarted(node, nhc, existingCR, nil)
+			Expect(nhc.Status.UnhealthyNodes).To(HaveLen(1))
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].Resource.Name).To(Equal(createdCR.GetName()))
+
+			By("deleting the CR when the node is healthy")
+			crs, err := rm.HandleHealthyNode(nodeName, nodeName, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crs).To(HaveLen(1))
+			Expect(errors.IsNotFound(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(createdCR), createdCR))).To(BeTrue())
+		})
+	})
+
+	Context("Remediation template CRD", func() {
+		var (
+			gvk    schema.GroupVersionKind
+			mapper *meta.DefaultRESTMapper
+			c      client.Client
+			rm     resources.Manager
+			nhc    *v1alpha1.NodeHealthCheck
+		)
+
+		BeforeEach(func() {
+			gvk = schema.GroupVersionKind{Group: "remediation.example.com", Version: "v1", Kind: "LateRemediationTemplate"}
+			mapper = meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+			c = fake.NewClientBuilder().WithRESTMapper(mapper).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = &v1.ObjectReference{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Namespace:  "default",
+				Name:       "late",
+			}
+		})
+
+		It("distinguishes a CRD which isn't established yet from a missing template", func() {
+			valid, reason, _, err := rm.ValidateTemplates(nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal(v1alpha1.ConditionReasonCRDNotEstablished))
+
+			By("establishing the CRD")
+			mapper.Add(gvk, meta.RESTScopeNamespace)
+			valid, reason, _, err = rm.ValidateTemplates(nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal(v1alpha1.ConditionReasonDisabledTemplateNotFound))
+
+			By("creating the template")
+			template := &unstructured.Unstructured{}
+			template.SetGroupVersionKind(gvk)
+			template.SetNamespace("default")
+			template.SetName("late")
+			Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template", "spec")).To(Succeed())
+			Expect(c.Create(context.Background(), template)).To(Succeed())
+			valid, _, _, err = rm.ValidateTemplates(nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeTrue())
+		})
+
+		It("waits for the CRD with backoff until the timeout expires", func() {
+			reconciler := &NodeHealthCheckReconciler{
+				Recorder:       record.NewFakeRecorder(10),
+				CRDWaitTimeout: time.Minute,
+			}
+			requeueAfter, waiting := reconciler.waitForCRD(nhc, "not established", controllerruntime.Log)
+			Expect(waiting).To(BeTrue())
+			Expect(requeueAfter).To(Equal(crdWaitMinRequeueAfter))
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeWaitingForCRD)).To(BeTrue())
+
+			By("backing off")
+			condition := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeWaitingForCRD)
+			condition.LastTransitionTime = metav1.NewTime(time.Now().Add(-10 * time.Second))
+			requeueAfter, waiting = reconciler.waitForCRD(nhc, "not established", controllerruntime.Log)
+			Expect(waiting).To(BeTrue())
+			Expect(requeueAfter).To(BeNumerically("~", 10*time.Second, time.Second))
+
+			By("giving up after the timeout")
+			condition = meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeWaitingForCRD)
+			condition.LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+			_, waiting = reconciler.waitForCRD(nhc, "not established", controllerruntime.Log)
+			Expect(waiting).To(BeFalse())
+		})
+
+		It("doesn't disable the NHC on transient errors when getting the template", func() {
+			mapper.Add(gvk, meta.RESTScopeNamespace)
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			nhc.Status.Phase = v1alpha1.PhaseEnabled
+			c = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(mapper).
+				WithObjects(nhc).
+				WithStatusSubresource(nhc).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if obj.GetObjectKind().GroupVersionKind().Kind == gvk.Kind {
+							return errors.NewTimeoutError("getting template", 1)
+						}
+						return client.Get(ctx, key, obj, opts...)
+					},
+				}).
+				Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+
+			valid, reason, _, err := rm.ValidateTemplates(nhc)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.IsTimeout(err)).To(BeTrue())
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(BeEmpty())
+
+			By("patching the status like at the end of reconcile")
+			reconciler := &NodeHealthCheckReconciler{
+				Client:   c,
+				Recorder: record.NewFakeRecorder(10),
+			}
+			nhcOrig := nhc.DeepCopy()
+			updateLastError(nhc, err)
+			Expect(reconciler.patchStatus(context.Background(), controllerruntime.Log, nhc, nhcOrig)).To(Succeed())
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeDisabled)).To(BeFalse())
+			Expect(nhc.Status.LastError).ToNot(BeNil())
+			Expect(nhc.Status.LastError.Message).To(Equal(err.Error()))
+			since := nhc.Status.LastError.Since
+
+			By("keeping the time of a repeated error")
+			updateLastError(nhc, err)
+			Expect(nhc.Status.LastError.Since).To(Equal(since))
+
+			By("clearing the error after a successful reconcile")
+			updateLastError(nhc, nil)
+			Expect(nhc.Status.LastError).To(BeNil())
+		})
+	})
+
+	Context("Escalating remediations which are not applicable", func() {
+		const bmcLabel = "example.com/bmc"
+		var (
+			c        client.Client
+			mapper   *meta.DefaultRESTMapper
+			objects  []client.Object
+			rm       resources.Manager
+			recorder *record.FakeRecorder
+			nhc      *v1alpha1.NodeHealthCheck
+			node     *v1.Node
+		)
+
+		BeforeEach(func() {
+			gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+			mapper = meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			objects = nil
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = nil
+			for i, kind := range []string{"BMCRemediationTemplate", "RebootRemediationTemplate"} {
+				mapper.Add(gv.WithKind(kind), meta.RESTScopeNamespace)
+				mapper.Add(gv.WithKind(strings.TrimSuffix(kind, "Template")), meta.RESTScopeNamespace)
+				template := &unstructured.Unstructured{}
+				template.SetGroupVersionKind(gv.WithKind(kind))
+				template.SetNamespace("default")
+				template.SetName("template")
+				Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template", "spec")).To(Succeed())
+				objects = append(objects, template)
+				nhc.Spec.EscalatingRemediations = append(nhc.Spec.EscalatingRemediations, v1alpha1.EscalatingRemediation{
+					RemediationTemplate: v1.ObjectReference{
+						APIVersion: gv.String(),
+						Kind:       kind,
+						Namespace:  "default",
+						Name:       "template",
+					},
+					Order:   i,
+					Timeout: metav1.Duration{Duration: time.Minute},
+				})
+			}
+			nhc.Spec.EscalatingRemediations[0].RequiredNodeLabels = map[string]string{bmcLabel: "ipmi"}
+			node = &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "bmc-node"}}
+		})
+
+		JustBeforeEach(func() {
+			c = fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(objects...).Build()
+			recorder = record.NewFakeRecorder(10)
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, recorder, false, nil)
+		})
+
+		When("the node has the required labels", func() {
+			BeforeEach(func() {
+				node.Labels = map[string]string{bmcLabel: "ipmi"}
+			})
+
+			It("uses the remediation", func() {
+				template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template.GetKind()).To(Equal("BMCRemediationTemplate"))
+				Expect(nhc.Status.UnhealthyNodes).To(BeEmpty())
+			})
+		})
+
+		When("the node is missing the required labels", func() {
+			BeforeEach(func() {
+				node.Labels = map[string]string{bmcLabel: "redfish"}
+			})
+
+			It("skips the remediation and records it as not applicable", func() {
+				template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template.GetKind()).To(Equal("RebootRemediationTemplate"))
+				Expect(nhc.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(nhc.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+				skipped := nhc.Status.UnhealthyNodes[0].Remediations[0]
+				Expect(skipped.Resource.Kind).To(Equal("BMCRemediation"))
+				Expect(skipped.Phase).To(Equal(v1alpha1.RemediationPhaseNotApplicable))
+				Expect(skipped.MissingNodeLabels).To(ConsistOf(bmcLabel + "=ipmi"))
+				Expect(skipped.Reason).To(Equal(v1alpha1.RemediationReasonMissingNodeLabels))
+				Expect(resources.IsStatusRemediationOngoing(skipped)).To(BeFalse())
+				Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonNotApplicable)))
+
+				By("not evaluating the labels again")
+				node.Labels = map[string]string{bmcLabel: "ipmi"}
+				template, _, err = rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template.GetKind()).To(Equal("RebootRemediationTemplate"))
+				Expect(nhc.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+				Expect(recorder.Events).ToNot(Receive())
+			})
+		})
+
+		When("the remediation is paused", func() {
+			BeforeEach(func() {
+				node.Labels = map[string]string{bmcLabel: "ipmi"}
+				nhc.Spec.EscalatingRemediations[0].PauseReason = "firmware update"
+			})
+
+			It("skips the remediation and records it as paused", func() {
+				template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template.GetKind()).To(Equal("RebootRemediationTemplate"))
+				Expect(nhc.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(nhc.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+				skipped := nhc.Status.UnhealthyNodes[0].Remediations[0]
+				Expect(skipped.Phase).To(Equal(v1alpha1.RemediationPhaseNotApplicable))
+				Expect(skipped.Reason).To(Equal(v1alpha1.RemediationReasonStepPaused))
+				Expect(skipped.MissingNodeLabels).To(BeEmpty())
+				Expect(recorder.Events).To(Receive(ContainSubstring("firmware update")))
+			})
+		})
+
+		When("the paused remediation is ongoing already", func() {
+			var cr *unstructured.Unstructured
+
+			BeforeEach(func() {
+				node.Labels = map[string]string{bmcLabel: "ipmi"}
+				nhc.Spec.EscalatingRemediations[0].PauseReason = "firmware update"
+				cr = newRemediationCRForNHC(node.GetName(), nhc)
+				cr.SetCreationTimestamp(metav1.Now())
+				objects = append(objects, cr)
+				resources.UpdateStatusRemediationStarted(node, nhc, cr, pointer.Duration(time.Minute))
+			})
+
+			It("leaves it alone by default", func() {
+				template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template.GetKind()).To(Equal("BMCRemediationTemplate"))
+
+				reconciler := &NodeHealthCheckReconciler{Recorder: record.NewFakeRecorder(10)}
+				Expect(reconciler.abortPausedRemediation(nhc, node, rm, controllerruntime.Log)).To(Succeed())
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseRunning))
+			})
+
+			When("it should be aborted", func() {
+				BeforeEach(func() {
+					nhc.Spec.EscalatingRemediations[0].AbortOnPause = true
+				})
+
+				It("deletes the remediation CR and continues with the next remediation", func() {
+					reconciler := &NodeHealthCheckReconciler{Recorder: record.NewFakeRecorder(10)}
+					Expect(reconciler.abortPausedRemediation(nhc, node, rm, controllerruntime.Log)).To(Succeed())
+					Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr))).To(BeTrue())
+					aborted := nhc.Status.UnhealthyNodes[0].Remediations[0]
+					Expect(aborted.Phase).To(Equal(v1alpha1.RemediationPhaseAborted))
+					Expect(aborted.TimedOut).ToNot(BeNil())
+
+					template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(template.GetKind()).To(Equal("RebootRemediationTemplate"))
+				})
+			})
+		})
+	})
+
+	Context("Remediator circuit breaker", func() {
+		var (
+			c        client.Client
+			rm       resources.Manager
+			r        *NodeHealthCheckReconciler
+			recorder *record.FakeRecorder
+			nhc      *v1alpha1.NodeHealthCheck
+			now      time.Time
+		)
+
+		fail := func(nodeName string) {
+			r.observeRemediationOutcome(nhc, nodeName, &v1alpha1.Remediation{Resource: v1.ObjectReference{Kind: "FenceRemediation"}}, metrics.RemediationOutcomeTimedOut)
+		}
+		succeed := func(nodeName string) {
+			r.observeRemediationOutcome(nhc, nodeName, &v1alpha1.Remediation{Resource: v1.ObjectReference{Kind: "FenceRemediation"}}, metrics.RemediationOutcomeSucceeded)
+		}
+		newUnhealthyNode := func(name string) *v1.Node {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			resources.UpdateStatusNodeUnhealthy(node, nhc, now)
+			return node
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			var objects []client.Object
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = nil
+			nhc.Spec.RemediatorCircuitBreaker = &v1alpha1.RemediatorCircuitBreaker{
+				FailureThreshold: 3,
+				OpenDuration:     &metav1.Duration{Duration: 10 * time.Minute},
+			}
+			for i, kind := range []string{"FenceRemediationTemplate", "RebootRemediationTemplate"} {
+				mapper.Add(gv.WithKind(kind), meta.RESTScopeNamespace)
+				mapper.Add(gv.WithKind(strings.TrimSuffix(kind, "Template")), meta.RESTScopeNamespace)
+				template := &unstructured.Unstructured{}
+				template.SetGroupVersionKind(gv.WithKind(kind))
+				template.SetNamespace("default")
+				template.SetName("template")
+				Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template", "spec")).To(Succeed())
+				objects = append(objects, template)
+				nhc.Spec.EscalatingRemediations = append(nhc.Spec.EscalatingRemediations, v1alpha1.EscalatingRemediation{
+					RemediationTemplate: v1.ObjectReference{
+						APIVersion: gv.String(),
+						Kind:       kind,
+						Namespace:  "default",
+						Name:       "template",
+					},
+					Order:   i,
+					Timeout: metav1.Duration{Duration: time.Minute},
+				})
+			}
+			c = fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(objects...).Build()
+			recorder = record.NewFakeRecorder(10)
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, recorder, false, nil)
+			r = &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: recorder,
+			}
+		})
+
+		It("opens the circuit when remediations of a kind fail in a row across nodes", func() {
+			By("resetting the failure count on success")
+			fail("node-1")
+			fail("node-2")
+			succeed("node-3")
+			Expect(nhc.Status.RemediatorCircuits).To(BeEmpty())
+
+			By("opening the circuit")
+			fail("node-1")
+			fail("node-2")
+			Expect(recorder.Events).ToNot(Receive())
+			fail("node-3")
+			Expect(nhc.Status.RemediatorCircuits).To(ConsistOf(&v1alpha1.RemediatorCircuit{
+				Kind:                "FenceRemediation",
+				State:               v1alpha1.CircuitStateOpen,
+				ConsecutiveFailures: 3,
+				Opened:              &metav1.Time{Time: now},
+			}))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonCircuitOpened)))
+			updateRemediatorDegradedCondition(nhc)
+			condition := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorDegraded)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("FenceRemediation"))
+
+			By("skipping the escalating remediation of that kind")
+			node := newUnhealthyNode("node-4")
+			template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.GetKind()).To(Equal("RebootRemediationTemplate"))
+			skipped := nhc.Status.UnhealthyNodes[0].Remediations[0]
+			Expect(skipped.Resource.Kind).To(Equal("FenceRemediation"))
+			Expect(skipped.Phase).To(Equal(v1alpha1.RemediationPhaseNotApplicable))
+			Expect(skipped.Reason).To(Equal(v1alpha1.RemediationReasonCircuitOpen))
+		})
+
+		When("the circuit is open for longer than the open duration", func() {
+			var probe, other *v1.Node
+
+			BeforeEach(func() {
+				for _, name := range []string{"node-1", "node-2", "node-3"} {
+					fail(name)
+				}
+				now = now.Add(11 * time.Minute)
+				probe = newUnhealthyNode("probe")
+				other = newUnhealthyNode("other")
+			})
+
+			It("lets a single remediation through as probe, and closes the circuit on its success", func() {
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", probe.GetName(), now)).To(BeTrue())
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", probe.GetName(), now)).To(BeTrue())
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", other.GetName(), now)).To(BeFalse())
+				Expect(nhc.Status.RemediatorCircuits[0].State).To(Equal(v1alpha1.CircuitStateHalfOpen))
+				Expect(nhc.Status.RemediatorCircuits[0].ProbeNode).To(Equal(probe.GetName()))
+
+				// drain the opened event
+				Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonCircuitOpened)))
+				updateRemediatorDegradedCondition(nhc)
+				Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorDegraded)).To(BeTrue())
+				succeed(probe.GetName())
+				Expect(nhc.Status.RemediatorCircuits).To(BeEmpty())
+				Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonCircuitClosed)))
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", other.GetName(), now)).To(BeTrue())
+
+				updateRemediatorDegradedCondition(nhc)
+				Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorDegraded)).To(BeTrue())
+			})
+
+			It("opens the circuit again when the probe fails", func() {
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", probe.GetName(), now)).To(BeTrue())
+
+				By("ignoring failures of other nodes")
+				fail(other.GetName())
+				Expect(nhc.Status.RemediatorCircuits[0].State).To(Equal(v1alpha1.CircuitStateHalfOpen))
+
+				fail(probe.GetName())
+				Expect(nhc.Status.RemediatorCircuits[0].State).To(Equal(v1alpha1.CircuitStateOpen))
+				Expect(nhc.Status.RemediatorCircuits[0].Opened.Time).To(Equal(now))
+				Expect(nhc.Status.RemediatorCircuits[0].ProbeNode).To(BeEmpty())
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", other.GetName(), now)).To(BeFalse())
+			})
+
+			It("lets another node probe when the probing node isn't unhealthy anymore", func() {
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", probe.GetName(), now)).To(BeTrue())
+				resources.UpdateStatusNodeHealthy(probe.GetName(), nhc)
+				Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", other.GetName(), now)).To(BeTrue())
+				Expect(nhc.Status.RemediatorCircuits[0].ProbeNode).To(Equal(other.GetName()))
+			})
+		})
+
+		It("doesn't track outcomes without circuit breaker", func() {
+			nhc.Spec.RemediatorCircuitBreaker = nil
+			for _, name := range []string{"node-1", "node-2", "node-3"} {
+				fail(name)
+			}
+			Expect(nhc.Status.RemediatorCircuits).To(BeEmpty())
+			Expect(resources.IsStatusRemediatorCircuitClosed(nhc, "FenceRemediation", "node-4", now)).To(BeTrue())
+		})
+	})
+
+	Context("Escalating remediation timeout multiplier", func() {
+		var (
+			rm   resources.Manager
+			nhc  *v1alpha1.NodeHealthCheck
+			node *v1.Node
+			now  time.Time
+		)
+
+		// startRemediation starts the current remediation of the node like the reconciler does, and returns its
+		// status entry
+		startRemediation := func(attempt int) *v1alpha1.Remediation {
+			template, timeout, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+			Expect(err).ToNot(HaveOccurred())
+			cr := &unstructured.Unstructured{}
+			cr.SetGroupVersionKind(template.GroupVersionKind())
+			cr.SetKind(strings.TrimSuffix(template.GetKind(), "Template"))
+			cr.SetName(fmt.Sprintf("%s-%d", node.GetName(), attempt))
+			cr.SetCreationTimestamp(metav1.Time{Time: now})
+			cr.SetAnnotations(map[string]string{annotations.RemediationAttemptAnnotation: strconv.Itoa(attempt)})
+			resources.UpdateStatusRemediationStarted(node, nhc, cr, timeout)
+			started := resources.FindStatusRemediation(node, nhc, func(r *v1alpha1.Remediation) bool {
+				return r.Resource.Name == cr.GetName()
+			})
+			Expect(started).ToNot(BeNil())
+			Expect(started.Attempt).To(Equal(attempt))
+			return started
+		}
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			var objects []client.Object
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = nil
+			nhc.Spec.EscalationMemory = &v1alpha1.EscalationMemory{Window: metav1.Duration{Duration: time.Hour}}
+			for i, kind := range []string{"FenceRemediationTemplate", "RebootRemediationTemplate"} {
+				mapper.Add(gv.WithKind(kind), meta.RESTScopeNamespace)
+				mapper.Add(gv.WithKind(strings.TrimSuffix(kind, "Template")), meta.RESTScopeNamespace)
+				template := &unstructured.Unstructured{}
+				template.SetGroupVersionKind(gv.WithKind(kind))
+				template.SetNamespace("default")
+				template.SetName("template")
+				Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template", "spec")).To(Succeed())
+				objects = append(objects, template)
+				nhc.Spec.EscalatingRemediations = append(nhc.Spec.EscalatingRemediations, v1alpha1.EscalatingRemediation{
+					RemediationTemplate: v1.ObjectReference{
+						APIVersion: gv.String(),
+						Kind:       kind,
+						Namespace:  "default",
+						Name:       "template",
+					},
+					Order:             i,
+					Timeout:           metav1.Duration{Duration: time.Minute},
+					TimeoutMultiplier: "2",
+					MaxTimeout:        &metav1.Duration{Duration: 3 * time.Minute},
+				})
+			}
+			c := fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(objects...).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			node = &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+		})
+
+		// recoverAndFailAgain lets the node get healthy and unhealthy again, so that the escalation memory starts its
+		// remediation like the reconciler does
+		recoverAndFailAgain := func() {
+			now = now.Add(time.Minute)
+			resources.UpdateStatusRecentRemediation(node.GetName(), nhc, now)
+			nhc.Status.UnhealthyNodes = nil
+			resources.UpdateStatusNodeUnhealthy(node, nhc, now)
+			resources.UpdateStatusEscalationStart(node.GetName(), nhc, resources.GetEscalationStartFromMemory(node.GetName(), nhc, now))
+		}
+
+		It("stretches the deadline of retried remediations up to the max timeout", func() {
+			By("using the configured timeout for the first step")
+			first := startRemediation(1)
+			Expect(first.Resource.Kind).To(Equal("FenceRemediation"))
+			Expect(first.Timeout.Duration).To(Equal(time.Minute))
+			Expect(first.TimeoutAt.Time).To(Equal(now.Add(time.Minute)))
+
+			By("using the configured timeout for the first attempt of the next step")
+			now = now.Add(time.Minute)
+			first.TimedOut = &metav1.Time{Time: now}
+			second := startRemediation(2)
+			Expect(second.Resource.Kind).To(Equal("RebootRemediation"))
+			Expect(second.Timeout.Duration).To(Equal(time.Minute))
+			Expect(second.TimeoutAt.Time).To(Equal(now.Add(time.Minute)))
+
+			By("doubling the timeout when the last step is retried")
+			recoverAndFailAgain()
+			third := startRemediation(3)
+			Expect(third.Resource.Kind).To(Equal("RebootRemediation"))
+			Expect(nhc.Status.UnhealthyNodes[0].EscalationStart.StepAttempt).To(Equal(2))
+			Expect(third.Timeout.Duration).To(Equal(2 * time.Minute))
+			Expect(third.TimeoutAt.Time).To(Equal(now.Add(2 * time.Minute)))
+
+			By("capping the timeout when the last step is retried again")
+			recoverAndFailAgain()
+			Expect(nhc.Status.RecentRemediations).To(HaveLen(1))
+			Expect(nhc.Status.RecentRemediations[0].StepAttempts).To(Equal(2))
+			fourth := startRemediation(4)
+			Expect(fourth.Resource.Kind).To(Equal("RebootRemediation"))
+			Expect(nhc.Status.UnhealthyNodes[0].EscalationStart.StepAttempt).To(Equal(3))
+			Expect(fourth.Timeout.Duration).To(Equal(3 * time.Minute))
+			Expect(fourth.TimeoutAt.Time).To(Equal(now.Add(3 * time.Minute)))
+		})
+
+		It("stretches the lease duration of retried remediations", func() {
+			first := startRemediation(1)
+			first.TimedOut = &metav1.Time{Time: now}
+			startRemediation(2)
+			recoverAndFailAgain()
+			retried := startRemediation(3)
+
+			cr := &unstructured.Unstructured{}
+			cr.SetGroupVersionKind(retried.Resource.GroupVersionKind())
+			current, previous := resources.GetRemediationDuration(node, nhc, cr, now)
+			Expect(current).To(Equal(2 * time.Minute))
+			Expect(previous).To(Equal(time.Minute))
+		})
+
+		It("keeps the timeout of ongoing remediations", func() {
+			startRemediation(1)
+			_, timeout, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*timeout).To(Equal(time.Minute))
+		})
+	})
+
+	Context("Remediator health check", func() {
+		var (
+			c          client.Client
+			rm         resources.Manager
+			r          *NodeHealthCheckReconciler
+			recorder   *record.FakeRecorder
+			nhc        *v1alpha1.NodeHealthCheck
+			deployment *appsv1.Deployment
+			node       *v1.Node
+			now        time.Time
+		)
+
+		setReadyReplicas := func(replicas int32) {
+			deployment.Status.ReadyReplicas = replicas
+			Expect(c.Status().Update(context.Background(), deployment)).To(Succeed())
+			Expect(r.updateUnavailableRemediators(context.Background(), c, nhc, now)).To(Succeed())
+			updateRemediatorUnavailableCondition(nhc)
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv, appsv1.SchemeGroupVersion})
+			mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+			deployment = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "remediators", Name: "fence"},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+			}
+			objects := []client.Object{deployment}
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = nil
+			nhc.Spec.RemediatorHealthCheck = []v1alpha1.RemediatorHealthCheck{{
+				Kind:          "FenceRemediation",
+				DeploymentRef: v1alpha1.DeploymentReference{Namespace: "remediators", Name: "fence"},
+			}}
+			for i, kind := range []string{"FenceRemediationTemplate", "RebootRemediationTemplate"} {
+				mapper.Add(gv.WithKind(kind), meta.RESTScopeNamespace)
+				mapper.Add(gv.WithKind(strings.TrimSuffix(kind, "Template")), meta.RESTScopeNamespace)
+				template := &unstructured.Unstructured{}
+				template.SetGroupVersionKind(gv.WithKind(kind))
+				template.SetNamespace("default")
+				template.SetName("template")
+				Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{}, "spec", "template", "spec")).To(Succeed())
+				objects = append(objects, template)
+				nhc.Spec.EscalatingRemediations = append(nhc.Spec.EscalatingRemediations, v1alpha1.EscalatingRemediation{
+					RemediationTemplate: v1.ObjectReference{
+						APIVersion: gv.String(),
+						Kind:       kind,
+						Namespace:  "default",
+						Name:       "template",
+					},
+					Order:   i,
+					Timeout: metav1.Duration{Duration: time.Minute},
+				})
+			}
+			c = fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(objects...).Build()
+			recorder = record.NewFakeRecorder(10)
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, recorder, false, nil)
+			r = &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: recorder,
+			}
+			node = &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+			resources.UpdateStatusNodeUnhealthy(node, nhc, now)
+		})
+
+		It("skips escalating remediations while their remediator isn't running", func() {
+			By("using the remediation while the remediator is running")
+			setReadyReplicas(1)
+			Expect(nhc.Status.UnavailableRemediators).To(BeEmpty())
+			Expect(meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorUnavailable)).To(BeNil())
+			template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.GetKind()).To(Equal("FenceRemediationTemplate"))
+
+			By("tracking the remediator when it stops running")
+			setReadyReplicas(0)
+			Expect(nhc.Status.UnavailableRemediators).To(ConsistOf(&v1alpha1.UnavailableRemediator{
+				Kind:    "FenceRemediation",
+				Message: "Deployment remediators/fence has no ready replicas",
+				Since:   metav1.Time{Time: now},
+			}))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonRemediatorUnavailable)))
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorUnavailable)).To(BeTrue())
+
+			By("skipping the remediation of another node")
+			other := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+			resources.UpdateStatusNodeUnhealthy(other, nhc, now)
+			template, _, err = rm.GetCurrentTemplateWithTimeout(other, nhc, currentTime())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.GetKind()).To(Equal("RebootRemediationTemplate"))
+			skipped := nhc.Status.UnhealthyNodes[1].Remediations[0]
+			Expect(skipped.Resource.Kind).To(Equal("FenceRemediation"))
+			Expect(skipped.Phase).To(Equal(v1alpha1.RemediationPhaseNotApplicable))
+			Expect(skipped.Reason).To(Equal(v1alpha1.RemediationReasonRemediatorUnavailable))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonNotApplicable)))
+
+			By("releasing the remediator when it runs again")
+			setReadyReplicas(2)
+			Expect(nhc.Status.UnavailableRemediators).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonRemediatorAvailable)))
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorUnavailable)).To(BeTrue())
+		})
+
+		It("keeps the time since when the remediator isn't running", func() {
+			setReadyReplicas(0)
+			since := nhc.Status.UnavailableRemediators[0].Since
+			now = now.Add(time.Minute)
+			setReadyReplicas(0)
+			Expect(nhc.Status.UnavailableRemediators[0].Since).To(Equal(since))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		It("reports wrong Deployment references", func() {
+			nhc.Spec.RemediatorHealthCheck[0].DeploymentRef.Name = "typo"
+			Expect(r.updateUnavailableRemediators(context.Background(), c, nhc, now)).To(Succeed())
+			updateRemediatorUnavailableCondition(nhc)
+			condition := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorUnavailable)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("remediators/typo"))
+		})
+
+		It("only warns for classic remediation", func() {
+			nhc.Spec.EscalatingRemediations = nil
+			nhc.Spec.RemediationTemplate = &v1.ObjectReference{
+				APIVersion: "remediation.example.com/v1",
+				Kind:       "FenceRemediationTemplate",
+				Namespace:  "default",
+				Name:       "template",
+			}
+			setReadyReplicas(0)
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorUnavailable)).To(BeTrue())
+			template, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.GetKind()).To(Equal("FenceRemediationTemplate"))
+		})
+
+		It("forgets unavailable remediators when the health check is removed", func() {
+			setReadyReplicas(0)
+			nhc.Spec.RemediatorHealthCheck = nil
+			updateRemediatorUnavailableCondition(nhc)
+			Expect(nhc.Status.UnavailableRemediators).To(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediatorUnavailable)).To(BeTrue())
+		})
+	})
+
+	Context("Remediation routes", func() {
+		const (
+			escalation = "escalation"
+			template   = "template"
+			inline     = "inline"
+		)
+
+		newRoutedNHC := func(routes ...string) *v1alpha1.NodeHealthCheck {
+			nhc := newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = nil
+			for _, route := range routes {
+				switch route {
+				case escalation:
+					nhc.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{{
+						RemediationTemplate: v1.ObjectReference{Kind: "RebootRemediationTemplate", Name: "reboot"},
+						Order:               1,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+					}}
+				case template:
+					nhc.Spec.RemediationTemplate = &v1.ObjectReference{Kind: "RebootRemediationTemplate", Name: "reboot"}
+				case inline:
+					nhc.Spec.InlineRemediationTemplate = &v1alpha1.InlineRemediationTemplate{Kind: "RebootRemediation"}
+				}
+			}
+			return nhc
+		}
+
+		DescribeTable("resolves the route with the highest precedence",
+			func(routes []string, expectedType v1alpha1.RemediationRouteType, expectedReason string) {
+				route := resources.ResolveRemediationRoute(newRoutedNHC(routes...))
+				Expect(route).ToNot(BeNil())
+				Expect(route.Type).To(Equal(expectedType))
+				Expect(route.Reason).To(Equal(expectedReason))
+			},
+			Entry("escalating remediations only", []string{escalation},
+				v1alpha1.RemediationRouteEscalation, "escalatingRemediations is the only configured route"),
+			Entry("remediation template only", []string{template},
+				v1alpha1.RemediationRouteTemplate, "remediationTemplate is the only configured route"),
+			Entry("inline remediation template only", []string{inline},
+				v1alpha1.RemediationRouteInlineTemplate, "inlineRemediationTemplate is the only configured route"),
+			Entry("escalating remediations and remediation template", []string{template, escalation},
+				v1alpha1.RemediationRouteEscalation, "escalatingRemediations takes precedence over remediationTemplate"),
+			Entry("escalating remediations and inline remediation template", []string{inline, escalation},
+				v1alpha1.RemediationRouteEscalation, "escalatingRemediations takes precedence over inlineRemediationTemplate"),
+			Entry("remediation template and inline remediation template", []string{inline, template},
+				v1alpha1.RemediationRouteTemplate, "remediationTemplate takes precedence over inlineRemediationTemplate"),
+			Entry("all routes", []string{inline, template, escalation},
+				v1alpha1.RemediationRouteEscalation, "escalatingRemediations takes precedence over remediationTemplate, inlineRemediationTemplate"),
+		)
+
+		It("doesn't resolve a route without configured routes", func() {
+			Expect(resources.ResolveRemediationRoute(newRoutedNHC())).To(BeNil())
+		})
+
+		It("records route changes in the status", func() {
+			nhc := newRoutedNHC(template)
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+			resources.UpdateStatusNodeUnhealthy(node, nhc, time.Now())
+
+			Expect(resources.UpdateStatusRemediationRoute(node.GetName(), nhc, resources.ResolveRemediationRoute(nhc))).To(BeTrue())
+			Expect(nhc.Status.UnhealthyNodes[0].Route.Type).To(Equal(v1alpha1.RemediationRouteTemplate))
+
+			By("not reporting an unchanged route")
+			Expect(resources.UpdateStatusRemediationRoute(node.GetName(), nhc, resources.ResolveRemediationRoute(nhc))).To(BeFalse())
+
+			By("reporting a changed route")
+			nhc.Spec.EscalatingRemediations = newRoutedNHC(escalation).Spec.EscalatingRemediations
+			Expect(resources.UpdateStatusRemediationRoute(node.GetName(), nhc, resources.ResolveRemediationRoute(nhc))).To(BeTrue())
+			Expect(nhc.Status.UnhealthyNodes[0].Route.Type).To(Equal(v1alpha1.RemediationRouteEscalation))
+		})
+	})
+
+	Context("Remediation priority", func() {
+		const priorityLabel = "example.com/remediation-priority"
+		var (
+			c     client.Client
+			nhc   *v1alpha1.NodeHealthCheck
+			nodes []v1.Node
+		)
+
+		newPriorityNode := func(name, priority string) v1.Node {
+			node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if priority != "" {
+				node.Labels = map[string]string{priorityLabel: priority}
+			}
+			return node
+		}
+
+		// acquire mimics the remediation loop: nodes are sorted by priority, and try to get a slot one after another
+		acquire := func(l limiter.Limiter) []string {
+			utils.SortNodesByPriority(nodes, nhc.Spec.PriorityLabel)
+			var acquired []string
+			for _, node := range nodes {
+				ok, err := l.TryAcquire(context.Background(), nhc, node.GetName())
+				Expect(err).ToNot(HaveOccurred())
+				if ok {
+					acquired = append(acquired, node.GetName())
+				}
+			}
+			return acquired
+		}
+
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			nhc.Spec.PriorityLabel = priorityLabel
+			nodes = []v1.Node{
+				newPriorityNode("low", "1"),
+				newPriorityNode("none", ""),
+				newPriorityNode("invalid", "high"),
+				newPriorityNode("high", "10"),
+				newPriorityNode("medium", "5"),
+			}
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(nhc.DeepCopy()).Build()
+		})
+
+		It("sorts nodes by descending priority", func() {
+			utils.SortNodesByPriority(nodes, priorityLabel)
+			var names []string
+			for _, node := range nodes {
+				names = append(names, node.GetName())
+			}
+			Expect(names).To(Equal([]string{"high", "medium", "low", "none", "invalid"}))
+		})
+
+		It("remediates nodes with higher priority first under a concurrency cap", func() {
+			l := limiter.NewLimiter(c, 2, controllerruntime.Log)
+			Expect(acquire(l)).To(ConsistOf("high", "medium"))
+		})
+
+		When("a node with lower priority is remediated already", func() {
+			BeforeEach(func() {
+				nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{
+					Name:         "low",
+					Remediations: []*v1alpha1.Remediation{{Phase: v1alpha1.RemediationPhaseRunning}},
+				}}
+			})
+
+			It("doesn't interrupt its remediation", func() {
+				l := limiter.NewLimiter(c, 2, controllerruntime.Log)
+				Expect(acquire(l)).To(ConsistOf("high", "low"))
+			})
+		})
+	})
+
+	Context("Topology serialization", func() {
+		const topologyKey = "medik8s.io/chassis"
+		var (
+			nodes      []v1.Node
+			remediated map[string]bool
+			now        time.Time
+		)
+
+		newTopologyNode := func(name, chassis string, unhealthyFor time.Duration) v1.Node {
+			node := *newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			node.Status.Conditions[0].LastTransitionTime = metav1.Time{Time: now.Add(-unhealthyFor)}
+			if chassis != "" {
+				node.Labels[topologyKey] = chassis
+			}
+			return node
+		}
+
+		getDeferred := func() map[string]string {
+			return utils.GetTopologyDeferredNodes(nodes, topologyKey, 1, func(nodeName string) bool {
+				return remediated[nodeName]
+			}, func(node *v1.Node) time.Time {
+				return node.Status.Conditions[0].LastTransitionTime.Time
+			})
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			remediated = map[string]bool{}
+			nodes = []v1.Node{
+				newTopologyNode("a-short", "a", time.Minute),
+				newTopologyNode("a-long", "a", time.Hour),
+				newTopologyNode("b-only", "b", time.Minute),
+				newTopologyNode("no-chassis-1", "", time.Minute),
+				newTopologyNode("no-chassis-2", "", time.Minute),
+			}
+		})
+
+		It("remediates the longest unhealthy node per failure domain first", func() {
+			Expect(getDeferred()).To(Equal(map[string]string{"a-short": "a"}))
+		})
+
+		It("doesn't serialize without topology key", func() {
+			Expect(utils.GetTopologyDeferredNodes(nodes, "", 1, func(string) bool { return false }, func(*v1.Node) time.Time { return now })).To(BeEmpty())
+		})
+
+		When("a node of the failure domain is remediated already", func() {
+			BeforeEach(func() {
+				remediated["a-short"] = true
+			})
+
+			It("defers the other nodes of the failure domain", func() {
+				Expect(getDeferred()).To(Equal(map[string]string{"a-long": "a"}))
+			})
+		})
+	})
+
+	Context("Phase durations", func() {
+		It("accumulates the time spent in each phase", func() {
+			nhc := newNodeHealthCheck()
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nhc).WithStatusSubresource(nhc).Build()
+			r := &NodeHealthCheckReconciler{Client: c}
+
+			start := time.Now().Truncate(time.Second)
+			fakeTime = &start
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+			patch := func(after time.Duration, disabled bool) {
+				now := start.Add(after)
+				fakeTime = &now
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+				nhcOrig := nhc.DeepCopy()
+				status := metav1.ConditionFalse
+				if disabled {
+					status = metav1.ConditionTrue
+				}
+				meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+					Type:   v1alpha1.ConditionTypeDisabled,
+					Status: status,
+					Reason: v1alpha1.ConditionReasonEnabled,
+				})
+				Expect(r.patchStatus(context.Background(), controllerruntime.Log, nhc, nhcOrig)).To(Succeed())
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+			}
+
+			By("starting enabled")
+			patch(0, false)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+			Expect(nhc.Status.LastPhaseTransitionTime.Time).To(BeTemporally("==", start))
+			Expect(nhc.Status.PhaseDurations).To(BeEmpty())
+
+			By("staying enabled")
+			patch(5*time.Minute, false)
+			Expect(nhc.Status.LastPhaseTransitionTime.Time).To(BeTemporally("==", start))
+			Expect(resources.GetPhaseDurations(nhc, start.Add(5*time.Minute))).To(Equal(map[v1alpha1.NHCPhase]time.Duration{
+				v1alpha1.PhaseEnabled: 5 * time.Minute,
+			}))
+
+			By("getting disabled")
+			patch(10*time.Minute, true)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseDisabled))
+			Expect(nhc.Status.LastPhaseTransitionTime.Time).To(BeTemporally("==", start.Add(10*time.Minute)))
+
+			By("getting enabled again")
+			patch(25*time.Minute, false)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+			Expect(nhc.Status.PhaseDurations).To(ConsistOf(
+				v1alpha1.PhaseDuration{Phase: v1alpha1.PhaseEnabled, Duration: metav1.Duration{Duration: 10 * time.Minute}},
+				v1alpha1.PhaseDuration{Phase: v1alpha1.PhaseDisabled, Duration: metav1.Duration{Duration: 15 * time.Minute}},
+			))
+			Expect(resources.GetPhaseDurations(nhc, start.Add(30*time.Minute))).To(Equal(map[v1alpha1.NHCPhase]time.Duration{
+				v1alpha1.PhaseEnabled:  15 * time.Minute,
+				v1alpha1.PhaseDisabled: 15 * time.Minute,
+			}))
+		})
+	})
+
+	Context("Progressing condition", func() {
+		It("follows the remediation of a node until it recovered", func() {
+			nhc := newNodeHealthCheck()
+			nhc.Generation = 3
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nhc).WithStatusSubresource(nhc).Build()
+			r := &NodeHealthCheckReconciler{Client: c}
+			node := newNode("unhealthy", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+
+			patch := func(update func()) *metav1.Condition {
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+				nhcOrig := nhc.DeepCopy()
+				update()
+				Expect(r.patchStatus(context.Background(), controllerruntime.Log, nhc, nhcOrig)).To(Succeed())
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+				condition := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeProgressing)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.ObservedGeneration).To(Equal(nhc.GetGeneration()))
+				return condition
+			}
+
+			By("being idle")
+			condition := patch(func() {})
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonIdle))
+
+			By("draining the node")
+			condition = patch(func() {
+				resources.UpdateStatusNodeUnhealthy(node, nhc, time.Now())
+				resources.UpdateStatusDrain(node.GetName(), nhc, &v1alpha1.Drain{Phase: v1alpha1.DrainPhaseDraining})
+			})
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonDraining))
+			Expect(condition.Message).To(Equal("Draining 1 nodes"))
+
+			By("remediating the node")
+			condition = patch(func() {
+				resources.UpdateStatusDrain(node.GetName(), nhc, &v1alpha1.Drain{Phase: v1alpha1.DrainPhaseCompleted})
+				resources.UpdateStatusRemediationStarted(node, nhc, newRemediationCRForNHC(node.GetName(), nhc), nil)
+			})
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonRemediating))
+			Expect(condition.Message).To(Equal("Remediating 1 nodes"))
+
+			By("verifying the node")
+			condition = patch(func() {
+				resources.UpdateStatusVerification(node.GetName(), nhc, &v1alpha1.Verification{Phase: v1alpha1.VerificationPhaseVerifying})
+			})
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonVerifying))
+
+			By("recovering the node")
+			condition = patch(func() {
+				resources.UpdateStatusNodeHealthy(node.GetName(), nhc)
+			})
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonIdle))
+		})
+	})
+
+	Context("Effective config", func() {
+		var (
+			gvk schema.GroupVersionKind
+			rm  resources.Manager
+			r   *NodeHealthCheckReconciler
+			nhc *v1alpha1.NodeHealthCheck
+		)
+
+		BeforeEach(func() {
+			gvk = schema.GroupVersionKind{Group: "remediation.example.com", Version: "v1", Kind: "EffectiveRemediationTemplate"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+			mapper.Add(gvk, meta.RESTScopeNamespace)
+			c := fake.NewClientBuilder().WithRESTMapper(mapper).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			r = &NodeHealthCheckReconciler{
+				RemediationLimiter: limiter.NewLimiter(c, 5, controllerruntime.Log),
+			}
+			nhc = newNodeHealthCheck()
+			nhc.Status.DefaultTemplateNamespace = "operator-ns"
+			nhc.Spec.RemediationTemplate = &v1.ObjectReference{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Name:       "template",
+				UID:        "ignored",
+			}
+			nhc.Spec.UnhealthyConditions[1].RemediationEnabled = pointer.Bool(false)
+			nhc.Spec.RemediationThrottle = &v1alpha1.RemediationThrottle{MaxConcurrent: 2, MaxPerWindow: 3}
+			maxUnhealthy := intstr.FromString("40%")
+			nhc.Spec.MaxUnhealthy = &maxUnhealthy
+		})
+
+		It("records the resolved values", func() {
+			Expect(r.updateEffectiveConfig(nhc, rm, 5)).To(Succeed())
+			config := nhc.Status.EffectiveConfig
+			Expect(config).ToNot(BeNil())
+			Expect(config.MinHealthy).To(Equal(pointer.Int(3)))
+			Expect(config.MaxUnhealthy).To(Equal(pointer.Int(2)))
+			Expect(config.UnhealthyConditions).To(Equal(nhc.Spec.UnhealthyConditions[:1]))
+			Expect(config.RemediationTemplates).To(Equal([]v1.ObjectReference{{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Namespace:  "operator-ns",
+				Name:       "template",
+			}}))
+			Expect(config.MaxClusterRemediations).To(Equal(5))
+			Expect(config.MaxConcurrentRemediations).To(Equal(2))
+			Expect(config.MaxRemediationsPerWindow).To(Equal(3))
+			Expect(config.RemediationCreationConcurrency).To(Equal(1))
+			Expect(config.Hash).ToNot(BeEmpty())
+		})
+
+		It("falls back to the default min healthy when it isn't set", func() {
+			nhc.Spec.MinHealthy = nil
+			Expect(r.updateEffectiveConfig(nhc, rm, 5)).To(Succeed())
+			Expect(nhc.Status.EffectiveConfig.MinHealthy).To(Equal(pointer.Int(3)))
+
+			allowed, _, err := resources.CheckMinHealthy(nhc, 5, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+			allowed, _, err = resources.CheckMinHealthy(nhc, 5, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+		})
+
+		It("only updates on changed values", func() {
+			Expect(r.updateEffectiveConfig(nhc, rm, 5)).To(Succeed())
+			config := nhc.Status.EffectiveConfig
+
+			By("resolving the same values")
+			Expect(r.updateEffectiveConfig(nhc, rm, 5)).To(Succeed())
+			Expect(nhc.Status.EffectiveConfig).To(BeIdenticalTo(config))
+
+			By("observing more nodes")
+			Expect(r.updateEffectiveConfig(nhc, rm, 10)).To(Succeed())
+			Expect(nhc.Status.EffectiveConfig.MinHealthy).To(Equal(pointer.Int(6)))
+			Expect(nhc.Status.EffectiveConfig.Hash).ToNot(Equal(config.Hash))
+		})
+	})
+
+	Context("Status reason", func() {
+		var (
+			nhc     *v1alpha1.NodeHealthCheck
+			c       client.Client
+			r       *NodeHealthCheckReconciler
+			patches int
+		)
+
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			patches = 0
+			c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(nhc).WithStatusSubresource(nhc).WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, client client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					patches++
+					return client.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+			r = &NodeHealthCheckReconciler{Client: c}
+		})
+
+		// patch updates the status like a reconcile, and returns the serialized status
+		patch := func(update func()) []byte {
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+			nhcOrig := nhc.DeepCopy()
+			update()
+			Expect(r.patchStatus(context.Background(), controllerruntime.Log, nhc, nhcOrig)).To(Succeed())
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+			status, err := json.Marshal(nhc.Status)
+			Expect(err).ToNot(HaveOccurred())
+			return status
+		}
+
+		It("doesn't patch the status on identical state", func() {
+			nodes := []string{"node-c", "node-a", "node-b"}
+			started := metav1.NewTime(time.Now().Truncate(time.Second))
+			remediate := func() {
+				for _, name := range nodes {
+					node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+					cr := newRemediationCRForNHC(name, nhc)
+					cr.SetCreationTimestamp(started)
+					resources.UpdateStatusNodeUnhealthy(node, nhc, started.Time)
+					resources.UpdateStatusRemediationStarted(node, nhc, cr, nil)
+				}
+			}
+			first := patch(remediate)
+			Expect(patches).To(Equal(1))
+			Expect(nhc.Status.Reason).To(Equal("NHC is remediating 3 nodes"))
+
+			By("reconciling the same state again")
+			second := patch(func() {
+				nodes = []string{"node-b", "node-c", "node-a"}
+				remediate()
+			})
+			Expect(patches).To(Equal(1))
+			Expect(second).To(Equal(first))
+		})
+
+		It("doesn't patch the status when only the wording of the reason changed", func() {
+			patch(func() {})
+			Expect(patches).To(Equal(1))
+			Expect(nhc.Status.Reason).To(Equal("NHC is enabled, no ongoing remediation"))
+
+			By("storing a reason of an older release")
+			nhc.Status.Reason = "NHC is enabled, nothing to do"
+			Expect(c.Status().Update(context.Background(), nhc)).To(Succeed())
+			patch(func() {})
+			Expect(patches).To(Equal(1))
+			Expect(nhc.Status.Reason).To(Equal("NHC is enabled, nothing to do"))
+
+			By("writing the reason with other changes")
+			nhc.Generation++
+			Expect(c.Update(context.Background(), nhc)).To(Succeed())
+			patch(func() {})
+			Expect(patches).To(Equal(2))
+			Expect(nhc.Status.Reason).To(Equal("NHC is enabled, no ongoing remediation"))
+		})
+
+		It("lists the nodes which would be remediated in dry run mode", func() {
+			nhc.Spec.DryRun = true
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseDryRun))
+			Expect(nhc.Status.Reason).To(Equal("NHC is in dry run mode, would remediate 0 nodes"))
+
+			for _, name := range []string{"node-b", "node-a", "node-c"} {
+				node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				resources.UpdateStatusNodeUnhealthy(node, nhc, time.Now())
+			}
+			resources.UpdateStatusMatchedCondition(nhc, map[string]*resources.NodeEvaluation{
+				"node-a": {Condition: &v1alpha1.MatchedCondition{Type: v1.NodeReady, Status: v1.ConditionFalse}},
+			})
+			nhc.Status.UnhealthyNodes[0].MatchedCapacity = &v1alpha1.MatchedCapacity{ResourceName: v1.ResourceMemory}
+			resources.UpdateStatusDeferral("node-a", nhc, v1alpha1.DeferralReasonDryRun, "dry run", time.Now())
+			resources.UpdateStatusDeferral("node-b", nhc, v1alpha1.DeferralReasonDryRun, "dry run", time.Now())
+			// not remediated because of other reasons
+			resources.UpdateStatusDeferral("node-c", nhc, v1alpha1.DeferralReasonMaxUnhealthyReached, "max unhealthy", time.Now())
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Reason).To(Equal("NHC is in dry run mode, would remediate 2 nodes, nodes which would be remediated: node-a (Ready=False), node-b (insufficient memory capacity)"))
+
+			By("pausing takes precedence")
+			nhc.Spec.PauseRequests = []string{"maintenance"}
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhasePaused))
+		})
+
+		It("lists the nodes deferred by MaxUnhealthy", func() {
+			for _, name := range []string{"node-a", "node-b", "node-c"} {
+				node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				resources.UpdateStatusNodeUnhealthy(node, nhc, time.Now())
+			}
+			resources.UpdateStatusRemediationStarted(newNode("node-a", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node), nhc, newRemediationCRForNHC("node-a", nhc), nil)
+			resources.UpdateStatusDeferral("node-c", nhc, v1alpha1.DeferralReasonMaxUnhealthyReached, "max unhealthy", time.Now())
+			resources.UpdateStatusDeferral("node-b", nhc, v1alpha1.DeferralReasonMaxUnhealthyReached, "max unhealthy", time.Now())
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+			Expect(nhc.Status.Reason).To(Equal("NHC is remediating 1 nodes, MaxUnhealthy is reached, deferred nodes: node-b, node-c"))
+		})
+
+		It("lists the nodes deferred until the next remediation window", func() {
+			// a Monday
+			now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+			nhc.Spec.RemediationWindows = []v1alpha1.TimeWindow{{
+				Start: "0 22 * * *",
+				End:   "0 4 * * *",
+			}}
+			for _, name := range []string{"node-b", "node-a"} {
+				node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				resources.UpdateStatusNodeUnhealthy(node, nhc, now)
+				resources.UpdateStatusDeferral(name, nhc, v1alpha1.DeferralReasonOutsideRemediationWindow, "outside of window", now)
+			}
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseWaitingForWindow))
+			Expect(nhc.Status.Reason).To(Equal("NHC is waiting for the next remediation window at 2024-01-15T22:00:00Z, deferred nodes: node-a, node-b"))
+
+			By("opening the window")
+			now = now.Add(11 * time.Hour)
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+		})
+
+		It("limits the listed nodes and the length", func() {
+			for i := 0; i < maxStatusReasonNodes+2; i++ {
+				node := newNode(fmt.Sprintf("node-%02d", i), v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				resources.UpdateStatusNodeUnhealthy(node, nhc, time.Now())
+				resources.UpdateStatusRemediationStarted(node, nhc, newRemediationCRForNHC(node.GetName(), nhc), nil)
+				nhc.Status.UnhealthyNodes[i].MatchedCapacity = &v1alpha1.MatchedCapacity{ResourceName: v1.ResourceMemory}
+			}
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Reason).To(HavePrefix("NHC is remediating 12 nodes, nodes with insufficient capacity: node-00 (memory), "))
+			Expect(nhc.Status.Reason).To(HaveSuffix("node-09 (memory) and 2 more"))
+
+			meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+				Type:    v1alpha1.ConditionTypeDisabled,
+				Status:  metav1.ConditionTrue,
+				Reason:  v1alpha1.ConditionReasonDisabledTemplateInvalid,
+				Message: strings.Repeat("ä", maxStatusReasonLength),
+			})
+			updatePhaseAndReason(nhc)
+			Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseDisabled))
+			Expect(len(nhc.Status.Reason)).To(BeNumerically("<=", maxStatusReasonLength))
+			Expect(nhc.Status.Reason).To(HaveSuffix(statusReasonTruncated))
+			Expect(utf8.ValidString(nhc.Status.Reason)).To(BeTrue())
+		})
+	})
+
+	Context("Unhealthy condition requeue", func() {
+		var (
+			nhc *v1alpha1.NodeHealthCheck
+			r   *NodeHealthCheckReconciler
+			now time.Time
+		)
+
+		newNodeUnhealthySince := func(name string, since time.Time) v1.Node {
+			node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			node.Status.Conditions[0].LastTransitionTime = metav1.Time{Time: since}
+			return *node
+		}
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+			nhc = newNodeHealthCheck()
+			nhc.Spec.UnhealthyConditions = []v1alpha1.UnhealthyCondition{{
+				Type:     v1.NodeReady,
+				Status:   v1.ConditionFalse,
+				Duration: metav1.Duration{Duration: 30 * time.Second},
+			}}
+			r = &NodeHealthCheckReconciler{
+				Log:                 controllerruntime.Log,
+				Recorder:            record.NewFakeRecorder(10),
+				MHCChecker:          mhc.DummyChecker{},
+				ConnectivityChecker: connectivity.NewChecker(false, controllerruntime.Log),
+				HeartbeatChecker:    heartbeat.NewChecker(false, controllerruntime.Log),
+				UtilizationChecker:  utilization.NewChecker(false, controllerruntime.Log),
+			}
+		})
+
+		It("requeues when the earliest duration expires", func() {
+			nodes := []v1.Node{
+				newNodeUnhealthySince("unhealthy-soon", now.Add(-20*time.Second)),
+				newNodeUnhealthySince("unhealthy-later", now.Add(-5*time.Second)),
+			}
+			_, soonMatchingNodes, matchingNodes, _, requeueAfter, err := r.checkNodeConditions(context.Background(), fake.NewClientBuilder().Build(), nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matchingNodes).To(BeEmpty())
+			Expect(soonMatchingNodes).To(HaveLen(2))
+			Expect(*requeueAfter).To(Equal(10*time.Second + time.Second))
+
+			By("matching when requeued")
+			now = now.Add(*requeueAfter)
+			_, soonMatchingNodes, matchingNodes, _, requeueAfter, err = r.checkNodeConditions(context.Background(), fake.NewClientBuilder().Build(), nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matchingNodes).To(ConsistOf(HaveField("Name", "unhealthy-soon")))
+			Expect(soonMatchingNodes).To(ConsistOf(HaveField("Name", "unhealthy-later")))
+			Expect(*requeueAfter).To(Equal(14*time.Second + time.Second))
+		})
+
+		It("includes the clock skew of transition times in the future", func() {
+			nodes := []v1.Node{newNodeUnhealthySince("skewed", now.Add(10*time.Second))}
+			_, soonMatchingNodes, _, _, requeueAfter, err := r.checkNodeConditions(context.Background(), fake.NewClientBuilder().Build(), nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(soonMatchingNodes).To(HaveLen(1))
+			Expect(*requeueAfter).To(Equal(40*time.Second + time.Second))
+		})
+	})
+
+	Context("Remediation history", func() {
+		var (
+			nhc *v1alpha1.NodeHealthCheck
+			r   *NodeHealthCheckReconciler
+			now time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+			nhc = newNodeHealthCheck()
+			r = &NodeHealthCheckReconciler{Recorder: record.NewFakeRecorder(10)}
+		})
+
+		finish := func(nodeName, outcome string) {
+			remediation := &v1alpha1.Remediation{
+				Resource:     v1.ObjectReference{Kind: "FenceRemediation"},
+				Started:      metav1.Time{Time: now.Add(-time.Minute)},
+				TemplateName: "fence",
+			}
+			r.observeRemediationOutcome(nhc, nodeName, remediation, outcome)
+		}
+
+		It("records the outcome of finished remediations", func() {
+			finish("node-a", metrics.RemediationOutcomeTimedOut)
+			finish("node-a", metrics.RemediationOutcomeSucceeded)
+			finish("node-b", metrics.RemediationOutcomeFailed)
+			finish("node-c", metrics.RemediationOutcomeExhausted)
+			Expect(nhc.Status.RemediationHistory).To(HaveLen(4))
+			Expect(nhc.Status.RemediationHistory[0]).To(Equal(v1alpha1.NodeRemediationRecord{
+				NodeName:     "node-a",
+				TemplateKind: "FenceRemediationTemplate",
+				TemplateName: "fence",
+				Started:      metav1.Time{Time: now.Add(-time.Minute)},
+				Finished:     metav1.Time{Time: now},
+				Outcome:      v1alpha1.RemediationOutcomeTimedOut,
+			}))
+			Expect(nhc.Status.RemediationHistory).To(HaveEach(HaveField("TemplateKind", "FenceRemediationTemplate")))
+			Expect(nhc.Status.RemediationHistory[1].Outcome).To(Equal(v1alpha1.RemediationOutcomeSucceeded))
+			Expect(nhc.Status.RemediationHistory[2].Outcome).To(Equal(v1alpha1.RemediationOutcomeFailed))
+			Expect(nhc.Status.RemediationHistory[3].Outcome).To(Equal(v1alpha1.RemediationOutcomeTimedOut))
+		})
+
+		It("keeps the latest HistoryMaxEntries records", func() {
+			for i := 0; i < 10; i++ {
+				finish(fmt.Sprintf("node-%d", i), metrics.RemediationOutcomeSucceeded)
+			}
+			By("defaulting to 8 records")
+			Expect(nhc.Status.RemediationHistory).To(HaveLen(8))
+			Expect(nhc.Status.RemediationHistory[0].NodeName).To(Equal("node-2"))
+
+			By("trimming the history when HistoryMaxEntries is lowered")
+			nhc.Spec.HistoryMaxEntries = 3
+			resources.TrimStatusRemediationHistory(nhc)
+			Expect(nhc.Status.RemediationHistory).To(HaveLen(3))
+			Expect(nhc.Status.RemediationHistory[0].NodeName).To(Equal("node-7"))
+			Expect(nhc.Status.RemediationHistory[2].NodeName).To(Equal("node-9"))
+		})
+	})
+
+	Context("Remediation cooldown", func() {
+		var (
+			nhc *v1alpha1.NodeHealthCheck
+			now time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationCooldown = &metav1.Duration{Duration: 10 * time.Minute}
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{
+				{
+					Name:         "node-a",
+					Remediations: []*v1alpha1.Remediation{{Resource: v1.ObjectReference{Kind: "FenceRemediation"}}},
+				},
+				{
+					Name: "node-b",
+				},
+			}
+		})
+
+		It("records finished remediations of nodes", func() {
+			resources.RecordStatusRemediationCooldown("node-a", nhc, now)
+			resources.RecordStatusRemediationCooldown("node-b", nhc, now)
+			Expect(nhc.Status.CoolingDownNodes).To(ConsistOf(&v1alpha1.CoolingDownNode{
+				Name:                "node-a",
+				RemediationFinished: metav1.Time{Time: now},
+			}))
+
+			By("updating the time of the next finished remediation")
+			resources.RecordStatusRemediationCooldown("node-a", nhc, now.Add(time.Minute))
+			Expect(nhc.Status.CoolingDownNodes).To(HaveLen(1))
+			Expect(nhc.Status.CoolingDownNodes[0].RemediationFinished.Time).To(Equal(now.Add(time.Minute)))
+		})
+
+		It("doesn't record finished remediations without cooldown", func() {
+			nhc.Spec.RemediationCooldown = nil
+			resources.RecordStatusRemediationCooldown("node-a", nhc, now)
+			Expect(nhc.Status.CoolingDownNodes).To(BeEmpty())
+		})
+
+		It("returns the remaining cooldown", func() {
+			resources.RecordStatusRemediationCooldown("node-a", nhc, now)
+			remaining := resources.GetRemediationCooldownRemaining("node-a", nhc, now.Add(4*time.Minute))
+			Expect(remaining).ToNot(BeNil())
+			Expect(*remaining).To(Equal(6 * time.Minute))
+			Expect(resources.GetRemediationCooldownRemaining("node-b", nhc, now.Add(4*time.Minute))).To(BeNil())
+			Expect(resources.GetRemediationCooldownRemaining("node-a", nhc, now.Add(10*time.Minute))).To(BeNil())
+		})
+
+		It("prunes expired cooldowns", func() {
+			resources.RecordStatusRemediationCooldown("node-a", nhc, now)
+			resources.PruneStatusCoolingDownNodes(nhc, now.Add(9*time.Minute))
+			Expect(nhc.Status.CoolingDownNodes).To(HaveLen(1))
+			resources.PruneStatusCoolingDownNodes(nhc, now.Add(10*time.Minute))
+			Expect(nhc.Status.CoolingDownNodes).To(BeEmpty())
+
+			By("forgetting all nodes when the cooldown is removed")
+			resources.RecordStatusRemediationCooldown("node-a", nhc, now)
+			nhc.Spec.RemediationCooldown = nil
+			resources.PruneStatusCoolingDownNodes(nhc, now)
+			Expect(nhc.Status.CoolingDownNodes).To(BeNil())
+		})
+	})
+
+	Context("Polling interval", func() {
+		It("requeues at least every polling interval", func() {
+			nhc := newNodeHealthCheck()
+			Expect(getPollingInterval(nhc)).To(BeNil())
+
+			nhc.Spec.PollingInterval = &metav1.Duration{Duration: 5 * time.Minute}
+			result := reconcile.Result{}
+			updateRequeueAfter(&result, getPollingInterval(nhc))
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+			By("keeping earlier requeues")
+			result = reconcile.Result{RequeueAfter: time.Minute}
+			updateRequeueAfter(&result, getPollingInterval(nhc))
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			By("ignoring a non positive interval")
+			nhc.Spec.PollingInterval = &metav1.Duration{}
+			Expect(getPollingInterval(nhc)).To(BeNil())
+		})
+	})
+
+	Context("Remediation windows", func() {
+		// a Monday
+		now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+		It("allows remediation at any time without windows", func() {
+			open, nextChange := utils.GetRemediationWindowState(nil, now)
+			Expect(open).To(BeTrue())
+			Expect(nextChange.IsZero()).To(BeTrue())
+		})
+
+		It("returns whether a window is open and when this changes", func() {
+			windows := []v1alpha1.TimeWindow{{
+				Start: "0 22 * * 1-5",
+				End:   "0 4 * * 2-6",
+			}}
+			open, nextChange := utils.GetRemediationWindowState(windows, now)
+			Expect(open).To(BeFalse())
+			Expect(nextChange).To(BeTemporally("==", time.Date(2024, 1, 15, 22, 0, 0, 0, time.UTC)))
+
+			By("being within the window")
+			open, nextChange = utils.GetRemediationWindowState(windows, now.Add(12*time.Hour))
+			Expect(open).To(BeTrue())
+			Expect(nextChange).To(BeTemporally("==", time.Date(2024, 1, 16, 4, 0, 0, 0, time.UTC)))
+
+			By("being outside of the window on weekends")
+			saturday := time.Date(2024, 1, 20, 23, 0, 0, 0, time.UTC)
+			open, nextChange = utils.GetRemediationWindowState(windows, saturday)
+			Expect(open).To(BeFalse())
+			Expect(nextChange).To(BeTemporally("==", time.Date(2024, 1, 22, 22, 0, 0, 0, time.UTC)))
+		})
+
+		It("evaluates windows in their time zone", func() {
+			windows := []v1alpha1.TimeWindow{{
+				Start:    "0 12 * * *",
+				End:      "0 14 * * *",
+				TimeZone: "Europe/Berlin",
+			}}
+			// 13:00 in Berlin during winter time
+			open, nextChange := utils.GetRemediationWindowState(windows, now)
+			Expect(open).To(BeTrue())
+			Expect(nextChange).To(BeTemporally("==", time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC)))
+		})
+
+		It("uses the earliest change of multiple windows", func() {
+			windows := []v1alpha1.TimeWindow{
+				{Start: "0 22 * * *", End: "0 23 * * *"},
+				{Start: "0 18 * * *", End: "0 19 * * *"},
+				{Start: "0 11 * * *", End: "0 20 * * *"},
+			}
+			open, nextChange := utils.GetRemediationWindowState(windows, now)
+			Expect(open).To(BeTrue())
+			Expect(nextChange).To(BeTemporally("==", time.Date(2024, 1, 15, 20, 0, 0, 0, time.UTC)))
+
+			open, nextChange = utils.GetRemediationWindowState(windows[:2], now)
+			Expect(open).To(BeFalse())
+			Expect(nextChange).To(BeTemporally("==", time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("Last reconcile time", func() {
+		It("is only refreshed when it is older than the interval", func() {
+			nhc := newNodeHealthCheck()
+			r := &NodeHealthCheckReconciler{LastReconcileTimeInterval: 5 * time.Minute}
+			start := time.Now().Truncate(time.Second)
+
+			By("setting it initially")
+			r.updateLastReconcileTime(nhc, start)
+			Expect(nhc.Status.LastReconcileTime.Time).To(BeTemporally("==", start))
+
+			By("not refreshing it within the interval")
+			r.updateLastReconcileTime(nhc, start.Add(4*time.Minute))
+			Expect(nhc.Status.LastReconcileTime.Time).To(BeTemporally("==", start))
+
+			By("refreshing it after the interval")
+			r.updateLastReconcileTime(nhc, start.Add(5*time.Minute))
+			Expect(nhc.Status.LastReconcileTime.Time).To(BeTemporally("==", start.Add(5*time.Minute)))
+
+			By("removing it when disabled")
+			r.LastReconcileTimeInterval = 0
+			r.updateLastReconcileTime(nhc, start.Add(6*time.Minute))
+			Expect(nhc.Status.LastReconcileTime).To(BeNil())
+		})
+	})
+
+	Context("Preexisting unhealthy conditions", func() {
+		var (
+			r         *NodeHealthCheckReconciler
+			nhc       *v1alpha1.NodeHealthCheck
+			created   time.Time
+			newNodeAt func(name string, transitionTime time.Time) v1.Node
+		)
+
+		BeforeEach(func() {
+			r = &NodeHealthCheckReconciler{
+				Recorder: record.NewFakeRecorder(10),
+			}
+			created = time.Now().Add(-time.Hour)
+			nhc = newNodeHealthCheck()
+			nhc.CreationTimestamp = metav1.NewTime(created)
+			nhc.Spec.IgnorePreexistingConditions = true
+			newNodeAt = func(name string, transitionTime time.Time) v1.Node {
+				node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				node.Status.Conditions[0].LastTransitionTime = metav1.NewTime(transitionTime)
+				return *node
+			}
+		})
+
+		It("ignores nodes which were unhealthy before the NHC was created", func() {
+			oldNode := newNodeAt("old", created.Add(-24*time.Hour))
+			newNode := newNodeAt("new", created.Add(time.Minute))
+			remediate, ignored := r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode, newNode}, nil, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(remediate[0].Name).To(Equal("new"))
+			Expect(ignored).To(HaveLen(1))
+			Expect(ignored[0].Name).To(Equal("old"))
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(ConsistOf(v1alpha1.IgnoredUnhealthyNode{
+				Name:   "old",
+				Reason: v1alpha1.IgnoredReasonPreexistingCondition,
+				Since:  oldNode.Status.Conditions[0].LastTransitionTime,
+			}))
+
+			By("remediating the node after it got healthy and unhealthy again")
+			oldNode.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+			remediate, ignored = r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode, newNode}, nil, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(2))
+			Expect(ignored).To(BeEmpty())
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(BeEmpty())
+		})
+
+		It("remediates ignored nodes with the remediate-preexisting-condition annotation", func() {
+			oldNode := newNodeAt("old", created.Add(-24*time.Hour))
+			_, ignored := r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode}, nil, controllerruntime.Log)
+			Expect(ignored).To(HaveLen(1))
+
+			oldNode.Annotations = map[string]string{annotations.RemediatePreexistingConditionAnnotation: ""}
+			remediate, ignored := r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode}, nil, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(ignored).To(BeEmpty())
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(BeEmpty())
+		})
+
+		It("considers since when nodes are unreachable", func() {
+			oldNode := newNodeAt("old", created.Add(-24*time.Hour))
+			evaluations := map[string]*resources.NodeEvaluation{"old": {UnreachableSince: &metav1.Time{Time: created.Add(time.Minute)}}}
+			remediate, ignored := r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode}, evaluations, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(ignored).To(BeEmpty())
+		})
+
+		It("doesn't ignore nodes with ongoing remediation", func() {
+			oldNode := newNodeAt("old", created.Add(-24*time.Hour))
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{
+				Name:         "old",
+				Remediations: []*v1alpha1.Remediation{{Started: metav1.NewTime(created.Add(-time.Minute))}},
+			}}
+			remediate, ignored := r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode}, nil, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(ignored).To(BeEmpty())
+		})
+
+		It("doesn't ignore any node by default", func() {
+			nhc.Spec.IgnorePreexistingConditions = false
+			oldNode := newNodeAt("old", created.Add(-24*time.Hour))
+			remediate, ignored := r.filterPreexistingUnhealthyNodes(nhc, []v1.Node{oldNode}, nil, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(ignored).To(BeEmpty())
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(BeEmpty())
+		})
+	})
+
+	Context("Min node age", func() {
+		var (
+			r         *NodeHealthCheckReconciler
+			nhc       *v1alpha1.NodeHealthCheck
+			now       time.Time
+			newNodeAt func(name string, created time.Time) v1.Node
+		)
+
+		BeforeEach(func() {
+			now = time.Now()
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			r = &NodeHealthCheckReconciler{
+				Recorder: record.NewFakeRecorder(10),
+			}
+			nhc = newNodeHealthCheck()
+			nhc.Spec.MinNodeAge = &metav1.Duration{Duration: 10 * time.Minute}
+			newNodeAt = func(name string, created time.Time) v1.Node {
+				node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				node.CreationTimestamp = metav1.NewTime(created)
+				return *node
+			}
+		})
+
+		It("doesn't remediate nodes which are younger than the min node age", func() {
+			oldNode := newNodeAt("old", now.Add(-time.Hour))
+			youngNode := newNodeAt("young", now.Add(-4*time.Minute))
+			remediate, tooYoung, requeueAfter := r.filterTooYoungNodes(nhc, []v1.Node{oldNode, youngNode}, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(remediate[0].Name).To(Equal("old"))
+			Expect(tooYoung).To(HaveLen(1))
+			Expect(tooYoung[0].Name).To(Equal("young"))
+			Expect(*requeueAfter).To(Equal(6*time.Minute + time.Second))
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(ConsistOf(v1alpha1.IgnoredUnhealthyNode{
+				Name:       "young",
+				Reason:     v1alpha1.IgnoredReasonNodeTooYoung,
+				Since:      youngNode.CreationTimestamp,
+				EligibleAt: &metav1.Time{Time: youngNode.CreationTimestamp.Add(10 * time.Minute)},
+			}))
+
+			By("keeping nodes ignored for other reasons")
+			nhc.Status.IgnoredUnhealthyNodes = append(nhc.Status.IgnoredUnhealthyNodes, v1alpha1.IgnoredUnhealthyNode{
+				Name:   "preexisting",
+				Reason: v1alpha1.IgnoredReasonPreexistingCondition,
+			})
+
+			By("remediating the node once it is old enough")
+			now = now.Add(6 * time.Minute)
+			remediate, tooYoung, requeueAfter = r.filterTooYoungNodes(nhc, []v1.Node{oldNode, youngNode}, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(2))
+			Expect(tooYoung).To(BeEmpty())
+			Expect(requeueAfter).To(BeNil())
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(ConsistOf(HaveField("Name", "preexisting")))
+		})
+
+		It("doesn't filter nodes with ongoing remediation", func() {
+			youngNode := newNodeAt("young", now.Add(-time.Minute))
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{
+				Name:         "young",
+				Remediations: []*v1alpha1.Remediation{{Started: metav1.NewTime(now)}},
+			}}
+			remediate, tooYoung, _ := r.filterTooYoungNodes(nhc, []v1.Node{youngNode}, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(tooYoung).To(BeEmpty())
+		})
+
+		It("doesn't filter any node by default", func() {
+			nhc.Spec.MinNodeAge = nil
+			youngNode := newNodeAt("young", now.Add(-time.Minute))
+			remediate, tooYoung, requeueAfter := r.filterTooYoungNodes(nhc, []v1.Node{youngNode}, controllerruntime.Log)
+			Expect(remediate).To(HaveLen(1))
+			Expect(tooYoung).To(BeEmpty())
+			Expect(requeueAfter).To(BeNil())
+			Expect(nhc.Status.IgnoredUnhealthyNodes).To(BeEmpty())
+		})
+	})
+
+	Context("Adaptive stabilization", func() {
+		var (
+			r    *NodeHealthCheckReconciler
+			nhc  *v1alpha1.NodeHealthCheck
+			node *v1.Node
+			now  time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Now()
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			r = &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: record.NewFakeRecorder(100),
+			}
+			nhc = newNodeHealthCheck()
+			nhc.Spec.UnhealthyConditions = []v1alpha1.UnhealthyCondition{{
+				Type:     v1.NodeReady,
+				Status:   v1.ConditionFalse,
+				Duration: metav1.Duration{Duration: time.Minute},
+			}}
+			nhc.Spec.AdaptiveStabilization = &v1alpha1.AdaptiveStabilization{
+				Enabled:     true,
+				Multiplier:  2,
+				Window:      metav1.Duration{Duration: time.Hour},
+				MaxDuration: &metav1.Duration{Duration: 3 * time.Minute},
+			}
+			node = newNode("flapping", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+		})
+
+		// flap lets the node get unhealthy now, and checks that it matches the unhealthy condition only after the
+		// given effective duration
+		flap := func(effectiveDuration time.Duration) {
+			node.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now)
+			now = now.Add(30 * time.Second)
+			resources.PruneStatusStabilizingNodes(nhc, now)
+			resources.UpdateStatusEffectiveDurations(nhc, getEffectiveDurations(nhc, []v1.Node{*node}, now))
+			Expect(nhc.Status.StabilizingNodes).To(ContainElement(HaveField("EffectiveDuration", &metav1.Duration{Duration: effectiveDuration})))
+
+			matched, requeueAfter := r.getMatchingUnhealthyCondition(nhc, node)
+			Expect(matched).To(BeNil())
+			Expect(*requeueAfter).To(Equal(effectiveDuration - 30*time.Second + time.Second))
+
+			now = now.Add(effectiveDuration)
+			matched, _ = r.getMatchingUnhealthyCondition(nhc, node)
+			Expect(matched).ToNot(BeNil())
+			resources.UpdateStatusEffectiveDurations(nhc, getEffectiveDurations(nhc, nil, now))
+
+			By("recovering after remediation")
+			now = now.Add(time.Minute)
+			resources.RecordStatusStabilizationRemediation(node.GetName(), nhc, now)
+		}
+
+		It("needs longer unhealthy durations for each recent remediation", func() {
+			By("using the configured duration for the first remediation")
+			flap(time.Minute)
+			Expect(nhc.Status.StabilizingNodes).To(HaveLen(1))
+			Expect(nhc.Status.StabilizingNodes[0].RemediationsFinished).To(HaveLen(1))
+			Expect(nhc.Status.StabilizingNodes[0].EffectiveDuration).To(BeNil())
+
+			By("multiplying the duration for the second remediation")
+			flap(2 * time.Minute)
+
+			By("capping the duration for the third remediation")
+			flap(3 * time.Minute)
+			Expect(nhc.Status.StabilizingNodes[0].RemediationsFinished).To(HaveLen(3))
+
+			By("forgetting remediations after the window")
+			now = now.Add(time.Hour)
+			resources.PruneStatusStabilizingNodes(nhc, now)
+			Expect(nhc.Status.StabilizingNodes).To(BeEmpty())
+			flap(time.Minute)
+		})
+
+		It("uses the configured duration when disabled", func() {
+			flap(time.Minute)
+			nhc.Spec.AdaptiveStabilization.Enabled = false
+			resources.PruneStatusStabilizingNodes(nhc, now)
+			Expect(nhc.Status.StabilizingNodes).To(BeNil())
+			Expect(resources.GetStabilizedUnhealthyCondition(node.GetName(), nhc, nhc.Spec.UnhealthyConditions[0]).Duration.Duration).To(Equal(time.Minute))
+		})
+	})
+
+	Context("PodDisruptionBudgets", func() {
+		var (
+			c        client.Client
+			r        *NodeHealthCheckReconciler
+			recorder *record.FakeRecorder
+			nhc      *v1alpha1.NodeHealthCheck
+			node     *v1.Node
+			now      time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Now()
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RespectPodDisruptionBudgets = &v1alpha1.RespectPodDisruptionBudgets{
+				Enabled:         true,
+				OverrideTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			}
+			node = newNode("unhealthy", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{Name: node.GetName()}}
+
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "critical", Labels: map[string]string{"app": "critical"}},
+				Spec:       v1.PodSpec{NodeName: node.GetName()},
+				Status: v1.PodStatus{
+					Phase:      v1.PodRunning,
+					Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+				},
+			}
+			pdb := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "critical"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "critical"}},
+				},
+			}
+			c = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(pod, pdb).Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &NodeHealthCheckReconciler{
+				Recorder:       recorder,
+				DisruptionGate: disruption.NewGate(controllerruntime.Log),
+			}
+		})
+
+		It("defers remediation until the override timeout", func() {
+			deferred, requeueAfter, err := r.isPDBViolated(context.Background(), c, nhc, node, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deferred).To(BeTrue())
+			Expect(*requeueAfter).To(Equal(disruptionGateRequeueAfter))
+			Expect(resources.GetStatusPDBViolationSince(node.GetName(), nhc).Time).To(Equal(now))
+			deferral := resources.GetStatusDeferral(node.GetName(), nhc)
+			Expect(deferral.Reason).To(Equal(v1alpha1.DeferralReasonPDBViolation))
+			Expect(deferral.Message).To(ContainSubstring("default/critical"))
+
+			By("keeping the start of the deferral when other deferrals interleave")
+			resources.UpdateStatusDeferral(node.GetName(), nhc, v1alpha1.DeferralReasonRemediationThrottled, "throttled", now)
+			now = now.Add(9*time.Minute + 50*time.Second)
+			deferred, requeueAfter, err = r.isPDBViolated(context.Background(), c, nhc, node, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deferred).To(BeTrue())
+			Expect(*requeueAfter).To(Equal(11 * time.Second))
+
+			By("remediating after the override timeout")
+			now = now.Add(11 * time.Second)
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			deferred, _, err = r.isPDBViolated(context.Background(), c, nhc, node, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deferred).To(BeFalse())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonPDBViolationOverridden)))
+		})
+
+		It("doesn't check unreachable nodes", func() {
+			evaluation := &resources.NodeEvaluation{UnreachableSince: &metav1.Time{Time: now}}
+			deferred, _, err := r.isPDBViolated(context.Background(), c, nhc, node, evaluation, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deferred).To(BeFalse())
+
+			node.Status.Conditions[0].Status = v1.ConditionUnknown
+			deferred, _, err = r.isPDBViolated(context.Background(), c, nhc, node, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deferred).To(BeFalse())
+			Expect(resources.GetStatusPDBViolationSince(node.GetName(), nhc)).To(BeNil())
+		})
+	})
+
+	Context("Remediation CR cleanup failures", func() {
+		var (
+			c          client.Client
+			rm         resources.Manager
+			nhc        *v1alpha1.NodeHealthCheck
+			cr         *unstructured.Unstructured
+			failDelete bool
+		)
+
+		BeforeEach(func() {
+			gv := schema.GroupVersion{Group: "remediation.example.com", Version: "v1"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind("RebootRemediation"), meta.RESTScopeNamespace)
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationTemplate = &v1.ObjectReference{
+				APIVersion: gv.String(),
+				Kind:       "RebootRemediationTemplate",
+				Namespace:  "default",
+				Name:       "template",
+			}
+			cr = newRemediationCRForNHC("healthy-node", nhc)
+			failDelete = true
+			c = fake.NewClientBuilder().
+				WithRESTMapper(mapper).
+				WithObjects(cr).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if failDelete {
+							return errors.NewForbidden(schema.GroupResource{Group: gv.Group, Resource: "rebootremediations"}, obj.GetName(), fmt.Errorf("missing permissions"))
+						}
+						return client.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+		})
+
+		It("tracks remediation CRs which couldn't be deleted until deletion succeeds", func() {
+			_, err := rm.HandleHealthyNode("healthy-node", "healthy-node", nhc)
+			Expect(err).To(HaveOccurred())
+			trackFailedCleanUp(nhc, err)
+			Expect(nhc.Status.OrphanedRemediations).To(HaveLen(1))
+			orphaned := nhc.Status.OrphanedRemediations[0]
+			Expect(orphaned.Resource.Kind).To(Equal("RebootRemediation"))
+			Expect(orphaned.Resource.Name).To(Equal(cr.GetName()))
+			Expect(orphaned.NodeName).To(Equal("healthy-node"))
+			Expect(orphaned.Reason).To(ContainSubstring("missing permissions"))
+			updateCleanUpFailedCondition(nhc)
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeCleanupFailed)).To(BeTrue())
+
+			reconciler := &NodeHealthCheckReconciler{}
+			By("retrying with a failing deletion")
+			Expect(reconciler.retryOrphanedRemediationsCleanUp(nhc, rm, controllerruntime.Log)).To(BeTrue())
+			Expect(nhc.Status.OrphanedRemediations).To(HaveLen(1))
+			Expect(nhc.Status.OrphanedRemediations[0].Since).To(Equal(orphaned.Since))
+
+			By("retrying with a succeeding deletion")
+			failDelete = false
+			Expect(reconciler.retryOrphanedRemediationsCleanUp(nhc, rm, controllerruntime.Log)).To(BeFalse())
+			Expect(nhc.Status.OrphanedRemediations).To(BeEmpty())
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr.DeepCopy())).To(MatchError(ContainSubstring("not found")))
+			updateCleanUpFailedCondition(nhc)
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeCleanupFailed)).To(BeTrue())
+		})
+	})
+
+	Context("Min selected nodes", func() {
+		It("withholds remediation while too few nodes are selected", func() {
+			nhc := newNodeHealthCheck()
+			nhc.Spec.MinSelectedNodes = 3
+			recorder := record.NewFakeRecorder(10)
+			r := &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: recorder,
+			}
+
+			By("not adding the condition for big enough pools")
+			Expect(r.updatePoolTooSmallCondition(nhc, 3, controllerruntime.Log)).To(BeFalse())
+			Expect(meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypePoolTooSmall)).To(BeNil())
+
+			By("setting the condition when too few nodes are selected")
+			Expect(r.updatePoolTooSmallCondition(nhc, 2, controllerruntime.Log)).To(BeTrue())
+			condition := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypePoolTooSmall)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonSelectedNodesBelowMinimum))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonPoolTooSmall)))
+
+			By("emitting the event only once")
+			Expect(r.updatePoolTooSmallCondition(nhc, 1, controllerruntime.Log)).To(BeTrue())
+			Expect(recorder.Events).ToNot(Receive())
+
+			By("clearing the condition when nodes joined")
+			Expect(r.updatePoolTooSmallCondition(nhc, 4, controllerruntime.Log)).To(BeFalse())
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypePoolTooSmall)).To(BeTrue())
+		})
+	})
+
+	Context("Heartbeat sources", func() {
+		It("considers nodes with stale heartbeat as unhealthy", func() {
+			gvk := schema.GroupVersionKind{Group: "agent.example.com", Version: "v1", Kind: "NodeHeartbeat"}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+			mapper.Add(gvk, meta.RESTScopeRoot)
+			var heartbeats []client.Object
+			for name, lastHeartbeat := range map[string]time.Time{"alive": time.Now(), "stale": time.Now().Add(-time.Hour)} {
+				heartbeat := &unstructured.Unstructured{}
+				heartbeat.SetGroupVersionKind(gvk)
+				heartbeat.SetName(name)
+				Expect(unstructured.SetNestedField(heartbeat.Object, lastHeartbeat.Format(time.RFC3339), "status", "lastHeartbeatTime")).To(Succeed())
+				heartbeats = append(heartbeats, heartbeat)
+			}
+			c := fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(heartbeats...).Build()
+
+			nhc := newNodeHealthCheck()
+			nhc.Spec.HeartbeatSource = &v1alpha1.HeartbeatSource{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				StaleAfter: metav1.Duration{Duration: time.Minute},
+			}
+			nodes := []v1.Node{
+				*newNode("alive", v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node),
+				*newNode("stale", v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node),
+			}
+			r := &NodeHealthCheckReconciler{
+				Log:                 controllerruntime.Log,
+				Recorder:            record.NewFakeRecorder(10),
+				MHCChecker:          mhc.DummyChecker{},
+				ConnectivityChecker: connectivity.NewChecker(false, controllerruntime.Log),
+				HeartbeatChecker:    heartbeat.NewChecker(true, controllerruntime.Log),
+				UtilizationChecker:  utilization.NewChecker(false, controllerruntime.Log),
+			}
+			notMatchingNodes, _, matchingNodes, evaluations, requeueAfter, err := r.checkNodeConditions(context.Background(), c, nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notMatchingNodes).To(HaveLen(1))
+			Expect(notMatchingNodes[0].Name).To(Equal("alive"))
+			Expect(matchingNodes).To(HaveLen(1))
+			Expect(matchingNodes[0].Name).To(Equal("stale"))
+			Expect(evaluations["stale"].HeartbeatStaleSince).ToNot(BeNil())
+			// check back when the alive heartbeat gets stale
+			Expect(requeueAfter).ToNot(BeNil())
+			Expect(*requeueAfter).To(BeNumerically("<=", time.Minute+time.Second))
+
+			By("reporting the stale heartbeat in the status")
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{Name: "stale"}}
+			resources.UpdateStatusHeartbeatStaleSince(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].HeartbeatStaleSince).ToNot(BeNil())
+			Expect(nhc.Status.UnhealthyNodes[0].HeartbeatStaleSince.Time).To(BeTemporally("~", time.Now().Add(-59*time.Minute), 2*time.Second))
+		})
+	})
+
+	Context("Pre remediation drain", func() {
+		var (
+			nhc  *v1alpha1.NodeHealthCheck
+			node *v1.Node
+			c    client.Client
+			r    *NodeHealthCheckReconciler
+			now  time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Now()
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			nhc = newNodeHealthCheck()
+			nhc.Spec.PreRemediationDrain = &v1alpha1.PreRemediationDrain{
+				Enabled: true,
+				Timeout: &metav1.Duration{Duration: time.Minute},
+			}
+			node = newNode("unhealthy", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{Name: node.GetName()}}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "workload"},
+				Spec:       v1.PodSpec{NodeName: node.GetName()},
+			}
+			c = fake.NewClientBuilder().WithObjects(node, pod).WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceCreate: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+					// the pod is evicted, but doesn't terminate
+					return nil
+				},
+			}).Build()
+			r = &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: record.NewFakeRecorder(10),
+				Drainer:  drain.NewDrainer(controllerruntime.Log),
+			}
+		})
+
+		It("drains reachable nodes until the timeout expires", func() {
+			draining, requeueAfter, err := r.drainBeforeRemediation(context.Background(), c, nhc, node, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(draining).To(BeTrue())
+			Expect(requeueAfter).ToNot(BeNil())
+			Expect(*requeueAfter).To(Equal(drainRequeueAfter))
+			Expect(nhc.Status.UnhealthyNodes[0].Drain).ToNot(BeNil())
+			Expect(nhc.Status.UnhealthyNodes[0].Drain.Phase).To(Equal(v1alpha1.DrainPhaseDraining))
+			Expect(nhc.Status.UnhealthyNodes[0].Drain.Message).To(ContainSubstring("default/workload"))
+
+			updated := &v1.Node{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(node), updated)).To(Succeed())
+			Expect(updated.Spec.Unschedulable).To(BeTrue())
+
+			By("expiring the timeout")
+			afterTimeout := now.Add(time.Minute)
+			fakeTime = &afterTimeout
+			draining, _, err = r.drainBeforeRemediation(context.Background(), c, nhc, updated, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(draining).To(BeFalse())
+			Expect(nhc.Status.UnhealthyNodes[0].Drain.Phase).To(Equal(v1alpha1.DrainPhaseTimedOut))
+
+			By("uncordoning the node")
+			Expect(r.Drainer.Uncordon(context.Background(), c, node.GetName())).To(Succeed())
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(node), updated)).To(Succeed())
+			Expect(updated.Spec.Unschedulable).To(BeFalse())
+		})
+
+		It("skips unreachable nodes", func() {
+			node.Status.Conditions[0].Status = v1.ConditionUnknown
+			draining, _, err := r.drainBeforeRemediation(context.Background(), c, nhc, node, nil, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(draining).To(BeFalse())
+			Expect(nhc.Status.UnhealthyNodes[0].Drain).To(BeNil())
+
+			updated := &v1.Node{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(node), updated)).To(Succeed())
+			Expect(updated.Spec.Unschedulable).To(BeFalse())
+		})
+	})
+
+	Context("Unhealthy signals", func() {
+		var (
+			r   *NodeHealthCheckReconciler
+			nhc *v1alpha1.NodeHealthCheck
+			now time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			nhc = newNodeHealthCheck()
+			r = newSignalReconciler()
+		})
+
+		DescribeTable("considers nodes which match the signal for the duration as unhealthy",
+			func(signal unhealthySignal) {
+				signal.configure(nhc)
+				unhealthy, healthy, c := signal.newNodes(now)
+				nodes := []v1.Node{unhealthy, healthy}
+
+				By("tracking when the signal was observed first")
+				signal.track(r, c, nhc, nodes, now)
+				notMatchingNodes, soonMatchingNodes, matchingNodes, evaluations, requeueAfter, err := r.checkNodeConditions(context.Background(), c, nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notMatchingNodes).To(ConsistOf(HaveField("Name", healthy.GetName())))
+				Expect(soonMatchingNodes).To(ConsistOf(HaveField("Name", unhealthy.GetName())))
+				Expect(matchingNodes).To(BeEmpty())
+				Expect(evaluations).To(HaveEach(HaveField(signal.field, BeNil())))
+				Expect(*requeueAfter).To(Equal(time.Minute + time.Second))
+
+				By("matching after the duration")
+				start := metav1.Time{Time: now}
+				now = now.Add(2 * time.Minute)
+				signal.track(r, c, nhc, nodes, now)
+				_, _, matchingNodes, evaluations, _, err = r.checkNodeConditions(context.Background(), c, nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matchingNodes).To(ConsistOf(HaveField("Name", unhealthy.GetName())))
+				Expect(evaluations).To(HaveEach(HaveField("Condition", BeNil())))
+				Expect(evaluations[unhealthy.GetName()]).To(HaveField(signal.field, Equal(signal.matched(start))))
+
+				By("reporting the signal in the status")
+				nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{Name: unhealthy.GetName()}}
+				r.updateStatusUnhealthySignals(nhc, evaluations)
+				Expect(nhc.Status.UnhealthyNodes[0]).To(HaveField("Matched"+signal.field, Equal(signal.matched(start))))
+				Expect(nhc.Status.UnhealthyNodes[0].MatchedCondition).To(BeNil())
+				Expect(describeUnhealthiness(nhc.Status.UnhealthyNodes[0])).To(Equal(signal.description))
+
+				By("forgetting nodes which don't match the signal anymore")
+				signal.heal(c, &nodes[0])
+				signal.track(r, c, nhc, nodes, now)
+				_, _, matchingNodes, _, _, err = r.checkNodeConditions(context.Background(), c, nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matchingNodes).To(BeEmpty())
+				Expect(signal.tracked(nhc)).To(BeEmpty())
+			},
+			Entry("capacity", unhealthySignal{
+				field:       "Capacity",
+				description: "insufficient nvidia.com/gpu capacity",
+				configure: func(nhc *v1alpha1.NodeHealthCheck) {
+					nhc.Spec.UnhealthyCapacity = []v1alpha1.UnhealthyCapacity{{
+						ResourceName: "nvidia.com/gpu",
+						MinQuantity:  resource.MustParse("1"),
+						Duration:     metav1.Duration{Duration: time.Minute},
+					}}
+				},
+				newNodes: func(_ time.Time) (v1.Node, v1.Node, client.Client) {
+					return newGPUNode("gpus-lost", "0"), newGPUNode("gpus", "2"), fake.NewClientBuilder().Build()
+				},
+				track: func(_ *NodeHealthCheckReconciler, _ client.Client, nhc *v1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+					updateInsufficientCapacityNodes(nhc, nodes, now)
+				},
+				heal: func(_ client.Client, node *v1.Node) {
+					node.Status.Capacity = v1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+				},
+				tracked: func(nhc *v1alpha1.NodeHealthCheck) interface{} {
+					return nhc.Status.InsufficientCapacityNodes
+				},
+				matched: func(since metav1.Time) interface{} {
+					return &v1alpha1.MatchedCapacity{ResourceName: "nvidia.com/gpu", Since: since}
+				},
+			}),
+			Entry("taints", unhealthySignal{
+				field:       "Taint",
+				description: "taint node.kubernetes.io/unreachable:NoExecute",
+				configure: func(nhc *v1alpha1.NodeHealthCheck) {
+					nhc.Spec.UnhealthyTaints = []v1alpha1.UnhealthyTaint{{
+						Key:      v1.TaintNodeUnreachable,
+						Effect:   v1.TaintEffectNoExecute,
+						Duration: metav1.Duration{Duration: time.Minute},
+					}}
+				},
+				newNodes: func(now time.Time) (v1.Node, v1.Node, client.Client) {
+					added := metav1.NewTime(now)
+					return newTaintedNode("tainted", v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &added}),
+						newTaintedNode("other-effect", v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoSchedule}),
+						fake.NewClientBuilder().Build()
+				},
+				track: func(_ *NodeHealthCheckReconciler, _ client.Client, nhc *v1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+					updateTaintedNodes(nhc, nodes, now)
+				},
+				heal: func(_ client.Client, node *v1.Node) {
+					node.Spec.Taints = nil
+				},
+				tracked: func(nhc *v1alpha1.NodeHealthCheck) interface{} {
+					return nhc.Status.TaintedNodes
+				},
+				matched: func(since metav1.Time) interface{} {
+					return &v1alpha1.MatchedTaint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, Since: since}
+				},
+			}),
+			Entry("annotations", unhealthySignal{
+				field:       "Annotation",
+				description: "annotation node-problem/ssd-failing",
+				configure: func(nhc *v1alpha1.NodeHealthCheck) {
+					nhc.Spec.UnhealthyAnnotations = []v1alpha1.AnnotationMatch{{
+						Key:      "node-problem/ssd-failing",
+						Value:    "true",
+						Duration: metav1.Duration{Duration: time.Minute},
+					}}
+				},
+				newNodes: func(_ time.Time) (v1.Node, v1.Node, client.Client) {
+					return newAnnotatedNode("annotated", map[string]string{"node-problem/ssd-failing": "true"}),
+						newAnnotatedNode("other-value", map[string]string{"node-problem/ssd-failing": "false"}),
+						fake.NewClientBuilder().Build()
+				},
+				track: func(_ *NodeHealthCheckReconciler, _ client.Client, nhc *v1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+					updateAnnotatedNodes(nhc, nodes, now)
+				},
+				heal: func(_ client.Client, node *v1.Node) {
+					node.SetAnnotations(nil)
+				},
+				tracked: func(nhc *v1alpha1.NodeHealthCheck) interface{} {
+					return nhc.Status.AnnotatedNodes
+				},
+				matched: func(since metav1.Time) interface{} {
+					return &v1alpha1.MatchedAnnotation{Key: "node-problem/ssd-failing", Value: "true", Since: since}
+				},
+			}),
+			Entry("utilization", unhealthySignal{
+				field:       "Utilization",
+				description: "high memory utilization",
+				configure: func(nhc *v1alpha1.NodeHealthCheck) {
+					nhc.Spec.UnhealthyUtilization = []v1alpha1.UnhealthyUtilization{{
+						ResourceName:     v1.ResourceMemory,
+						ThresholdPercent: 90,
+						Duration:         metav1.Duration{Duration: time.Minute},
+					}}
+				},
+				newNodes: func(_ time.Time) (v1.Node, v1.Node, client.Client) {
+					return newMemoryNode("high"), newMemoryNode("normal"),
+						newNodeMetricsClient(newNodeMetrics("high", "7600Mi"), newNodeMetrics("normal", "4Gi"))
+				},
+				track: func(r *NodeHealthCheckReconciler, c client.Client, nhc *v1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time) {
+					Expect(r.updateHighUtilizationNodes(context.Background(), c, nhc, nodes, now)).To(Succeed())
+				},
+				heal: func(c client.Client, node *v1.Node) {
+					metrics := newNodeMetrics(node.GetName(), "")
+					Expect(c.Get(context.Background(), client.ObjectKeyFromObject(metrics), metrics)).To(Succeed())
+					Expect(unstructured.SetNestedField(metrics.Object, "6Gi", "usage", "memory")).To(Succeed())
+					Expect(c.Update(context.Background(), metrics)).To(Succeed())
+				},
+				tracked: func(nhc *v1alpha1.NodeHealthCheck) interface{} {
+					return nhc.Status.HighUtilizationNodes
+				},
+				matched: func(since metav1.Time) interface{} {
+					return &v1alpha1.MatchedUtilization{ResourceName: v1.ResourceMemory, Since: since}
+				},
+			}),
+		)
+
+		Context("capacity", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyCapacity = []v1alpha1.UnhealthyCapacity{{
+					ResourceName: "nvidia.com/gpu",
+					MinQuantity:  resource.MustParse("1"),
+					Duration:     metav1.Duration{Duration: time.Minute},
+				}}
+			})
+
+			It("considers nodes without the resource as having insufficient capacity", func() {
+				updateInsufficientCapacityNodes(nhc, []v1.Node{newGPUNode("gpus-absent", "")}, now)
+				Expect(nhc.Status.InsufficientCapacityNodes).To(ConsistOf(
+					v1alpha1.InsufficientCapacityNode{Name: "gpus-absent", ResourceName: "nvidia.com/gpu", Since: metav1.Time{Time: now}},
+				))
+			})
+
+			It("reconciles on capacity updates of the configured resources only", func() {
+				oldCapacity := v1.ResourceList{"nvidia.com/gpu": resource.MustParse("2"), v1.ResourceCPU: resource.MustParse("4")}
+				Expect(capacityNeedsReconcile(oldCapacity, oldCapacity.DeepCopy(), "nvidia.com/gpu")).To(BeFalse())
+
+				newCapacity := oldCapacity.DeepCopy()
+				newCapacity[v1.ResourceCPU] = resource.MustParse("8")
+				Expect(capacityNeedsReconcile(oldCapacity, newCapacity, "nvidia.com/gpu")).To(BeFalse())
+
+				newCapacity["nvidia.com/gpu"] = resource.MustParse("0")
+				Expect(capacityNeedsReconcile(oldCapacity, newCapacity, "nvidia.com/gpu")).To(BeTrue())
+
+				delete(newCapacity, "nvidia.com/gpu")
+				Expect(capacityNeedsReconcile(oldCapacity, newCapacity, "nvidia.com/gpu")).To(BeTrue())
+			})
+		})
+
+		Context("taints", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyTaints = []v1alpha1.UnhealthyTaint{{
+					Key:      "hw.example.com/disk-failure",
+					Value:    "sda",
+					Effect:   v1.TaintEffectNoSchedule,
+					Duration: metav1.Duration{Duration: time.Minute},
+				}}
+			})
+
+			It("tracks taints without timestamp", func() {
+				nodes := []v1.Node{
+					newTaintedNode("sda", v1.Taint{Key: "hw.example.com/disk-failure", Value: "sda", Effect: v1.TaintEffectNoSchedule}),
+					newTaintedNode("sdb", v1.Taint{Key: "hw.example.com/disk-failure", Value: "sdb", Effect: v1.TaintEffectNoSchedule}),
+				}
+				c := fake.NewClientBuilder().Build()
+
+				By("tracking when the taint was observed first")
+				updateTaintedNodes(nhc, nodes, now)
+				Expect(nhc.Status.TaintedNodes).To(ConsistOf(v1alpha1.TaintedNode{
+					Name:   "sda",
+					Key:    "hw.example.com/disk-failure",
+					Effect: v1.TaintEffectNoSchedule,
+					Since:  metav1.Time{Time: now},
+				}))
+				_, soonMatchingNodes, matchingNodes, _, _, err := r.checkNodeConditions(context.Background(), c, nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(soonMatchingNodes).To(ConsistOf(HaveField("Name", "sda")))
+				Expect(matchingNodes).To(BeEmpty())
+
+				By("matching after the duration")
+				start := now
+				now = now.Add(2 * time.Minute)
+				updateTaintedNodes(nhc, nodes, now)
+				_, _, matchingNodes, evaluations, _, err := r.checkNodeConditions(context.Background(), c, nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matchingNodes).To(ConsistOf(HaveField("Name", "sda")))
+				Expect(evaluations["sda"].Taint.Since).To(Equal(metav1.Time{Time: start}))
+			})
+
+			It("votes for the Taints signal", func() {
+				Expect(nhc.Spec.GetUnhealthySignals()).To(ContainElement(v1alpha1.UnhealthySignalTaints))
+			})
+		})
+
+		Context("annotations", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyAnnotations = []v1alpha1.AnnotationMatch{{
+					Key:      "node-problem/ssd-failing",
+					Duration: metav1.Duration{Duration: time.Minute},
+				}}
+			})
+
+			It("matches annotations with any value if no value is configured", func() {
+				nodes := []v1.Node{newAnnotatedNode("other-value", map[string]string{"node-problem/ssd-failing": "false"})}
+				updateAnnotatedNodes(nhc, nodes, now)
+				now = now.Add(2 * time.Minute)
+				_, _, matchingNodes, evaluations, _, err := r.checkNodeConditions(context.Background(), fake.NewClientBuilder().Build(), nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matchingNodes).To(HaveLen(1))
+				Expect(evaluations["other-value"].Annotation.Value).To(Equal("false"))
+			})
+
+			It("detects annotation changes", func() {
+				oldAnnotations := map[string]string{"node-problem/ssd-failing": "false"}
+				newAnnotations := map[string]string{"node-problem/ssd-failing": "false", "other": "value"}
+				Expect(annotationNeedsReconcile(oldAnnotations, newAnnotations, "node-problem/ssd-failing")).To(BeFalse())
+
+				newAnnotations["node-problem/ssd-failing"] = "true"
+				Expect(annotationNeedsReconcile(oldAnnotations, newAnnotations, "node-problem/ssd-failing")).To(BeTrue())
+
+				delete(newAnnotations, "node-problem/ssd-failing")
+				Expect(annotationNeedsReconcile(oldAnnotations, newAnnotations, "node-problem/ssd-failing")).To(BeTrue())
+			})
+
+			It("votes for the Annotations signal", func() {
+				Expect(nhc.Spec.GetUnhealthySignals()).To(ContainElement(v1alpha1.UnhealthySignalAnnotations))
+			})
+		})
+
+		Context("utilization", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyUtilization = []v1alpha1.UnhealthyUtilization{{
+					ResourceName:     v1.ResourceMemory,
+					ThresholdPercent: 90,
+					Duration:         metav1.Duration{Duration: time.Minute},
+				}}
+				nhc.Status.HighUtilizationNodes = []v1alpha1.HighUtilizationNode{
+					{Name: "high", ResourceName: v1.ResourceMemory, Since: metav1.Time{Time: now.Add(-time.Hour)}},
+				}
+			})
+
+			It("doesn't consider nodes as unhealthy when node metrics are not available", func() {
+				c := fake.NewClientBuilder().Build()
+				nodes := []v1.Node{newMemoryNode("normal"), newMemoryNode("high")}
+				Expect(r.updateHighUtilizationNodes(context.Background(), c, nhc, nodes, now)).To(Succeed())
+				Expect(nhc.Status.HighUtilizationNodes).To(BeEmpty())
+				_, _, matchingNodes, _, _, err := r.checkNodeConditions(context.Background(), c, nodes, nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matchingNodes).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Remediation throttle", func() {
+		var (
+			nhc *v1alpha1.NodeHealthCheck
+			now time.Time
+		)
+
+		remediatedNode := func(name string) *v1alpha1.UnhealthyNode {
+			return &v1alpha1.UnhealthyNode{
+				Name:         name,
+				Remediations: []*v1alpha1.Remediation{{Started: metav1.Time{Time: now.Add(-time.Minute)}}},
+			}
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationThrottle = &v1alpha1.RemediationThrottle{
+				MaxConcurrent: 2,
+				MaxPerWindow:  3,
+				Window:        metav1.Duration{Duration: time.Hour},
+			}
+		})
+
+		It("doesn't limit remediations without throttle", func() {
+			nhc.Spec.RemediationThrottle = nil
+			nhc.Status.RemediationThrottle = &v1alpha1.RemediationThrottleStatus{InFlight: 1}
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			Expect(nhc.Status.RemediationThrottle).To(BeNil())
+			limit, _ := resources.GetRemediationThrottleLimit(nhc, now)
+			Expect(limit).To(BeEmpty())
+		})
+
+		It("binds MaxConcurrent when too many nodes are under remediation", func() {
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{remediatedNode("node-1"), {Name: "node-2"}}
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			Expect(nhc.Status.RemediationThrottle.InFlight).To(Equal(1))
+			limit, _ := resources.GetRemediationThrottleLimit(nhc, now)
+			Expect(limit).To(BeEmpty())
+
+			By("counting a started remediation")
+			resources.RecordStatusRemediationThrottleStart("node-2", nhc, now)
+			Expect(nhc.Status.RemediationThrottle.InFlight).To(Equal(2))
+			limit, requeueAfter := resources.GetRemediationThrottleLimit(nhc, now)
+			Expect(limit).To(Equal(v1alpha1.RemediationThrottleLimitMaxConcurrent))
+			Expect(requeueAfter).To(BeNil())
+
+			By("not counting exhausted remediations")
+			nhc.Status.UnhealthyNodes[0].RemediationExhausted = &metav1.Time{Time: now}
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			Expect(nhc.Status.RemediationThrottle.InFlight).To(Equal(0))
+		})
+
+		It("counts nodes being drained as in flight once", func() {
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{
+				{Name: "node-1", Drain: &v1alpha1.Drain{Phase: v1alpha1.DrainPhaseDraining}},
+			}
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			Expect(nhc.Status.RemediationThrottle.InFlight).To(Equal(1))
+			resources.RecordStatusRemediationThrottleStart("node-1", nhc, now)
+			Expect(nhc.Status.RemediationThrottle.InFlight).To(Equal(1))
+			Expect(nhc.Status.RemediationThrottle.StartedInWindow).To(HaveLen(1))
+		})
+
+		It("binds MaxPerWindow when too many remediations started within the window", func() {
+			nhc.Spec.RemediationThrottle.MaxConcurrent = 0
+			nhc.Status.RemediationThrottle = &v1alpha1.RemediationThrottleStatus{
+				StartedInWindow: []metav1.Time{
+					{Time: now.Add(-2 * time.Hour)},
+					{Time: now.Add(-50 * time.Minute)},
+					{Time: now.Add(-10 * time.Minute)},
+				},
+				BindingLimit: v1alpha1.RemediationThrottleLimitMaxConcurrent,
+			}
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			Expect(nhc.Status.RemediationThrottle.StartedInWindow).To(HaveLen(2))
+			Expect(nhc.Status.RemediationThrottle.BindingLimit).To(BeEmpty())
+			limit, _ := resources.GetRemediationThrottleLimit(nhc, now)
+			Expect(limit).To(BeEmpty())
+
+			resources.RecordStatusRemediationThrottleStart("node-1", nhc, now)
+			limit, requeueAfter := resources.GetRemediationThrottleLimit(nhc, now)
+			Expect(limit).To(Equal(v1alpha1.RemediationThrottleLimitMaxPerWindow))
+			Expect(*requeueAfter).To(Equal(10 * time.Minute))
+
+			By("allowing remediation when the oldest start left the window")
+			later := now.Add(10 * time.Minute)
+			resources.UpdateStatusRemediationThrottle(nhc, later)
+			limit, _ = resources.GetRemediationThrottleLimit(nhc, later)
+			Expect(limit).To(BeEmpty())
+		})
+
+		It("prefers MaxConcurrent when both limits are reached", func() {
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{remediatedNode("node-1"), remediatedNode("node-2")}
+			nhc.Status.RemediationThrottle = &v1alpha1.RemediationThrottleStatus{
+				StartedInWindow: []metav1.Time{{Time: now}, {Time: now}, {Time: now}},
+			}
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			limit, _ := resources.GetRemediationThrottleLimit(nhc, now)
+			Expect(limit).To(Equal(v1alpha1.RemediationThrottleLimitMaxConcurrent))
+		})
+	})
+
+	Context("Remediation fairness", func() {
+		var (
+			nhc *v1alpha1.NodeHealthCheck
+			now time.Time
+		)
+
+		// reconcile mimics the remediation loop with the given unhealthy nodes, and returns the nodes whose remediation
+		// started
+		reconcile := func(nodeNames ...string) []string {
+			resources.UpdateStatusRemediationThrottle(nhc, now)
+			var nodes []v1.Node
+			for _, name := range nodeNames {
+				node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+				resources.UpdateStatusNodeUnhealthy(&node, nhc, now)
+				nodes = append(nodes, node)
+			}
+			utils.SortNodesByEligibility(nodes, nhc.Spec.PriorityLabel, func(nodeName string) time.Time {
+				return resources.GetStatusEligibleSince(nodeName, nhc, now)
+			})
+			var started []string
+			for _, node := range nodes {
+				if resources.HasStatusRemediations(node.GetName(), nhc) {
+					continue
+				}
+				if limit, _ := resources.GetRemediationThrottleLimit(nhc, now); limit != "" {
+					continue
+				}
+				resources.RecordStatusRemediationThrottleStart(node.GetName(), nhc, now)
+				for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+					if unhealthyNode.Name == node.GetName() {
+						unhealthyNode.Remediations = []*v1alpha1.Remediation{{Started: metav1.Time{Time: now}}}
+					}
+				}
+				started = append(started, node.GetName())
+			}
+			return started
+		}
+
+		// finish removes the given node from the status, like when it's healthy again
+		finish := func(nodeName string) {
+			for i, unhealthyNode := range nhc.Status.UnhealthyNodes {
+				if unhealthyNode.Name == nodeName {
+					nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes[:i], nhc.Status.UnhealthyNodes[i+1:]...)
+					return
+				}
+			}
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationThrottle = &v1alpha1.RemediationThrottle{
+				MaxConcurrent: 1,
+			}
+		})
+
+		It("remediates deferred nodes in the order in which they became eligible", func() {
+			By("remediating the first unhealthy node")
+			Expect(reconcile("busy")).To(ConsistOf("busy"))
+
+			By("deferring nodes which become unhealthy one after another")
+			unhealthy := []string{"busy"}
+			for _, name := range []string{"node-c", "node-a", "node-b"} {
+				now = now.Add(time.Minute)
+				unhealthy = append(unhealthy, name)
+				Expect(reconcile(unhealthy...)).To(BeEmpty())
+				Expect(resources.GetStatusEligibleSince(name, nhc, time.Time{})).To(Equal(now))
+			}
+
+			By("keeping the order after a restart")
+			nhc = nhc.DeepCopy()
+
+			By("remediating the deferred nodes one after another, oldest first")
+			finished := "busy"
+			for _, expected := range []string{"node-c", "node-a", "node-b"} {
+				now = now.Add(time.Minute)
+				finish(finished)
+				unhealthy = unhealthy[1:]
+				// a node which becomes unhealthy now doesn't overtake waiting nodes
+				Expect(reconcile(append(unhealthy, "node-0")...)).To(ConsistOf(expected))
+				finished = expected
+			}
+
+			By("remediating the newest node last")
+			finish(finished)
+			Expect(reconcile("node-0")).To(ConsistOf("node-0"))
+		})
+	})
+
+	Context("Unhealthy signal events", func() {
+		var (
+			r        *NodeHealthCheckReconciler
+			recorder *record.FakeRecorder
+			nhc      *v1alpha1.NodeHealthCheck
+			since    metav1.Time
+		)
+
+		BeforeEach(func() {
+			since = metav1.NewTime(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC))
+			nhc = newNodeHealthCheck()
+			nhc.Status.UnhealthyNodes = []*v1alpha1.UnhealthyNode{{Name: "node"}}
+			recorder = record.NewFakeRecorder(20)
+			r = &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: recorder,
+			}
+		})
+
+		It("emits an event only when a node starts being unreachable", func() {
+			evaluations := map[string]*resources.NodeEvaluation{"node": {UnreachableSince: &since}}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].UnreachableSince).To(Equal(&since))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedUnreachable)))
+
+			By("not emitting the event again on the next reconcile")
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).ToNot(Receive())
+
+			By("emitting the event again when the node is unreachable again")
+			r.updateStatusUnhealthySignals(nhc, nil)
+			Expect(nhc.Status.UnhealthyNodes[0].UnreachableSince).To(BeNil())
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedUnreachable)))
+		})
+
+		It("emits an event only when the heartbeat of a node gets stale", func() {
+			evaluations := map[string]*resources.NodeEvaluation{"node": {HeartbeatStaleSince: &since}}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].HeartbeatStaleSince).To(Equal(&since))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedStaleHeartbeat)))
+
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).ToNot(Receive())
+		})
+
+		It("emits an event only when a node starts having insufficient capacity of a resource", func() {
+			evaluations := map[string]*resources.NodeEvaluation{"node": {Capacity: &v1alpha1.MatchedCapacity{ResourceName: "nvidia.com/gpu", Since: since}}}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].MatchedCapacity).ToNot(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedLowCapacity)))
+
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).ToNot(Receive())
+
+			By("emitting the event for another resource")
+			evaluations["node"].Capacity = &v1alpha1.MatchedCapacity{ResourceName: v1.ResourceMemory, Since: since}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedLowCapacity)))
+		})
+
+		It("emits an event only when a node starts having an unhealthy taint", func() {
+			evaluations := map[string]*resources.NodeEvaluation{"node": {Taint: &v1alpha1.MatchedTaint{Key: "example.com/broken", Effect: v1.TaintEffectNoSchedule, Since: since}}}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].MatchedTaint).ToNot(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedUnhealthyTaint)))
+
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).ToNot(Receive())
+		})
+
+		It("emits an event only when a node starts having an unhealthy annotation", func() {
+			evaluations := map[string]*resources.NodeEvaluation{"node": {Annotation: &v1alpha1.MatchedAnnotation{Key: "example.com/broken", Value: "true", Since: since}}}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].MatchedAnnotation).ToNot(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedUnhealthyAnnot)))
+
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).ToNot(Receive())
+		})
+
+		It("emits an event only when a node starts having high utilization", func() {
+			evaluations := map[string]*resources.NodeEvaluation{"node": {Utilization: &v1alpha1.MatchedUtilization{ResourceName: v1.ResourceMemory, Since: since}}}
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(nhc.Status.UnhealthyNodes[0].MatchedUtilization).ToNot(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonDetectedHighUtilization)))
+
+			r.updateStatusUnhealthySignals(nhc, evaluations)
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
+
+	Context("Unhealthy quorum", func() {
+		var (
+			r     *NodeHealthCheckReconciler
+			nhc   *v1alpha1.NodeHealthCheck
+			nodes []v1.Node
+			now   time.Time
+		)
+
+		newQuorumNode := func(name string, ready v1.ConditionStatus, gpus string) v1.Node {
+			node := newNode(name, v1.NodeReady, ready, false, true).(*v1.Node)
+			node.Status.Capacity = v1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)}
+			return *node
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+
+			nhc = newNodeHealthCheck()
+			nhc.Spec.UnhealthyCapacity = []v1alpha1.UnhealthyCapacity{
+				{
+					ResourceName: "nvidia.com/gpu",
+					MinQuantity:  resource.MustParse("1"),
+					Duration:     metav1.Duration{Duration: time.Minute},
+				},
+			}
+			nhc.Spec.UnhealthyQuorum = 2
+			nodes = []v1.Node{
+				newQuorumNode("healthy", v1.ConditionTrue, "2"),
+				newQuorumNode("not-ready", v1.ConditionFalse, "2"),
+				newQuorumNode("gpus-lost", v1.ConditionTrue, "0"),
+				newQuorumNode("not-ready-gpus-lost", v1.ConditionFalse, "0"),
+			}
+			r = &NodeHealthCheckReconciler{
+				Log:                 controllerruntime.Log,
+				Recorder:            record.NewFakeRecorder(20),
+				MHCChecker:          mhc.DummyChecker{},
+				ConnectivityChecker: connectivity.NewChecker(false, controllerruntime.Log),
+				HeartbeatChecker:    heartbeat.NewChecker(false, controllerruntime.Log),
+				UtilizationChecker:  utilization.NewChecker(false, controllerruntime.Log),
+			}
+		})
+
+		It("considers nodes as unhealthy only when enough signals vote for it", func() {
+			c := fake.NewClientBuilder().Build()
+
+			By("waiting for the capacity signal")
+			updateInsufficientCapacityNodes(nhc, nodes, now)
+			notMatchingNodes, soonMatchingNodes, matchingNodes, _, requeueAfter, err := r.checkNodeConditions(context.Background(), c, nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notMatchingNodes).To(ConsistOf(HaveField("Name", "healthy"), HaveField("Name", "not-ready")))
+			Expect(soonMatchingNodes).To(ConsistOf(HaveField("Name", "gpus-lost"), HaveField("Name", "not-ready-gpus-lost")))
+			Expect(matchingNodes).To(BeEmpty())
+			Expect(*requeueAfter).To(Equal(time.Minute + time.Second))
+			Expect(nhc.Status.UnhealthySignalVotes).To(ConsistOf(
+				v1alpha1.UnhealthySignalVote{Name: "not-ready", Signals: []v1alpha1.UnhealthySignal{v1alpha1.UnhealthySignalConditions}},
+				v1alpha1.UnhealthySignalVote{Name: "not-ready-gpus-lost", Signals: []v1alpha1.UnhealthySignal{v1alpha1.UnhealthySignalConditions}},
+			))
+
+			By("matching nodes with a quorum")
+			now = now.Add(2 * time.Minute)
+			updateInsufficientCapacityNodes(nhc, nodes, now)
+			notMatchingNodes, soonMatchingNodes, matchingNodes, _, _, err = r.checkNodeConditions(context.Background(), c, nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notMatchingNodes).To(HaveLen(3))
+			Expect(soonMatchingNodes).To(BeEmpty())
+			Expect(matchingNodes).To(ConsistOf(HaveField("Name", "not-ready-gpus-lost")))
+			Expect(nhc.Status.UnhealthySignalVotes).To(ConsistOf(
+				v1alpha1.UnhealthySignalVote{Name: "not-ready", Signals: []v1alpha1.UnhealthySignal{v1alpha1.UnhealthySignalConditions}},
+				v1alpha1.UnhealthySignalVote{Name: "gpus-lost", Signals: []v1alpha1.UnhealthySignal{v1alpha1.UnhealthySignalCapacity}},
+				v1alpha1.UnhealthySignalVote{Name: "not-ready-gpus-lost", Signals: []v1alpha1.UnhealthySignal{v1alpha1.UnhealthySignalConditions, v1alpha1.UnhealthySignalCapacity}},
+			))
+
+			By("clearing the votes when the quorum is removed")
+			nhc.Spec.UnhealthyQuorum = 0
+			_, _, matchingNodes, _, _, err = r.checkNodeConditions(context.Background(), c, nodes, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matchingNodes).To(HaveLen(3))
+			Expect(nhc.Status.UnhealthySignalVotes).To(BeEmpty())
+		})
+	})
+
+	Context("Timed out annotation", func() {
+		var (
+			reconciler *NodeHealthCheckReconciler
+			rm         resources.Manager
+			nhc        *v1alpha1.NodeHealthCheck
+			cr         *unstructured.Unstructured
+			now        metav1.Time
+		)
+
+		BeforeEach(func() {
+			now = metav1.NewTime(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC))
+			nhc = newNodeHealthCheck()
+			cr = newRemediationCRForNHC("node", nhc)
+			cr.SetAnnotations(map[string]string{annotations.RemediationAttemptAnnotation: "2"})
+			reconciler = &NodeHealthCheckReconciler{
+				Log:      controllerruntime.Log,
+				Recorder: record.NewFakeRecorder(10),
+			}
+		})
+
+		JustBeforeEach(func() {
+			c := fake.NewClientBuilder().WithObjects(cr).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+		})
+
+		It("has the timestamp, the reason, and the attempt", func() {
+			Expect(reconciler.addTimeOutAnnotation(rm, nhc, cr, now, v1alpha1.TimedOutReasonFailed)).To(Succeed())
+			Expect(cr.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.NhcTimedOutAnnotation, "2024-01-02T15:04:05Z;reason=Failed"))
+			Expect(cr.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.NhcTimedOutStepAnnotation, "attempt=2"))
+
+			timedOut, reason, err := v1alpha1.ParseNhcTimedOutAnnotation(cr.GetAnnotations()[v1alpha1.NhcTimedOutAnnotation])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(timedOut).To(BeTemporally("==", now.Time))
+			Expect(reason).To(Equal(v1alpha1.TimedOutReasonFailed))
+		})
+
+		When("escalating remediations are used", func() {
+			BeforeEach(func() {
+				nhc.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{{
+					RemediationTemplate: *nhc.Spec.RemediationTemplate,
+					Order:               3,
+					Timeout:             metav1.Duration{Duration: time.Minute},
+				}}
+				nhc.Spec.RemediationTemplate = nil
+			})
+
+			It("has the order of the escalating remediation", func() {
+				Expect(reconciler.addTimeOutAnnotation(rm, nhc, cr, now, v1alpha1.TimedOutReasonTimeout)).To(Succeed())
+				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.NhcTimedOutAnnotation, "2024-01-02T15:04:05Z;reason=Timeout"))
+				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.NhcTimedOutStepAnnotation, "order=3;attempt=2"))
+			})
+		})
+
+		When("the plain timed out annotation is configured", func() {
+			BeforeEach(func() {
+				reconciler.PlainTimedOutAnnotation = true
+			})
+
+			It("has the timestamp only", func() {
+				Expect(reconciler.addTimeOutAnnotation(rm, nhc, cr, now, v1alpha1.TimedOutReasonTimeout)).To(Succeed())
+				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.NhcTimedOutAnnotation, "2024-01-02T15:04:05Z"))
+				Expect(cr.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.NhcTimedOutStepAnnotation, "attempt=2"))
+			})
+		})
+	})
+
+	Context("Alert silencing", func() {
+		var (
+			reconciler *NodeHealthCheckReconciler
+			silencer   *fakeSilencer
+			nhc        *v1alpha1.NodeHealthCheck
+			node       *v1.Node
+			now        time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+			silencer = &fakeSilencer{silences: map[string]time.Time{}}
+			reconciler = &NodeHealthCheckReconciler{
+				Log:           controllerruntime.Log,
+				Recorder:      record.NewFakeRecorder(10),
+				AlertSilencer: silencer,
+			}
+			nhc = newNodeHealthCheck()
+			nhc.Spec.AlertSilencing = &v1alpha1.AlertSilencing{
+				AlertmanagerURL: "http://alertmanager:9093",
+				Matchers:        []v1alpha1.SilenceMatcher{{Name: "instance", Value: "{{.NodeName}}"}},
+			}
+			node = &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+			resources.UpdateStatusNodeUnhealthy(node, nhc, now)
+			nhc.Status.UnhealthyNodes[0].Remediations = []*v1alpha1.Remediation{{
+				Resource: v1.ObjectReference{Kind: "InfrastructureRemediation", Name: "node"},
+				Started:  metav1.Time{Time: now},
+			}}
+		})
+
+		It("silences, extends and expires alerts of the node", func() {
+			By("silencing alerts when remediation started")
+			requeueAfter := reconciler.syncAlertSilences(context.Background(), nhc, now)
+			Expect(silencer.silences).To(HaveKeyWithValue("silence-1", now.Add(time.Hour)))
+			Expect(nhc.Status.AlertSilences).To(ConsistOf(&v1alpha1.AlertSilence{
+				NodeName: "node",
+				ID:       "silence-1",
+				EndsAt:   metav1.Time{Time: now.Add(time.Hour)},
+			}))
+			Expect(requeueAfter).To(Equal(pointer.Duration(30*time.Minute + time.Second)))
+			Expect(meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeAlertSilencingFailed)).To(BeNil())
+
+			By("not extending the silence before half of its duration is over")
+			reconciler.syncAlertSilences(context.Background(), nhc, now.Add(20*time.Minute))
+			Expect(silencer.calls).To(Equal(1))
+
+			By("extending the silence while remediation is in flight")
+			later := now.Add(31 * time.Minute)
+			reconciler.syncAlertSilences(context.Background(), nhc, later)
+			Expect(silencer.silences).To(HaveKeyWithValue("silence-1", later.Add(time.Hour)))
+			Expect(nhc.Status.AlertSilences[0].EndsAt.Time).To(Equal(later.Add(time.Hour)))
+
+			By("expiring the silence when remediation timed out")
+			nhc.Status.UnhealthyNodes[0].Remediations[0].TimedOut = &metav1.Time{Time: later}
+			Expect(reconciler.syncAlertSilences(context.Background(), nhc, later)).To(BeNil())
+			Expect(silencer.silences).To(BeEmpty())
+			Expect(nhc.Status.AlertSilences).To(BeEmpty())
+		})
+
+		It("expires the silence when the node is healthy again", func() {
+			reconciler.syncAlertSilences(context.Background(), nhc, now)
+			Expect(silencer.silences).To(HaveLen(1))
+			resources.UpdateStatusNodeHealthy(node.GetName(), nhc)
+			reconciler.syncAlertSilences(context.Background(), nhc, now)
+			Expect(silencer.silences).To(BeEmpty())
+			Expect(nhc.Status.AlertSilences).To(BeEmpty())
+		})
+
+		It("only reports failures without affecting remediation", func() {
+			silencer.err = fmt.Errorf("connection refused")
+			requeueAfter := reconciler.syncAlertSilences(context.Background(), nhc, now)
+			Expect(requeueAfter).To(Equal(&alertSilencingRetryRequeueAfter))
+			Expect(nhc.Status.AlertSilences).To(BeEmpty())
+			condition := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeAlertSilencingFailed)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(v1alpha1.ConditionReasonAlertmanagerRequestFailed))
+			Expect(condition.Message).To(ContainSubstring("silencing node node: connection refused"))
+
+			By("retrying")
+			silencer.err = nil
+			reconciler.syncAlertSilences(context.Background(), nhc, now.Add(time.Minute))
+			Expect(nhc.Status.AlertSilences).To(HaveLen(1))
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeAlertSilencingFailed)).To(BeTrue())
+
+			By("keeping silences which failed to expire")
+			silencer.err = fmt.Errorf("connection refused")
+			resources.UpdateStatusNodeHealthy(node.GetName(), nhc)
+			reconciler.syncAlertSilences(context.Background(), nhc, now.Add(2*time.Minute))
+			Expect(nhc.Status.AlertSilences).To(HaveLen(1))
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeAlertSilencingFailed)).To(BeTrue())
+
+			By("forgetting silences which ended on their own")
+			reconciler.syncAlertSilences(context.Background(), nhc, now.Add(2*time.Hour))
+			Expect(nhc.Status.AlertSilences).To(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeAlertSilencingFailed)).To(BeTrue())
+		})
+
+		It("forgets silences when alert silencing is removed", func() {
+			reconciler.syncAlertSilences(context.Background(), nhc, now)
+			Expect(nhc.Status.AlertSilences).To(HaveLen(1))
+			nhc.Spec.AlertSilencing = nil
+			Expect(reconciler.syncAlertSilences(context.Background(), nhc, now)).To(BeNil())
+			Expect(nhc.Status.AlertSilences).To(BeEmpty())
+			Expect(silencer.calls).To(Equal(1))
+		})
+	})
+
+	Context("Remediation CR updates with conflicts", func() {
+		var (
+			reconciler *NodeHealthCheckReconciler
+			rm         resources.Manager
+			cr         *unstructured.Unstructured
+			nhc        *v1alpha1.NodeHealthCheck
+			conflicts  int
+			updates    int
+		)
+
+		BeforeEach(func() {
+			conflicts, updates = 0, 0
+			conflictInjected := map[string]bool{}
+			nhc = newNodeHealthCheck()
+			cr = newRemediationCRForNHC("conflicting-node", nhc)
+			cr.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-remediationCRAlertTimeout - time.Minute)))
+
+			// inject one conflict per updated object
+			c := fake.NewClientBuilder().WithObjects(cr).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if !conflictInjected[obj.GetName()] {
+						conflictInjected[obj.GetName()] = true
+						conflicts++
+						return errors.NewConflict(schema.GroupResource{Resource: "remediations"}, obj.GetName(), fmt.Errorf("injected conflict"))
+					}
+					updates++
+					return c.Update(ctx, obj, opts...)
+				},
+			}).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			reconciler = &NodeHealthCheckReconciler{
+				Client:   c,
+				Log:      controllerruntime.Log,
+				Recorder: record.NewFakeRecorder(10),
+			}
+		})
+
+		It("retries adding the timed out annotation once", func() {
+			Expect(reconciler.addTimeOutAnnotation(rm, nhc, cr, metav1.Now(), v1alpha1.TimedOutReasonTimeout)).To(Succeed())
+			Expect(cr.GetAnnotations()).To(HaveKey(commonannotations.NhcTimedOut))
+			timedOut := cr.GetAnnotations()[commonannotations.NhcTimedOut]
+
+			By("not updating again")
+			Expect(reconciler.addTimeOutAnnotation(rm, nhc, cr, metav1.NewTime(time.Now().Add(time.Hour)), v1alpha1.TimedOutReasonFailed)).To(Succeed())
+			Expect(cr.GetAnnotations()[commonannotations.NhcTimedOut]).To(Equal(timedOut))
+			Expect(conflicts).To(Equal(1))
+			Expect(updates).To(Equal(1))
+		})
+
+		It("alerts about an old remediation CR once", func() {
+			isAlert, _ := reconciler.alertOldRemediationCR(cr, rm)
+			Expect(isAlert).To(BeTrue())
+			Expect(cr.GetAnnotations()).To(HaveKey(oldRemediationCRAnnotationKey))
+
+			By("not alerting again")
+			isAlert, _ = reconciler.alertOldRemediationCR(cr, rm)
+			Expect(isAlert).To(BeFalse())
+			Expect(conflicts).To(Equal(1))
+			Expect(updates).To(Equal(1))
+		})
+	})
+
+	Context("Machine owner references", func() {
+		var (
+			rm       resources.Manager
+			nhc      *v1alpha1.NodeHealthCheck
+			node     *v1.Node
+			machine  *unstructured.Unstructured
+			template *unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			machine = &unstructured.Unstructured{}
+			machine.SetGroupVersionKind(utils.CAPIMachineGVK)
+			machine.SetNamespace("capi-cluster")
+			machine.SetName("capi-machine")
+			machine.SetUID("capi-machine-uid")
+			node = newNode("capi-node", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			node.SetAnnotations(map[string]string{
+				"cluster.x-k8s.io/machine":           machine.GetName(),
+				"cluster.x-k8s.io/cluster-namespace": machine.GetNamespace(),
+			})
+			c := fake.NewClientBuilder().WithObjects(machine, node).Build()
+			// not on OpenShift, the CAPI machine is used anyway
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			template = newTestRemediationTemplateCR(InfraRemediationKind, machine.GetNamespace(), InfraRemediationTemplateName)
+		})
+
+		It("adds the CAPI machine as owner to a non Metal3 remediation CR", func() {
+			cr, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cr.GetOwnerReferences()).To(HaveLen(2))
+			Expect(cr.GetOwnerReferences()[0].UID).To(Equal(nhc.GetUID()))
+			Expect(cr.GetOwnerReferences()[1]).To(And(
+				HaveField("APIVersion", "cluster.x-k8s.io/v1beta1"),
+				HaveField("Kind", "Machine"),
+				HaveField("Name", machine.GetName()),
+				HaveField("UID", machine.GetUID()),
+				HaveField("Controller", HaveValue(BeFalse())),
+			))
+		})
+
+		It("skips the machine owner when the remediation CR is in another namespace", func() {
+			template.SetNamespace("other-namespace")
+			cr, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cr.GetOwnerReferences()).To(HaveLen(1))
+			Expect(cr.GetOwnerReferences()[0].UID).To(Equal(nhc.GetUID()))
+		})
+
+		When("the remediation CR namespace is derived from the machine", func() {
+			BeforeEach(func() {
+				nhc.Spec.RemediationNamespaceStrategy = v1alpha1.RemediationNamespaceStrategyMachineNamespace
+				template.SetNamespace("other-namespace")
+			})
+
+			It("creates the remediation CR in the machine's namespace", func() {
+				cr, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cr.GetNamespace()).To(Equal(machine.GetNamespace()))
+				Expect(cr.GetOwnerReferences()).To(HaveLen(2))
+				Expect(cr.GetOwnerReferences()[1].UID).To(Equal(machine.GetUID()))
+			})
+
+			It("falls back to the template namespace for nodes without machine annotation", func() {
+				node.SetAnnotations(nil)
+				cr, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cr.GetNamespace()).To(Equal("other-namespace"))
+				Expect(cr.GetOwnerReferences()).To(HaveLen(1))
+			})
+
+			It("checks the Metal3 namespace against the machine's namespace", func() {
+				metal3Template := newTestRemediationTemplateCR("Metal3Remediation", "openshift-machine-api", "metal3-template")
+				_, err := rm.GenerateRemediationCRForNode(node, nhc, metal3Template)
+				Expect(err).To(MatchError(ContainSubstring("Metal3Remediation must be in the openshift-machine-api namespace")))
+
+				node.SetAnnotations(map[string]string{"machine.openshift.io/machine": "openshift-machine-api/machine"})
+				metal3Template.SetNamespace("other-namespace")
+				cr, err := rm.GenerateRemediationCRForNode(node, nhc, metal3Template)
+				// the OpenShift machine doesn't exist, but the namespace check passed
+				Expect(err).To(MatchError(ContainSubstring("failed to get machine")))
+				Expect(cr).To(BeNil())
+			})
+
+			It("records not applicable remediations with the machine's namespace", func() {
+				nhc.Spec.RemediationTemplate = nil
+				nhc.Spec.EscalatingRemediations = []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: v1.ObjectReference{Kind: InfraRemediationTemplateKind, APIVersion: InfraRemediationAPIVersion, Namespace: "other-namespace", Name: InfraRemediationTemplateName},
+						Order:               0,
+						Timeout:             metav1.Duration{Duration: time.Minute},
+						PauseReason:         "maintenance",
+					},
+				}
+				_, _, err := rm.GetCurrentTemplateWithTimeout(node, nhc, currentTime())
+				Expect(err).To(HaveOccurred())
+				Expect(nhc.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(nhc.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+				Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].Resource.Namespace).To(Equal(machine.GetNamespace()))
+			})
+		})
+	})
+
+	Context("Management cluster", func() {
+		var (
+			guestClient       client.Client
+			managementClient  client.Client
+			managementCluster *resources.ManagementCluster
+			nhc               *v1alpha1.NodeHealthCheck
+			node              *v1.Node
+			machine           *unstructured.Unstructured
+			template          *unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			machine = &unstructured.Unstructured{}
+			machine.SetGroupVersionKind(utils.CAPIMachineGVK)
+			machine.SetNamespace("clusters-guest")
+			machine.SetName("guest-machine")
+			machine.SetUID("guest-machine-uid")
+			node = newNode("guest-node", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			node.SetAnnotations(map[string]string{
+				"cluster.x-k8s.io/machine":           machine.GetName(),
+				"cluster.x-k8s.io/cluster-namespace": machine.GetNamespace(),
+			})
+			// the machine only exists in the management cluster
+			guestClient = fake.NewClientBuilder().WithObjects(node).Build()
+			managementClient = fake.NewClientBuilder().WithObjects(machine).Build()
+			managementCluster = &resources.ManagementCluster{Client: managementClient}
+			template = newTestRemediationTemplateCR(InfraRemediationKind, machine.GetNamespace(), InfraRemediationTemplateName)
+		})
+
+		When("remediation CRs are created in the guest cluster", func() {
+			var rm resources.Manager
+
+			BeforeEach(func() {
+				rm = resources.NewManager(guestClient, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, managementCluster)
+			})
+
+			It("looks up the machine in the management cluster, but skips it as owner", func() {
+				cr, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cr.GetOwnerReferences()).To(HaveLen(1))
+				Expect(cr.GetOwnerReferences()[0].UID).To(Equal(nhc.GetUID()))
+
+				By("failing when the machine doesn't exist in the management cluster")
+				Expect(managementClient.Delete(context.Background(), machine)).To(Succeed())
+				_, err = rm.GenerateRemediationCRForNode(node, nhc, template)
+				Expect(err).To(MatchError(ContainSubstring("failed to get machine")))
+			})
+
+			It("rejects Metal3 remediation", func() {
+				metal3Template := newTestRemediationTemplateCR("Metal3Remediation", machine.GetNamespace(), "metal3-template")
+				_, err := rm.GenerateRemediationCRForNode(node, nhc, metal3Template)
+				Expect(err).To(MatchError(ContainSubstring("Metal3Remediation must be created in the management cluster")))
+			})
+		})
+
+		When("remediation CRs are created in the management cluster", func() {
+			var rm resources.Manager
+
+			BeforeEach(func() {
+				managementCluster.HasRemediations = true
+				rm = resources.NewManager(managementClient, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, managementCluster)
+			})
+
+			It("uses owner labels instead of a NHC owner reference", func() {
+				cr, err := rm.GenerateRemediationCRForNode(node, nhc, template)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cr.GetOwnerReferences()).To(HaveLen(1))
+				Expect(cr.GetOwnerReferences()[0].UID).To(Equal(machine.GetUID()))
+				Expect(cr.GetLabels()).To(And(
+					HaveKeyWithValue("app.kubernetes.io/part-of", "node-healthcheck-controller"),
+					HaveKeyWithValue(resources.OwnerKindLabel, "NodeHealthCheck"),
+					HaveKeyWithValue(resources.OwnerNameLabel, nhc.GetName()),
+				))
+				Expect(resources.IsOwner(cr, nhc)).To(BeTrue())
+
+				otherNHC := newNodeHealthCheck()
+				otherNHC.SetName("other")
+				Expect(resources.IsOwner(cr, otherNHC)).To(BeFalse())
+			})
+
+			It("checks the Metal3 namespace against the machine's namespace", func() {
+				metal3Template := newTestRemediationTemplateCR("Metal3Remediation", "openshift-machine-api", "metal3-template")
+				_, err := rm.GenerateRemediationCRForNode(node, nhc, metal3Template)
+				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("Metal3Remediation must be in the namespace %q", machine.GetNamespace()))))
+
+				metal3Template.SetNamespace(machine.GetNamespace())
+				cr, err := rm.GenerateRemediationCRForNode(node, nhc, metal3Template)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cr.GetNamespace()).To(Equal(machine.GetNamespace()))
+			})
+
+			It("doesn't support the remediation ServiceAccount", func() {
+				nhc.Spec.RemediationServiceAccount = &v1alpha1.ServiceAccountReference{Namespace: "gpu", Name: "gpu-remediator"}
+				scheme := runtime.NewScheme()
+				Expect(v1.AddToScheme(scheme)).To(Succeed())
+				Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+				c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nhc).WithStatusSubresource(nhc).Build()
+				reconciler := &NodeHealthCheckReconciler{
+					Client:               c,
+					Log:                  controllerruntime.Log,
+					Recorder:             record.NewFakeRecorder(10),
+					MHCChecker:           mhc.DummyChecker{},
+					RemoteClients:        remote.NewClientProvider(c, c, false, controllerruntime.Log),
+					ImpersonatingClients: fakeImpersonatingClients(func(c client.Client, _ *v1alpha1.NodeHealthCheck) (client.Client, error) { return c, nil }),
+					ManagementCluster:    managementCluster,
+				}
+				_, err := reconciler.Reconcile(context.Background(), controllerruntime.Request{NamespacedName: client.ObjectKeyFromObject(nhc)})
+				Expect(err).To(MatchError(ContainSubstring("remediation ServiceAccount can't be used")))
+			})
+		})
+	})
+
+	Context("Parallel remediation CR creation", func() {
+		const nodeCount = 20
+		const createLatency = 100 * time.Millisecond
+		var (
+			reconciler   *NodeHealthCheckReconciler
+			rm           resources.Manager
+			c            client.Client
+			nhc          *v1alpha1.NodeHealthCheck
+			remediations []*pendingRemediation
+			failingCR    string
+		)
+
+		BeforeEach(func() {
+			failingCR = ""
+			nhc = newNodeHealthCheck()
+			c, remediations = newPendingRemediations(nodeCount, nhc, createLatency, func(name string) error {
+				if name == failingCR {
+					return fmt.Errorf("injected error")
+				}
+				return nil
+			})
+			leaseManager, err := resources.NewLeaseManager(c, "nhc-test", controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, leaseManager, record.NewFakeRecorder(10), false, nil)
+			reconciler = &NodeHealthCheckReconciler{
+				Client:                         c,
+				Log:                            controllerruntime.Log,
+				Recorder:                       record.NewFakeRecorder(nodeCount), // every created CR emits an event
+				EventEmitter:                   eventsink.DummyEmitter{},
+				RemediationCreationConcurrency: 10,
+			}
+		})
+
+		It("creates all remediation CRs within the context deadline", func() {
+			// creating one CR after another would exceed the deadline
+			ctx, cancel := context.WithTimeout(context.Background(), nodeCount*createLatency/2)
+			defer cancel()
+			reconciler.createRemediationCRs(ctx, nhc, rm, remediations)
+			Expect(ctx.Err()).ToNot(HaveOccurred())
+
+			for _, pending := range remediations {
+				Expect(pending.attempted).To(BeTrue())
+				Expect(pending.err).ToNot(HaveOccurred())
+				Expect(pending.created).To(BeTrue())
+				cr := newRemediationCRForNHC(pending.node.GetName(), nhc)
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			}
+
+			By("updating the status in node order")
+			for _, pending := range remediations {
+				_, err := reconciler.finishRemediation(nhc, pending, rm)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(nhc.Status.UnhealthyNodes).To(HaveLen(nodeCount))
+			for i, unhealthyNode := range nhc.Status.UnhealthyNodes {
+				Expect(unhealthyNode.Name).To(Equal(remediations[i].node.GetName()))
+				Expect(unhealthyNode.Remediations).To(HaveLen(1))
+			}
+		})
+
+		It("doesn't start further creations after a failed creation", func() {
+			reconciler.RemediationCreationConcurrency = 1
+			failingCR = remediations[5].node.GetName()
+			reconciler.createRemediationCRs(context.Background(), nhc, rm, remediations)
+
+			for i, pending := range remediations {
+				switch {
+				case i < 5:
+					Expect(pending.created).To(BeTrue())
+				case i == 5:
+					Expect(pending.err).To(MatchError(ContainSubstring("injected error")))
+				default:
+					Expect(pending.attempted).To(BeFalse())
+				}
+			}
+		})
+	})
+
+	Context("Remediation CRs deleted externally", func() {
+		var (
+			c          client.Client
+			rm         resources.Manager
+			reconciler *NodeHealthCheckReconciler
+			nhc        *v1alpha1.NodeHealthCheck
+			node       *v1.Node
+			cr         *unstructured.Unstructured
+			now        time.Time
+		)
+
+		BeforeEach(func() {
+			gv := schema.GroupVersion{Group: InfraRemediationGroup, Version: InfraRemediationVersion}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind(InfraRemediationKind), meta.RESTScopeNamespace)
+			mapper.Add(gv.WithKind(InfraRemediationTemplateKind), meta.RESTScopeNamespace)
+			c = fake.NewClientBuilder().WithRESTMapper(mapper).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			reconciler = &NodeHealthCheckReconciler{
+				Log:          controllerruntime.Log,
+				Recorder:     record.NewFakeRecorder(10),
+				EventEmitter: eventsink.DummyEmitter{},
+			}
+
+			nhc = newNodeHealthCheck()
+			node = newNode("unhealthy", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			cr = newRemediationCRForNHC(node.GetName(), nhc)
+			resources.UpdateStatusRemediationStarted(node, nhc, cr, pointer.Duration(time.Hour))
+
+			now = nhc.Status.UnhealthyNodes[0].Remediations[0].Started.Add(externalDeletionGracePeriod + time.Second)
+			fakeTime = &now
+			DeferCleanup(func() {
+				fakeTime = nil
+			})
+		})
 
-			Context("one control plane node is unhealthy, and DisruptionsAllowed = 0", func() {
-				BeforeEach(func() {
-					objects = newNodes(1, 2, true, true)
-					underTest = newNodeHealthCheck()
-					objects = append(objects, underTest)
+		It("ignores existing remediation CRs", func() {
+			Expect(c.Create(context.Background(), cr)).To(Succeed())
+			requeueAfter, err := reconciler.handleExternallyDeletedRemediationCR(nhc, node, rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNil())
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].DeletedExternally).To(BeNil())
+		})
 
-					// update pdb status
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pdb), pdb)).To(Succeed())
-					pdb.Status.DisruptionsAllowed = 0
-					Expect(k8sClient.Status().Update(context.Background(), pdb)).To(Succeed())
-				})
+		It("waits for the cache within the grace period", func() {
+			justStarted := nhc.Status.UnhealthyNodes[0].Remediations[0].Started.Add(time.Second)
+			fakeTime = &justStarted
+			requeueAfter, err := reconciler.handleExternallyDeletedRemediationCR(nhc, node, rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).ToNot(BeNil())
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].DeletedExternally).To(BeNil())
+		})
 
-				createGuardPod := func(isReady bool) {
-					pod := &v1.Pod{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      "some-name",
-							Namespace: pdb.Namespace,
-							Labels:    pdbSelector,
-						},
-						Spec: v1.PodSpec{
-							Containers: []v1.Container{
-								{
-									Name:  "test",
-									Image: "test",
-								},
-							},
-							NodeName: "unhealthy-control-plane-node-1",
-						},
-					}
-					Expect(k8sClient.Create(context.Background(), pod)).To(Succeed())
-					DeferCleanup(func() {
-						Expect(k8sClient.Delete(context.Background(), pod, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})).To(Succeed())
-					})
+		It("recreates the remediation CR by default", func() {
+			requeueAfter, err := reconciler.handleExternallyDeletedRemediationCR(nhc, node, rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNil())
+			remediation := nhc.Status.UnhealthyNodes[0].Remediations[0]
+			Expect(remediation.DeletedExternally).ToNot(BeNil())
+			Expect(resources.IsStatusRemediationOngoing(remediation)).To(BeTrue())
+
+			By("continuing the remediation with the recreated CR")
+			started := remediation.Started
+			recreated := newRemediationCRForNHC(node.GetName(), nhc)
+			recreated.SetUID("recreated")
+			resources.UpdateStatusRemediationStarted(node, nhc, recreated, pointer.Duration(time.Hour))
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].Resource.UID).To(Equal(recreated.GetUID()))
+			Expect(nhc.Status.UnhealthyNodes[0].Remediations[0].Started).To(Equal(started))
+		})
 
-					// update pod status
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)).To(Succeed())
-					status := v1.ConditionTrue
-					if !isReady {
-						status = v1.ConditionFalse
-					}
-					pod.Status.Conditions = []v1.PodCondition{
-						{
-							Type:   v1.PodReady,
-							Status: status,
-						},
-					}
-					Expect(k8sClient.Status().Update(context.Background(), pod)).To(Succeed())
-				}
+		It("treats the remediation as completed", func() {
+			nhc.Spec.ExternalCRDeletionPolicy = v1alpha1.ExternalCRDeletionPolicyTreatAsCompleted
+			_, err := reconciler.handleExternallyDeletedRemediationCR(nhc, node, rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			remediation := nhc.Status.UnhealthyNodes[0].Remediations[0]
+			Expect(remediation.Phase).To(Equal(v1alpha1.RemediationPhaseSucceeded))
+			Expect(remediation.TimedOut).To(BeNil())
+			Expect(resources.IsStatusRemediationOngoing(remediation)).To(BeFalse())
+		})
 
-				When("unhealthy node is not disrupted already (guard pod is ready)", func() {
+		It("treats the remediation as failed", func() {
+			nhc.Spec.ExternalCRDeletionPolicy = v1alpha1.ExternalCRDeletionPolicyTreatAsFailed
+			_, err := reconciler.handleExternallyDeletedRemediationCR(nhc, node, rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			remediation := nhc.Status.UnhealthyNodes[0].Remediations[0]
+			Expect(remediation.Phase).To(Equal(v1alpha1.RemediationPhaseTimedOut))
+			Expect(remediation.TimedOut).ToNot(BeNil())
+			Expect(resources.IsStatusRemediationOngoing(remediation)).To(BeFalse())
+		})
+	})
 
-					BeforeEach(func() {
-						// create ready guard pod
-						createGuardPod(true)
-					})
+	Context("Remediation ServiceAccount", func() {
+		var (
+			c          client.Client
+			reconciler *NodeHealthCheckReconciler
+			nhc        *v1alpha1.NodeHealthCheck
+			forbidden  bool
+		)
 
-					It("doesn't create a remediation CR for control plane node", func() {
-						cr := newRemediationCRForNHC("unhealthy-control-plane-node-1", underTest)
-						crList := &unstructured.UnstructuredList{Object: cr.Object}
-						Consistently(func(g Gomega) {
-							g.Expect(k8sClient.List(context.Background(), crList)).To(Succeed())
-							g.Expect(len(crList.Items)).To(BeNumerically("==", 0), "expected no remediation for cp node")
-						}, "10s", "1s").Should(Succeed())
-						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
-						Expect(*underTest.Status.ObservedNodes).To(Equal(3))
-						Expect(underTest.Status.InFlightRemediations).To(HaveLen(0))
-						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-						Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
-							And(
-								HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
-								HaveField("Remediations", BeNil()),
-							),
-						))
-					})
-				})
+		BeforeEach(func() {
+			forbidden = false
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationServiceAccount = &v1alpha1.ServiceAccountReference{Namespace: "gpu", Name: "gpu-remediator"}
+
+			gv := schema.GroupVersion{Group: InfraRemediationGroup, Version: InfraRemediationVersion}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind(InfraRemediationKind), meta.RESTScopeNamespace)
+			mapper.Add(gv.WithKind(InfraRemediationTemplateKind), meta.RESTScopeNamespace)
+			scheme := runtime.NewScheme()
+			Expect(v1.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).
+				WithObjects(nhc, newTestRemediationTemplateCR(InfraRemediationKind, MachineNamespace, InfraRemediationTemplateName)).
+				WithStatusSubresource(nhc).
+				Build()
+
+			reconciler = &NodeHealthCheckReconciler{
+				Client:        c,
+				Log:           controllerruntime.Log,
+				Recorder:      record.NewFakeRecorder(10),
+				MHCChecker:    mhc.DummyChecker{},
+				RemoteClients: remote.NewClientProvider(c, c, false, controllerruntime.Log),
+				// the ServiceAccount's permissions are simulated by the impersonating client
+				ImpersonatingClients: fakeImpersonatingClients(func(c client.Client, nhc *v1alpha1.NodeHealthCheck) (client.Client, error) {
+					return interceptor.NewClient(c.(client.WithWatch), interceptor.Funcs{
+						Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							if forbidden && obj.GetObjectKind().GroupVersionKind().Kind == InfraRemediationKind {
+								return errors.NewForbidden(schema.GroupResource{Group: gv.Group, Resource: "infrastructureremediations"}, obj.GetName(), fmt.Errorf("missing permissions"))
+							}
+							return client.Create(ctx, obj, opts...)
+						},
+					}), nil
+				}),
+			}
+		})
 
-				When("unhealthy node is disrupted already (guard pod is not ready)", func() {
+		reconcile := func() {
+			_, err := reconciler.Reconcile(context.Background(), controllerruntime.Request{NamespacedName: client.ObjectKeyFromObject(nhc)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+		}
 
-					BeforeEach(func() {
-						// create unready pod
-						createGuardPod(false)
-					})
+		When("the ServiceAccount lacks create permission", func() {
+			BeforeEach(func() {
+				forbidden = true
+			})
 
-					It("does create a remediation CR for control plane node", func() {
-						cr := newRemediationCRForNHC("unhealthy-control-plane-node-1", underTest)
-						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			It("disables the NHC", func() {
+				reconcile()
+				disabled := meta.FindStatusCondition(nhc.Status.Conditions, v1alpha1.ConditionTypeDisabled)
+				Expect(disabled).ToNot(BeNil())
+				Expect(disabled.Status).To(Equal(metav1.ConditionTrue))
+				Expect(disabled.Reason).To(Equal(v1alpha1.ConditionReasonDisabledRBAC))
+				Expect(disabled.Message).To(ContainSubstring("ServiceAccount gpu/gpu-remediator"))
+				Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseDisabled))
+			})
 
-						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
-						Expect(*underTest.Status.ObservedNodes).To(Equal(3))
-						Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
-						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-						Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
-							And(
-								HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
-								HaveField("Remediations", Not(BeNil())),
-							),
-						))
-					})
+			It("evaluates the disabled NHC again with backoff, until RBAC was fixed", func() {
+				now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+				fakeTime = &now
+				DeferCleanup(func() {
+					fakeTime = nil
 				})
+				reconcileAt := func(offset time.Duration) time.Duration {
+					at := now.Add(offset)
+					fakeTime = &at
+					result, err := reconciler.Reconcile(context.Background(), controllerruntime.Request{NamespacedName: client.ObjectKeyFromObject(nhc)})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+					return result.RequeueAfter
+				}
 
-				When("unhealthy node has no guard pod (node doesn't run etcd or guard pod was deleted)", func() {
+				Expect(reconcileAt(0)).To(Equal(15 * time.Second))
+				Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseDisabled))
+				Expect(nhc.Status.NextRetryTime.Time).To(BeTemporally("==", now.Add(15*time.Second)))
 
-					It("does create a remediation CR for control plane node", func() {
-						cr := newRemediationCRForNHC("unhealthy-control-plane-node-1", underTest)
-						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				By("keeping the schedule when reconciled before the retry is due")
+				Expect(reconcileAt(5 * time.Second)).To(Equal(10 * time.Second))
+				Expect(nhc.Status.NextRetryTime.Time).To(BeTemporally("==", now.Add(15*time.Second)))
 
-						Expect(*underTest.Status.HealthyNodes).To(Equal(2))
-						Expect(*underTest.Status.ObservedNodes).To(Equal(3))
-						Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
-						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-						Expect(underTest.Status.UnhealthyNodes).To(ContainElements(
-							And(
-								HaveField("Name", ContainSubstring("unhealthy-control-plane-node")),
-								HaveField("Remediations", Not(BeNil())),
-							),
-						))
-					})
-				})
+				By("doubling the interval on due retries")
+				Expect(reconcileAt(15 * time.Second)).To(Equal(30 * time.Second))
+				Expect(reconcileAt(45 * time.Second)).To(Equal(time.Minute))
+				Expect(nhc.Status.NextRetryTime.Time).To(BeTemporally("==", now.Add(105*time.Second)))
+
+				By("capping the interval")
+				offset := 105 * time.Second
+				for i := 0; i < 6; i++ {
+					offset += reconcileAt(offset)
+				}
+				Expect(reconcileAt(offset)).To(Equal(10 * time.Minute))
+
+				By("resetting the interval when the spec changes")
+				nhc.SetGeneration(nhc.GetGeneration() + 1)
+				Expect(reconciler.updateNextRetryTime(nhc, now.Add(offset+time.Second))).To(HaveValue(Equal(15 * time.Second)))
+
+				By("enabling the NHC after RBAC was fixed without any watched event")
+				forbidden = false
+				// pretend that the template and remediation CRs are watched already
+				gv := schema.GroupVersion{Group: InfraRemediationGroup, Version: InfraRemediationVersion}
+				reconciler.watchesLock = &sync.Mutex{}
+				reconciler.watches = map[string]struct{}{
+					gv.WithKind(InfraRemediationTemplateKind).String(): {},
+					gv.WithKind(InfraRemediationKind).String():         {},
+				}
+				reconciler.UtilizationChecker = utilization.NewChecker(false, controllerruntime.Log)
+				reconciler.ConnectivityChecker = connectivity.NewChecker(false, controllerruntime.Log)
+				reconciler.HeartbeatChecker = heartbeat.NewChecker(false, controllerruntime.Log)
+				reconciler.ClusterUpgradeStatusChecker = &fakeClusterUpgradeChecker{}
+				reconciler.RemediationLimiter = limiter.DummyLimiter{}
+				Expect(reconcileAt(offset + 10*time.Minute)).To(BeZero())
+				Expect(nhc.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+				Expect(nhc.Status.NextRetryTime).To(BeNil())
 			})
+		})
+
+		It("checks permissions with dry run requests only", func() {
+			remediationClient, err := reconciler.ImpersonatingClients.GetClient(c, nhc)
+			Expect(err).ToNot(HaveOccurred())
+			rm := resources.NewManager(remediationClient, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			allowed, message, err := rm.ValidateRemediationCRPermissions(nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+			Expect(message).To(BeEmpty())
 
+			crs := &unstructured.UnstructuredList{}
+			crs.SetGroupVersionKind(schema.GroupVersionKind{Group: InfraRemediationGroup, Version: InfraRemediationVersion, Kind: InfraRemediationKind + "List"})
+			Expect(c.List(context.Background(), crs)).To(Succeed())
+			Expect(crs.Items).To(BeEmpty())
 		})
+	})
 
-		When("remediation is needed but pauseRequests exists", func() {
-			BeforeEach(func() {
-				setupObjects(1, 2, true)
-				underTest.Spec.PauseRequests = []string{"I'm an admin, asking you to stop remediating this group of nodes"}
-			})
+	Context("Aborting all remediations", func() {
+		var (
+			c          client.Client
+			rm         resources.Manager
+			recorder   *record.FakeRecorder
+			reconciler *NodeHealthCheckReconciler
+			nhc        *v1alpha1.NodeHealthCheck
+			nodes      []*v1.Node
+			crs        []*unstructured.Unstructured
+		)
 
-			It("skips remediation and updates status", func() {
-				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
-				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-				Expect(errors.IsNotFound(err)).To(BeTrue())
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			nhc.Annotations = map[string]string{annotations.AbortAllAnnotation: "maintenance"}
+			nodes = nil
+			crs = nil
+			for _, name := range []string{"unhealthy-1", "unhealthy-2"} {
+				node := newNode(name, v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+				cr := newRemediationCRForNHC(node.GetName(), nhc)
+				resources.UpdateStatusRemediationStarted(node, nhc, cr, nil)
+				nodes = append(nodes, node)
+				crs = append(crs, cr)
+			}
+			// the first node was drained before remediation
+			nodes[0].Spec.Unschedulable = true
+			nodes[0].Annotations = map[string]string{annotations.CordonedByNHCAnnotation: ""}
+			resources.UpdateStatusDrain(nodes[0].GetName(), nhc, &v1alpha1.Drain{Phase: v1alpha1.DrainPhaseCompleted})
+		})
 
-				Expect(*underTest.Status.HealthyNodes).To(Equal(0))
-				Expect(*underTest.Status.ObservedNodes).To(Equal(0))
-				Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
-				Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
-				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhasePaused))
-				Expect(underTest.Status.Reason).ToNot(BeEmpty())
-			})
+		JustBeforeEach(func() {
+			gv := schema.GroupVersion{Group: InfraRemediationGroup, Version: InfraRemediationVersion}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind(InfraRemediationKind), meta.RESTScopeNamespace)
+			mapper.Add(gv.WithKind(InfraRemediationTemplateKind), meta.RESTScopeNamespace)
+			scheme := runtime.NewScheme()
+			Expect(v1.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			objects := []client.Object{nhc.DeepCopy()}
+			for i := range nodes {
+				objects = append(objects, nodes[i], crs[i])
+			}
+			c = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objects...).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			recorder = record.NewFakeRecorder(10)
+			reconciler = &NodeHealthCheckReconciler{
+				Client:             c,
+				Log:                controllerruntime.Log,
+				Recorder:           recorder,
+				RemediationLimiter: limiter.DummyLimiter{},
+				Drainer:            drain.NewDrainer(controllerruntime.Log),
+			}
 		})
 
-		When("Nodes are candidates for remediation and cluster is upgrading", func() {
+		It("aborts all remediations and pauses the NHC", func() {
+			pending, err := reconciler.abortAll(context.Background(), c, nhc, "maintenance", rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeFalse())
+
+			By("deleting the remediation CRs")
+			for _, cr := range crs {
+				Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr.DeepCopy()))).To(BeTrue())
+			}
+
+			By("aborting the remediations in the status")
+			Expect(nhc.Status.UnhealthyNodes).To(HaveLen(2))
+			for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+				Expect(unhealthyNode.Remediations).To(HaveLen(1))
+				Expect(unhealthyNode.Remediations[0].Phase).To(Equal(v1alpha1.RemediationPhaseAborted))
+				Expect(unhealthyNode.Remediations[0].Reason).To(Equal(v1alpha1.RemediationReasonAbortAll))
+				Expect(unhealthyNode.Remediations[0].TimedOut).ToNot(BeNil())
+				Expect(unhealthyNode.Drain).To(BeNil())
+			}
+			Expect(nhc.Status.InFlightRemediations).To(BeEmpty())
+
+			By("uncordoning the drained node")
+			node := &v1.Node{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nodes[0]), node)).To(Succeed())
+			Expect(node.Spec.Unschedulable).To(BeFalse())
+
+			By("adding a pause request")
+			Expect(nhc.Spec.PauseRequests).To(ConsistOf("abort-all: maintenance"))
+			updated := &v1alpha1.NodeHealthCheck{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), updated)).To(Succeed())
+			Expect(updated.Spec.PauseRequests).To(ConsistOf("abort-all: maintenance"))
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonAbortedAll)))
+
+			By("not adding the pause request again")
+			_, err = reconciler.abortAll(context.Background(), c, nhc, "maintenance", rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nhc.Spec.PauseRequests).To(HaveLen(1))
+			Expect(recorder.Events).ToNot(Receive())
+
+			By("starting over when resuming")
+			resources.RemoveStatusRemediationsAbortedAll(nhc)
+			Expect(nhc.Status.UnhealthyNodes).To(BeEmpty())
+		})
+
+		When("remediation CRs have finalizers", func() {
 			BeforeEach(func() {
-				clusterUpgradeRequeueAfter = 5 * time.Second
-				upgradeChecker.Upgrading = true
-				setupObjects(1, 2, true)
+				crs[1].SetFinalizers([]string{"remediator.medik8s.io/finalizer"})
 			})
 
-			AfterEach(func() {
-				upgradeChecker.Upgrading = false
-			})
+			It("surfaces remediation CRs which are pending deletion", func() {
+				_, err := reconciler.abortAll(context.Background(), c, nhc, "maintenance", rm, controllerruntime.Log)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonAbortedAll)))
 
-			It("doesn't not remediate but requeues reconciliation and updates status", func() {
-				cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
-				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-				Expect(errors.IsNotFound(err)).To(BeTrue())
+				pending, err := reconciler.abortAll(context.Background(), c, nhc, "maintenance", rm, controllerruntime.Log)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(BeTrue())
+				Expect(recorder.Events).To(Receive(ContainSubstring(crs[1].GetName())))
+			})
+		})
+	})
 
-				Expect(*underTest.Status.HealthyNodes).To(Equal(0))
-				Expect(*underTest.Status.ObservedNodes).To(Equal(0))
-				Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
-				Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
-				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
-				Expect(underTest.Status.Reason).ToNot(BeEmpty())
+	Context("Resetting nodes", func() {
+		var (
+			c          client.Client
+			rm         resources.Manager
+			recorder   *record.FakeRecorder
+			reconciler *NodeHealthCheckReconciler
+			nhc        *v1alpha1.NodeHealthCheck
+			node       *v1.Node
+			cr         *unstructured.Unstructured
+		)
 
-				By("stopping upgrade and waiting for requeue")
-				upgradeChecker.Upgrading = false
-				time.Sleep(10 * time.Second)
-				err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-				Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			nhc.Spec.MaxRemediationDuration = &metav1.Duration{Duration: time.Hour}
+			node = newNode("unhealthy", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			cr = newRemediationCRForNHC(node.GetName(), nhc)
+			resources.UpdateStatusRemediationStarted(node, nhc, cr, nil)
+
+			gv := schema.GroupVersion{Group: InfraRemediationGroup, Version: InfraRemediationVersion}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind(InfraRemediationKind), meta.RESTScopeNamespace)
+			mapper.Add(gv.WithKind(InfraRemediationTemplateKind), meta.RESTScopeNamespace)
+			scheme := runtime.NewScheme()
+			Expect(v1.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(nhc.DeepCopy(), node, cr).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+			recorder = record.NewFakeRecorder(10)
+			reconciler = &NodeHealthCheckReconciler{
+				Client:             c,
+				Log:                controllerruntime.Log,
+				Recorder:           recorder,
+				RemediationLimiter: limiter.DummyLimiter{},
+				Drainer:            drain.NewDrainer(controllerruntime.Log),
+				EventEmitter:       eventsink.DummyEmitter{},
+			}
 
-				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
-				Expect(*underTest.Status.HealthyNodes).To(Equal(2))
-				Expect(*underTest.Status.ObservedNodes).To(Equal(3))
-				Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
-				Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+			afterMaxDuration := nhc.Status.UnhealthyNodes[0].Remediations[0].Started.Add(2 * time.Hour)
+			fakeTime = &afterMaxDuration
+			DeferCleanup(func() {
+				fakeTime = nil
 			})
+		})
+
+		It("resumes remediation of an exhausted node", func() {
+			By("exhausting remediation")
+			exhausted, _, err := reconciler.checkMaxRemediationDuration(nhc, node, rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exhausted).To(BeTrue())
+			updateRemediationExhaustedCondition(nhc)
+			Expect(nhc.Status.ExhaustedNodes).To(ConsistOf(node.GetName()))
+			Expect(meta.IsStatusConditionTrue(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediationExhausted)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonRemediationExhausted)))
+
+			By("requesting the reset")
+			stored := &v1alpha1.NodeHealthCheck{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), stored)).To(Succeed())
+			stored.Annotations = map[string]string{annotations.ResetNodeAnnotation: node.GetName()}
+			Expect(c.Update(context.Background(), stored)).To(Succeed())
+
+			By("deleting the remediation CR first")
+			pending, err := reconciler.resetNode(context.Background(), c, nhc, node.GetName(), rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr.DeepCopy()))).To(BeTrue())
+			Expect(resources.IsStatusRemediationExhausted(node.GetName(), nhc)).To(BeTrue())
 
+			By("resetting the status when the remediation CR is gone")
+			pending, err = reconciler.resetNode(context.Background(), c, nhc, node.GetName(), rm, controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeFalse())
+			Expect(nhc.Status.UnhealthyNodes).To(BeEmpty())
+			updateRemediationExhaustedCondition(nhc)
+			Expect(nhc.Status.ExhaustedNodes).To(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(nhc.Status.Conditions, v1alpha1.ConditionTypeRemediationExhausted)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonNodeReset)))
+
+			By("removing the annotation")
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nhc), stored)).To(Succeed())
+			Expect(stored.Annotations).ToNot(HaveKey(annotations.ResetNodeAnnotation))
 		})
+	})
 
-		Context("Machine owners", func() {
-			When("Metal3RemediationTemplate is in correct namespace", func() {
+	Context("Target platforms", func() {
+		var (
+			c     client.Client
+			rm    resources.Manager
+			nhc   *v1alpha1.NodeHealthCheck
+			nodes []client.Object
+		)
 
-				var machine *machinev1beta1.Machine
+		newPlatformNode := func(name, arch, os string) *v1.Node {
+			node := newNode(name, v1.NodeReady, v1.ConditionTrue, false, false).(*v1.Node)
+			node.Labels[v1.LabelArchStable] = arch
+			node.Labels[v1.LabelOSStable] = os
+			return node
+		}
 
-				BeforeEach(func() {
-					setupObjects(1, 2, true)
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			nhc.Spec.TargetArchitectures = []string{"amd64"}
+			nhc.Spec.TargetOperatingSystems = []string{"linux"}
+			nodes = []client.Object{
+				newPlatformNode("linux-amd64", "amd64", "linux"),
+				newPlatformNode("linux-arm64", "arm64", "linux"),
+				newPlatformNode("windows-amd64", "amd64", "windows"),
+			}
+		})
 
-					// create machine
-					machine = &machinev1beta1.Machine{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      "test-machine",
-							Namespace: MachineNamespace,
-						},
-					}
-					objects = append(objects, machine)
+		JustBeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(v1.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(nodes, nhc)...).Build()
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+		})
 
-					// set machine annotation to unhealthy node
-					for _, o := range objects {
-						o := o
-						if o.GetName() == unhealthyNodeName {
-							ann := make(map[string]string)
-							ann["machine.openshift.io/machine"] = fmt.Sprintf("%s/%s", machine.Namespace, machine.Name)
-							o.SetAnnotations(ann)
-						}
-					}
+		It("only selects nodes of the target architectures and operating systems", func() {
+			selected, err := rm.GetSelectedNodes(nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selected).To(HaveLen(1))
+			Expect(selected[0].GetName()).To(Equal("linux-amd64"))
+
+			By("only queuing the NHC for nodes of the target platforms")
+			mapper := utils.NHCByNodeMapperFunc(c, controllerruntime.Log)
+			Expect(mapper(context.Background(), nodes[0])).To(HaveLen(1))
+			Expect(mapper(context.Background(), nodes[1])).To(BeEmpty())
+			Expect(mapper(context.Background(), nodes[2])).To(BeEmpty())
+		})
 
-					// set metal3 template
-					underTest.Spec.RemediationTemplate.Kind = "Metal3RemediationTemplate"
-					underTest.Spec.RemediationTemplate.Name = "ok"
-					underTest.Spec.RemediationTemplate.Namespace = MachineNamespace
+		It("selects nodes of all platforms without targets", func() {
+			nhc.Spec.TargetArchitectures = nil
+			nhc.Spec.TargetOperatingSystems = nil
+			selected, err := rm.GetSelectedNodes(nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selected).To(HaveLen(3))
+		})
 
-				})
+		When("a node name is set", func() {
+			It("doesn't select the node of another platform", func() {
+				nhc.Spec.NodeName = "linux-arm64"
+				selected, err := rm.GetSelectedNodes(nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(selected).To(BeEmpty())
 
-				It("should set owner ref to the machine", func() {
-					cr := newRemediationCRForNHC(unhealthyNodeName, underTest)
-					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
-					Expect(cr.GetOwnerReferences()).To(
-						ContainElement(
-							And(
-								// Kind and API version aren't set on underTest, envtest issue...
-								// Controller is empty for HaveField because false is the zero value?
-								HaveField("Name", machine.Name),
-								HaveField("UID", machine.UID),
-							),
-						),
-					)
-				})
+				nhc.Spec.NodeName = "linux-amd64"
+				selected, err = rm.GetSelectedNodes(nhc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(selected).To(HaveLen(1))
 			})
-
 		})
-
 	})
 
-	// TODO move to new suite in utils package
-	Context("Controller Watches", func() {
+	Context("Deleted nodes", func() {
 		var (
-			underTest1 *v1alpha1.NodeHealthCheck
-			underTest2 *v1alpha1.NodeHealthCheck
-			objects    []client.Object
+			c          client.Client
+			rm         resources.Manager
+			recorder   *record.FakeRecorder
+			reconciler *NodeHealthCheckReconciler
+			nhc        *v1alpha1.NodeHealthCheck
+			otherNHC   *v1alpha1.NodeHealthCheck
+			node       *v1.Node
+			cr         *unstructured.Unstructured
 		)
 
-		JustBeforeEach(func() {
-			createObjects(objects...)
-			time.Sleep(2 * time.Second)
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			otherNHC = newNodeHealthCheck()
+			otherNHC.Name = "other"
+			node = newNode("deleted", v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+			cr = newRemediationCRForNHC(node.GetName(), nhc)
+			resources.UpdateStatusRemediationStarted(node, nhc, cr, nil)
 		})
 
-		AfterEach(func() {
-			deleteObjects(objects...)
-			time.Sleep(1 * time.Second)
+		JustBeforeEach(func() {
+			gv := schema.GroupVersion{Group: InfraRemediationGroup, Version: InfraRemediationVersion}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind(InfraRemediationKind), meta.RESTScopeNamespace)
+			mapper.Add(gv.WithKind(InfraRemediationTemplateKind), meta.RESTScopeNamespace)
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			// the node is already deleted
+			c = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(nhc.DeepCopy(), otherNHC, cr).Build()
+			leaseManager, err := resources.NewLeaseManager(c, "test", controllerruntime.Log)
+			Expect(err).ToNot(HaveOccurred())
+			rm = resources.NewManager(c, context.Background(), controllerruntime.Log, false, leaseManager, record.NewFakeRecorder(10), false, nil)
+			recorder = record.NewFakeRecorder(10)
+			reconciler = &NodeHealthCheckReconciler{
+				Client:             c,
+				Log:                controllerruntime.Log,
+				Recorder:           recorder,
+				RemediationLimiter: limiter.DummyLimiter{},
+			}
 		})
 
-		When("a node changes status and is selectable by one NHC selector", func() {
-			BeforeEach(func() {
-				objects = newNodes(3, 10, false, true)
-				underTest1 = newNodeHealthCheck()
-				underTest2 = newNodeHealthCheck()
-				underTest2.Name = "test-2"
-				emptySelector, _ := metav1.ParseToLabelSelector("fooLabel=bar")
-				underTest2.Spec.Selector = *emptySelector
-				objects = append(objects, underTest1, underTest2)
-			})
+		It("only queues the NHCs which track the node", func() {
+			requests := utils.NHCByNodeMapperFunc(c, controllerruntime.Log)(context.Background(), node)
+			Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: nhc.GetName()}}))
+		})
 
-			It("creates a reconcile request", func() {
-				handler := utils.NHCByNodeMapperFunc(k8sClient, controllerruntime.Log)
-				updatedNode := v1.Node{
-					ObjectMeta: controllerruntime.ObjectMeta{Name: "healthy-worker-node-1"},
-				}
-				requests := handler(context.TODO(), &updatedNode)
-				Expect(len(requests)).To(Equal(1))
-				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest1.GetName()}}))
-			})
+		It("removes the node from the status and deletes its remediation CR", func() {
+			Expect(reconciler.handleDeletedNodes(context.Background(), c, nhc, nil, rm, controllerruntime.Log)).To(Succeed())
+			Expect(nhc.Status.UnhealthyNodes).To(BeEmpty())
+			Expect(nhc.Status.InFlightRemediations).To(BeEmpty())
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr.DeepCopy()))).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonNodeDeleted)))
 		})
 
-		When("a node changes status and is selectable by the more 2 NHC selector", func() {
+		When("the remediator expects the node's deletion", func() {
 			BeforeEach(func() {
-				objects = newNodes(3, 10, false, true)
-				underTest1 = newNodeHealthCheck()
-				underTest2 = newNodeHealthCheck()
-				underTest2.Name = "test-2"
-				objects = append(objects, underTest1, underTest2)
+				Expect(unstructured.SetNestedSlice(cr.Object, []interface{}{
+					map[string]interface{}{
+						"type":               commonconditions.PermanentNodeDeletionExpectedType,
+						"status":             string(metav1.ConditionTrue),
+						"lastTransitionTime": time.Now().Format(time.RFC3339),
+						"reason":             "Deleting",
+					},
+				}, "status", "conditions")).To(Succeed())
 			})
 
-			It("creates 2 reconcile requests", func() {
-				handler := utils.NHCByNodeMapperFunc(k8sClient, controllerruntime.Log)
-				updatedNode := v1.Node{
-					ObjectMeta: controllerruntime.ObjectMeta{Name: "healthy-worker-node-1"},
-				}
-				requests := handler(context.TODO(), &updatedNode)
-				Expect(len(requests)).To(Equal(2))
-				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest1.GetName()}}))
-				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest2.GetName()}}))
+			It("keeps the remediation CR", func() {
+				Expect(reconciler.handleDeletedNodes(context.Background(), c, nhc, nil, rm, controllerruntime.Log)).To(Succeed())
+				Expect(nhc.Status.UnhealthyNodes).To(BeEmpty())
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr.DeepCopy())).To(Succeed())
+				Expect(recorder.Events).To(Receive(ContainSubstring(utils.EventReasonNodeDeleted)))
 			})
 		})
-		When("a node changes status and there are no NHC objects", func() {
-			BeforeEach(func() {
-				objects = newNodes(3, 10, false, true)
-			})
 
-			It("doesn't create reconcile requests", func() {
-				handler := utils.NHCByNodeMapperFunc(k8sClient, controllerruntime.Log)
-				updatedNode := v1.Node{
-					ObjectMeta: controllerruntime.ObjectMeta{Name: "healthy-worker-node-1"},
-				}
-				requests := handler(context.TODO(), &updatedNode)
-				Expect(requests).To(BeEmpty())
+		When("the node isn't selected anymore", func() {
+			It("keeps tracking the node", func() {
+				Expect(c.Create(context.Background(), node)).To(Succeed())
+				Expect(reconciler.handleDeletedNodes(context.Background(), c, nhc, nil, rm, controllerruntime.Log)).To(Succeed())
+				Expect(nhc.Status.UnhealthyNodes).To(HaveLen(1))
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr.DeepCopy())).To(Succeed())
+				Expect(recorder.Events).ToNot(Receive())
 			})
 		})
 	})
@@ -1837,6 +6942,34 @@ var _ = Describe("Node Health Check CR", func() {
 			})
 		})
 
+		When("NetworkUnavailable condition status changed", func() {
+			BeforeEach(func() {
+				oldConditions = []v1.NodeCondition{
+					{
+						Type:   v1.NodeReady,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   v1.NodeNetworkUnavailable,
+						Status: v1.ConditionFalse,
+					},
+				}
+				newConditions = []v1.NodeCondition{
+					{
+						Type:   v1.NodeReady,
+						Status: v1.ConditionTrue,
+					},
+					{
+						Type:   v1.NodeNetworkUnavailable,
+						Status: v1.ConditionTrue,
+					},
+				}
+			})
+			It("should request reconcile", func() {
+				Expect(conditionsNeedReconcile(oldConditions, newConditions)).To(BeTrue())
+			})
+		})
+
 		When("condition was removed", func() {
 			BeforeEach(func() {
 				oldConditions = append(newConditions,
@@ -1952,6 +7085,63 @@ var _ = Describe("Node Health Check CR", func() {
 			})
 		})
 
+		When("NetworkUnavailable condition is used", func() {
+			BeforeEach(func() {
+				// every match emits an event
+				r.Recorder = record.NewFakeRecorder(10)
+				nhc.Spec.UnhealthyConditions = []v1alpha1.UnhealthyCondition{
+					{
+						Type:     v1.NodeNetworkUnavailable,
+						Status:   v1.ConditionTrue,
+						Duration: unhealthyDuration,
+					},
+				}
+			})
+
+			When("network is unavailable for less than the duration", func() {
+				BeforeEach(func() {
+					nodeConditions = []v1.NodeCondition{
+						{
+							Type:   v1.NodeReady,
+							Status: v1.ConditionTrue,
+						},
+						{
+							Type:               v1.NodeNetworkUnavailable,
+							Status:             v1.ConditionTrue,
+							LastTransitionTime: notExpiredTransitionTime,
+						},
+					}
+				})
+				It("should not report match, should report expiry", func() {
+					match, expire := r.matchesUnhealthyConditions(nhc, node)
+					Expect(match).To(BeFalse(), "expected healthy")
+					Expect(expire).ToNot(BeNil(), "expected expire to be set")
+					Expect(*expire).To(Equal(expireIn+expireBuffer), "expected expire in 3 seconds")
+				})
+			})
+
+			When("network is unavailable for longer than the duration", func() {
+				BeforeEach(func() {
+					nodeConditions = []v1.NodeCondition{
+						{
+							Type:   v1.NodeReady,
+							Status: v1.ConditionTrue,
+						},
+						{
+							Type:               v1.NodeNetworkUnavailable,
+							Status:             v1.ConditionTrue,
+							LastTransitionTime: expiredTransitionTime,
+						},
+					}
+				})
+				It("should report match, should not report expiry", func() {
+					match, expire := r.matchesUnhealthyConditions(nhc, node)
+					Expect(match).To(BeTrue(), "expected not healthy")
+					Expect(expire).To(BeNil(), "expected expire to not be set")
+				})
+			})
+		})
+
 		When("first condition matches but didn't expire, second condition matches and expired", func() {
 			BeforeEach(func() {
 				nodeConditions = []v1.NodeCondition{
@@ -1997,6 +7187,102 @@ var _ = Describe("Node Health Check CR", func() {
 			})
 		})
 
+		Context("with taint", func() {
+			var taints []v1.Taint
+
+			BeforeEach(func() {
+				// every match emits an event
+				r.Recorder = record.NewFakeRecorder(10)
+				nhc.Spec.UnhealthyConditions = []v1alpha1.UnhealthyCondition{
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						Duration: metav1.Duration{Duration: 0},
+					},
+					{
+						Type:          v1.NodeReady,
+						Status:        v1.ConditionUnknown,
+						Duration:      unhealthyDuration,
+						TaintKey:      v1.TaintNodeUnreachable,
+						TaintDuration: &unhealthyDuration,
+					},
+				}
+				nodeConditions = []v1.NodeCondition{
+					{
+						Type:               v1.NodeReady,
+						Status:             v1.ConditionUnknown,
+						LastTransitionTime: expiredTransitionTime,
+					},
+				}
+				taints = nil
+			})
+
+			JustBeforeEach(func() {
+				node.Spec.Taints = taints
+			})
+
+			When("the node doesn't have the taint", func() {
+				It("should match the condition without taint key", func() {
+					matched, expire := r.getMatchingUnhealthyCondition(nhc, node)
+					Expect(expire).To(BeNil(), "expected expire to not be set")
+					Expect(matched).To(Equal(&v1alpha1.MatchedCondition{
+						Type:   v1.NodeReady,
+						Status: v1.ConditionUnknown,
+					}))
+				})
+			})
+
+			When("the node has the taint for long enough", func() {
+				BeforeEach(func() {
+					taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &expiredTransitionTime}}
+				})
+				It("should match the condition with taint key", func() {
+					matched, expire := r.getMatchingUnhealthyCondition(nhc, node)
+					Expect(expire).To(BeNil(), "expected expire to not be set")
+					Expect(matched).To(Equal(&v1alpha1.MatchedCondition{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						TaintKey: v1.TaintNodeUnreachable,
+					}))
+				})
+			})
+
+			When("the node has the taint for a too short time", func() {
+				BeforeEach(func() {
+					taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &notExpiredTransitionTime}}
+				})
+				It("should not match the condition without taint key, should report expiry", func() {
+					matched, expire := r.getMatchingUnhealthyCondition(nhc, node)
+					Expect(matched).To(BeNil(), "expected healthy")
+					Expect(expire).ToNot(BeNil(), "expected expire to be set")
+					Expect(*expire).To(Equal(expireIn+expireBuffer), "expected expire in 3 seconds")
+				})
+			})
+
+			When("the condition didn't persist long enough, but the taint did", func() {
+				BeforeEach(func() {
+					nodeConditions[0].LastTransitionTime = notExpiredTransitionTime
+					taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &expiredTransitionTime}}
+				})
+				It("should not match, should report expiry", func() {
+					matched, expire := r.getMatchingUnhealthyCondition(nhc, node)
+					Expect(matched).To(BeNil(), "expected healthy")
+					Expect(expire).ToNot(BeNil(), "expected expire to be set")
+					Expect(*expire).To(Equal(expireIn+expireBuffer), "expected expire in 3 seconds")
+				})
+			})
+
+			When("the taint has no timestamp", func() {
+				BeforeEach(func() {
+					taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}
+				})
+				It("should match the condition with taint key", func() {
+					matched, _ := r.getMatchingUnhealthyCondition(nhc, node)
+					Expect(matched).ToNot(BeNil(), "expected not healthy")
+					Expect(matched.TaintKey).To(Equal(v1.TaintNodeUnreachable))
+				})
+			})
+		})
 	})
 })
 
@@ -2152,3 +7438,270 @@ func newNode(name string, t v1.NodeConditionType, s v1.ConditionStatus, isContro
 		},
 	}
 }
+
+// newPendingRemediations returns a fake client with count unhealthy nodes, and prepared remediations for them. Creating
+// remediation CRs takes the given latency, and fails with the error returned by createErr for the CR's name.
+func newPendingRemediations(count int, nhc *v1alpha1.NodeHealthCheck, latency time.Duration, createErr func(name string) error) (client.Client, []*pendingRemediation) {
+	var nodes []client.Object
+	var remediations []*pendingRemediation
+	for i := 0; i < count; i++ {
+		node := newNode(fmt.Sprintf("unhealthy-node-%02d", i), v1.NodeReady, v1.ConditionFalse, false, true).(*v1.Node)
+		nodes = append(nodes, node)
+		remediations = append(remediations, &pendingRemediation{
+			node: node,
+			cr:   newRemediationCRForNHC(node.GetName(), nhc),
+		})
+	}
+	c := fake.NewClientBuilder().WithObjects(nodes...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, isCR := obj.(*unstructured.Unstructured); isCR {
+				time.Sleep(latency)
+				if err := createErr(obj.GetName()); err != nil {
+					return err
+				}
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	return c, remediations
+}
+
+func BenchmarkCreateRemediationCRs(b *testing.B) {
+	for _, concurrency := range []int{1, 5, 20} {
+		b.Run(fmt.Sprintf("concurrency-%d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				nhc := newNodeHealthCheck()
+				c, remediations := newPendingRemediations(50, nhc, 5*time.Millisecond, func(string) error { return nil })
+				leaseManager, err := resources.NewLeaseManager(c, "nhc-test", controllerruntime.Log)
+				if err != nil {
+					b.Fatal(err)
+				}
+				rm := resources.NewManager(c, context.Background(), controllerruntime.Log, false, leaseManager, record.NewFakeRecorder(10), false, nil)
+				reconciler := &NodeHealthCheckReconciler{
+					Client:                         c,
+					Log:                            controllerruntime.Log,
+					RemediationCreationConcurrency: concurrency,
+				}
+				b.StartTimer()
+
+				reconciler.createRemediationCRs(context.Background(), nhc, rm, remediations)
+				for _, pending := range remediations {
+					if !pending.created {
+						b.Fatalf("remediation CR for node %s wasn't created: %v", pending.node.GetName(), pending.err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkGetSelectedNodes verifies that the node reads of many NHCs are served by the single shared node informer of
+// the manager's cache, instead of each NHC listing all nodes from the API server on every reconcile.
+func BenchmarkGetSelectedNodes(b *testing.B) {
+	for _, nhcCount := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("nhcs-%d", nhcCount), func(b *testing.B) {
+			api := newNodeListAPI(500)
+			config := &rest.Config{Host: "https://api.example.com"}
+			httpClient := &http.Client{Transport: api}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{v1.SchemeGroupVersion})
+			mapper.Add(v1.SchemeGroupVersion.WithKind("Node"), meta.RESTScopeRoot)
+
+			nodeCache, err := cache.New(config, cache.Options{HTTPClient: httpClient, Scheme: clientgoscheme.Scheme, Mapper: mapper})
+			if err != nil {
+				b.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() { _ = nodeCache.Start(ctx) }()
+			if !nodeCache.WaitForCacheSync(ctx) {
+				b.Fatal("failed to start the cache")
+			}
+
+			c, err := client.New(config, client.Options{HTTPClient: httpClient, Scheme: clientgoscheme.Scheme, Mapper: mapper,
+				Cache: &client.CacheOptions{Reader: nodeCache}})
+			if err != nil {
+				b.Fatal(err)
+			}
+			nhcs := make([]*v1alpha1.NodeHealthCheck, 0, nhcCount)
+			for i := 0; i < nhcCount; i++ {
+				nhc := newNodeHealthCheck()
+				nhc.Name = fmt.Sprintf("nhc-%d", i)
+				nhcs = append(nhcs, nhc)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for _, nhc := range nhcs {
+					rm := resources.NewManager(c, ctx, controllerruntime.Log, false, nil, record.NewFakeRecorder(10), false, nil)
+					nodes, err := rm.GetSelectedNodes(nhc)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if len(nodes) != api.nodeCount {
+						b.Fatalf("expected %d nodes, got %d", api.nodeCount, len(nodes))
+					}
+				}
+			}
+
+			b.StopTimer()
+			lists := api.lists.Load()
+			b.ReportMetric(float64(lists), "node-api-lists")
+			if lists != 1 {
+				b.Fatalf("expected a single node list request from the shared informer, got %d", lists)
+			}
+		})
+	}
+}
+
+// nodeListAPI is a minimal API server for nodes, which counts list requests
+type nodeListAPI struct {
+	nodeCount int
+	list      []byte
+	lists     atomic.Int64
+}
+
+func newNodeListAPI(nodeCount int) *nodeListAPI {
+	nodes := &v1.NodeList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NodeList"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+	}
+	for i := 0; i < nodeCount; i++ {
+		nodes.Items = append(nodes.Items, v1.Node{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i), ResourceVersion: "1"},
+		})
+	}
+	list, err := json.Marshal(nodes)
+	if err != nil {
+		panic(err)
+	}
+	return &nodeListAPI{nodeCount: nodeCount, list: list}
+}
+
+func (a *nodeListAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if req.Method != http.MethodGet || req.URL.Path != "/api/v1/nodes" {
+		return &http.Response{StatusCode: http.StatusNotFound, Header: header, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+	}
+	if req.URL.Query().Get("watch") == "true" {
+		// keep the watch open without events until the informer stops
+		body, writer := io.Pipe()
+		go func() {
+			<-req.Context().Done()
+			_ = writer.Close()
+		}()
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: body, Request: req}, nil
+	}
+	a.lists.Add(1)
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewReader(a.list)), Request: req}, nil
+}
+
+// fakeImpersonatingClients provides clients for simulating the permissions of remediation ServiceAccounts
+type fakeImpersonatingClients func(c client.Client, nhc *v1alpha1.NodeHealthCheck) (client.Client, error)
+
+func (f fakeImpersonatingClients) GetClient(c client.Client, nhc *v1alpha1.NodeHealthCheck) (client.Client, error) {
+	return f(c, nhc)
+}
+
+// fakeSilencer records the Alertmanager silences of the AlertSilencing by ID
+type fakeSilencer struct {
+	silences map[string]time.Time
+	calls    int
+	err      error
+}
+
+func (f *fakeSilencer) Silence(_ context.Context, _ *v1alpha1.NodeHealthCheck, _, id string, endsAt time.Time) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	if id == "" {
+		id = fmt.Sprintf("silence-%d", len(f.silences)+1)
+	}
+	f.silences[id] = endsAt
+	return id, nil
+}
+
+func (f *fakeSilencer) Expire(_ context.Context, _ *v1alpha1.NodeHealthCheck, id string) error {
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.silences, id)
+	return nil
+}
+
+// newSignalReconciler returns a reconciler for checking the unhealthy signals of nodes with fake clients, without
+// connectivity and heartbeat checks
+func newSignalReconciler() *NodeHealthCheckReconciler {
+	return &NodeHealthCheckReconciler{
+		Log:                 controllerruntime.Log,
+		Recorder:            record.NewFakeRecorder(10),
+		MHCChecker:          mhc.DummyChecker{},
+		ConnectivityChecker: connectivity.NewChecker(false, controllerruntime.Log),
+		HeartbeatChecker:    heartbeat.NewChecker(false, controllerruntime.Log),
+		UtilizationChecker:  utilization.NewChecker(true, controllerruntime.Log),
+	}
+}
+
+// unhealthySignal configures an unhealthy signal of a NHC, and provides nodes which match and don't match it
+type unhealthySignal struct {
+	// field is the name of the signal in the node evaluation, its name in the unhealthy node status is prefixed with
+	// "Matched"
+	field string
+	// description is how the unhealthy node status describes the matched signal
+	description string
+	configure   func(nhc *v1alpha1.NodeHealthCheck)
+	// newNodes returns a node which matches the signal, one which doesn't, and a client for reading their signal
+	newNodes func(now time.Time) (unhealthy, healthy v1.Node, c client.Client)
+	// track records in the status which nodes match the signal since now
+	track func(r *NodeHealthCheckReconciler, c client.Client, nhc *v1alpha1.NodeHealthCheck, nodes []v1.Node, now time.Time)
+	// heal stops the given node from matching the signal
+	heal func(c client.Client, node *v1.Node)
+	// tracked returns the nodes which are tracked in the status as matching the signal
+	tracked func(nhc *v1alpha1.NodeHealthCheck) interface{}
+	// matched returns what an unhealthy node matches since the given time
+	matched func(since metav1.Time) interface{}
+}
+
+func newGPUNode(name string, gpus string) v1.Node {
+	node := newNode(name, v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node)
+	if gpus != "" {
+		node.Status.Capacity = v1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)}
+	}
+	return *node
+}
+
+func newTaintedNode(name string, taints ...v1.Taint) v1.Node {
+	node := newNode(name, v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node)
+	node.Spec.Taints = taints
+	return *node
+}
+
+func newAnnotatedNode(name string, annotations map[string]string) v1.Node {
+	node := newNode(name, v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node)
+	node.SetAnnotations(annotations)
+	return *node
+}
+
+func newMemoryNode(name string) v1.Node {
+	node := newNode(name, v1.NodeReady, v1.ConditionTrue, false, true).(*v1.Node)
+	node.Status.Allocatable = v1.ResourceList{v1.ResourceMemory: resource.MustParse("8Gi")}
+	return *node
+}
+
+func newNodeMetrics(name, memory string) *unstructured.Unstructured {
+	metrics := &unstructured.Unstructured{}
+	metrics.SetGroupVersionKind(utilization.NodeMetricsGVK)
+	metrics.SetName(name)
+	Expect(unstructured.SetNestedStringMap(metrics.Object, map[string]string{"cpu": "1", "memory": memory}, "usage")).To(Succeed())
+	return metrics
+}
+
+// newNodeMetricsClient returns a fake client which serves the given node metrics
+func newNodeMetricsClient(metrics ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(utilization.NodeMetricsGVK, meta.RESTScopeRoot)
+	return fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(metrics...).Build()
+}
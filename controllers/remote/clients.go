@@ -0,0 +1,193 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+// KubeconfigSecretKey is the key of the kubeconfig in the secret referenced by a NHC's remoteCluster
+const KubeconfigSecretKey = "kubeconfig"
+
+// NotEnabledError indicates that a NHC configures a remote cluster, but remote clusters are not enabled
+var NotEnabledError = errors.New("remote clusters are not enabled, start the operator with --enable-remote-clusters")
+
+// ClientProvider provides clients for the remote clusters configured in NHCs
+type ClientProvider interface {
+	// GetClient returns the client to use for the nodes and remediation resources of the given NHC.
+	// That is the local client for NHCs without remote cluster. For remote clusters an error is returned
+	// when the cluster isn't reachable.
+	GetClient(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck) (client.Client, error)
+	// RemoveClient drops the cached remote client of the NHC with the given name, e.g. because it was deleted.
+	RemoveClient(nhcName string)
+}
+
+// NewClientProvider creates a new ClientProvider. The given client is used for NHCs without remote cluster.
+// The secret reader is used for reading kubeconfig secrets, it should be an uncached one in order to not cache
+// all secrets of the cluster. Only secrets in the operator's namespace are read.
+func NewClientProvider(localClient client.Client, secretReader client.Reader, enabled bool, log logr.Logger) ClientProvider {
+	return &clientProvider{
+		localClient:  localClient,
+		secretReader: secretReader,
+		enabled:      enabled,
+		log:          log.WithName("RemoteClientProvider"),
+		newClient: func(config *rest.Config, httpClient *http.Client, c client.Client) (client.Client, error) {
+			return client.New(config, client.Options{Scheme: c.Scheme(), HTTPClient: httpClient})
+		},
+		clients: make(map[string]*cachedClient),
+	}
+}
+
+type cachedClient struct {
+	secretKey       string
+	resourceVersion string
+	httpClient      *http.Client
+	client          client.Client
+}
+
+type clientProvider struct {
+	localClient  client.Client
+	secretReader client.Reader
+	enabled      bool
+	log          logr.Logger
+	newClient    func(config *rest.Config, httpClient *http.Client, c client.Client) (client.Client, error)
+	lock         sync.Mutex
+	// clients caches remote clients by NHC name
+	clients map[string]*cachedClient
+}
+
+var _ ClientProvider = &clientProvider{}
+
+func (p *clientProvider) GetClient(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck) (client.Client, error) {
+	if nhc.Spec.RemoteCluster == nil {
+		p.RemoveClient(nhc.GetName())
+		return p.localClient, nil
+	}
+	if !p.enabled {
+		return nil, NotEnabledError
+	}
+
+	secretRef := nhc.Spec.RemoteCluster.KubeconfigSecretRef
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the operator's namespace")
+	}
+	if secretRef.Namespace != ns {
+		return nil, fmt.Errorf("kubeconfig secret %s/%s isn't in the operator's namespace %s", secretRef.Namespace, secretRef.Name, ns)
+	}
+	secret := &corev1.Secret{}
+	if err := p.secretReader.Get(ctx, client.ObjectKey{Namespace: secretRef.Namespace, Name: secretRef.Name}, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get kubeconfig secret %s/%s", secretRef.Namespace, secretRef.Name)
+	}
+
+	remoteClient, err := p.getRemoteClient(nhc.GetName(), secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// ensure the remote cluster is reachable
+	if err := remoteClient.List(ctx, &corev1.NodeList{}, client.Limit(1)); err != nil {
+		return nil, errors.Wrapf(err, "remote cluster of secret %s/%s isn't reachable", secretRef.Namespace, secretRef.Name)
+	}
+	return remoteClient, nil
+}
+
+func (p *clientProvider) RemoveClient(nhcName string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.removeClient(nhcName)
+}
+
+// removeClient needs to be called with the lock held
+func (p *clientProvider) removeClient(nhcName string) {
+	cached, exists := p.clients[nhcName]
+	if !exists {
+		return
+	}
+	cached.httpClient.CloseIdleConnections()
+	delete(p.clients, nhcName)
+	p.log.Info("removed client for remote cluster", "NodeHealthCheck", nhcName, "secret", cached.secretKey)
+}
+
+func (p *clientProvider) getRemoteClient(nhcName string, secret *corev1.Secret) (client.Client, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	key := client.ObjectKeyFromObject(secret).String()
+	if cached, exists := p.clients[nhcName]; exists {
+		if cached.secretKey == key && cached.resourceVersion == secret.GetResourceVersion() {
+			return cached.client, nil
+		}
+		// the secret changed, don't keep connections with outdated credentials
+		p.removeClient(nhcName)
+	}
+
+	config, err := getRESTConfig(secret)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create HTTP client for remote cluster of secret %s", key)
+	}
+	remoteClient, err := p.newClient(config, httpClient, p.localClient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client for remote cluster of secret %s", key)
+	}
+
+	p.log.Info("created client for remote cluster", "NodeHealthCheck", nhcName, "secret", key, "host", config.Host)
+	p.clients[nhcName] = &cachedClient{
+		secretKey:       key,
+		resourceVersion: secret.GetResourceVersion(),
+		httpClient:      httpClient,
+		client:          remoteClient,
+	}
+	return remoteClient, nil
+}
+
+// getRESTConfig returns the config of the kubeconfig in the given secret. Kubeconfigs which would make the operator
+// run commands, use auth provider plugins or read files of the operator's pod are rejected, because everyone who can
+// write secrets in the operator's namespace could use them for acting with the operator's identity.
+func getRESTConfig(secret *corev1.Secret) (*rest.Config, error) {
+	key := client.ObjectKeyFromObject(secret).String()
+	kubeconfig, exists := secret.Data[KubeconfigSecretKey]
+	if !exists {
+		return nil, fmt.Errorf("kubeconfig secret %s doesn't have a %q key", key, KubeconfigSecretKey)
+	}
+	apiConfig, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse kubeconfig of secret %s", key)
+	}
+	for name, authInfo := range apiConfig.AuthInfos {
+		switch {
+		case authInfo.Exec != nil:
+			return nil, fmt.Errorf("kubeconfig of secret %s uses an exec plugin for user %s, which isn't supported", key, name)
+		case authInfo.AuthProvider != nil:
+			return nil, fmt.Errorf("kubeconfig of secret %s uses an auth provider for user %s, which isn't supported", key, name)
+		case authInfo.TokenFile != "" || authInfo.ClientCertificate != "" || authInfo.ClientKey != "":
+			return nil, fmt.Errorf("kubeconfig of secret %s references files for user %s, which isn't supported", key, name)
+		}
+	}
+	for name, cluster := range apiConfig.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return nil, fmt.Errorf("kubeconfig of secret %s references files for cluster %s, which isn't supported", key, name)
+		}
+	}
+	config, err := clientcmd.NewDefaultClientConfig(*apiConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse kubeconfig of secret %s", key)
+	}
+	return config, nil
+}
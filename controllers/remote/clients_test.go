@@ -0,0 +1,181 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+const (
+	operatorNamespace = "nhc-operator"
+	remoteHost        = "https://spoke.example.com:6443"
+)
+
+func newKubeconfig(user string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: spoke
+  cluster:
+    server: %s
+contexts:
+- name: spoke
+  context:
+    cluster: spoke
+    user: nhc
+current-context: spoke
+users:
+- name: nhc
+  user:
+%s
+`, remoteHost, user))
+}
+
+var _ = Describe("Remote clients", func() {
+
+	var (
+		localClient  client.Client
+		remoteClient client.Client
+		secret       *corev1.Secret
+		hosts        []string
+		p            *clientProvider
+		nhc          *remediationv1alpha1.NodeHealthCheck
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("DEPLOYMENT_NAMESPACE", operatorNamespace)).To(Succeed())
+		DeferCleanup(os.Unsetenv, "DEPLOYMENT_NAMESPACE")
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "spoke-kubeconfig"},
+			Data:       map[string][]byte{KubeconfigSecretKey: newKubeconfig("    token: secret-token")},
+		}
+		localClient = fake.NewClientBuilder().WithObjects(secret).Build()
+		remoteClient = fake.NewClientBuilder().WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "spoke-node"}}).Build()
+		hosts = nil
+		p = NewClientProvider(localClient, localClient, true, zap.New()).(*clientProvider)
+		p.newClient = func(config *rest.Config, _ *http.Client, _ client.Client) (client.Client, error) {
+			hosts = append(hosts, config.Host)
+			return remoteClient, nil
+		}
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				RemoteCluster: &remediationv1alpha1.RemoteCluster{
+					KubeconfigSecretRef: corev1.SecretReference{Namespace: operatorNamespace, Name: "spoke-kubeconfig"},
+				},
+			},
+		}
+	})
+
+	updateKubeconfig := func(user string) {
+		secret.Data[KubeconfigSecretKey] = newKubeconfig(user)
+		Expect(localClient.Update(context.Background(), secret)).To(Succeed())
+	}
+
+	When("no remote cluster is configured", func() {
+		It("should return the local client", func() {
+			nhc.Spec.RemoteCluster = nil
+			c, err := p.GetClient(context.Background(), nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c).To(BeIdenticalTo(localClient))
+			Expect(hosts).To(BeEmpty())
+		})
+	})
+
+	When("remote clusters are not enabled", func() {
+		It("should return an error", func() {
+			p.enabled = false
+			_, err := p.GetClient(context.Background(), nhc)
+			Expect(err).To(MatchError(NotEnabledError))
+			Expect(hosts).To(BeEmpty())
+		})
+	})
+
+	When("the kubeconfig secret is valid", func() {
+		It("should create a client for the remote cluster", func() {
+			c, err := p.GetClient(context.Background(), nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c).To(BeIdenticalTo(remoteClient))
+			Expect(hosts).To(ConsistOf(remoteHost))
+		})
+
+		It("should cache the client as long as the secret doesn't change", func() {
+			_, err := p.GetClient(context.Background(), nhc)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = p.GetClient(context.Background(), nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hosts).To(HaveLen(1))
+
+			updateKubeconfig("    token: rotated-token")
+			_, err = p.GetClient(context.Background(), nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hosts).To(HaveLen(2))
+			Expect(p.clients).To(HaveLen(1))
+		})
+
+		It("should drop the client of deleted NHCs", func() {
+			_, err := p.GetClient(context.Background(), nhc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.clients).To(HaveKey(nhc.GetName()))
+
+			p.RemoveClient(nhc.GetName())
+			Expect(p.clients).To(BeEmpty())
+		})
+
+		It("should return an error when the remote cluster isn't reachable", func() {
+			remoteClient = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+					return errors.New("connection refused")
+				},
+			}).Build()
+			_, err := p.GetClient(context.Background(), nhc)
+			Expect(err).To(MatchError(ContainSubstring("isn't reachable")))
+		})
+	})
+
+	When("the kubeconfig secret is invalid", func() {
+		It("should not read secrets outside of the operator's namespace", func() {
+			nhc.Spec.RemoteCluster.KubeconfigSecretRef.Namespace = "other"
+			_, err := p.GetClient(context.Background(), nhc)
+			Expect(err).To(MatchError(ContainSubstring("isn't in the operator's namespace")))
+			Expect(hosts).To(BeEmpty())
+		})
+
+		It("should return an error when the kubeconfig key is missing", func() {
+			secret.Data = map[string][]byte{"config": newKubeconfig("    token: secret-token")}
+			Expect(localClient.Update(context.Background(), secret)).To(Succeed())
+			_, err := p.GetClient(context.Background(), nhc)
+			Expect(err).To(MatchError(ContainSubstring(`doesn't have a "kubeconfig" key`)))
+			Expect(hosts).To(BeEmpty())
+		})
+
+		DescribeTable("should reject kubeconfigs which act with the operator's identity",
+			func(user, expectedError string) {
+				updateKubeconfig(user)
+				_, err := p.GetClient(context.Background(), nhc)
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+				Expect(hosts).To(BeEmpty())
+			},
+			Entry("exec plugin", "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: cat\n      args: [\"/etc/passwd\"]", "uses an exec plugin"),
+			Entry("auth provider", "    auth-provider:\n      name: oidc", "uses an auth provider"),
+			Entry("token file", "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token", "references files"),
+			Entry("client certificate file", "    client-certificate: /etc/tls/tls.crt\n    client-key: /etc/tls/tls.key", "references files"),
+		)
+	})
+})
@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+// NodeEvaluation is the result of checking the unhealthy signals of a node. The fields of signals which the node doesn't
+// match are nil.
+type NodeEvaluation struct {
+	Condition           *remediationv1alpha1.MatchedCondition
+	Capacity            *remediationv1alpha1.MatchedCapacity
+	Taint               *remediationv1alpha1.MatchedTaint
+	Annotation          *remediationv1alpha1.MatchedAnnotation
+	Utilization         *remediationv1alpha1.MatchedUtilization
+	UnreachableSince    *metav1.Time
+	HeartbeatStaleSince *metav1.Time
+}
+
+// GetUnhealthySince returns since when the node matches the unhealthy signals other than node conditions, which
+// don't have a transition time on the node.
+func (e *NodeEvaluation) GetUnhealthySince() []metav1.Time {
+	if e == nil {
+		return nil
+	}
+	var since []metav1.Time
+	if e.Capacity != nil {
+		since = append(since, e.Capacity.Since)
+	}
+	if e.Taint != nil {
+		since = append(since, e.Taint.Since)
+	}
+	if e.Annotation != nil {
+		since = append(since, e.Annotation.Since)
+	}
+	if e.Utilization != nil {
+		since = append(since, e.Utilization.Since)
+	}
+	for _, t := range []*metav1.Time{e.UnreachableSince, e.HeartbeatStaleSince} {
+		if t != nil {
+			since = append(since, *t)
+		}
+	}
+	return since
+}
+
+// GetMatchingUnhealthyCondition returns the first unhealthy condition with enabled remediation, which the given node
+// matches at the given time. If it doesn't match any, it returns the time until it is going to match one, or nil if
+// it isn't going to match any. Nodes which were remediated recently need to match for longer, if the NHC is configured
+// with adaptive stabilization.
+func GetMatchingUnhealthyCondition(nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node, now time.Time) (*remediationv1alpha1.MatchedCondition, *time.Duration) {
+	var expiresAfter *time.Duration
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		if !utils.IsRemediationEnabled(c) || utils.IsOverriddenByTaintedCondition(c, nhc.Spec.UnhealthyConditions, node) {
+			continue
+		}
+		c = GetStabilizedUnhealthyCondition(node.GetName(), nhc, c)
+		matches, thisExpiresAfter := utils.MatchesUnhealthyCondition(c, node, now)
+		if matches {
+			return &remediationv1alpha1.MatchedCondition{
+				Type:     c.Type,
+				Status:   c.Status,
+				TaintKey: c.TaintKey,
+			}, nil
+		}
+		expiresAfter = utils.MinRequeueDuration(expiresAfter, thisExpiresAfter)
+	}
+	return nil, expiresAfter
+}
+
+// GetMatchingReportOnlyConditions returns the unhealthy conditions with disabled remediation, which the given node
+// matches at the given time, and the time until the next one is going to match, or nil if none is going to match.
+func GetMatchingReportOnlyConditions(nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node, now time.Time) ([]remediationv1alpha1.MatchedCondition, *time.Duration) {
+	var matched []remediationv1alpha1.MatchedCondition
+	var expiresAfter *time.Duration
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		if utils.IsRemediationEnabled(c) || utils.IsOverriddenByTaintedCondition(c, nhc.Spec.UnhealthyConditions, node) {
+			continue
+		}
+		if matches, thisExpiresAfter := utils.MatchesUnhealthyCondition(c, node, now); matches {
+			matched = append(matched, remediationv1alpha1.MatchedCondition{
+				Type:     c.Type,
+				Status:   c.Status,
+				TaintKey: c.TaintKey,
+			})
+		} else {
+			expiresAfter = utils.MinRequeueDuration(expiresAfter, thisExpiresAfter)
+		}
+	}
+	return matched, expiresAfter
+}
+
+// GetMinHealthy returns the number of healthy nodes which the NHC's MinHealthy requires of the given number of
+// observed nodes. Percentages are rounded up. Falls back to the default MinHealthy when it isn't set, e.g. for NHCs
+// which were created while the defaulting webhook wasn't running.
+func GetMinHealthy(nhc *remediationv1alpha1.NodeHealthCheck, observedNodes int) (int, error) {
+	minHealthy := nhc.Spec.MinHealthy
+	if minHealthy == nil {
+		defaultMinHealthy := intstr.FromString(remediationv1alpha1.DefaultMinHealthy)
+		minHealthy = &defaultMinHealthy
+	}
+	return intstr.GetScaledValueFromIntOrPercent(minHealthy, observedNodes, true)
+}
+
+// GetMaxUnhealthy returns the number of nodes which the NHC's MaxUnhealthy allows to remediate at the same time, of
+// the given number of observed nodes. Percentages are rounded down. Returns -1 when MaxUnhealthy isn't set.
+func GetMaxUnhealthy(nhc *remediationv1alpha1.NodeHealthCheck, observedNodes int) (int, error) {
+	if nhc.Spec.MaxUnhealthy == nil {
+		return -1, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(nhc.Spec.MaxUnhealthy, observedNodes, false)
+}
+
+// CheckMinHealthy returns if the given number of healthy nodes meets the NHC's MinHealthy of the given number of
+// observed nodes, and a message explaining why remediation is skipped if it doesn't.
+func CheckMinHealthy(nhc *remediationv1alpha1.NodeHealthCheck, observedNodes, healthyNodes int) (bool, string, error) {
+	minHealthy, err := GetMinHealthy(nhc, observedNodes)
+	if err != nil {
+		return false, "", err
+	}
+	if healthyNodes < minHealthy {
+		return false, fmt.Sprintf("Skipped remediation because the number of healthy nodes selected by the selector is %d and should equal or exceed %d", healthyNodes, minHealthy), nil
+	}
+	return true, "", nil
+}
+
+// HasPositiveHealth returns true if the given node reports a Ready condition with status True
+func HasPositiveHealth(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package resources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+func newEvaluationNHC() *remediationv1alpha1.NodeHealthCheck {
+	return &remediationv1alpha1.NodeHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+		Spec: remediationv1alpha1.NodeHealthCheckSpec{
+			UnhealthyConditions: []remediationv1alpha1.UnhealthyCondition{
+				{
+					Type:     corev1.NodeReady,
+					Status:   corev1.ConditionFalse,
+					Duration: metav1.Duration{Duration: 5 * time.Minute},
+				},
+				{
+					Type:               corev1.NodeMemoryPressure,
+					Status:             corev1.ConditionTrue,
+					Duration:           metav1.Duration{Duration: time.Minute},
+					RemediationEnabled: pointer.Bool(false),
+				},
+			},
+		},
+	}
+}
+
+func newEvaluationNode(readyStatus corev1.ConditionStatus, since time.Time) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeReady,
+					Status:             readyStatus,
+					LastTransitionTime: metav1.Time{Time: since},
+				},
+				{
+					Type:               corev1.NodeMemoryPressure,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{Time: since},
+				},
+			},
+		},
+	}
+}
+
+func TestGetMatchingUnhealthyCondition(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	node := newEvaluationNode(corev1.ConditionFalse, now.Add(-2*time.Minute))
+
+	tests := []struct {
+		name             string
+		now              time.Time
+		wantMatched      *remediationv1alpha1.MatchedCondition
+		wantExpiresAfter *time.Duration
+	}{
+		{
+			name:             "not unhealthy for long enough",
+			now:              now,
+			wantExpiresAfter: pointer.Duration(3 * time.Minute),
+		},
+		{
+			name:        "unhealthy after the duration",
+			now:         now.Add(3*time.Minute + time.Second),
+			wantMatched: &remediationv1alpha1.MatchedCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, expiresAfter := GetMatchingUnhealthyCondition(newEvaluationNHC(), node, tt.now)
+			if !reflect.DeepEqual(matched, tt.wantMatched) {
+				t.Errorf("GetMatchingUnhealthyCondition() matched = %v, want %v", matched, tt.wantMatched)
+			}
+			if !reflect.DeepEqual(expiresAfter, tt.wantExpiresAfter) {
+				t.Errorf("GetMatchingUnhealthyCondition() expiresAfter = %v, want %v", expiresAfter, tt.wantExpiresAfter)
+			}
+		})
+	}
+}
+
+func TestGetMatchingReportOnlyConditions(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	node := newEvaluationNode(corev1.ConditionFalse, now.Add(-2*time.Minute))
+
+	matched, _ := GetMatchingReportOnlyConditions(newEvaluationNHC(), node, now)
+	want := []remediationv1alpha1.MatchedCondition{{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue}}
+	if !reflect.DeepEqual(matched, want) {
+		t.Errorf("GetMatchingReportOnlyConditions() = %v, want %v", matched, want)
+	}
+}
+
+func TestCheckMinHealthy(t *testing.T) {
+	tests := []struct {
+		name         string
+		minHealthy   *intstr.IntOrString
+		healthyNodes int
+		wantAllowed  bool
+		wantMessage  string
+		wantErr      bool
+	}{
+		{
+			name:         "enough healthy nodes",
+			minHealthy:   &intstr.IntOrString{Type: intstr.String, StrVal: "51%"},
+			healthyNodes: 2,
+			wantAllowed:  true,
+		},
+		{
+			name:         "not enough healthy nodes",
+			minHealthy:   &intstr.IntOrString{Type: intstr.String, StrVal: "51%"},
+			healthyNodes: 1,
+			wantMessage:  "is 1 and should equal or exceed 2",
+		},
+		{
+			name:         "default MinHealthy",
+			healthyNodes: 1,
+			wantMessage:  "is 1 and should equal or exceed 2",
+		},
+		{
+			name:         "invalid MinHealthy",
+			minHealthy:   &intstr.IntOrString{Type: intstr.String, StrVal: "many"},
+			healthyNodes: 3,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nhc := newEvaluationNHC()
+			nhc.Spec.MinHealthy = tt.minHealthy
+			allowed, message, err := CheckMinHealthy(nhc, 3, tt.healthyNodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckMinHealthy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("CheckMinHealthy() allowed = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if tt.wantMessage == "" && message != "" || !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("CheckMinHealthy() message = %q, want %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestGetMaxUnhealthy(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxUnhealthy *intstr.IntOrString
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "not set",
+			want: -1,
+		},
+		{
+			name:         "percentage rounded down",
+			maxUnhealthy: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+			want:         1,
+		},
+		{
+			name:         "absolute number",
+			maxUnhealthy: &intstr.IntOrString{Type: intstr.Int, IntVal: 2},
+			want:         2,
+		},
+		{
+			name:         "invalid value",
+			maxUnhealthy: &intstr.IntOrString{Type: intstr.String, StrVal: "many"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nhc := newEvaluationNHC()
+			nhc.Spec.MaxUnhealthy = tt.maxUnhealthy
+			got, err := GetMaxUnhealthy(nhc, 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMaxUnhealthy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("GetMaxUnhealthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasPositiveHealth(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "ready",
+			node: newEvaluationNode(corev1.ConditionTrue, time.Now()),
+			want: true,
+		},
+		{
+			name: "not ready",
+			node: newEvaluationNode(corev1.ConditionFalse, time.Now()),
+		},
+		{
+			name: "no Ready condition",
+			node: &corev1.Node{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasPositiveHealth(tt.node); got != tt.want {
+				t.Errorf("HasPositiveHealth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,22 +24,28 @@ import (
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
+	"github.com/medik8s/node-healthcheck-operator/metrics"
 )
 
 type Manager interface {
-	GetCurrentTemplateWithTimeout(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck) (*unstructured.Unstructured, *time.Duration, error)
+	GetCurrentTemplateWithTimeout(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) (*unstructured.Unstructured, *time.Duration, error)
+	GetRemediationTemplates(nhc *remediationv1alpha1.NodeHealthCheck) ([]*unstructured.Unstructured, error)
 	GetTemplate(mhc *machinev1beta1.MachineHealthCheck) (*unstructured.Unstructured, error)
 	GenerateTemplate(reference *corev1.ObjectReference) *unstructured.Unstructured
 	ValidateTemplates(nhc *remediationv1alpha1.NodeHealthCheck) (valid bool, reason string, message string, err error)
+	ResolveTemplateRefs(nhc *remediationv1alpha1.NodeHealthCheck) ([]corev1.ObjectReference, error)
+	ValidateRemediationCRPermissions(nhc *remediationv1alpha1.NodeHealthCheck) (allowed bool, message string, err error)
 	GenerateRemediationCRBase(gvk schema.GroupVersionKind) *unstructured.Unstructured
 	GenerateRemediationCRBaseNamed(gvk schema.GroupVersionKind, namespace string, name string) *unstructured.Unstructured
 	GenerateRemediationCRForNode(node *corev1.Node, owner client.Object, template *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	GenerateRemediationCRForMachine(machine *machinev1beta1.Machine, owner client.Object, template *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	CreateRemediationCR(remediationCR *unstructured.Unstructured, owner client.Object, nodeName *string, currentRemediationDuration, previousRemediationsDuration time.Duration) (bool, *time.Duration, *unstructured.Unstructured, error)
 	DeleteRemediationCR(remediationCR *unstructured.Unstructured, owner client.Object) (bool, error)
-	UpdateRemediationCR(remediationCR *unstructured.Unstructured) error
+	UpdateRemediationCR(remediationCR *unstructured.Unstructured, mutate func() bool) (bool, error)
+	DeleteRemediationCRsOfKind(gvk schema.GroupVersionKind, owner client.Object) (int, error)
 	ListRemediationCRs(remediationTemplates []*corev1.ObjectReference, remediationCRFilter func(r unstructured.Unstructured) bool) ([]unstructured.Unstructured, error)
 	GetNodes(labelSelector metav1.LabelSelector) ([]corev1.Node, error)
+	GetSelectedNodes(nhc *remediationv1alpha1.NodeHealthCheck) ([]corev1.Node, error)
 	GetMHCTargets(mhc *machinev1beta1.MachineHealthCheck) ([]Target, error)
 	HandleHealthyNode(nodeName string, crName string, owner client.Object) ([]unstructured.Unstructured, error)
 	CleanUp(nodeName string) error
@@ -49,6 +55,36 @@ type RemediationCRNotOwned struct{ msg string }
 
 func (r RemediationCRNotOwned) Error() string { return r.msg }
 
+// RemediationCRDeletionFailed is returned when deleting a remediation CR failed
+type RemediationCRDeletionFailed struct {
+	Resource corev1.ObjectReference
+	NodeName string
+	err      error
+}
+
+func (r RemediationCRDeletionFailed) Error() string { return r.err.Error() }
+
+func (r RemediationCRDeletionFailed) Unwrap() error { return r.err }
+
+const (
+	// OwnerKindLabel is the label with the kind of the owner of remediation CRs, which are created in another cluster
+	// than their owner, so that they can't have an owner reference
+	OwnerKindLabel = "remediation.medik8s.io/owner-kind"
+	// OwnerNameLabel is the label with the name of the owner of remediation CRs, which are created in another cluster
+	// than their owner, so that they can't have an owner reference
+	OwnerNameLabel = "remediation.medik8s.io/owner-name"
+)
+
+// ManagementCluster is the management cluster of hosted control planes, e.g. HyperShift, where the Machines of the
+// nodes live
+type ManagementCluster struct {
+	// Client is the client of the management cluster
+	Client client.Client
+	// HasRemediations is true when remediation templates and CRs live in the management cluster as well, which means
+	// that the Manager's client is the client of the management cluster
+	HasRemediations bool
+}
+
 type manager struct {
 	client.Client
 	ctx          context.Context
@@ -56,48 +92,70 @@ type manager struct {
 	onOpenshift  bool
 	leaseManager LeaseManager
 	recorder     record.EventRecorder
+	// generateNames is true when remediation CRs are always created with a generated name
+	generateNames bool
+	// managementCluster is the cluster of the Machines, nil when they live next to the nodes
+	managementCluster *ManagementCluster
 }
 
 var _ Manager = &manager{}
 
-func NewManager(c client.Client, ctx context.Context, log logr.Logger, onOpenshift bool, leaseManager LeaseManager, recorder record.EventRecorder) Manager {
+// NewManager creates a new Manager. When generateNames is true, remediation CRs are created with a generated name,
+// prefixed with the node or machine name, and they are tracked by annotations instead of by their name.
+// The optional management cluster is used for looking up the Machines of nodes.
+func NewManager(c client.Client, ctx context.Context, log logr.Logger, onOpenshift bool, leaseManager LeaseManager, recorder record.EventRecorder, generateNames bool, managementCluster *ManagementCluster) Manager {
 	return &manager{
-		Client:       c,
-		ctx:          ctx,
-		log:          log.WithName("resource manager"),
-		onOpenshift:  onOpenshift,
-		leaseManager: leaseManager,
-		recorder:     recorder,
+		Client:            c,
+		ctx:               ctx,
+		log:               log.WithName("resource manager"),
+		onOpenshift:       onOpenshift,
+		leaseManager:      leaseManager,
+		recorder:          recorder,
+		generateNames:     generateNames,
+		managementCluster: managementCluster,
 	}
 }
 
 func (m *manager) GenerateRemediationCRForNode(node *corev1.Node, owner client.Object, template *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 
 	nhcOwnerRef := createOwnerRef(owner)
+	var ownerLabels map[string]string
+	if m.managementCluster != nil && m.managementCluster.HasRemediations {
+		// owner references don't work across clusters
+		nhcOwnerRef = nil
+		ownerLabels = createOwnerLabels(owner)
+	}
 
-	// also set the node's machine as owner ref if possible
-	// TODO also handle CAPI clusters / machines
-	var machineOwnerRef *metav1.OwnerReference
-	if m.onOpenshift {
-		ref, machineNamespace, err := m.getOwningMachineWithNamespace(node)
-		if err != nil {
+	namespace := m.getRemediationCRNamespace(node, owner, template.GetNamespace())
+	if template.GetKind() == metal3RemediationTemplateKind {
+		if err := m.validateMetal3Namespace(node, namespace); err != nil {
 			return nil, err
 		}
-		if ref != nil && machineNamespace != "" {
-			// Owners must be cluster scoped, or in the same namespace as their dependent.
-			// Machines are always namespaced.
-			// So setting the machine as owner only works when the machine is in the same template as the remediation CR
-			if template.GetNamespace() == machineNamespace {
-				machineOwnerRef = ref
-			} else {
-				// What to do if namespaces don't match?
-				// So far this is a known issue for Metal3 remediation only, and that case was checked already
-				// in the Reconciler. So ignore, logging it is too verbose.
-			}
+	}
+
+	// also set the node's machine as owner ref if possible, for any template kind, so that remediation CRs are
+	// garbage collected together with the machine
+	var machineOwnerRef *metav1.OwnerReference
+	ref, machineNamespace, err := m.getOwningMachineWithNamespace(node)
+	if err != nil {
+		return nil, err
+	}
+	if ref != nil && machineNamespace != "" {
+		// Owners must be cluster scoped, or in the same namespace as their dependent.
+		// Machines are always namespaced.
+		// So setting the machine as owner only works when the machine is in the same namespace as the remediation CR
+		if m.managementCluster != nil && !m.managementCluster.HasRemediations {
+			m.log.Info("skipping machine owner reference, because the machine is in the management cluster",
+				"node", node.GetName(), "machine", ref.Name, "machine namespace", machineNamespace)
+		} else if namespace == machineNamespace {
+			machineOwnerRef = ref
+		} else {
+			m.log.Info("skipping machine owner reference, because the machine is in another namespace than the remediation CR",
+				"node", node.GetName(), "machine", ref.Name, "machine namespace", machineNamespace, "remediation CR namespace", namespace)
 		}
 	}
 
-	return m.generateRemediationCR(node.GetName(), nhcOwnerRef, machineOwnerRef, template)
+	return m.generateRemediationCR(node.GetName(), nhcOwnerRef, machineOwnerRef, ownerLabels, template, namespace)
 }
 
 func (m *manager) GenerateRemediationCRForMachine(machine *machinev1beta1.Machine, owner client.Object, template *unstructured.Unstructured) (*unstructured.Unstructured, error) {
@@ -115,10 +173,10 @@ func (m *manager) GenerateRemediationCRForMachine(machine *machinev1beta1.Machin
 		// So it can be ignored here.
 	}
 
-	return m.generateRemediationCR(machine.GetName(), mhcOwnerRef, machineOwnerRef, template)
+	return m.generateRemediationCR(machine.GetName(), mhcOwnerRef, machineOwnerRef, nil, template, template.GetNamespace())
 }
 
-func (m *manager) generateRemediationCR(name string, healthCheckOwnerRef *metav1.OwnerReference, machineOwnerRef *metav1.OwnerReference, template *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (m *manager) generateRemediationCR(name string, healthCheckOwnerRef *metav1.OwnerReference, machineOwnerRef *metav1.OwnerReference, ownerLabels map[string]string, template *unstructured.Unstructured, namespace string) (*unstructured.Unstructured, error) {
 
 	remediationCR := m.GenerateRemediationCRBase(template.GroupVersionKind())
 
@@ -126,14 +184,14 @@ func (m *manager) generateRemediationCR(name string, healthCheckOwnerRef *metav1
 	templateSpec, _, _ := unstructured.NestedMap(template.Object, "spec", "template", "spec")
 	unstructured.SetNestedField(remediationCR.Object, templateSpec, "spec")
 
-	if annotations.HasMultipleTemplatesAnnotation(template) {
+	if m.generateNames || annotations.HasMultipleTemplatesAnnotation(template) {
 		remediationCR.SetGenerateName(name)
 		remediationCR.SetAnnotations(map[string]string{commonannotations.NodeNameAnnotation: name, annotations.TemplateNameAnnotation: template.GetName()})
 	} else {
 		remediationCR.SetName(name)
 	}
 
-	remediationCR.SetNamespace(template.GetNamespace())
+	remediationCR.SetNamespace(namespace)
 	remediationCR.SetResourceVersion("")
 	remediationCR.SetFinalizers(nil)
 	remediationCR.SetUID("")
@@ -141,11 +199,17 @@ func (m *manager) generateRemediationCR(name string, healthCheckOwnerRef *metav1
 	remediationCR.SetCreationTimestamp(metav1.Now())
 
 	owners := make([]metav1.OwnerReference, 0)
+	if healthCheckOwnerRef != nil || ownerLabels != nil {
+		labels := map[string]string{
+			"app.kubernetes.io/part-of": "node-healthcheck-controller",
+		}
+		for key, value := range ownerLabels {
+			labels[key] = value
+		}
+		remediationCR.SetLabels(labels)
+	}
 	if healthCheckOwnerRef != nil {
 		owners = append(owners, *healthCheckOwnerRef)
-		remediationCR.SetLabels(map[string]string{
-			"app.kubernetes.io/part-of": "node-healthcheck-controller",
-		})
 	}
 	if machineOwnerRef != nil {
 		owners = append(owners, *machineOwnerRef)
@@ -245,14 +309,50 @@ func (m *manager) DeleteRemediationCR(remediationCR *unstructured.Unstructured,
 
 	err = m.Delete(m.ctx, remediationCR, &client.DeleteOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		return false, err
+		return false, RemediationCRDeletionFailed{
+			Resource: corev1.ObjectReference{
+				APIVersion: remediationCR.GetAPIVersion(),
+				Kind:       remediationCR.GetKind(),
+				Namespace:  remediationCR.GetNamespace(),
+				Name:       remediationCR.GetName(),
+			},
+			NodeName: GetNodeName(*remediationCR),
+			err:      err,
+		}
 	}
 	commonevents.NormalEventf(m.recorder, owner, utils.EventReasonRemediationRemoved, "Deleted remediation CR of kind %s with name %s", remediationCR.GetKind(), remediationCR.GetName())
+	if _, isNHC := owner.(*remediationv1alpha1.NodeHealthCheck); isNHC {
+		metrics.ObserveNodeHealthCheckRemediationCRDeleted(owner.GetName(), remediationCR.GetKind())
+	}
 	return true, nil
 }
 
-func (m *manager) UpdateRemediationCR(remediationCR *unstructured.Unstructured) error {
-	return m.Update(m.ctx, remediationCR)
+// DeleteRemediationCRsOfKind deletes all remediation CRs of the given kind which are owned by the given owner.
+// Returns the number of owned CRs which existed before, including ones which are still pending deletion because of
+// finalizers. The error is a NoMatch error when the kind isn't served anymore, e.g. because its CRD was uninstalled.
+func (m *manager) DeleteRemediationCRsOfKind(gvk schema.GroupVersionKind, owner client.Object) (int, error) {
+	crList := &unstructured.UnstructuredList{Object: m.GenerateRemediationCRBase(gvk).Object}
+	if err := m.List(m.ctx, crList); err != nil {
+		return 0, errors.Wrapf(err, "failed to list remediation CRs of kind %s", gvk.Kind)
+	}
+	owned := 0
+	for i := range crList.Items {
+		cr := &crList.Items[i]
+		if !IsOwner(cr, owner) {
+			continue
+		}
+		owned++
+		if _, err := m.DeleteRemediationCR(cr, owner); err != nil {
+			return owned, errors.Wrapf(err, "failed to delete remediation CR %s/%s", cr.GetNamespace(), cr.GetName())
+		}
+	}
+	return owned, nil
+}
+
+// UpdateRemediationCR applies the given mutation to the latest version of the remediation CR and updates it, with
+// retries on conflicts. The mutation returns false if no update is needed. Returns true if the CR was updated.
+func (m *manager) UpdateRemediationCR(remediationCR *unstructured.Unstructured, mutate func() bool) (bool, error) {
+	return utils.UpdateWithConflictRetry(m.ctx, m.Client, remediationCR, mutate)
 }
 
 func (m *manager) ListRemediationCRs(remediationTemplates []*corev1.ObjectReference, remediationCRFilter func(r unstructured.Unstructured) bool) ([]unstructured.Unstructured, error) {
@@ -269,7 +369,7 @@ func (m *manager) ListRemediationCRs(remediationTemplates []*corev1.ObjectRefere
 				baseRemediationCR.GetAPIVersion())
 		} else {
 			for _, cr := range crList.Items {
-				if m.isMatchNodeTemplate(cr, m.extractNodeName(cr), template.Name) {
+				if m.isMatchNodeTemplate(cr, GetNodeName(cr), template.Name) {
 					remediationCRs = append(remediationCRs, cr)
 				}
 			}
@@ -297,6 +397,34 @@ func (m *manager) GetNodes(labelSelector metav1.LabelSelector) ([]corev1.Node, e
 	return nodes.Items, err
 }
 
+// GetSelectedNodes returns the nodes selected by the given NHC, which is either the node with the configured node
+// name, or the nodes matching the selector. Both are restricted to the NHC's target architectures and operating
+// systems.
+func (m *manager) GetSelectedNodes(nhc *remediationv1alpha1.NodeHealthCheck) ([]corev1.Node, error) {
+	if nhc.Spec.NodeName == "" {
+		selector, err := nhc.Spec.GetNodeSelector()
+		if err != nil {
+			return []corev1.Node{}, errors.Wrapf(err, "failed converting a selector from NHC selector")
+		}
+		var nodes corev1.NodeList
+		err = m.List(m.ctx, &nodes, &client.ListOptions{LabelSelector: selector})
+		return nodes.Items, err
+	}
+	node := &corev1.Node{}
+	if err := m.Get(m.ctx, client.ObjectKey{Name: nhc.Spec.NodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []corev1.Node{}, nil
+		}
+		return []corev1.Node{}, err
+	}
+	if !nhc.Spec.MatchesTargetPlatform(node.GetLabels()) {
+		return []corev1.Node{}, nil
+	}
+	return []corev1.Node{*node}, nil
+}
+
+// IsOwner returns true if the given owner owns the given remediation CR, by owner reference, or by owner labels for
+// remediation CRs in another cluster than their owner
 func IsOwner(remediationCR *unstructured.Unstructured, owner client.Object) bool {
 	apiVersion, kind := owner.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
 	for _, ownerRef := range remediationCR.GetOwnerReferences() {
@@ -304,12 +432,13 @@ func IsOwner(remediationCR *unstructured.Unstructured, owner client.Object) bool
 			return true
 		}
 	}
-	return false
+	labels := remediationCR.GetLabels()
+	return kind != "" && labels[OwnerKindLabel] == kind && labels[OwnerNameLabel] == owner.GetName()
 }
 
 func (m *manager) HandleHealthyNode(nodeName string, crName string, owner client.Object) ([]unstructured.Unstructured, error) {
 	remediationCRs, err := m.ListRemediationCRs(utils.GetAllRemediationTemplates(owner), func(cr unstructured.Unstructured) bool {
-		return (cr.GetName() == crName || m.extractNodeName(cr) == nodeName) && IsOwner(&cr, owner)
+		return (cr.GetName() == crName || GetNodeName(cr) == nodeName) && IsOwner(&cr, owner)
 	})
 	if err != nil {
 		m.log.Error(err, "failed to get remediation CRs for healthy node", "node", nodeName)
@@ -341,23 +470,83 @@ func (m *manager) CleanUp(nodeName string) error {
 	return m.leaseManager.InvalidateLease(m.ctx, nodeName)
 }
 
+// getRemediationCRNamespace returns the namespace of the given node's remediation CR. That is the template's
+// namespace, unless the owner is a NHC with the MachineNamespace strategy, and the node has a machine annotation.
+func (m *manager) getRemediationCRNamespace(node *corev1.Node, owner client.Object, templateNamespace string) string {
+	nhc, isNHC := owner.(*remediationv1alpha1.NodeHealthCheck)
+	if !isNHC || nhc.Spec.RemediationNamespaceStrategy != remediationv1alpha1.RemediationNamespaceStrategyMachineNamespace {
+		return templateNamespace
+	}
+	ns, _, err := utils.GetMachineNamespaceName(node)
+	if errors.Is(err, utils.MachineAnnotationNotFoundError) {
+		ns, _, err = utils.GetCAPIMachineNamespaceName(node)
+	}
+	if err != nil || ns == "" {
+		m.log.Info("didn't find machine namespace, using the template namespace for the remediation CR", "node", node.GetName(), "namespace", templateNamespace)
+		return templateNamespace
+	}
+	return ns
+}
+
+// getOwningMachineWithNamespace returns an owner reference to the OpenShift or Cluster API machine of the given node,
+// and the machine's namespace. Returns nil without error when the node has no machine annotation.
 func (m *manager) getOwningMachineWithNamespace(node *corev1.Node) (*metav1.OwnerReference, string, error) {
+	var machine client.Object = &machinev1beta1.Machine{}
 	ns, name, err := utils.GetMachineNamespaceName(node)
+	if errors.Is(err, utils.MachineAnnotationNotFoundError) {
+		capiMachine := &unstructured.Unstructured{}
+		capiMachine.SetGroupVersionKind(utils.CAPIMachineGVK)
+		machine = capiMachine
+		ns, name, err = utils.GetCAPIMachineNamespaceName(node)
+	}
 	if err != nil {
 		if errors.Is(err, utils.MachineAnnotationNotFoundError) {
-			m.log.Info("didn't find machine annotation for Openshift machine", "node", node.GetName())
+			m.log.Info("didn't find machine annotation", "node", node.GetName())
 			// nothing we can do, continue without owning machine
 			return nil, "", nil
 		}
 		return nil, "", err
 	}
-	machine := &machinev1beta1.Machine{}
-	if err := m.Get(m.ctx, client.ObjectKey{Namespace: ns, Name: name}, machine); err != nil {
+	if err := m.getMachineClient().Get(m.ctx, client.ObjectKey{Namespace: ns, Name: name}, machine); err != nil {
 		return nil, "", errors.Wrapf(err, "failed to get machine. namespace %v, name: %v", ns, name)
 	}
 	return createOwnerRef(machine), ns, nil
 }
 
+// getMachineClient returns the client of the cluster of the Machines
+func (m *manager) getMachineClient() client.Client {
+	if m.managementCluster != nil {
+		return m.managementCluster.Client
+	}
+	return m.Client
+}
+
+// validateMetal3Namespace checks that the Metal3Remediation of the given node can be created in the given namespace.
+// Metal3 remediation needs the node's machine as owner ref, and owners need to be in the same namespace as their
+// dependent. That is the openshift-machine-api namespace, or the namespace of the node's machine in the management
+// cluster of hosted control planes.
+func (m *manager) validateMetal3Namespace(node *corev1.Node, namespace string) error {
+	if m.managementCluster == nil {
+		if namespace != machineAPINamespace {
+			return errors.Errorf("Metal3Remediation must be in the %s namespace, but it would be created in namespace %s for node %s",
+				machineAPINamespace, namespace, node.GetName())
+		}
+		return nil
+	}
+	if !m.managementCluster.HasRemediations {
+		return errors.Errorf("Metal3Remediation must be created in the management cluster next to the machine of node %s", node.GetName())
+	}
+	_, machineNamespace, err := m.getOwningMachineWithNamespace(node)
+	if err != nil {
+		return err
+	}
+	if namespace != machineNamespace {
+		return errors.Errorf("Metal3Remediation must be in the namespace %q of the machine of node %s, but it would be created in namespace %s",
+			machineNamespace, node.GetName(), namespace)
+	}
+	return nil
+}
+
 func (m *manager) getCRWithNodeNameAnnotation(remediationCR *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	nodeName := remediationCR.GetAnnotations()[commonannotations.NodeNameAnnotation]
 	templateName := remediationCR.GetAnnotations()[annotations.TemplateNameAnnotation]
@@ -390,7 +579,8 @@ func (m *manager) isMatchNodeTemplate(cr unstructured.Unstructured, nodeName str
 	return ann[annotations.TemplateNameAnnotation] == templateName && ann[commonannotations.NodeNameAnnotation] == nodeName
 }
 
-func (m *manager) extractNodeName(cr unstructured.Unstructured) string {
+// GetNodeName returns the name of the node the given remediation CR was created for
+func GetNodeName(cr unstructured.Unstructured) string {
 	if cr.GetAnnotations() == nil {
 		return cr.GetName()
 	}
@@ -401,6 +591,15 @@ func (m *manager) extractNodeName(cr unstructured.Unstructured) string {
 	return ann[commonannotations.NodeNameAnnotation]
 }
 
+// createOwnerLabels returns the labels which identify the given owner of remediation CRs in another cluster
+func createOwnerLabels(owner client.Object) map[string]string {
+	_, kind := owner.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	return map[string]string{
+		OwnerKindLabel: kind,
+		OwnerNameLabel: owner.GetName(),
+	}
+}
+
 func createOwnerRef(obj client.Object) *metav1.OwnerReference {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	apiVersion, kind := gvk.ToAPIVersionAndKind()
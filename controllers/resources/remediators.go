@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// GetUnavailableRemediators returns an explanation by remediation kind for the remediators referenced in the NHC's
+// RemediatorHealthCheck which aren't running. A remediator is running when its Deployment has ready replicas.
+// Deployments which don't exist or can't be read because of missing permissions are reported as unavailable, so that
+// a wrong reference doesn't fail reconciles. Other errors are returned. The given client is used for reading
+// Deployments.
+func GetUnavailableRemediators(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, log logr.Logger) (map[string]string, error) {
+	unavailable := make(map[string]string)
+	for _, check := range nhc.Spec.RemediatorHealthCheck {
+		ref := check.DeploymentRef
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, deployment); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+				log.Info("remediator Deployment can't be read", "NHC", nhc.GetName(), "kind", check.Kind, "namespace", ref.Namespace, "name", ref.Name, "error", err.Error())
+				unavailable[check.Kind] = fmt.Sprintf("Deployment %s/%s can't be read: %s", ref.Namespace, ref.Name, err.Error())
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get remediator Deployment %s/%s", ref.Namespace, ref.Name)
+		}
+		if deployment.Status.ReadyReplicas < 1 {
+			unavailable[check.Kind] = fmt.Sprintf("Deployment %s/%s has no ready replicas", ref.Namespace, ref.Name)
+		}
+	}
+	return unavailable, nil
+}
@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+func TestGetUnavailableRemediators(t *testing.T) {
+	const kind = "SelfNodeRemediation"
+
+	tests := []struct {
+		name            string
+		deploymentName  string
+		readyReplicas   int32
+		getErr          error
+		wantUnavailable map[string]string
+		wantErr         bool
+	}{
+		{
+			name:            "ready replicas",
+			deploymentName:  "snr",
+			readyReplicas:   1,
+			wantUnavailable: map[string]string{},
+		},
+		{
+			name:            "no ready replicas",
+			deploymentName:  "snr",
+			wantUnavailable: map[string]string{kind: "Deployment remediators/snr has no ready replicas"},
+		},
+		{
+			name:            "missing Deployment",
+			deploymentName:  "typo",
+			readyReplicas:   1,
+			wantUnavailable: map[string]string{kind: "Deployment remediators/typo can't be read"},
+		},
+		{
+			name:            "missing permissions",
+			deploymentName:  "snr",
+			readyReplicas:   1,
+			getErr:          apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "snr", nil),
+			wantUnavailable: map[string]string{kind: "Deployment remediators/snr can't be read"},
+		},
+		{
+			name:           "other error",
+			deploymentName: "snr",
+			readyReplicas:  1,
+			getErr:         apierrors.NewServiceUnavailable("try again"),
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "remediators", Name: "snr"},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: tt.readyReplicas},
+			}
+			c := fake.NewClientBuilder().WithObjects(deployment).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if tt.getErr != nil {
+						return tt.getErr
+					}
+					return client.Get(ctx, key, obj, opts...)
+				},
+			}).Build()
+			nhc := &remediationv1alpha1.NodeHealthCheck{
+				ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+				Spec: remediationv1alpha1.NodeHealthCheckSpec{
+					RemediatorHealthCheck: []remediationv1alpha1.RemediatorHealthCheck{{
+						Kind: kind,
+						DeploymentRef: remediationv1alpha1.DeploymentReference{
+							Namespace: "remediators",
+							Name:      tt.deploymentName,
+						},
+					}},
+				},
+			}
+
+			unavailable, err := GetUnavailableRemediators(context.Background(), c, nhc, zap.New())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetUnavailableRemediators() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(unavailable) != len(tt.wantUnavailable) {
+				t.Fatalf("GetUnavailableRemediators() = %v, want %v", unavailable, tt.wantUnavailable)
+			}
+			for k, want := range tt.wantUnavailable {
+				if got := unavailable[k]; !strings.HasPrefix(got, want) {
+					t.Errorf("GetUnavailableRemediators()[%s] = %q, want prefix %q", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// remediationRoutes are the routes for remediating nodes, in the order of their precedence. New ways of choosing the
+// remediation templates of a node belong here, instead of into the callers of ResolveRemediationRoute.
+var remediationRoutes = []struct {
+	routeType    remediationv1alpha1.RemediationRouteType
+	field        string
+	isConfigured func(nhc *remediationv1alpha1.NodeHealthCheck) bool
+}{
+	{
+		routeType: remediationv1alpha1.RemediationRouteEscalation,
+		field:     "escalatingRemediations",
+		isConfigured: func(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+			return len(nhc.Spec.EscalatingRemediations) > 0
+		},
+	},
+	{
+		routeType: remediationv1alpha1.RemediationRouteTemplate,
+		field:     "remediationTemplate",
+		isConfigured: func(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+			return nhc.Spec.RemediationTemplate != nil
+		},
+	},
+	{
+		routeType: remediationv1alpha1.RemediationRouteTemplates,
+		field:     "remediationTemplates",
+		isConfigured: func(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+			return len(nhc.Spec.RemediationTemplates) > 0
+		},
+	},
+	{
+		routeType: remediationv1alpha1.RemediationRouteInlineTemplate,
+		field:     "inlineRemediationTemplate",
+		isConfigured: func(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+			return nhc.Spec.InlineRemediationTemplate != nil
+		},
+	},
+}
+
+// ResolveRemediationRoute returns the route for remediating the nodes of the given NHC, which is the configured route
+// with the highest precedence: escalating remediations first, then the remediation template, the remediation
+// templates, and the inline remediation template last. The webhook rejects NHCs with several routes, but they can still exist, e.g. when they
+// were created before the webhook was running. The reason of the returned route names the routes it takes precedence
+// over. Returns nil when no route is configured.
+func ResolveRemediationRoute(nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.RemediationRoute {
+	var route *remediationv1alpha1.RemediationRoute
+	var field string
+	var overridden []string
+	for _, r := range remediationRoutes {
+		if !r.isConfigured(nhc) {
+			continue
+		}
+		if route != nil {
+			overridden = append(overridden, r.field)
+			continue
+		}
+		route = &remediationv1alpha1.RemediationRoute{Type: r.routeType}
+		field = r.field
+	}
+	if route == nil {
+		return nil
+	}
+	if len(overridden) == 0 {
+		route.Reason = fmt.Sprintf("%s is the only configured route", field)
+	} else {
+		route.Reason = fmt.Sprintf("%s takes precedence over %s", field, strings.Join(overridden, ", "))
+	}
+	return route
+}
@@ -1,18 +1,29 @@
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
 	"github.com/medik8s/node-healthcheck-operator/metrics"
 )
 
-func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured) {
+func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured, timeout *time.Duration) {
 	if _, exists := nhc.Status.InFlightRemediations[remediationCR.GetName()]; !exists {
 		if nhc.Status.InFlightRemediations == nil {
 			nhc.Status.InFlightRemediations = make(map[string]metav1.Time, 1)
@@ -22,6 +33,8 @@ func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.
 		}
 	}
 
+	AddStatusCreatedRemediationKind(nhc, remediationCR.GroupVersionKind())
+
 	var templateName string
 	if remediationCR.GetAnnotations() != nil {
 		templateName = remediationCR.GetAnnotations()[annotations.TemplateNameAnnotation]
@@ -36,6 +49,12 @@ func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.
 		},
 		Started:      remediationCR.GetCreationTimestamp(),
 		TemplateName: templateName,
+		Phase:        remediationv1alpha1.RemediationPhaseRunning,
+		Attempt:      annotations.GetRemediationAttempt(remediationCR),
+	}
+	if timeout != nil {
+		remediation.Timeout = &metav1.Duration{Duration: *timeout}
+		remediation.TimeoutAt = &metav1.Time{Time: remediation.Started.Add(*timeout)}
 	}
 
 	foundNode := false
@@ -44,13 +63,31 @@ func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.
 			foundNode = true
 			foundRem := false
 			for _, rem := range unhealthyNode.Remediations {
-				if rem.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() {
+				if rem.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() && rem.Resource.Name == remediationCR.GetName() {
 					foundRem = true
+					if rem.DeletedExternally != nil {
+						// the CR was recreated with the same name
+						rem.Resource.UID = remediation.Resource.UID
+					}
+					if rem.Timeout == nil {
+						rem.Timeout = remediation.Timeout
+					}
+					if rem.TimeoutAt == nil {
+						rem.TimeoutAt = remediation.TimeoutAt
+					}
+					if rem.Attempt == 0 {
+						rem.Attempt = remediation.Attempt
+					}
 					break
 				}
 			}
 			if !foundRem {
-				unhealthyNode.Remediations = append(unhealthyNode.Remediations, &remediation)
+				if recreated := findRecreatedRemediation(unhealthyNode, &remediation); recreated != nil {
+					// the CR was recreated with another name, it continues the same remediation
+					recreated.Resource = remediation.Resource
+				} else {
+					unhealthyNode.Remediations = append(unhealthyNode.Remediations, &remediation)
+				}
 			}
 			break
 		}
@@ -67,12 +104,28 @@ func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.
 
 }
 
+// findRecreatedRemediation returns the ongoing remediation of the given node, whose remediation CR was deleted
+// externally and is recreated as the given remediation, see ExternalCRDeletionPolicy. Returns nil if there is none.
+func findRecreatedRemediation(unhealthyNode *remediationv1alpha1.UnhealthyNode, remediation *remediationv1alpha1.Remediation) *remediationv1alpha1.Remediation {
+	for _, rem := range unhealthyNode.Remediations {
+		if rem.DeletedExternally != nil && IsStatusRemediationOngoing(rem) &&
+			rem.Resource.GroupVersionKind() == remediation.Resource.GroupVersionKind() && rem.TemplateName == remediation.TemplateName {
+			return rem
+		}
+	}
+	return nil
+}
+
 func UpdateStatusNodeHealthy(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) {
 	delete(nhc.Status.InFlightRemediations, nodeName)
 	for i, _ := range nhc.Status.UnhealthyNodes {
 		if nhc.Status.UnhealthyNodes[i].Name == nodeName {
 			for _, remediation := range nhc.Status.UnhealthyNodes[i].Remediations {
 				remediation := remediation
+				if remediation.Phase == remediationv1alpha1.RemediationPhaseNotApplicable {
+					// no remediation CR was created
+					continue
+				}
 				remediationResource := remediation.Resource
 				duration := time.Now().Sub(remediation.Started.Time)
 				metrics.ObserveNodeHealthCheckRemediationDeleted(remediationResource.Name, remediationResource.Namespace, remediationResource.Kind)
@@ -84,17 +137,35 @@ func UpdateStatusNodeHealthy(nodeName string, nhc *remediationv1alpha1.NodeHealt
 	}
 }
 
-func UpdateStatusNodeUnhealthy(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck) {
+// UpdateStatusNodeUnhealthy tracks the given node as unhealthy, which is eligible for remediation since the given time.
+// The eligibility time of already tracked nodes is kept.
+func UpdateStatusNodeUnhealthy(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
 	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
 		if unhealthyNode.Name == node.Name {
+			if unhealthyNode.EligibleSince == nil {
+				// tracked before eligibility times were recorded
+				unhealthyNode.EligibleSince = &metav1.Time{Time: now}
+			}
 			return
 		}
 	}
 	nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes, &remediationv1alpha1.UnhealthyNode{
-		Name: node.GetName(),
+		Name:          node.GetName(),
+		EligibleSince: &metav1.Time{Time: now},
 	})
 }
 
+// GetStatusEligibleSince returns the time since when the given node is eligible for remediation, or the given time if
+// the node isn't tracked as unhealthy yet
+func GetStatusEligibleSince(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) time.Time {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName && unhealthyNode.EligibleSince != nil {
+			return unhealthyNode.EligibleSince.Time
+		}
+	}
+	return now
+}
+
 func UpdateStatusNodeConditionsHealthy(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) *time.Time {
 	for i, _ := range nhc.Status.UnhealthyNodes {
 		if nhc.Status.UnhealthyNodes[i].Name == nodeName {
@@ -107,16 +178,1484 @@ func UpdateStatusNodeConditionsHealthy(nodeName string, nhc *remediationv1alpha1
 	return nil
 }
 
-// FindStatusRemediation return the first remediation in the NHC's status for the given node which matches the remediationFilter
-func FindStatusRemediation(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationFilter func(r *remediationv1alpha1.Remediation) bool) *remediationv1alpha1.Remediation {
+// UpdateStatusUnreachableSince sets the UnreachableSince field of all unhealthy nodes, based on the given node
+// evaluations, and clears it for nodes which aren't reported as unreachable. It returns the names of the nodes which
+// weren't unreachable before.
+func UpdateStatusUnreachableSince(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) []string {
+	var added []string
 	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
-		if unhealthyNode.Name == node.GetName() {
-			for _, rem := range unhealthyNode.Remediations {
-				if remediationFilter(rem) {
-					return rem
-				}
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.UnreachableSince != nil {
+			if unhealthyNode.UnreachableSince == nil {
+				added = append(added, unhealthyNode.Name)
+			}
+			unhealthyNode.UnreachableSince = evaluation.UnreachableSince.DeepCopy()
+		} else {
+			unhealthyNode.UnreachableSince = nil
+		}
+	}
+	return added
+}
+
+// UpdateStatusMatchedCondition sets the MatchedCondition field of all unhealthy nodes, based on the given node
+// evaluations, and clears it for nodes which don't match an unhealthy condition.
+func UpdateStatusMatchedCondition(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.Condition != nil {
+			unhealthyNode.MatchedCondition = evaluation.Condition.DeepCopy()
+		} else {
+			unhealthyNode.MatchedCondition = nil
+		}
+	}
+}
+
+// UpdateStatusHeartbeatStaleSince sets the HeartbeatStaleSince field of all unhealthy nodes, based on the given node
+// evaluations, and clears it for nodes without stale heartbeat. It returns the names of the nodes whose heartbeat
+// wasn't stale before.
+func UpdateStatusHeartbeatStaleSince(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) []string {
+	var added []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.HeartbeatStaleSince != nil {
+			if unhealthyNode.HeartbeatStaleSince == nil {
+				added = append(added, unhealthyNode.Name)
+			}
+			unhealthyNode.HeartbeatStaleSince = evaluation.HeartbeatStaleSince.DeepCopy()
+		} else {
+			unhealthyNode.HeartbeatStaleSince = nil
+		}
+	}
+	return added
+}
+
+// UpdateStatusMatchedTaint sets the MatchedTaint field of all unhealthy nodes, based on the given node evaluations,
+// and clears it for nodes without unhealthy taint. It returns the names of the nodes which didn't match the taint
+// before.
+func UpdateStatusMatchedTaint(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) []string {
+	var added []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.Taint != nil {
+			matchedTaint := evaluation.Taint
+			if unhealthyNode.MatchedTaint == nil || unhealthyNode.MatchedTaint.Key != matchedTaint.Key || unhealthyNode.MatchedTaint.Effect != matchedTaint.Effect {
+				added = append(added, unhealthyNode.Name)
+			}
+			unhealthyNode.MatchedTaint = matchedTaint.DeepCopy()
+		} else {
+			unhealthyNode.MatchedTaint = nil
+		}
+	}
+	return added
+}
+
+// UpdateStatusMatchedAnnotation sets the MatchedAnnotation field of all unhealthy nodes, based on the given node
+// evaluations, and clears it for nodes without unhealthy annotation. It returns the names of the nodes which didn't
+// match the annotation before.
+func UpdateStatusMatchedAnnotation(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) []string {
+	var added []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.Annotation != nil {
+			matchedAnnotation := evaluation.Annotation
+			if unhealthyNode.MatchedAnnotation == nil || unhealthyNode.MatchedAnnotation.Key != matchedAnnotation.Key || unhealthyNode.MatchedAnnotation.Value != matchedAnnotation.Value {
+				added = append(added, unhealthyNode.Name)
+			}
+			unhealthyNode.MatchedAnnotation = matchedAnnotation.DeepCopy()
+		} else {
+			unhealthyNode.MatchedAnnotation = nil
+		}
+	}
+	return added
+}
+
+// UpdateStatusMatchedCapacity sets the MatchedCapacity field of all unhealthy nodes, based on the given node
+// evaluations, and clears it for nodes with sufficient capacity. It returns the names of the nodes which didn't have
+// insufficient capacity of the matched resource before.
+func UpdateStatusMatchedCapacity(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) []string {
+	var added []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.Capacity != nil {
+			matchedCapacity := evaluation.Capacity
+			if unhealthyNode.MatchedCapacity == nil || unhealthyNode.MatchedCapacity.ResourceName != matchedCapacity.ResourceName {
+				added = append(added, unhealthyNode.Name)
+			}
+			unhealthyNode.MatchedCapacity = matchedCapacity.DeepCopy()
+		} else {
+			unhealthyNode.MatchedCapacity = nil
+		}
+	}
+	return added
+}
+
+// UpdateStatusInsufficientCapacityNodes replaces the tracked nodes with insufficient capacity with the given nodes,
+// keeping the Since timestamp of already tracked node resources. It returns the updated list.
+func UpdateStatusInsufficientCapacityNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []remediationv1alpha1.InsufficientCapacityNode) []remediationv1alpha1.InsufficientCapacityNode {
+	for i := range nodes {
+		if since := GetStatusInsufficientCapacitySince(nodes[i].Name, nodes[i].ResourceName, nhc); since != nil {
+			nodes[i].Since = *since
+		}
+	}
+	nhc.Status.InsufficientCapacityNodes = nodes
+	return nodes
+}
+
+// GetStatusInsufficientCapacitySince returns since when the given node has insufficient capacity of the given
+// resource, or nil if it isn't tracked.
+func GetStatusInsufficientCapacitySince(nodeName string, resourceName corev1.ResourceName, nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	for _, node := range nhc.Status.InsufficientCapacityNodes {
+		if node.Name == nodeName && node.ResourceName == resourceName {
+			return node.Since.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// UpdateStatusTaintedNodes replaces the tracked nodes with unhealthy taints with the given nodes, keeping the Since
+// timestamp of already tracked node taints. It returns the updated list.
+func UpdateStatusTaintedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []remediationv1alpha1.TaintedNode) []remediationv1alpha1.TaintedNode {
+	for i := range nodes {
+		if since := GetStatusTaintedSince(nodes[i].Name, nodes[i].Key, nodes[i].Effect, nhc); since != nil {
+			nodes[i].Since = *since
+		}
+	}
+	nhc.Status.TaintedNodes = nodes
+	return nodes
+}
+
+// GetStatusTaintedSince returns since when the given node has the taint with the given key and effect, or nil if it
+// isn't tracked.
+func GetStatusTaintedSince(nodeName, key string, effect corev1.TaintEffect, nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	for _, node := range nhc.Status.TaintedNodes {
+		if node.Name == nodeName && node.Key == key && node.Effect == effect {
+			return node.Since.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// UpdateStatusAnnotatedNodes replaces the tracked nodes with unhealthy annotations with the given nodes, keeping the
+// Since timestamp of already tracked node annotations. It returns the updated list.
+func UpdateStatusAnnotatedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []remediationv1alpha1.AnnotatedNode) []remediationv1alpha1.AnnotatedNode {
+	for i := range nodes {
+		if since := GetStatusAnnotatedSince(nodes[i].Name, nodes[i].Key, nhc); since != nil {
+			nodes[i].Since = *since
+		}
+	}
+	nhc.Status.AnnotatedNodes = nodes
+	return nodes
+}
+
+// GetStatusAnnotatedSince returns since when the given node has the annotation with the given key, or nil if it isn't
+// tracked.
+func GetStatusAnnotatedSince(nodeName, key string, nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	for _, node := range nhc.Status.AnnotatedNodes {
+		if node.Name == nodeName && node.Key == key {
+			return node.Since.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// UpdateStatusMatchedUtilization sets the MatchedUtilization field of all unhealthy nodes, based on the given node
+// evaluations, and clears it for nodes without high utilization. It returns the names of the nodes which didn't have
+// high utilization of the matched resource before.
+func UpdateStatusMatchedUtilization(nhc *remediationv1alpha1.NodeHealthCheck, evaluations map[string]*NodeEvaluation) []string {
+	var added []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if evaluation := evaluations[unhealthyNode.Name]; evaluation != nil && evaluation.Utilization != nil {
+			matchedUtilization := evaluation.Utilization
+			if unhealthyNode.MatchedUtilization == nil || unhealthyNode.MatchedUtilization.ResourceName != matchedUtilization.ResourceName {
+				added = append(added, unhealthyNode.Name)
+			}
+			unhealthyNode.MatchedUtilization = matchedUtilization.DeepCopy()
+		} else {
+			unhealthyNode.MatchedUtilization = nil
+		}
+	}
+	return added
+}
+
+// UpdateStatusHighUtilizationNodes replaces the tracked nodes with high utilization with the given nodes, keeping the
+// Since timestamp of already tracked node resources. It returns the updated list.
+func UpdateStatusHighUtilizationNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []remediationv1alpha1.HighUtilizationNode) []remediationv1alpha1.HighUtilizationNode {
+	for i := range nodes {
+		if since := GetStatusHighUtilizationSince(nodes[i].Name, nodes[i].ResourceName, nhc); since != nil {
+			nodes[i].Since = *since
+		}
+	}
+	nhc.Status.HighUtilizationNodes = nodes
+	return nodes
+}
+
+// GetStatusHighUtilizationSince returns since when the given node has a utilization of the given resource above the
+// threshold, or nil if it isn't tracked.
+func GetStatusHighUtilizationSince(nodeName string, resourceName corev1.ResourceName, nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	for _, node := range nhc.Status.HighUtilizationNodes {
+		if node.Name == nodeName && node.ResourceName == resourceName {
+			return node.Since.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// UpdateStatusReportOnlyUnhealthyNodes replaces the tracked report only unhealthy nodes with the given nodes and their
+// matching report only conditions, keeping the Since timestamp of already tracked nodes. It returns the names of
+// newly tracked nodes.
+func UpdateStatusReportOnlyUnhealthyNodes(nhc *remediationv1alpha1.NodeHealthCheck, matches map[string][]remediationv1alpha1.MatchedCondition, now time.Time) []string {
+	var added []string
+	var nodes []remediationv1alpha1.ReportOnlyUnhealthyNode
+	for nodeName, conditions := range matches {
+		since := metav1.NewTime(now)
+		if existing := getStatusReportOnlyUnhealthyNode(nodeName, nhc); existing != nil {
+			since = existing.Since
+		} else {
+			added = append(added, nodeName)
+		}
+		nodes = append(nodes, remediationv1alpha1.ReportOnlyUnhealthyNode{
+			Name:       nodeName,
+			Conditions: conditions,
+			Since:      since,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	sort.Strings(added)
+	nhc.Status.ReportOnlyUnhealthyNodes = nodes
+	return added
+}
+
+// UpdateStatusUnhealthySignalVotes replaces the tracked unhealthy signal votes with the given nodes and the signal
+// categories which vote them as unhealthy.
+func UpdateStatusUnhealthySignalVotes(nhc *remediationv1alpha1.NodeHealthCheck, votes map[string][]remediationv1alpha1.UnhealthySignal) {
+	var nodes []remediationv1alpha1.UnhealthySignalVote
+	for nodeName, signals := range votes {
+		nodes = append(nodes, remediationv1alpha1.UnhealthySignalVote{
+			Name:    nodeName,
+			Signals: signals,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	nhc.Status.UnhealthySignalVotes = nodes
+}
+
+func getStatusReportOnlyUnhealthyNode(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.ReportOnlyUnhealthyNode {
+	for i := range nhc.Status.ReportOnlyUnhealthyNodes {
+		if nhc.Status.ReportOnlyUnhealthyNodes[i].Name == nodeName {
+			return &nhc.Status.ReportOnlyUnhealthyNodes[i]
+		}
+	}
+	return nil
+}
+
+// UpdateStatusIgnoredUnhealthyNodes replaces the tracked ignored unhealthy nodes with the given reason with the given
+// nodes. It returns the names of newly tracked nodes.
+func UpdateStatusIgnoredUnhealthyNodes(nhc *remediationv1alpha1.NodeHealthCheck, reason string, nodes []remediationv1alpha1.IgnoredUnhealthyNode) []string {
+	var added []string
+	for _, node := range nodes {
+		if existing := getStatusIgnoredUnhealthyNode(node.Name, nhc); existing == nil || existing.Reason != reason {
+			added = append(added, node.Name)
+		}
+	}
+	for _, existing := range nhc.Status.IgnoredUnhealthyNodes {
+		if existing.Reason != reason {
+			nodes = append(nodes, existing)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	sort.Strings(added)
+	nhc.Status.IgnoredUnhealthyNodes = nodes
+	return added
+}
+
+func getStatusIgnoredUnhealthyNode(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.IgnoredUnhealthyNode {
+	for i := range nhc.Status.IgnoredUnhealthyNodes {
+		if nhc.Status.IgnoredUnhealthyNodes[i].Name == nodeName {
+			return &nhc.Status.IgnoredUnhealthyNodes[i]
+		}
+	}
+	return nil
+}
+
+// UpdateStatusOrphanedRemediation tracks the given remediation CR, which couldn't be deleted, with the given reason.
+// The Since timestamp of an already tracked CR is kept.
+func UpdateStatusOrphanedRemediation(nhc *remediationv1alpha1.NodeHealthCheck, resource corev1.ObjectReference, nodeName string, reason string, now time.Time) {
+	for i := range nhc.Status.OrphanedRemediations {
+		if isSameResource(nhc.Status.OrphanedRemediations[i].Resource, resource) {
+			nhc.Status.OrphanedRemediations[i].Reason = reason
+			return
+		}
+	}
+	nhc.Status.OrphanedRemediations = append(nhc.Status.OrphanedRemediations, remediationv1alpha1.OrphanedRemediation{
+		Resource: resource,
+		NodeName: nodeName,
+		Reason:   reason,
+		Since:    metav1.NewTime(now),
+	})
+}
+
+// RemoveStatusOrphanedRemediation removes the given remediation CR from the orphaned remediations
+func RemoveStatusOrphanedRemediation(nhc *remediationv1alpha1.NodeHealthCheck, resource corev1.ObjectReference) {
+	var remaining []remediationv1alpha1.OrphanedRemediation
+	for _, orphaned := range nhc.Status.OrphanedRemediations {
+		if !isSameResource(orphaned.Resource, resource) {
+			remaining = append(remaining, orphaned)
+		}
+	}
+	nhc.Status.OrphanedRemediations = remaining
+}
+
+func isSameResource(a, b corev1.ObjectReference) bool {
+	return a.APIVersion == b.APIVersion && a.Kind == b.Kind && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// UpdateStatusUnhealthyDurationBuckets counts the given nodes by how long they match an unhealthy condition of the
+// NHC already. Nodes which don't match any unhealthy condition are ignored.
+func UpdateStatusUnhealthyDurationBuckets(nhc *remediationv1alpha1.NodeHealthCheck, nodes []corev1.Node, now time.Time) {
+	var buckets *remediationv1alpha1.UnhealthyDurationBuckets
+	for i := range nodes {
+		since := getUnhealthySince(nhc, &nodes[i])
+		if since == nil {
+			continue
+		}
+		if buckets == nil {
+			buckets = &remediationv1alpha1.UnhealthyDurationBuckets{}
+		}
+		switch unhealthyFor := now.Sub(since.Time); {
+		case unhealthyFor < time.Minute:
+			buckets.LessThan1m++
+		case unhealthyFor < 5*time.Minute:
+			buckets.From1mTo5m++
+		default:
+			buckets.AtLeast5m++
+		}
+	}
+	nhc.Status.UnhealthyDurationBuckets = buckets
+}
+
+// getUnhealthySince returns the earliest transition time of the node's conditions matching an unhealthy condition
+func getUnhealthySince(nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) *metav1.Time {
+	var since *metav1.Time
+	for _, unhealthyCondition := range nhc.Spec.UnhealthyConditions {
+		for i, nodeCondition := range node.Status.Conditions {
+			if nodeCondition.Type != unhealthyCondition.Type || nodeCondition.Status != unhealthyCondition.Status {
+				continue
+			}
+			if since == nil || nodeCondition.LastTransitionTime.Before(since) {
+				since = &node.Status.Conditions[i].LastTransitionTime
+			}
+		}
+	}
+	return since
+}
+
+// GetUnmigratedInFlightRemediations returns the names of nodes which are only tracked in the deprecated
+// InFlightRemediations status field, as written by older versions of the operator.
+func GetUnmigratedInFlightRemediations(nhc *remediationv1alpha1.NodeHealthCheck) []string {
+	var nodeNames []string
+	for nodeName := range nhc.Status.InFlightRemediations {
+		if !HasStatusRemediations(nodeName, nhc) {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+	sort.Strings(nodeNames)
+	return nodeNames
+}
+
+// MigrateStatusInFlightRemediation reconstructs the UnhealthyNodes entry of a node, which is only tracked in the
+// deprecated InFlightRemediations status field, from the node's remediation CRs.
+func MigrateStatusInFlightRemediation(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, remediationCRs []unstructured.Unstructured) {
+	sort.Slice(remediationCRs, func(i, j int) bool {
+		return remediationCRs[i].GetCreationTimestamp().Time.Before(remediationCRs[j].GetCreationTimestamp().Time)
+	})
+	remediations := make([]*remediationv1alpha1.Remediation, 0, len(remediationCRs))
+	for _, cr := range remediationCRs {
+		remediation := &remediationv1alpha1.Remediation{
+			Resource: corev1.ObjectReference{
+				Kind:       cr.GetKind(),
+				Namespace:  cr.GetNamespace(),
+				Name:       cr.GetName(),
+				UID:        cr.GetUID(),
+				APIVersion: cr.GetAPIVersion(),
+			},
+			Started:      cr.GetCreationTimestamp(),
+			TemplateName: cr.GetAnnotations()[annotations.TemplateNameAnnotation],
+			Phase:        remediationv1alpha1.RemediationPhaseRunning,
+		}
+		if timedOut, exists := cr.GetAnnotations()[remediationv1alpha1.NhcTimedOutAnnotation]; exists {
+			timedOutTime, _, err := remediationv1alpha1.ParseNhcTimedOutAnnotation(timedOut)
+			if err != nil {
+				// the annotation is there, so the remediation timed out for sure
+				timedOutTime = remediation.Started.Time
+			}
+			remediation.TimedOut = &metav1.Time{Time: timedOutTime}
+			remediation.Phase = remediationv1alpha1.RemediationPhaseTimedOut
+		}
+		remediations = append(remediations, remediation)
+	}
+
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.Remediations = remediations
+			return
+		}
+	}
+	nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes, &remediationv1alpha1.UnhealthyNode{
+		Name:         nodeName,
+		Remediations: remediations,
+	})
+}
+
+// HasStatusRemediations returns true if the given node has remediations in the NHC's status
+func HasStatusRemediations(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) bool {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return len(unhealthyNode.Remediations) > 0
+		}
+	}
+	return false
+}
+
+// UpdateStatusDeferral sets the deferral of the given unhealthy node. An empty reason removes the deferral.
+// The time since when remediation is deferred is kept, as long as the reason doesn't change.
+func UpdateStatusDeferral(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, reason, message string, now time.Time) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != nodeName {
+			continue
+		}
+		if reason == "" {
+			unhealthyNode.Deferral = nil
+			return
+		}
+		if unhealthyNode.Deferral == nil || unhealthyNode.Deferral.Reason != reason {
+			unhealthyNode.Deferral = &remediationv1alpha1.RemediationDeferral{
+				Reason: reason,
+				Since:  metav1.Time{Time: now},
+			}
+		}
+		unhealthyNode.Deferral.Message = message
+		return
+	}
+}
+
+// GetStatusDrain returns the drain of the given node, or nil if the node isn't drained
+func GetStatusDrain(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.Drain {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return unhealthyNode.Drain
+		}
+	}
+	return nil
+}
+
+// UpdateStatusDrain sets the drain of the given node
+func UpdateStatusDrain(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, drain *remediationv1alpha1.Drain) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.Drain = drain
+			return
+		}
+	}
+}
+
+// UpdateStatusControllingNHC sets whether the given NHC owns the remediation CR of the given node
+func UpdateStatusControllingNHC(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, isControlling bool) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.IsControllingNHC = pointer.Bool(isControlling)
+			return
+		}
+	}
+}
+
+// AddStatusCreatedRemediationKind adds the given kind to the created remediation kinds, if it isn't tracked yet
+func AddStatusCreatedRemediationKind(nhc *remediationv1alpha1.NodeHealthCheck, gvk schema.GroupVersionKind) {
+	kind := metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	for _, existing := range nhc.Status.CreatedRemediationKinds {
+		if existing == kind {
+			return
+		}
+	}
+	nhc.Status.CreatedRemediationKinds = append(nhc.Status.CreatedRemediationKinds, kind)
+}
+
+// RemoveStatusCreatedRemediationKind removes the given kind from the created remediation kinds
+func RemoveStatusCreatedRemediationKind(nhc *remediationv1alpha1.NodeHealthCheck, gvk schema.GroupVersionKind) {
+	kind := metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	kinds := make([]metav1.GroupVersionKind, 0, len(nhc.Status.CreatedRemediationKinds))
+	for _, existing := range nhc.Status.CreatedRemediationKinds {
+		if existing != kind {
+			kinds = append(kinds, existing)
+		}
+	}
+	if len(kinds) == 0 {
+		kinds = nil
+	}
+	nhc.Status.CreatedRemediationKinds = kinds
+}
+
+// UpdateStatusRemediationsAborted sets the phase of all remediations of the given kind to Aborted
+func UpdateStatusRemediationsAborted(nhc *remediationv1alpha1.NodeHealthCheck, gvk schema.GroupVersionKind) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		for _, rem := range unhealthyNode.Remediations {
+			if rem.Resource.GroupVersionKind() == gvk && rem.Phase != remediationv1alpha1.RemediationPhaseNotApplicable {
+				rem.Phase = remediationv1alpha1.RemediationPhaseAborted
+			}
+		}
+	}
+}
+
+// RemoveStatusRemediationsAbortedAll removes the remediations which were aborted with the abort-all annotation, so
+// that remediation of still unhealthy nodes starts over. Unhealthy nodes without remaining remediations are removed.
+func RemoveStatusRemediationsAbortedAll(nhc *remediationv1alpha1.NodeHealthCheck) {
+	var unhealthyNodes []*remediationv1alpha1.UnhealthyNode
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		var remediations []*remediationv1alpha1.Remediation
+		abortedAll := false
+		for _, rem := range unhealthyNode.Remediations {
+			if rem.Phase == remediationv1alpha1.RemediationPhaseAborted && rem.Reason == remediationv1alpha1.RemediationReasonAbortAll {
+				abortedAll = true
+				continue
 			}
+			remediations = append(remediations, rem)
+		}
+		if abortedAll && len(remediations) == 0 {
+			continue
+		}
+		unhealthyNode.Remediations = remediations
+		unhealthyNodes = append(unhealthyNodes, unhealthyNode)
+	}
+	nhc.Status.UnhealthyNodes = unhealthyNodes
+}
+
+// UpdateStatusRemediationNotApplicable records the given escalating remediation as not applicable for the given
+// node, with the given reason, and the required labels the node is missing, if any. The namespace is the one the
+// remediation CR would have been created in.
+func UpdateStatusRemediationNotApplicable(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, escRem remediationv1alpha1.EscalatingRemediation, namespace string, reason string, missingLabels []string, now time.Time) {
+	remediation := &remediationv1alpha1.Remediation{
+		Resource: corev1.ObjectReference{
+			Kind:       strings.TrimSuffix(escRem.RemediationTemplate.Kind, templateSuffix),
+			Namespace:  namespace,
+			APIVersion: escRem.RemediationTemplate.APIVersion,
+		},
+		Started:           metav1.Time{Time: now},
+		Phase:             remediationv1alpha1.RemediationPhaseNotApplicable,
+		TemplateName:      escRem.RemediationTemplate.Name,
+		MissingNodeLabels: missingLabels,
+		Reason:            reason,
+	}
+	UpdateStatusNodeUnhealthy(node, nhc, now)
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == node.GetName() {
+			unhealthyNode.Remediations = append(unhealthyNode.Remediations, remediation)
+			return
+		}
+	}
+}
+
+// IsStatusRemediationOngoing returns true if the given remediation neither timed out, nor was aborted, skipped or
+// completed
+func IsStatusRemediationOngoing(remediation *remediationv1alpha1.Remediation) bool {
+	return remediation.TimedOut == nil &&
+		remediation.Phase != remediationv1alpha1.RemediationPhaseAborted &&
+		remediation.Phase != remediationv1alpha1.RemediationPhaseNotApplicable &&
+		remediation.Phase != remediationv1alpha1.RemediationPhaseSucceeded
+}
+
+// GetStatusDeferral returns the deferral of the given unhealthy node, or nil if there is none
+func GetStatusDeferral(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.RemediationDeferral {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return unhealthyNode.Deferral
+		}
+	}
+	return nil
+}
+
+// GetStatusPDBViolationSince returns since when the remediation of the given node is deferred because of
+// PodDisruptionBudget violations, or nil if it isn't
+func GetStatusPDBViolationSince(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return unhealthyNode.PDBViolationSince
+		}
+	}
+	return nil
+}
+
+// UpdateStatusPDBViolationSince sets since when the remediation of the given node is deferred because of
+// PodDisruptionBudget violations, nil removes it
+func UpdateStatusPDBViolationSince(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, since *metav1.Time) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.PDBViolationSince = since
+			return
+		}
+	}
+}
+
+// GetStatusVerification returns the post remediation verification of the given unhealthy node, or nil if there is none
+func GetStatusVerification(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.Verification {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return unhealthyNode.Verification
 		}
 	}
 	return nil
 }
+
+// UpdateStatusVerification sets the post remediation verification of the given unhealthy node
+func UpdateStatusVerification(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, verification *remediationv1alpha1.Verification) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.Verification = verification
+			return
+		}
+	}
+}
+
+// GetStatusFirstRemediationStarted returns the start time of the first remediation of the given unhealthy node,
+// or nil if there is none
+func GetStatusFirstRemediationStarted(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	var first *metav1.Time
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != nodeName {
+			continue
+		}
+		for _, rem := range unhealthyNode.Remediations {
+			if rem.Phase == remediationv1alpha1.RemediationPhaseNotApplicable {
+				continue
+			}
+			if first == nil || rem.Started.Time.Before(first.Time) {
+				first = &rem.Started
+			}
+		}
+	}
+	return first
+}
+
+// IsStatusRemediationExhausted returns true if remediation of the given unhealthy node was given up
+func IsStatusRemediationExhausted(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) bool {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return unhealthyNode.RemediationExhausted != nil
+		}
+	}
+	return false
+}
+
+// UpdateStatusRemediationExhausted marks remediation of the given unhealthy node as given up
+func UpdateStatusRemediationExhausted(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.RemediationExhausted = &metav1.Time{Time: now}
+			return
+		}
+	}
+}
+
+// GetStatusExhaustedNodes returns the sorted names of unhealthy nodes whose remediation was given up
+func GetStatusExhaustedNodes(nhc *remediationv1alpha1.NodeHealthCheck) []string {
+	var nodeNames []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.RemediationExhausted != nil {
+			nodeNames = append(nodeNames, unhealthyNode.Name)
+		}
+	}
+	sort.Strings(nodeNames)
+	return nodeNames
+}
+
+// ResetStatusNode forgets all remediations of the given node, including remembered ones, so that its remediation
+// starts over
+func ResetStatusNode(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) {
+	delete(nhc.Status.InFlightRemediations, nodeName)
+	for i, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes[:i], nhc.Status.UnhealthyNodes[i+1:]...)
+			break
+		}
+	}
+	for i, recent := range nhc.Status.RecentRemediations {
+		if recent.Name == nodeName {
+			nhc.Status.RecentRemediations = append(nhc.Status.RecentRemediations[:i], nhc.Status.RecentRemediations[i+1:]...)
+			break
+		}
+	}
+}
+
+// FindStatusRemediation return the first remediation in the NHC's status for the given node which matches the remediationFilter
+func FindStatusRemediation(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationFilter func(r *remediationv1alpha1.Remediation) bool) *remediationv1alpha1.Remediation {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == node.GetName() {
+			for _, rem := range unhealthyNode.Remediations {
+				if remediationFilter(rem) {
+					return rem
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FindStatusRemediations returns all remediations of the given node which match the given filter, e.g. the ongoing
+// remediations of all RemediationTemplates
+func FindStatusRemediations(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationFilter func(r *remediationv1alpha1.Remediation) bool) []*remediationv1alpha1.Remediation {
+	var remediations []*remediationv1alpha1.Remediation
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == node.GetName() {
+			for _, rem := range unhealthyNode.Remediations {
+				if remediationFilter(rem) {
+					remediations = append(remediations, rem)
+				}
+			}
+		}
+	}
+	return remediations
+}
+
+// GetStatusLastRemediation returns the latest remediation of the given node which created a remediation CR, or nil
+func GetStatusLastRemediation(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.Remediation {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != nodeName {
+			continue
+		}
+		for i := len(unhealthyNode.Remediations) - 1; i >= 0; i-- {
+			if unhealthyNode.Remediations[i].Phase != remediationv1alpha1.RemediationPhaseNotApplicable {
+				return unhealthyNode.Remediations[i]
+			}
+		}
+	}
+	return nil
+}
+
+// PreviousAttemptOutcomeSucceeded is the outcome of a previous remediation attempt which made the node healthy
+const PreviousAttemptOutcomeSucceeded = "Succeeded"
+
+// GetNextRemediationAttempt returns the number of the next remediation attempt for the given node, and the outcome
+// of the previous attempt, if known. Attempts of earlier unhealthy periods of the node are only taken into account
+// when they are remembered by the escalation memory.
+func GetNextRemediationAttempt(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) (int, string) {
+	attempt := 0
+	outcome := ""
+	if nhc.Spec.EscalationMemory != nil {
+		for _, recent := range nhc.Status.RecentRemediations {
+			if recent.Name == nodeName && recent.Attempts > 0 && now.Before(recent.Finished.Add(nhc.Spec.EscalationMemory.Window.Duration)) {
+				attempt = recent.Attempts
+				outcome = PreviousAttemptOutcomeSucceeded
+				break
+			}
+		}
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != nodeName {
+			continue
+		}
+		for _, rem := range unhealthyNode.Remediations {
+			if rem.Phase == remediationv1alpha1.RemediationPhaseNotApplicable {
+				continue
+			}
+			if rem.Attempt > attempt {
+				attempt = rem.Attempt
+			} else {
+				attempt++
+			}
+			outcome = ""
+			if rem.Phase == remediationv1alpha1.RemediationPhaseTimedOut || rem.Phase == remediationv1alpha1.RemediationPhaseAborted {
+				outcome = string(rem.Phase)
+			}
+		}
+	}
+	return attempt + 1, outcome
+}
+
+// UpdateStatusRecentRemediation remembers the last used escalating remediation of the given node, in case the
+// escalation memory is configured. Needs to be called before the node is removed from the unhealthy nodes.
+func UpdateStatusRecentRemediation(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if nhc.Spec.EscalationMemory == nil {
+		return
+	}
+	var lastUsed *remediationv1alpha1.EscalatingRemediation
+	var escalationStart *remediationv1alpha1.EscalationStart
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name != nodeName {
+			continue
+		}
+		escalationStart = unhealthyNode.EscalationStart
+		for _, rem := range unhealthyNode.Remediations {
+			if rem.Phase == remediationv1alpha1.RemediationPhaseNotApplicable {
+				continue
+			}
+			if escRem := FindEscalatingRemediation(nhc, rem); escRem != nil && (lastUsed == nil || escRem.Order > lastUsed.Order) {
+				lastUsed = escRem
+			}
+		}
+	}
+	if lastUsed == nil {
+		return
+	}
+
+	attempts, _ := GetNextRemediationAttempt(nodeName, nhc, now)
+	recentRemediation := &remediationv1alpha1.RecentRemediation{
+		Name:     nodeName,
+		Order:    lastUsed.Order,
+		Finished: metav1.Time{Time: now},
+		Attempts: attempts - 1,
+	}
+	if escalationStart != nil && escalationStart.Order == lastUsed.Order {
+		recentRemediation.StepAttempts = escalationStart.StepAttempt
+	}
+	for i, recent := range nhc.Status.RecentRemediations {
+		if recent.Name == nodeName {
+			nhc.Status.RecentRemediations[i] = recentRemediation
+			return
+		}
+	}
+	nhc.Status.RecentRemediations = append(nhc.Status.RecentRemediations, recentRemediation)
+}
+
+// PruneStatusRecentRemediations removes remembered remediations which are older than the escalation memory window
+func PruneStatusRecentRemediations(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if nhc.Spec.EscalationMemory == nil {
+		nhc.Status.RecentRemediations = nil
+		return
+	}
+	var recentRemediations []*remediationv1alpha1.RecentRemediation
+	for _, recent := range nhc.Status.RecentRemediations {
+		if now.Before(recent.Finished.Add(nhc.Spec.EscalationMemory.Window.Duration)) {
+			recentRemediations = append(recentRemediations, recent)
+		}
+	}
+	nhc.Status.RecentRemediations = recentRemediations
+}
+
+// AppendStatusRemediationHistory records the finished remediation of the given node, and removes the oldest records
+// exceeding HistoryMaxEntries
+func AppendStatusRemediationHistory(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, remediation *remediationv1alpha1.Remediation, outcome remediationv1alpha1.RemediationOutcome, now time.Time) {
+	nhc.Status.RemediationHistory = append(nhc.Status.RemediationHistory, remediationv1alpha1.NodeRemediationRecord{
+		NodeName:     nodeName,
+		TemplateKind: remediation.Resource.Kind + templateSuffix,
+		TemplateName: remediation.TemplateName,
+		Started:      remediation.Started,
+		Finished:     metav1.Time{Time: now},
+		Outcome:      outcome,
+	})
+	TrimStatusRemediationHistory(nhc)
+}
+
+// TrimStatusRemediationHistory removes the oldest records of the remediation history exceeding HistoryMaxEntries
+func TrimStatusRemediationHistory(nhc *remediationv1alpha1.NodeHealthCheck) {
+	maxEntries := nhc.Spec.HistoryMaxEntries
+	if maxEntries < 1 {
+		maxEntries = 8
+	}
+	if excess := len(nhc.Status.RemediationHistory) - maxEntries; excess > 0 {
+		nhc.Status.RemediationHistory = append([]remediationv1alpha1.NodeRemediationRecord(nil), nhc.Status.RemediationHistory[excess:]...)
+	}
+}
+
+// RecordStatusRemediationCooldown remembers when the remediation of the given node finished, in case the remediation
+// cooldown is configured. Needs to be called before the node is removed from the unhealthy nodes, nodes without
+// remediations are ignored.
+func RecordStatusRemediationCooldown(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if nhc.Spec.RemediationCooldown == nil || !HasStatusRemediations(nodeName, nhc) {
+		return
+	}
+	finished := metav1.Time{Time: now}
+	for _, coolingDownNode := range nhc.Status.CoolingDownNodes {
+		if coolingDownNode.Name == nodeName {
+			coolingDownNode.RemediationFinished = finished
+			return
+		}
+	}
+	nhc.Status.CoolingDownNodes = append(nhc.Status.CoolingDownNodes, &remediationv1alpha1.CoolingDownNode{
+		Name:                nodeName,
+		RemediationFinished: finished,
+	})
+}
+
+// PruneStatusCoolingDownNodes forgets nodes whose remediation cooldown expired
+func PruneStatusCoolingDownNodes(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if nhc.Spec.RemediationCooldown == nil {
+		nhc.Status.CoolingDownNodes = nil
+		return
+	}
+	var coolingDownNodes []*remediationv1alpha1.CoolingDownNode
+	for _, coolingDownNode := range nhc.Status.CoolingDownNodes {
+		if now.Before(coolingDownNode.RemediationFinished.Add(nhc.Spec.RemediationCooldown.Duration)) {
+			coolingDownNodes = append(coolingDownNodes, coolingDownNode)
+		}
+	}
+	nhc.Status.CoolingDownNodes = coolingDownNodes
+}
+
+// GetRemediationCooldownRemaining returns the remaining time of the remediation cooldown of the given node, or nil if
+// the node isn't cooling down
+func GetRemediationCooldownRemaining(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) *time.Duration {
+	if nhc.Spec.RemediationCooldown == nil {
+		return nil
+	}
+	for _, coolingDownNode := range nhc.Status.CoolingDownNodes {
+		if coolingDownNode.Name != nodeName {
+			continue
+		}
+		if remaining := coolingDownNode.RemediationFinished.Add(nhc.Spec.RemediationCooldown.Duration).Sub(now); remaining > 0 {
+			return &remaining
+		}
+		return nil
+	}
+	return nil
+}
+
+// RecordStatusStabilizationRemediation remembers that the remediation of the given node finished, in case the
+// adaptive stabilization is enabled.
+func RecordStatusStabilizationRemediation(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if !isAdaptiveStabilizationEnabled(nhc) {
+		return
+	}
+	finished := metav1.Time{Time: now}
+	if stabilizingNode := getStatusStabilizingNode(nodeName, nhc); stabilizingNode != nil {
+		stabilizingNode.RemediationsFinished = append(stabilizingNode.RemediationsFinished, finished)
+		return
+	}
+	nhc.Status.StabilizingNodes = append(nhc.Status.StabilizingNodes, &remediationv1alpha1.StabilizingNode{
+		Name:                 nodeName,
+		RemediationsFinished: []metav1.Time{finished},
+	})
+}
+
+// PruneStatusStabilizingNodes forgets remediations which finished before the adaptive stabilization window, and
+// removes nodes without recent remediations and effective duration.
+func PruneStatusStabilizingNodes(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	if !isAdaptiveStabilizationEnabled(nhc) {
+		nhc.Status.StabilizingNodes = nil
+		return
+	}
+	var stabilizingNodes []*remediationv1alpha1.StabilizingNode
+	for _, stabilizingNode := range nhc.Status.StabilizingNodes {
+		var finished []metav1.Time
+		for _, f := range stabilizingNode.RemediationsFinished {
+			if now.Before(f.Add(nhc.Spec.AdaptiveStabilization.Window.Duration)) {
+				finished = append(finished, f)
+			}
+		}
+		stabilizingNode.RemediationsFinished = finished
+		if len(finished) > 0 || stabilizingNode.EffectiveDuration != nil {
+			stabilizingNodes = append(stabilizingNodes, stabilizingNode)
+		}
+	}
+	nhc.Status.StabilizingNodes = stabilizingNodes
+}
+
+// UpdateStatusEffectiveDurations sets the effective unhealthy condition durations of the given nodes, and removes
+// them from all other nodes, in case the adaptive stabilization is enabled.
+func UpdateStatusEffectiveDurations(nhc *remediationv1alpha1.NodeHealthCheck, effectiveDurations map[string]time.Duration) {
+	if !isAdaptiveStabilizationEnabled(nhc) {
+		return
+	}
+	for _, stabilizingNode := range nhc.Status.StabilizingNodes {
+		if _, exists := effectiveDurations[stabilizingNode.Name]; !exists {
+			stabilizingNode.EffectiveDuration = nil
+		}
+	}
+	for nodeName, duration := range effectiveDurations {
+		effectiveDuration := &metav1.Duration{Duration: duration}
+		if stabilizingNode := getStatusStabilizingNode(nodeName, nhc); stabilizingNode != nil {
+			stabilizingNode.EffectiveDuration = effectiveDuration
+			continue
+		}
+		nhc.Status.StabilizingNodes = append(nhc.Status.StabilizingNodes, &remediationv1alpha1.StabilizingNode{
+			Name:              nodeName,
+			EffectiveDuration: effectiveDuration,
+		})
+	}
+	var stabilizingNodes []*remediationv1alpha1.StabilizingNode
+	for _, stabilizingNode := range nhc.Status.StabilizingNodes {
+		if len(stabilizingNode.RemediationsFinished) > 0 || stabilizingNode.EffectiveDuration != nil {
+			stabilizingNodes = append(stabilizingNodes, stabilizingNode)
+		}
+	}
+	sort.Slice(stabilizingNodes, func(i, j int) bool {
+		return stabilizingNodes[i].Name < stabilizingNodes[j].Name
+	})
+	nhc.Status.StabilizingNodes = stabilizingNodes
+}
+
+// GetStabilizedUnhealthyCondition returns the given unhealthy condition with the duration which is in force for the
+// given node: when the adaptive stabilization is enabled, the configured duration is multiplied by the multiplier for
+// each recent remediation of the node, capped at the max duration.
+func GetStabilizedUnhealthyCondition(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, c remediationv1alpha1.UnhealthyCondition) remediationv1alpha1.UnhealthyCondition {
+	stabilizingNode := getStatusStabilizingNode(nodeName, nhc)
+	if !isAdaptiveStabilizationEnabled(nhc) || stabilizingNode == nil || c.Duration.Duration <= 0 {
+		return c
+	}
+	stabilization := nhc.Spec.AdaptiveStabilization
+	multiplier := time.Duration(stabilization.Multiplier)
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	duration := c.Duration.Duration
+	maxDuration := time.Duration(math.MaxInt64)
+	if stabilization.MaxDuration != nil {
+		maxDuration = stabilization.MaxDuration.Duration
+	}
+	for range stabilizingNode.RemediationsFinished {
+		if duration >= maxDuration || duration > maxDuration/multiplier {
+			duration = maxDuration
+			break
+		}
+		duration *= multiplier
+	}
+	if duration < c.Duration.Duration {
+		// a max duration below the configured duration doesn't shorten it
+		duration = c.Duration.Duration
+	}
+	c.Duration = metav1.Duration{Duration: duration}
+	return c
+}
+
+func isAdaptiveStabilizationEnabled(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+	return nhc.Spec.AdaptiveStabilization != nil && nhc.Spec.AdaptiveStabilization.Enabled
+}
+
+func getStatusStabilizingNode(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.StabilizingNode {
+	for _, stabilizingNode := range nhc.Status.StabilizingNodes {
+		if stabilizingNode.Name == nodeName {
+			return stabilizingNode
+		}
+	}
+	return nil
+}
+
+// UpdateStatusRemediationThrottle prepares the RemediationThrottle status for checking its limits: it counts the nodes
+// under remediation or being drained for remediation, forgets remediation starts before the window, and resets the
+// binding limit. Removes the status when no RemediationThrottle is configured.
+func UpdateStatusRemediationThrottle(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	throttle := nhc.Spec.RemediationThrottle
+	if throttle == nil {
+		nhc.Status.RemediationThrottle = nil
+		return
+	}
+	status := nhc.Status.RemediationThrottle
+	if status == nil {
+		status = &remediationv1alpha1.RemediationThrottleStatus{}
+		nhc.Status.RemediationThrottle = status
+	}
+	status.BindingLimit = ""
+	status.InFlight = CountStatusRemediatedNodes(nhc)
+	var started []metav1.Time
+	for _, start := range status.StartedInWindow {
+		if now.Before(start.Add(throttle.Window.Duration)) {
+			started = append(started, start)
+		}
+	}
+	status.StartedInWindow = started
+}
+
+// CountStatusRemediatedNodes returns the number of nodes under remediation or being drained for remediation. Nodes
+// whose remediation was given up aren't counted.
+func CountStatusRemediatedNodes(nhc *remediationv1alpha1.NodeHealthCheck) int {
+	count := 0
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if (len(unhealthyNode.Remediations) > 0 && unhealthyNode.RemediationExhausted == nil) || unhealthyNode.Drain != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// GetRemediationThrottleLimit returns the limit of the RemediationThrottle which prevents starting the remediation of
+// another node, and when to check again. Returns an empty limit when no limit is reached.
+func GetRemediationThrottleLimit(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) (remediationv1alpha1.RemediationThrottleLimit, *time.Duration) {
+	throttle, status := nhc.Spec.RemediationThrottle, nhc.Status.RemediationThrottle
+	if throttle == nil || status == nil {
+		return "", nil
+	}
+	if throttle.MaxConcurrent > 0 && status.InFlight >= throttle.MaxConcurrent {
+		// remediations finishing trigger a reconcile anyway
+		return remediationv1alpha1.RemediationThrottleLimitMaxConcurrent, nil
+	}
+	if throttle.MaxPerWindow > 0 && len(status.StartedInWindow) >= throttle.MaxPerWindow {
+		// the oldest start leaves the window first
+		oldest := status.StartedInWindow[0].Time
+		for _, start := range status.StartedInWindow {
+			if start.Time.Before(oldest) {
+				oldest = start.Time
+			}
+		}
+		requeueAfter := oldest.Add(throttle.Window.Duration).Sub(now)
+		return remediationv1alpha1.RemediationThrottleLimitMaxPerWindow, &requeueAfter
+	}
+	return "", nil
+}
+
+// RecordStatusRemediationThrottleStart counts the start of the given node's remediation for the RemediationThrottle.
+// Nodes which were drained before are counted as in flight already.
+func RecordStatusRemediationThrottleStart(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) {
+	status := nhc.Status.RemediationThrottle
+	if nhc.Spec.RemediationThrottle == nil || status == nil {
+		return
+	}
+	if GetStatusDrain(nodeName, nhc) == nil {
+		status.InFlight++
+	}
+	status.StartedInWindow = append(status.StartedInWindow, metav1.Time{Time: now})
+}
+
+// RecordStatusRemediatorOutcome records the outcome of a remediation of the given kind and node for the
+// RemediatorCircuitBreaker. A success closes the circuit of the kind. A failure counts towards opening it, or opens it
+// again when it was the failure of the probe of a half open circuit. Returns the circuit when its state changed.
+func RecordStatusRemediatorOutcome(nhc *remediationv1alpha1.NodeHealthCheck, kind, nodeName string, succeeded bool, now time.Time) *remediationv1alpha1.RemediatorCircuit {
+	if nhc.Spec.RemediatorCircuitBreaker == nil {
+		return nil
+	}
+	circuit := getStatusRemediatorCircuit(nhc, kind)
+	if succeeded {
+		if circuit == nil {
+			return nil
+		}
+		removeStatusRemediatorCircuit(nhc, kind)
+		if circuit.State == remediationv1alpha1.CircuitStateClosed {
+			return nil
+		}
+		return &remediationv1alpha1.RemediatorCircuit{Kind: kind, State: remediationv1alpha1.CircuitStateClosed}
+	}
+
+	if circuit == nil {
+		circuit = &remediationv1alpha1.RemediatorCircuit{Kind: kind, State: remediationv1alpha1.CircuitStateClosed}
+		nhc.Status.RemediatorCircuits = append(nhc.Status.RemediatorCircuits, circuit)
+	}
+	switch circuit.State {
+	case remediationv1alpha1.CircuitStateOpen:
+		// a remediation which started before the circuit opened
+		return nil
+	case remediationv1alpha1.CircuitStateHalfOpen:
+		if circuit.ProbeNode != nodeName {
+			return nil
+		}
+	default:
+		circuit.ConsecutiveFailures++
+		if circuit.ConsecutiveFailures < utils.GetCircuitFailureThreshold(nhc) {
+			return nil
+		}
+	}
+	circuit.State = remediationv1alpha1.CircuitStateOpen
+	circuit.Opened = &metav1.Time{Time: now}
+	circuit.ProbeNode = ""
+	return circuit
+}
+
+// IsStatusRemediatorCircuitClosed returns true if a new remediation CR of the given kind can be created for the given
+// node. When a circuit is open for longer than the open duration, the first node asking is let through as probe, and
+// the circuit is half open until the probe's outcome is recorded. Another node becomes the probe, when the probing
+// node isn't unhealthy anymore without an outcome, e.g. because it was deleted.
+func IsStatusRemediatorCircuitClosed(nhc *remediationv1alpha1.NodeHealthCheck, kind, nodeName string, now time.Time) bool {
+	if nhc.Spec.RemediatorCircuitBreaker == nil {
+		return true
+	}
+	circuit := getStatusRemediatorCircuit(nhc, kind)
+	if circuit == nil {
+		return true
+	}
+	switch circuit.State {
+	case remediationv1alpha1.CircuitStateOpen:
+		if circuit.Opened != nil && now.Before(circuit.Opened.Add(utils.GetCircuitOpenDuration(nhc))) {
+			return false
+		}
+		circuit.State = remediationv1alpha1.CircuitStateHalfOpen
+	case remediationv1alpha1.CircuitStateHalfOpen:
+		if circuit.ProbeNode != nodeName && isStatusNodeUnhealthy(circuit.ProbeNode, nhc) {
+			return false
+		}
+	}
+	circuit.ProbeNode = nodeName
+	return true
+}
+
+// GetStatusOpenRemediatorCircuits returns the sorted kinds of remediations whose circuit isn't closed
+func GetStatusOpenRemediatorCircuits(nhc *remediationv1alpha1.NodeHealthCheck) []string {
+	var kinds []string
+	for _, circuit := range nhc.Status.RemediatorCircuits {
+		if circuit.State != remediationv1alpha1.CircuitStateClosed {
+			kinds = append(kinds, circuit.Kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// PruneStatusRemediatorCircuits removes all circuits when the RemediatorCircuitBreaker isn't configured
+func PruneStatusRemediatorCircuits(nhc *remediationv1alpha1.NodeHealthCheck) {
+	if nhc.Spec.RemediatorCircuitBreaker == nil {
+		nhc.Status.RemediatorCircuits = nil
+	}
+}
+
+// UpdateStatusUnavailableRemediators updates the remediators which aren't running, keeping the time since when they
+// aren't running. The given messages explain why the remediators aren't running, by remediation kind. Returns the
+// sorted kinds whose remediator stopped running, and whose remediator is running again.
+func UpdateStatusUnavailableRemediators(nhc *remediationv1alpha1.NodeHealthCheck, unavailable map[string]string, now time.Time) (stopped, started []string) {
+	var updated []*remediationv1alpha1.UnavailableRemediator
+	for _, remediator := range nhc.Status.UnavailableRemediators {
+		message, exists := unavailable[remediator.Kind]
+		if !exists {
+			started = append(started, remediator.Kind)
+			continue
+		}
+		remediator.Message = message
+		updated = append(updated, remediator)
+	}
+	for kind, message := range unavailable {
+		if GetStatusUnavailableRemediator(nhc, kind) != nil {
+			continue
+		}
+		stopped = append(stopped, kind)
+		updated = append(updated, &remediationv1alpha1.UnavailableRemediator{
+			Kind:    kind,
+			Message: message,
+			Since:   metav1.Time{Time: now},
+		})
+	}
+	sort.Slice(updated, func(i, j int) bool {
+		return updated[i].Kind < updated[j].Kind
+	})
+	nhc.Status.UnavailableRemediators = updated
+	sort.Strings(stopped)
+	sort.Strings(started)
+	return stopped, started
+}
+
+// GetStatusUnavailableRemediator returns the status of the remediator of the given remediation kind, or nil if it
+// isn't known to be unavailable
+func GetStatusUnavailableRemediator(nhc *remediationv1alpha1.NodeHealthCheck, kind string) *remediationv1alpha1.UnavailableRemediator {
+	for _, remediator := range nhc.Status.UnavailableRemediators {
+		if remediator.Kind == kind {
+			return remediator
+		}
+	}
+	return nil
+}
+
+// GetStatusNodesUnderRemediation returns the sorted names of unhealthy nodes with an ongoing remediation or a running
+// verification, whose remediation wasn't given up
+func GetStatusNodesUnderRemediation(nhc *remediationv1alpha1.NodeHealthCheck) []string {
+	var nodeNames []string
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.RemediationExhausted != nil {
+			continue
+		}
+		underRemediation := unhealthyNode.Verification != nil && unhealthyNode.Verification.Phase == remediationv1alpha1.VerificationPhaseVerifying
+		for _, remediation := range unhealthyNode.Remediations {
+			underRemediation = underRemediation || IsStatusRemediationOngoing(remediation)
+		}
+		if underRemediation {
+			nodeNames = append(nodeNames, unhealthyNode.Name)
+		}
+	}
+	sort.Strings(nodeNames)
+	return nodeNames
+}
+
+// GetStatusAlertSilence returns the Alertmanager silence of the given node, or nil if there is none
+func GetStatusAlertSilence(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.AlertSilence {
+	for _, silence := range nhc.Status.AlertSilences {
+		if silence.NodeName == nodeName {
+			return silence
+		}
+	}
+	return nil
+}
+
+// UpdateStatusAlertSilence adds the given Alertmanager silence, or replaces the silence of its node
+func UpdateStatusAlertSilence(nhc *remediationv1alpha1.NodeHealthCheck, silence *remediationv1alpha1.AlertSilence) {
+	RemoveStatusAlertSilence(silence.NodeName, nhc)
+	nhc.Status.AlertSilences = append(nhc.Status.AlertSilences, silence)
+	sort.Slice(nhc.Status.AlertSilences, func(i, j int) bool {
+		return nhc.Status.AlertSilences[i].NodeName < nhc.Status.AlertSilences[j].NodeName
+	})
+}
+
+// RemoveStatusAlertSilence removes the Alertmanager silence of the given node
+func RemoveStatusAlertSilence(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) {
+	for i, silence := range nhc.Status.AlertSilences {
+		if silence.NodeName == nodeName {
+			nhc.Status.AlertSilences = append(nhc.Status.AlertSilences[:i], nhc.Status.AlertSilences[i+1:]...)
+			return
+		}
+	}
+}
+
+func getStatusRemediatorCircuit(nhc *remediationv1alpha1.NodeHealthCheck, kind string) *remediationv1alpha1.RemediatorCircuit {
+	for _, circuit := range nhc.Status.RemediatorCircuits {
+		if circuit.Kind == kind {
+			return circuit
+		}
+	}
+	return nil
+}
+
+func removeStatusRemediatorCircuit(nhc *remediationv1alpha1.NodeHealthCheck, kind string) {
+	for i, circuit := range nhc.Status.RemediatorCircuits {
+		if circuit.Kind == kind {
+			nhc.Status.RemediatorCircuits = append(nhc.Status.RemediatorCircuits[:i], nhc.Status.RemediatorCircuits[i+1:]...)
+			return
+		}
+	}
+}
+
+func isStatusNodeUnhealthy(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) bool {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEscalationStartFromMemory returns the escalating remediation to start with for the given node, based on the
+// remembered remediations. Returns nil when remediation should start with the first escalating remediation, or when
+// remediation of the node already started.
+func GetEscalationStartFromMemory(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) *remediationv1alpha1.EscalationStart {
+	if nhc.Spec.EscalationMemory == nil || len(nhc.Spec.EscalatingRemediations) == 0 {
+		return nil
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName && (len(unhealthyNode.Remediations) > 0 || unhealthyNode.EscalationStart != nil) {
+			return nil
+		}
+	}
+	var recentRemediation *remediationv1alpha1.RecentRemediation
+	for _, recent := range nhc.Status.RecentRemediations {
+		if recent.Name == nodeName && now.Before(recent.Finished.Add(nhc.Spec.EscalationMemory.Window.Duration)) {
+			recentRemediation = recent
+			break
+		}
+	}
+	if recentRemediation == nil {
+		return nil
+	}
+
+	// use the next remediation, or the last one if there is no next one
+	var next, last *remediationv1alpha1.EscalatingRemediation
+	for i := range nhc.Spec.EscalatingRemediations {
+		escRem := &nhc.Spec.EscalatingRemediations[i]
+		if escRem.Order > recentRemediation.Order && (next == nil || escRem.Order < next.Order) {
+			next = escRem
+		}
+		if last == nil || escRem.Order > last.Order {
+			last = escRem
+		}
+	}
+	start := next
+	if start == nil {
+		start = last
+	}
+	escalationStart := &remediationv1alpha1.EscalationStart{
+		Order: start.Order,
+		Reason: fmt.Sprintf("node got unhealthy again %s after its last remediation with order %d finished",
+			now.Sub(recentRemediation.Finished.Time).Round(time.Second), recentRemediation.Order),
+	}
+	if start.Order == recentRemediation.Order {
+		// the last used remediation is retried
+		escalationStart.StepAttempt = max(recentRemediation.StepAttempts, 1) + 1
+	}
+	return escalationStart
+}
+
+// UpdateStatusEscalationStart sets the escalating remediation to start with for the given unhealthy node
+func UpdateStatusEscalationStart(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, start *remediationv1alpha1.EscalationStart) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			unhealthyNode.EscalationStart = start
+			return
+		}
+	}
+}
+
+// UpdateStatusRemediationRoute sets the route which is used for remediating the given node. Returns true if the route
+// changed.
+func UpdateStatusRemediationRoute(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck, route *remediationv1alpha1.RemediationRoute) bool {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			current := unhealthyNode.Route
+			if (current == nil && route == nil) || (current != nil && route != nil && *current == *route) {
+				return false
+			}
+			unhealthyNode.Route = route
+			return true
+		}
+	}
+	return false
+}
+
+// FindEscalatingRemediation returns the escalating remediation which was used for the given remediation
+func FindEscalatingRemediation(nhc *remediationv1alpha1.NodeHealthCheck, remediation *remediationv1alpha1.Remediation) *remediationv1alpha1.EscalatingRemediation {
+	for i := range nhc.Spec.EscalatingRemediations {
+		escRem := &nhc.Spec.EscalatingRemediations[i]
+		if strings.TrimSuffix(escRem.RemediationTemplate.Kind, templateSuffix) != remediation.Resource.Kind {
+			continue
+		}
+		if len(remediation.TemplateName) > 0 && remediation.TemplateName != escRem.RemediationTemplate.Name {
+			continue
+		}
+		return escRem
+	}
+	return nil
+}
+
+// UpdateStatusPhaseTransition adds the time spent in the given previous phase to the phase durations, and sets the
+// last phase transition time, if the phase changed. The first phase of a NHC only sets the transition time.
+func UpdateStatusPhaseTransition(nhc *remediationv1alpha1.NodeHealthCheck, previousPhase remediationv1alpha1.NHCPhase, now time.Time) {
+	if nhc.Status.LastPhaseTransitionTime != nil && nhc.Status.Phase == previousPhase {
+		return
+	}
+	if nhc.Status.LastPhaseTransitionTime != nil && previousPhase != "" {
+		spent := now.Sub(nhc.Status.LastPhaseTransitionTime.Time)
+		if spent < 0 {
+			spent = 0
+		}
+		found := false
+		for i := range nhc.Status.PhaseDurations {
+			if nhc.Status.PhaseDurations[i].Phase == previousPhase {
+				nhc.Status.PhaseDurations[i].Duration.Duration += spent
+				found = true
+				break
+			}
+		}
+		if !found {
+			nhc.Status.PhaseDurations = append(nhc.Status.PhaseDurations, remediationv1alpha1.PhaseDuration{
+				Phase:    previousPhase,
+				Duration: metav1.Duration{Duration: spent},
+			})
+		}
+	}
+	nhc.Status.LastPhaseTransitionTime = &metav1.Time{Time: now}
+}
+
+// GetPhaseDurations returns the cumulative time spent in each phase until now, including the current phase
+func GetPhaseDurations(nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) map[remediationv1alpha1.NHCPhase]time.Duration {
+	durations := make(map[remediationv1alpha1.NHCPhase]time.Duration, len(nhc.Status.PhaseDurations)+1)
+	for _, phaseDuration := range nhc.Status.PhaseDurations {
+		durations[phaseDuration.Phase] = phaseDuration.Duration.Duration
+	}
+	if nhc.Status.Phase != "" && nhc.Status.LastPhaseTransitionTime != nil && now.After(nhc.Status.LastPhaseTransitionTime.Time) {
+		durations[nhc.Status.Phase] += now.Sub(nhc.Status.LastPhaseTransitionTime.Time)
+	}
+	return durations
+}
+
+// UpdateStatusEffectiveConfig records the given resolved configuration in the status, if it differs from the
+// recorded one
+func UpdateStatusEffectiveConfig(nhc *remediationv1alpha1.NodeHealthCheck, config *remediationv1alpha1.EffectiveConfig) error {
+	config.Hash = ""
+	data, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal effective config")
+	}
+	config.Hash = fmt.Sprintf("%x", sha256.Sum256(data))[:16]
+	if nhc.Status.EffectiveConfig != nil && nhc.Status.EffectiveConfig.Hash == config.Hash {
+		return nil
+	}
+	nhc.Status.EffectiveConfig = config
+	return nil
+}
@@ -1,28 +1,37 @@
 package resources
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"sort"
 	"strings"
 	"time"
 
+	commonevents "github.com/medik8s/common/pkg/events"
 	"github.com/pkg/errors"
 
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
 )
 
 const (
 	metal3RemediationTemplateKind = "Metal3RemediationTemplate"
 	machineAPINamespace           = "openshift-machine-api"
+	// permissionCheckCRName is the name of the dry run remediation CRs used for checking permissions
+	permissionCheckCRName = "nhc-permission-check"
 )
 
 type brokenTemplateError struct{ msg string }
@@ -34,19 +43,33 @@ type NoTemplateLeftError struct{ msg string }
 func (nt NoTemplateLeftError) Error() string { return nt.msg }
 
 // GetCurrentTemplateWithTimeout returns the current template to use. It might have been used for starting remediation already, but remediation didn't time out yet
-func (m *manager) GetCurrentTemplateWithTimeout(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck) (*unstructured.Unstructured, *time.Duration, error) {
-	if nhc.Spec.RemediationTemplate != nil {
-		template, err := m.getTemplate(nhc.Spec.RemediationTemplate)
-		return template, nil, err
+// With RemediationTemplates, it returns the first of them, see GetRemediationTemplates for all of them.
+func (m *manager) GetCurrentTemplateWithTimeout(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) (*unstructured.Unstructured, *time.Duration, error) {
+	if route := ResolveRemediationRoute(nhc); route != nil {
+		switch route.Type {
+		case remediationv1alpha1.RemediationRouteTemplate:
+			template, err := m.getTemplate(nhc.Spec.RemediationTemplate, nhc)
+			return template, nil, err
+		case remediationv1alpha1.RemediationRouteTemplates:
+			template, err := m.getTemplate(&nhc.Spec.RemediationTemplates[0], nhc)
+			return template, nil, err
+		case remediationv1alpha1.RemediationRouteInlineTemplate:
+			template, err := m.getInlineTemplate(nhc)
+			return template, nil, err
+		}
 	}
 
 	remediations := nhc.Spec.EscalatingRemediations
 	sort.Slice(remediations, func(i, j int) bool {
 		return remediations[i].Order < remediations[j].Order
 	})
+	escalationStart := getEscalationStart(node, nhc)
 	for _, rem := range remediations {
-		// ensure this remediation wasn't used and timed out already
-		startedRemediation := FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+		// skip remediations which are before the start of escalation, see EscalationMemory
+		if escalationStart != nil && rem.Order < escalationStart.Order {
+			continue
+		}
+		isStatusMatch := func(r *remediationv1alpha1.Remediation) bool {
 			gvk := schema.GroupVersionKind{
 				Group:   rem.RemediationTemplate.GroupVersionKind().Group,
 				Version: rem.RemediationTemplate.GroupVersionKind().Version,
@@ -54,44 +77,185 @@ func (m *manager) GetCurrentTemplateWithTimeout(node *v1.Node, nhc *remediationv
 				Kind: rem.RemediationTemplate.GroupVersionKind().Kind[:len(rem.RemediationTemplate.GroupVersionKind().Kind)-len("Template")],
 			}
 			isTemplateMatch := len(r.TemplateName) == 0 || r.TemplateName == rem.RemediationTemplate.Name
-			return r.Resource.GroupVersionKind() == gvk && r.TimedOut != nil && isTemplateMatch
+			return r.Resource.GroupVersionKind() == gvk && isTemplateMatch
+		}
+		// ensure this remediation wasn't used and timed out already, or was skipped as not applicable
+		startedRemediation := FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+			isUsed := r.TimedOut != nil || r.Phase == remediationv1alpha1.RemediationPhaseNotApplicable
+			return isStatusMatch(r) && isUsed
 		})
-		if startedRemediation == nil {
-			// not started, or ongoing, but not timed out
-			template, err := m.getTemplate(&rem.RemediationTemplate)
-			return template, &rem.Timeout.Duration, err
+		if startedRemediation != nil {
+			continue
 		}
+		// skip remediations which are paused or not applicable for the node, unless they are ongoing already
+		ongoingRemediation := FindStatusRemediation(node, nhc, isStatusMatch)
+		if ongoingRemediation == nil {
+			kind := strings.TrimSuffix(rem.RemediationTemplate.Kind, templateSuffix)
+			if rem.PauseReason != "" {
+				m.log.Info("skipping paused escalating remediation", "node", node.GetName(), "template", rem.RemediationTemplate.Name, "pauseReason", rem.PauseReason)
+				commonevents.WarningEventf(m.recorder, nhc, utils.EventReasonNotApplicable, "Skipping %s remediation for node %s, it is paused: %s", kind, node.GetName(), rem.PauseReason)
+				UpdateStatusRemediationNotApplicable(node, nhc, rem, m.getRemediationCRNamespace(node, nhc, rem.RemediationTemplate.Namespace), remediationv1alpha1.RemediationReasonStepPaused, nil, now)
+				continue
+			}
+			if missingLabels := getMissingNodeLabels(node, rem.RequiredNodeLabels); len(missingLabels) > 0 {
+				m.log.Info("skipping escalating remediation, node is missing required labels", "node", node.GetName(), "template", rem.RemediationTemplate.Name, "missingLabels", missingLabels)
+				commonevents.WarningEventf(m.recorder, nhc, utils.EventReasonNotApplicable, "Skipping %s remediation for node %s, node is missing required labels %s", kind, node.GetName(), strings.Join(missingLabels, ", "))
+				UpdateStatusRemediationNotApplicable(node, nhc, rem, m.getRemediationCRNamespace(node, nhc, rem.RemediationTemplate.Namespace), remediationv1alpha1.RemediationReasonMissingNodeLabels, missingLabels, now)
+				continue
+			}
+			if !IsStatusRemediatorCircuitClosed(nhc, kind, node.GetName(), now) {
+				m.log.Info("skipping escalating remediation, the circuit of its remediator is open", "node", node.GetName(), "template", rem.RemediationTemplate.Name)
+				commonevents.WarningEventf(m.recorder, nhc, utils.EventReasonNotApplicable, "Skipping %s remediation for node %s, the circuit of the remediator is open because remediations failed in a row", kind, node.GetName())
+				UpdateStatusRemediationNotApplicable(node, nhc, rem, m.getRemediationCRNamespace(node, nhc, rem.RemediationTemplate.Namespace), remediationv1alpha1.RemediationReasonCircuitOpen, nil, now)
+				continue
+			}
+			if remediator := GetStatusUnavailableRemediator(nhc, kind); remediator != nil {
+				m.log.Info("skipping escalating remediation, its remediator isn't running", "node", node.GetName(), "template", rem.RemediationTemplate.Name, "message", remediator.Message)
+				commonevents.WarningEventf(m.recorder, nhc, utils.EventReasonNotApplicable, "Skipping %s remediation for node %s, its remediator isn't running: %s", kind, node.GetName(), remediator.Message)
+				UpdateStatusRemediationNotApplicable(node, nhc, rem, m.getRemediationCRNamespace(node, nhc, rem.RemediationTemplate.Namespace), remediationv1alpha1.RemediationReasonRemediatorUnavailable, nil, now)
+				continue
+			}
+		}
+		// not started, or ongoing, but not timed out
+		template, err := m.getTemplate(&rem.RemediationTemplate, nhc)
+		// the timeout is stretched when the remediation is retried
+		stepAttempt := utils.GetEscalatingRemediationStepAttempt(rem, escalationStart)
+		timeout := utils.GetEscalatingRemediationStepTimeout(nhc, rem, stepAttempt)
+		return template, &timeout, err
 	}
 
 	// no template left
 	return nil, nil, NoTemplateLeftError{msg: fmt.Sprintf("didn't find a template to use for NHC %s and node %s", nhc.Name, node.Name)}
 }
 
+// GetRemediationTemplates returns the templates of the RemediationTemplates of the given NHC, which are all used at
+// once for remediating a node
+func (m *manager) GetRemediationTemplates(nhc *remediationv1alpha1.NodeHealthCheck) ([]*unstructured.Unstructured, error) {
+	templates := make([]*unstructured.Unstructured, 0, len(nhc.Spec.RemediationTemplates))
+	for i := range nhc.Spec.RemediationTemplates {
+		template, err := m.getTemplate(&nhc.Spec.RemediationTemplates[i], nhc)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// ResolveTemplateRefs returns the references of the remediation templates of all routes of the given NHC, with the
+// namespace they are read from. It expects that the templates were validated, which resolves the default namespace.
+func (m *manager) ResolveTemplateRefs(nhc *remediationv1alpha1.NodeHealthCheck) ([]v1.ObjectReference, error) {
+	var refs []v1.ObjectReference
+	for _, templateRef := range utils.GetAllRemediationTemplates(nhc) {
+		ref := v1.ObjectReference{
+			APIVersion: templateRef.APIVersion,
+			Kind:       templateRef.Kind,
+			Namespace:  templateRef.Namespace,
+			Name:       templateRef.Name,
+		}
+		obj := m.GenerateTemplate(templateRef)
+		if nhc.Spec.InlineRemediationTemplate != nil {
+			// the inline template doesn't exist in the cluster, it uses the namespace of the remediation CR
+			obj = m.GenerateRemediationCRBase(obj.GroupVersionKind())
+		}
+		if isNamespaced, err := m.IsObjectNamespaced(obj); err != nil {
+			return nil, errors.Wrapf(err, "failed to check if remediation template %q is namespaced", ref.Name)
+		} else if !isNamespaced {
+			ref.Namespace = ""
+		} else if ref.Namespace == "" {
+			ref.Namespace = nhc.Status.DefaultTemplateNamespace
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// getMissingNodeLabels returns the sorted required labels, formatted as "key=value", which the node doesn't have
+func getMissingNodeLabels(node *v1.Node, requiredLabels map[string]string) []string {
+	var missing []string
+	nodeLabels := node.GetLabels()
+	for key, value := range requiredLabels {
+		if nodeValue, exists := nodeLabels[key]; !exists || nodeValue != value {
+			missing = append(missing, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// GetRemediationDuration returns the expected remediation duration for the given remediation CR of the given node,
+// and all previous used templates, see utils.GetRemediationDuration. The time which passed between the timeout of a
+// remediation and the start of the next one, e.g. because of the EscalationDelay, counts towards the previous used
+// templates. With the EscalationHandshake enabled, the current remediation might wait for the acknowledgment of its
+// timeout for up to the handshake's grace period, without the lease being extended meanwhile.
+func GetRemediationDuration(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured, now time.Time) (currentRemediationDuration, previousRemediationsDuration time.Duration) {
+	currentRemediationDuration, previousRemediationsDuration = utils.GetRemediationDuration(nhc, remediationCR, getEscalationStart(node, nhc))
+	if nhc.Spec.EscalationHandshake && len(nhc.Spec.EscalatingRemediations) > 0 {
+		currentRemediationDuration += utils.GetEscalationHandshakeGracePeriod(nhc)
+	}
+	return currentRemediationDuration, previousRemediationsDuration + getEscalationGaps(node, nhc, now)
+}
+
+// getEscalationGaps returns the sum of the time between the timeout of the given node's remediations and the start of
+// the following remediation, or the given time if no remediation followed yet.
+func getEscalationGaps(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, now time.Time) time.Duration {
+	var gaps time.Duration
+	var timedOut *metav1.Time
+	for _, rem := range FindStatusRemediations(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+		return r.Phase != remediationv1alpha1.RemediationPhaseNotApplicable
+	}) {
+		if timedOut != nil && rem.Started.After(timedOut.Time) {
+			gaps += rem.Started.Sub(timedOut.Time)
+		}
+		timedOut = rem.TimedOut
+	}
+	if timedOut != nil && now.After(timedOut.Time) {
+		gaps += now.Sub(timedOut.Time)
+	}
+	return gaps
+}
+
+func getEscalationStart(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.EscalationStart {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == node.GetName() {
+			return unhealthyNode.EscalationStart
+		}
+	}
+	return nil
+}
+
 func (m *manager) GetTemplate(mhc *machinev1beta1.MachineHealthCheck) (*unstructured.Unstructured, error) {
 	if mhc.Spec.RemediationTemplate == nil {
 		// TODO catch this early in Reconciler
 		return nil, fmt.Errorf("remediation template must set for MHC %s", mhc.GetName())
 	}
-	template, err := m.getTemplateWithFallbackNamespace(mhc.Spec.RemediationTemplate, mhc.GetNamespace())
+	template, err := m.getTemplateWithFallbackNamespace(mhc.Spec.RemediationTemplate, func() (string, error) {
+		return mhc.GetNamespace(), nil
+	})
 	return template, err
 }
 
-func (m *manager) getTemplate(templateRef *v1.ObjectReference) (*unstructured.Unstructured, error) {
-	return m.getTemplateWithFallbackNamespace(templateRef, "")
+func (m *manager) getTemplate(templateRef *v1.ObjectReference, nhc *remediationv1alpha1.NodeHealthCheck) (*unstructured.Unstructured, error) {
+	return m.getTemplateWithFallbackNamespace(templateRef, func() (string, error) {
+		return resolveDefaultTemplateNamespace(nhc)
+	})
 }
 
-func (m *manager) getTemplateWithFallbackNamespace(templateRef *v1.ObjectReference, crNamespace string) (*unstructured.Unstructured, error) {
+func (m *manager) getTemplateWithFallbackNamespace(templateRef *v1.ObjectReference, getFallbackNamespace func() (string, error)) (*unstructured.Unstructured, error) {
 	template := m.GenerateTemplate(templateRef)
 
-	// ensure namespace is set if needed
+	// ensure namespace is set if needed, and ignored if not
 	if isNamespaced, err := m.IsObjectNamespaced(template); err != nil {
 		return nil, errors.Wrapf(err, "failed to check if remediation template %q is namespaced", template.GetName())
-	} else if isNamespaced && template.GetNamespace() == "" {
-		if crNamespace == "" {
-			return nil, errors.Errorf("remediation template %q not found, it is namespaced, but no namespace is provided", template.GetName())
+	} else if !isNamespaced {
+		template.SetNamespace("")
+	} else if template.GetNamespace() == "" {
+		fallbackNamespace, err := getFallbackNamespace()
+		if err != nil {
+			return nil, brokenTemplateError{fmt.Sprintf("remediation template %q is namespaced, but no namespace is provided, and the default namespace can't be determined: %v", template.GetName(), err)}
 		}
-		m.log.Info("Remediation template requires namespace, but it is missing. Falling back to CR's namespace", "template", template.GetName(), "CR namespace", crNamespace)
-		template.SetNamespace(crNamespace)
+		m.log.Info("Remediation template requires namespace, but it is missing. Falling back to default namespace", "template", template.GetName(), "namespace", fallbackNamespace)
+		template.SetNamespace(fallbackNamespace)
 	}
 
 	if err := m.Get(m.ctx, client.ObjectKeyFromObject(template), template); err != nil {
@@ -106,6 +270,38 @@ func (m *manager) getTemplateWithFallbackNamespace(templateRef *v1.ObjectReferen
 	return template, nil
 }
 
+// getInlineTemplate returns a virtual template for the inline remediation template of the given NHC, which can be
+// used like a template which exists in the cluster
+func (m *manager) getInlineTemplate(nhc *remediationv1alpha1.NodeHealthCheck) (*unstructured.Unstructured, error) {
+	inline := nhc.Spec.InlineRemediationTemplate
+	template := m.GenerateTemplate(utils.GetInlineRemediationTemplateRef(nhc))
+
+	// the template doesn't exist in the cluster, so check the remediation CR for the namespace
+	remediationCR := m.GenerateRemediationCRBase(template.GroupVersionKind())
+	if isNamespaced, err := m.IsObjectNamespaced(remediationCR); err != nil {
+		return nil, errors.Wrapf(err, "failed to check if inline remediation %q is namespaced", remediationCR.GetKind())
+	} else if !isNamespaced {
+		template.SetNamespace("")
+	} else if template.GetNamespace() == "" {
+		defaultNamespace, err := resolveDefaultTemplateNamespace(nhc)
+		if err != nil {
+			return nil, brokenTemplateError{fmt.Sprintf("invalid inline template, remediation %q is namespaced, but no namespace is provided, and the default namespace can't be determined: %v", remediationCR.GetKind(), err)}
+		}
+		template.SetNamespace(defaultNamespace)
+	}
+
+	spec := make(map[string]interface{})
+	if len(inline.Spec.Raw) > 0 {
+		if err := json.Unmarshal(inline.Spec.Raw, &spec); err != nil {
+			return nil, brokenTemplateError{fmt.Sprintf("invalid inline template, failed to parse spec: %v", err)}
+		}
+	}
+	if err := unstructured.SetNestedMap(template.Object, spec, "spec", "template", "spec"); err != nil {
+		return nil, brokenTemplateError{fmt.Sprintf("invalid inline template, failed to set spec: %v", err)}
+	}
+	return template, nil
+}
+
 func (m *manager) GenerateTemplate(templateRef *v1.ObjectReference) *unstructured.Unstructured {
 	template := new(unstructured.Unstructured)
 	template.SetGroupVersionKind(templateRef.GroupVersionKind())
@@ -116,25 +312,78 @@ func (m *manager) GenerateTemplate(templateRef *v1.ObjectReference) *unstructure
 
 // ValidateTemplates only returns an error when we don't know whether the template is valid or not, for triggering a requeue with backoff
 func (m *manager) ValidateTemplates(nhc *remediationv1alpha1.NodeHealthCheck) (valid bool, reason, message string, err error) {
+	if nhc.Spec.InlineRemediationTemplate != nil {
+		if template, err := m.getInlineTemplate(nhc); err != nil {
+			return m.handleTemplateError(err, utils.GetInlineRemediationTemplateRef(nhc).GroupVersionKind())
+		} else {
+			return m.validateTemplate(template, nhc)
+		}
+	}
 	if templateRef := nhc.Spec.RemediationTemplate; templateRef != nil {
-		if template, err := m.getTemplate(templateRef); err != nil {
-			return m.handleTemplateError(err)
+		if template, err := m.getTemplate(templateRef, nhc); err != nil {
+			return m.handleTemplateError(err, templateRef.GroupVersionKind())
 		} else {
-			return m.validateTemplate(template)
+			return m.validateTemplate(template, nhc)
+		}
+	}
+	for i := range nhc.Spec.RemediationTemplates {
+		templateRef := &nhc.Spec.RemediationTemplates[i]
+		if template, err := m.getTemplate(templateRef, nhc); err != nil {
+			return m.handleTemplateError(err, templateRef.GroupVersionKind())
+		} else if valid, reason, message, err = m.validateTemplate(template, nhc); !valid {
+			return valid, reason, message, err
 		}
 	}
 	for _, escRem := range nhc.Spec.EscalatingRemediations {
 		templateRef := escRem.RemediationTemplate
-		if template, err := m.getTemplate(&templateRef); err != nil {
-			return m.handleTemplateError(err)
-		} else if valid, reason, message, err = m.validateTemplate(template); !valid {
+		if template, err := m.getTemplate(&templateRef, nhc); err != nil {
+			return m.handleTemplateError(err, templateRef.GroupVersionKind())
+		} else if valid, reason, message, err = m.validateTemplate(template, nhc); !valid {
 			return valid, reason, message, err
 		}
 	}
 	return true, "", "", nil
 }
 
-func (m *manager) handleTemplateError(templateError error) (valid bool, reason, message string, err error) {
+// ValidateRemediationCRPermissions checks with dry run requests if the client is allowed to create, update and delete
+// the remediation CRs of all templates of the given NHC. Authorization happens before a request is processed, so other
+// errors than Forbidden are expected, e.g. NotFound for updating the dry run CR, and they are ignored. Returns an error
+// only when the templates can't be read.
+func (m *manager) ValidateRemediationCRPermissions(nhc *remediationv1alpha1.NodeHealthCheck) (allowed bool, message string, err error) {
+	var templates []*unstructured.Unstructured
+	if nhc.Spec.InlineRemediationTemplate != nil {
+		template, err := m.getInlineTemplate(nhc)
+		if err != nil {
+			return false, "", err
+		}
+		templates = append(templates, template)
+	} else {
+		for _, templateRef := range utils.GetAllRemediationTemplates(nhc) {
+			template, err := m.getTemplate(templateRef, nhc)
+			if err != nil {
+				return false, "", err
+			}
+			templates = append(templates, template)
+		}
+	}
+
+	for _, template := range templates {
+		remediationCR := m.GenerateRemediationCRBaseNamed(template.GroupVersionKind(), template.GetNamespace(), permissionCheckCRName)
+		checks := []func() error{
+			func() error { return m.Create(m.ctx, remediationCR.DeepCopy(), client.DryRunAll) },
+			func() error { return m.Update(m.ctx, remediationCR.DeepCopy(), client.DryRunAll) },
+			func() error { return m.Delete(m.ctx, remediationCR.DeepCopy(), client.DryRunAll) },
+		}
+		for _, check := range checks {
+			if err := check(); apierrors.IsForbidden(err) {
+				return false, err.Error(), nil
+			}
+		}
+	}
+	return true, "", nil
+}
+
+func (m *manager) handleTemplateError(templateError error, templateGVK schema.GroupVersionKind) (valid bool, reason, message string, err error) {
 
 	// When the template doesn't exist, we can get different kind of errors, e.g. NotFound or NoMatch error.
 	// Also check the error string in order to catch this error, which is thrown when the api group doesn't exist:
@@ -144,7 +393,21 @@ func (m *manager) handleTemplateError(templateError error) (valid bool, reason,
 			strings.Contains(err.Error(), "could not find") || strings.Contains(err.Error(), "not found")
 	}
 
+	// don't disable the NHC because of transient errors, they are returned for retrying with backoff
+	if isTransientError(templateError) {
+		return false, "", "", templateError
+	}
+
 	if isTemplateNotFoundError(templateError) {
+		// distinguish a missing template from a CRD which isn't established yet, e.g. during installation
+		if established, err := m.isCRDEstablished(templateGVK); err != nil {
+			return false, "", "", err
+		} else if !established {
+			return false,
+				remediationv1alpha1.ConditionReasonCRDNotEstablished,
+				fmt.Sprintf("CRD of remediation template kind %s isn't established yet", templateGVK.GroupKind()),
+				nil
+		}
 		return false,
 			remediationv1alpha1.ConditionReasonDisabledTemplateNotFound,
 			fmt.Sprintf("Remediation template not found: %q", templateError.Error()),
@@ -158,10 +421,42 @@ func (m *manager) handleTemplateError(templateError error) (valid bool, reason,
 	return false, "", "", templateError
 }
 
-func (m *manager) validateTemplate(template *unstructured.Unstructured) (valid bool, reason, message string, err error) {
+// isTransientError returns true for errors which are expected to go away by retrying, e.g. timeouts or throttling
+// of the API server
+func isTransientError(err error) bool {
+	if apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) || apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isCRDEstablished uses discovery for checking if the API server serves the given kind, which isn't the case as long
+// as its CRD isn't established
+func (m *manager) isCRDEstablished(gvk schema.GroupVersionKind) (bool, error) {
+	if _, err := m.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) || discovery.IsGroupDiscoveryFailedError(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to discover remediation template kind %s", gvk.GroupKind())
+	}
+	return true, nil
+}
+
+func (m *manager) validateTemplate(template *unstructured.Unstructured, nhc *remediationv1alpha1.NodeHealthCheck) (valid bool, reason, message string, err error) {
 	// Metal3 remediation needs the node's machine as owner ref,
 	// and owners need to be in the same namespace as their dependent.
 	// Make sure that the template is in the Machine's namespace.
+	// With the MachineNamespace strategy, the remediation CR's namespace is resolved per node, and is checked when the
+	// remediation CR is generated.
+	// With a management cluster, the Machine's namespace is looked up there when the remediation CR is generated.
+	if nhc.Spec.RemediationNamespaceStrategy == remediationv1alpha1.RemediationNamespaceStrategyMachineNamespace || m.managementCluster != nil {
+		return true, "", "", nil
+	}
 	if template.GetKind() == metal3RemediationTemplateKind && template.GetNamespace() != machineAPINamespace {
 		return false,
 			remediationv1alpha1.ConditionReasonDisabledTemplateInvalid,
@@ -170,3 +465,20 @@ func (m *manager) validateTemplate(template *unstructured.Unstructured) (valid b
 	}
 	return true, "", "", nil
 }
+
+// resolveDefaultTemplateNamespace returns the namespace to use for template references without namespace, which is
+// the operator's namespace. It is resolved once and recorded in the NHC's status, so that it stays stable.
+func resolveDefaultTemplateNamespace(nhc *remediationv1alpha1.NodeHealthCheck) (string, error) {
+	if nhc.Status.DefaultTemplateNamespace != "" {
+		return nhc.Status.DefaultTemplateNamespace, nil
+	}
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return "", err
+	}
+	if ns == "" {
+		return "", errors.New("operator namespace is empty")
+	}
+	nhc.Status.DefaultTemplateNamespace = ns
+	return ns, nil
+}
@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	coordv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+func TestGetRemediationDurationWithEscalationGaps(t *testing.T) {
+	acquired := time.Now().Add(-11 * time.Minute).Truncate(time.Second)
+	now := acquired.Add(11 * time.Minute)
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+
+	newNHC := func(remediations ...*remediationv1alpha1.Remediation) *remediationv1alpha1.NodeHealthCheck {
+		nhc := &remediationv1alpha1.NodeHealthCheck{
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				EscalationDelay: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			Status: remediationv1alpha1.NodeHealthCheckStatus{
+				UnhealthyNodes: []*remediationv1alpha1.UnhealthyNode{{Name: node.GetName(), Remediations: remediations}},
+			},
+		}
+		for i, kind := range []string{"FenceRemediationTemplate", "RebootRemediationTemplate"} {
+			nhc.Spec.EscalatingRemediations = append(nhc.Spec.EscalatingRemediations, remediationv1alpha1.EscalatingRemediation{
+				RemediationTemplate: corev1.ObjectReference{APIVersion: "remediation.example.com/v1", Kind: kind, Name: "template"},
+				Order:               i,
+				Timeout:             metav1.Duration{Duration: time.Minute},
+			})
+		}
+		return nhc
+	}
+	fence := &remediationv1alpha1.Remediation{
+		Resource: corev1.ObjectReference{APIVersion: "remediation.example.com/v1", Kind: "FenceRemediation", Name: "node"},
+		Started:  metav1.Time{Time: acquired},
+		TimedOut: &metav1.Time{Time: acquired.Add(time.Minute)},
+		Phase:    remediationv1alpha1.RemediationPhaseTimedOut,
+	}
+	reboot := &remediationv1alpha1.Remediation{
+		Resource: corev1.ObjectReference{APIVersion: "remediation.example.com/v1", Kind: "RebootRemediation", Name: "node"},
+		Started:  metav1.Time{Time: now},
+	}
+	rebootCR := &unstructured.Unstructured{}
+	rebootCR.SetAPIVersion("remediation.example.com/v1")
+	rebootCR.SetKind("RebootRemediation")
+
+	tests := []struct {
+		name         string
+		nhc          *remediationv1alpha1.NodeHealthCheck
+		wantPrevious time.Duration
+	}{
+		{
+			name:         "next remediation didn't start yet",
+			nhc:          newNHC(fence),
+			wantPrevious: 11 * time.Minute,
+		},
+		{
+			name:         "next remediation started",
+			nhc:          newNHC(fence, reboot),
+			wantPrevious: 11 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, previous := GetRemediationDuration(node, tt.nhc, rebootCR, now)
+			if current != time.Minute {
+				t.Errorf("GetRemediationDuration() current = %v, want %v", current, time.Minute)
+			}
+			if previous != tt.wantPrevious {
+				t.Errorf("GetRemediationDuration() previous = %v, want %v", previous, tt.wantPrevious)
+			}
+
+			// the escalation delay exceeds twice the timeout, the lease must not be overdue when the next step starts
+			m := &nhcLeaseManager{log: zap.New()}
+			lease := &coordv1.Lease{Spec: coordv1.LeaseSpec{AcquireTime: &metav1.MicroTime{Time: acquired}}}
+			if expiration := m.calcLeaseExpiration(lease, current, previous); !expiration.After(now) {
+				t.Errorf("lease expires at %v, before the next remediation started at %v", expiration, now)
+			}
+		})
+	}
+}
+
+func TestGetRemediationDurationWithEscalationHandshake(t *testing.T) {
+	acquired := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	timedOut := acquired.Add(time.Minute)
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+	fenceCR := &unstructured.Unstructured{}
+	fenceCR.SetAPIVersion("remediation.example.com/v1")
+	fenceCR.SetKind("FenceRemediation")
+
+	// the grace period exceeds three times the timeout
+	nhc := &remediationv1alpha1.NodeHealthCheck{
+		Spec: remediationv1alpha1.NodeHealthCheckSpec{
+			EscalatingRemediations: []remediationv1alpha1.EscalatingRemediation{{
+				RemediationTemplate: corev1.ObjectReference{APIVersion: "remediation.example.com/v1", Kind: "FenceRemediationTemplate", Name: "template"},
+				Timeout:             metav1.Duration{Duration: time.Minute},
+			}},
+			EscalationHandshake:            true,
+			EscalationHandshakeGracePeriod: &metav1.Duration{Duration: 10 * time.Minute},
+		},
+		Status: remediationv1alpha1.NodeHealthCheckStatus{
+			UnhealthyNodes: []*remediationv1alpha1.UnhealthyNode{{
+				Name: node.GetName(),
+				Remediations: []*remediationv1alpha1.Remediation{{
+					Resource: corev1.ObjectReference{APIVersion: "remediation.example.com/v1", Kind: "FenceRemediation", Name: "node"},
+					Started:  metav1.Time{Time: acquired},
+					TimedOut: &metav1.Time{Time: timedOut},
+					Phase:    remediationv1alpha1.RemediationPhaseAwaitingAcknowledgment,
+				}},
+			}},
+		},
+	}
+
+	// the lease was renewed for the last time when the remediation timed out, and is checked shortly before the
+	// grace period ends
+	current, previous := GetRemediationDuration(node, nhc, fenceCR, timedOut)
+	if want := 11 * time.Minute; current != want {
+		t.Errorf("GetRemediationDuration() current = %v, want %v", current, want)
+	}
+	now := timedOut.Add(9 * time.Minute)
+	if renewedUntil := timedOut.Add(current + LeaseBuffer); !renewedUntil.After(now) {
+		t.Errorf("lease expires at %v, before the grace period ends at %v", renewedUntil, now)
+	}
+	m := &nhcLeaseManager{log: zap.New()}
+	lease := &coordv1.Lease{Spec: coordv1.LeaseSpec{AcquireTime: &metav1.MicroTime{Time: acquired}}}
+	if expiration := m.calcLeaseExpiration(lease, current, previous); !expiration.After(now) {
+		t.Errorf("lease is overdue at %v, before the grace period ends at %v", expiration, now)
+	}
+}
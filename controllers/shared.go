@@ -7,9 +7,13 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
 )
 
 func nodeUpdateNeedsReconcile(ev event.UpdateEvent) bool {
@@ -22,9 +26,41 @@ func nodeUpdateNeedsReconcile(ev event.UpdateEvent) bool {
 	if newNode, ok = ev.ObjectNew.(*v1.Node); !ok {
 		return false
 	}
+	// allows to remediate nodes with preexisting unhealthy conditions
+	if annotations.HasRemediatePreexistingConditionAnnotation(oldNode) != annotations.HasRemediatePreexistingConditionAnnotation(newNode) {
+		return true
+	}
+	// nodes joining or leaving the selected pool, see MinSelectedNodes
+	if !labels.Equals(oldNode.GetLabels(), newNode.GetLabels()) {
+		return true
+	}
+	// unhealthy conditions can depend on taints
+	if taintsNeedReconcile(oldNode.Spec.Taints, newNode.Spec.Taints) {
+		return true
+	}
 	return conditionsNeedReconcile(oldNode.Status.Conditions, newNode.Status.Conditions)
 }
 
+func taintsNeedReconcile(oldTaints, newTaints []v1.Taint) bool {
+	if len(oldTaints) != len(newTaints) {
+		return true
+	}
+	for _, taintOld := range oldTaints {
+		taintFound := false
+		for _, taintNew := range newTaints {
+			// unhealthy taints can match values and effects as well
+			if taintOld.Key == taintNew.Key && taintOld.Value == taintNew.Value && taintOld.Effect == taintNew.Effect {
+				taintFound = true
+				break
+			}
+		}
+		if !taintFound {
+			return true
+		}
+	}
+	return false
+}
+
 func conditionsNeedReconcile(oldConditions, newConditions []v1.NodeCondition) bool {
 	// Check if the Ready condition exists on the new node.
 	// If not, the node was just created and hasn't updated its status yet
@@ -60,6 +96,23 @@ func conditionsNeedReconcile(oldConditions, newConditions []v1.NodeCondition) bo
 	return false
 }
 
+// capacityNeedsReconcile returns true when the capacity of the given resource was added, removed or changed
+func capacityNeedsReconcile(oldCapacity, newCapacity v1.ResourceList, resourceName v1.ResourceName) bool {
+	oldQuantity, oldExists := oldCapacity[resourceName]
+	newQuantity, newExists := newCapacity[resourceName]
+	if oldExists != newExists {
+		return true
+	}
+	return oldQuantity.Cmp(newQuantity) != 0
+}
+
+// annotationNeedsReconcile returns true when the annotation with the given key was added, removed or changed
+func annotationNeedsReconcile(oldAnnotations, newAnnotations map[string]string, key string) bool {
+	oldValue, oldExists := oldAnnotations[key]
+	newValue, newExists := newAnnotations[key]
+	return oldExists != newExists || oldValue != newValue
+}
+
 type ObjectWithStatus interface {
 	GetStatus() interface{}
 }
@@ -97,3 +150,18 @@ func getStatus(obj client.Object) (map[string]interface{}, error) {
 	}
 	return status, nil
 }
+
+// connectivityReportUpdateNeedsReconcile ignores updates of the probe time, only reachability changes are interesting
+func connectivityReportUpdateNeedsReconcile(ev event.UpdateEvent) bool {
+	var oldReport *remediationv1alpha1.NodeConnectivityReport
+	var newReport *remediationv1alpha1.NodeConnectivityReport
+	var ok bool
+	if oldReport, ok = ev.ObjectOld.(*remediationv1alpha1.NodeConnectivityReport); !ok {
+		return false
+	}
+	if newReport, ok = ev.ObjectNew.(*remediationv1alpha1.NodeConnectivityReport); !ok {
+		return false
+	}
+	return oldReport.Status.Reachable != newReport.Status.Reachable ||
+		!oldReport.Status.LastTransitionTime.Equal(&newReport.Status.LastTransitionTime)
+}
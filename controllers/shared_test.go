@@ -5,6 +5,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
 )
 
 var _ = Describe("Generic Reconciler Tests", func() {
@@ -141,6 +145,58 @@ var _ = Describe("Generic Reconciler Tests", func() {
 				Expect(conditionsNeedReconcile(oldConditions, newConditions)).To(BeTrue())
 			})
 		})
+
+		When("the remediate-preexisting-condition annotation was added", func() {
+			It("should request reconcile", func() {
+				conditions := []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}
+				oldNode := &v1.Node{Status: v1.NodeStatus{Conditions: conditions}}
+				newNode := &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{annotations.RemediatePreexistingConditionAnnotation: ""},
+					},
+					Status: v1.NodeStatus{Conditions: conditions},
+				}
+				Expect(nodeUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: oldNode})).To(BeFalse())
+				Expect(nodeUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode})).To(BeTrue())
+			})
+		})
+
+		When("the node's labels changed", func() {
+			It("should request reconcile", func() {
+				conditions := []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+				oldNode := &v1.Node{Status: v1.NodeStatus{Conditions: conditions}}
+				newNode := &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"node-role.kubernetes.io/worker": ""},
+					},
+					Status: v1.NodeStatus{Conditions: conditions},
+				}
+				Expect(nodeUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: newNode, ObjectNew: newNode})).To(BeFalse())
+				Expect(nodeUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode})).To(BeTrue())
+			})
+		})
+
+		When("a taint was added", func() {
+			It("should request reconcile", func() {
+				conditions := []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionUnknown}}
+				oldNode := &v1.Node{Status: v1.NodeStatus{Conditions: conditions}}
+				newNode := &v1.Node{
+					Spec:   v1.NodeSpec{Taints: []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}},
+					Status: v1.NodeStatus{Conditions: conditions},
+				}
+				Expect(nodeUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: newNode, ObjectNew: newNode})).To(BeFalse())
+				Expect(nodeUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode})).To(BeTrue())
+			})
+		})
+
+		When("the value or effect of a taint changed", func() {
+			It("should request reconcile", func() {
+				oldTaints := []v1.Taint{{Key: "hw.example.com/disk-failure", Value: "sda", Effect: v1.TaintEffectNoSchedule}}
+				Expect(taintsNeedReconcile(oldTaints, oldTaints)).To(BeFalse())
+				Expect(taintsNeedReconcile(oldTaints, []v1.Taint{{Key: "hw.example.com/disk-failure", Value: "sdb", Effect: v1.TaintEffectNoSchedule}})).To(BeTrue())
+				Expect(taintsNeedReconcile(oldTaints, []v1.Taint{{Key: "hw.example.com/disk-failure", Value: "sda", Effect: v1.TaintEffectNoExecute}})).To(BeTrue())
+			})
+		})
 	})
 
 })
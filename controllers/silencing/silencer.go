@@ -0,0 +1,258 @@
+package silencing
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+const (
+	// TokenKey is the key of the bearer token in the secret of the AlertSilencing
+	TokenKey = "token"
+	// CAKey is the key of the CA bundle in the secret of the AlertSilencing
+	CAKey = "ca.crt"
+
+	silencesPath = "/api/v2/silences"
+	silencePath  = "/api/v2/silence/"
+	createdBy    = "node-healthcheck-operator"
+
+	requestTimeout = 10 * time.Second
+)
+
+// Silencer manages the Alertmanager silences of the NHC's AlertSilencing
+type Silencer interface {
+	// Silence creates a silence of the alerts of the given node, or extends the silence with the given ID, so that
+	// it ends at the given time. It returns the ID of the silence, which can differ from the given one, because
+	// Alertmanager replaces silences on some updates.
+	Silence(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodeName, id string, endsAt time.Time) (string, error)
+	// Expire expires the silence with the given ID. Silences which don't exist anymore are ignored.
+	Expire(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, id string) error
+}
+
+// NewSilencer creates a new Silencer. The given reader is used for reading the secret of the AlertSilencing, only
+// secrets in the operator's namespace are read.
+func NewSilencer(secretReader client.Reader, log logr.Logger) Silencer {
+	return &silencer{
+		secretReader: secretReader,
+		log:          log.WithName("AlertSilencer"),
+		clients:      make(map[string]*cachedClient),
+	}
+}
+
+type cachedClient struct {
+	resourceVersion string
+	httpClient      *http.Client
+}
+
+type silencer struct {
+	secretReader client.Reader
+	log          logr.Logger
+	lock         sync.Mutex
+	// clients caches HTTP clients with the CA bundle of a secret by secret namespace/name
+	clients map[string]*cachedClient
+}
+
+var _ Silencer = &silencer{}
+
+type matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type silence struct {
+	ID        string    `json:"id,omitempty"`
+	Matchers  []matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+type silenceResponse struct {
+	SilenceID string `json:"silenceID"`
+}
+
+func (s *silencer) Silence(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodeName, id string, endsAt time.Time) (string, error) {
+	matchers, err := renderMatchers(nhc.Spec.AlertSilencing.Matchers, nodeName)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(silence{
+		ID:        id,
+		Matchers:  matchers,
+		StartsAt:  time.Now().UTC(),
+		EndsAt:    endsAt.UTC(),
+		CreatedBy: createdBy,
+		Comment:   fmt.Sprintf("Node %s is remediated by NodeHealthCheck %s", nodeName, nhc.GetName()),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal silence")
+	}
+
+	respBody, err := s.do(ctx, nhc, http.MethodPost, silencesPath, body)
+	if err != nil {
+		return "", err
+	}
+	resp := &silenceResponse{}
+	if err := json.Unmarshal(respBody, resp); err != nil || resp.SilenceID == "" {
+		return "", fmt.Errorf("unexpected response of Alertmanager: %q", string(respBody))
+	}
+	s.log.Info("silenced alerts of node", "NHC", nhc.GetName(), "node", nodeName, "silenceID", resp.SilenceID, "endsAt", endsAt)
+	return resp.SilenceID, nil
+}
+
+func (s *silencer) Expire(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, id string) error {
+	if _, err := s.do(ctx, nhc, http.MethodDelete, silencePath+url.PathEscape(id), nil); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		return err
+	}
+	s.log.Info("expired silence", "NHC", nhc.GetName(), "silenceID", id)
+	return nil
+}
+
+var errNotFound = errors.New("silence not found")
+
+// do sends a request to Alertmanager and returns the body of a successful response
+func (s *silencer) do(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, method, path string, body []byte) ([]byte, error) {
+	silencing := nhc.Spec.AlertSilencing
+	httpClient, token, err := s.newHTTPClient(ctx, silencing)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(silencing.AlertmanagerURL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Alertmanager request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to send %s request to Alertmanager", method)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read Alertmanager response")
+	}
+	if resp.StatusCode == http.StatusNotFound && method == http.MethodDelete {
+		return nil, errNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s request to Alertmanager failed with status %d: %s", method, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+// newHTTPClient returns an HTTP client which trusts the CA bundle of the AlertSilencing's secret, and its bearer token
+func (s *silencer) newHTTPClient(ctx context.Context, silencing *remediationv1alpha1.AlertSilencing) (*http.Client, string, error) {
+	if silencing.SecretRef == nil {
+		return http.DefaultClient, "", nil
+	}
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to get the operator's namespace")
+	}
+	if silencing.SecretRef.Namespace != ns {
+		return nil, "", fmt.Errorf("Alertmanager secret %s/%s isn't in the operator's namespace %s", silencing.SecretRef.Namespace, silencing.SecretRef.Name, ns)
+	}
+	secret := &corev1.Secret{}
+	if err := s.secretReader.Get(ctx, client.ObjectKey{Namespace: silencing.SecretRef.Namespace, Name: silencing.SecretRef.Name}, secret); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get Alertmanager secret %s/%s", silencing.SecretRef.Namespace, silencing.SecretRef.Name)
+	}
+	token := strings.TrimSpace(string(secret.Data[TokenKey]))
+	httpClient, err := s.getCachedHTTPClient(secret)
+	if err != nil {
+		return nil, "", err
+	}
+	return httpClient, token, nil
+}
+
+// getCachedHTTPClient returns the HTTP client for the CA bundle of the given secret. Clients are reused as long as
+// the secret doesn't change, in order to reuse their connections.
+func (s *silencer) getCachedHTTPClient(secret *corev1.Secret) (*http.Client, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := client.ObjectKeyFromObject(secret).String()
+	cached, exists := s.clients[key]
+	if exists && cached.resourceVersion == secret.GetResourceVersion() {
+		return cached.httpClient, nil
+	}
+	if exists {
+		cached.httpClient.CloseIdleConnections()
+		delete(s.clients, key)
+	}
+
+	ca, exists := secret.Data[CAKey]
+	if !exists {
+		return http.DefaultClient, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no valid certificate in key %q of Alertmanager secret %s", CAKey, key)
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		},
+	}
+	s.clients[key] = &cachedClient{
+		resourceVersion: secret.GetResourceVersion(),
+		httpClient:      httpClient,
+	}
+	return httpClient, nil
+}
+
+// renderMatchers replaces the {{.NodeName}} placeholder in the values of the given matchers
+func renderMatchers(silenceMatchers []remediationv1alpha1.SilenceMatcher, nodeName string) ([]matcher, error) {
+	var matchers []matcher
+	for _, m := range silenceMatchers {
+		tmpl, err := template.New(m.Name).Option("missingkey=error").Parse(m.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid template of silence matcher %q", m.Name)
+		}
+		value := &strings.Builder{}
+		if err := tmpl.Execute(value, struct{ NodeName string }{NodeName: nodeName}); err != nil {
+			return nil, errors.Wrapf(err, "failed to render silence matcher %q", m.Name)
+		}
+		matchers = append(matchers, matcher{
+			Name:    m.Name,
+			Value:   value.String(),
+			IsRegex: m.IsRegex,
+			IsEqual: true,
+		})
+	}
+	return matchers, nil
+}
@@ -0,0 +1,257 @@
+package silencing
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// fakeAlertmanager implements the silence endpoints of the Alertmanager v2 API
+type fakeAlertmanager struct {
+	sync.Mutex
+	token    string
+	silences map[string]silence
+	expired  []string
+	nextID   int
+	failWith int
+}
+
+func (am *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	am.Lock()
+	defer am.Unlock()
+	if am.failWith != 0 {
+		http.Error(w, "unavailable", am.failWith)
+		return
+	}
+	if am.token != "" && r.Header.Get("Authorization") != "Bearer "+am.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == silencesPath:
+		s := silence{}
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, exists := am.silences[s.ID]; s.ID != "" && !exists {
+			http.Error(w, "silence not found", http.StatusNotFound)
+			return
+		}
+		if s.ID == "" {
+			am.nextID++
+			s.ID = fmt.Sprintf("silence-%d", am.nextID)
+		}
+		am.silences[s.ID] = s
+		_ = json.NewEncoder(w).Encode(silenceResponse{SilenceID: s.ID})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, silencePath):
+		id := strings.TrimPrefix(r.URL.Path, silencePath)
+		if _, exists := am.silences[id]; !exists {
+			http.Error(w, "silence not found", http.StatusNotFound)
+			return
+		}
+		delete(am.silences, id)
+		am.expired = append(am.expired, id)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func getCachedClients(s Silencer) map[string]*cachedClient {
+	return s.(*silencer).clients
+}
+
+var _ = Describe("Alert silencer", func() {
+
+	const (
+		nodeName          = "worker-1"
+		operatorNamespace = "nhc-operator"
+	)
+
+	var (
+		am           *fakeAlertmanager
+		server       *httptest.Server
+		useTLS       bool
+		secret       *corev1.Secret
+		secretClient client.Client
+		nhc          *remediationv1alpha1.NodeHealthCheck
+		silencer     Silencer
+		endsAt       time.Time
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("DEPLOYMENT_NAMESPACE", operatorNamespace)).To(Succeed())
+		DeferCleanup(os.Unsetenv, "DEPLOYMENT_NAMESPACE")
+		am = &fakeAlertmanager{silences: make(map[string]silence)}
+		useTLS = false
+		secret = nil
+		endsAt = time.Now().Add(time.Hour).Truncate(time.Second)
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				AlertSilencing: &remediationv1alpha1.AlertSilencing{
+					Matchers: []remediationv1alpha1.SilenceMatcher{
+						{Name: "instance", Value: "{{.NodeName}}"},
+						{Name: "node", Value: "{{.NodeName}}(:.*)?", IsRegex: true},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		if useTLS {
+			server = httptest.NewTLSServer(am)
+		} else {
+			server = httptest.NewServer(am)
+		}
+		DeferCleanup(server.Close)
+		nhc.Spec.AlertSilencing.AlertmanagerURL = server.URL + "/"
+
+		builder := fake.NewClientBuilder()
+		if secret != nil {
+			if useTLS {
+				secret.Data[CAKey] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			}
+			builder.WithObjects(secret)
+			nhc.Spec.AlertSilencing.SecretRef = &corev1.SecretReference{Namespace: secret.Namespace, Name: secret.Name}
+		}
+		secretClient = builder.Build()
+		silencer = NewSilencer(secretClient, zap.New())
+	})
+
+	It("should create, extend and expire silences of the node", func() {
+		id, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(am.silences).To(HaveKey(id))
+		s := am.silences[id]
+		Expect(s.EndsAt).To(BeTemporally("==", endsAt))
+		Expect(s.CreatedBy).To(Equal(createdBy))
+		Expect(s.Comment).To(ContainSubstring(nodeName))
+		Expect(s.Matchers).To(Equal([]matcher{
+			{Name: "instance", Value: nodeName, IsEqual: true},
+			{Name: "node", Value: nodeName + "(:.*)?", IsRegex: true, IsEqual: true},
+		}))
+
+		By("extending the silence")
+		extendedID, err := silencer.Silence(context.Background(), nhc, nodeName, id, endsAt.Add(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(extendedID).To(Equal(id))
+		Expect(am.silences).To(HaveLen(1))
+		Expect(am.silences[id].EndsAt).To(BeTemporally("==", endsAt.Add(time.Hour)))
+
+		By("expiring the silence")
+		Expect(silencer.Expire(context.Background(), nhc, id)).To(Succeed())
+		Expect(am.silences).To(BeEmpty())
+		Expect(am.expired).To(ConsistOf(id))
+
+		By("ignoring silences which don't exist anymore")
+		Expect(silencer.Expire(context.Background(), nhc, id)).To(Succeed())
+	})
+
+	It("should return errors of Alertmanager", func() {
+		am.failWith = http.StatusServiceUnavailable
+		_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+		Expect(err).To(MatchError(ContainSubstring("status 503")))
+		Expect(silencer.Expire(context.Background(), nhc, "silence-1")).To(MatchError(ContainSubstring("status 503")))
+	})
+
+	It("should return an error when Alertmanager isn't reachable", func() {
+		server.Close()
+		_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+		Expect(err).To(MatchError(ContainSubstring("failed to send POST request")))
+	})
+
+	When("Alertmanager needs a token and a CA", func() {
+		BeforeEach(func() {
+			useTLS = true
+			am.token = "secret-token"
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "alertmanager"},
+				Data: map[string][]byte{
+					TokenKey: []byte(am.token + "\n"),
+				},
+			}
+		})
+
+		It("should authenticate and verify the certificate", func() {
+			id, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(am.silences).To(HaveKey(id))
+		})
+
+		It("should reuse the HTTP client until the secret changes", func() {
+			_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).ToNot(HaveOccurred())
+			clients := getCachedClients(silencer)
+			Expect(clients).To(HaveLen(1))
+			httpClient := clients[client.ObjectKeyFromObject(secret).String()].httpClient
+
+			_, err = silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clients[client.ObjectKeyFromObject(secret).String()].httpClient).To(BeIdenticalTo(httpClient))
+
+			By("updating the secret")
+			secret.Labels = map[string]string{"rotated": "true"}
+			Expect(secretClient.Update(context.Background(), secret)).To(Succeed())
+			_, err = silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clients).To(HaveLen(1))
+			Expect(clients[client.ObjectKeyFromObject(secret).String()].httpClient).ToNot(BeIdenticalTo(httpClient))
+		})
+
+		It("should fail when the secret doesn't exist", func() {
+			nhc.Spec.AlertSilencing.SecretRef.Name = "typo"
+			_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).To(MatchError(ContainSubstring("failed to get Alertmanager secret")))
+		})
+
+		It("should not read secrets outside of the operator's namespace", func() {
+			nhc.Spec.AlertSilencing.SecretRef.Namespace = "monitoring"
+			_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).To(MatchError(ContainSubstring("isn't in the operator's namespace")))
+			Expect(am.silences).To(BeEmpty())
+		})
+	})
+
+	When("the secret has an invalid CA", func() {
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "alertmanager"},
+				Data: map[string][]byte{
+					CAKey: []byte("invalid"),
+				},
+			}
+		})
+
+		It("should fail", func() {
+			_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+			Expect(err).To(MatchError(ContainSubstring("no valid certificate")))
+		})
+	})
+
+	It("should fail with an invalid matcher template", func() {
+		nhc.Spec.AlertSilencing.Matchers[0].Value = "{{.Namespace}}"
+		_, err := silencer.Silence(context.Background(), nhc, nodeName, "", endsAt)
+		Expect(err).To(MatchError(ContainSubstring("failed to render silence matcher")))
+		Expect(am.silences).To(BeEmpty())
+	})
+})
@@ -0,0 +1,13 @@
+package silencing
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSilencing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Alert Silencing Suite")
+}
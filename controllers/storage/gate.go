@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// NotEnabledError indicates that a NHC defers remediation on unsafe storage, but storage gating is not enabled
+var NotEnabledError = errors.New("storage gating is not enabled, start the operator with --enable-storage-gating")
+
+// Gate checks if the storage of a node is in a safe state for remediation
+type Gate interface {
+	// IsRemediationAllowed returns false and an explanation if the given node has unsafe storage: PersistentVolumes
+	// bound to pods on the node, which are Failed, or Released or Failed PersistentVolumes, which are still attached
+	// to the node. The given client is used for reading pods, PersistentVolumeClaims, PersistentVolumes and
+	// VolumeAttachments.
+	IsRemediationAllowed(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) (allowed bool, message string, err error)
+}
+
+// NewGate creates a new Gate. If not enabled, remediations of NHCs which defer remediation on unsafe storage are
+// never allowed, because the storage can't be checked.
+func NewGate(enabled bool, log logr.Logger) Gate {
+	return &gate{
+		enabled: enabled,
+		log:     log.WithName("StorageGate"),
+	}
+}
+
+type gate struct {
+	enabled bool
+	log     logr.Logger
+}
+
+var _ Gate = &gate{}
+
+func (g *gate) IsRemediationAllowed(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) (bool, string, error) {
+	if !nhc.Spec.DeferOnUnsafeStorage {
+		return true, "", nil
+	}
+	if !g.enabled {
+		return false, NotEnabledError.Error(), nil
+	}
+
+	unsafe := make(map[string]corev1.PersistentVolumePhase)
+	if err := g.checkPodVolumes(ctx, c, node, unsafe); err != nil {
+		return false, "", err
+	}
+	if err := g.checkVolumeAttachments(ctx, c, node, unsafe); err != nil {
+		return false, "", err
+	}
+	if len(unsafe) == 0 {
+		return true, "", nil
+	}
+
+	volumes := make([]string, 0, len(unsafe))
+	for name, phase := range unsafe {
+		volumes = append(volumes, fmt.Sprintf("%s (%s)", name, phase))
+	}
+	sort.Strings(volumes)
+	message := fmt.Sprintf("PersistentVolumes of node %s are not safe for remediation: %s", node.GetName(), strings.Join(volumes, ", "))
+	g.log.Info("unsafe storage, deferring remediation", "NHC", nhc.GetName(), "node", node.GetName(), "volumes", volumes)
+	return false, message, nil
+}
+
+// checkPodVolumes adds the Failed PersistentVolumes, which are bound to pods on the given node, to unsafe
+func (g *gate) checkPodVolumes(ctx context.Context, c client.Client, node *corev1.Node, unsafe map[string]corev1.PersistentVolumePhase) error {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods); err != nil {
+		return errors.Wrapf(err, "failed to list pods")
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != node.GetName() {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: pod.GetNamespace(), Name: volume.PersistentVolumeClaim.ClaimName}, pvc); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return errors.Wrapf(err, "failed to get PersistentVolumeClaim %s/%s", pod.GetNamespace(), volume.PersistentVolumeClaim.ClaimName)
+			}
+			if pvc.Spec.VolumeName == "" {
+				continue
+			}
+			pv, err := getPersistentVolume(ctx, c, pvc.Spec.VolumeName)
+			if err != nil {
+				return err
+			}
+			if pv != nil && pv.Status.Phase == corev1.VolumeFailed {
+				unsafe[pv.GetName()] = pv.Status.Phase
+			}
+		}
+	}
+	return nil
+}
+
+// checkVolumeAttachments adds the Released or Failed PersistentVolumes, which are still attached to the given node,
+// to unsafe
+func (g *gate) checkVolumeAttachments(ctx context.Context, c client.Client, node *corev1.Node, unsafe map[string]corev1.PersistentVolumePhase) error {
+	attachments := &storagev1.VolumeAttachmentList{}
+	if err := c.List(ctx, attachments); err != nil {
+		return errors.Wrapf(err, "failed to list VolumeAttachments")
+	}
+	for _, attachment := range attachments.Items {
+		if attachment.Spec.NodeName != node.GetName() || !attachment.Status.Attached || attachment.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		pv, err := getPersistentVolume(ctx, c, *attachment.Spec.Source.PersistentVolumeName)
+		if err != nil {
+			return err
+		}
+		if pv != nil && (pv.Status.Phase == corev1.VolumeReleased || pv.Status.Phase == corev1.VolumeFailed) {
+			unsafe[pv.GetName()] = pv.Status.Phase
+		}
+	}
+	return nil
+}
+
+// getPersistentVolume returns the PersistentVolume with the given name, or nil if it doesn't exist
+func getPersistentVolume(ctx context.Context, c client.Client, name string) (*corev1.PersistentVolume, error) {
+	pv := &corev1.PersistentVolume{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, pv); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get PersistentVolume %s", name)
+	}
+	return pv, nil
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Storage gate", func() {
+
+	const nodeName = "node"
+
+	var (
+		c       client.Client
+		g       Gate
+		nhc     *remediationv1alpha1.NodeHealthCheck
+		node    *corev1.Node
+		objects []client.Object
+		enabled bool
+	)
+
+	newPV := func(name string, phase corev1.PersistentVolumePhase) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+
+	newPodWithPVC := func(podNodeName, pvName string) []client.Object {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claim-" + pvName},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pvName},
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-" + pvName},
+			Spec: corev1.PodSpec{
+				NodeName: podNodeName,
+				Volumes: []corev1.Volume{{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.GetName()},
+					},
+				}},
+			},
+		}
+		return []client.Object{pvc, pod}
+	}
+
+	newAttachment := func(pvName string, attached bool) *storagev1.VolumeAttachment {
+		return &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "attachment-" + pvName},
+			Spec: storagev1.VolumeAttachmentSpec{
+				NodeName: nodeName,
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: pointer.String(pvName)},
+			},
+			Status: storagev1.VolumeAttachmentStatus{Attached: attached},
+		}
+	}
+
+	BeforeEach(func() {
+		enabled = true
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				DeferOnUnsafeStorage: true,
+			},
+		}
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		objects = nil
+	})
+
+	JustBeforeEach(func() {
+		c = fake.NewClientBuilder().WithObjects(objects...).Build()
+		g = NewGate(enabled, zap.New())
+	})
+
+	isAllowed := func() bool {
+		allowed, message, err := g.IsRemediationAllowed(context.Background(), c, nhc, node)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(Equal(message == ""))
+		return allowed
+	}
+
+	When("the node's volumes are bound", func() {
+		BeforeEach(func() {
+			objects = append(newPodWithPVC(nodeName, "pv"), newPV("pv", corev1.VolumeBound), newAttachment("pv", true))
+		})
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("a volume of a pod on the node failed", func() {
+		BeforeEach(func() {
+			objects = append(newPodWithPVC(nodeName, "pv"), newPV("pv", corev1.VolumeFailed))
+		})
+		It("should defer remediation", func() {
+			Expect(isAllowed()).To(BeFalse())
+		})
+
+		When("deferring on unsafe storage isn't configured", func() {
+			BeforeEach(func() {
+				nhc.Spec.DeferOnUnsafeStorage = false
+			})
+			It("should allow remediation", func() {
+				Expect(isAllowed()).To(BeTrue())
+			})
+		})
+	})
+
+	When("a volume of a pod on another node failed", func() {
+		BeforeEach(func() {
+			objects = append(newPodWithPVC("other", "pv"), newPV("pv", corev1.VolumeFailed))
+		})
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("a released volume is still attached to the node", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newPV("pv", corev1.VolumeReleased), newAttachment("pv", true)}
+		})
+		It("should defer remediation", func() {
+			Expect(isAllowed()).To(BeFalse())
+		})
+	})
+
+	When("a released volume is detached from the node", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newPV("pv", corev1.VolumeReleased), newAttachment("pv", false)}
+		})
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("storage gating is not enabled", func() {
+		BeforeEach(func() {
+			enabled = false
+		})
+		It("should defer remediation", func() {
+			allowed, message, err := g.IsRemediationAllowed(context.Background(), c, nhc, node)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+			Expect(message).To(Equal(NotEnabledError.Error()))
+		})
+	})
+})
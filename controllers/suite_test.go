@@ -53,8 +53,23 @@ import (
 
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
 	"github.com/medik8s/node-healthcheck-operator/controllers/cluster"
+	"github.com/medik8s/node-healthcheck-operator/controllers/connectivity"
+	"github.com/medik8s/node-healthcheck-operator/controllers/disruption"
+	"github.com/medik8s/node-healthcheck-operator/controllers/drain"
+	"github.com/medik8s/node-healthcheck-operator/controllers/eventrecorder"
+	"github.com/medik8s/node-healthcheck-operator/controllers/eventsink"
 	"github.com/medik8s/node-healthcheck-operator/controllers/featuregates"
+	"github.com/medik8s/node-healthcheck-operator/controllers/heartbeat"
+	"github.com/medik8s/node-healthcheck-operator/controllers/impersonation"
+	"github.com/medik8s/node-healthcheck-operator/controllers/limiter"
 	"github.com/medik8s/node-healthcheck-operator/controllers/mhc"
+	"github.com/medik8s/node-healthcheck-operator/controllers/remote"
+	"github.com/medik8s/node-healthcheck-operator/controllers/silencing"
+	"github.com/medik8s/node-healthcheck-operator/controllers/storage"
+	"github.com/medik8s/node-healthcheck-operator/controllers/surge"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utilization"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+	"github.com/medik8s/node-healthcheck-operator/controllers/verification"
 )
 
 // These tests use Ginkgo (BDD-style Go testing framework). Refer to
@@ -72,6 +87,8 @@ const (
 	InfraRemediationTemplateName      = "infra-remediation-template"
 	MultipleSupportTemplateName       = "multi-supported-template"
 	SecondMultipleSupportTemplateName = "second-multi-supported-template"
+	VerificationKind                  = "NodeVerification"
+	VerificationTemplateName          = "verification-template"
 )
 
 var (
@@ -191,6 +208,11 @@ var _ = BeforeSuite(func() {
 	secondMultiSupportTemplate.SetAnnotations(map[string]string{commonannotations.MultipleTemplatesSupportedAnnotation: "true"})
 	Expect(k8sClient.Create(context.Background(), secondMultiSupportTemplate)).To(Succeed())
 
+	Expect(k8sClient.Create(context.Background(), newTestRemediationTemplateCRD(VerificationKind))).To(Succeed())
+	Expect(k8sClient.Create(context.Background(), newTestRemediationCRD(VerificationKind))).To(Succeed())
+	time.Sleep(time.Second)
+	Expect(k8sClient.Create(context.Background(), newTestRemediationTemplateCR(VerificationKind, MachineNamespace, VerificationTemplateName))).To(Succeed())
+
 	upgradeChecker = &fakeClusterUpgradeChecker{
 		Err:       nil,
 		Upgrading: false,
@@ -220,9 +242,22 @@ var _ = BeforeSuite(func() {
 	err = (&NodeHealthCheckReconciler{
 		Client:                      k8sManager.GetClient(),
 		Log:                         k8sManager.GetLogger().WithName("test reconciler"),
-		Recorder:                    k8sManager.GetEventRecorderFor("NodeHealthCheck"),
+		Recorder:                    eventrecorder.NewRecorder(k8sManager.GetEventRecorderFor("NodeHealthCheck"), utils.PriorityEventReasons, k8sManager.GetLogger()),
 		ClusterUpgradeStatusChecker: upgradeChecker,
 		MHCChecker:                  mhcChecker,
+		RemediationLimiter:          limiter.DummyLimiter{},
+		RemoteClients:               remote.NewClientProvider(k8sManager.GetClient(), k8sManager.GetAPIReader(), false, k8sManager.GetLogger()),
+		ImpersonatingClients:        impersonation.NewClientProvider(k8sManager.GetConfig(), k8sManager.GetLogger()),
+		EventEmitter:                eventsink.DummyEmitter{},
+		SurgeGate:                   surge.NewGate(true, k8sManager.GetLogger()),
+		StorageGate:                 storage.NewGate(true, k8sManager.GetLogger()),
+		DisruptionGate:              disruption.NewGate(k8sManager.GetLogger()),
+		Verifier:                    verification.NewVerifier(true, k8sManager.GetLogger()),
+		ConnectivityChecker:         connectivity.NewChecker(true, k8sManager.GetLogger()),
+		HeartbeatChecker:            heartbeat.NewChecker(true, k8sManager.GetLogger()),
+		UtilizationChecker:          utilization.NewChecker(true, k8sManager.GetLogger()),
+		AlertSilencer:               silencing.NewSilencer(k8sManager.GetAPIReader(), k8sManager.GetLogger()),
+		Drainer:                     drain.NewDrainer(k8sManager.GetLogger()),
 		MHCEvents:                   mhcEvents,
 		OnOpenShift:                 true,
 	}).SetupWithManager(k8sManager)
@@ -0,0 +1,120 @@
+package surge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+// NotEnabledError indicates that a NHC configures a surge gate, but surge gating is not enabled
+var NotEnabledError = errors.New("MachineSet surge gating is not enabled, start the operator with --enable-machineset-surge-gating")
+
+// Gate checks if the MachineSet owning a node can tolerate losing it
+type Gate interface {
+	// IsRemediationAllowed returns false and an explanation if remediating the given node would leave its
+	// MachineSet with less ready replicas than configured in the NHC's surge gate. The given client is used for
+	// reading machines and MachineSets. Nodes without MachineSet are always allowed.
+	IsRemediationAllowed(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) (allowed bool, message string, err error)
+}
+
+// NewGate creates a new Gate. If not enabled, remediations of NHCs with surge gate are never allowed, because the
+// MachineSet might not be able to tolerate it.
+func NewGate(enabled bool, log logr.Logger) Gate {
+	return &gate{
+		enabled: enabled,
+		log:     log.WithName("SurgeGate"),
+	}
+}
+
+type gate struct {
+	enabled bool
+	log     logr.Logger
+}
+
+var _ Gate = &gate{}
+
+func (g *gate) IsRemediationAllowed(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, node *corev1.Node) (bool, string, error) {
+	if nhc.Spec.SurgeGate == nil {
+		return true, "", nil
+	}
+	if !g.enabled {
+		return false, NotEnabledError.Error(), nil
+	}
+
+	machineSet, err := g.getMachineSet(ctx, c, node)
+	if err != nil {
+		return false, "", err
+	}
+	if machineSet == nil {
+		// no MachineSet, nothing to check
+		return true, "", nil
+	}
+
+	readyAfterRemediation := machineSet.Status.ReadyReplicas
+	if isNodeReady(node) {
+		// the node is still counted as ready, but won't be after remediation
+		readyAfterRemediation--
+	}
+	minReady := nhc.Spec.SurgeGate.MinReadyReplicas
+	if readyAfterRemediation >= minReady {
+		return true, "", nil
+	}
+	message := fmt.Sprintf("MachineSet %s/%s has %d of %d replicas ready, remediating node %s would leave %d ready replicas, but at least %d are required",
+		machineSet.GetNamespace(), machineSet.GetName(), machineSet.Status.ReadyReplicas, machineSet.Status.Replicas, node.GetName(), readyAfterRemediation, minReady)
+	g.log.Info("insufficient surge capacity, deferring remediation", "NHC", nhc.GetName(), "node", node.GetName(), "MachineSet", machineSet.GetName(),
+		"replicas", machineSet.Status.Replicas, "readyReplicas", machineSet.Status.ReadyReplicas, "minReadyReplicas", minReady)
+	return false, message, nil
+}
+
+// getMachineSet returns the MachineSet owning the machine of the given node, or nil if there is none
+func (g *gate) getMachineSet(ctx context.Context, c client.Client, node *corev1.Node) (*machinev1beta1.MachineSet, error) {
+	machineNs, machineName, err := utils.GetMachineNamespaceName(node)
+	if err != nil {
+		if errors.Is(err, utils.MachineAnnotationNotFoundError) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	machine := &machinev1beta1.Machine{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: machineNs, Name: machineName}, machine); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get machine %s/%s of node %s", machineNs, machineName, node.GetName())
+	}
+
+	for _, owner := range machine.GetOwnerReferences() {
+		if owner.Kind != "MachineSet" || owner.APIVersion != machinev1beta1.GroupVersion.String() {
+			continue
+		}
+		machineSet := &machinev1beta1.MachineSet{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: machineNs, Name: owner.Name}, machineSet); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "failed to get MachineSet %s/%s of machine %s", machineNs, owner.Name, machineName)
+		}
+		return machineSet, nil
+	}
+	return nil, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
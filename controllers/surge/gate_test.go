@@ -0,0 +1,138 @@
+package surge
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Surge gate", func() {
+
+	const ns = "openshift-machine-api"
+
+	var (
+		c          client.Client
+		g          Gate
+		nhc        *remediationv1alpha1.NodeHealthCheck
+		node       *corev1.Node
+		machineSet *machinev1beta1.MachineSet
+		enabled    bool
+	)
+
+	BeforeEach(func() {
+		enabled = true
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				SurgeGate: &remediationv1alpha1.SurgeGate{MinReadyReplicas: 2},
+			},
+		}
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node",
+				Annotations: map[string]string{"machine.openshift.io/machine": ns + "/machine"},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+			},
+		}
+		machineSet = &machinev1beta1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "machineset", Namespace: ns},
+			Status: machinev1beta1.MachineSetStatus{
+				Replicas:      3,
+				ReadyReplicas: 2,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		machine := &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "machine",
+				Namespace: ns,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: machinev1beta1.GroupVersion.String(),
+					Kind:       "MachineSet",
+					Name:       machineSet.GetName(),
+				}},
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(machinev1beta1.Install(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine, machineSet).Build()
+		g = NewGate(enabled, zap.New())
+	})
+
+	isAllowed := func() bool {
+		allowed, message, err := g.IsRemediationAllowed(context.Background(), c, nhc, node)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(Equal(message == ""))
+		return allowed
+	}
+
+	When("enough replicas stay ready", func() {
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("the node is still counted as ready", func() {
+		BeforeEach(func() {
+			node.Status.Conditions[0].Status = corev1.ConditionTrue
+		})
+		It("should defer remediation", func() {
+			Expect(isAllowed()).To(BeFalse())
+		})
+	})
+
+	When("too few replicas are ready", func() {
+		BeforeEach(func() {
+			machineSet.Status.ReadyReplicas = 1
+		})
+		It("should defer remediation", func() {
+			Expect(isAllowed()).To(BeFalse())
+		})
+
+		When("no surge gate is configured", func() {
+			BeforeEach(func() {
+				nhc.Spec.SurgeGate = nil
+			})
+			It("should allow remediation", func() {
+				Expect(isAllowed()).To(BeTrue())
+			})
+		})
+	})
+
+	When("the node has no machine", func() {
+		BeforeEach(func() {
+			node.Annotations = nil
+		})
+		It("should allow remediation", func() {
+			Expect(isAllowed()).To(BeTrue())
+		})
+	})
+
+	When("surge gating is not enabled", func() {
+		BeforeEach(func() {
+			enabled = false
+		})
+		It("should defer remediation", func() {
+			allowed, message, err := g.IsRemediationAllowed(context.Background(), c, nhc, node)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+			Expect(message).To(Equal(NotEnabledError.Error()))
+		})
+	})
+})
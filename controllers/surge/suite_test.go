@@ -0,0 +1,13 @@
+package surge
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSurge(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Surge Gate Suite")
+}
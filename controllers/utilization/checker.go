@@ -0,0 +1,122 @@
+package utilization
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// NodeMetricsGVK is the kind of the node metrics served by the metrics.k8s.io API, e.g. by metrics-server
+var NodeMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}
+
+// NotEnabledError indicates that a NHC configures unhealthy utilization, but utilization checks are not enabled
+var NotEnabledError = errors.New("utilization checks are not enabled, start the operator with --enable-utilization-checks")
+
+// Utilization is the usage of node resources in percent of the node's allocatable resources, by node name
+type Utilization map[string]map[corev1.ResourceName]int64
+
+// Checker reads the utilization of nodes from the metrics.k8s.io API
+type Checker interface {
+	// IsEnabled returns if utilization checks are enabled
+	IsEnabled() bool
+	// GetUtilization returns the utilization of the resources configured in the NHC's UnhealthyUtilization for the
+	// given nodes. The result is empty when utilization checks are disabled or when the metrics.k8s.io API isn't
+	// available, and doesn't contain nodes without metrics. The given client is used for reading NodeMetrics.
+	GetUtilization(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodes []corev1.Node) (Utilization, error)
+}
+
+// NewChecker creates a new Checker. If not enabled, no utilization is reported, and so no node is considered
+// unhealthy because of its utilization.
+func NewChecker(enabled bool, log logr.Logger) Checker {
+	return &checker{
+		enabled: enabled,
+		log:     log.WithName("UtilizationChecker"),
+	}
+}
+
+type checker struct {
+	enabled bool
+	log     logr.Logger
+}
+
+var _ Checker = &checker{}
+
+func (c *checker) IsEnabled() bool {
+	return c.enabled
+}
+
+func (c *checker) GetUtilization(ctx context.Context, cl client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodes []corev1.Node) (Utilization, error) {
+	utilization := make(Utilization)
+	if len(nhc.Spec.UnhealthyUtilization) == 0 || len(nodes) == 0 {
+		return utilization, nil
+	}
+	if !c.enabled {
+		c.log.Info("ignoring unhealthy utilization", "NHC", nhc.GetName(), "reason", NotEnabledError.Error())
+		return utilization, nil
+	}
+
+	metricsList := &unstructured.UnstructuredList{}
+	metricsList.SetGroupVersionKind(NodeMetricsGVK.GroupVersion().WithKind(NodeMetricsGVK.Kind + "List"))
+	if err := cl.List(ctx, metricsList); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			c.log.Info("node metrics are not available, ignoring unhealthy utilization", "NHC", nhc.GetName(), "error", err.Error())
+			return utilization, nil
+		}
+		return nil, errors.Wrapf(err, "failed to list node metrics")
+	}
+	usages := make(map[string]map[string]interface{}, len(metricsList.Items))
+	for _, metrics := range metricsList.Items {
+		usage, found, err := unstructured.NestedMap(metrics.Object, "usage")
+		if err != nil || !found {
+			continue
+		}
+		usages[metrics.GetName()] = usage
+	}
+
+	for _, node := range nodes {
+		usage, exists := usages[node.GetName()]
+		if !exists {
+			continue
+		}
+		for _, u := range nhc.Spec.UnhealthyUtilization {
+			percent, ok := getPercent(usage, node.Status.Allocatable, u.ResourceName)
+			if !ok {
+				continue
+			}
+			if utilization[node.GetName()] == nil {
+				utilization[node.GetName()] = make(map[corev1.ResourceName]int64)
+			}
+			utilization[node.GetName()][u.ResourceName] = percent
+		}
+	}
+	return utilization, nil
+}
+
+// getPercent returns the usage of the given resource in percent of the allocatable resource, and false if either
+// is unknown
+func getPercent(usage map[string]interface{}, allocatable corev1.ResourceList, name corev1.ResourceName) (int64, bool) {
+	value, ok := usage[string(name)].(string)
+	if !ok {
+		return 0, false
+	}
+	used, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, false
+	}
+	available, exists := allocatable[name]
+	if !exists || available.MilliValue() <= 0 {
+		return 0, false
+	}
+	return used.MilliValue() * 100 / available.MilliValue(), true
+}
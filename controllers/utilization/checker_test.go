@@ -0,0 +1,128 @@
+package utilization
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Utilization checker", func() {
+
+	const nodeName = "node"
+
+	var (
+		c              client.Client
+		checker        Checker
+		nhc            *remediationv1alpha1.NodeHealthCheck
+		nodes          []corev1.Node
+		objects        []client.Object
+		enabled        bool
+		metricsServing bool
+	)
+
+	newNodeMetrics := func(name, cpu, memory string) *unstructured.Unstructured {
+		metrics := &unstructured.Unstructured{}
+		metrics.SetGroupVersionKind(NodeMetricsGVK)
+		metrics.SetName(name)
+		Expect(unstructured.SetNestedStringMap(metrics.Object, map[string]string{"cpu": cpu, "memory": memory}, "usage")).To(Succeed())
+		return metrics
+	}
+
+	BeforeEach(func() {
+		enabled = true
+		metricsServing = true
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				UnhealthyUtilization: []remediationv1alpha1.UnhealthyUtilization{
+					{ResourceName: corev1.ResourceCPU, ThresholdPercent: 90},
+					{ResourceName: corev1.ResourceMemory, ThresholdPercent: 90},
+				},
+			},
+		}
+		nodes = []corev1.Node{{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+			},
+		}}
+		objects = []client.Object{newNodeMetrics(nodeName, "3", "2Gi")}
+	})
+
+	JustBeforeEach(func() {
+		builder := fake.NewClientBuilder()
+		if metricsServing {
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(NodeMetricsGVK, meta.RESTScopeRoot)
+			builder = builder.WithRESTMapper(mapper).WithObjects(objects...)
+		}
+		c = builder.Build()
+		checker = NewChecker(enabled, zap.New())
+	})
+
+	getUtilization := func() Utilization {
+		utilization, err := checker.GetUtilization(context.Background(), c, nhc, nodes)
+		Expect(err).ToNot(HaveOccurred())
+		return utilization
+	}
+
+	It("should return the utilization in percent of allocatable", func() {
+		Expect(getUtilization()).To(Equal(Utilization{
+			nodeName: {corev1.ResourceCPU: 75, corev1.ResourceMemory: 25},
+		}))
+	})
+
+	When("only a single resource is configured", func() {
+		BeforeEach(func() {
+			nhc.Spec.UnhealthyUtilization = nhc.Spec.UnhealthyUtilization[1:]
+		})
+		It("should return the utilization of the configured resource only", func() {
+			Expect(getUtilization()).To(Equal(Utilization{
+				nodeName: {corev1.ResourceMemory: 25},
+			}))
+		})
+	})
+
+	When("a node has no metrics", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newNodeMetrics("other", "3", "2Gi")}
+		})
+		It("should not return the node", func() {
+			Expect(getUtilization()).To(BeEmpty())
+		})
+	})
+
+	When("the metrics API is not available", func() {
+		BeforeEach(func() {
+			metricsServing = false
+		})
+		It("should return no utilization", func() {
+			Expect(getUtilization()).To(BeEmpty())
+		})
+	})
+
+	When("utilization checks are not enabled", func() {
+		BeforeEach(func() {
+			enabled = false
+		})
+		It("should return no utilization", func() {
+			Expect(checker.IsEnabled()).To(BeFalse())
+			Expect(getUtilization()).To(BeEmpty())
+		})
+	})
+})
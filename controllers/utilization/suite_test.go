@@ -0,0 +1,13 @@
+package utilization
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUtilization(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Utilization Checker Suite")
+}
@@ -1,6 +1,9 @@
 package annotations
 
 import (
+	"fmt"
+	"strconv"
+
 	commonannotations "github.com/medik8s/common/pkg/annotations"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +16,35 @@ const (
 	// TemplateNameAnnotation is an annotation that will be placed on the CRs of remediatiors who support multiple templates of the same remediator.
 	// This is done because when checking for timeout CRs we need to know whether a CR was already created or not by that template.
 	TemplateNameAnnotation = "remediation.medik8s.io/template-name"
+	// SkipInlineTemplateValidationAnnotation is an annotation that can be applied to NodeHealthCheck objects to skip
+	// the webhook's check for the existence of the CRD of an inline remediation template, e.g. when the remediator
+	// will be installed later.
+	SkipInlineTemplateValidationAnnotation = "remediation.medik8s.io/skip-inline-template-validation"
+	// LogLevelAnnotation is an annotation that can be applied to NodeHealthCheck objects to lower the verbosity of the
+	// operator's logs for that NodeHealthCheck.
+	LogLevelAnnotation = "remediation.medik8s.io/log-level"
+	// NhcTimedOutAcknowledgedAnnotation is an annotation that remediators add to remediation CRs in order to
+	// acknowledge NHC's timed-out annotation, when the escalation handshake is enabled.
+	NhcTimedOutAcknowledgedAnnotation = "remediation.medik8s.io/nhc-timed-out-acknowledged"
+	// RemediatePreexistingConditionAnnotation is an annotation that can be applied to nodes in order to remediate
+	// them, although they were unhealthy already when a NodeHealthCheck with IgnorePreexistingConditions was created.
+	RemediatePreexistingConditionAnnotation = "remediation.medik8s.io/remediate-preexisting-condition"
+	// RemediationAttemptAnnotation is an annotation that NHC adds to remediation CRs, carrying the number of the
+	// remediation attempt for the node, so that remediators can e.g. pick more aggressive strategies on repeated attempts.
+	RemediationAttemptAnnotation = "remediation.medik8s.io/attempt"
+	// PreviousAttemptOutcomeAnnotation is an annotation that NHC adds to remediation CRs, carrying the outcome of
+	// the previous remediation attempt for the node, if known.
+	PreviousAttemptOutcomeAnnotation = "remediation.medik8s.io/previous-attempt-outcome"
+	// CordonedByNHCAnnotation is an annotation that NHC adds to nodes which it cordoned for draining them before
+	// remediation, so that only these nodes are uncordoned again when they are healthy.
+	CordonedByNHCAnnotation = "remediation.medik8s.io/cordoned-by-nhc"
+	// AbortAllAnnotation is an annotation that admins can apply to NodeHealthCheck objects in order to abort all
+	// of its remediations and to pause it. The value is the reason for aborting.
+	AbortAllAnnotation = "remediation.medik8s.io/abort-all"
+	// ResetNodeAnnotation is an annotation that admins can apply to NodeHealthCheck objects in order to reset the
+	// remediation state of the node with the given name, e.g. after manual repair of a node whose remediation was
+	// given up. The annotation is removed by NHC after the reset.
+	ResetNodeAnnotation = "remediation.medik8s.io/reset-node"
 )
 
 // HasMultipleTemplatesAnnotation returns true if the object has the medik8s `multiple-templates-support` annotation.
@@ -24,6 +56,59 @@ func HasMHCPausedAnnotation(o metav1.Object) bool {
 	return hasAnnotation(o, MHCPausedAnnotation)
 }
 
+// HasSkipInlineTemplateValidationAnnotation returns true if the object has the skip-inline-template-validation annotation.
+func HasSkipInlineTemplateValidationAnnotation(o metav1.Object) bool {
+	return hasAnnotation(o, SkipInlineTemplateValidationAnnotation)
+}
+
+// HasNhcTimedOutAcknowledgedAnnotation returns true if the object has the nhc-timed-out-acknowledged annotation.
+func HasNhcTimedOutAcknowledgedAnnotation(o metav1.Object) bool {
+	return hasAnnotation(o, NhcTimedOutAcknowledgedAnnotation)
+}
+
+// HasRemediatePreexistingConditionAnnotation returns true if the object has the remediate-preexisting-condition annotation.
+func HasRemediatePreexistingConditionAnnotation(o metav1.Object) bool {
+	return hasAnnotation(o, RemediatePreexistingConditionAnnotation)
+}
+
+// GetLogLevel returns the value of the log-level annotation, and whether it is set.
+func GetLogLevel(o metav1.Object) (int, bool, error) {
+	value, exists := o.GetAnnotations()[LogLevelAnnotation]
+	if !exists {
+		return 0, false, nil
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid value %q of annotation %s: %w", value, LogLevelAnnotation, err)
+	}
+	return level, true, nil
+}
+
+// GetRemediationAttempt returns the value of the attempt annotation, or 0 if it isn't set or invalid.
+func GetRemediationAttempt(o metav1.Object) int {
+	value, exists := o.GetAnnotations()[RemediationAttemptAnnotation]
+	if !exists {
+		return 0
+	}
+	attempt, err := strconv.Atoi(value)
+	if err != nil || attempt < 0 {
+		return 0
+	}
+	return attempt
+}
+
+// GetAbortAllReason returns the value of the abort-all annotation, and whether it is set.
+func GetAbortAllReason(o metav1.Object) (string, bool) {
+	reason, exists := o.GetAnnotations()[AbortAllAnnotation]
+	return reason, exists
+}
+
+// GetResetNode returns the node name of the reset-node annotation, and whether it is set.
+func GetResetNode(o metav1.Object) (string, bool) {
+	nodeName, exists := o.GetAnnotations()[ResetNodeAnnotation]
+	return nodeName, exists && nodeName != ""
+}
+
 // hasAnnotation returns true if the object has the specified annotation.
 func hasAnnotation(o metav1.Object, annotation string) bool {
 	annotations := o.GetAnnotations()
@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	"github.com/openshift/api/machine/v1beta1"
 
@@ -62,7 +63,8 @@ func isHealthy(unhealthyConditions []unhealthyCondition, nodeConditions []corev1
 			continue
 		}
 		if n.Status == c.Status {
-			if now.After(n.LastTransitionTime.Add(c.Duration.Duration)) {
+			// a zero duration matches immediately, even if the transition time is in the future because of clock skew
+			if c.Duration.Duration == 0 || now.After(n.LastTransitionTime.Add(c.Duration.Duration)) {
 				// unhealthy condition duration expired, node is unhealthy
 				return false, nil
 			} else {
@@ -75,6 +77,73 @@ func isHealthy(unhealthyConditions []unhealthyCondition, nodeConditions []corev1
 	return true, nil
 }
 
+// MatchesUnhealthyCondition checks if the node matches the given unhealthy condition, including its taint. When the
+// node has the condition's type and status, and its taint, but not for long enough yet, it returns when the condition
+// is going to match.
+func MatchesUnhealthyCondition(c remediationv1alpha1.UnhealthyCondition, node *corev1.Node, now time.Time) (bool, *time.Duration) {
+	var nodeCondition *corev1.NodeCondition
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == c.Type {
+			nodeCondition = &node.Status.Conditions[i]
+			break
+		}
+	}
+	if nodeCondition == nil || nodeCondition.Status != c.Status {
+		return false, nil
+	}
+
+	// a zero duration matches immediately, even if the transition time is in the future because of clock skew
+	var unhealthyAt time.Time
+	if c.Duration.Duration > 0 {
+		unhealthyAt = nodeCondition.LastTransitionTime.Add(c.Duration.Duration)
+	}
+	if c.TaintKey != "" {
+		taint := GetTaint(node, c.TaintKey)
+		if taint == nil {
+			return false, nil
+		}
+		// taints without timestamp match as soon as they are present
+		if c.TaintDuration != nil && c.TaintDuration.Duration > 0 && taint.TimeAdded != nil {
+			if taintUnhealthyAt := taint.TimeAdded.Add(c.TaintDuration.Duration); taintUnhealthyAt.After(unhealthyAt) {
+				unhealthyAt = taintUnhealthyAt
+			}
+		}
+	}
+	if now.After(unhealthyAt) {
+		return true, nil
+	}
+	return false, pointer.Duration(unhealthyAt.Sub(now))
+}
+
+// IsOverriddenByTaintedCondition returns true if the given unhealthy condition has no taint key, and the node has the
+// taint of another condition with the same type, status and remediation setting. The condition with the taint takes
+// precedence, so that e.g. nodes with the unreachable taint are only handled by the condition with that taint.
+func IsOverriddenByTaintedCondition(c remediationv1alpha1.UnhealthyCondition, conditions []remediationv1alpha1.UnhealthyCondition, node *corev1.Node) bool {
+	if c.TaintKey != "" {
+		return false
+	}
+	for _, other := range conditions {
+		if other.TaintKey == "" || other.Type != c.Type || other.Status != c.Status ||
+			IsRemediationEnabled(other) != IsRemediationEnabled(c) {
+			continue
+		}
+		if GetTaint(node, other.TaintKey) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTaint returns the taint of the given node with the given key, or nil if the node doesn't have it
+func GetTaint(node *corev1.Node, key string) *corev1.Taint {
+	for i := range node.Spec.Taints {
+		if node.Spec.Taints[i].Key == key {
+			return &node.Spec.Taints[i]
+		}
+	}
+	return nil
+}
+
 // IsConditionTrue return true when the conditions contain a condition of given type and reason with status true
 func IsConditionTrue(conditions []metav1.Condition, conditionType string, reason string) bool {
 	condition := meta.FindStatusCondition(conditions, conditionType)
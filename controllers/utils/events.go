@@ -1,10 +1,53 @@
 package utils
 
 const (
-	EventReasonDetectedUnhealthy  = "DetectedUnhealthy"
-	EventReasonRemediationCreated = "RemediationCreated"
-	EventReasonRemediationSkipped = "RemediationSkipped"
-	EventReasonRemediationRemoved = "RemediationRemoved"
-	EventReasonDisabled           = "Disabled"
-	EventReasonEnabled            = "Enabled"
+	EventReasonDetectedUnhealthy       = "DetectedUnhealthy"
+	EventReasonDetectedReportOnly      = "DetectedUnhealthyReportOnly"
+	EventReasonDetectedUnreachable     = "DetectedUnreachable"
+	EventReasonDetectedPreexisting     = "DetectedUnhealthyPreexisting"
+	EventReasonDetectedTooYoung        = "DetectedUnhealthyTooYoung"
+	EventReasonDetectedStaleHeartbeat  = "DetectedStaleHeartbeat"
+	EventReasonDetectedLowCapacity     = "DetectedInsufficientCapacity"
+	EventReasonDetectedHighUtilization = "DetectedHighUtilization"
+	EventReasonDetectedUnhealthyTaint  = "DetectedUnhealthyTaint"
+	EventReasonDetectedUnhealthyAnnot  = "DetectedUnhealthyAnnotation"
+	EventReasonRemediationCreated      = "RemediationCreated"
+	EventReasonRemediationSkipped      = "RemediationSkipped"
+	EventReasonRemediationRemoved      = "RemediationRemoved"
+	EventReasonRemediationCRDeleted    = "RemediationCRDeletedExternally"
+	EventReasonNotApplicable           = "RemediationNotApplicable"
+	EventReasonEscalationStartAdjusted = "EscalationStartAdjusted"
+	EventReasonTimeoutAcknowledged     = "TimeoutAcknowledged"
+	EventReasonHandshakeExpired        = "EscalationHandshakeExpired"
+	EventReasonEscalationCooldown      = "EscalationCooldown"
+	EventReasonDrainStarted            = "DrainStarted"
+	EventReasonDrainFinished           = "DrainFinished"
+	EventReasonVerificationStarted     = "VerificationStarted"
+	EventReasonVerificationSucceeded   = "VerificationSucceeded"
+	EventReasonVerificationFailed      = "VerificationFailed"
+	EventReasonRemediationExhausted    = "RemediationExhausted"
+	EventReasonNoTemplateLeft          = "NoTemplateLeft"
+	EventReasonRouteResolved           = "RemediationRouteResolved"
+	EventReasonExternalRemediationOver = "ExternalRemediationGracePeriodExpired"
+	EventReasonPDBViolationOverridden  = "PDBViolationOverridden"
+	EventReasonCircuitOpened           = "RemediatorCircuitOpened"
+	EventReasonCircuitClosed           = "RemediatorCircuitClosed"
+	EventReasonRemediatorUnavailable   = "RemediatorUnavailable"
+	EventReasonRemediatorAvailable     = "RemediatorAvailable"
+	EventReasonWaitingForCRD           = "WaitingForCRD"
+	EventReasonPoolTooSmall            = "PoolTooSmall"
+	EventReasonDisabled                = "Disabled"
+	EventReasonEnabled                 = "Enabled"
+	EventReasonAbortedAll              = "AbortedAll"
+	EventReasonNodeReset               = "NodeReset"
+	EventReasonNodeDeleted             = "NodeDeletedDuringRemediation"
 )
+
+// PriorityEventReasons are the reasons of events which are never aggregated or rate limited
+var PriorityEventReasons = []string{
+	EventReasonRemediationCreated,
+	EventReasonNoTemplateLeft,
+	EventReasonRemediationExhausted,
+	EventReasonAbortedAll,
+	EventReasonCircuitOpened,
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// levelFilterSink is a logr.LogSink which drops info messages above a max verbosity level.
+// Errors are always logged.
+type levelFilterSink struct {
+	logr.LogSink
+	maxLevel int
+}
+
+var _ logr.LogSink = &levelFilterSink{}
+var _ logr.CallDepthLogSink = &levelFilterSink{}
+
+// withMaxLevel returns a logger which only logs info messages up to the given verbosity level.
+// A negative level disables info messages completely.
+func withMaxLevel(log logr.Logger, maxLevel int) logr.Logger {
+	sink := log.GetSink()
+	if sink == nil {
+		return log
+	}
+	return log.WithSink(&levelFilterSink{LogSink: sink, maxLevel: maxLevel})
+}
+
+func (s *levelFilterSink) Enabled(level int) bool {
+	return level <= s.maxLevel && s.LogSink.Enabled(level)
+}
+
+func (s *levelFilterSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &levelFilterSink{LogSink: s.LogSink.WithValues(keysAndValues...), maxLevel: s.maxLevel}
+}
+
+func (s *levelFilterSink) WithName(name string) logr.LogSink {
+	return &levelFilterSink{LogSink: s.LogSink.WithName(name), maxLevel: s.maxLevel}
+}
+
+func (s *levelFilterSink) WithCallDepth(depth int) logr.LogSink {
+	if callDepthSink, ok := s.LogSink.(logr.CallDepthLogSink); ok {
+		return &levelFilterSink{LogSink: callDepthSink.WithCallDepth(depth), maxLevel: s.maxLevel}
+	}
+	return s
+}
@@ -49,9 +49,18 @@ func NHCByNodeMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
 		}
 
 		for _, nhc := range nhcList.Items {
-			// when node is nil, it was deleted, and we need to queue all NHCs
-			if node != nil {
-				selector, err := metav1.LabelSelectorAsSelector(&nhc.Spec.Selector)
+			// when node is nil, it was deleted, and it doesn't match any selector anymore, so queue the NHCs which
+			// track the node in their status
+			if node == nil {
+				if !isNodeInStatus(&nhc, o.GetName()) {
+					continue
+				}
+			} else if nhc.Spec.NodeName != "" {
+				if nhc.Spec.NodeName != node.GetName() || !nhc.Spec.MatchesTargetPlatform(node.GetLabels()) {
+					continue
+				}
+			} else {
+				selector, err := nhc.Spec.GetNodeSelector()
 				if err != nil {
 					logger.Error(err, "mapper: invalid node selector", "NHC name", nhc.GetName())
 					continue
@@ -68,6 +77,17 @@ func NHCByNodeMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
 	return delegate
 }
 
+// isNodeInStatus returns true if the given NHC tracks the given node as unhealthy
+func isNodeInStatus(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) bool {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == nodeName {
+			return true
+		}
+	}
+	_, inFlight := nhc.Status.InFlightRemediations[nodeName]
+	return inFlight
+}
+
 // NHCByMHCEventMapperFunc return the MHC-event-to-NHC mapper function
 func NHCByMHCEventMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
 	delegate := func(ctx context.Context, o client.Object) []reconcile.Request {
@@ -125,6 +145,12 @@ func NHCByRemediationTemplateCRMapperFunc(c client.Client, logger logr.Logger) h
 			if nhc.Spec.RemediationTemplate != nil {
 				match = templateMatches(*nhc.Spec.RemediationTemplate)
 			} else {
+				for _, template := range nhc.Spec.RemediationTemplates {
+					if templateMatches(template) {
+						match = true
+						break
+					}
+				}
 				for _, template := range nhc.Spec.EscalatingRemediations {
 					if templateMatches(template.RemediationTemplate) {
 						match = true
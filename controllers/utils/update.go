@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/medik8s/node-healthcheck-operator/metrics"
+)
+
+// UpdateWithConflictRetry gets the latest version of the given object, applies the given mutation, and updates the
+// object. On conflicts, this is retried with a fresh version of the object. The mutation needs to return false when
+// the object doesn't need to be updated, e.g. because it was updated already, so that retries don't apply changes
+// twice. Returns true if the object was updated. Conflicts are counted by object kind.
+func UpdateWithConflictRetry(ctx context.Context, c client.Client, obj client.Object, mutate func() bool) (bool, error) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+			kind = gvk.Kind
+		}
+	}
+
+	updated := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		if !mutate() {
+			return nil
+		}
+		if err := c.Update(ctx, obj); err != nil {
+			if apierrors.IsConflict(err) {
+				metrics.ObserveNodeHealthCheckUpdateConflict(kind)
+			}
+			return err
+		}
+		updated = true
+		return nil
+	})
+	return updated, err
+}
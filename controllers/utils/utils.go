@@ -2,7 +2,10 @@ package utils
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,15 +23,40 @@ import (
 	"github.com/openshift/api/machine/v1beta1"
 
 	"github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils/annotations"
 )
 
 const (
 	machineAnnotation = "machine.openshift.io/machine"
+	// Cluster API annotates nodes with the name of their machine, and with the namespace of the machine's cluster
+	capiMachineAnnotation          = "cluster.x-k8s.io/machine"
+	capiClusterNamespaceAnnotation = "cluster.x-k8s.io/cluster-namespace"
 )
 
+// CAPIMachineGVK is the GroupVersionKind of Cluster API Machines
+var CAPIMachineGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Machine"}
+
 var (
 	// DefaultRemediationDuration is used for node lease calculations for remediations without configured timeout
 	DefaultRemediationDuration = 10 * time.Minute
+	// DefaultEscalationHandshakeGracePeriod is used for the escalation handshake when no grace period is configured
+	DefaultEscalationHandshakeGracePeriod = 5 * time.Minute
+	// DefaultVerificationTimeout is used for post remediation verification when no timeout is configured
+	DefaultVerificationTimeout = 10 * time.Minute
+	// DefaultExternalRemediationGracePeriod is used for externally remediated nodes when no grace period is configured
+	DefaultExternalRemediationGracePeriod = 10 * time.Minute
+	// DefaultRemediationRecordRetention is used for the RemediatedByNHC node condition when no retention is configured
+	DefaultRemediationRecordRetention = 1 * time.Hour
+	// DefaultPreRemediationDrainTimeout is used for draining nodes before remediation when no timeout is configured
+	DefaultPreRemediationDrainTimeout = 5 * time.Minute
+	// DefaultPDBOverrideTimeout is used for PodDisruptionBudget violations when no override timeout is configured
+	DefaultPDBOverrideTimeout = 30 * time.Minute
+	// DefaultCircuitOpenDuration is used for the RemediatorCircuitBreaker when no open duration is configured
+	DefaultCircuitOpenDuration = 30 * time.Minute
+	// DefaultCircuitFailureThreshold is used for the RemediatorCircuitBreaker when no failure threshold is configured
+	DefaultCircuitFailureThreshold = 5
+	// DefaultAlertSilenceDuration is used for Alertmanager silences when no duration is configured
+	DefaultAlertSilenceDuration = 1 * time.Hour
 )
 
 // GetDeploymentNamespace returns the Namespace this operator is deployed on.
@@ -63,9 +91,18 @@ func IsOnOpenshift(config *rest.Config) (bool, error) {
 	return false, nil
 }
 
-// GetLogWithNHC return a logger with NHC namespace and name
+// GetLogWithNHC return a logger with NHC namespace and name, which respects the NHC's log-level annotation
 func GetLogWithNHC(log logr.Logger, nhc *v1alpha1.NodeHealthCheck) logr.Logger {
-	return log.WithValues("NodeHealthCheck name", nhc.Name)
+	log = log.WithValues("NodeHealthCheck name", nhc.Name)
+	level, exists, err := annotations.GetLogLevel(nhc)
+	if err != nil {
+		log.Error(err, "ignoring log level annotation")
+		return log
+	}
+	if exists {
+		log = withMaxLevel(log, level)
+	}
+	return log
 }
 
 // MinRequeueDuration returns the minimal valid requeue duration
@@ -88,6 +125,16 @@ func GetAllRemediationTemplates(healthCheck client.Object) []*v1.ObjectReference
 		if nhc.Spec.RemediationTemplate != nil {
 			return []*v1.ObjectReference{nhc.Spec.RemediationTemplate}
 		}
+		if len(nhc.Spec.RemediationTemplates) > 0 {
+			refs := make([]*v1.ObjectReference, len(nhc.Spec.RemediationTemplates))
+			for i := range nhc.Spec.RemediationTemplates {
+				refs[i] = &nhc.Spec.RemediationTemplates[i]
+			}
+			return refs
+		}
+		if nhc.Spec.InlineRemediationTemplate != nil {
+			return []*v1.ObjectReference{GetInlineRemediationTemplateRef(nhc)}
+		}
 		refs := make([]*v1.ObjectReference, len(nhc.Spec.EscalatingRemediations))
 		for i, rem := range nhc.Spec.EscalatingRemediations {
 			rem := rem
@@ -102,8 +149,21 @@ func GetAllRemediationTemplates(healthCheck client.Object) []*v1.ObjectReference
 	}
 }
 
-// GetRemediationDuration returns the expected remediation duration for the given CR, and all previous used templates
-func GetRemediationDuration(nhc *v1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured) (currentRemediationDuration, previousRemediationsDuration time.Duration) {
+// GetInlineRemediationTemplateRef returns a reference to a virtual template for the inline remediation template of the
+// given NodeHealthCheck. It doesn't exist in the cluster, but allows to handle inline templates like normal ones.
+func GetInlineRemediationTemplateRef(nhc *v1alpha1.NodeHealthCheck) *v1.ObjectReference {
+	inline := nhc.Spec.InlineRemediationTemplate
+	return &v1.ObjectReference{
+		APIVersion: inline.APIVersion,
+		Kind:       inline.Kind + "Template",
+		Namespace:  inline.Namespace,
+		Name:       nhc.GetName(),
+	}
+}
+
+// GetRemediationDuration returns the expected remediation duration for the given CR, and all previous used templates.
+// The timeouts are stretched for retried remediations, based on the given escalation start of the node.
+func GetRemediationDuration(nhc *v1alpha1.NodeHealthCheck, remediationCR *unstructured.Unstructured, escalationStart *v1alpha1.EscalationStart) (currentRemediationDuration, previousRemediationsDuration time.Duration) {
 
 	if len(nhc.Spec.EscalatingRemediations) == 0 {
 		return DefaultRemediationDuration, 0
@@ -124,18 +184,136 @@ func GetRemediationDuration(nhc *v1alpha1.NodeHealthCheck, remediationCR *unstru
 	}
 
 	// get the timeout of the current escalating remediation for currentRemediationDuration
-	currentRemediationDuration = currentRemediation.Timeout.Duration
+	currentRemediationDuration = GetEscalatingRemediationStepTimeout(nhc, *currentRemediation, GetEscalatingRemediationStepAttempt(*currentRemediation, escalationStart))
 
 	// get the sum of timeouts of all previous escalating remediations for previousRemediationsDuration
 	for _, remediation := range nhc.Spec.EscalatingRemediations {
 		if currentRemediation.Order > remediation.Order {
-			previousRemediationsDuration += remediation.Timeout.Duration
+			previousRemediationsDuration += GetEscalatingRemediationStepTimeout(nhc, remediation, GetEscalatingRemediationStepAttempt(remediation, escalationStart))
 		}
 	}
 
 	return
 }
 
+// GetEscalationHandshakeGracePeriod returns the configured grace period for the escalation handshake, or the default
+func GetEscalationHandshakeGracePeriod(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.EscalationHandshakeGracePeriod != nil {
+		return nhc.Spec.EscalationHandshakeGracePeriod.Duration
+	}
+	return DefaultEscalationHandshakeGracePeriod
+}
+
+// GetExternalRemediationGracePeriod returns the configured grace period for externally remediated nodes, or the default
+func GetExternalRemediationGracePeriod(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.ExternalRemediationGracePeriod != nil {
+		return nhc.Spec.ExternalRemediationGracePeriod.Duration
+	}
+	return DefaultExternalRemediationGracePeriod
+}
+
+// GetPreRemediationDrainTimeout returns the configured timeout of the drain before remediation, or the default
+func GetPreRemediationDrainTimeout(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.PreRemediationDrain != nil && nhc.Spec.PreRemediationDrain.Timeout != nil {
+		return nhc.Spec.PreRemediationDrain.Timeout.Duration
+	}
+	return DefaultPreRemediationDrainTimeout
+}
+
+// GetPDBOverrideTimeout returns the configured time after which remediation violates PodDisruptionBudgets, or the
+// default
+func GetPDBOverrideTimeout(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.RespectPodDisruptionBudgets != nil && nhc.Spec.RespectPodDisruptionBudgets.OverrideTimeout != nil {
+		return nhc.Spec.RespectPodDisruptionBudgets.OverrideTimeout.Duration
+	}
+	return DefaultPDBOverrideTimeout
+}
+
+// GetCircuitOpenDuration returns the configured duration of open circuits of the RemediatorCircuitBreaker, or the
+// default
+func GetCircuitOpenDuration(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.RemediatorCircuitBreaker != nil && nhc.Spec.RemediatorCircuitBreaker.OpenDuration != nil {
+		return nhc.Spec.RemediatorCircuitBreaker.OpenDuration.Duration
+	}
+	return DefaultCircuitOpenDuration
+}
+
+// GetCircuitFailureThreshold returns the configured failure threshold of the RemediatorCircuitBreaker, or the default
+func GetCircuitFailureThreshold(nhc *v1alpha1.NodeHealthCheck) int {
+	if nhc.Spec.RemediatorCircuitBreaker != nil && nhc.Spec.RemediatorCircuitBreaker.FailureThreshold > 0 {
+		return nhc.Spec.RemediatorCircuitBreaker.FailureThreshold
+	}
+	return DefaultCircuitFailureThreshold
+}
+
+// GetAlertSilenceDuration returns the configured duration of the Alertmanager silences of the AlertSilencing, or the
+// default
+func GetAlertSilenceDuration(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.AlertSilencing != nil && nhc.Spec.AlertSilencing.Duration != nil {
+		return nhc.Spec.AlertSilencing.Duration.Duration
+	}
+	return DefaultAlertSilenceDuration
+}
+
+// GetRemediationRecordRetention returns the configured retention of the RemediatedByNHC node condition, or the default
+func GetRemediationRecordRetention(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.RemediationRecordRetention != nil {
+		return nhc.Spec.RemediationRecordRetention.Duration
+	}
+	return DefaultRemediationRecordRetention
+}
+
+// IsRemediationEnabled returns true if matching the given unhealthy condition triggers remediation, which is the default
+func IsRemediationEnabled(condition v1alpha1.UnhealthyCondition) bool {
+	return condition.RemediationEnabled == nil || *condition.RemediationEnabled
+}
+
+// GetVerificationTimeout returns the configured timeout of the post remediation verification, or the default
+func GetVerificationTimeout(nhc *v1alpha1.NodeHealthCheck) time.Duration {
+	if nhc.Spec.PostRemediationVerification != nil && nhc.Spec.PostRemediationVerification.Timeout != nil {
+		return nhc.Spec.PostRemediationVerification.Timeout.Duration
+	}
+	return DefaultVerificationTimeout
+}
+
+// GetEscalatingRemediationTimeout returns the effective timeout of the given escalating remediation. That is the
+// configured timeout, or the timeout computed by the Exponential EscalationTimeoutStrategy if no timeout is configured.
+func GetEscalatingRemediationTimeout(nhc *v1alpha1.NodeHealthCheck, remediation v1alpha1.EscalatingRemediation) time.Duration {
+	return nhc.Spec.GetEscalatingRemediationTimeout(remediation)
+}
+
+// GetEscalatingRemediationStepAttempt returns the number of the consecutive attempt of the given escalating
+// remediation, based on the given escalation start of the node. It is greater than 1 when the escalation memory
+// retries the last used remediation.
+func GetEscalatingRemediationStepAttempt(remediation v1alpha1.EscalatingRemediation, escalationStart *v1alpha1.EscalationStart) int {
+	if escalationStart != nil && escalationStart.Order == remediation.Order && escalationStart.StepAttempt > 1 {
+		return escalationStart.StepAttempt
+	}
+	return 1
+}
+
+// GetEscalatingRemediationStepTimeout returns the timeout of the given escalating remediation for the given
+// consecutive attempt of it, see GetEscalatingRemediationStepAttempt. That is the effective timeout, see
+// GetEscalatingRemediationTimeout, stretched by the TimeoutMultiplier for every previous attempt, and capped by the
+// MaxTimeout.
+func GetEscalatingRemediationStepTimeout(nhc *v1alpha1.NodeHealthCheck, remediation v1alpha1.EscalatingRemediation, stepAttempt int) time.Duration {
+	timeout := GetEscalatingRemediationTimeout(nhc, remediation)
+	multiplier, err := strconv.ParseFloat(remediation.TimeoutMultiplier, 64)
+	if err == nil && multiplier > 1 && stepAttempt > 1 {
+		stretched := float64(timeout) * math.Pow(multiplier, float64(stepAttempt-1))
+		if stretched >= math.MaxInt64 {
+			// prevent overflow
+			timeout = math.MaxInt64
+		} else {
+			timeout = time.Duration(stretched)
+		}
+	}
+	if remediation.MaxTimeout != nil && timeout > remediation.MaxTimeout.Duration {
+		timeout = remediation.MaxTimeout.Duration
+	}
+	return timeout
+}
+
 // MachineAnnotationNotFoundError indicates that in GetMachineNsName the machine annotation wasn't found on the given node
 var MachineAnnotationNotFoundError = errors.New("machine annotation not found")
 
@@ -154,3 +332,95 @@ func GetMachineNamespaceName(node *v1.Node) (namespace, name string, err error)
 	}
 	return
 }
+
+// GetCAPIMachineNamespaceName returns the namespace and name of the Cluster API machine of the given Node. Returns
+// MachineAnnotationNotFoundError in case the needed annotations don't exist on the given node
+func GetCAPIMachineNamespaceName(node *v1.Node) (namespace, name string, err error) {
+	name, nameExists := node.GetAnnotations()[capiMachineAnnotation]
+	namespace, namespaceExists := node.GetAnnotations()[capiClusterNamespaceAnnotation]
+	if !nameExists || !namespaceExists {
+		return "", "", MachineAnnotationNotFoundError
+	}
+	return namespace, name, nil
+}
+
+// GetNodePriority returns the remediation priority of the given node, based on the value of the given priority label.
+// Returns 0 if the label is missing or isn't an integer.
+func GetNodePriority(node *v1.Node, priorityLabel string) int {
+	if priorityLabel == "" {
+		return 0
+	}
+	priority, err := strconv.Atoi(node.GetLabels()[priorityLabel])
+	if err != nil {
+		return 0
+	}
+	return priority
+}
+
+// SortNodesByPriority sorts the given nodes by descending remediation priority. Nodes with the same priority
+// keep their order.
+func SortNodesByPriority(nodes []v1.Node, priorityLabel string) {
+	if priorityLabel == "" {
+		return
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return GetNodePriority(&nodes[i], priorityLabel) > GetNodePriority(&nodes[j], priorityLabel)
+	})
+}
+
+// SortNodesByEligibility sorts the given nodes by descending remediation priority, and nodes with the same priority
+// by the time since when they are eligible for remediation, oldest first, and by name for equal times. This way a
+// node which waits for a free remediation slot can't be starved by nodes which became eligible later.
+func SortNodesByEligibility(nodes []v1.Node, priorityLabel string, eligibleSince func(nodeName string) time.Time) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		iSince, jSince := eligibleSince(nodes[i].GetName()), eligibleSince(nodes[j].GetName())
+		if !iSince.Equal(jSince) {
+			return iSince.Before(jSince)
+		}
+		return nodes[i].GetName() < nodes[j].GetName()
+	})
+	SortNodesByPriority(nodes, priorityLabel)
+}
+
+// GetTopologyDeferredNodes returns the names of those of the given unhealthy nodes, whose remediation needs to be
+// deferred because otherwise more than max nodes with the same value of the given topology label would be remediated
+// at the same time. The returned map contains the label value of each deferred node. Nodes for which isRemediated
+// returns true keep their slot. The remaining slots are given to the other nodes which are unhealthy for the longest
+// time, according to unhealthySince, and by name for equal times. Nodes without the label are never deferred.
+func GetTopologyDeferredNodes(nodes []v1.Node, topologyKey string, max int, isRemediated func(nodeName string) bool, unhealthySince func(node *v1.Node) time.Time) map[string]string {
+	deferred := make(map[string]string)
+	if topologyKey == "" {
+		return deferred
+	}
+
+	remediatedCount := make(map[string]int)
+	var candidates []v1.Node
+	for _, node := range nodes {
+		value, exists := node.GetLabels()[topologyKey]
+		if !exists {
+			continue
+		}
+		if isRemediated(node.GetName()) {
+			remediatedCount[value]++
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		since1, since2 := unhealthySince(&candidates[i]), unhealthySince(&candidates[j])
+		if !since1.Equal(since2) {
+			return since1.Before(since2)
+		}
+		return candidates[i].GetName() < candidates[j].GetName()
+	})
+	for _, node := range candidates {
+		value := node.GetLabels()[topologyKey]
+		if remediatedCount[value] >= max {
+			deferred[node.GetName()] = value
+			continue
+		}
+		remediatedCount[value]++
+	}
+	return deferred
+}
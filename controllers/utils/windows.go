@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/robfig/cron"
+
+	"github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// GetRemediationWindowState returns whether the given time is within one of the given remediation windows, and when
+// this changes next: the earliest end of the open windows, or the earliest start of the closed windows. Without
+// windows, remediation is allowed at any time, and the returned time is zero. Invalid windows, which are rejected by
+// the webhook, are ignored.
+func GetRemediationWindowState(windows []v1alpha1.TimeWindow, now time.Time) (open bool, nextChange time.Time) {
+	validWindows := 0
+	for _, window := range windows {
+		start, startErr := cron.ParseStandard(window.Start)
+		end, endErr := cron.ParseStandard(window.End)
+		location, locationErr := time.LoadLocation(window.TimeZone)
+		if startErr != nil || endErr != nil || locationErr != nil {
+			continue
+		}
+		nextStart, nextEnd := start.Next(now.In(location)), end.Next(now.In(location))
+		if nextStart.IsZero() || nextEnd.IsZero() {
+			// the schedule never matches
+			continue
+		}
+		validWindows++
+
+		// the window is open when it closes before it opens again
+		windowOpen := nextEnd.Before(nextStart)
+		windowChange := nextStart
+		if windowOpen {
+			windowChange = nextEnd
+		}
+		if windowOpen && !open {
+			open, nextChange = true, windowChange
+		} else if windowOpen == open && (nextChange.IsZero() || windowChange.Before(nextChange)) {
+			nextChange = windowChange
+		}
+	}
+	if validWindows == 0 {
+		return true, time.Time{}
+	}
+	return open, nextChange
+}
@@ -0,0 +1,13 @@
+package verification
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestVerification(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Verification Suite")
+}
@@ -0,0 +1,269 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	commonannotations "github.com/medik8s/common/pkg/annotations"
+	commonconditions "github.com/medik8s/common/pkg/conditions"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+)
+
+const templateSuffix = "Template"
+
+// NotEnabledError indicates that a NHC configures post remediation verification, but verification is not enabled
+var NotEnabledError = errors.New("post remediation verification is not enabled, start the operator with --enable-post-remediation-verification")
+
+// Verifier manages the verification CRs of remediated nodes
+type Verifier interface {
+	// Verify ensures that a verification CR exists for the given node, if the NHC configures a verification template,
+	// checks the node's MinReadyTime and RequiredPods, and returns the current state of the verification.
+	// Returns nil if the NHC doesn't configure verification, or if verification is not enabled.
+	// The given client is used for reading the verification template, for managing verification CRs, and for reading
+	// the node and its pods.
+	Verify(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, now time.Time) (*remediationv1alpha1.Verification, error)
+	// CleanUp deletes the given verification CR, if it still exists
+	CleanUp(ctx context.Context, c client.Client, verification *remediationv1alpha1.Verification) error
+}
+
+// NewVerifier creates a new Verifier. If not enabled, remediated nodes are not verified.
+func NewVerifier(enabled bool, log logr.Logger) Verifier {
+	return &verifier{
+		enabled: enabled,
+		log:     log.WithName("Verifier"),
+	}
+}
+
+type verifier struct {
+	enabled bool
+	log     logr.Logger
+}
+
+var _ Verifier = &verifier{}
+
+func (v *verifier) Verify(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, now time.Time) (*remediationv1alpha1.Verification, error) {
+	if nhc.Spec.PostRemediationVerification == nil {
+		return nil, nil
+	}
+	if !v.enabled {
+		v.log.Info("skipping post remediation verification", "NHC", nhc.GetName(), "node", nodeName, "reason", NotEnabledError.Error())
+		return nil, nil
+	}
+
+	verification := &remediationv1alpha1.Verification{
+		Started: metav1.Time{Time: now},
+		Phase:   remediationv1alpha1.VerificationPhaseVerifying,
+	}
+	// without verification CR, continue an ongoing verification
+	if previous := resources.GetStatusVerification(nodeName, nhc); previous != nil && previous.Resource.Name == "" &&
+		previous.Phase == remediationv1alpha1.VerificationPhaseVerifying {
+		verification.Started = previous.Started
+	}
+
+	crSucceeded := true
+	if nhc.Spec.PostRemediationVerification.VerificationTemplate != (corev1.ObjectReference{}) {
+		verificationCR, err := v.getOrCreateVerificationCR(ctx, c, nhc, nodeName, now)
+		if err != nil {
+			return nil, err
+		}
+		verification.Resource = corev1.ObjectReference{
+			Kind:       verificationCR.GetKind(),
+			Namespace:  verificationCR.GetNamespace(),
+			Name:       verificationCR.GetName(),
+			UID:        verificationCR.GetUID(),
+			APIVersion: verificationCR.GetAPIVersion(),
+		}
+		verification.Started = verificationCR.GetCreationTimestamp()
+
+		status, message := getSucceededCondition(verificationCR)
+		if status == metav1.ConditionFalse {
+			verification.Phase = remediationv1alpha1.VerificationPhaseFailed
+			verification.Message = message
+			return verification, nil
+		}
+		crSucceeded = status == metav1.ConditionTrue
+		verification.Message = message
+	}
+
+	checksSucceeded, checksMessage, err := v.checkNode(ctx, c, nhc, nodeName, now)
+	if err != nil {
+		return nil, err
+	}
+	if crSucceeded && checksSucceeded {
+		verification.Phase = remediationv1alpha1.VerificationPhaseSucceeded
+		return verification, nil
+	}
+	if checksMessage != "" {
+		verification.Message = checksMessage
+	}
+	if timeout := utils.GetVerificationTimeout(nhc); now.After(verification.Started.Add(timeout)) {
+		verification.Phase = remediationv1alpha1.VerificationPhaseFailed
+		verification.Message = fmt.Sprintf("verification didn't finish within %s", timeout)
+		if checksMessage != "" {
+			verification.Message = fmt.Sprintf("%s: %s", verification.Message, checksMessage)
+		}
+	}
+	return verification, nil
+}
+
+// checkNode checks the MinReadyTime and RequiredPods of the given node. When a check didn't succeed yet, the returned
+// message explains what verification is waiting for.
+func (v *verifier) checkNode(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, now time.Time) (bool, string, error) {
+	config := nhc.Spec.PostRemediationVerification
+	if config.MinReadyTime == nil && len(config.RequiredPods) == 0 {
+		return true, "", nil
+	}
+
+	node := &corev1.Node{}
+	if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+		return false, "", errors.Wrapf(err, "failed to get node %s", nodeName)
+	}
+	var ready *corev1.NodeCondition
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == corev1.NodeReady {
+			ready = &node.Status.Conditions[i]
+		}
+	}
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return false, "node is not Ready", nil
+	}
+	if config.MinReadyTime != nil {
+		if readySince := ready.LastTransitionTime.Time; now.Sub(readySince) < config.MinReadyTime.Duration {
+			return false, fmt.Sprintf("node is Ready since %s, for less than %s", readySince.UTC().Format(time.RFC3339), config.MinReadyTime.Duration), nil
+		}
+	}
+
+	for _, requiredPods := range config.RequiredPods {
+		selector, err := metav1.LabelSelectorAsSelector(&requiredPods.Selector)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "invalid selector of required pods in namespace %s", requiredPods.Namespace)
+		}
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, client.InNamespace(requiredPods.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return false, "", errors.Wrapf(err, "failed to list required pods in namespace %s", requiredPods.Namespace)
+		}
+		if !hasReadyPodOnNode(pods.Items, nodeName) {
+			return false, fmt.Sprintf("no Ready pod matching %q in namespace %s", selector.String(), requiredPods.Namespace), nil
+		}
+	}
+	return true, "", nil
+}
+
+// hasReadyPodOnNode returns true if one of the given pods runs on the given node and is Ready
+func hasReadyPodOnNode(pods []corev1.Pod, nodeName string) bool {
+	for _, pod := range pods {
+		if pod.Spec.NodeName != nodeName || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *verifier) CleanUp(ctx context.Context, c client.Client, verification *remediationv1alpha1.Verification) error {
+	if verification.Resource.Name == "" {
+		// verification didn't use a verification CR
+		return nil
+	}
+	verificationCR := &unstructured.Unstructured{}
+	verificationCR.SetGroupVersionKind(verification.Resource.GroupVersionKind())
+	verificationCR.SetNamespace(verification.Resource.Namespace)
+	verificationCR.SetName(verification.Resource.Name)
+	if err := c.Delete(ctx, verificationCR); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete verification CR %s/%s", verification.Resource.Namespace, verification.Resource.Name)
+	}
+	return nil
+}
+
+func (v *verifier) getOrCreateVerificationCR(ctx context.Context, c client.Client, nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, now time.Time) (*unstructured.Unstructured, error) {
+	templateRef := nhc.Spec.PostRemediationVerification.VerificationTemplate
+	templateGVK := templateRef.GroupVersionKind()
+
+	verificationCR := &unstructured.Unstructured{}
+	verificationCR.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   templateGVK.Group,
+		Version: templateGVK.Version,
+		Kind:    strings.TrimSuffix(templateGVK.Kind, templateSuffix),
+	})
+	verificationCR.SetNamespace(templateRef.Namespace)
+	verificationCR.SetName(nodeName)
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(verificationCR), verificationCR); err == nil {
+		return verificationCR, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get verification CR %s/%s", verificationCR.GetNamespace(), verificationCR.GetName())
+	}
+
+	template := &unstructured.Unstructured{}
+	template.SetGroupVersionKind(templateGVK)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: templateRef.Namespace, Name: templateRef.Name}, template); err != nil {
+		return nil, errors.Wrapf(err, "failed to get verification template %s/%s", templateRef.Namespace, templateRef.Name)
+	}
+	templateSpec, found, err := unstructured.NestedMap(template.Object, "spec", "template", "spec")
+	if !found || err != nil {
+		return nil, fmt.Errorf("invalid verification template %s/%s, didn't find spec.template.spec", templateRef.Namespace, templateRef.Name)
+	}
+	if err := unstructured.SetNestedMap(verificationCR.Object, templateSpec, "spec"); err != nil {
+		return nil, errors.Wrapf(err, "failed to set spec of verification CR")
+	}
+	verificationCR.SetCreationTimestamp(metav1.Time{Time: now})
+	verificationCR.SetAnnotations(map[string]string{commonannotations.NodeNameAnnotation: nodeName})
+	verificationCR.SetLabels(map[string]string{
+		"app.kubernetes.io/part-of": "node-healthcheck-controller",
+	})
+	verificationCR.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: remediationv1alpha1.GroupVersion.String(),
+		Kind:       "NodeHealthCheck",
+		Name:       nhc.GetName(),
+		UID:        nhc.GetUID(),
+		Controller: pointer.Bool(false),
+	}})
+
+	v.log.Info("Creating a verification CR", "NHC", nhc.GetName(), "node", nodeName, "CR kind", verificationCR.GetKind(), "namespace", verificationCR.GetNamespace())
+	if err := c.Create(ctx, verificationCR); err != nil {
+		return nil, errors.Wrapf(err, "failed to create verification CR %s/%s", verificationCR.GetNamespace(), verificationCR.GetName())
+	}
+	return verificationCR, nil
+}
+
+// getSucceededCondition returns the status and message of the Succeeded condition of the given verification CR,
+// or an empty status if there is none
+func getSucceededCondition(verificationCR *unstructured.Unstructured) (metav1.ConditionStatus, string) {
+	conditions, found, _ := unstructured.NestedSlice(verificationCR.Object, "status", "conditions")
+	if !found {
+		return "", ""
+	}
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condType, _, _ := unstructured.NestedString(condition, "type"); condType != commonconditions.SucceededType {
+			continue
+		}
+		status, _, _ := unstructured.NestedString(condition, "status")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		return metav1.ConditionStatus(status), message
+	}
+	return "", ""
+}
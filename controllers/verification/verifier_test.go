@@ -0,0 +1,266 @@
+package verification
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Verifier", func() {
+
+	const (
+		ns       = "default"
+		nodeName = "node"
+	)
+
+	var (
+		c        client.Client
+		v        Verifier
+		nhc      *remediationv1alpha1.NodeHealthCheck
+		template *unstructured.Unstructured
+		objects  []client.Object
+		enabled  bool
+	)
+
+	verificationGVK := schema.GroupVersionKind{Group: "verification.example.com", Version: "v1", Kind: "WorkloadCheck"}
+
+	BeforeEach(func() {
+		enabled = true
+		objects = nil
+		nhc = &remediationv1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "nhc", UID: "1234"},
+			Spec: remediationv1alpha1.NodeHealthCheckSpec{
+				PostRemediationVerification: &remediationv1alpha1.PostRemediationVerification{
+					VerificationTemplate: corev1.ObjectReference{
+						APIVersion: "verification.example.com/v1",
+						Kind:       "WorkloadCheckTemplate",
+						Namespace:  ns,
+						Name:       "workloads",
+					},
+				},
+			},
+		}
+		template = &unstructured.Unstructured{}
+		template.SetAPIVersion("verification.example.com/v1")
+		template.SetKind("WorkloadCheckTemplate")
+		template.SetNamespace(ns)
+		template.SetName("workloads")
+		Expect(unstructured.SetNestedMap(template.Object, map[string]interface{}{"minReadyPods": "all"}, "spec", "template", "spec")).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		c = fake.NewClientBuilder().WithObjects(append(objects, template)...).Build()
+		v = NewVerifier(enabled, zap.New())
+	})
+
+	getVerificationCR := func() *unstructured.Unstructured {
+		cr := &unstructured.Unstructured{}
+		cr.SetGroupVersionKind(verificationGVK)
+		Expect(c.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: nodeName}, cr)).To(Succeed())
+		return cr
+	}
+
+	setSucceeded := func(status metav1.ConditionStatus) {
+		cr := getVerificationCR()
+		Expect(unstructured.SetNestedSlice(cr.Object, []interface{}{
+			map[string]interface{}{"type": "Succeeded", "status": string(status), "message": "checked workloads"},
+		}, "status", "conditions")).To(Succeed())
+		Expect(c.Update(context.Background(), cr)).To(Succeed())
+	}
+
+	verify := func(now time.Time) *remediationv1alpha1.Verification {
+		verification, err := v.Verify(context.Background(), c, nhc, nodeName, now)
+		Expect(err).ToNot(HaveOccurred())
+		return verification
+	}
+
+	It("should create the verification CR from the template", func() {
+		verification := verify(time.Now())
+		Expect(verification).ToNot(BeNil())
+		Expect(verification.Phase).To(Equal(remediationv1alpha1.VerificationPhaseVerifying))
+		Expect(verification.Resource.GroupVersionKind()).To(Equal(verificationGVK))
+
+		cr := getVerificationCR()
+		Expect(cr.Object["spec"]).To(HaveKeyWithValue("minReadyPods", "all"))
+		Expect(cr.GetOwnerReferences()).To(HaveLen(1))
+		Expect(cr.GetOwnerReferences()[0].UID).To(Equal(nhc.GetUID()))
+	})
+
+	It("should report success", func() {
+		verify(time.Now())
+		setSucceeded(metav1.ConditionTrue)
+		verification := verify(time.Now())
+		Expect(verification.Phase).To(Equal(remediationv1alpha1.VerificationPhaseSucceeded))
+		Expect(verification.Message).To(Equal("checked workloads"))
+	})
+
+	It("should report failure", func() {
+		verify(time.Now())
+		setSucceeded(metav1.ConditionFalse)
+		Expect(verify(time.Now()).Phase).To(Equal(remediationv1alpha1.VerificationPhaseFailed))
+	})
+
+	It("should fail after the timeout", func() {
+		started := verify(time.Now()).Started
+		Expect(verify(started.Add(5 * time.Minute)).Phase).To(Equal(remediationv1alpha1.VerificationPhaseVerifying))
+		Expect(verify(started.Add(11 * time.Minute)).Phase).To(Equal(remediationv1alpha1.VerificationPhaseFailed))
+	})
+
+	It("should delete the verification CR on cleanup", func() {
+		verification := verify(time.Now())
+		Expect(v.CleanUp(context.Background(), c, verification)).To(Succeed())
+		cr := &unstructured.Unstructured{}
+		cr.SetGroupVersionKind(verificationGVK)
+		err := c.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: nodeName}, cr)
+		Expect(err).To(HaveOccurred())
+		Expect(v.CleanUp(context.Background(), c, verification)).To(Succeed())
+	})
+
+	Context("with node checks", func() {
+		var (
+			node    *corev1.Node
+			readyAt time.Time
+		)
+
+		newPod := func(name, podNodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system", Labels: map[string]string{"app": "cni"}},
+				Spec:       corev1.PodSpec{NodeName: podNodeName},
+				Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: ready},
+				}},
+			}
+		}
+
+		BeforeEach(func() {
+			readyAt = time.Now().Add(-time.Minute).Truncate(time.Second)
+			node = &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+				Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Time{Time: readyAt}},
+				}},
+			}
+			objects = append(objects, node)
+			nhc.Spec.PostRemediationVerification.VerificationTemplate = corev1.ObjectReference{}
+		})
+
+		When("min ready time is configured", func() {
+			BeforeEach(func() {
+				nhc.Spec.PostRemediationVerification.MinReadyTime = &metav1.Duration{Duration: 2 * time.Minute}
+			})
+
+			It("should succeed when the node is Ready for long enough", func() {
+				verification := verify(readyAt.Add(time.Minute))
+				Expect(verification.Phase).To(Equal(remediationv1alpha1.VerificationPhaseVerifying))
+				Expect(verification.Resource.Name).To(BeEmpty())
+				Expect(verification.Message).To(ContainSubstring("for less than 2m0s"))
+
+				Expect(verify(readyAt.Add(3 * time.Minute)).Phase).To(Equal(remediationv1alpha1.VerificationPhaseSucceeded))
+			})
+
+			It("should keep the start time of the ongoing verification", func() {
+				started := readyAt.Add(time.Minute)
+				verification := verify(started)
+				nhc.Status.UnhealthyNodes = []*remediationv1alpha1.UnhealthyNode{{Name: nodeName, Verification: verification}}
+				Expect(verify(started.Add(time.Second)).Started.Time).To(Equal(started))
+			})
+
+			It("should not create a verification CR", func() {
+				verify(time.Now())
+				list := &unstructured.UnstructuredList{}
+				list.SetGroupVersionKind(verificationGVK.GroupVersion().WithKind(verificationGVK.Kind + "List"))
+				Expect(c.List(context.Background(), list)).To(Succeed())
+				Expect(list.Items).To(BeEmpty())
+			})
+		})
+
+		When("required pods are configured", func() {
+			BeforeEach(func() {
+				nhc.Spec.PostRemediationVerification.RequiredPods = []remediationv1alpha1.RequiredPods{{
+					Namespace: "kube-system",
+					Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "cni"}},
+				}}
+			})
+
+			When("there is no Ready pod on the node", func() {
+				BeforeEach(func() {
+					objects = append(objects,
+						newPod("cni-node", nodeName, corev1.ConditionFalse),
+						newPod("cni-other", "other-node", corev1.ConditionTrue),
+					)
+				})
+				It("should keep verifying, and fail after the timeout", func() {
+					now := time.Now()
+					verification := verify(now)
+					Expect(verification.Phase).To(Equal(remediationv1alpha1.VerificationPhaseVerifying))
+					Expect(verification.Message).To(ContainSubstring("no Ready pod matching \"app=cni\" in namespace kube-system"))
+
+					nhc.Status.UnhealthyNodes = []*remediationv1alpha1.UnhealthyNode{{Name: nodeName, Verification: verification}}
+					verification = verify(now.Add(11 * time.Minute))
+					Expect(verification.Phase).To(Equal(remediationv1alpha1.VerificationPhaseFailed))
+					Expect(verification.Message).To(ContainSubstring("no Ready pod"))
+				})
+			})
+
+			When("there is a Ready pod on the node", func() {
+				BeforeEach(func() {
+					objects = append(objects, newPod("cni-node", nodeName, corev1.ConditionTrue))
+				})
+				It("should succeed", func() {
+					Expect(verify(time.Now()).Phase).To(Equal(remediationv1alpha1.VerificationPhaseSucceeded))
+				})
+			})
+
+			When("the node is not Ready", func() {
+				BeforeEach(func() {
+					node.Status.Conditions[0].Status = corev1.ConditionFalse
+					objects = append(objects, newPod("cni-node", nodeName, corev1.ConditionTrue))
+				})
+				It("should keep verifying", func() {
+					verification := verify(time.Now())
+					Expect(verification.Phase).To(Equal(remediationv1alpha1.VerificationPhaseVerifying))
+					Expect(verification.Message).To(Equal("node is not Ready"))
+				})
+			})
+		})
+
+		When("a verification template is configured as well", func() {
+			BeforeEach(func() {
+				nhc.Spec.PostRemediationVerification.VerificationTemplate = corev1.ObjectReference{
+					APIVersion: "verification.example.com/v1",
+					Kind:       "WorkloadCheckTemplate",
+					Namespace:  ns,
+					Name:       "workloads",
+				}
+				nhc.Spec.PostRemediationVerification.MinReadyTime = &metav1.Duration{Duration: 2 * time.Minute}
+			})
+			It("should need both to succeed", func() {
+				verify(readyAt)
+				setSucceeded(metav1.ConditionTrue)
+				Expect(verify(readyAt.Add(time.Minute)).Phase).To(Equal(remediationv1alpha1.VerificationPhaseVerifying))
+				Expect(verify(readyAt.Add(3 * time.Minute)).Phase).To(Equal(remediationv1alpha1.VerificationPhaseSucceeded))
+			})
+		})
+	})
+
+	When("verification is not enabled", func() {
+		BeforeEach(func() {
+			enabled = false
+		})
+		It("should skip verification", func() {
+			Expect(verify(time.Now())).To(BeNil())
+		})
+	})
+})
@@ -16,3 +16,4 @@ LDFLAGS+="-X github.com/medik8s/node-healthcheck-operator/version.Version=${VERS
 LDFLAGS+="-X github.com/medik8s/node-healthcheck-operator/version.GitCommit=${COMMIT} "
 LDFLAGS+="-X github.com/medik8s/node-healthcheck-operator/version.BuildDate=${BUILD_DATE} "
 GOFLAGS=-mod=vendor CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -ldflags="${LDFLAGS}" -o bin/manager main.go
+GOFLAGS=-mod=vendor CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -ldflags="${LDFLAGS}" -o bin/nhcctl cmd/nhcctl/main.go
@@ -23,6 +23,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
+	// Embed the time zone database, for evaluating the time zones of remediation windows in minimal images
+	_ "time/tzdata"
 
 	// +kubebuilder:scaffold:imports
 	"github.com/go-logr/logr"
@@ -31,11 +34,13 @@ import (
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -49,10 +54,26 @@ import (
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
 	"github.com/medik8s/node-healthcheck-operator/controllers"
 	"github.com/medik8s/node-healthcheck-operator/controllers/cluster"
+	"github.com/medik8s/node-healthcheck-operator/controllers/connectivity"
+	"github.com/medik8s/node-healthcheck-operator/controllers/defaultnhc"
+	"github.com/medik8s/node-healthcheck-operator/controllers/disruption"
+	"github.com/medik8s/node-healthcheck-operator/controllers/drain"
+	"github.com/medik8s/node-healthcheck-operator/controllers/eventrecorder"
+	"github.com/medik8s/node-healthcheck-operator/controllers/eventsink"
 	"github.com/medik8s/node-healthcheck-operator/controllers/featuregates"
+	"github.com/medik8s/node-healthcheck-operator/controllers/heartbeat"
+	"github.com/medik8s/node-healthcheck-operator/controllers/impersonation"
 	"github.com/medik8s/node-healthcheck-operator/controllers/initializer"
+	"github.com/medik8s/node-healthcheck-operator/controllers/limiter"
 	"github.com/medik8s/node-healthcheck-operator/controllers/mhc"
+	"github.com/medik8s/node-healthcheck-operator/controllers/remote"
+	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
+	"github.com/medik8s/node-healthcheck-operator/controllers/silencing"
+	"github.com/medik8s/node-healthcheck-operator/controllers/storage"
+	"github.com/medik8s/node-healthcheck-operator/controllers/surge"
+	"github.com/medik8s/node-healthcheck-operator/controllers/utilization"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
+	"github.com/medik8s/node-healthcheck-operator/controllers/verification"
 	"github.com/medik8s/node-healthcheck-operator/metrics"
 	"github.com/medik8s/node-healthcheck-operator/version"
 )
@@ -85,12 +106,75 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var enableHTTP2 bool
+	var maxClusterRemediations int
+	var enableRemoteClusters bool
+	var cloudEventsSink string
+	var enableMachineSetSurgeGating bool
+	var enableStorageGating bool
+	var disableInFlightRemediationsStatus bool
+	var plainTimedOutAnnotation bool
+	var enablePostRemediationVerification bool
+	var enableConnectivityReports bool
+	var enableHeartbeatSources bool
+	var enableUtilizationChecks bool
+	var createDefaultNHC bool
+	var generateRemediationCRNames bool
+	var crdWaitTimeout time.Duration
+	var lastReconcileTimeInterval time.Duration
+	var remediationCreationConcurrency int
+	var managementKubeconfig string
+	var createRemediationsInManagementCluster bool
+	var defaultNHCTemplate defaultnhc.TemplateConfig
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false, "If HTTP/2 should be enabled for the metrics and webhook servers.")
+	flag.IntVar(&maxClusterRemediations, "max-cluster-remediations", 0,
+		"The maximum number of simultaneous remediations across all NodeHealthChecks. 0 means unlimited.")
+	flag.BoolVar(&enableRemoteClusters, "enable-remote-clusters", false,
+		"If NodeHealthChecks are allowed to observe and remediate nodes of remote clusters, using a kubeconfig secret.")
+	flag.StringVar(&cloudEventsSink, "cloudevents-sink", "",
+		"The URI of a sink which receives remediation lifecycle events in CloudEvents format. Empty means disabled.")
+	flag.BoolVar(&enableMachineSetSurgeGating, "enable-machineset-surge-gating", false,
+		"If NodeHealthChecks are allowed to defer remediation based on the ready replicas of the nodes' MachineSets. Requires the OpenShift Machine API.")
+	flag.BoolVar(&enableStorageGating, "enable-storage-gating", false,
+		"If NodeHealthChecks are allowed to defer remediation of nodes with unsafe PersistentVolumes. Requires watching PersistentVolumes, PersistentVolumeClaims and VolumeAttachments.")
+	flag.BoolVar(&disableInFlightRemediationsStatus, "disable-inflight-remediations-status", false,
+		"If the deprecated status.inFlightRemediations field of NodeHealthChecks should not be written anymore. Use status.unhealthyNodes instead.")
+	flag.BoolVar(&plainTimedOutAnnotation, "plain-timed-out-annotation", false,
+		"If the remediation.medik8s.io/nhc-timed-out annotation of remediation CRs should only contain the timestamp, for remediators which don't support its structured form with the reason.")
+	flag.BoolVar(&enablePostRemediationVerification, "enable-post-remediation-verification", false,
+		"If NodeHealthChecks are allowed to verify remediated nodes, with a verification CR or with checks of the node and its pods, before considering them as healthy.")
+	flag.BoolVar(&enableConnectivityReports, "enable-connectivity-reports", false,
+		"If NodeHealthChecks are allowed to consider nodes as unhealthy, which are reported as unreachable by their NodeConnectivityReport.")
+	flag.BoolVar(&enableHeartbeatSources, "enable-heartbeat-sources", false,
+		"If NodeHealthChecks are allowed to consider nodes as unhealthy, whose heartbeat object of a custom node agent is stale.")
+	flag.BoolVar(&enableUtilizationChecks, "enable-utilization-checks", false,
+		"If NodeHealthChecks are allowed to consider nodes as unhealthy, whose cpu or memory utilization is above a threshold. Requires the metrics.k8s.io API, e.g. served by metrics-server.")
+	flag.BoolVar(&generateRemediationCRNames, "generate-remediation-cr-names", false,
+		"If remediation CRs should be created with a generated name, prefixed with the node name, instead of the node name. "+
+			"Avoids conflicts with leftover remediation CRs of earlier remediations.")
+	flag.DurationVar(&crdWaitTimeout, "remediation-crd-wait-timeout", 5*time.Minute,
+		"The time to wait for the CRD of a remediation template to be established, before a NodeHealthCheck is disabled. 0 disables waiting.")
+	flag.DurationVar(&lastReconcileTimeInterval, "last-reconcile-time-interval", 5*time.Minute,
+		"The maximum age of the lastReconcileTime status field of NodeHealthChecks before a successful reconcile refreshes it. 0 disables the field.")
+	flag.IntVar(&remediationCreationConcurrency, "remediation-creation-concurrency", 1,
+		"The maximum number of remediation CRs a NodeHealthCheck creates in parallel, e.g. when many nodes get unhealthy at once. 1 creates them one after another.")
+	flag.StringVar(&managementKubeconfig, "management-kubeconfig", "",
+		"The path of a kubeconfig of the management cluster of hosted control planes, e.g. HyperShift, where the Machines of the nodes live. "+
+			"Usually mounted from a secret. Empty when Machines live in the same cluster as the nodes.")
+	flag.BoolVar(&createRemediationsInManagementCluster, "create-remediations-in-management-cluster", false,
+		"If remediation templates and CRs live in the management cluster configured by --management-kubeconfig, instead of in the cluster of the nodes.")
+	flag.BoolVar(&createDefaultNHC, "create-default-nhc", false,
+		"If a default NodeHealthCheck should be created when none exists. It is not created again after it was deleted.")
+	flag.StringVar(&defaultNHCTemplate.APIVersion, "default-nhc-template-api-version", defaultnhc.DefaultTemplateAPIVersion,
+		"The apiVersion of the remediation template used by the default NodeHealthCheck.")
+	flag.StringVar(&defaultNHCTemplate.Kind, "default-nhc-template-kind", defaultnhc.DefaultTemplateKind,
+		"The kind of the remediation template used by the default NodeHealthCheck.")
+	flag.StringVar(&defaultNHCTemplate.Name, "default-nhc-template-name", defaultnhc.DefaultTemplateName,
+		"The name of the remediation template used by the default NodeHealthCheck. It needs to exist in the operator's namespace.")
 
 	opts := zap.Options{
 		Development: true,
@@ -154,14 +238,50 @@ func main() {
 		os.Exit(1)
 	}
 
+	managementCluster, err := getManagementCluster(managementKubeconfig, createRemediationsInManagementCluster)
+	if err != nil {
+		setupLog.Error(err, "unable to create management cluster client")
+		os.Exit(1)
+	}
+
+	eventEmitter, err := eventsink.NewEmitter(cloudEventsSink, ctrl.Log.WithName("controllers"))
+	if err != nil {
+		setupLog.Error(err, "unable initialize CloudEvents emitter")
+		os.Exit(1)
+	}
+	if err = mgr.Add(eventEmitter); err != nil {
+		setupLog.Error(err, "failed to add CloudEvents emitter to the manager")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.NodeHealthCheckReconciler{
-		Client:                      mgr.GetClient(),
-		Log:                         ctrl.Log.WithName("controllers").WithName("NodeHealthCheck"),
-		Recorder:                    mgr.GetEventRecorderFor("NodeHealthCheck"),
-		ClusterUpgradeStatusChecker: upgradeChecker,
-		MHCChecker:                  mhcChecker,
-		OnOpenShift:                 onOpenshift,
-		MHCEvents:                   mhcEvents,
+		Client:                            mgr.GetClient(),
+		Log:                               ctrl.Log.WithName("controllers").WithName("NodeHealthCheck"),
+		Recorder:                          eventrecorder.NewRecorder(mgr.GetEventRecorderFor("NodeHealthCheck"), utils.PriorityEventReasons, ctrl.Log.WithName("controllers")),
+		ClusterUpgradeStatusChecker:       upgradeChecker,
+		MHCChecker:                        mhcChecker,
+		RemediationLimiter:                limiter.NewLimiter(mgr.GetClient(), maxClusterRemediations, ctrl.Log.WithName("controllers")),
+		RemoteClients:                     remote.NewClientProvider(mgr.GetClient(), mgr.GetAPIReader(), enableRemoteClusters, ctrl.Log.WithName("controllers")),
+		ImpersonatingClients:              impersonation.NewClientProvider(mgr.GetConfig(), ctrl.Log.WithName("controllers")),
+		EventEmitter:                      eventEmitter,
+		SurgeGate:                         surge.NewGate(enableMachineSetSurgeGating, ctrl.Log.WithName("controllers")),
+		StorageGate:                       storage.NewGate(enableStorageGating, ctrl.Log.WithName("controllers")),
+		DisruptionGate:                    disruption.NewGate(ctrl.Log.WithName("controllers")),
+		Verifier:                          verification.NewVerifier(enablePostRemediationVerification, ctrl.Log.WithName("controllers")),
+		ConnectivityChecker:               connectivity.NewChecker(enableConnectivityReports, ctrl.Log.WithName("controllers")),
+		HeartbeatChecker:                  heartbeat.NewChecker(enableHeartbeatSources, ctrl.Log.WithName("controllers")),
+		UtilizationChecker:                utilization.NewChecker(enableUtilizationChecks, ctrl.Log.WithName("controllers")),
+		AlertSilencer:                     silencing.NewSilencer(mgr.GetAPIReader(), ctrl.Log.WithName("controllers")),
+		Drainer:                           drain.NewDrainer(ctrl.Log.WithName("controllers")),
+		OnOpenShift:                       onOpenshift,
+		DisableInFlightRemediationsStatus: disableInFlightRemediationsStatus,
+		PlainTimedOutAnnotation:           plainTimedOutAnnotation,
+		GenerateRemediationCRNames:        generateRemediationCRNames,
+		CRDWaitTimeout:                    crdWaitTimeout,
+		LastReconcileTimeInterval:         lastReconcileTimeInterval,
+		RemediationCreationConcurrency:    remediationCreationConcurrency,
+		ManagementCluster:                 managementCluster,
+		MHCEvents:                         mhcEvents,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeHealthCheck")
 		os.Exit(1)
@@ -204,6 +324,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = mgr.Add(defaultnhc.NewCreator(mgr.GetClient(), mgr.GetAPIReader(), createDefaultNHC, defaultNHCTemplate, ctrl.Log.WithName("controllers"))); err != nil {
+		setupLog.Error(err, "failed to add default NodeHealthCheck creator to the manager")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -250,6 +375,29 @@ func getWebhookServer(tlsOpts []func(*tls.Config), log logr.Logger) webhook.Serv
 	return webhook.NewServer(options)
 }
 
+// getManagementCluster returns the management cluster of hosted control planes, or nil if no kubeconfig is configured
+func getManagementCluster(kubeconfig string, hasRemediations bool) (*resources.ManagementCluster, error) {
+	if kubeconfig == "" {
+		if hasRemediations {
+			return nil, fmt.Errorf("--create-remediations-in-management-cluster needs --management-kubeconfig")
+		}
+		return nil, nil
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load management kubeconfig %s: %v", kubeconfig, err)
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create management cluster client: %v", err)
+	}
+	setupLog.Info("using management cluster for Machines", "remediations in management cluster", hasRemediations)
+	return &resources.ManagementCluster{
+		Client:          c,
+		HasRemediations: hasRemediations,
+	}, nil
+}
+
 func printVersion() {
 	setupLog.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
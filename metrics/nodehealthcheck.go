@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -40,11 +42,261 @@ var (
 	)
 )
 
+var (
+	// nodeHealthCheckClusterRemediationsMax is a Prometheus metric, which reports the cluster wide limit of simultaneous remediations
+	nodeHealthCheckClusterRemediationsMax = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_cluster_remediations_max",
+			Help: "Maximum number of simultaneous remediations across all NodeHealthChecks, 0 means unlimited",
+		},
+	)
+
+	// nodeHealthCheckClusterRemediationsInFlight is a Prometheus metric, which reports the number of ongoing remediations across all NodeHealthChecks
+	nodeHealthCheckClusterRemediationsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_cluster_remediations_in_flight",
+			Help: "Number of ongoing remediations across all NodeHealthChecks",
+		},
+	)
+
+	// nodeHealthCheckClusterRemediationsShare is a Prometheus metric, which reports the number of ongoing remediations per NodeHealthCheck
+	nodeHealthCheckClusterRemediationsShare = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_cluster_remediations_share",
+			Help: "Number of ongoing remediations counted against the cluster wide limit per NodeHealthCheck",
+		}, []string{"name"},
+	)
+
+	// nodeHealthCheckClusterRemediationsShareNames are the NodeHealthChecks with a share series, for deleting the
+	// series of NodeHealthChecks without ongoing remediations
+	nodeHealthCheckClusterRemediationsShareNames     = make(map[string]struct{})
+	nodeHealthCheckClusterRemediationsShareNamesLock sync.Mutex
+)
+
+var (
+	// nodeHealthCheckCloudEventsDeadLetters is a Prometheus metric, which reports CloudEvents which couldn't be delivered to the configured sink
+	nodeHealthCheckCloudEventsDeadLetters = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_cloudevents_dead_letters_total",
+			Help: "Number of remediation lifecycle CloudEvents which were dropped because they couldn't be delivered",
+		}, []string{"type"},
+	)
+)
+
+const (
+	// RemediationOutcomeSucceeded is the outcome of remediations of nodes which got healthy before the remediation timed out
+	RemediationOutcomeSucceeded = "succeeded"
+	// RemediationOutcomeFailed is the outcome of remediations which were reported as failed by the remediator
+	RemediationOutcomeFailed = "failed"
+	// RemediationOutcomeTimedOut is the outcome of remediations which timed out
+	RemediationOutcomeTimedOut = "timed_out"
+	// RemediationOutcomeExhausted is the outcome of remediations which were given up because of the MaxRemediationDuration
+	RemediationOutcomeExhausted = "exhausted"
+)
+
+var (
+	// nodeHealthCheckRemediationOutcome is a Prometheus metric, which reports the outcome of remediations per template.
+	// The node isn't used as label on purpose, for keeping the cardinality low.
+	nodeHealthCheckRemediationOutcome = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_remediation_outcome_total",
+			Help: "Number of finished remediations per remediation template and outcome",
+		}, []string{"template_kind", "template_name", "outcome"},
+	)
+)
+
+var (
+	// nodeHealthCheckExternalCRDeletions is a Prometheus metric, which reports remediation CRs of ongoing remediations,
+	// which were deleted by someone else than NHC, per template and applied deletion policy
+	nodeHealthCheckExternalCRDeletions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_external_cr_deletions_total",
+			Help: "Number of remediation CRs of ongoing remediations which were deleted by someone else than NodeHealthCheck",
+		}, []string{"template_kind", "template_name", "policy"},
+	)
+)
+
+var (
+	// nodeHealthCheckUpdateConflicts is a Prometheus metric, which reports conflicts when updating objects
+	nodeHealthCheckUpdateConflicts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_update_conflicts_total",
+			Help: "Number of conflicts when updating objects, which were retried",
+		}, []string{"kind"},
+	)
+)
+
+var (
+	// nodeHealthCheckInfo is a Prometheus metric, which reports the effective configuration of each NodeHealthCheck
+	// as labels, with a constant value of 1. Only low cardinality configuration is used as labels.
+	nodeHealthCheckInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_info",
+			Help: "Effective configuration of NodeHealthChecks, always 1",
+		}, []string{"name", "strategy", "min_healthy", "paused", "report_only"},
+	)
+
+	// nodeHealthCheckInfoLabels are the labels of the current info series of each NodeHealthCheck, for deleting
+	// outdated series when the configuration changes
+	nodeHealthCheckInfoLabels     = make(map[string]prometheus.Labels)
+	nodeHealthCheckInfoLabelsLock sync.Mutex
+)
+
+var (
+	// nodeHealthCheckPhaseSeconds is a Prometheus metric, which reports the cumulative time NodeHealthChecks spent in
+	// each phase. It is derived from the persisted phase durations in the status, so it survives operator restarts.
+	nodeHealthCheckPhaseSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_phase_seconds_total",
+			Help: "Cumulative time NodeHealthChecks spent in each phase",
+		}, []string{"name", "phase"},
+	)
+
+	// nodeHealthCheckPhaseSecondsReported are the values reported so far per NodeHealthCheck and phase, for
+	// increasing the counters only by the time which wasn't reported yet
+	nodeHealthCheckPhaseSecondsReported     = make(map[string]map[string]float64)
+	nodeHealthCheckPhaseSecondsReportedLock sync.Mutex
+)
+
+var (
+	// nodeHealthCheckLastReconcileSuccess is a Prometheus metric, which reports the time of the last successful
+	// reconcile per NodeHealthCheck, for alerting on a stuck controller
+	nodeHealthCheckLastReconcileSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_last_reconcile_success_timestamp_seconds",
+			Help: "Unix time of the last successful reconcile of a NodeHealthCheck",
+		}, []string{"name"},
+	)
+
+	// nodeHealthCheckOperatorLastReconcileSuccess is a Prometheus metric, which reports the time of the last
+	// successful reconcile of any NodeHealthCheck
+	nodeHealthCheckOperatorLastReconcileSuccess = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_operator_last_reconcile_success_timestamp_seconds",
+			Help: "Unix time of the last successful reconcile of any NodeHealthCheck",
+		},
+	)
+)
+
+var (
+	// nodeHealthCheckRemediatorCircuitOpen is a Prometheus metric, which reports the remediation kinds whose circuit of
+	// the RemediatorCircuitBreaker isn't closed, with a constant value of 1
+	nodeHealthCheckRemediatorCircuitOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_remediator_circuit_open",
+			Help: "Remediation kinds whose circuit is open, because their remediations timed out or failed in a row, always 1",
+		}, []string{"name", "kind"},
+	)
+
+	// nodeHealthCheckAlertSilencingFailures is a Prometheus metric, which counts the failed requests to Alertmanager
+	// per NodeHealthCheck and operation of the AlertSilencing
+	nodeHealthCheckAlertSilencingFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_alert_silencing_failures_total",
+			Help: "Number of failures to create, extend or expire Alertmanager silences of nodes under remediation",
+		}, []string{"name", "operation"},
+	)
+)
+
+var (
+	// nodeHealthCheckObservedNodes is a Prometheus metric, which reports the number of nodes selected by a NodeHealthCheck
+	nodeHealthCheckObservedNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_observed_nodes",
+			Help: "Number of nodes observed by a NodeHealthCheck",
+		}, []string{"name"},
+	)
+
+	// nodeHealthCheckHealthyNodes is a Prometheus metric, which reports the number of healthy nodes of a NodeHealthCheck
+	nodeHealthCheckHealthyNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_healthy_nodes",
+			Help: "Number of healthy nodes observed by a NodeHealthCheck",
+		}, []string{"name"},
+	)
+
+	// nodeHealthCheckUnhealthyNodes is a Prometheus metric, which reports the number of unhealthy nodes of a NodeHealthCheck
+	nodeHealthCheckUnhealthyNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_unhealthy_nodes",
+			Help: "Number of unhealthy nodes observed by a NodeHealthCheck",
+		}, []string{"name"},
+	)
+
+	// nodeHealthCheckRemediationsStarted is a Prometheus metric, which counts the remediation CRs created by a
+	// NodeHealthCheck per remediation kind
+	nodeHealthCheckRemediationsStarted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_remediations_started_total",
+			Help: "Number of remediation CRs created by a NodeHealthCheck per remediation kind",
+		}, []string{"name", "kind"},
+	)
+)
+
+var (
+	// nodeHealthCheckRemediationsDeleted is a Prometheus metric, which counts the remediation CRs deleted by a
+	// NodeHealthCheck per remediation kind
+	nodeHealthCheckRemediationsDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_remediations_deleted_total",
+			Help: "Number of remediation CRs deleted by a NodeHealthCheck per remediation kind",
+		}, []string{"name", "kind"},
+	)
+
+	// nodeHealthCheckRemediationsInFlight is a Prometheus metric, which reports the number of nodes under remediation
+	// of a NodeHealthCheck
+	nodeHealthCheckRemediationsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_remediations_in_flight",
+			Help: "Number of nodes under remediation of a NodeHealthCheck",
+		}, []string{"name"},
+	)
+
+	// nodeHealthCheckRemediationsTimedOut is a Prometheus metric, which counts the timed out remediations of a
+	// NodeHealthCheck per remediation kind
+	nodeHealthCheckRemediationsTimedOut = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nodehealthcheck_remediations_timed_out_total",
+			Help: "Number of timed out remediations of a NodeHealthCheck per remediation kind",
+		}, []string{"name", "kind"},
+	)
+
+	// nodeHealthCheckPhase is a Prometheus metric, which reports the current phase of a NodeHealthCheck, with a
+	// constant value of 1
+	nodeHealthCheckPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nodehealthcheck_phase",
+			Help: "Current phase of a NodeHealthCheck, always 1",
+		}, []string{"name", "phase"},
+	)
+)
+
 func InitializeNodeHealthCheckMetrics() {
 	metrics.Registry.MustRegister(
 		nodeHealthCheckOldRemediationCR,
 		nodeHealthCheckOngoingRemediation,
 		nodehealtCheckRemediationDuration,
+		nodeHealthCheckClusterRemediationsMax,
+		nodeHealthCheckClusterRemediationsInFlight,
+		nodeHealthCheckClusterRemediationsShare,
+		nodeHealthCheckCloudEventsDeadLetters,
+		nodeHealthCheckUpdateConflicts,
+		nodeHealthCheckRemediationOutcome,
+		nodeHealthCheckExternalCRDeletions,
+		nodeHealthCheckInfo,
+		nodeHealthCheckPhaseSeconds,
+		nodeHealthCheckLastReconcileSuccess,
+		nodeHealthCheckOperatorLastReconcileSuccess,
+		nodeHealthCheckRemediatorCircuitOpen,
+		nodeHealthCheckAlertSilencingFailures,
+		nodeHealthCheckObservedNodes,
+		nodeHealthCheckHealthyNodes,
+		nodeHealthCheckUnhealthyNodes,
+		nodeHealthCheckRemediationsStarted,
+		nodeHealthCheckRemediationsDeleted,
+		nodeHealthCheckRemediationsInFlight,
+		nodeHealthCheckRemediationsTimedOut,
+		nodeHealthCheckPhase,
 	)
 }
 
@@ -78,3 +330,232 @@ func ObserveNodeHealthCheckUnhealthyNodeDuration(name, namespace, remediation st
 		"remediation": remediation,
 	}).Observe(duration.Seconds())
 }
+
+func ObserveClusterRemediationsMax(max int) {
+	nodeHealthCheckClusterRemediationsMax.Set(float64(max))
+}
+
+func ObserveClusterRemediationsInFlight(inFlight int, perNHC map[string]int) {
+	nodeHealthCheckClusterRemediationsInFlight.Set(float64(inFlight))
+	nodeHealthCheckClusterRemediationsShareNamesLock.Lock()
+	defer nodeHealthCheckClusterRemediationsShareNamesLock.Unlock()
+	for nhcName := range nodeHealthCheckClusterRemediationsShareNames {
+		if _, exists := perNHC[nhcName]; !exists {
+			nodeHealthCheckClusterRemediationsShare.Delete(prometheus.Labels{"name": nhcName})
+			delete(nodeHealthCheckClusterRemediationsShareNames, nhcName)
+		}
+	}
+	for nhcName, count := range perNHC {
+		nodeHealthCheckClusterRemediationsShare.With(prometheus.Labels{
+			"name": nhcName,
+		}).Set(float64(count))
+		nodeHealthCheckClusterRemediationsShareNames[nhcName] = struct{}{}
+	}
+}
+
+func ObserveCloudEventDeadLetter(eventType string) {
+	nodeHealthCheckCloudEventsDeadLetters.With(prometheus.Labels{
+		"type": eventType,
+	}).Inc()
+}
+
+func ObserveNodeHealthCheckRemediationOutcome(templateKind, templateName, outcome string) {
+	nodeHealthCheckRemediationOutcome.With(prometheus.Labels{
+		"template_kind": templateKind,
+		"template_name": templateName,
+		"outcome":       outcome,
+	}).Inc()
+}
+
+func ObserveNodeHealthCheckExternalCRDeletion(templateKind, templateName, policy string) {
+	nodeHealthCheckExternalCRDeletions.With(prometheus.Labels{
+		"template_kind": templateKind,
+		"template_name": templateName,
+		"policy":        policy,
+	}).Inc()
+}
+
+func ObserveNodeHealthCheckUpdateConflict(kind string) {
+	nodeHealthCheckUpdateConflicts.With(prometheus.Labels{
+		"kind": kind,
+	}).Inc()
+}
+
+// ObserveNodeHealthCheckInfo sets the info series of the given NodeHealthCheck, and deletes its previous series if
+// the configuration changed
+func ObserveNodeHealthCheckInfo(name, strategy, minHealthy string, paused, reportOnly bool) {
+	labels := prometheus.Labels{
+		"name":        name,
+		"strategy":    strategy,
+		"min_healthy": minHealthy,
+		"paused":      strconv.FormatBool(paused),
+		"report_only": strconv.FormatBool(reportOnly),
+	}
+	nodeHealthCheckInfoLabelsLock.Lock()
+	defer nodeHealthCheckInfoLabelsLock.Unlock()
+	if previous, exists := nodeHealthCheckInfoLabels[name]; exists && !equalLabels(previous, labels) {
+		nodeHealthCheckInfo.Delete(previous)
+	}
+	nodeHealthCheckInfoLabels[name] = labels
+	nodeHealthCheckInfo.With(labels).Set(1)
+}
+
+// DeleteNodeHealthCheckInfo deletes the info series of the given NodeHealthCheck
+func DeleteNodeHealthCheckInfo(name string) {
+	nodeHealthCheckInfoLabelsLock.Lock()
+	defer nodeHealthCheckInfoLabelsLock.Unlock()
+	if previous, exists := nodeHealthCheckInfoLabels[name]; exists {
+		nodeHealthCheckInfo.Delete(previous)
+		delete(nodeHealthCheckInfoLabels, name)
+	}
+}
+
+func equalLabels(l1, l2 prometheus.Labels) bool {
+	if len(l1) != len(l2) {
+		return false
+	}
+	for key, value := range l1 {
+		if l2[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ObserveNodeHealthCheckPhaseDurations increases the phase counters of the given NodeHealthCheck to the given
+// cumulative phase durations
+func ObserveNodeHealthCheckPhaseDurations(name string, durations map[string]time.Duration) {
+	nodeHealthCheckPhaseSecondsReportedLock.Lock()
+	defer nodeHealthCheckPhaseSecondsReportedLock.Unlock()
+	reported, exists := nodeHealthCheckPhaseSecondsReported[name]
+	if !exists {
+		reported = make(map[string]float64)
+		nodeHealthCheckPhaseSecondsReported[name] = reported
+	}
+	for phase, duration := range durations {
+		if increase := duration.Seconds() - reported[phase]; increase > 0 {
+			nodeHealthCheckPhaseSeconds.With(prometheus.Labels{
+				"name":  name,
+				"phase": phase,
+			}).Add(increase)
+			reported[phase] = duration.Seconds()
+		}
+	}
+}
+
+// DeleteNodeHealthCheckPhaseDurations deletes the phase counters of the given NodeHealthCheck
+func DeleteNodeHealthCheckPhaseDurations(name string) {
+	nodeHealthCheckPhaseSecondsReportedLock.Lock()
+	defer nodeHealthCheckPhaseSecondsReportedLock.Unlock()
+	nodeHealthCheckPhaseSeconds.DeletePartialMatch(prometheus.Labels{"name": name})
+	delete(nodeHealthCheckPhaseSecondsReported, name)
+}
+
+// ObserveNodeHealthCheckReconcileSuccess sets the time of the last successful reconcile of the given NodeHealthCheck,
+// and of the operator
+func ObserveNodeHealthCheckReconcileSuccess(name string, now time.Time) {
+	nodeHealthCheckLastReconcileSuccess.With(prometheus.Labels{
+		"name": name,
+	}).Set(float64(now.Unix()))
+	nodeHealthCheckOperatorLastReconcileSuccess.Set(float64(now.Unix()))
+}
+
+// DeleteNodeHealthCheckReconcileSuccess deletes the last successful reconcile time of the given NodeHealthCheck
+func DeleteNodeHealthCheckReconcileSuccess(name string) {
+	nodeHealthCheckLastReconcileSuccess.Delete(prometheus.Labels{"name": name})
+}
+
+// ObserveNodeHealthCheckRemediatorCircuits reports the given remediation kinds as the kinds with open circuit of the
+// given NodeHealthCheck
+func ObserveNodeHealthCheckRemediatorCircuits(name string, openKinds []string) {
+	nodeHealthCheckRemediatorCircuitOpen.DeletePartialMatch(prometheus.Labels{"name": name})
+	for _, kind := range openKinds {
+		nodeHealthCheckRemediatorCircuitOpen.With(prometheus.Labels{
+			"name": name,
+			"kind": kind,
+		}).Set(1)
+	}
+}
+
+// DeleteNodeHealthCheckRemediatorCircuits deletes the open circuits of the given NodeHealthCheck
+func DeleteNodeHealthCheckRemediatorCircuits(name string) {
+	nodeHealthCheckRemediatorCircuitOpen.DeletePartialMatch(prometheus.Labels{"name": name})
+}
+
+// ObserveNodeHealthCheckAlertSilencingFailure increases the failures of the given AlertSilencing operation of the
+// given NodeHealthCheck, which is "silence" or "expire"
+func ObserveNodeHealthCheckAlertSilencingFailure(name, operation string) {
+	nodeHealthCheckAlertSilencingFailures.With(prometheus.Labels{
+		"name":      name,
+		"operation": operation,
+	}).Inc()
+}
+
+// DeleteNodeHealthCheckAlertSilencingFailures deletes the AlertSilencing failures of the given NodeHealthCheck
+func DeleteNodeHealthCheckAlertSilencingFailures(name string) {
+	nodeHealthCheckAlertSilencingFailures.DeletePartialMatch(prometheus.Labels{"name": name})
+}
+
+// ObserveNodeHealthCheckNodes sets the number of observed, healthy and unhealthy nodes of the given NodeHealthCheck
+func ObserveNodeHealthCheckNodes(name string, observed, healthy int) {
+	labels := prometheus.Labels{"name": name}
+	nodeHealthCheckObservedNodes.With(labels).Set(float64(observed))
+	nodeHealthCheckHealthyNodes.With(labels).Set(float64(healthy))
+	nodeHealthCheckUnhealthyNodes.With(labels).Set(float64(observed - healthy))
+}
+
+// ObserveNodeHealthCheckRemediationStarted increases the number of remediation CRs with the given remediation kind
+// created by the given NodeHealthCheck
+func ObserveNodeHealthCheckRemediationStarted(name, kind string) {
+	nodeHealthCheckRemediationsStarted.With(prometheus.Labels{
+		"name": name,
+		"kind": kind,
+	}).Inc()
+}
+
+// ObserveNodeHealthCheckRemediationCRDeleted increases the number of remediation CRs with the given remediation kind
+// deleted by the given NodeHealthCheck
+func ObserveNodeHealthCheckRemediationCRDeleted(name, kind string) {
+	nodeHealthCheckRemediationsDeleted.With(prometheus.Labels{
+		"name": name,
+		"kind": kind,
+	}).Inc()
+}
+
+// ObserveNodeHealthCheckRemediationsInFlight sets the number of nodes under remediation of the given NodeHealthCheck
+func ObserveNodeHealthCheckRemediationsInFlight(name string, inFlight int) {
+	nodeHealthCheckRemediationsInFlight.With(prometheus.Labels{
+		"name": name,
+	}).Set(float64(inFlight))
+}
+
+// ObserveNodeHealthCheckRemediationTimedOut increases the timed out remediations of the given remediation kind of the
+// given NodeHealthCheck
+func ObserveNodeHealthCheckRemediationTimedOut(name, kind string) {
+	nodeHealthCheckRemediationsTimedOut.With(prometheus.Labels{
+		"name": name,
+		"kind": kind,
+	}).Inc()
+}
+
+// ObserveNodeHealthCheckPhase reports the given phase as the current phase of the given NodeHealthCheck
+func ObserveNodeHealthCheckPhase(name, phase string) {
+	nodeHealthCheckPhase.DeletePartialMatch(prometheus.Labels{"name": name})
+	nodeHealthCheckPhase.With(prometheus.Labels{
+		"name":  name,
+		"phase": phase,
+	}).Set(1)
+}
+
+// DeleteNodeHealthCheckNodes deletes the node counts, remediation metrics and phase of the given NodeHealthCheck
+func DeleteNodeHealthCheckNodes(name string) {
+	labels := prometheus.Labels{"name": name}
+	nodeHealthCheckObservedNodes.Delete(labels)
+	nodeHealthCheckHealthyNodes.Delete(labels)
+	nodeHealthCheckUnhealthyNodes.Delete(labels)
+	nodeHealthCheckRemediationsInFlight.Delete(labels)
+	nodeHealthCheckRemediationsStarted.DeletePartialMatch(labels)
+	nodeHealthCheckRemediationsDeleted.DeletePartialMatch(labels)
+	nodeHealthCheckRemediationsTimedOut.DeletePartialMatch(labels)
+	nodeHealthCheckPhase.DeletePartialMatch(labels)
+}
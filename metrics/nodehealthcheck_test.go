@@ -0,0 +1,179 @@
+package metrics
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var _ = Describe("NodeHealthCheck metrics", func() {
+
+	const nhcName = "test-nhc"
+
+	// getSeries returns the series of the given collector with the given labels, or nil if it doesn't exist
+	getSeries := func(collector prometheus.Collector, labels prometheus.Labels) *dto.Metric {
+		ch := make(chan prometheus.Metric, 100)
+		collector.Collect(ch)
+		close(ch)
+		for metric := range ch {
+			m := &dto.Metric{}
+			Expect(metric.Write(m)).To(Succeed())
+			matches := len(m.GetLabel()) == len(labels)
+			for _, label := range m.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					matches = false
+				}
+			}
+			if matches {
+				return m
+			}
+		}
+		return nil
+	}
+
+	// getValue returns the value of the given counter or gauge series, or the sample count of the given histogram series
+	getValue := func(collector prometheus.Collector, labels prometheus.Labels) float64 {
+		m := getSeries(collector, labels)
+		Expect(m).ToNot(BeNil(), "missing series %v", labels)
+		switch {
+		case m.Counter != nil:
+			return m.Counter.GetValue()
+		case m.Gauge != nil:
+			return m.Gauge.GetValue()
+		case m.Histogram != nil:
+			return float64(m.Histogram.GetSampleCount())
+		}
+		return 0
+	}
+
+	nhcLabels := prometheus.Labels{"name": nhcName}
+	kindLabels := prometheus.Labels{"name": nhcName, "kind": "TestRemediation"}
+
+	AfterEach(func() {
+		DeleteNodeHealthCheckNodes(nhcName)
+	})
+
+	When("node counts are observed", func() {
+		It("should report observed, healthy and unhealthy nodes", func() {
+			ObserveNodeHealthCheckNodes(nhcName, 5, 3)
+			ObserveNodeHealthCheckRemediationsInFlight(nhcName, 1)
+			Expect(getValue(nodeHealthCheckObservedNodes, nhcLabels)).To(Equal(5.0))
+			Expect(getValue(nodeHealthCheckHealthyNodes, nhcLabels)).To(Equal(3.0))
+			Expect(getValue(nodeHealthCheckUnhealthyNodes, nhcLabels)).To(Equal(2.0))
+			Expect(getValue(nodeHealthCheckRemediationsInFlight, nhcLabels)).To(Equal(1.0))
+
+			By("node getting healthy again")
+			ObserveNodeHealthCheckNodes(nhcName, 5, 5)
+			ObserveNodeHealthCheckRemediationsInFlight(nhcName, 0)
+			Expect(getValue(nodeHealthCheckHealthyNodes, nhcLabels)).To(Equal(5.0))
+			Expect(getValue(nodeHealthCheckUnhealthyNodes, nhcLabels)).To(Equal(0.0))
+			Expect(getValue(nodeHealthCheckRemediationsInFlight, nhcLabels)).To(Equal(0.0))
+		})
+	})
+
+	When("remediations start and finish", func() {
+		It("should count started, timed out and deleted remediations", func() {
+			ObserveNodeHealthCheckRemediationStarted(nhcName, "TestRemediation")
+			ObserveNodeHealthCheckRemediationStarted(nhcName, "TestRemediation")
+			Expect(getValue(nodeHealthCheckRemediationsStarted, kindLabels)).To(Equal(2.0))
+			Expect(getSeries(nodeHealthCheckRemediationsTimedOut, kindLabels)).To(BeNil())
+
+			ObserveNodeHealthCheckRemediationTimedOut(nhcName, "TestRemediation")
+			Expect(getValue(nodeHealthCheckRemediationsTimedOut, kindLabels)).To(Equal(1.0))
+
+			By("deleting the remediation CRs")
+			ObserveNodeHealthCheckRemediationCRDeleted(nhcName, "TestRemediation")
+			ObserveNodeHealthCheckRemediationCRDeleted(nhcName, "TestRemediation")
+			Expect(getValue(nodeHealthCheckRemediationsDeleted, kindLabels)).To(Equal(2.0))
+		})
+	})
+
+	When("cluster wide remediations are observed", func() {
+		It("should only delete the share of NHCs without ongoing remediations", func() {
+			ObserveClusterRemediationsInFlight(3, map[string]int{nhcName: 2, "other-nhc": 1})
+			Expect(getValue(nodeHealthCheckClusterRemediationsShare, prometheus.Labels{"name": nhcName})).To(Equal(2.0))
+			Expect(getValue(nodeHealthCheckClusterRemediationsShare, prometheus.Labels{"name": "other-nhc"})).To(Equal(1.0))
+
+			ObserveClusterRemediationsInFlight(1, map[string]int{nhcName: 1})
+			Expect(getValue(nodeHealthCheckClusterRemediationsShare, prometheus.Labels{"name": nhcName})).To(Equal(1.0))
+			Expect(getSeries(nodeHealthCheckClusterRemediationsShare, prometheus.Labels{"name": "other-nhc"})).To(BeNil())
+
+			ObserveClusterRemediationsInFlight(0, map[string]int{})
+			Expect(getSeries(nodeHealthCheckClusterRemediationsShare, prometheus.Labels{"name": nhcName})).To(BeNil())
+		})
+	})
+
+	When("the phase changes", func() {
+		It("should only report the current phase", func() {
+			ObserveNodeHealthCheckPhase(nhcName, "Enabled")
+			Expect(getValue(nodeHealthCheckPhase, prometheus.Labels{"name": nhcName, "phase": "Enabled"})).To(Equal(1.0))
+
+			ObserveNodeHealthCheckPhase(nhcName, "Remediating")
+			Expect(getValue(nodeHealthCheckPhase, prometheus.Labels{"name": nhcName, "phase": "Remediating"})).To(Equal(1.0))
+			Expect(getSeries(nodeHealthCheckPhase, prometheus.Labels{"name": nhcName, "phase": "Enabled"})).To(BeNil())
+		})
+	})
+
+	When("a reconcile succeeds", func() {
+		It("should report the time for the NHC and the operator", func() {
+			now := time.Now()
+			ObserveNodeHealthCheckReconcileSuccess(nhcName, now)
+			Expect(getValue(nodeHealthCheckLastReconcileSuccess, nhcLabels)).To(Equal(float64(now.Unix())))
+			Expect(getValue(nodeHealthCheckOperatorLastReconcileSuccess, nil)).To(Equal(float64(now.Unix())))
+
+			DeleteNodeHealthCheckReconcileSuccess(nhcName)
+			Expect(getSeries(nodeHealthCheckLastReconcileSuccess, nhcLabels)).To(BeNil())
+		})
+	})
+
+	When("phase durations, remediator circuits and alert silencing failures are observed", func() {
+		It("should report them with the NHC's name", func() {
+			ObserveNodeHealthCheckPhaseDurations(nhcName, map[string]time.Duration{"Enabled": time.Minute})
+			ObserveNodeHealthCheckPhaseDurations(nhcName, map[string]time.Duration{"Enabled": 3 * time.Minute})
+			Expect(getValue(nodeHealthCheckPhaseSeconds, prometheus.Labels{"name": nhcName, "phase": "Enabled"})).To(Equal(180.0))
+
+			ObserveNodeHealthCheckRemediatorCircuits(nhcName, []string{"TestRemediation"})
+			Expect(getValue(nodeHealthCheckRemediatorCircuitOpen, kindLabels)).To(Equal(1.0))
+			ObserveNodeHealthCheckRemediatorCircuits(nhcName, nil)
+			Expect(getSeries(nodeHealthCheckRemediatorCircuitOpen, kindLabels)).To(BeNil())
+
+			ObserveNodeHealthCheckAlertSilencingFailure(nhcName, "silence")
+			Expect(getValue(nodeHealthCheckAlertSilencingFailures, prometheus.Labels{"name": nhcName, "operation": "silence"})).To(Equal(1.0))
+
+			DeleteNodeHealthCheckPhaseDurations(nhcName)
+			DeleteNodeHealthCheckAlertSilencingFailures(nhcName)
+			Expect(getSeries(nodeHealthCheckPhaseSeconds, prometheus.Labels{"name": nhcName, "phase": "Enabled"})).To(BeNil())
+			Expect(getSeries(nodeHealthCheckAlertSilencingFailures, prometheus.Labels{"name": nhcName, "operation": "silence"})).To(BeNil())
+		})
+	})
+
+	When("the NHC is deleted", func() {
+		It("should delete all its series", func() {
+			ObserveNodeHealthCheckNodes(nhcName, 3, 2)
+			ObserveNodeHealthCheckRemediationsInFlight(nhcName, 1)
+			ObserveNodeHealthCheckRemediationStarted(nhcName, "TestRemediation")
+			ObserveNodeHealthCheckRemediationTimedOut(nhcName, "TestRemediation")
+			ObserveNodeHealthCheckRemediationCRDeleted(nhcName, "TestRemediation")
+			ObserveNodeHealthCheckPhase(nhcName, "Remediating")
+			ObserveNodeHealthCheckNodes("other-nhc", 1, 1)
+
+			DeleteNodeHealthCheckNodes(nhcName)
+			Expect(getSeries(nodeHealthCheckObservedNodes, nhcLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckHealthyNodes, nhcLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckUnhealthyNodes, nhcLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckRemediationsInFlight, nhcLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckRemediationsStarted, kindLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckRemediationsDeleted, kindLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckRemediationsTimedOut, kindLabels)).To(BeNil())
+			Expect(getSeries(nodeHealthCheckPhase, prometheus.Labels{"name": nhcName, "phase": "Remediating"})).To(BeNil())
+
+			By("keeping series of other NHCs")
+			Expect(getValue(nodeHealthCheckObservedNodes, prometheus.Labels{"name": "other-nhc"})).To(Equal(1.0))
+			DeleteNodeHealthCheckNodes("other-nhc")
+		})
+	})
+})